@@ -0,0 +1,162 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"asset-management-api/internal/utils"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/spf13/cobra"
+)
+
+func newLoginCommand(opts *options) *cobra.Command {
+	var token string
+	var test bool
+	cmd := &cobra.Command{
+		Use:   "login",
+		Short: "Check a token with the API and save it for later commands",
+		Long: `Checks a token with the API and saves it, with the server, for later
+commands. The token is given with --token or on standard input. With --test,
+a token is requested from the server's test login endpoint, which only
+development servers have.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			saved, err := loadCredentials()
+			if err != nil {
+				return err
+			}
+			server := opts.serverAddress(saved)
+
+			switch {
+			case test && token != "":
+				return errors.New("--token and --test cannot be used together")
+			case test:
+				if token, err = testLogin(cmd, server); err != nil {
+					return err
+				}
+			case token == "":
+				if token, err = readToken(cmd); err != nil {
+					return err
+				}
+			}
+
+			// Any authenticated request tells whether the API accepts the token
+			c := newClient(server, token)
+			if _, err := c.get(cmd.Context(), apiPath+"/folders", url.Values{"limit": {"1"}}, nil); err != nil {
+				var apiErr *apiError
+				if errors.As(err, &apiErr) && apiErr.Status == http.StatusUnauthorized {
+					return fmt.Errorf("the server does not accept the token: %w", err)
+				}
+				return err
+			}
+
+			path, err := saveCredentials(credentials{Server: server, Token: token})
+			if err != nil {
+				return err
+			}
+			claims := tokenClaims(token)
+			if claims != nil && claims.Username != "" {
+				fmt.Fprintf(cmd.OutOrStdout(), "Logged in to %s as %s\n", server, claims.Username)
+			} else {
+				fmt.Fprintf(cmd.OutOrStdout(), "Logged in to %s\n", server)
+			}
+			fmt.Fprintf(cmd.ErrOrStderr(), "Credentials saved to %s\n", path)
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&token, "token", "", "bearer token to log in with")
+	cmd.Flags().BoolVar(&test, "test", false, "get a token from the test login endpoint")
+	return cmd
+}
+
+func newLogoutCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "logout",
+		Short: "Remove the saved credentials",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return removeCredentials()
+		},
+	}
+}
+
+func newWhoamiCommand(opts *options) *cobra.Command {
+	return &cobra.Command{
+		Use:   "whoami",
+		Short: "Show the server and the user of the token",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c, err := opts.newClient()
+			if err != nil {
+				return err
+			}
+			claims := tokenClaims(c.token)
+			if claims == nil {
+				return errors.New("the token is not a JWT")
+			}
+
+			if opts.output == outputJSON {
+				data, err := json.Marshal(claims)
+				if err != nil {
+					return err
+				}
+				return writeJSON(cmd.OutOrStdout(), data)
+			}
+			expires := "never"
+			if claims.ExpiresAt != nil {
+				expires = formatTime(claims.ExpiresAt.Time)
+			}
+			return writeTable(cmd.OutOrStdout(),
+				[]string{"SERVER", "USER ID", "USERNAME", "EMAIL", "ROLE", "EXPIRES"},
+				[][]string{{c.server, claims.UserID.String(), claims.Username, claims.Email, claims.Role, expires}})
+		},
+	}
+}
+
+// testLogin gets a token from the test login endpoint of a development server
+func testLogin(cmd *cobra.Command, server string) (string, error) {
+	var data struct {
+		Token string `json:"token"`
+	}
+	if _, err := newClient(server, "").post(cmd.Context(), "/test/login", nil, &data); err != nil {
+		return "", fmt.Errorf("test login failed: %w", err)
+	}
+	if data.Token == "" {
+		return "", errors.New("test login returned no token")
+	}
+	return data.Token, nil
+}
+
+// readToken reads the token from standard input, prompting for it on a
+// terminal
+func readToken(cmd *cobra.Command) (string, error) {
+	if info, err := os.Stdin.Stat(); err == nil && info.Mode()&os.ModeCharDevice != 0 {
+		fmt.Fprint(cmd.ErrOrStderr(), "Token: ")
+	}
+	line, err := bufio.NewReader(cmd.InOrStdin()).ReadString('\n')
+	token := strings.TrimPrefix(strings.TrimSpace(line), "Bearer ")
+	if token == "" {
+		if err != nil {
+			return "", fmt.Errorf("failed to read the token: %w", err)
+		}
+		return "", errors.New("no token given")
+	}
+	return token, nil
+}
+
+// tokenClaims returns the claims of a JWT without checking its signature,
+// which only the server can, or nil when it is not one
+func tokenClaims(token string) *utils.Claims {
+	claims := &utils.Claims{}
+	if _, _, err := jwt.NewParser().ParseUnverified(token, claims); err != nil {
+		return nil
+	}
+	return claims
+}
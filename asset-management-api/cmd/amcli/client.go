@@ -0,0 +1,205 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"asset-management-api/internal/utils"
+)
+
+// apiPath is the prefix of the API routes
+const apiPath = "/api/v1"
+
+// requestTimeout bounds the requests of every command but events tail
+const requestTimeout = 30 * time.Second
+
+// client sends requests to the API with a bearer token
+type client struct {
+	server string
+	token  string
+	http   *http.Client
+}
+
+func newClient(server, token string) *client {
+	return &client{server: server, token: token, http: &http.Client{}}
+}
+
+// envelope is the body of every API response
+type envelope struct {
+	Success    bool                    `json:"success"`
+	Message    string                  `json:"message"`
+	Data       json.RawMessage         `json:"data"`
+	Error      string                  `json:"error"`
+	Errors     []string                `json:"errors"`
+	RequestID  string                  `json:"request_id"`
+	Pagination *utils.CursorPagination `json:"pagination"`
+}
+
+// apiError is a response the API failed a request with
+type apiError struct {
+	Status    int
+	Message   string
+	Detail    string
+	Errors    []string
+	RequestID string
+}
+
+func (e *apiError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s (%d %s)", e.Message, e.Status, http.StatusText(e.Status))
+	if e.Detail != "" {
+		fmt.Fprintf(&b, ": %s", e.Detail)
+	}
+	for _, problem := range e.Errors {
+		fmt.Fprintf(&b, "\n  %s", problem)
+	}
+	if e.RequestID != "" {
+		fmt.Fprintf(&b, "\nrequest ID: %s", e.RequestID)
+	}
+	return b.String()
+}
+
+// do sends a request with body encoded as JSON, when not nil, and returns the
+// response envelope. Responses other than 2xx are returned as an *apiError.
+func (c *client) do(ctx context.Context, method, path string, query url.Values, body interface{}) (*envelope, error) {
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+
+	req, err := c.newRequest(ctx, method, path, query, body)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	if resp.StatusCode/100 != 2 {
+		return nil, newAPIError(resp.StatusCode, data)
+	}
+	var env envelope
+	if err := json.Unmarshal(data, &env); err != nil {
+		return nil, fmt.Errorf("unexpected response from %s: %w", req.URL.Path, err)
+	}
+	return &env, nil
+}
+
+// newAPIError returns the error of a response other than 2xx, with the
+// message of its body
+func newAPIError(status int, body []byte) *apiError {
+	var env envelope
+	if err := json.Unmarshal(body, &env); err != nil || env.Message == "" {
+		return &apiError{Status: status, Message: "Request failed", Detail: strings.TrimSpace(string(body))}
+	}
+	return &apiError{
+		Status:    status,
+		Message:   env.Message,
+		Detail:    env.Error,
+		Errors:    env.Errors,
+		RequestID: env.RequestID,
+	}
+}
+
+// get sends a GET request and decodes the data of the response into out
+func (c *client) get(ctx context.Context, path string, query url.Values, out interface{}) (*envelope, error) {
+	env, err := c.do(ctx, http.MethodGet, path, query, nil)
+	if err != nil {
+		return nil, err
+	}
+	return env, env.decode(out)
+}
+
+// post sends a POST request and decodes the data of the response into out
+func (c *client) post(ctx context.Context, path string, body, out interface{}) (*envelope, error) {
+	env, err := c.do(ctx, http.MethodPost, path, nil, body)
+	if err != nil {
+		return nil, err
+	}
+	return env, env.decode(out)
+}
+
+func (c *client) delete(ctx context.Context, path string) (*envelope, error) {
+	return c.do(ctx, http.MethodDelete, path, nil, nil)
+}
+
+func (c *client) newRequest(ctx context.Context, method, path string, query url.Values, body interface{}) (*http.Request, error) {
+	u := c.server + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, u, reader)
+	if err != nil {
+		return nil, err
+	}
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", "amcli")
+	return req, nil
+}
+
+// decode decodes the data of the response into out, when not nil
+func (e *envelope) decode(out interface{}) error {
+	if out == nil || len(e.Data) == 0 || string(e.Data) == "null" {
+		return nil
+	}
+	if err := json.Unmarshal(e.Data, out); err != nil {
+		return fmt.Errorf("unexpected response data: %w", err)
+	}
+	return nil
+}
+
+// list reads a list by cursor: the page at the cursor of the flags, or every
+// page from there with --all. It returns the items and the cursor of the next
+// page, empty after the last one.
+func (c *client) list(ctx context.Context, path string, flags listFlags) ([]json.RawMessage, string, error) {
+	var items []json.RawMessage
+	cursor := flags.cursor
+	for {
+		query := url.Values{"limit": {strconv.Itoa(flags.limit)}}
+		if cursor != "" {
+			query.Set("cursor", cursor)
+		}
+		var page []json.RawMessage
+		env, err := c.get(ctx, path, query, &page)
+		if err != nil {
+			return nil, "", err
+		}
+		items = append(items, page...)
+
+		cursor = ""
+		if env.Pagination != nil && env.Pagination.HasMore {
+			cursor = env.Pagination.NextCursor
+		}
+		if !flags.all || cursor == "" {
+			return items, cursor, nil
+		}
+	}
+}
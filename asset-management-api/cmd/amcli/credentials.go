@@ -0,0 +1,106 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// credentials are what login saves for later commands
+type credentials struct {
+	Server string `json:"server"`
+	Token  string `json:"token"`
+}
+
+// credentialsPath is where login saves the credentials, readable by the user
+// only
+func credentialsPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to find the configuration directory: %w", err)
+	}
+	return filepath.Join(dir, "amcli", "credentials.json"), nil
+}
+
+// loadCredentials returns the saved credentials, empty when there are none
+func loadCredentials() (credentials, error) {
+	var creds credentials
+	path, err := credentialsPath()
+	if err != nil {
+		return creds, err
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return creds, nil
+	}
+	if err != nil {
+		return creds, fmt.Errorf("failed to read credentials: %w", err)
+	}
+	if err := json.Unmarshal(data, &creds); err != nil {
+		return creds, fmt.Errorf("failed to read credentials from %s: %w", path, err)
+	}
+	return creds, nil
+}
+
+func saveCredentials(creds credentials) (string, error) {
+	path, err := credentialsPath()
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return "", fmt.Errorf("failed to save credentials: %w", err)
+	}
+	data, err := json.MarshalIndent(creds, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		return "", fmt.Errorf("failed to save credentials: %w", err)
+	}
+	return path, nil
+}
+
+func removeCredentials() error {
+	path, err := credentialsPath()
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to remove credentials: %w", err)
+	}
+	return nil
+}
+
+// serverAddress is the API address: the flag, the environment or the saved
+// server, in that order
+func (o *options) serverAddress(saved credentials) string {
+	for _, server := range []string{o.server, os.Getenv("AMCLI_SERVER"), saved.Server} {
+		if server != "" {
+			return strings.TrimSuffix(server, "/")
+		}
+	}
+	return defaultServer
+}
+
+// newClient returns a client of the API authenticated with the token of the
+// flag, the environment or login
+func (o *options) newClient() (*client, error) {
+	saved, err := loadCredentials()
+	if err != nil {
+		return nil, err
+	}
+	token := o.token
+	if token == "" {
+		token = os.Getenv("AMCLI_TOKEN")
+	}
+	if token == "" {
+		token = saved.Token
+	}
+	if token == "" {
+		return nil, errors.New("not logged in: run amcli login, or set --token or AMCLI_TOKEN")
+	}
+	return newClient(o.serverAddress(saved), token), nil
+}
@@ -0,0 +1,204 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// Delays between reconnections of events tail, doubling from the first
+const (
+	firstReconnectDelay = time.Second
+	maxReconnectDelay   = 30 * time.Second
+)
+
+// streamEvent is an event of the Server-Sent Events stream
+type streamEvent struct {
+	ID    string          `json:"id,omitempty"`
+	Event string          `json:"event"`
+	Data  json.RawMessage `json:"data"`
+}
+
+func newEventsCommand(opts *options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "events",
+		Short: "Follow the asset and team events visible to you",
+	}
+	cmd.AddCommand(newEventsTailCommand(opts))
+	return cmd
+}
+
+func newEventsTailCommand(opts *options) *cobra.Command {
+	var since string
+	var reconnect bool
+	cmd := &cobra.Command{
+		Use:   "tail",
+		Short: "Print events as they happen, until interrupted",
+		Long: `Prints the events of the event stream as they happen, until interrupted.
+When the connection drops, the stream is resumed after the last event
+received, so no asset event is missed; when the server cannot replay the
+missed events, a resync event is printed. With --since, the stream starts
+after the event of that ID. With --output json, each event is printed as a
+JSON object on its own line.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c, err := opts.newClient()
+			if err != nil {
+				return err
+			}
+			return tailEvents(cmd, opts, c, since, reconnect)
+		},
+	}
+	cmd.Flags().StringVar(&since, "since", "", "ID of the event to resume after")
+	cmd.Flags().BoolVar(&reconnect, "reconnect", true, "reconnect when the connection drops")
+	return cmd
+}
+
+// tailEvents prints the events of the stream, reconnecting with the ID of the
+// last one until the command is interrupted
+func tailEvents(cmd *cobra.Command, opts *options, c *client, lastEventID string, reconnect bool) error {
+	ctx := cmd.Context()
+	delay := firstReconnectDelay
+	for {
+		var writeErr error
+		received, err := c.stream(ctx, lastEventID, func(event streamEvent) error {
+			switch {
+			case event.ID != "":
+				lastEventID = event.ID
+			case event.Event == "resync":
+				// The stream starts over from the live events
+				lastEventID = ""
+			}
+			writeErr = writeEvent(cmd, opts, event)
+			return writeErr
+		})
+		if ctx.Err() != nil {
+			return nil
+		}
+		if writeErr != nil {
+			return writeErr
+		}
+		var apiErr *apiError
+		if errors.As(err, &apiErr) && apiErr.Status != http.StatusTooManyRequests && apiErr.Status < 500 {
+			return err
+		}
+		if !reconnect {
+			if err == nil {
+				err = errors.New("the server closed the event stream")
+			}
+			return err
+		}
+
+		if received {
+			delay = firstReconnectDelay
+		}
+		if err != nil {
+			fmt.Fprintf(cmd.ErrOrStderr(), "Event stream failed: %v; reconnecting in %s\n", err, delay)
+		} else {
+			fmt.Fprintf(cmd.ErrOrStderr(), "Event stream closed; reconnecting in %s\n", delay)
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(delay):
+		}
+		if delay *= 2; delay > maxReconnectDelay {
+			delay = maxReconnectDelay
+		}
+	}
+}
+
+// stream reads the event stream, resuming after lastEventID when set, and
+// passes each event to handle until the stream ends. It reports whether any
+// event was received.
+func (c *client) stream(ctx context.Context, lastEventID string, handle func(streamEvent) error) (received bool, err error) {
+	req, err := c.newRequest(ctx, http.MethodGet, apiPath+"/events/stream", nil, nil)
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Accept", "text/event-stream")
+	if lastEventID != "" {
+		req.Header.Set("Last-Event-ID", lastEventID)
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 64*1024))
+		return false, newAPIError(resp.StatusCode, body)
+	}
+
+	var event streamEvent
+	var data []string
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			// A blank line ends the event
+			if event.Event != "" || len(data) > 0 {
+				event.Data = json.RawMessage(strings.Join(data, "\n"))
+				if event.Event == "" {
+					event.Event = "message"
+				}
+				received = true
+				if err := handle(event); err != nil {
+					return received, err
+				}
+			}
+			event, data = streamEvent{}, nil
+			continue
+		}
+		if strings.HasPrefix(line, ":") {
+			// Heartbeat comments
+			continue
+		}
+		field, value, _ := strings.Cut(line, ":")
+		value = strings.TrimPrefix(value, " ")
+		switch field {
+		case "id":
+			event.ID = value
+		case "event":
+			event.Event = value
+		case "data":
+			data = append(data, value)
+		}
+	}
+	return received, scanner.Err()
+}
+
+func writeEvent(cmd *cobra.Command, opts *options, event streamEvent) error {
+	if !json.Valid(event.Data) {
+		// Events are JSON; anything else is passed on as a string
+		event.Data, _ = json.Marshal(string(event.Data))
+	}
+	if opts.output == outputJSON {
+		line, err := json.Marshal(event)
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintf(cmd.OutOrStdout(), "%s\n", line)
+		return err
+	}
+
+	if event.Event == "resync" {
+		fmt.Fprintln(cmd.ErrOrStderr(), "Missed events could not be replayed: refetch what you follow")
+	}
+	id := event.ID
+	if id == "" {
+		id = "-"
+	}
+	_, err := fmt.Fprintf(cmd.OutOrStdout(), "%s  %s  %s  %s\n", time.Now().Format("15:04:05"), event.Event, id, event.Data)
+	return err
+}
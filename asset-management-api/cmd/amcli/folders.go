@@ -0,0 +1,110 @@
+package main
+
+import (
+	"encoding/json"
+	"net/url"
+	"strconv"
+
+	"asset-management-api/internal/models"
+
+	"github.com/spf13/cobra"
+)
+
+var folderHeader = []string{"ID", "NAME", "DESCRIPTION", "UPDATED"}
+
+func folderRow(folder models.Folder) []string {
+	return []string{folder.FolderID.String(), folder.Name, folder.Description, formatTime(folder.UpdatedAt)}
+}
+
+func newFoldersCommand(opts *options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "folders",
+		Aliases: []string{"folder"},
+		Short:   "List, show and create folders",
+	}
+	cmd.AddCommand(newFoldersListCommand(opts), newFoldersGetCommand(opts), newFoldersCreateCommand(opts))
+	return cmd
+}
+
+func newFoldersListCommand(opts *options) *cobra.Command {
+	var flags listFlags
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List the folders you own",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c, err := opts.newClient()
+			if err != nil {
+				return err
+			}
+			items, next, err := c.list(cmd.Context(), apiPath+"/folders", flags)
+			if err != nil {
+				return err
+			}
+			return writeList(cmd, opts, items, next, folderHeader, folderRow)
+		},
+	}
+	flags.register(cmd)
+	return cmd
+}
+
+func newFoldersGetCommand(opts *options) *cobra.Command {
+	return &cobra.Command{
+		Use:   "get <folder-id>",
+		Short: "Show a folder",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c, err := opts.newClient()
+			if err != nil {
+				return err
+			}
+			var data json.RawMessage
+			if _, err := c.get(cmd.Context(), apiPath+"/folders/"+url.PathEscape(args[0]), nil, &data); err != nil {
+				return err
+			}
+			return writeFolder(cmd, opts, data)
+		},
+	}
+}
+
+func newFoldersCreateCommand(opts *options) *cobra.Command {
+	var description string
+	cmd := &cobra.Command{
+		Use:   "create <name>",
+		Short: "Create a folder",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c, err := opts.newClient()
+			if err != nil {
+				return err
+			}
+			body := map[string]string{"name": args[0], "description": description}
+			var data json.RawMessage
+			if _, err := c.post(cmd.Context(), apiPath+"/folders", body, &data); err != nil {
+				return err
+			}
+			return writeFolder(cmd, opts, data)
+		},
+	}
+	cmd.Flags().StringVar(&description, "description", "", "description of the folder")
+	return cmd
+}
+
+func writeFolder(cmd *cobra.Command, opts *options, data json.RawMessage) error {
+	if opts.output == outputJSON {
+		return writeJSON(cmd.OutOrStdout(), data)
+	}
+	var folder models.Folder
+	if err := json.Unmarshal(data, &folder); err != nil {
+		return err
+	}
+	return writeFields(cmd.OutOrStdout(), [][2]string{
+		{"ID", folder.FolderID.String()},
+		{"Name", folder.Name},
+		{"Description", folder.Description},
+		{"Owner", folder.OwnerID.String()},
+		{"Version", strconv.FormatInt(folder.Version, 10)},
+		{"Created", formatTime(folder.CreatedAt)},
+		{"Updated", formatTime(folder.UpdatedAt)},
+	})
+}
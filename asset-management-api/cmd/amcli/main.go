@@ -0,0 +1,87 @@
+// Command amcli is a command line client of the asset management API, for
+// scripting and support.
+//
+// Usage:
+//
+//	amcli login [--token token | --test]
+//	amcli folders list|get|create ...
+//	amcli notes list|get|create ...
+//	amcli share|unshare folder|note <id> <user-id>
+//	amcli events tail
+//
+// The server and token are read from the --server and --token flags, the
+// AMCLI_SERVER and AMCLI_TOKEN environment variables, or the credentials
+// saved by login, in that order. Lists print a table, or the API's JSON with
+// --output json.
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/spf13/cobra"
+)
+
+// defaultServer is the address the API listens on in development
+const defaultServer = "http://localhost:8000"
+
+// Output formats
+const (
+	outputTable = "table"
+	outputJSON  = "json"
+)
+
+// options are the flags of every command
+type options struct {
+	server string
+	token  string
+	output string
+}
+
+func main() {
+	// Interrupting ends events tail, and cancels the request of other commands
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if err := newRootCommand().ExecuteContext(ctx); err != nil {
+		stop()
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		os.Exit(1)
+	}
+}
+
+func newRootCommand() *cobra.Command {
+	opts := &options{}
+	root := &cobra.Command{
+		Use:           "amcli",
+		Short:         "Command line client of the asset management API",
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			if opts.output != outputTable && opts.output != outputJSON {
+				return fmt.Errorf("--output must be %s or %s", outputTable, outputJSON)
+			}
+			return nil
+		},
+	}
+
+	flags := root.PersistentFlags()
+	flags.StringVar(&opts.server, "server", "", "API address (default $AMCLI_SERVER, the saved server or "+defaultServer+")")
+	flags.StringVar(&opts.token, "token", "", "bearer token (default $AMCLI_TOKEN or the saved token)")
+	flags.StringVarP(&opts.output, "output", "o", outputTable, "output format: table or json")
+
+	root.AddCommand(
+		newLoginCommand(opts),
+		newLogoutCommand(),
+		newWhoamiCommand(opts),
+		newFoldersCommand(opts),
+		newNotesCommand(opts),
+		newShareCommand(opts),
+		newUnshareCommand(opts),
+		newEventsCommand(opts),
+	)
+	return root
+}
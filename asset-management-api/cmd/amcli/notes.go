@@ -0,0 +1,158 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"strconv"
+
+	"asset-management-api/internal/models"
+
+	"github.com/spf13/cobra"
+)
+
+var noteHeader = []string{"ID", "TITLE", "FOLDER", "BODY", "UPDATED"}
+
+func noteRow(note models.Note) []string {
+	return []string{note.NoteID.String(), note.Title, note.FolderID.String(), note.Body, formatTime(note.UpdatedAt)}
+}
+
+func newNotesCommand(opts *options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "notes",
+		Aliases: []string{"note"},
+		Short:   "List, show and create notes",
+	}
+	cmd.AddCommand(newNotesListCommand(opts), newNotesGetCommand(opts), newNotesCreateCommand(opts))
+	return cmd
+}
+
+func newNotesListCommand(opts *options) *cobra.Command {
+	var flags listFlags
+	var folderID string
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List the notes you own, or the notes of a folder",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c, err := opts.newClient()
+			if err != nil {
+				return err
+			}
+			path := apiPath + "/notes"
+			if folderID != "" {
+				path = apiPath + "/folders/" + url.PathEscape(folderID) + "/notes"
+			}
+			items, next, err := c.list(cmd.Context(), path, flags)
+			if err != nil {
+				return err
+			}
+			return writeList(cmd, opts, items, next, noteHeader, noteRow)
+		},
+	}
+	flags.register(cmd)
+	cmd.Flags().StringVar(&folderID, "folder", "", "list the notes of this folder")
+	return cmd
+}
+
+func newNotesGetCommand(opts *options) *cobra.Command {
+	return &cobra.Command{
+		Use:   "get <note-id>",
+		Short: "Show a note",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c, err := opts.newClient()
+			if err != nil {
+				return err
+			}
+			var data json.RawMessage
+			if _, err := c.get(cmd.Context(), apiPath+"/notes/"+url.PathEscape(args[0]), nil, &data); err != nil {
+				return err
+			}
+			return writeNote(cmd, opts, data)
+		},
+	}
+}
+
+func newNotesCreateCommand(opts *options) *cobra.Command {
+	var folderID, title, body, bodyFile string
+	cmd := &cobra.Command{
+		Use:   "create",
+		Short: "Create a note in a folder",
+		Long: `Creates a note in a folder. The body is given with --body, or read from
+the file of --body-file, or from standard input when it is "-".`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if body != "" && bodyFile != "" {
+				return errors.New("--body and --body-file cannot be used together")
+			}
+			if bodyFile != "" {
+				data, err := readBodyFile(cmd, bodyFile)
+				if err != nil {
+					return err
+				}
+				body = data
+			}
+
+			c, err := opts.newClient()
+			if err != nil {
+				return err
+			}
+			req := map[string]string{"title": title, "body": body}
+			var data json.RawMessage
+			if _, err := c.post(cmd.Context(), apiPath+"/folders/"+url.PathEscape(folderID)+"/notes", req, &data); err != nil {
+				return err
+			}
+			return writeNote(cmd, opts, data)
+		},
+	}
+	cmd.Flags().StringVar(&folderID, "folder", "", "folder to create the note in")
+	cmd.Flags().StringVar(&title, "title", "", "title of the note")
+	cmd.Flags().StringVar(&body, "body", "", "body of the note")
+	cmd.Flags().StringVar(&bodyFile, "body-file", "", `file to read the body from, "-" for standard input`)
+	_ = cmd.MarkFlagRequired("folder")
+	_ = cmd.MarkFlagRequired("title")
+	return cmd
+}
+
+func readBodyFile(cmd *cobra.Command, name string) (string, error) {
+	var data []byte
+	var err error
+	if name == "-" {
+		data, err = io.ReadAll(cmd.InOrStdin())
+	} else {
+		data, err = os.ReadFile(name)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to read the body: %w", err)
+	}
+	return string(data), nil
+}
+
+// writeNote writes a note, its body after its fields
+func writeNote(cmd *cobra.Command, opts *options, data json.RawMessage) error {
+	if opts.output == outputJSON {
+		return writeJSON(cmd.OutOrStdout(), data)
+	}
+	var note models.Note
+	if err := json.Unmarshal(data, &note); err != nil {
+		return err
+	}
+	err := writeFields(cmd.OutOrStdout(), [][2]string{
+		{"ID", note.NoteID.String()},
+		{"Title", note.Title},
+		{"Folder", note.FolderID.String()},
+		{"Owner", note.OwnerID.String()},
+		{"Version", strconv.FormatInt(note.Version, 10)},
+		{"Created", formatTime(note.CreatedAt)},
+		{"Updated", formatTime(note.UpdatedAt)},
+	})
+	if err != nil || note.Body == "" {
+		return err
+	}
+	_, err = fmt.Fprintf(cmd.OutOrStdout(), "\n%s\n", note.Body)
+	return err
+}
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// maxCellWidth truncates long values in tables, such as note bodies
+const maxCellWidth = 60
+
+// writeJSON writes the JSON of the API indented
+func writeJSON(w io.Writer, data json.RawMessage) error {
+	var buf bytes.Buffer
+	if err := json.Indent(&buf, data, "", "  "); err != nil {
+		return err
+	}
+	buf.WriteByte('\n')
+	_, err := buf.WriteTo(w)
+	return err
+}
+
+// writeItems writes the items of a list as a JSON array
+func writeItems(w io.Writer, items []json.RawMessage) error {
+	if items == nil {
+		items = []json.RawMessage{}
+	}
+	data, err := json.Marshal(items)
+	if err != nil {
+		return err
+	}
+	return writeJSON(w, data)
+}
+
+// writeTable writes rows under a header, in aligned columns
+func writeTable(w io.Writer, header []string, rows [][]string) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, strings.Join(header, "\t"))
+	for _, row := range rows {
+		cells := make([]string, len(row))
+		for i, cell := range row {
+			cells[i] = cellText(cell)
+		}
+		fmt.Fprintln(tw, strings.Join(cells, "\t"))
+	}
+	return tw.Flush()
+}
+
+// decodeItems decodes the items of a list
+func decodeItems[T any](items []json.RawMessage) ([]T, error) {
+	decoded := make([]T, len(items))
+	for i, item := range items {
+		if err := json.Unmarshal(item, &decoded[i]); err != nil {
+			return nil, fmt.Errorf("unexpected response data: %w", err)
+		}
+	}
+	return decoded, nil
+}
+
+// cellText fits a value on one line of a table
+func cellText(s string) string {
+	s = strings.Join(strings.Fields(s), " ")
+	if runes := []rune(s); len(runes) > maxCellWidth {
+		s = string(runes[:maxCellWidth-1]) + "…"
+	}
+	return s
+}
+
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Local().Format("2006-01-02 15:04")
+}
+
+// writeFields writes the fields of one item, a name and value per line
+func writeFields(w io.Writer, fields [][2]string) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	for _, field := range fields {
+		fmt.Fprintf(tw, "%s:\t%s\n", field[0], field[1])
+	}
+	return tw.Flush()
+}
+
+// listFlags are the flags of the commands listing by cursor
+type listFlags struct {
+	limit  int
+	cursor string
+	all    bool
+}
+
+func (f *listFlags) register(cmd *cobra.Command) {
+	cmd.Flags().IntVar(&f.limit, "limit", 50, "items per page, at most 100")
+	cmd.Flags().StringVar(&f.cursor, "cursor", "", "cursor of the page to list, printed after the previous page")
+	cmd.Flags().BoolVar(&f.all, "all", false, "list every page")
+}
+
+// writeList writes a list in the output format, rows giving the table rows of
+// its items. When there are more pages, the cursor of the next one is
+// printed to standard error.
+func writeList[T any](cmd *cobra.Command, opts *options, items []json.RawMessage, next string, header []string, row func(T) []string) error {
+	if next != "" {
+		defer fmt.Fprintf(cmd.ErrOrStderr(), "More items: list the next page with --cursor %s\n", next)
+	}
+	if opts.output == outputJSON {
+		return writeItems(cmd.OutOrStdout(), items)
+	}
+	decoded, err := decodeItems[T](items)
+	if err != nil {
+		return err
+	}
+	rows := make([][]string, len(decoded))
+	for i, item := range decoded {
+		rows[i] = row(item)
+	}
+	return writeTable(cmd.OutOrStdout(), header, rows)
+}
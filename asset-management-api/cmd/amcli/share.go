@@ -0,0 +1,77 @@
+package main
+
+import (
+	"fmt"
+	"net/url"
+
+	"asset-management-api/internal/models"
+
+	"github.com/spf13/cobra"
+)
+
+// assetCollections are the API collections of the assets that can be shared
+var assetCollections = map[string]string{
+	"folder": "folders",
+	"note":   "notes",
+}
+
+func newShareCommand(opts *options) *cobra.Command {
+	var access string
+	cmd := &cobra.Command{
+		Use:       "share folder|note <asset-id> <user-id>",
+		Short:     "Share a folder or note with a user",
+		Args:      cobra.ExactArgs(3),
+		ValidArgs: []string{"folder", "note"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path, err := assetPath(args[0], args[1])
+			if err != nil {
+				return err
+			}
+			c, err := opts.newClient()
+			if err != nil {
+				return err
+			}
+			req := models.ShareRequest{UserID: args[2], AccessLevel: access}
+			if _, err := c.post(cmd.Context(), path+"/share", req, nil); err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Shared %s %s with %s (%s)\n", args[0], args[1], args[2], access)
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&access, "access", "read", "access level: read or write")
+	return cmd
+}
+
+func newUnshareCommand(opts *options) *cobra.Command {
+	return &cobra.Command{
+		Use:       "unshare folder|note <asset-id> <user-id>",
+		Short:     "Stop sharing a folder or note with a user",
+		Args:      cobra.ExactArgs(3),
+		ValidArgs: []string{"folder", "note"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path, err := assetPath(args[0], args[1])
+			if err != nil {
+				return err
+			}
+			c, err := opts.newClient()
+			if err != nil {
+				return err
+			}
+			if _, err := c.delete(cmd.Context(), path+"/share/"+url.PathEscape(args[2])); err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Stopped sharing %s %s with %s\n", args[0], args[1], args[2])
+			return nil
+		},
+	}
+}
+
+// assetPath is the API path of a folder or note
+func assetPath(assetType, assetID string) (string, error) {
+	collection, ok := assetCollections[assetType]
+	if !ok {
+		return "", fmt.Errorf("cannot share a %q: only folders and notes are shared", assetType)
+	}
+	return apiPath + "/" + collection + "/" + url.PathEscape(assetID), nil
+}
@@ -0,0 +1,85 @@
+// Command migrate applies and reverts the database migrations.
+//
+// Usage:
+//
+//	migrate [-config file] [-profile name] <command> [args]
+//
+// Commands:
+//
+//	up                 apply all pending migrations
+//	up-by-one          apply the next pending migration
+//	up-to VERSION      apply the migrations up to VERSION
+//	down               revert the latest migration
+//	down-to VERSION    revert the migrations after VERSION
+//	redo               revert and apply the latest migration again
+//	reset              revert all migrations
+//	status             list the migrations and whether they are applied
+//	version            print the current version
+//	create NAME        add an empty SQL migration to the migrations directory
+//
+// The SQL migrations are written for Postgres. MySQL and SQLite databases
+// support only up, which creates and extends their tables from the models.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"asset-management-api/internal/config"
+	"asset-management-api/internal/database"
+
+	"github.com/pressly/goose/v3"
+	"github.com/sirupsen/logrus"
+)
+
+func main() {
+	configFile := flag.String("config", "", "configuration file, YAML or TOML (default $CONFIG_FILE or config.yaml)")
+	profile := flag.String("profile", "", "configuration profile such as dev, staging or prod (default $CONFIG_PROFILE)")
+	dir := flag.String("dir", "migrations", "migrations directory, written to by create")
+	flag.Usage = func() {
+		fmt.Fprintln(flag.CommandLine.Output(), "Usage: migrate [flags] <up|up-by-one|up-to|down|down-to|redo|reset|status|version|create> [args]")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if flag.NArg() < 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	command, args := flag.Arg(0), flag.Args()[1:]
+
+	// New migrations are written to disk, not to the built-in files
+	if command == "create" {
+		if len(args) != 1 {
+			log.Fatal("Usage: migrate create NAME")
+		}
+		goose.SetBaseFS(nil)
+		goose.SetSequential(true)
+		if err := goose.Create(nil, *dir, args[0], "sql"); err != nil {
+			log.Fatalf("Failed to create migration: %v", err)
+		}
+		return
+	}
+
+	cfg, err := config.Load(*configFile, *profile)
+	if err != nil {
+		log.Fatalf("Failed to load configuration: %v", err)
+	}
+
+	db, err := database.NewConnection(&cfg.Database, database.NewCredentials(cfg.Database.User, cfg.Database.Password), logrus.StandardLogger())
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if err := database.Migrate(ctx, db, command, args...); err != nil {
+		log.Fatal(err)
+	}
+}
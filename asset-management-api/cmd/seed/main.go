@@ -0,0 +1,60 @@
+// Command seed fills the database with demo data from a fixture file.
+//
+// Usage:
+//
+//	seed [-config file] [-profile name] [-file fixture]
+//
+// The fixture is a YAML file of users, teams, folders, notes and shares; see
+// fixtures/demo.yaml. Users are referred to by email address, and users that
+// already exist are reused. Everything is created in one transaction.
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"asset-management-api/internal/config"
+	"asset-management-api/internal/database"
+	"asset-management-api/internal/repository/postgres"
+	"asset-management-api/internal/seed"
+
+	"github.com/sirupsen/logrus"
+)
+
+func main() {
+	configFile := flag.String("config", "", "configuration file, YAML or TOML (default $CONFIG_FILE or config.yaml)")
+	profile := flag.String("profile", "", "configuration profile such as dev, staging or prod (default $CONFIG_PROFILE)")
+	fixtureFile := flag.String("file", "fixtures/demo.yaml", "fixture file to seed from")
+	flag.Parse()
+
+	// The fixture is checked before connecting, so mistakes are reported
+	// without a database at hand
+	fixture, err := seed.LoadFixture(*fixtureFile)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	cfg, err := config.Load(*configFile, *profile)
+	if err != nil {
+		log.Fatalf("Failed to load configuration: %v", err)
+	}
+
+	db, err := database.NewConnection(&cfg.Database, database.NewCredentials(cfg.Database.User, cfg.Database.Password), logrus.StandardLogger())
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	result, err := seed.Seed(ctx, postgres.NewTxManager(db), fixture)
+	if err != nil {
+		log.Fatalf("Failed to seed database: %v", err)
+	}
+	log.Printf("Seeded %d users (%d already existed), %d teams, %d folders, %d notes and %d shares",
+		result.Users, result.ExistingUsers, result.Teams, result.Folders, result.Notes, result.Shares)
+}
@@ -1,41 +1,113 @@
 package main
 
 import (
+	"asset-management-api/internal/audit"
+	"asset-management-api/internal/backup"
 	"asset-management-api/internal/cache"
+	memcachedCache "asset-management-api/internal/cache/memcached"
 	redisCache "asset-management-api/internal/cache/redis"
+	tieredCache "asset-management-api/internal/cache/tiered"
 	"asset-management-api/internal/config"
 	"asset-management-api/internal/database"
+	errorReporting "asset-management-api/internal/errreport"
+	"asset-management-api/internal/grpcapi"
 	"asset-management-api/internal/events/kafka"
+	"asset-management-api/internal/events/memory"
+	"asset-management-api/internal/events/store"
+	"asset-management-api/internal/graph"
 	"asset-management-api/internal/handler"
+	handlerV2 "asset-management-api/internal/handler/v2"
+	"asset-management-api/internal/health"
+	"asset-management-api/internal/i18n"
+	"asset-management-api/internal/logging"
 	"asset-management-api/internal/middleware"
+	"asset-management-api/internal/models"
+	"asset-management-api/internal/notification"
+	"asset-management-api/internal/realtime"
 	"asset-management-api/internal/repository/postgres"
+	"asset-management-api/internal/retention"
 	"asset-management-api/internal/service"
+	"asset-management-api/internal/tracing"
 	"asset-management-api/internal/utils"
+	"asset-management-api/internal/webhook"
 	"asset-management-api/pkg/eventbus"
 	cacheInterface "asset-management-api/pkg/cache"
+	"asset-management-api/pkg/errreport"
+	"asset-management-api/pkg/ipfilter"
+	"asset-management-api/pkg/maintenance"
+	"asset-management-api/pkg/quota"
+	"asset-management-api/pkg/ratelimit"
 
 	"context"
+	"flag"
+	"fmt"
 	"log"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
+	"runtime"
+	"slices"
+	"strings"
 	"syscall"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"gorm.io/gorm"
 )
 
+// activityPublisher records the user.activity audit trail from enhanceHandler;
+// nil when events are disabled
+var activityPublisher *audit.ActivityPublisher
+
+// Route patterns of the Server-Sent Events stream and the WebSocket
+const (
+	realtimeStreamRoute = "/api/v1/events/stream"
+	realtimeSocketRoute = "/api/v1/ws"
+)
+
+// batchRoute is the route pattern of batch requests
+const batchRoute = "/api/v1/batch"
+
 func main() {
+	configFile := flag.String("config", "", "configuration file, YAML or TOML (default $CONFIG_FILE or config.yaml)")
+	profile := flag.String("profile", "", "configuration profile such as dev, staging or prod, read from config.<profile>.yaml (default $CONFIG_PROFILE)")
+	flag.Parse()
+
 	// Load configuration
-	cfg, err := config.Load()
+	cfg, err := config.Load(*configFile, *profile)
 	if err != nil {
 		log.Fatalf("Failed to load configuration: %v", err)
 	}
+	configHolder := config.NewHolder(cfg, *configFile, *profile)
+
+	// Build the logger before anything logs through it
+	appLogger, closeLogger := initializeLogger(&cfg.Logging)
+	defer closeLogger()
+
+	for _, warning := range cfg.Warnings() {
+		middleware.LogWarn(warning, map[string]interface{}{"component": "config"})
+	}
+
+	// Histograms take their buckets before anything is observed
+	if err := middleware.SetLatencyBuckets(cfg.Metrics.HTTPLatencyBuckets, cfg.Metrics.DBLatencyBuckets); err != nil {
+		log.Fatalf("Invalid metrics configuration: %v", err)
+	}
+
+	// Report panics and server errors from the first request on
+	errorReporter := initializeErrorReporting(&cfg.ErrorReporting)
+
+	// Set up span export before the database and Redis clients are instrumented
+	shutdownTracing := initializeTracing(&cfg.Tracing)
 
 	// Connect to database
-	db, err := database.NewConnection(&cfg.Database)
+	dbCredentials := database.NewCredentials(cfg.Database.User, cfg.Database.Password)
+	db, err := database.NewConnection(&cfg.Database, dbCredentials, appLogger)
 	if err != nil {
 		log.Fatalf("Failed to connect to database: %v", err)
 	}
@@ -46,13 +118,31 @@ func main() {
 		"name": cfg.Database.DBName,
 	})
 
+	if cfg.Database.MigrateOnStart {
+		if err := database.Migrate(context.Background(), db, "up"); err != nil {
+			log.Fatalf("Failed to migrate database: %v", err)
+		}
+	}
+
 	// Initialize JWT utility
 	jwtUtil := utils.NewJWTUtil(cfg.JWT.SecretKey, cfg.JWT.ExpirationTime)
 
-	// NEW: Initialize Redis cache if enabled
+	// NEW: Initialize cache backend selected by CACHE_BACKEND
 	var cacheService cacheInterface.CacheService
-	if cfg.Redis.Enabled {
-		cacheService, err = initializeRedisCache(&cfg.Redis)
+	var redisClient *redisCache.RedisClient
+	if cfg.Cache.Backend == "memcached" {
+		cacheService, err = initializeMemcachedCache(&cfg.Memcached, &cfg.Cache)
+		if err != nil {
+			log.Printf("Failed to initialize memcached cache: %v, continuing without cache", err)
+			cacheService = &noOpCacheService{} // Fallback to no-op implementation
+		} else {
+			middleware.LogInfo("Memcached cache initialized successfully", map[string]interface{}{
+				"servers":     cfg.Memcached.Servers,
+				"key_version": cacheService.KeyVersion(),
+			})
+		}
+	} else if cfg.Redis.Enabled {
+		cacheService, redisClient, err = initializeRedisCache(&cfg.Redis, &cfg.Cache)
 		if err != nil {
 			log.Printf("Failed to initialize Redis cache: %v, continuing without cache", err)
 			cacheService = &noOpCacheService{} // Fallback to no-op implementation
@@ -61,6 +151,7 @@ func main() {
 				"host": cfg.Redis.Host,
 				"port": cfg.Redis.Port,
 				"database": cfg.Redis.Database,
+				"key_version": cacheService.KeyVersion(),
 			})
 		}
 	} else {
@@ -70,27 +161,35 @@ func main() {
 
 	// Initialize Kafka event bus if enabled
 	var eventBus eventbus.EventBus
+	var deadLetterRedriver eventbus.DeadLetterRedriver
+	var consumptionController eventbus.ConsumptionController
 	var cacheEventHandler *cache.CacheEventHandler
+	var responseCache cacheInterface.ResponseCache
+	if redisClient != nil {
+		// Created even when response caching is disabled, as a reload can
+		// enable it
+		responseCache = redisCache.NewRedisResponseCache(redisClient)
+	}
 	if cfg.Kafka.Enabled {
-		eventBus, err = initializeKafka(cfg)
+		// Events published only in this process would never reach the other
+		// instances, so an unreachable Kafka is fatal rather than a fallback
+		kafkaBus, err := initializeKafka(cfg)
 		if err != nil {
-			log.Printf("Failed to initialize Kafka: %v, continuing without event bus", err)
-			eventBus = &noOpEventBus{} // Fallback to no-op implementation
-		} else {
-			middleware.LogInfo("Kafka initialized successfully", map[string]interface{}{
-				"brokers": cfg.Kafka.Brokers,
-				"group_id": cfg.Kafka.ConsumerGroupID,
-			})
-			
-			// NEW: Initialize cache event handler and subscribe to events
-			cacheEventHandler = cache.NewCacheEventHandler(cacheService)
-			if err := subscribeToEvents(eventBus, cacheEventHandler); err != nil {
-				log.Printf("Failed to subscribe to events: %v", err)
-			}
+			log.Fatalf("Failed to initialize Kafka: %v", err)
 		}
+		eventBus = kafkaBus
+		deadLetterRedriver = kafkaBus
+		consumptionController = kafkaBus
+		if redisClient != nil && cfg.Kafka.ConsumerDedupEnabled {
+			kafkaBus.SetDeduplicator(redisCache.NewRedisDeduplicator(redisClient, cfg.Kafka.ConsumerDedupTTL, cfg.Kafka.ConsumerDedupProcessingTTL))
+		}
+		middleware.LogInfo("Kafka initialized successfully", map[string]interface{}{
+			"brokers": cfg.Kafka.Brokers,
+			"group_id": cfg.Kafka.ConsumerGroupID,
+		})
 	} else {
-		log.Println("Kafka disabled, using no-op event bus")
-		eventBus = &noOpEventBus{}
+		log.Println("Kafka disabled, using local event bus")
+		eventBus = initializeLocalEventBus(cfg)
 	}
 
 	// Initialize repositories
@@ -99,13 +198,146 @@ func main() {
 	shareRepo := postgres.NewShareRepository(db)
 	userRepo := postgres.NewUserRepository(db)
 	teamRepo := postgres.NewTeamRepository(db)
+	webhookRepo := postgres.NewWebhookRepository(db)
+	assetEventRepo := postgres.NewAssetEventRepository(db)
+	subscriptionRepo := postgres.NewSubscriptionRepository(db)
+	userActivityRepo := postgres.NewUserActivityRepository(db)
+	exportJobRepo := postgres.NewExportJobRepository(db)
+	txManager := postgres.NewTxManager(db)
+	if cfg.Database.AccessChecks == "raw" {
+		// Skip GORM for the checks made on every request
+		sqlDB, err := db.DB()
+		if err != nil {
+			log.Fatalf("Failed to get database connection pool: %v", err)
+		}
+		folderRepo = postgres.NewRawFolderRepository(db, sqlDB)
+		noteRepo = postgres.NewRawNoteRepository(db, sqlDB)
+		shareRepo = postgres.NewRawShareRepository(db, sqlDB)
+		userRepo = postgres.NewRawUserRepository(db, sqlDB)
+	}
+
+	// Record mutating requests in the api_audit table; unlike the
+	// user.activity trail this does not need the event bus
+	var apiAuditLog *audit.APIAuditLog
+	if cfg.APIAudit.Enabled {
+		apiAuditLog = audit.NewAPIAuditLog(audit.APIAuditConfig{
+			BufferSize:    cfg.APIAudit.BufferSize,
+			BatchSize:     cfg.APIAudit.BatchSize,
+			FlushInterval: cfg.APIAudit.FlushInterval,
+		}, postgres.NewAPIAuditRepository(db))
+		apiAuditLog.Start()
+	}
+
+	// Create the monthly partitions of the audit and event tables ahead of
+	// time and drop the months past retention
+	retentionJob := retention.NewJob(retention.Config{
+		Interval: cfg.Retention.CheckInterval,
+		Policies: []retention.Policy{
+			{Table: "api_audit", Months: cfg.Retention.APIAuditMonths},
+			{Table: "user_activity_log", Months: cfg.Retention.UserActivityMonths},
+			{Table: "asset_event_log", Months: cfg.Retention.AssetEventMonths},
+			{Table: "notifications", Months: cfg.Retention.NotificationMonths},
+		},
+	}, postgres.NewPartitionRepository(db))
+	retentionJob.Start()
+
+	// Export teams to S3 for disaster recovery, on request and optionally
+	// on a schedule, and for their managers through the export jobs
+	var backupJob *backup.Job
+	var exporter *backup.Exporter
+	if cfg.Backup.S3Bucket != "" {
+		backupJob = backup.NewJob(backup.Config{
+			Prefix:   cfg.Backup.S3Prefix,
+			Interval: cfg.Backup.Interval,
+			Timeout:  cfg.Backup.Timeout,
+		}, backup.Repositories{
+			Teams:    teamRepo,
+			Folders:  folderRepo,
+			Notes:    noteRepo,
+			Shares:   shareRepo,
+			Activity: userActivityRepo,
+		}, backup.NewS3Store(backup.S3Config{
+			Bucket:          cfg.Backup.S3Bucket,
+			Region:          cfg.Backup.S3Region,
+			Endpoint:        cfg.Backup.S3Endpoint,
+			AccessKeyID:     cfg.Secrets.AWSAccessKeyID,
+			SecretAccessKey: cfg.Secrets.AWSSecretAccessKey,
+			SessionToken:    cfg.Secrets.AWSSessionToken,
+			Timeout:         cfg.Backup.Timeout,
+		}))
+		backupJob.Start()
+		exporter = backup.NewExporter(backupJob, exportJobRepo, backup.ExportConfig{
+			Workers: cfg.Backup.ExportWorkers,
+			Timeout: cfg.Backup.ExportTimeout,
+			LinkTTL: cfg.Backup.ExportLinkTTL,
+		})
+		exporter.Start()
+	}
+
+	// NEW: Initialize cache event handler, webhook dispatcher, asset event
+	// recorder, subscription notifier, realtime hub and user activity audit
+	// trail, then subscribe to events
+	var webhookDispatcher *webhook.Dispatcher
+	var realtimeHub *realtime.Hub
+	if _, noOp := eventBus.(*noOpEventBus); !noOp {
+		cacheEventHandler = cache.NewCacheEventHandler(cacheService)
+		if responseCache != nil {
+			cacheEventHandler.SetResponseCache(responseCache)
+		}
+		if cfg.Webhook.Enabled {
+			webhookDispatcher = webhook.NewDispatcher(webhook.Config{
+				Workers:        cfg.Webhook.Workers,
+				QueueSize:      cfg.Webhook.QueueSize,
+				MaxAttempts:    cfg.Webhook.MaxAttempts,
+				Timeout:        cfg.Webhook.Timeout,
+				InitialBackoff: cfg.Webhook.InitialBackoff,
+				MaxBackoff:     cfg.Webhook.MaxBackoff,
+				PollInterval:   cfg.Webhook.PollInterval,
+			}, webhookRepo, teamRepo, service.NewACLLoader(folderRepo, noteRepo, shareRepo, cacheService), noteRepo)
+			webhookDispatcher.Start()
+		}
+		assetEventRecorder := store.NewAssetEventRecorder(assetEventRepo)
+		notifier := notification.NewNotifier(subscriptionRepo, noteRepo, service.NewACLLoader(folderRepo, noteRepo, shareRepo, cacheService), eventBus)
+		activityRecorder := store.NewUserActivityRecorder(userActivityRepo)
+		activityPublisher = audit.NewActivityPublisher(eventBus)
+		if err := subscribeToEvents(eventBus, cacheEventHandler, webhookDispatcher, assetEventRecorder, notifier, activityRecorder); err != nil {
+			log.Printf("Failed to subscribe to events: %v", err)
+		}
+		if cfg.Realtime.Enabled {
+			realtimeHub = realtime.NewHub(realtime.Config{
+				ClientBuffer:          cfg.Realtime.ClientBuffer,
+				MaxConnectionsPerUser: cfg.Realtime.MaxConnectionsPerUser,
+				ReplayLimit:           cfg.Realtime.ReplayLimit,
+			}, service.NewACLLoader(folderRepo, noteRepo, shareRepo, cacheService), noteRepo, teamRepo, assetEventRepo)
+			if err := subscribeRealtime(eventBus, realtimeHub); err != nil {
+				log.Printf("Failed to subscribe realtime hub to events: %v", err)
+			}
+		}
+	}
+
+	// Serve authorization user lookups from cache unless caching is disabled
+	_, cacheDisabled := cacheService.(*noOpCacheService)
+	if !cacheDisabled {
+		userRepo = service.NewCacheIntegratedUserRepository(userRepo, cacheService)
+	}
 
 	// Initialize services with event bus and cache
-	folderService := service.NewFolderService(folderRepo, shareRepo, eventBus)
+	folderService := service.NewFolderService(folderRepo, shareRepo, txManager, eventBus)
 	noteService := service.NewNoteService(noteRepo, folderRepo, shareRepo, eventBus)
 	shareService := service.NewShareService(shareRepo, folderRepo, noteRepo, userRepo, eventBus)
 	managerService := service.NewManagerService(userRepo, teamRepo, folderRepo, noteRepo, shareRepo)
-	teamService := service.NewTeamService(teamRepo, userRepo, eventBus)
+	teamService := service.NewTeamService(teamRepo, userRepo, txManager, eventBus)
+	webhookService := service.NewWebhookService(webhookRepo, teamRepo)
+
+	// Front the services with cache-integrated decorators unless caching is disabled
+	if !cacheDisabled {
+		aclLoader := service.NewACLLoader(folderRepo, noteRepo, shareRepo, cacheService)
+		folderService = service.NewCacheIntegratedFolderService(folderService, folderRepo, cacheService, aclLoader)
+		noteService = service.NewCacheIntegratedNoteService(noteService, noteRepo, cacheService, aclLoader)
+		shareService = service.NewCacheIntegratedShareService(shareService, cacheService, aclLoader)
+		teamService = service.NewCacheIntegratedTeamService(teamService, cacheService)
+		log.Println("Cache-integrated services enabled")
+	}
 
 	// Initialize handlers
 	folderHandler := handler.NewFolderHandler(folderService)
@@ -113,12 +345,139 @@ func main() {
 	shareHandler := handler.NewShareHandler(shareService)
 	managerHandler := handler.NewManagerHandler(managerService)
 	teamHandler := handler.NewTeamHandler(teamService)
+	cacheHandler := handler.NewCacheHandler(cacheService)
+	deadLetterHandler := handler.NewDeadLetterHandler(deadLetterRedriver)
+	consumerHandler := handler.NewConsumerHandler(consumptionController)
+	ipFilter := initializeIPFilter(&cfg.IPFilter, redisClient)
+	ipFilterHandler := handler.NewIPFilterHandler(ipFilter)
+	maintenanceMode := initializeMaintenance(&cfg.Maintenance, redisClient)
+	maintenanceHandler := handler.NewMaintenanceHandler(maintenanceMode)
+	webhookHandler := handler.NewWebhookHandler(webhookService)
+	subscriptionHandler := handler.NewSubscriptionHandler(service.NewSubscriptionService(subscriptionRepo, folderService, noteService))
+	auditHandler := handler.NewAuditHandler(apiAuditLog)
+	backupHandler := handler.NewBackupHandler(backupJob, exporter)
+	folderHandlerV2 := handlerV2.NewFolderHandler(folderService)
+	noteHandlerV2 := handlerV2.NewNoteHandler(noteService)
+	shareHandlerV2 := handlerV2.NewShareHandler(shareService)
+	teamHandlerV2 := handlerV2.NewTeamHandler(teamService)
+	graphqlHandler := handler.NewGraphQLHandler(graph.NewServer(graph.Services{
+		Folders: folderService,
+		Notes:   noteService,
+		Shares:  shareService,
+		Teams:   teamService,
+	}))
+	var debugHandler *handler.DebugHandler
+	if cfg.Debug.Enabled {
+		runtime.SetBlockProfileRate(cfg.Debug.BlockProfileRate)
+		runtime.SetMutexProfileFraction(cfg.Debug.MutexProfileFraction)
+		debugHandler = handler.NewDebugHandler()
+	}
+	var batchHandler *handler.BatchHandler
+	if cfg.Batch.Enabled {
+		batchHandler = handler.NewBatchHandler(cfg.Batch.MaxRequests)
+	}
+	var realtimeHandler *handler.RealtimeHandler
+	if realtimeHub != nil {
+		realtimeHandler = handler.NewRealtimeHandler(realtimeHub, cfg.Realtime.Heartbeat, cfg.Realtime.AllowedOrigins)
+	}
+	healthHandler := handler.NewHealthHandler(initializeHealthChecker(&cfg.Health, db, redisClient, eventBus))
 
 	// Initialize middleware
 	authMiddleware := middleware.NewAuthMiddleware(jwtUtil)
+	catalog := initializeCatalog(&cfg.I18n)
+	rateLimit := initializeRateLimit(&cfg.RateLimit, redisClient)
+	var rateLimitMiddleware gin.HandlerFunc
+	if rateLimit != nil {
+		rateLimitMiddleware = rateLimit.Middleware()
+	}
+	quotas := initializeQuotas(&cfg.Quota, redisClient)
+	quotaHandler := handler.NewQuotaHandler(quotas)
+	var quotaMiddleware gin.HandlerFunc
+	if quotas != nil {
+		quotaMiddleware = quotas.Middleware()
+	}
+	var ipFilterMiddleware gin.HandlerFunc
+	if ipFilter != nil {
+		ipFilterMiddleware = ipFilter.Middleware()
+	}
+	var auditMiddleware gin.HandlerFunc
+	if apiAuditLog != nil {
+		auditMiddleware = apiAuditLog.Middleware()
+	}
+	var otelMiddleware gin.HandlerFunc
+	if shutdownTracing != nil {
+		otelMiddleware = middleware.OTelMiddleware(cfg.Tracing.ServiceName)
+	}
+	var responseCaching *middleware.ResponseCaching
+	var responseCacheMiddleware gin.HandlerFunc
+	if responseCache != nil {
+		responseCaching = middleware.NewResponseCaching(responseCache, responseCachePolicy(&cfg.ResponseCache))
+		responseCacheMiddleware = responseCaching.Middleware()
+	}
+	var bodyLimitMiddleware gin.HandlerFunc
+	if cfg.BodyLimit.Enabled {
+		bodyLimitMiddleware = middleware.BodyLimitMiddleware(middleware.BodyLimitPolicy{
+			Default: cfg.BodyLimit.Default,
+			Routes:  cfg.BodyLimit.Routes,
+		})
+	}
+	var concurrencyMiddleware gin.HandlerFunc
+	if cfg.Concurrency.Enabled {
+		routes := cfg.Concurrency.Routes
+		if realtimeHandler != nil {
+			// Event streams stay open for as long as clients are connected and
+			// are capped per user by the hub instead
+			routes[realtimeStreamRoute] = 0
+			routes[realtimeSocketRoute] = 0
+		}
+		if cfg.Batch.Enabled {
+			// A batch waiting for slots for its requests while holding one
+			// could starve them; each of its requests takes a slot instead
+			routes[batchRoute] = 0
+		}
+		concurrencyMiddleware = middleware.ConcurrencyLimitMiddleware(middleware.ConcurrencyPolicy{
+			Default:      cfg.Concurrency.Default,
+			Routes:       routes,
+			QueueTimeout: cfg.Concurrency.QueueTimeout,
+		})
+	}
+	var compressionMiddleware gin.HandlerFunc
+	if cfg.Compression.Enabled {
+		compressionMiddleware = middleware.CompressionMiddleware(middleware.CompressionPolicy{
+			MinSize:      cfg.Compression.MinSize,
+			ContentTypes: cfg.Compression.ContentTypes,
+		})
+	}
+	hsts := middleware.StrictTransportSecurity(cfg.Security.HSTSMaxAge, cfg.Security.HSTSIncludeSubdomains, cfg.Security.HSTSPreload)
+	securityMiddleware := middleware.SecurityMiddleware(middleware.SecurityPolicy{
+		Default: middleware.SecurityHeaders{
+			StrictTransportSecurity: hsts,
+			ContentSecurityPolicy:   cfg.Security.ContentSecurityPolicy,
+			FrameOptions:            cfg.Security.FrameOptions,
+			ReferrerPolicy:          cfg.Security.ReferrerPolicy,
+		},
+		// Transport security is never relaxed
+		Relaxed: middleware.SecurityHeaders{
+			StrictTransportSecurity: hsts,
+			ContentSecurityPolicy:   cfg.Security.RelaxedContentSecurityPolicy,
+			FrameOptions:            cfg.Security.RelaxedFrameOptions,
+			ReferrerPolicy:          cfg.Security.RelaxedReferrerPolicy,
+		},
+		RelaxedRoutes: cfg.Security.RelaxedRoutes,
+	})
 
 	// Setup Gin router
-	router := setupRouter(folderHandler, noteHandler, shareHandler, managerHandler, teamHandler, authMiddleware, jwtUtil, cacheService)
+	logSampling := middleware.LogSamplingPolicy{
+		SuccessRate: cfg.Logging.SampleSuccessRate,
+		ErrorRate:   cfg.Logging.SampleErrorRate,
+		Routes:      cfg.Logging.SampleRoutes,
+	}
+	router := setupRouter(appLogger, logSampling, errorReporter, folderHandler, noteHandler, shareHandler, managerHandler, teamHandler, cacheHandler, deadLetterHandler, consumerHandler, ipFilterHandler, maintenanceHandler, quotaHandler, webhookHandler, subscriptionHandler, realtimeHandler, batchHandler, debugHandler, cfg.Debug.AllowedUserIDs, auditHandler, backupHandler, graphqlHandler, folderHandlerV2, noteHandlerV2, shareHandlerV2, teamHandlerV2, healthHandler, authMiddleware, middleware.LanguageMiddleware(catalog), middleware.ErrorFormatMiddleware(cfg.Server.ErrorFormat == "problem"), maintenanceMode.Middleware(), rateLimitMiddleware, quotaMiddleware, responseCacheMiddleware, otelMiddleware, compressionMiddleware, securityMiddleware, ipFilterMiddleware, bodyLimitMiddleware, concurrencyMiddleware, auditMiddleware, jwtUtil, cfg.JWT.TestLoginEnabled, cacheService, eventBus)
+	// Without trusted proxies the client IP is the remote address, so the IP
+	// filter and rate limiter cannot be bypassed with X-Forwarded-For
+	if err := router.SetTrustedProxies(cfg.Server.TrustedProxies); err != nil {
+		log.Fatalf("Invalid SERVER_TRUSTED_PROXIES: %v", err)
+	}
 
 	// Create HTTP server
 	server := &http.Server{
@@ -127,11 +486,59 @@ func main() {
 		ReadTimeout:  cfg.Server.ReadTimeout,
 		WriteTimeout: cfg.Server.WriteTimeout,
 	}
+	if realtimeHub != nil {
+		// Open event streams never go idle; end them so Shutdown can finish
+		server.RegisterOnShutdown(realtimeHub.Close)
+	}
+
+	// gRPC API for internal services, through the same services as REST
+	var grpcServer *grpc.Server
+	if cfg.Server.GRPCPort != "" {
+		grpcServer = grpcapi.NewServer(jwtUtil, grpcapi.Services{
+			Folders: folderService,
+			Notes:   noteService,
+			Shares:  shareService,
+			Teams:   teamService,
+		})
+	}
 
 	// Setup graceful shutdown
 	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
 	defer stop()
 
+	// Apply configuration changes on SIGHUP
+	configHolder.OnReload(func(cfg *config.Config) {
+		applyConfigReload(cfg, appLogger, rateLimit, quotas, responseCaching, catalog)
+	})
+	go configHolder.WatchSignals(ctx, func(err error) {
+		if err != nil {
+			middleware.LogError(err, map[string]interface{}{
+				"component": "config",
+				"action":    "reload",
+			})
+		}
+	})
+
+	// Pick up deny list entries added on other instances
+	if ipFilter != nil && redisClient != nil && cfg.IPFilter.RefreshInterval > 0 {
+		go ipFilter.Watch(ctx, cfg.IPFilter.RefreshInterval)
+	}
+
+	// Pick up maintenance mode switched on other instances
+	if redisClient != nil && cfg.Maintenance.RefreshInterval > 0 {
+		go maintenanceMode.Watch(ctx, cfg.Maintenance.RefreshInterval)
+	}
+
+	// Sample the connection pool into the db_connections_* gauges
+	if cfg.Database.StatsInterval > 0 {
+		go database.SampleStats(ctx, db, cfg.Database.StatsInterval)
+	}
+
+	// Pick up database credentials rotated in the secret store
+	if cfg.Database.CredentialsRotationInterval > 0 && (cfg.Database.UserSecret != "" || cfg.Database.PasswordSecret != "") {
+		go database.RotateCredentials(ctx, dbCredentials, dbCredentialsSource(cfg), cfg.Database.CredentialsRotationInterval)
+	}
+
 	// Start server in a goroutine
 	go func() {
 		middleware.LogInfo("Server starting", map[string]interface{}{
@@ -151,6 +558,25 @@ func main() {
 		}
 	}()
 
+	if grpcServer != nil {
+		listener, err := net.Listen("tcp", ":"+cfg.Server.GRPCPort)
+		if err != nil {
+			log.Fatalf("Failed to listen on GRPC_PORT: %v", err)
+		}
+		go func() {
+			middleware.LogInfo("gRPC server starting", map[string]interface{}{
+				"port": cfg.Server.GRPCPort,
+			})
+			if err := grpcServer.Serve(listener); err != nil {
+				middleware.LogError(err, map[string]interface{}{
+					"component": "grpc_server",
+					"action":    "start",
+				})
+				log.Fatalf("Failed to start gRPC server: %v", err)
+			}
+		}()
+	}
+
 	// Wait for interrupt signal
 	<-ctx.Done()
 	
@@ -166,11 +592,57 @@ func main() {
 		log.Printf("Server forced to shutdown: %v", err)
 	}
 
-	// Close event bus
+	// Let in-flight gRPC calls finish within the same deadline
+	if grpcServer != nil {
+		stopped := make(chan struct{})
+		go func() {
+			grpcServer.GracefulStop()
+			close(stopped)
+		}()
+		select {
+		case <-stopped:
+		case <-shutdownCtx.Done():
+			log.Println("gRPC server forced to shutdown")
+			grpcServer.Stop()
+		}
+	}
+
+	// Write the audit records of the last requests
+	if apiAuditLog != nil {
+		if err := apiAuditLog.Close(); err != nil {
+			log.Printf("Error closing API audit log: %v", err)
+		}
+	}
+
+	if err := retentionJob.Close(); err != nil {
+		log.Printf("Error closing retention job: %v", err)
+	}
+
+	if backupJob != nil {
+		// Running export jobs are queued again for the other instances
+		if err := exporter.Close(); err != nil {
+			log.Printf("Error closing export workers: %v", err)
+		}
+		if err := backupJob.Close(); err != nil {
+			log.Printf("Error closing backup job: %v", err)
+		}
+	}
+
+	// Drain the event bus: in-flight Kafka handlers get a bounded period to
+	// finish and commit their offsets before the consumers close
 	if eventBus != nil {
-		if err := eventBus.Close(); err != nil {
+		drainCtx, drainCancel := context.WithTimeout(context.Background(), cfg.Kafka.ConsumerDrainTimeout)
+		if err := shutdownEventBus(drainCtx, eventBus); err != nil {
 			log.Printf("Error closing event bus: %v", err)
 		}
+		drainCancel()
+	}
+
+	// Stop webhook delivery once no more events arrive
+	if webhookDispatcher != nil {
+		if err := webhookDispatcher.Close(); err != nil {
+			log.Printf("Error closing webhook dispatcher: %v", err)
+		}
 	}
 
 	// NEW: Close cache service
@@ -180,15 +652,30 @@ func main() {
 		}
 	}
 
+	// Flush the spans of the last requests and events
+	if shutdownTracing != nil {
+		tracingCtx, tracingCancel := context.WithTimeout(context.Background(), 5*time.Second)
+		if err := shutdownTracing(tracingCtx); err != nil {
+			log.Printf("Error flushing traces: %v", err)
+		}
+		tracingCancel()
+	}
+
+	// Send the errors of the last requests
+	if errorReporter != nil && !errorReporter.Flush(2*time.Second) {
+		log.Println("Timed out sending reported errors")
+	}
+
 	log.Println("Server exited")
 }
 
 // NEW: Initialize Redis cache
-func initializeRedisCache(cfg *config.RedisConfig) (cacheInterface.CacheService, error) {
+func initializeRedisCache(cfg *config.RedisConfig, cacheCfg *config.CacheConfig) (cacheInterface.CacheService, *redisCache.RedisClient, error) {
 	// Convert config to Redis config
 	redisConfig := &redisCache.RedisConfig{
 		Host:               cfg.Host,
 		Port:               cfg.Port,
+		Username:           cfg.Username,
 		Password:           cfg.Password,
 		Database:           cfg.Database,
 		PoolSize:           cfg.PoolSize,
@@ -202,42 +689,522 @@ func initializeRedisCache(cfg *config.RedisConfig) (cacheInterface.CacheService,
 		ReadTimeout:        cfg.ReadTimeout,
 		WriteTimeout:       cfg.WriteTimeout,
 		DialTimeout:        cfg.DialTimeout,
+
+		Compression:          cacheCfg.Compression,
+		CompressionThreshold: cacheCfg.CompressionThreshold,
+
+		CallBudget:              cfg.CallBudget,
+		BreakerFailureThreshold: cfg.BreakerFailureThreshold,
+		BreakerOpenTimeout:      cfg.BreakerOpenTimeout,
+
+		TLS: redisCache.RedisTLSConfig{
+			Enabled:            cfg.TLSEnabled,
+			CAFile:             cfg.TLSCAFile,
+			CertFile:           cfg.TLSCertFile,
+			KeyFile:            cfg.TLSKeyFile,
+			ServerName:         cfg.TLSServerName,
+			InsecureSkipVerify: cfg.TLSInsecureSkipVerify,
+		},
 	}
 
 	// Create Redis client
 	redisClient, err := redisCache.NewRedisClient(redisConfig)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	// Create cache service
-	return redisCache.NewRedisCacheService(redisClient), nil
+	cacheService := redisCache.NewRedisCacheService(redisClient, cacheInterface.NewKeyNamespace(cacheCfg.KeyVersion))
+
+	// Agree on the key namespace version with other instances
+	syncCtx, syncCancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer syncCancel()
+	if _, err := cacheService.SyncKeyVersion(syncCtx, cacheCfg.KeyVersion); err != nil {
+		log.Printf("Failed to sync cache key version, using configured v%d: %v", cacheCfg.KeyVersion, err)
+	}
+	cacheService.WatchKeyVersion(cacheCfg.KeyVersionRefresh)
+
+	// Convert team member keys left behind by the List-based implementation
+	migrateCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	if migrated, err := cacheService.MigrateTeamMemberKeys(migrateCtx); err != nil {
+		log.Printf("Failed to migrate team member cache keys: %v", err)
+	} else if migrated > 0 {
+		log.Printf("Migrated %d team member cache keys from lists to sets", migrated)
+	}
+
+	// Optionally front Redis with an in-process cache kept coherent via pub/sub
+	if cacheCfg.L1Enabled {
+		invalidator := redisCache.NewRedisInvalidator(redisClient, cacheCfg.InvalidationChannel)
+		tieredService, err := tieredCache.NewTieredCacheService(cacheService, invalidator, cacheCfg.L1TTL, cacheCfg.L1MaxEntries)
+		if err != nil {
+			log.Printf("Failed to enable L1 cache, using Redis only: %v", err)
+			return cacheService, redisClient, nil
+		}
+		return tieredService, redisClient, nil
+	}
+
+	return cacheService, redisClient, nil
+}
+
+// Initialize memcached cache
+func initializeMemcachedCache(cfg *config.MemcachedConfig, cacheCfg *config.CacheConfig) (cacheInterface.CacheService, error) {
+	memcachedConfig := &memcachedCache.MemcachedConfig{
+		Servers:      cfg.Servers,
+		Timeout:      cfg.Timeout,
+		MaxIdleConns: cfg.MaxIdleConns,
+
+		Compression:          cacheCfg.Compression,
+		CompressionThreshold: cacheCfg.CompressionThreshold,
+	}
+
+	memcachedClient, err := memcachedCache.NewMemcachedClient(memcachedConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	cacheService := memcachedCache.NewMemcachedCacheService(memcachedClient, cacheInterface.NewKeyNamespace(cacheCfg.KeyVersion))
+
+	// Agree on the key namespace version with other instances
+	if _, err := cacheService.SyncKeyVersion(context.Background(), cacheCfg.KeyVersion); err != nil {
+		log.Printf("Failed to sync cache key version, using configured v%d: %v", cacheCfg.KeyVersion, err)
+	}
+	cacheService.WatchKeyVersion(cacheCfg.KeyVersionRefresh)
+
+	return cacheService, nil
 }
 
-// NEW: Subscribe to Kafka events for cache invalidation
-func subscribeToEvents(eventBus eventbus.EventBus, handler *cache.CacheEventHandler) error {
+// NEW: Subscribe to Kafka events for cache invalidation, the asset event
+// store, subscription notifications and, when the dispatcher is set, webhook
+// delivery
+func subscribeToEvents(eventBus eventbus.EventBus, handler *cache.CacheEventHandler, dispatcher *webhook.Dispatcher, recorder *store.AssetEventRecorder, notifier *notification.Notifier, activityRecorder *store.UserActivityRecorder) error {
 	ctx := context.Background()
+
+	teamHandler := eventbus.EventHandler(handler.HandleTeamEvent)
+	assetHandler := eventbus.FanOut(handler.HandleAssetEvent, recorder.HandleAssetEvent, notifier.HandleAssetEvent)
+	if dispatcher != nil {
+		teamHandler = eventbus.FanOut(teamHandler, dispatcher.HandleTeamEvent)
+		assetHandler = eventbus.FanOut(assetHandler, dispatcher.HandleAssetEvent)
+	}
 	
 	// Subscribe to team events
-	if err := eventBus.Subscribe(ctx, "team.activity", handler.HandleTeamEvent); err != nil {
+	if err := eventBus.Subscribe(ctx, "team.activity", teamHandler); err != nil {
 		return fmt.Errorf("failed to subscribe to team events: %w", err)
 	}
 	
 	// Subscribe to asset events
-	if err := eventBus.Subscribe(ctx, "asset.changes", handler.HandleAssetEvent); err != nil {
+	if err := eventBus.Subscribe(ctx, "asset.changes", assetHandler); err != nil {
 		return fmt.Errorf("failed to subscribe to asset events: %w", err)
 	}
 	
+	// Subscribe to user events
+	if err := eventBus.Subscribe(ctx, "user.changes", handler.HandleUserEvent); err != nil {
+		return fmt.Errorf("failed to subscribe to user events: %w", err)
+	}
+
+	// Subscribe to user activity, the audit trail
+	if err := eventBus.Subscribe(ctx, "user.activity", activityRecorder.HandleActivityEvent); err != nil {
+		return fmt.Errorf("failed to subscribe to user activity events: %w", err)
+	}
+	
 	log.Println("Successfully subscribed to Kafka events for cache invalidation")
 	return nil
 }
 
+// subscribeRealtime feeds the realtime hub. Each instance streams to its own
+// clients and so needs every event, not the share its consumer group assigns.
+func subscribeRealtime(eventBus eventbus.EventBus, hub *realtime.Hub) error {
+	ctx := context.Background()
+
+	subscribe := eventBus.Subscribe
+	if broadcaster, ok := eventBus.(eventbus.BroadcastSubscriber); ok {
+		subscribe = broadcaster.SubscribeBroadcast
+	}
+
+	if err := subscribe(ctx, "team.activity", hub.HandleTeamEvent); err != nil {
+		return fmt.Errorf("failed to subscribe to team events: %w", err)
+	}
+	if err := subscribe(ctx, "asset.changes", hub.HandleAssetEvent); err != nil {
+		return fmt.Errorf("failed to subscribe to asset events: %w", err)
+	}
+	if err := subscribe(ctx, "user.notifications", hub.HandleNotificationEvent); err != nil {
+		return fmt.Errorf("failed to subscribe to notification events: %w", err)
+	}
+	return nil
+}
+
+// initializeRateLimit builds the rate limiter; it returns nil when there is
+// no Redis to count requests in. Without Redis rate limiting cannot be
+// enabled by a reload either.
+func initializeRateLimit(cfg *config.RateLimitConfig, redisClient *redisCache.RedisClient) *middleware.RateLimit {
+	if redisClient == nil {
+		log.Println("Rate limiting requires the Redis cache backend, rate limiting disabled")
+		return nil
+	}
+
+	policy, err := rateLimitPolicy(cfg)
+	if err != nil {
+		log.Fatalf("Invalid rate limit configuration: %v", err)
+	}
+	if policy == nil {
+		log.Println("Rate limiting disabled")
+	}
+	return middleware.NewRateLimit(redisCache.NewRedisRateLimiter(redisClient), policy)
+}
+
+// rateLimitPolicy parses the configured limits; it returns nil when rate
+// limiting is disabled
+func rateLimitPolicy(cfg *config.RateLimitConfig) (*middleware.RateLimitPolicy, error) {
+	if !cfg.Enabled {
+		return nil, nil
+	}
+
+	defaultLimit, err := ratelimit.ParseLimit(cfg.Default)
+	if err != nil {
+		return nil, fmt.Errorf("invalid RATE_LIMIT_DEFAULT: %w", err)
+	}
+	roles, err := ratelimit.ParseLimits(cfg.Roles)
+	if err != nil {
+		return nil, fmt.Errorf("invalid RATE_LIMIT_ROLES: %w", err)
+	}
+	routes, err := ratelimit.ParseLimits(cfg.Routes)
+	if err != nil {
+		return nil, fmt.Errorf("invalid RATE_LIMIT_ROUTES: %w", err)
+	}
+
+	return &middleware.RateLimitPolicy{
+		Default: defaultLimit,
+		Roles:   roles,
+		Routes:  routes,
+	}, nil
+}
+
+// initializeQuotas sets up the per-role API call quotas; it returns nil when
+// Redis is not available to count calls in
+func initializeQuotas(cfg *config.QuotaConfig, redisClient *redisCache.RedisClient) *middleware.Quotas {
+	if redisClient == nil {
+		log.Println("API quotas require the Redis cache backend, API quotas disabled")
+		return nil
+	}
+	if !cfg.Enabled {
+		log.Println("API quotas disabled")
+	}
+	return middleware.NewQuotas(redisCache.NewRedisQuotaTracker(redisClient), quotaPolicy(cfg))
+}
+
+// quotaPolicy builds the quotas of each role from the defaults and the role
+// overrides; it returns nil when quotas are disabled
+func quotaPolicy(cfg *config.QuotaConfig) *middleware.QuotaPolicy {
+	if !cfg.Enabled {
+		return nil
+	}
+
+	defaults := quota.Quota{Daily: cfg.DailyDefault, Monthly: cfg.MonthlyDefault}
+	roles := make(map[string]quota.Quota)
+	for role, calls := range cfg.Daily {
+		q, ok := roles[role]
+		if !ok {
+			q = defaults
+		}
+		q.Daily = calls
+		roles[role] = q
+	}
+	for role, calls := range cfg.Monthly {
+		q, ok := roles[role]
+		if !ok {
+			q = defaults
+		}
+		q.Monthly = calls
+		roles[role] = q
+	}
+
+	return &middleware.QuotaPolicy{
+		Default: defaults,
+		Roles:   roles,
+	}
+}
+
+// responseCachePolicy returns the cached routes; nil when response caching is
+// disabled
+func responseCachePolicy(cfg *config.ResponseCacheConfig) *middleware.ResponseCachePolicy {
+	if !cfg.Enabled {
+		return nil
+	}
+	return &middleware.ResponseCachePolicy{
+		Routes: cfg.Routes,
+		TTL:    cfg.TTL,
+	}
+}
+
+// applyConfigReload applies the settings that can change without a restart:
+// the log level, rate limits, quotas, response caching and message catalogs.
+// Other settings take effect on the next restart.
+func applyConfigReload(cfg *config.Config, appLogger *logrus.Logger, rateLimit *middleware.RateLimit, quotas *middleware.Quotas, responseCaching *middleware.ResponseCaching, catalog *i18n.Catalog) {
+	if level, err := logrus.ParseLevel(cfg.Logging.Level); err == nil {
+		appLogger.SetLevel(level)
+	}
+
+	if rateLimit != nil {
+		policy, err := rateLimitPolicy(&cfg.RateLimit)
+		if err != nil {
+			middleware.LogError(err, map[string]interface{}{
+				"component": "config",
+				"action":    "reload",
+			})
+		} else {
+			rateLimit.SetPolicy(policy)
+		}
+	}
+	if quotas != nil {
+		quotas.SetPolicy(quotaPolicy(&cfg.Quota))
+	}
+	if responseCaching != nil {
+		responseCaching.SetPolicy(responseCachePolicy(&cfg.ResponseCache))
+	}
+	if err := catalog.Load(cfg.I18n.CatalogDir); err != nil {
+		middleware.LogError(err, map[string]interface{}{
+			"component": "i18n",
+			"action":    "reload",
+		})
+	}
+
+	middleware.LogInfo("Configuration reloaded", map[string]interface{}{
+		"log_level":              cfg.Logging.Level,
+		"rate_limit_enabled":     cfg.RateLimit.Enabled,
+		"quota_enabled":          cfg.Quota.Enabled,
+		"response_cache_enabled": cfg.ResponseCache.Enabled,
+		"languages":              catalog.Languages(),
+	})
+}
+
+// initializeCatalog loads the message catalogs. Without them, messages are
+// answered in the default language.
+func initializeCatalog(cfg *config.I18nConfig) *i18n.Catalog {
+	catalog := i18n.NewCatalog(cfg.DefaultLanguage)
+	if err := catalog.Load(cfg.CatalogDir); err != nil {
+		log.Printf("Failed to load message catalogs, answering in %s: %v", cfg.DefaultLanguage, err)
+		return catalog
+	}
+	log.Printf("Message catalogs loaded (%s)", strings.Join(catalog.Languages(), ", "))
+	return catalog
+}
+
+// initializeIPFilter builds the IP filter from the configured networks and
+// loads its deny list; it returns nil when IP filtering is disabled. Without
+// Redis, deny list entries only apply to the instance they are added on.
+func initializeIPFilter(cfg *config.IPFilterConfig, redisClient *redisCache.RedisClient) *middleware.IPFilter {
+	if !cfg.Enabled {
+		log.Println("IP filtering disabled")
+		return nil
+	}
+
+	allowlist, err := ipfilter.ParsePrefixes(cfg.Allowlist)
+	if err != nil {
+		log.Fatalf("Invalid IP_ALLOWLIST: %v", err)
+	}
+	denylist, err := ipfilter.ParsePrefixes(cfg.Denylist)
+	if err != nil {
+		log.Fatalf("Invalid IP_DENYLIST: %v", err)
+	}
+
+	var store ipfilter.Store = ipfilter.NewMemoryStore()
+	if redisClient != nil {
+		store = redisCache.NewRedisDenyList(redisClient)
+	} else {
+		log.Println("IP deny list is not shared without the Redis cache backend")
+	}
+
+	filter := middleware.NewIPFilter(middleware.IPFilterPolicy{
+		Allowlist:       allowlist,
+		AllowlistRoutes: cfg.AllowlistRoutes,
+		Denylist:        denylist,
+	}, store)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := filter.Refresh(ctx); err != nil {
+		// The watcher retries; until then only the configured deny list applies
+		log.Printf("Failed to load IP deny list: %v", err)
+	}
+
+	log.Printf("IP filtering enabled (%d allowlisted, %d denied networks)", len(allowlist), len(denylist))
+	return filter
+}
+
+// initializeMaintenance creates the maintenance mode switch and loads its
+// state. Without Redis, maintenance mode only applies to the instance it is
+// switched on at.
+func initializeMaintenance(cfg *config.MaintenanceConfig, redisClient *redisCache.RedisClient) *middleware.Maintenance {
+	var store maintenance.Store = maintenance.NewMemoryStore()
+	if redisClient != nil {
+		store = redisCache.NewRedisMaintenanceStore(redisClient)
+	} else {
+		log.Println("Maintenance mode is not shared without the Redis cache backend")
+	}
+
+	m := middleware.NewMaintenance(store, cfg.Message, cfg.RetryAfter)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := m.Refresh(ctx); err != nil {
+		// The watcher retries; until then the instance serves traffic
+		log.Printf("Failed to load maintenance state: %v", err)
+	}
+	if m.State() != nil {
+		log.Println("Starting in maintenance mode")
+	}
+	return m
+}
+
+// initializeLogger builds the application logger from configuration and
+// makes it the logger of the middleware's Log* helpers; the returned
+// function closes the log file
+func initializeLogger(cfg *config.LoggingConfig) (*logrus.Logger, func() error) {
+	logger, closeLogger, err := logging.New(logging.Config{
+		Level:   cfg.Level,
+		Format:  cfg.Format,
+		Outputs: cfg.Outputs,
+		File: logging.FileConfig{
+			Path:           cfg.FilePath,
+			MaxSizeMB:      cfg.FileMaxSizeMB,
+			MaxBackups:     cfg.FileMaxBackups,
+			MaxAge:         cfg.FileMaxAge,
+			RotateInterval: cfg.FileRotateInterval,
+			Compress:       cfg.FileCompress,
+		},
+	})
+	if err != nil {
+		log.Fatalf("Failed to set up logging: %v", err)
+	}
+
+	middleware.SetLogger(logger)
+	return logger, closeLogger
+}
+
+// dbCredentialsSource reads the database user and password again from the
+// secret references they were configured with
+func dbCredentialsSource(cfg *config.Config) database.CredentialsSource {
+	resolver := config.NewSecretResolver(&cfg.Secrets)
+	return func(ctx context.Context) (string, string, error) {
+		user, password := cfg.Database.User, cfg.Database.Password
+		if cfg.Database.UserSecret != "" {
+			user = cfg.Database.UserSecret
+		}
+		if cfg.Database.PasswordSecret != "" {
+			password = cfg.Database.PasswordSecret
+		}
+		err := resolver.Resolve(ctx, &user, &password)
+		return user, password, err
+	}
+}
+
+// initializeHealthChecker sets up the readiness checks of the dependencies
+// in use: Postgres, and Redis and the Kafka brokers when enabled
+func initializeHealthChecker(cfg *config.HealthConfig, db *gorm.DB, redisClient *redisCache.RedisClient, eventBus eventbus.EventBus) *health.Checker {
+	critical := func(name string) bool {
+		return slices.Contains(cfg.CriticalDependencies, name)
+	}
+
+	checks := []health.Check{{
+		Name:     "database",
+		Critical: critical("database"),
+		Probe: func(ctx context.Context) error {
+			sqlDB, err := db.DB()
+			if err != nil {
+				return err
+			}
+			return sqlDB.PingContext(ctx)
+		},
+	}}
+	if redisClient != nil {
+		checks = append(checks, health.Check{
+			Name:     "redis",
+			Critical: critical("redis"),
+			Probe:    redisClient.Ping,
+		})
+	}
+	if brokers, ok := eventBus.(interface{ CheckBrokers(context.Context) error }); ok {
+		checks = append(checks, health.Check{
+			Name:     "kafka",
+			Critical: critical("kafka"),
+			Probe:    brokers.CheckBrokers,
+		})
+	}
+	return health.NewChecker(cfg.ReadinessTimeout, checks...)
+}
+
+// initializeErrorReporting connects to the error tracking service; it
+// returns nil when error reporting is disabled
+func initializeErrorReporting(cfg *config.ErrorReportingConfig) errreport.Reporter {
+	if !cfg.Enabled {
+		log.Println("Error reporting disabled")
+		return nil
+	}
+
+	reporter, err := errorReporting.NewSentryReporter(errorReporting.SentryConfig{
+		DSN:         cfg.DSN,
+		Environment: cfg.Environment,
+		Release:     cfg.Release,
+		SampleRate:  cfg.SampleRate,
+	})
+	if err != nil {
+		log.Fatalf("Failed to initialize error reporting: %v", err)
+	}
+
+	middleware.LogInfo("Error reporting initialized", map[string]interface{}{
+		"environment": cfg.Environment,
+		"release":     cfg.Release,
+		"sample_rate": cfg.SampleRate,
+	})
+	return reporter
+}
+
+// initializeTracing sets up the export of OpenTelemetry spans; it returns the
+// function flushing them on shutdown, or nil when tracing is disabled
+func initializeTracing(cfg *config.TracingConfig) func(context.Context) error {
+	if !cfg.Enabled {
+		log.Println("OpenTelemetry tracing disabled")
+		return nil
+	}
+
+	shutdown, err := tracing.Setup(context.Background(), tracing.ExporterConfig{
+		ServiceName:    cfg.ServiceName,
+		ServiceVersion: "1.0.0",
+		Endpoint:       cfg.Endpoint,
+		SampleRatio:    cfg.SampleRatio,
+	})
+	if err != nil {
+		log.Fatalf("Failed to initialize tracing: %v", err)
+	}
+
+	middleware.LogInfo("OpenTelemetry tracing initialized", map[string]interface{}{
+		"endpoint":     cfg.Endpoint,
+		"sample_ratio": cfg.SampleRatio,
+	})
+	return shutdown
+}
+
+// shutdownEventBus drains buses that support a deadline, and closes the others
+func shutdownEventBus(ctx context.Context, bus eventbus.EventBus) error {
+	if drainer, ok := bus.(interface{ Shutdown(context.Context) error }); ok {
+		return drainer.Shutdown(ctx)
+	}
+	return bus.Close()
+}
+
 // Initialize Kafka event bus
-func initializeKafka(cfg *config.Config) (eventbus.EventBus, error) {
+func initializeKafka(cfg *config.Config) (*kafka.KafkaEventBus, error) {
+	retry := kafka.RetryPolicy{
+		MaxAttempts: cfg.Kafka.ConsumerMaxRetries,
+		Backoff:     cfg.Kafka.ConsumerRetryBackoff,
+		Multiplier:  cfg.Kafka.ConsumerRetryMultiplier,
+		MaxBackoff:  cfg.Kafka.ConsumerRetryMaxBackoff,
+		Jitter:      cfg.Kafka.ConsumerRetryJitter,
+	}
+
 	// Create Kafka configuration
 	kafkaConfig := &kafka.KafkaConfig{
 		Brokers: cfg.Kafka.Brokers,
 		ProducerConfig: kafka.ProducerConfig{
+			Name:             cfg.Kafka.ProducerName,
 			RetryMax:         cfg.Kafka.ProducerRetryMax,
 			RequiredAcks:     cfg.Kafka.ProducerRequiredAcks,
 			FlushTimeout:     cfg.Kafka.ProducerFlushTimeout,
@@ -245,34 +1212,107 @@ func initializeKafka(cfg *config.Config) (eventbus.EventBus, error) {
 			FlushMessages:    100,
 			CompressionType:  "snappy",
 			IdempotentWrites: true,
+			Async:            cfg.Kafka.ProducerAsync,
+			AsyncQueueSize:   cfg.Kafka.ProducerQueueSize,
+			AsyncWorkers:     cfg.Kafka.ProducerWorkers,
+			OverflowPolicy:   cfg.Kafka.ProducerOverflowPolicy,
+			EnqueueTimeout:   cfg.Kafka.ProducerEnqueueTimeout,
+			DrainTimeout:     cfg.Kafka.ProducerDrainTimeout,
+
+			BreakerFailureThreshold: cfg.Kafka.ProducerBreakerFailureThreshold,
+			BreakerOpenTimeout:      cfg.Kafka.ProducerBreakerOpenTimeout,
 		},
 		ConsumerConfig: kafka.ConsumerConfig{
 			GroupID:            cfg.Kafka.ConsumerGroupID,
 			SessionTimeout:     cfg.Kafka.ConsumerSessionTimeout,
 			HeartbeatInterval:  3 * time.Second,
 			RebalanceTimeout:   60 * time.Second,
-			AutoCommit:         true,
+			AutoCommit:         cfg.Kafka.AutoCommit,
 			AutoCommitInterval: cfg.Kafka.AutoCommitInterval,
+			ManualCommitTopics: cfg.Kafka.ManualCommitTopics,
+			Retry:              retry,
+			TopicRetry:         kafka.ParseTopicRetryPolicies(cfg.Kafka.ConsumerTopicRetry, retry),
+			DeadLetterEnabled:  cfg.Kafka.DeadLetterEnabled,
+			Concurrency:        cfg.Kafka.ConsumerConcurrency,
+			TopicConcurrency:   cfg.Kafka.ConsumerTopicConcurrency,
+			DrainTimeout:       cfg.Kafka.ConsumerDrainTimeout,
+		},
+		Serialization: cfg.Kafka.Serialization,
+		SchemaRegistry: kafka.SchemaRegistryConfig{
+			URL:          cfg.Kafka.SchemaRegistryURL,
+			Username:     cfg.Kafka.SchemaRegistryUsername,
+			Password:     cfg.Kafka.SchemaRegistryPassword,
+			AutoRegister: cfg.Kafka.SchemaRegistryAutoRegister,
+			Timeout:      cfg.Kafka.SchemaRegistryTimeout,
+		},
+		CloudEvents: kafka.CloudEventsConfig{
+			Enabled:    cfg.Kafka.CloudEventsEnabled,
+			Topics:     cfg.Kafka.CloudEventsTopics,
+			Source:     cfg.Kafka.CloudEventsSource,
+			TypePrefix: cfg.Kafka.CloudEventsTypePrefix,
+		},
+		TLS: kafka.TLSConfig{
+			Enabled:            cfg.Kafka.TLSEnabled,
+			CAFile:             cfg.Kafka.TLSCAFile,
+			CertFile:           cfg.Kafka.TLSCertFile,
+			KeyFile:            cfg.Kafka.TLSKeyFile,
+			ServerName:         cfg.Kafka.TLSServerName,
+			InsecureSkipVerify: cfg.Kafka.TLSInsecureSkipVerify,
+		},
+		SASL: kafka.SASLConfig{
+			Mechanism: cfg.Kafka.SASLMechanism,
+			Username:  cfg.Kafka.SASLUsername,
+			Password:  cfg.Kafka.SASLPassword,
+		},
+		HealthCheck: kafka.HealthCheckConfig{
+			Interval: cfg.Kafka.HealthCheckInterval,
+			Timeout:  cfg.Kafka.HealthCheckTimeout,
+		},
+		Topics: kafka.TopicsConfig{
+			AutoCreate:        cfg.Kafka.TopicsAutoCreate,
+			Names:             cfg.Kafka.Topics,
+			Partitions:        cfg.Kafka.TopicPartitions,
+			TopicPartitions:   cfg.Kafka.TopicPartitionsByTopic,
+			ReplicationFactor: cfg.Kafka.TopicReplicationFactor,
+			Retention:         cfg.Kafka.TopicRetention,
 		},
 	}
 
-	// Create producer
-	producer := kafka.NewKafkaProducer(kafkaConfig)
-	
-	// Test connectivity by creating a dummy writer
-	testCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
+	// Create event bus; its consumer dead-letters through the same producer
+	bus, err := kafka.NewKafkaEventBus(kafkaConfig)
+	if err != nil {
+		return nil, err
+	}
 	
-	// Try to publish a test message to validate connectivity
-	if err := producer.Publish(testCtx, "test.connectivity", map[string]string{
-		"test": "connectivity",
-		"timestamp": time.Now().Format(time.RFC3339),
-	}); err != nil {
+	// Check connectivity with a metadata request instead of publishing
+	if err := bus.CheckBrokers(context.Background()); err != nil {
+		bus.Close()
 		return nil, err
 	}
+	if kafkaConfig.Topics.AutoCreate {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		err := bus.ProvisionTopics(ctx)
+		cancel()
+		if err != nil {
+			log.Printf("Kafka topic provisioning failed: %v", err)
+		}
+	}
+	bus.StartBrokerMonitor()
 
-	log.Println("Kafka connectivity test successful")
-	return producer, nil
+	log.Println("Kafka connectivity check successful")
+	return bus, nil
+}
+
+// initializeLocalEventBus returns the in-process event bus used without
+// Kafka, or a no-op bus when it is disabled
+func initializeLocalEventBus(cfg *config.Config) eventbus.EventBus {
+	if !cfg.Kafka.InMemoryEnabled {
+		log.Println("In-memory event bus disabled, using no-op event bus")
+		return &noOpEventBus{}
+	}
+
+	log.Printf("Using in-memory event bus (buffer size %d)", cfg.Kafka.InMemoryBufferSize)
+	return memory.NewInMemoryEventBus(cfg.Kafka.ProducerName, cfg.Kafka.InMemoryBufferSize)
 }
 
 // NEW: No-op cache service for fallback
@@ -280,20 +1320,34 @@ type noOpCacheService struct{}
 
 func (n *noOpCacheService) CacheTeamMembers(ctx context.Context, teamID uuid.UUID, members []uuid.UUID) error { return nil }
 func (n *noOpCacheService) GetTeamMembers(ctx context.Context, teamID uuid.UUID) ([]uuid.UUID, error) { return nil, nil }
+func (n *noOpCacheService) IsTeamMember(ctx context.Context, teamID, userID uuid.UUID) (bool, bool, error) { return false, false, nil }
 func (n *noOpCacheService) AddTeamMember(ctx context.Context, teamID, memberID uuid.UUID) error { return nil }
 func (n *noOpCacheService) RemoveTeamMember(ctx context.Context, teamID, memberID uuid.UUID) error { return nil }
 func (n *noOpCacheService) InvalidateTeamMembers(ctx context.Context, teamID uuid.UUID) error { return nil }
+func (n *noOpCacheService) CacheUserTeams(ctx context.Context, userID uuid.UUID, teams []*models.Team) error { return nil }
+func (n *noOpCacheService) GetUserTeams(ctx context.Context, userID uuid.UUID) ([]*models.Team, error) { return nil, nil }
+func (n *noOpCacheService) InvalidateUserTeams(ctx context.Context, userID uuid.UUID) error { return nil }
+func (n *noOpCacheService) CacheUserProfile(ctx context.Context, user *models.User) error { return nil }
+func (n *noOpCacheService) GetUserProfile(ctx context.Context, userID uuid.UUID) (*models.User, error) { return nil, nil }
+func (n *noOpCacheService) InvalidateUserProfile(ctx context.Context, userID uuid.UUID) error { return nil }
 func (n *noOpCacheService) CacheFolderMetadata(ctx context.Context, folder *models.Folder) error { return nil }
 func (n *noOpCacheService) GetFolderMetadata(ctx context.Context, folderID uuid.UUID) (*models.Folder, error) { return nil, nil }
 func (n *noOpCacheService) CacheNoteMetadata(ctx context.Context, note *models.Note) error { return nil }
 func (n *noOpCacheService) GetNoteMetadata(ctx context.Context, noteID uuid.UUID) (*models.Note, error) { return nil, nil }
+func (n *noOpCacheService) GetFolderMetadataBatch(ctx context.Context, folderIDs []uuid.UUID) (map[uuid.UUID]*models.Folder, error) { return map[uuid.UUID]*models.Folder{}, nil }
+func (n *noOpCacheService) GetNoteMetadataBatch(ctx context.Context, noteIDs []uuid.UUID) (map[uuid.UUID]*models.Note, error) { return map[uuid.UUID]*models.Note{}, nil }
 func (n *noOpCacheService) InvalidateFolderMetadata(ctx context.Context, folderID uuid.UUID) error { return nil }
 func (n *noOpCacheService) InvalidateNoteMetadata(ctx context.Context, noteID uuid.UUID) error { return nil }
 func (n *noOpCacheService) CacheAssetACL(ctx context.Context, assetID uuid.UUID, acl map[string]string) error { return nil }
+func (n *noOpCacheService) ReplaceAssetACL(ctx context.Context, assetID uuid.UUID, acl map[string]string) error { return nil }
 func (n *noOpCacheService) GetAssetACL(ctx context.Context, assetID uuid.UUID) (map[string]string, error) { return nil, nil }
 func (n *noOpCacheService) UpdateAssetACL(ctx context.Context, assetID, userID uuid.UUID, accessLevel string) error { return nil }
 func (n *noOpCacheService) RemoveAssetACL(ctx context.Context, assetID, userID uuid.UUID) error { return nil }
 func (n *noOpCacheService) InvalidateAssetACL(ctx context.Context, assetID uuid.UUID) error { return nil }
+func (n *noOpCacheService) KeyVersion() int64 { return 0 }
+func (n *noOpCacheService) BumpKeyVersion(ctx context.Context) (int64, error) { return 0, nil }
+func (n *noOpCacheService) InspectKey(ctx context.Context, key string) (*cacheInterface.KeyInfo, error) { return nil, nil }
+func (n *noOpCacheService) Stats(ctx context.Context) (*cacheInterface.CacheStats, error) { return nil, cacheInterface.ErrNotSupported }
 func (n *noOpCacheService) HealthCheck() map[string]interface{} { return map[string]interface{}{"status": "disabled"} }
 func (n *noOpCacheService) Close() error { return nil }
 
@@ -305,6 +1359,11 @@ func (n *noOpEventBus) Publish(ctx context.Context, topic string, event interfac
 	return nil
 }
 
+func (n *noOpEventBus) PublishBatch(ctx context.Context, topic string, events []interface{}) error {
+	log.Printf("No-op event bus: would publish %d events to topic %s", len(events), topic)
+	return nil
+}
+
 func (n *noOpEventBus) Subscribe(ctx context.Context, topic string, handler eventbus.EventHandler) error {
 	log.Printf("No-op event bus: would subscribe to topic %s", topic)
 	return nil
@@ -315,14 +1374,52 @@ func (n *noOpEventBus) Close() error {
 }
 
 func setupRouter(
+	logger *logrus.Logger,
+	logSampling middleware.LogSamplingPolicy,
+	errorReporter errreport.Reporter,
 	folderHandler *handler.FolderHandler,
 	noteHandler *handler.NoteHandler,
 	shareHandler *handler.ShareHandler,
 	managerHandler *handler.ManagerHandler,
 	teamHandler *handler.TeamHandler,
+	cacheHandler *handler.CacheHandler,
+	deadLetterHandler *handler.DeadLetterHandler,
+	consumerHandler *handler.ConsumerHandler,
+	ipFilterHandler *handler.IPFilterHandler,
+	maintenanceHandler *handler.MaintenanceHandler,
+	quotaHandler *handler.QuotaHandler,
+	webhookHandler *handler.WebhookHandler,
+	subscriptionHandler *handler.SubscriptionHandler,
+	realtimeHandler *handler.RealtimeHandler,
+	batchHandler *handler.BatchHandler,
+	debugHandler *handler.DebugHandler,
+	debugUserIDs []uuid.UUID,
+	auditHandler *handler.AuditHandler,
+	backupHandler *handler.BackupHandler,
+	graphqlHandler *handler.GraphQLHandler,
+	folderHandlerV2 *handlerV2.FolderHandler,
+	noteHandlerV2 *handlerV2.NoteHandler,
+	shareHandlerV2 *handlerV2.ShareHandler,
+	teamHandlerV2 *handlerV2.TeamHandler,
+	healthHandler *handler.HealthHandler,
 	authMiddleware *middleware.AuthMiddleware,
+	languageMiddleware gin.HandlerFunc,
+	errorFormatMiddleware gin.HandlerFunc,
+	maintenanceMiddleware gin.HandlerFunc,
+	rateLimitMiddleware gin.HandlerFunc,
+	quotaMiddleware gin.HandlerFunc,
+	responseCacheMiddleware gin.HandlerFunc,
+	otelMiddleware gin.HandlerFunc,
+	compressionMiddleware gin.HandlerFunc,
+	securityMiddleware gin.HandlerFunc,
+	ipFilterMiddleware gin.HandlerFunc,
+	bodyLimitMiddleware gin.HandlerFunc,
+	concurrencyMiddleware gin.HandlerFunc,
+	auditMiddleware gin.HandlerFunc,
 	jwtUtil *utils.JWTUtil,
+	testLoginEnabled bool,
 	cacheService cacheInterface.CacheService, // NEW: Added cache service
+	eventBus eventbus.EventBus,
 ) *gin.Engine {
 	// Set Gin mode
 	gin.SetMode(gin.ReleaseMode)
@@ -330,15 +1427,52 @@ func setupRouter(
 	router := gin.New()
 
 	// Global middleware - Order matters!
-	router.Use(middleware.RecoveryMiddleware())
-	router.Use(middleware.StructuredLoggingMiddleware())
-	router.Use(middleware.RequestResponseLoggingMiddleware())
+	router.Use(middleware.RecoveryMiddleware(errorReporter))
+	router.Use(middleware.RequestIDMiddleware())
+	router.Use(languageMiddleware)
+	router.Use(errorFormatMiddleware)
+	if otelMiddleware != nil {
+		router.Use(otelMiddleware)
+	}
+	router.Use(middleware.TracingMiddleware())
+	if errorReporter != nil {
+		router.Use(middleware.ErrorReportingMiddleware(errorReporter))
+	}
+	// Audited ahead of the filters so rejected requests are recorded too
+	if auditMiddleware != nil {
+		router.Use(auditMiddleware)
+	}
+	router.Use(middleware.StructuredLoggingMiddleware(logger))
+	// Oversized bodies are turned away before the request logger reads them
+	if bodyLimitMiddleware != nil {
+		router.Use(bodyLimitMiddleware)
+	}
+	router.Use(middleware.RequestResponseLoggingMiddleware(logger, logSampling))
 	router.Use(middleware.PrometheusMiddleware())
 	router.Use(middleware.CORSMiddleware())
-	router.Use(middleware.SecurityMiddleware())
+	router.Use(securityMiddleware)
+	if ipFilterMiddleware != nil {
+		router.Use(ipFilterMiddleware)
+	}
+	if compressionMiddleware != nil {
+		router.Use(compressionMiddleware)
+	}
 
 	// Metrics endpoint for Prometheus
-	router.GET("/metrics", gin.WrapH(promhttp.Handler()))
+	// OpenMetrics is the format that carries the latency exemplars
+	router.GET("/metrics", gin.WrapH(promhttp.InstrumentMetricHandler(
+		prometheus.DefaultRegisterer,
+		promhttp.HandlerFor(prometheus.DefaultGatherer, promhttp.HandlerOpts{EnableOpenMetrics: true}),
+	)))
+
+	// Kubernetes probes
+	router.GET("/live", healthHandler.Live)
+	router.GET("/ready", healthHandler.Ready)
+
+	// Documentation of the problem types, which their type URIs point at
+	problemHandler := handler.NewProblemHandler()
+	router.GET(utils.ProblemTypesPath, problemHandler.ListProblemTypes)
+	router.GET(utils.ProblemTypesPath+"/:code", problemHandler.GetProblemType)
 
 	// Health check endpoint with enhanced monitoring
 	router.GET("/health", func(c *gin.Context) {
@@ -349,6 +1483,13 @@ func setupRouter(
 			"status":    "healthy",
 			"cache":     cacheService.HealthCheck(), // NEW: Include cache health
 		}
+		if checker, ok := eventBus.(interface{ HealthCheck() map[string]interface{} }); ok {
+			events := checker.HealthCheck()
+			healthData["events"] = events
+			if events["status"] == "unhealthy" {
+				healthData["status"] = "degraded"
+			}
+		}
 
 		middleware.LogInfo("Health check performed", map[string]interface{}{
 			"endpoint":  "/health",
@@ -358,39 +1499,58 @@ func setupRouter(
 		utils.SuccessResponse(c, http.StatusOK, "Server is healthy", healthData)
 	})
 
-	// Test login endpoint for debugging (REMOVE IN PRODUCTION)
-	router.POST("/test/login", func(c *gin.Context) {
-		testUserID := uuid.New()
-		token, err := jwtUtil.GenerateToken(testUserID, "test@example.com", "manager", "testuser")
-		if err != nil {
-			middleware.LogError(err, map[string]interface{}{
-				"component": "jwt",
-				"action":    "generate_test_token",
-				"user_id":   testUserID,
-			})
-			utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to generate token", err.Error())
-			return
-		}
+	// Test login endpoint for local development; Validate refuses it in production
+	if testLoginEnabled {
+		router.POST("/test/login", func(c *gin.Context) {
+			testUserID := uuid.New()
+			token, err := jwtUtil.GenerateToken(testUserID, "test@example.com", "manager", "testuser")
+			if err != nil {
+				middleware.LogError(err, map[string]interface{}{
+					"component": "jwt",
+					"action":    "generate_test_token",
+					"user_id":   testUserID,
+				})
+				utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to generate token", err.Error())
+				return
+			}
 
-		// Record JWT generation
-		middleware.RecordJWTGenerated()
-		
-		middleware.LogBusinessEvent("test_login", map[string]interface{}{
-			"user_id":  testUserID,
-			"username": "testuser",
-			"role":     "manager",
-		})
+			// Record JWT generation
+			middleware.RecordJWTGenerated()
+			
+			middleware.LogBusinessEvent("test_login", map[string]interface{}{
+				"user_id":  testUserID,
+				"username": "testuser",
+				"role":     "manager",
+			})
 
-		utils.SuccessResponse(c, http.StatusOK, "Test token generated", gin.H{
-			"token":      token,
-			"user_id":    testUserID,
-			"expires_in": "24h",
+			utils.SuccessResponse(c, http.StatusOK, "Test token generated", gin.H{
+				"token":      token,
+				"user_id":    testUserID,
+				"expires_in": "24h",
+			})
 		})
-	})
+	}
 
 	// API v1 routes with authentication
 	v1 := router.Group("/api/v1")
+	// Shed load before spending anything on the request
+	if concurrencyMiddleware != nil {
+		v1.Use(concurrencyMiddleware)
+	}
 	v1.Use(authMiddleware.RequireAuth())
+	// Managers get through to run the maintenance
+	v1.Use(maintenanceMiddleware)
+	if rateLimitMiddleware != nil {
+		v1.Use(rateLimitMiddleware)
+	}
+	if quotaMiddleware != nil {
+		v1.Use(quotaMiddleware)
+	}
+	// ?fields= is checked before a cached response is served for it
+	v1.Use(middleware.SparseFieldsetsMiddleware())
+	if responseCacheMiddleware != nil {
+		v1.Use(responseCacheMiddleware)
+	}
 	{
 		// Folder management routes
 		folders := v1.Group("/folders")
@@ -439,6 +1599,63 @@ func setupRouter(
 			// Team manager management
 			teams.POST("/:teamId/managers", enhanceHandler(teamHandler.AddManager, "add_team_manager"))
 			teams.DELETE("/:teamId/managers/:managerId", enhanceHandler(teamHandler.RemoveManager, "remove_team_manager"))
+
+			// Team webhooks
+			teams.POST("/:teamId/webhooks", enhanceHandler(webhookHandler.RegisterWebhook, "register_webhook"))
+			teams.GET("/:teamId/webhooks", enhanceHandler(webhookHandler.ListWebhooks, "list_webhooks"))
+			teams.DELETE("/:teamId/webhooks/:webhookId", enhanceHandler(webhookHandler.DeleteWebhook, "delete_webhook"))
+			teams.GET("/:teamId/webhooks/:webhookId/deliveries", enhanceHandler(webhookHandler.GetDeliveries, "get_webhook_deliveries"))
+		}
+
+		// Webhooks of the teams the caller manages
+		webhooks := v1.Group("/webhooks")
+		{
+			webhooks.POST("", enhanceHandler(webhookHandler.CreateWebhook, "create_webhook"))
+			webhooks.GET("", enhanceHandler(webhookHandler.ListManagedWebhooks, "list_managed_webhooks"))
+			webhooks.GET("/:webhookId", enhanceHandler(webhookHandler.GetWebhook, "get_webhook"))
+			webhooks.PATCH("/:webhookId", enhanceHandler(webhookHandler.UpdateWebhook, "update_webhook"))
+			webhooks.DELETE("/:webhookId", enhanceHandler(webhookHandler.DeleteWebhookByID, "delete_webhook_by_id"))
+			webhooks.GET("/:webhookId/deliveries", enhanceHandler(webhookHandler.GetWebhookDeliveries, "get_webhook_delivery_history"))
+			webhooks.GET("/:webhookId/secret", enhanceHandler(webhookHandler.GetWebhookSecret, "get_webhook_secret"))
+			webhooks.POST("/:webhookId/secret/rotate", enhanceHandler(webhookHandler.RotateWebhookSecret, "rotate_webhook_secret"))
+		}
+
+		// Team exports built in the background, see backup.Exporter
+		exports := v1.Group("/exports")
+		{
+			exports.POST("", enhanceHandler(backupHandler.CreateExport, "create_export"))
+			exports.GET("", enhanceHandler(backupHandler.ListExports, "list_exports"))
+			exports.GET("/:exportId", enhanceHandler(backupHandler.GetExport, "get_export"))
+		}
+
+		// Asset subscriptions and the notifications they produce
+		subscriptions := v1.Group("/subscriptions")
+		{
+			subscriptions.POST("", enhanceHandler(subscriptionHandler.Subscribe, "create_subscription"))
+			subscriptions.GET("", enhanceHandler(subscriptionHandler.ListSubscriptions, "list_subscriptions"))
+			subscriptions.DELETE("/:subscriptionId", enhanceHandler(subscriptionHandler.Unsubscribe, "delete_subscription"))
+		}
+
+		// Quota usage of the caller
+		v1.GET("/quota", enhanceHandler(quotaHandler.GetUsage, "get_quota_usage"))
+
+		notifications := v1.Group("/notifications")
+		{
+			notifications.GET("", enhanceHandler(subscriptionHandler.GetNotifications, "get_notifications"))
+			notifications.POST("/:notificationId/read", enhanceHandler(subscriptionHandler.MarkNotificationRead, "mark_notification_read"))
+		}
+
+		// Realtime asset and team events (Server-Sent Events), and the
+		// WebSocket that also delivers notifications
+		if realtimeHandler != nil {
+			v1.GET("/events/stream", enhanceHandler(realtimeHandler.StreamEvents, "stream_events"))
+			v1.GET("/ws", enhanceHandler(realtimeHandler.Connect, "realtime_socket"))
+		}
+
+		// Several requests in one round trip
+		if batchHandler != nil {
+			batchHandler.Mount(router)
+			v1.POST("/batch", enhanceHandler(batchHandler.Execute, "execute_batch"))
 		}
 
 		// Manager-only routes
@@ -447,6 +1664,137 @@ func setupRouter(
 		{
 			manager.GET("/teams/:teamId/assets", enhanceHandler(managerHandler.GetTeamAssets, "get_team_assets"))
 			manager.GET("/users/:userId/assets", enhanceHandler(managerHandler.GetUserAssets, "get_user_assets"))
+
+			// Cache administration
+			manager.GET("/admin/cache/version", enhanceHandler(cacheHandler.GetKeyVersion, "get_cache_key_version"))
+			manager.POST("/admin/cache/version/bump", enhanceHandler(cacheHandler.BumpKeyVersion, "bump_cache_key_version"))
+			manager.GET("/admin/cache/keys", enhanceHandler(cacheHandler.InspectKey, "inspect_cache_key"))
+			manager.GET("/admin/cache/stats", enhanceHandler(cacheHandler.GetStats, "get_cache_stats"))
+			manager.DELETE("/admin/cache/teams/:teamId", enhanceHandler(cacheHandler.FlushTeam, "flush_team_cache"))
+			manager.DELETE("/admin/cache/assets/:assetId", enhanceHandler(cacheHandler.FlushAsset, "flush_asset_cache"))
+
+			// Event administration
+			manager.POST("/admin/events/dlq/:topic/redrive", enhanceHandler(deadLetterHandler.Redrive, "redrive_dead_letters"))
+			manager.GET("/admin/events/consumers", enhanceHandler(consumerHandler.GetStatus, "get_consumer_status"))
+			manager.POST("/admin/events/consumers/:topic/pause", enhanceHandler(consumerHandler.Pause, "pause_consumer"))
+			manager.POST("/admin/events/consumers/:topic/resume", enhanceHandler(consumerHandler.Resume, "resume_consumer"))
+
+			// Network administration
+			manager.GET("/admin/network/denylist", enhanceHandler(ipFilterHandler.ListDenied, "list_denied_networks"))
+			manager.POST("/admin/network/denylist", enhanceHandler(ipFilterHandler.Deny, "deny_network"))
+			manager.DELETE("/admin/network/denylist", enhanceHandler(ipFilterHandler.Undeny, "undeny_network"))
+
+			// Maintenance mode
+			manager.GET("/admin/maintenance", enhanceHandler(maintenanceHandler.GetStatus, "get_maintenance_status"))
+			manager.PUT("/admin/maintenance", enhanceHandler(maintenanceHandler.Enable, "enable_maintenance"))
+			manager.DELETE("/admin/maintenance", enhanceHandler(maintenanceHandler.Disable, "disable_maintenance"))
+
+			// API audit log
+			manager.GET("/admin/audit", enhanceHandler(auditHandler.ListRequests, "list_api_audit"))
+
+			// Team backups
+			manager.POST("/admin/backups/teams/:teamId", enhanceHandler(backupHandler.ExportTeam, "export_team_backup"))
+		}
+	}
+
+	// API v2 routes, served next to v1 behind the same middleware
+	v2 := router.Group("/api/v2")
+	if concurrencyMiddleware != nil {
+		v2.Use(concurrencyMiddleware)
+	}
+	v2.Use(authMiddleware.RequireAuth())
+	v2.Use(maintenanceMiddleware)
+	if rateLimitMiddleware != nil {
+		v2.Use(rateLimitMiddleware)
+	}
+	if quotaMiddleware != nil {
+		v2.Use(quotaMiddleware)
+	}
+	if responseCacheMiddleware != nil {
+		v2.Use(responseCacheMiddleware)
+	}
+	{
+		folders := v2.Group("/folders")
+		{
+			folders.POST("", enhanceHandler(folderHandlerV2.CreateFolder, "create_folder"))
+			folders.GET("", enhanceHandler(folderHandlerV2.ListFolders, "get_user_folders"))
+			folders.GET("/:folderId", enhanceHandler(folderHandlerV2.GetFolder, "get_folder"))
+			folders.PATCH("/:folderId", enhanceHandler(folderHandlerV2.UpdateFolder, "update_folder"))
+			folders.DELETE("/:folderId", enhanceHandler(folderHandlerV2.DeleteFolder, "delete_folder"))
+
+			folders.POST("/:folderId/notes", enhanceHandler(noteHandlerV2.CreateNote, "create_note"))
+			folders.GET("/:folderId/notes", enhanceHandler(noteHandlerV2.ListFolderNotes, "get_folder_notes"))
+
+			folders.POST("/:folderId/shares", enhanceHandler(shareHandlerV2.CreateFolderShare, "share_folder"))
+			folders.GET("/:folderId/shares", enhanceHandler(shareHandlerV2.ListFolderShares, "get_folder_shares"))
+			folders.GET("/:folderId/shares/:userId", enhanceHandler(shareHandlerV2.GetFolderShare, "get_folder_share"))
+			folders.DELETE("/:folderId/shares/:userId", enhanceHandler(shareHandlerV2.DeleteFolderShare, "unshare_folder"))
+		}
+
+		notes := v2.Group("/notes")
+		{
+			notes.GET("", enhanceHandler(noteHandlerV2.ListNotes, "get_user_notes"))
+			notes.GET("/:noteId", enhanceHandler(noteHandlerV2.GetNote, "get_note"))
+			notes.PATCH("/:noteId", enhanceHandler(noteHandlerV2.UpdateNote, "update_note"))
+			notes.DELETE("/:noteId", enhanceHandler(noteHandlerV2.DeleteNote, "delete_note"))
+
+			notes.POST("/:noteId/shares", enhanceHandler(shareHandlerV2.CreateNoteShare, "share_note"))
+			notes.GET("/:noteId/shares", enhanceHandler(shareHandlerV2.ListNoteShares, "get_note_shares"))
+			notes.GET("/:noteId/shares/:userId", enhanceHandler(shareHandlerV2.GetNoteShare, "get_note_share"))
+			notes.DELETE("/:noteId/shares/:userId", enhanceHandler(shareHandlerV2.DeleteNoteShare, "unshare_note"))
+		}
+
+		teams := v2.Group("/teams")
+		{
+			teams.POST("", enhanceHandler(teamHandlerV2.CreateTeam, "create_team"))
+			teams.GET("", enhanceHandler(teamHandlerV2.ListTeams, "get_user_teams"))
+			teams.GET("/:teamId", enhanceHandler(teamHandlerV2.GetTeam, "get_team"))
+
+			teams.POST("/:teamId/members", enhanceHandler(teamHandlerV2.AddMember, "add_team_member"))
+			teams.GET("/:teamId/members", enhanceHandler(teamHandlerV2.ListMembers, "get_team_members"))
+			teams.GET("/:teamId/members/:userId", enhanceHandler(teamHandlerV2.GetMember, "get_team_member"))
+			teams.DELETE("/:teamId/members/:userId", enhanceHandler(teamHandlerV2.RemoveMember, "remove_team_member"))
+
+			teams.POST("/:teamId/managers", enhanceHandler(teamHandlerV2.AddManager, "add_team_manager"))
+			teams.GET("/:teamId/managers", enhanceHandler(teamHandlerV2.ListManagers, "get_team_managers"))
+			teams.GET("/:teamId/managers/:userId", enhanceHandler(teamHandlerV2.GetManager, "get_team_manager"))
+			teams.DELETE("/:teamId/managers/:userId", enhanceHandler(teamHandlerV2.RemoveManager, "remove_team_manager"))
+		}
+	}
+
+	// GraphQL API, behind the same middleware as v1 but the response cache
+	graphql := router.Group("/graphql")
+	if concurrencyMiddleware != nil {
+		graphql.Use(concurrencyMiddleware)
+	}
+	graphql.Use(authMiddleware.RequireAuth())
+	graphql.Use(maintenanceMiddleware)
+	if rateLimitMiddleware != nil {
+		graphql.Use(rateLimitMiddleware)
+	}
+	if quotaMiddleware != nil {
+		graphql.Use(quotaMiddleware)
+	}
+	{
+		graphql.GET("", enhanceHandler(graphqlHandler.Query, "graphql_query"))
+		graphql.POST("", enhanceHandler(graphqlHandler.Query, "graphql_query"))
+	}
+
+	// Profiling and runtime diagnostics, for the allowlisted users only
+	if debugHandler != nil {
+		debug := router.Group("/debug")
+		debug.Use(authMiddleware.RequireAuth(), authMiddleware.RequireUserIDs(debugUserIDs))
+		{
+			debug.GET("/runtime", debugHandler.GetRuntimeStats)
+			debug.GET("/pprof/", debugHandler.Index)
+			for _, profile := range []string{"allocs", "block", "goroutine", "heap", "mutex", "threadcreate"} {
+				debug.GET("/pprof/"+profile, debugHandler.Index)
+			}
+			debug.GET("/pprof/cmdline", debugHandler.Cmdline)
+			debug.GET("/pprof/profile", debugHandler.Profile)
+			debug.GET("/pprof/symbol", debugHandler.Symbol)
+			debug.POST("/pprof/symbol", debugHandler.Symbol)
+			debug.GET("/pprof/trace", debugHandler.Trace)
 		}
 	}
 
@@ -471,12 +1819,14 @@ func enhanceHandler(handler gin.HandlerFunc, operation string) gin.HandlerFunc {
 		// Get user context
 		userID, _ := middleware.GetUserIDFromContext(c)
 		userRole, _ := middleware.GetUserRoleFromContext(c)
+		requestID := middleware.GetRequestIDFromContext(c)
 		
 		// Log business operation start
 		middleware.LogBusinessEvent(operation+"_started", map[string]interface{}{
-			"user_id":   userID,
-			"user_role": userRole,
-			"operation": operation,
+			"user_id":    userID,
+			"user_role":  userRole,
+			"operation":  operation,
+			"request_id": requestID,
 		})
 
 		// Execute handler
@@ -497,8 +1847,14 @@ func enhanceHandler(handler gin.HandlerFunc, operation string) gin.HandlerFunc {
 			"status":      status,
 			"duration_ms": duration.Milliseconds(),
 			"http_status": c.Writer.Status(),
+			"request_id":  requestID,
 		})
 
+		// Publish the audit trail of mutating requests
+		if activityPublisher != nil {
+			activityPublisher.Record(c, operation, duration)
+		}
+
 		// Record business metrics
 		switch operation {
 		case "create_folder":
@@ -526,6 +1882,7 @@ func enhanceHandler(handler gin.HandlerFunc, operation string) gin.HandlerFunc {
 				"user_role":   userRole,
 				"http_status": c.Writer.Status(),
 				"endpoint":    c.FullPath(),
+				"request_id":  requestID,
 			})
 		}
 	}
@@ -0,0 +1,114 @@
+// Package audit publishes the audit trail of user requests.
+package audit
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"asset-management-api/internal/events/types"
+	"asset-management-api/internal/middleware"
+	"asset-management-api/pkg/eventbus"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	activityPublishedTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "user_activity_published_total",
+			Help: "Total number of user activity events published",
+		},
+		[]string{"result"},
+	)
+
+	activityPublishFailuresTotal = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "user_activity_publish_failures_total",
+			Help: "Total number of user activity events that could not be published",
+		},
+	)
+)
+
+// ActivityPublisher publishes a user.activity event for every mutating
+// request made by an authenticated user
+type ActivityPublisher struct {
+	eventBus eventbus.EventBus
+}
+
+// NewActivityPublisher creates a new activity publisher
+func NewActivityPublisher(eventBus eventbus.EventBus) *ActivityPublisher {
+	return &ActivityPublisher{eventBus: eventBus}
+}
+
+// Record publishes the activity of a handled request. Reads and requests
+// without an authenticated user are not recorded.
+func (p *ActivityPublisher) Record(c *gin.Context, operation string, duration time.Duration) {
+	if !isMutating(c.Request.Method) {
+		return
+	}
+	actorID, ok := middleware.GetUserIDFromContext(c)
+	if !ok {
+		return
+	}
+	actorRole, _ := middleware.GetUserRoleFromContext(c)
+
+	entityType, entityID, params := activityEntity(c)
+	status := c.Writer.Status()
+	event := &types.UserActivityEvent{
+		EventType:  types.UserActivity,
+		ActorID:    actorID,
+		ActorRole:  actorRole,
+		Operation:  operation,
+		Method:     c.Request.Method,
+		Endpoint:   c.FullPath(),
+		EntityType: entityType,
+		EntityID:   entityID,
+		Params:     params,
+		Result:     types.ActivityResult(status),
+		HTTPStatus: status,
+		ClientIP:   c.ClientIP(),
+		DurationMs: duration.Milliseconds(),
+		Timestamp:  time.Now().UTC(),
+	}
+
+	// The activity happened even if the client has gone away by now, so only
+	// the request's values (such as its trace) are kept
+	ctx := context.WithoutCancel(c.Request.Context())
+	if err := p.eventBus.Publish(ctx, types.UserActivityTopic, event); err != nil {
+		activityPublishFailuresTotal.Inc()
+		log.Printf("Failed to publish user activity %s of user %s: %v", operation, actorID, err)
+		return
+	}
+	activityPublishedTotal.WithLabelValues(event.Result).Inc()
+}
+
+func isMutating(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return false
+	}
+	return true
+}
+
+// activityEntity names the entity a request acted on. Routes address their
+// entity by their first path parameter, e.g. :folderId for
+// /folders/:folderId/share/:userId; routes without parameters create an
+// entity in the collection they end with.
+func activityEntity(c *gin.Context) (entityType, entityID string, params map[string]string) {
+	if len(c.Params) == 0 {
+		segments := strings.Split(strings.Trim(c.FullPath(), "/"), "/")
+		return strings.TrimSuffix(segments[len(segments)-1], "s"), "", nil
+	}
+
+	params = make(map[string]string, len(c.Params))
+	for _, param := range c.Params {
+		params[param.Key] = param.Value
+	}
+	first := c.Params[0]
+	return strings.TrimSuffix(first.Key, "Id"), first.Value, params
+}
@@ -0,0 +1,206 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"sync"
+	"time"
+
+	"asset-management-api/internal/events/types"
+	"asset-management-api/internal/middleware"
+	"asset-management-api/internal/models"
+	"asset-management-api/internal/repository/interfaces"
+	"asset-management-api/internal/tracing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	apiAuditRecordsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "api_audit_records_total",
+			Help: "Total number of API audit records by result (written, dropped, failed)",
+		},
+		[]string{"result"},
+	)
+
+	apiAuditQueueLength = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "api_audit_queue_length",
+			Help: "Number of API audit records waiting to be written",
+		},
+	)
+)
+
+// APIAuditConfig controls how audit records are buffered and batched
+type APIAuditConfig struct {
+	BufferSize    int
+	BatchSize     int
+	FlushInterval time.Duration
+}
+
+// APIAuditLog records every mutating API request in the api_audit table.
+// Unlike the user.activity trail it covers requests rejected before reaching
+// a handler, and it does not depend on the event bus. Records are written in
+// batches off the request path; when the buffer is full they are dropped
+// rather than slowing requests down.
+type APIAuditLog struct {
+	config APIAuditConfig
+	repo   interfaces.APIAuditRepository
+
+	queue chan *models.APIAudit
+	stop  chan struct{}
+	wg    sync.WaitGroup
+	once  sync.Once
+}
+
+// NewAPIAuditLog creates a new API audit log
+func NewAPIAuditLog(config APIAuditConfig, repo interfaces.APIAuditRepository) *APIAuditLog {
+	if config.BufferSize <= 0 {
+		config.BufferSize = 10000
+	}
+	if config.BatchSize <= 0 {
+		config.BatchSize = 100
+	}
+	if config.FlushInterval <= 0 {
+		config.FlushInterval = time.Second
+	}
+
+	return &APIAuditLog{
+		config: config,
+		repo:   repo,
+		queue:  make(chan *models.APIAudit, config.BufferSize),
+		stop:   make(chan struct{}),
+	}
+}
+
+// Start launches the writer
+func (l *APIAuditLog) Start() {
+	l.wg.Add(1)
+	go l.run()
+}
+
+// Middleware records the mutating requests passing through it. It must run
+// after the request ID and tracing middleware, and sees the authenticated
+// user once the handlers have run.
+func (l *APIAuditLog) Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !isMutating(c.Request.Method) {
+			c.Next()
+			return
+		}
+
+		start := time.Now()
+		c.Next()
+		l.record(c, start)
+	}
+}
+
+func (l *APIAuditLog) record(c *gin.Context, start time.Time) {
+	status := c.Writer.Status()
+	record := &models.APIAudit{
+		RequestID:  tracing.RequestID(c.Request.Context()),
+		Method:     c.Request.Method,
+		Route:      c.FullPath(),
+		Path:       c.Request.URL.Path,
+		Outcome:    types.ActivityResult(status),
+		HTTPStatus: status,
+		LatencyMs:  time.Since(start).Milliseconds(),
+		ClientIP:   c.ClientIP(),
+		TraceID:    tracing.TraceID(c.Request.Context()),
+		OccurredAt: start.UTC(),
+	}
+	if actorID, ok := middleware.GetUserIDFromContext(c); ok {
+		record.ActorID = &actorID
+		record.ActorRole, _ = middleware.GetUserRoleFromContext(c)
+	}
+	// Requests matching no route have no entity to name
+	if record.Route == "" {
+		record.Route = "unmatched"
+	} else {
+		var params map[string]string
+		record.EntityType, record.EntityID, params = activityEntity(c)
+		if len(params) > 0 {
+			record.Params, _ = json.Marshal(params)
+		}
+	}
+
+	select {
+	case l.queue <- record:
+		apiAuditQueueLength.Inc()
+	default:
+		apiAuditRecordsTotal.WithLabelValues("dropped").Inc()
+	}
+}
+
+// run writes queued records in batches, once a batch is full or the flush
+// interval has passed
+func (l *APIAuditLog) run() {
+	defer l.wg.Done()
+
+	flushTicker := time.NewTicker(l.config.FlushInterval)
+	defer flushTicker.Stop()
+
+	batch := make([]*models.APIAudit, 0, l.config.BatchSize)
+	for {
+		select {
+		case record := <-l.queue:
+			batch = append(batch, record)
+			if len(batch) >= l.config.BatchSize {
+				batch = l.flush(batch)
+			}
+		case <-flushTicker.C:
+			batch = l.flush(batch)
+		case <-l.stop:
+			// Write what was queued before the server stopped
+			for {
+				select {
+				case record := <-l.queue:
+					batch = append(batch, record)
+					if len(batch) >= l.config.BatchSize {
+						batch = l.flush(batch)
+					}
+				default:
+					l.flush(batch)
+					return
+				}
+			}
+		}
+	}
+}
+
+// flush writes the batch and returns it emptied. A failed batch is dropped:
+// retrying would hold up newer records while the database is unavailable.
+func (l *APIAuditLog) flush(batch []*models.APIAudit) []*models.APIAudit {
+	if len(batch) == 0 {
+		return batch
+	}
+	apiAuditQueueLength.Sub(float64(len(batch)))
+
+	if err := l.repo.AppendBatch(context.Background(), batch); err != nil {
+		apiAuditRecordsTotal.WithLabelValues("failed").Add(float64(len(batch)))
+		log.Printf("Failed to write %d API audit records: %v", len(batch), err)
+	} else {
+		apiAuditRecordsTotal.WithLabelValues("written").Add(float64(len(batch)))
+	}
+	return batch[:0]
+}
+
+// Query returns the recorded requests matching the filter, most recent
+// first. Records still buffered are not included.
+func (l *APIAuditLog) Query(ctx context.Context, filter models.APIAuditFilter) ([]*models.APIAudit, error) {
+	return l.repo.List(ctx, filter)
+}
+
+// Close stops the writer once the queued records are written. Call it after
+// the HTTP server has shut down.
+func (l *APIAuditLog) Close() error {
+	l.once.Do(func() {
+		close(l.stop)
+	})
+	l.wg.Wait()
+	return nil
+}
@@ -0,0 +1,347 @@
+package backup
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"path"
+	"sync"
+	"time"
+
+	"asset-management-api/internal/models"
+	"asset-management-api/internal/repository/interfaces"
+
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"gorm.io/gorm"
+)
+
+var backupExportsTotal = promauto.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "backup_exports_total",
+		Help: "Total number of team exports by trigger (request, schedule) and result (written, failed)",
+	},
+	[]string{"trigger", "result"},
+)
+
+// ErrTeamNotFound is returned when exporting a team that does not exist
+var ErrTeamNotFound = errors.New("team not found")
+
+// Config sets where exports are stored and how often every team is
+// exported; with no interval teams are only exported on request. Timeout
+// bounds each scheduled export.
+type Config struct {
+	Prefix   string
+	Interval time.Duration
+	Timeout  time.Duration
+}
+
+// Repositories are the repositories the exports are read from
+type Repositories struct {
+	Teams    interfaces.TeamRepository
+	Folders  interfaces.FolderRepository
+	Notes    interfaces.NoteRepository
+	Shares   interfaces.ShareRepository
+	Activity interfaces.UserActivityRepository
+}
+
+// Result describes a stored export
+type Result struct {
+	TeamID     uuid.UUID `json:"team_id"`
+	Location   string    `json:"location"`
+	ExportedAt time.Time `json:"exported_at"`
+	SizeBytes  int       `json:"size_bytes"`
+	Users      int       `json:"users"`
+	Folders    int       `json:"folders"`
+	Notes      int       `json:"notes"`
+	Shares     int       `json:"shares"`
+	Activity   int       `json:"activity"`
+}
+
+// Job exports teams to the store, on request and, with an interval, every
+// team on a schedule. Exports are not transactional: data changed while a
+// team is exported may be caught half-way.
+type Job struct {
+	config Config
+	repos  Repositories
+	store  Store
+
+	stop chan struct{}
+	wg   sync.WaitGroup
+	once sync.Once
+}
+
+// NewJob creates a new backup job
+func NewJob(config Config, repos Repositories, store Store) *Job {
+	if config.Timeout <= 0 {
+		config.Timeout = 5 * time.Minute
+	}
+
+	return &Job{
+		config: config,
+		repos:  repos,
+		store:  store,
+		stop:   make(chan struct{}),
+	}
+}
+
+// Start launches the scheduled exports, if an interval is set. The first
+// runs one interval after startup, so restarts do not export every time.
+func (j *Job) Start() {
+	if j.config.Interval <= 0 {
+		return
+	}
+
+	j.wg.Add(1)
+	go j.run()
+	log.Printf("Backup job started, exporting every team every %s", j.config.Interval)
+}
+
+func (j *Job) run() {
+	defer j.wg.Done()
+
+	ticker := time.NewTicker(j.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			j.exportAll()
+		case <-j.stop:
+			return
+		}
+	}
+}
+
+// exportAll exports every team; a failed team does not stop the others
+func (j *Job) exportAll() {
+	teamIDs, err := j.repos.Teams.GetAllIDs(context.Background())
+	if err != nil {
+		log.Printf("Failed to list teams to back up: %v", err)
+		return
+	}
+
+	for _, teamID := range teamIDs {
+		select {
+		case <-j.stop:
+			return
+		default:
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), j.config.Timeout)
+		result, err := j.export(ctx, teamID, "schedule")
+		cancel()
+		if err != nil {
+			log.Printf("Failed to back up team %s: %v", teamID, err)
+			continue
+		}
+		log.Printf("Backed up team %s to %s (%d bytes)", teamID, result.Location, result.SizeBytes)
+	}
+}
+
+// ExportTeam exports a team now
+func (j *Job) ExportTeam(ctx context.Context, teamID uuid.UUID) (*Result, error) {
+	return j.export(ctx, teamID, "request")
+}
+
+func (j *Job) export(ctx context.Context, teamID uuid.UUID, trigger string) (*Result, error) {
+	export, err := j.build(ctx, teamID, nil)
+	if err == nil {
+		var result *Result
+		key := path.Join(j.config.Prefix, "teams", teamID.String(), export.ExportedAt.Format("20060102T150405Z")+".json")
+		if result, err = j.write(ctx, key, export); err == nil {
+			backupExportsTotal.WithLabelValues(trigger, "written").Inc()
+			return result, nil
+		}
+	}
+	backupExportsTotal.WithLabelValues(trigger, "failed").Inc()
+	return nil, err
+}
+
+// build reads the data of the team, reporting to progress, when not nil, the
+// steps done out of the total as it goes: one per user whose folders are
+// read, and one for the activity
+func (j *Job) build(ctx context.Context, teamID uuid.UUID, progress func(done, total int)) (*TeamExport, error) {
+	team, err := j.repos.Teams.GetByID(ctx, teamID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrTeamNotFound
+		}
+		return nil, fmt.Errorf("failed to get team: %w", err)
+	}
+
+	export := &TeamExport{
+		FormatVersion: FormatVersion,
+		ExportedAt:    time.Now().UTC(),
+		Team: Team{
+			TeamID:    team.TeamID,
+			TeamName:  team.TeamName,
+			CreatedBy: team.CreatedBy,
+			Version:   team.Version,
+			CreatedAt: team.CreatedAt,
+			UpdatedAt: team.UpdatedAt,
+			Managers:  []uuid.UUID{},
+			Members:   []uuid.UUID{},
+		},
+		Users:    []User{},
+		Folders:  []Folder{},
+		Activity: []*models.UserActivityLog{},
+	}
+
+	// A manager may also be a member; users are listed once
+	var userIDs []uuid.UUID
+	seen := make(map[uuid.UUID]bool)
+	addUser := func(user models.User) {
+		if seen[user.UserID] {
+			return
+		}
+		seen[user.UserID] = true
+		userIDs = append(userIDs, user.UserID)
+		export.Users = append(export.Users, User{
+			UserID:    user.UserID,
+			Username:  user.Username,
+			Email:     user.Email,
+			Role:      user.Role,
+			CreatedAt: user.CreatedAt,
+		})
+	}
+	for _, manager := range team.Managers {
+		export.Team.Managers = append(export.Team.Managers, manager.UserID)
+		addUser(manager)
+	}
+	for _, member := range team.Members {
+		export.Team.Members = append(export.Team.Members, member.UserID)
+		addUser(member)
+	}
+
+	report := func(done int) {
+		if progress != nil {
+			progress(done, len(userIDs)+1)
+		}
+	}
+	for i, userID := range userIDs {
+		report(i)
+		folders, err := j.repos.Folders.GetByOwnerID(ctx, userID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get folders of user %s: %w", userID, err)
+		}
+		for _, folder := range folders {
+			exported, err := j.folder(ctx, folder)
+			if err != nil {
+				return nil, err
+			}
+			export.Folders = append(export.Folders, *exported)
+		}
+	}
+
+	report(len(userIDs))
+	if len(userIDs) > 0 {
+		activity, err := j.repos.Activity.ListByActorIDs(ctx, userIDs)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get user activity: %w", err)
+		}
+		export.Activity = append(export.Activity, activity...)
+	}
+	report(len(userIDs) + 1)
+	return export, nil
+}
+
+func (j *Job) folder(ctx context.Context, folder *models.Folder) (*Folder, error) {
+	folderShares, err := j.repos.Shares.GetFolderShares(ctx, folder.FolderID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get shares of folder %s: %w", folder.FolderID, err)
+	}
+	exported := &Folder{
+		FolderID:    folder.FolderID,
+		Name:        folder.Name,
+		Description: folder.Description,
+		OwnerID:     folder.OwnerID,
+		Version:     folder.Version,
+		CreatedAt:   folder.CreatedAt,
+		UpdatedAt:   folder.UpdatedAt,
+		Shares:      []Share{},
+		Notes:       []Note{},
+	}
+	for _, share := range folderShares {
+		exported.Shares = append(exported.Shares, Share{
+			UserID:      share.SharedWithUserID,
+			AccessLevel: share.AccessLevel,
+			SharedBy:    share.SharedBy,
+			CreatedAt:   share.CreatedAt,
+		})
+	}
+
+	notes, err := j.repos.Notes.GetByFolderID(ctx, folder.FolderID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get notes of folder %s: %w", folder.FolderID, err)
+	}
+	for _, note := range notes {
+		noteShares, err := j.repos.Shares.GetNoteShares(ctx, note.NoteID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get shares of note %s: %w", note.NoteID, err)
+		}
+		exportedNote := Note{
+			NoteID:    note.NoteID,
+			Title:     note.Title,
+			Body:      note.Body,
+			OwnerID:   note.OwnerID,
+			Version:   note.Version,
+			CreatedAt: note.CreatedAt,
+			UpdatedAt: note.UpdatedAt,
+			Shares:    []Share{},
+		}
+		for _, share := range noteShares {
+			exportedNote.Shares = append(exportedNote.Shares, Share{
+				UserID:      share.SharedWithUserID,
+				AccessLevel: share.AccessLevel,
+				SharedBy:    share.SharedBy,
+				CreatedAt:   share.CreatedAt,
+			})
+		}
+		exported.Notes = append(exported.Notes, exportedNote)
+	}
+	return exported, nil
+}
+
+// write stores the export under key
+func (j *Job) write(ctx context.Context, key string, export *TeamExport) (*Result, error) {
+	body, err := json.Marshal(export)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode export: %w", err)
+	}
+
+	location, err := j.store.Put(ctx, key, body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to store export: %w", err)
+	}
+
+	result := &Result{
+		TeamID:     export.Team.TeamID,
+		Location:   location,
+		ExportedAt: export.ExportedAt,
+		SizeBytes:  len(body),
+		Users:      len(export.Users),
+		Folders:    len(export.Folders),
+		Activity:   len(export.Activity),
+	}
+	for _, folder := range export.Folders {
+		result.Notes += len(folder.Notes)
+		result.Shares += len(folder.Shares)
+		for _, note := range folder.Notes {
+			result.Shares += len(note.Shares)
+		}
+	}
+	return result, nil
+}
+
+// Close stops the scheduled exports, waiting for the team being exported
+func (j *Job) Close() error {
+	j.once.Do(func() {
+		close(j.stop)
+	})
+	j.wg.Wait()
+	return nil
+}
@@ -0,0 +1,352 @@
+package backup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"path"
+	"sync"
+	"time"
+
+	"asset-management-api/internal/models"
+	"asset-management-api/internal/repository/interfaces"
+
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"gorm.io/gorm"
+)
+
+var exportJobsTotal = promauto.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "backup_export_jobs_total",
+		Help: "Total number of export jobs by result (succeeded, failed, requeued)",
+	},
+	[]string{"result"},
+)
+
+// Errors of export requests
+var (
+	ErrExportNotFound = errors.New("export not found")
+	ErrNotTeamManager = errors.New("only the managers of a team can export it")
+)
+
+// writeProgress is the share of a job, in percent, left for storing the export
+// once the team is read
+const writeProgress = 10
+
+// ExportConfig tunes the export jobs. Workers run the jobs, polling for them
+// every PollInterval and at once when one is requested; Timeout bounds each
+// job. A running job not updated for StaleAfter is taken over by another
+// worker, e.g. after its instance was stopped, up to MaxAttempts times.
+// Download links are valid for LinkTTL.
+type ExportConfig struct {
+	Workers      int
+	PollInterval time.Duration
+	Timeout      time.Duration
+	StaleAfter   time.Duration
+	MaxAttempts  int
+	LinkTTL      time.Duration
+}
+
+// ExportStatus is an export job as shown to its requester: once the job
+// succeeded, with a link to download the export until DownloadExpiresAt
+type ExportStatus struct {
+	*models.ExportJob
+	DownloadURL       string     `json:"download_url,omitempty"`
+	DownloadExpiresAt *time.Time `json:"download_expires_at,omitempty"`
+}
+
+// Exporter runs the team exports requested through the API in the
+// background, for teams too large to export within a request. The exports
+// have the format of the backups, see TeamExport, and are stored under
+// <prefix>/exports/<export_id>.json. Jobs are kept in the database, so any
+// instance can run them and report on them.
+type Exporter struct {
+	job    *Job
+	jobs   interfaces.ExportJobRepository
+	config ExportConfig
+
+	wake chan struct{}
+	stop chan struct{}
+	wg   sync.WaitGroup
+	once sync.Once
+}
+
+// NewExporter creates an exporter building the exports with job
+func NewExporter(job *Job, jobs interfaces.ExportJobRepository, config ExportConfig) *Exporter {
+	if config.Workers <= 0 {
+		config.Workers = 2
+	}
+	if config.PollInterval <= 0 {
+		config.PollInterval = 5 * time.Second
+	}
+	if config.Timeout <= 0 {
+		config.Timeout = 30 * time.Minute
+	}
+	if config.StaleAfter <= 0 {
+		config.StaleAfter = 2 * time.Minute
+	}
+	if config.MaxAttempts <= 0 {
+		config.MaxAttempts = 3
+	}
+	if config.LinkTTL <= 0 {
+		config.LinkTTL = 15 * time.Minute
+	}
+
+	return &Exporter{
+		job:    job,
+		jobs:   jobs,
+		config: config,
+		wake:   make(chan struct{}, 1),
+		stop:   make(chan struct{}),
+	}
+}
+
+// Start launches the workers
+func (e *Exporter) Start() {
+	for i := 0; i < e.config.Workers; i++ {
+		e.wg.Add(1)
+		go e.work()
+	}
+	log.Printf("Export workers started (%d)", e.config.Workers)
+}
+
+// Request queues an export of a team, which only its managers may request
+func (e *Exporter) Request(ctx context.Context, teamID, requestorID uuid.UUID) (*models.ExportJob, error) {
+	isManager, err := e.job.repos.Teams.IsTeamManager(ctx, teamID, requestorID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check team manager: %w", err)
+	}
+	if !isManager {
+		if _, err := e.job.repos.Teams.GetByID(ctx, teamID); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, ErrTeamNotFound
+			}
+			return nil, fmt.Errorf("failed to get team: %w", err)
+		}
+		return nil, ErrNotTeamManager
+	}
+
+	job := &models.ExportJob{
+		TeamID:      teamID,
+		RequestedBy: requestorID,
+		Status:      models.ExportQueued,
+	}
+	if err := e.jobs.Create(ctx, job); err != nil {
+		return nil, fmt.Errorf("failed to create export job: %w", err)
+	}
+
+	select {
+	case e.wake <- struct{}{}:
+	default:
+	}
+	return job, nil
+}
+
+// Get returns an export job of the requestor; the jobs of others are not
+// found
+func (e *Exporter) Get(ctx context.Context, exportID, requestorID uuid.UUID) (*ExportStatus, error) {
+	job, err := e.jobs.GetByID(ctx, exportID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrExportNotFound
+		}
+		return nil, fmt.Errorf("failed to get export job: %w", err)
+	}
+	if job.RequestedBy != requestorID {
+		return nil, ErrExportNotFound
+	}
+
+	status := &ExportStatus{ExportJob: job}
+	if presigner, ok := e.job.store.(Presigner); ok && job.Status == models.ExportSucceeded {
+		expiresAt := time.Now().UTC().Add(e.config.LinkTTL)
+		if status.DownloadURL, err = presigner.PresignGet(job.ObjectKey, e.config.LinkTTL); err != nil {
+			return nil, fmt.Errorf("failed to sign download link: %w", err)
+		}
+		status.DownloadExpiresAt = &expiresAt
+	}
+	return status, nil
+}
+
+// List returns the latest export jobs of the requestor, newest first
+func (e *Exporter) List(ctx context.Context, requestorID uuid.UUID, limit int) ([]*models.ExportJob, error) {
+	jobs, err := e.jobs.ListByRequester(ctx, requestorID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list export jobs: %w", err)
+	}
+	return jobs, nil
+}
+
+func (e *Exporter) work() {
+	defer e.wg.Done()
+
+	ticker := time.NewTicker(e.config.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		// Run jobs until none is left to claim
+		for e.runNext() {
+		}
+
+		select {
+		case <-ticker.C:
+		case <-e.wake:
+		case <-e.stop:
+			return
+		}
+	}
+}
+
+// runNext claims a pending job and runs it, reporting whether it did
+func (e *Exporter) runNext() bool {
+	select {
+	case <-e.stop:
+		return false
+	default:
+	}
+
+	ctx := context.Background()
+	staleBefore := time.Now().UTC().Add(-e.config.StaleAfter)
+	pending, err := e.jobs.ListPending(ctx, staleBefore, e.config.Workers)
+	if err != nil {
+		log.Printf("Failed to list pending export jobs: %v", err)
+		return false
+	}
+	for _, job := range pending {
+		claimed, err := e.jobs.Claim(ctx, job.ExportID, staleBefore, time.Now().UTC())
+		if err != nil {
+			log.Printf("Failed to claim export job %s: %v", job.ExportID, err)
+			return false
+		}
+		if !claimed {
+			continue // Another worker was first
+		}
+		claimedJob, err := e.jobs.GetByID(ctx, job.ExportID)
+		if err != nil {
+			log.Printf("Failed to get export job %s: %v", job.ExportID, err)
+			return false
+		}
+		e.run(claimedJob)
+		return true
+	}
+	return false
+}
+
+// run builds and stores the export of a claimed job and records the outcome.
+// A job cut short by Close is queued again for another instance.
+func (e *Exporter) run(job *models.ExportJob) {
+	if job.Attempts > e.config.MaxAttempts {
+		e.finish(job, fmt.Errorf("gave up after %d attempts", e.config.MaxAttempts))
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), e.config.Timeout)
+	defer cancel()
+	go func() {
+		select {
+		case <-e.stop:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	// The progress is written as it changes and, so that the job is not
+	// taken for stale during a long step, at least every third of StaleAfter
+	var mu sync.Mutex
+	progress := 0
+	setProgress := func(p int, heartbeat bool) {
+		mu.Lock()
+		defer mu.Unlock()
+		if p > progress {
+			progress = p
+		} else if !heartbeat {
+			return
+		}
+		if err := e.jobs.SetProgress(ctx, job.ExportID, progress, time.Now().UTC()); err != nil && ctx.Err() == nil {
+			log.Printf("Failed to record progress of export job %s: %v", job.ExportID, err)
+		}
+	}
+	heartbeat := time.NewTicker(e.config.StaleAfter / 3)
+	defer heartbeat.Stop()
+	go func() {
+		for {
+			select {
+			case <-heartbeat.C:
+				setProgress(0, true)
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	export, err := e.job.build(ctx, job.TeamID, func(done, total int) {
+		setProgress(done*(100-writeProgress)/total, false)
+	})
+	var result *Result
+	if err == nil {
+		job.ObjectKey = path.Join(e.job.config.Prefix, "exports", job.ExportID.String()+".json")
+		result, err = e.job.write(ctx, job.ObjectKey, export)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	job.Progress = progress
+
+	select {
+	case <-e.stop:
+		if err != nil {
+			// Closing: leave the job to the next worker
+			job.Status = models.ExportQueued
+			job.Progress = 0
+			job.Attempts--
+			job.ObjectKey = ""
+			job.StartedAt = nil
+			e.save(job)
+			exportJobsTotal.WithLabelValues("requeued").Inc()
+			return
+		}
+	default:
+	}
+	if err == nil {
+		job.SizeBytes = result.SizeBytes
+	}
+	e.finish(job, err)
+}
+
+// finish records the outcome of a job
+func (e *Exporter) finish(job *models.ExportJob, err error) {
+	now := time.Now().UTC()
+	job.CompletedAt = &now
+	if err != nil {
+		job.Status = models.ExportFailed
+		job.LastError = err.Error()
+		job.ObjectKey = ""
+		exportJobsTotal.WithLabelValues("failed").Inc()
+		log.Printf("Export job %s of team %s failed: %v", job.ExportID, job.TeamID, err)
+	} else {
+		job.Status = models.ExportSucceeded
+		job.Progress = 100
+		job.LastError = ""
+		exportJobsTotal.WithLabelValues("succeeded").Inc()
+	}
+	e.save(job)
+}
+
+func (e *Exporter) save(job *models.ExportJob) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	job.UpdatedAt = time.Now().UTC()
+	if err := e.jobs.Update(ctx, job); err != nil {
+		log.Printf("Failed to save export job %s: %v", job.ExportID, err)
+	}
+}
+
+// Close stops the workers; the jobs they were running are queued again
+func (e *Exporter) Close() error {
+	e.once.Do(func() {
+		close(e.stop)
+	})
+	e.wg.Wait()
+	return nil
+}
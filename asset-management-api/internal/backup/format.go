@@ -0,0 +1,109 @@
+// Package backup exports the data of a team to S3 for disaster recovery.
+//
+// Each export is one JSON document, a TeamExport, stored under
+// <prefix>/teams/<team_id>/<exported_at>.json, e.g.
+//
+//	{
+//	  "format_version": 1,
+//	  "exported_at": "2026-10-18T03:00:00Z",
+//	  "team": {
+//	    "team_id": "…", "team_name": "Platform", "created_by": "…",
+//	    "version": 3, "created_at": "…", "updated_at": "…",
+//	    "managers": ["<user_id>"], "members": ["<user_id>"]
+//	  },
+//	  "users": [
+//	    {"user_id": "…", "username": "alice", "email": "alice@example.com", "role": "manager", "created_at": "…"}
+//	  ],
+//	  "folders": [
+//	    {
+//	      "folder_id": "…", "name": "Runbooks", "description": "…", "owner_id": "…",
+//	      "version": 1, "created_at": "…", "updated_at": "…",
+//	      "shares": [{"user_id": "…", "access_level": "read", "shared_by": "…", "created_at": "…"}],
+//	      "notes": [
+//	        {"note_id": "…", "title": "…", "body": "…", "owner_id": "…", "version": 2,
+//	         "created_at": "…", "updated_at": "…", "shares": […]}
+//	      ]
+//	    }
+//	  ],
+//	  "activity": [<user activity records, as stored in user_activity_log>]
+//	}
+//
+// The users are the team's managers and members, without their password
+// hashes. The folders are those owned by the team's users, with all their
+// notes; the activity is the audit trail of the team's users, oldest first.
+// A user in several teams appears in the export of each. Times are RFC 3339
+// in UTC. FormatVersion is raised on changes that readers must know about;
+// new fields may be added without raising it.
+package backup
+
+import (
+	"time"
+
+	"asset-management-api/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// FormatVersion is the version of the TeamExport format
+const FormatVersion = 1
+
+// TeamExport is the backup of a team
+type TeamExport struct {
+	FormatVersion int                       `json:"format_version"`
+	ExportedAt    time.Time                 `json:"exported_at"`
+	Team          Team                      `json:"team"`
+	Users         []User                    `json:"users"`
+	Folders       []Folder                  `json:"folders"`
+	Activity      []*models.UserActivityLog `json:"activity"`
+}
+
+// Team is a team with the IDs of its managers and members
+type Team struct {
+	TeamID    uuid.UUID   `json:"team_id"`
+	TeamName  string      `json:"team_name"`
+	CreatedBy uuid.UUID   `json:"created_by"`
+	Version   int64       `json:"version"`
+	CreatedAt time.Time   `json:"created_at"`
+	UpdatedAt time.Time   `json:"updated_at"`
+	Managers  []uuid.UUID `json:"managers"`
+	Members   []uuid.UUID `json:"members"`
+}
+
+type User struct {
+	UserID    uuid.UUID `json:"user_id"`
+	Username  string    `json:"username"`
+	Email     string    `json:"email"`
+	Role      string    `json:"role"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type Folder struct {
+	FolderID    uuid.UUID `json:"folder_id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	OwnerID     uuid.UUID `json:"owner_id"`
+	Version     int64     `json:"version"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+	Shares      []Share   `json:"shares"`
+	Notes       []Note    `json:"notes"`
+}
+
+type Note struct {
+	NoteID    uuid.UUID `json:"note_id"`
+	Title     string    `json:"title"`
+	Body      string    `json:"body"`
+	OwnerID   uuid.UUID `json:"owner_id"`
+	Version   int64     `json:"version"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+	Shares    []Share   `json:"shares"`
+}
+
+// Share is the access to a folder or note granted to a user
+type Share struct {
+	UserID      uuid.UUID `json:"user_id"`
+	AccessLevel string    `json:"access_level"`
+	SharedBy    uuid.UUID `json:"shared_by"`
+	CreatedAt   time.Time `json:"created_at"`
+}
@@ -0,0 +1,112 @@
+package backup
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"asset-management-api/internal/awssign"
+)
+
+// Store keeps the exports
+type Store interface {
+	// Put stores body under key and returns where it was stored
+	Put(ctx context.Context, key string, body []byte) (string, error)
+}
+
+// Presigner is implemented by stores that can hand out download links
+type Presigner interface {
+	// PresignGet returns a URL reading the object of key without
+	// credentials until ttl has passed
+	PresignGet(key string, ttl time.Duration) (string, error)
+}
+
+// S3Config describes the bucket exports are written to. Endpoint overrides
+// the regional endpoint, e.g. for MinIO, and addresses the bucket in the
+// path rather than the host name.
+type S3Config struct {
+	Bucket          string
+	Region          string
+	Endpoint        string
+	AccessKeyID     string
+	SecretAccessKey string
+	SessionToken    string
+	Timeout         time.Duration
+}
+
+// S3Store writes exports to an S3 bucket
+type S3Store struct {
+	config  S3Config
+	baseURL string
+	signer  awssign.Signer
+	client  *http.Client
+}
+
+var (
+	_ Store     = (*S3Store)(nil)
+	_ Presigner = (*S3Store)(nil)
+)
+
+// NewS3Store creates a store writing to the bucket of config
+func NewS3Store(config S3Config) *S3Store {
+	baseURL := fmt.Sprintf("https://%s.s3.%s.amazonaws.com", config.Bucket, config.Region)
+	if config.Endpoint != "" {
+		baseURL = strings.TrimSuffix(config.Endpoint, "/") + "/" + url.PathEscape(config.Bucket)
+	}
+	return &S3Store{
+		config:  config,
+		baseURL: baseURL,
+		signer: awssign.Signer{
+			Region:          config.Region,
+			Service:         "s3",
+			AccessKeyID:     config.AccessKeyID,
+			SecretAccessKey: config.SecretAccessKey,
+			SessionToken:    config.SessionToken,
+		},
+		client: &http.Client{Timeout: config.Timeout},
+	}
+}
+
+func (s *S3Store) Put(ctx context.Context, key string, body []byte) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, s.objectURL(key), bytes.NewReader(body))
+	if err != nil {
+		return "", fmt.Errorf("failed to create S3 request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Amz-Content-Sha256", awssign.PayloadHash(body))
+	s.signer.Sign(req, body, time.Now().UTC())
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to call S3: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		return "", fmt.Errorf("s3 returned %s: %s", resp.Status, strings.TrimSpace(string(respBody)))
+	}
+	return fmt.Sprintf("s3://%s/%s", s.config.Bucket, key), nil
+}
+
+func (s *S3Store) PresignGet(key string, ttl time.Duration) (string, error) {
+	u, err := url.Parse(s.objectURL(key))
+	if err != nil {
+		return "", fmt.Errorf("failed to parse S3 object URL: %w", err)
+	}
+	return s.signer.Presign(http.MethodGet, u, ttl, time.Now().UTC()).String(), nil
+}
+
+// objectURL is the URL of the object of key, its segments escaped
+func (s *S3Store) objectURL(key string) string {
+	segments := strings.Split(key, "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+	return s.baseURL + "/" + strings.Join(segments, "/")
+}
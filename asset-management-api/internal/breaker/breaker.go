@@ -0,0 +1,197 @@
+// Package breaker provides the circuit breaker guarding calls to the
+// service's dependencies (Postgres, Redis and Kafka). After a run of failed
+// calls the breaker opens and fails calls fast, without waiting on a
+// dependency that is down, then probes it to recover on its own.
+package breaker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// ErrOpen is returned, wrapped with the breaker's name, for calls rejected
+// while a breaker is open
+var ErrOpen = errors.New("circuit breaker is open")
+
+// State of a breaker, also used as the value of the state gauge
+type State int
+
+const (
+	Closed State = iota
+	HalfOpen
+	Open
+)
+
+func (s State) String() string {
+	switch s {
+	case HalfOpen:
+		return "half_open"
+	case Open:
+		return "open"
+	default:
+		return "closed"
+	}
+}
+
+var (
+	stateGauge = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "circuit_breaker_state",
+			Help: "Circuit breaker state by dependency (0=closed, 1=half-open, 2=open)",
+		},
+		[]string{"dependency"},
+	)
+
+	rejectionsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "circuit_breaker_rejections_total",
+			Help: "Total number of calls rejected by an open circuit breaker, by dependency",
+		},
+		[]string{"dependency"},
+	)
+
+	transitionsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "circuit_breaker_transitions_total",
+			Help: "Total number of circuit breaker state changes, by dependency and new state",
+		},
+		[]string{"dependency", "state"},
+	)
+)
+
+// Breaker opens after failureThreshold consecutive failures and rejects
+// calls until openTimeout has passed, then lets a single probe through to
+// decide whether to close again. A threshold of zero or less disables it.
+type Breaker struct {
+	name             string
+	failureThreshold int
+	openTimeout      time.Duration
+
+	mu       sync.Mutex
+	state    State
+	failures int
+	openedAt time.Time
+	probing  bool
+}
+
+// New creates a closed breaker; name labels its metrics and errors
+func New(name string, failureThreshold int, openTimeout time.Duration) *Breaker {
+	stateGauge.WithLabelValues(name).Set(float64(Closed))
+	return &Breaker{
+		name:             name,
+		failureThreshold: failureThreshold,
+		openTimeout:      openTimeout,
+	}
+}
+
+// Allow reports whether a call may proceed. Every allowed call must be
+// followed by Record or, if its outcome says nothing about the dependency,
+// Skip.
+func (b *Breaker) Allow() bool {
+	if b.failureThreshold <= 0 {
+		return true
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case Open:
+		if time.Since(b.openedAt) < b.openTimeout {
+			rejectionsTotal.WithLabelValues(b.name).Inc()
+			return false
+		}
+		b.setState(HalfOpen)
+		b.probing = true
+		return true
+	case HalfOpen:
+		if b.probing {
+			rejectionsTotal.WithLabelValues(b.name).Inc()
+			return false
+		}
+		b.probing = true
+		return true
+	default:
+		return true
+	}
+}
+
+// Record reports the outcome of an allowed call
+func (b *Breaker) Record(success bool) {
+	if b.failureThreshold <= 0 {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.probing = false
+	if success {
+		b.failures = 0
+		b.setState(Closed)
+		return
+	}
+
+	b.failures++
+	if b.state == HalfOpen || b.failures >= b.failureThreshold {
+		b.openedAt = time.Now()
+		b.setState(Open)
+	}
+}
+
+// Skip ends an allowed call without counting it, e.g. when the caller gave
+// up; a skipped probe lets the next call probe instead
+func (b *Breaker) Skip() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.probing = false
+}
+
+// Do runs call unless the breaker is open. healthy tells whether an error
+// still shows the dependency working, such as a missing row; with nil only
+// success does.
+func (b *Breaker) Do(call func() error, healthy func(error) bool) error {
+	if !b.Allow() {
+		return b.OpenError()
+	}
+
+	err := call()
+	switch {
+	case errors.Is(err, context.Canceled):
+		// A caller giving up says nothing about the dependency's health
+		b.Skip()
+	case err == nil:
+		b.Record(true)
+	default:
+		b.Record(healthy != nil && healthy(err))
+	}
+	return err
+}
+
+// OpenError is the error of a call rejected by the breaker
+func (b *Breaker) OpenError() error {
+	return fmt.Errorf("%s %w", b.name, ErrOpen)
+}
+
+// State returns the breaker's current state
+func (b *Breaker) State() State {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.state
+}
+
+func (b *Breaker) setState(state State) {
+	if b.state != state {
+		transitionsTotal.WithLabelValues(b.name, state.String()).Inc()
+	}
+	b.state = state
+	stateGauge.WithLabelValues(b.name).Set(float64(state))
+}
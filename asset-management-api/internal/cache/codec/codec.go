@@ -0,0 +1,124 @@
+// Package codec transparently compresses cached payloads above a size
+// threshold. Compressed values start with a marker byte identifying the
+// algorithm; values without a marker are plain JSON as written before
+// compression was introduced, so existing entries stay readable.
+package codec
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/klauspost/compress/snappy"
+	"github.com/klauspost/compress/zstd"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// Format marker bytes. JSON never starts with a control byte, so these
+// cannot be confused with uncompressed payloads.
+const (
+	markerSnappy byte = 0x01
+	markerZstd   byte = 0x02
+)
+
+// Supported compression algorithms
+const (
+	None   = "none"
+	Snappy = "snappy"
+	Zstd   = "zstd"
+)
+
+var (
+	compressionBytesTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "cache_compression_bytes_total",
+			Help: "Total bytes passed through cache compression, before and after",
+		},
+		[]string{"algorithm", "stage"},
+	)
+
+	compressionRatio = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "cache_compression_ratio",
+			Help:    "Compressed size divided by original size for cached payloads",
+			Buckets: prometheus.LinearBuckets(0.1, 0.1, 10),
+		},
+		[]string{"algorithm"},
+	)
+)
+
+var (
+	zstdEncoder, _ = zstd.NewWriter(nil, zstd.WithEncoderLevel(zstd.SpeedDefault))
+	zstdDecoder, _ = zstd.NewReader(nil, zstd.WithDecoderConcurrency(0))
+)
+
+// Codec compresses payloads of at least Threshold bytes with Algorithm
+type Codec struct {
+	Algorithm string
+	Threshold int
+}
+
+// New validates the algorithm and returns a codec
+func New(algorithm string, threshold int) (*Codec, error) {
+	algorithm = strings.ToLower(algorithm)
+	switch algorithm {
+	case "", None:
+		algorithm = None
+	case Snappy, Zstd:
+	default:
+		return nil, fmt.Errorf("unsupported cache compression algorithm: %s", algorithm)
+	}
+	return &Codec{Algorithm: algorithm, Threshold: threshold}, nil
+}
+
+// Encode returns data unchanged when compression is disabled or the payload
+// is below the threshold; otherwise the marker byte followed by the compressed data
+func (c *Codec) Encode(data []byte) []byte {
+	if c == nil || c.Algorithm == None || len(data) < c.Threshold {
+		return data
+	}
+
+	var encoded []byte
+	switch c.Algorithm {
+	case Snappy:
+		encoded = append([]byte{markerSnappy}, snappy.Encode(nil, data)...)
+	case Zstd:
+		encoded = zstdEncoder.EncodeAll(data, []byte{markerZstd})
+	}
+
+	// Keep the original when compression does not pay off
+	if len(encoded) >= len(data) {
+		return data
+	}
+
+	compressionBytesTotal.WithLabelValues(c.Algorithm, "raw").Add(float64(len(data)))
+	compressionBytesTotal.WithLabelValues(c.Algorithm, "compressed").Add(float64(len(encoded)))
+	compressionRatio.WithLabelValues(c.Algorithm).Observe(float64(len(encoded)) / float64(len(data)))
+
+	return encoded
+}
+
+// Decode reverses Encode based on the marker byte. It does not depend on the
+// codec configuration, so values written with another algorithm still decode.
+func Decode(data []byte) ([]byte, error) {
+	if len(data) == 0 {
+		return data, nil
+	}
+
+	switch data[0] {
+	case markerSnappy:
+		decoded, err := snappy.Decode(nil, data[1:])
+		if err != nil {
+			return nil, fmt.Errorf("failed to decompress snappy payload: %w", err)
+		}
+		return decoded, nil
+	case markerZstd:
+		decoded, err := zstdDecoder.DecodeAll(data[1:], nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decompress zstd payload: %w", err)
+		}
+		return decoded, nil
+	default:
+		return data, nil
+	}
+}
@@ -0,0 +1,39 @@
+package memcached
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"asset-management-api/pkg/cache"
+	"github.com/bradfitz/gomemcache/memcache"
+)
+
+// InspectKey returns the decoded value of a key in the current namespace, or
+// nil if the key does not exist. memcached does not expose remaining TTLs.
+func (m *MemcachedCacheService) InspectKey(ctx context.Context, key string) (*cache.KeyInfo, error) {
+	fullKey := m.keys.Raw(key)
+
+	raw, err := m.client.Get(fullKey)
+	if err != nil {
+		if errors.Is(err, memcache.ErrCacheMiss) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read cache key: %w", err)
+	}
+
+	info := &cache.KeyInfo{Key: fullKey, Type: "string", TTLSeconds: -1}
+	var decoded interface{}
+	if DecodeJSON(raw, &decoded) == nil {
+		info.Value = decoded
+	} else {
+		info.Value = string(raw)
+	}
+
+	return info, nil
+}
+
+// Stats is not supported: memcached cannot enumerate keys by prefix
+func (m *MemcachedCacheService) Stats(ctx context.Context) (*cache.CacheStats, error) {
+	return nil, cache.ErrNotSupported
+}
@@ -0,0 +1,462 @@
+package memcached
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+
+	"asset-management-api/internal/models"
+	"asset-management-api/pkg/cache"
+	"github.com/bradfitz/gomemcache/memcache"
+	"github.com/google/uuid"
+)
+
+// MemcachedCacheService implements the CacheService interface using memcached.
+// Team members and ACLs have no native collection type in memcached, so they
+// are stored as JSON documents and mutated with compare-and-swap.
+type MemcachedCacheService struct {
+	client    *MemcachedClient
+	keys      cache.CacheKeys
+	namespace *cache.KeyNamespace
+	stopWatch chan struct{}
+}
+
+// NewMemcachedCacheService creates a new memcached cache service whose keys
+// are prefixed with the given namespace version
+func NewMemcachedCacheService(client *MemcachedClient, namespace *cache.KeyNamespace) *MemcachedCacheService {
+	return &MemcachedCacheService{
+		client:    client,
+		keys:      cache.NewCacheKeys(namespace),
+		namespace: namespace,
+		stopWatch: make(chan struct{}),
+	}
+}
+
+// Team member caching methods
+func (m *MemcachedCacheService) CacheTeamMembers(ctx context.Context, teamID uuid.UUID, members []uuid.UUID) error {
+	key := m.keys.TeamMembers(teamID)
+
+	// Clear existing members
+	if err := m.client.Delete(key); err != nil {
+		log.Printf("Warning: failed to clear existing team members cache: %v", err)
+	}
+
+	if len(members) > 0 {
+		memberStrs := make([]string, len(members))
+		for i, member := range members {
+			memberStrs[i] = member.String()
+		}
+
+		if err := m.client.SetJSON(key, memberStrs, cache.DefaultTeamMembersTTL); err != nil {
+			return fmt.Errorf("failed to cache team members: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (m *MemcachedCacheService) GetTeamMembers(ctx context.Context, teamID uuid.UUID) ([]uuid.UUID, error) {
+	key := m.keys.TeamMembers(teamID)
+
+	var memberStrs []string
+	if err := m.client.GetJSON(key, &memberStrs); err != nil {
+		if errors.Is(err, memcache.ErrCacheMiss) {
+			return nil, nil // Cache miss
+		}
+		return nil, fmt.Errorf("failed to get team members from cache: %w", err)
+	}
+
+	members := make([]uuid.UUID, 0, len(memberStrs))
+	for _, memberStr := range memberStrs {
+		memberID, err := uuid.Parse(memberStr)
+		if err != nil {
+			log.Printf("Warning: invalid UUID in team members cache: %s", memberStr)
+			continue
+		}
+		members = append(members, memberID)
+	}
+
+	return members, nil
+}
+
+func (m *MemcachedCacheService) IsTeamMember(ctx context.Context, teamID, userID uuid.UUID) (bool, bool, error) {
+	members, err := m.GetTeamMembers(ctx, teamID)
+	if err != nil {
+		return false, false, err
+	}
+	if members == nil {
+		return false, false, nil // Cache miss
+	}
+
+	for _, memberID := range members {
+		if memberID == userID {
+			return true, true, nil
+		}
+	}
+
+	return false, true, nil
+}
+
+func (m *MemcachedCacheService) AddTeamMember(ctx context.Context, teamID, memberID uuid.UUID) error {
+	key := m.keys.TeamMembers(teamID)
+
+	err := m.client.UpdateJSON(key, cache.DefaultTeamMembersTTL, func(current []byte) (interface{}, error) {
+		var memberStrs []string
+		if err := json.Unmarshal(current, &memberStrs); err != nil {
+			return nil, err
+		}
+		for _, memberStr := range memberStrs {
+			if memberStr == memberID.String() {
+				return memberStrs, nil // Already a member
+			}
+		}
+		return append(memberStrs, memberID.String()), nil
+	})
+	if err != nil {
+		// If key doesn't exist, skip (cache will be populated on next read)
+		if errors.Is(err, memcache.ErrCacheMiss) {
+			return nil
+		}
+		return fmt.Errorf("failed to add team member to cache: %w", err)
+	}
+
+	return nil
+}
+
+func (m *MemcachedCacheService) RemoveTeamMember(ctx context.Context, teamID, memberID uuid.UUID) error {
+	key := m.keys.TeamMembers(teamID)
+
+	err := m.client.UpdateJSON(key, cache.DefaultTeamMembersTTL, func(current []byte) (interface{}, error) {
+		var memberStrs []string
+		if err := json.Unmarshal(current, &memberStrs); err != nil {
+			return nil, err
+		}
+
+		// Remove all occurrences of the member
+		remaining := memberStrs[:0]
+		for _, memberStr := range memberStrs {
+			if memberStr != memberID.String() {
+				remaining = append(remaining, memberStr)
+			}
+		}
+		return remaining, nil
+	})
+	if err != nil && !errors.Is(err, memcache.ErrCacheMiss) {
+		return fmt.Errorf("failed to remove team member from cache: %w", err)
+	}
+
+	return nil
+}
+
+func (m *MemcachedCacheService) InvalidateTeamMembers(ctx context.Context, teamID uuid.UUID) error {
+	key := m.keys.TeamMembers(teamID)
+	return m.client.Delete(key)
+}
+
+// User teams caching methods
+func (m *MemcachedCacheService) CacheUserTeams(ctx context.Context, userID uuid.UUID, teams []*models.Team) error {
+	key := m.keys.UserTeams(userID)
+
+	if teams == nil {
+		teams = []*models.Team{} // Cache "no teams" as a hit, not a miss
+	}
+
+	if err := m.client.SetJSON(key, teams, cache.DefaultUserTeamsTTL); err != nil {
+		return fmt.Errorf("failed to cache user teams: %w", err)
+	}
+
+	return nil
+}
+
+func (m *MemcachedCacheService) GetUserTeams(ctx context.Context, userID uuid.UUID) ([]*models.Team, error) {
+	key := m.keys.UserTeams(userID)
+
+	var teams []*models.Team
+	if err := m.client.GetJSON(key, &teams); err != nil {
+		if errors.Is(err, memcache.ErrCacheMiss) {
+			return nil, nil // Cache miss
+		}
+		return nil, fmt.Errorf("failed to get user teams from cache: %w", err)
+	}
+
+	return teams, nil
+}
+
+func (m *MemcachedCacheService) InvalidateUserTeams(ctx context.Context, userID uuid.UUID) error {
+	key := m.keys.UserTeams(userID)
+	return m.client.Delete(key)
+}
+
+// User profile caching methods
+func (m *MemcachedCacheService) CacheUserProfile(ctx context.Context, user *models.User) error {
+	key := m.keys.UserProfile(user.UserID)
+
+	if err := m.client.SetJSON(key, user, cache.DefaultUserProfileTTL); err != nil {
+		return fmt.Errorf("failed to cache user profile: %w", err)
+	}
+
+	return nil
+}
+
+func (m *MemcachedCacheService) GetUserProfile(ctx context.Context, userID uuid.UUID) (*models.User, error) {
+	key := m.keys.UserProfile(userID)
+
+	var user models.User
+	if err := m.client.GetJSON(key, &user); err != nil {
+		if errors.Is(err, memcache.ErrCacheMiss) {
+			return nil, nil // Cache miss
+		}
+		return nil, fmt.Errorf("failed to get user profile from cache: %w", err)
+	}
+
+	return &user, nil
+}
+
+func (m *MemcachedCacheService) InvalidateUserProfile(ctx context.Context, userID uuid.UUID) error {
+	key := m.keys.UserProfile(userID)
+	return m.client.Delete(key)
+}
+
+// Asset metadata caching methods
+func (m *MemcachedCacheService) CacheFolderMetadata(ctx context.Context, folder *models.Folder) error {
+	key := m.keys.FolderMetadata(folder.FolderID)
+
+	if err := m.client.SetJSON(key, folder, cache.DefaultAssetTTL); err != nil {
+		return fmt.Errorf("failed to cache folder metadata: %w", err)
+	}
+
+	return nil
+}
+
+func (m *MemcachedCacheService) GetFolderMetadata(ctx context.Context, folderID uuid.UUID) (*models.Folder, error) {
+	key := m.keys.FolderMetadata(folderID)
+
+	var folder models.Folder
+	if err := m.client.GetJSON(key, &folder); err != nil {
+		if errors.Is(err, memcache.ErrCacheMiss) {
+			return nil, nil // Cache miss
+		}
+		return nil, fmt.Errorf("failed to get folder metadata from cache: %w", err)
+	}
+
+	return &folder, nil
+}
+
+func (m *MemcachedCacheService) CacheNoteMetadata(ctx context.Context, note *models.Note) error {
+	key := m.keys.NoteMetadata(note.NoteID)
+
+	if err := m.client.SetJSON(key, note, cache.DefaultAssetTTL); err != nil {
+		return fmt.Errorf("failed to cache note metadata: %w", err)
+	}
+
+	return nil
+}
+
+func (m *MemcachedCacheService) GetNoteMetadata(ctx context.Context, noteID uuid.UUID) (*models.Note, error) {
+	key := m.keys.NoteMetadata(noteID)
+
+	var note models.Note
+	if err := m.client.GetJSON(key, &note); err != nil {
+		if errors.Is(err, memcache.ErrCacheMiss) {
+			return nil, nil // Cache miss
+		}
+		return nil, fmt.Errorf("failed to get note metadata from cache: %w", err)
+	}
+
+	return &note, nil
+}
+
+// GetFolderMetadataBatch fetches many folders with a single GetMulti
+func (m *MemcachedCacheService) GetFolderMetadataBatch(ctx context.Context, folderIDs []uuid.UUID) (map[uuid.UUID]*models.Folder, error) {
+	folders := make(map[uuid.UUID]*models.Folder, len(folderIDs))
+	if len(folderIDs) == 0 {
+		return folders, nil
+	}
+
+	keys := make([]string, len(folderIDs))
+	for i, folderID := range folderIDs {
+		keys[i] = m.keys.FolderMetadata(folderID)
+	}
+
+	values, err := m.client.GetMulti(keys)
+	if err != nil {
+		return nil, fmt.Errorf("failed to batch get folder metadata from cache: %w", err)
+	}
+
+	for i, key := range keys {
+		value, ok := values[key]
+		if !ok {
+			continue // Cache miss
+		}
+
+		var folder models.Folder
+		if err := DecodeJSON(value, &folder); err != nil {
+			log.Printf("Warning: invalid folder metadata in cache for %s: %v", folderIDs[i], err)
+			continue
+		}
+		folders[folderIDs[i]] = &folder
+	}
+
+	return folders, nil
+}
+
+// GetNoteMetadataBatch fetches many notes with a single GetMulti
+func (m *MemcachedCacheService) GetNoteMetadataBatch(ctx context.Context, noteIDs []uuid.UUID) (map[uuid.UUID]*models.Note, error) {
+	notes := make(map[uuid.UUID]*models.Note, len(noteIDs))
+	if len(noteIDs) == 0 {
+		return notes, nil
+	}
+
+	keys := make([]string, len(noteIDs))
+	for i, noteID := range noteIDs {
+		keys[i] = m.keys.NoteMetadata(noteID)
+	}
+
+	values, err := m.client.GetMulti(keys)
+	if err != nil {
+		return nil, fmt.Errorf("failed to batch get note metadata from cache: %w", err)
+	}
+
+	for i, key := range keys {
+		value, ok := values[key]
+		if !ok {
+			continue // Cache miss
+		}
+
+		var note models.Note
+		if err := DecodeJSON(value, &note); err != nil {
+			log.Printf("Warning: invalid note metadata in cache for %s: %v", noteIDs[i], err)
+			continue
+		}
+		notes[noteIDs[i]] = &note
+	}
+
+	return notes, nil
+}
+
+func (m *MemcachedCacheService) InvalidateFolderMetadata(ctx context.Context, folderID uuid.UUID) error {
+	key := m.keys.FolderMetadata(folderID)
+	return m.client.Delete(key)
+}
+
+func (m *MemcachedCacheService) InvalidateNoteMetadata(ctx context.Context, noteID uuid.UUID) error {
+	key := m.keys.NoteMetadata(noteID)
+	return m.client.Delete(key)
+}
+
+// Access control caching methods
+func (m *MemcachedCacheService) CacheAssetACL(ctx context.Context, assetID uuid.UUID, acl map[string]string) error {
+	if len(acl) == 0 {
+		return nil
+	}
+
+	key := m.keys.AssetACL(assetID)
+
+	// Merge into any existing ACL, matching Redis HSET semantics
+	err := m.client.UpdateJSON(key, cache.DefaultACLTTL, func(current []byte) (interface{}, error) {
+		existing := make(map[string]string)
+		if err := json.Unmarshal(current, &existing); err != nil {
+			return nil, err
+		}
+		for userID, accessLevel := range acl {
+			existing[userID] = accessLevel
+		}
+		return existing, nil
+	})
+	if errors.Is(err, memcache.ErrCacheMiss) {
+		err = m.client.SetJSON(key, acl, cache.DefaultACLTTL)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to cache asset ACL: %w", err)
+	}
+
+	return nil
+}
+
+func (m *MemcachedCacheService) ReplaceAssetACL(ctx context.Context, assetID uuid.UUID, acl map[string]string) error {
+	key := m.keys.AssetACL(assetID)
+	if len(acl) == 0 {
+		return m.client.Delete(key)
+	}
+
+	if err := m.client.SetJSON(key, acl, cache.DefaultACLTTL); err != nil {
+		return fmt.Errorf("failed to replace asset ACL in cache: %w", err)
+	}
+	return nil
+}
+
+func (m *MemcachedCacheService) GetAssetACL(ctx context.Context, assetID uuid.UUID) (map[string]string, error) {
+	key := m.keys.AssetACL(assetID)
+
+	var acl map[string]string
+	if err := m.client.GetJSON(key, &acl); err != nil {
+		if errors.Is(err, memcache.ErrCacheMiss) {
+			return nil, nil // Cache miss
+		}
+		return nil, fmt.Errorf("failed to get asset ACL from cache: %w", err)
+	}
+
+	if len(acl) == 0 {
+		return nil, nil // Cache miss or empty ACL
+	}
+
+	return acl, nil
+}
+
+func (m *MemcachedCacheService) UpdateAssetACL(ctx context.Context, assetID, userID uuid.UUID, accessLevel string) error {
+	key := m.keys.AssetACL(assetID)
+
+	err := m.client.UpdateJSON(key, cache.DefaultACLTTL, func(current []byte) (interface{}, error) {
+		acl := make(map[string]string)
+		if err := json.Unmarshal(current, &acl); err != nil {
+			return nil, err
+		}
+		acl[userID.String()] = accessLevel
+		return acl, nil
+	})
+	if err != nil {
+		if errors.Is(err, memcache.ErrCacheMiss) {
+			return nil // Cache doesn't exist, skip update
+		}
+		return fmt.Errorf("failed to update asset ACL in cache: %w", err)
+	}
+
+	return nil
+}
+
+func (m *MemcachedCacheService) RemoveAssetACL(ctx context.Context, assetID, userID uuid.UUID) error {
+	key := m.keys.AssetACL(assetID)
+
+	err := m.client.UpdateJSON(key, cache.DefaultACLTTL, func(current []byte) (interface{}, error) {
+		acl := make(map[string]string)
+		if err := json.Unmarshal(current, &acl); err != nil {
+			return nil, err
+		}
+		delete(acl, userID.String())
+		return acl, nil
+	})
+	if err != nil && !errors.Is(err, memcache.ErrCacheMiss) {
+		return fmt.Errorf("failed to remove asset ACL from cache: %w", err)
+	}
+
+	return nil
+}
+
+func (m *MemcachedCacheService) InvalidateAssetACL(ctx context.Context, assetID uuid.UUID) error {
+	key := m.keys.AssetACL(assetID)
+	return m.client.Delete(key)
+}
+
+// Health check and cleanup
+func (m *MemcachedCacheService) HealthCheck() map[string]interface{} {
+	health := m.client.Health()
+	health["key_version"] = m.namespace.Version()
+	return health
+}
+
+func (m *MemcachedCacheService) Close() error {
+	close(m.stopWatch)
+	return m.client.Close()
+}
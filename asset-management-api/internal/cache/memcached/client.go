@@ -0,0 +1,202 @@
+package memcached
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"asset-management-api/internal/cache/codec"
+	"github.com/bradfitz/gomemcache/memcache"
+)
+
+// maxCASRetries bounds the number of compare-and-swap attempts for a single update
+const maxCASRetries = 5
+
+// MemcachedClient wraps the memcached client with additional functionality
+type MemcachedClient struct {
+	client *memcache.Client
+	config *MemcachedConfig
+	codec  *codec.Codec
+}
+
+// NewMemcachedClient creates a new memcached client instance
+func NewMemcachedClient(config *MemcachedConfig) (*MemcachedClient, error) {
+	if len(config.Servers) == 0 {
+		return nil, errors.New("no memcached servers configured")
+	}
+
+	payloadCodec, err := codec.New(config.Compression, config.CompressionThreshold)
+	if err != nil {
+		return nil, err
+	}
+
+	mc := memcache.New(config.Servers...)
+	mc.Timeout = config.Timeout
+	mc.MaxIdleConns = config.MaxIdleConns
+
+	// Test connection
+	if err := mc.Ping(); err != nil {
+		return nil, fmt.Errorf("failed to connect to memcached: %w", err)
+	}
+
+	log.Printf("Successfully connected to memcached at %v", config.Servers)
+
+	return &MemcachedClient{
+		client: mc,
+		config: config,
+		codec:  payloadCodec,
+	}, nil
+}
+
+// Close closes all idle memcached connections
+func (m *MemcachedClient) Close() error {
+	return m.client.Close()
+}
+
+// Health returns the health status of the memcached connection
+func (m *MemcachedClient) Health() map[string]interface{} {
+	start := time.Now()
+	err := m.client.Ping()
+	latency := time.Since(start)
+
+	health := map[string]interface{}{
+		"status":     "healthy",
+		"backend":    "memcached",
+		"latency_ms": latency.Milliseconds(),
+		"servers":    m.config.Servers,
+	}
+
+	if err != nil {
+		health["status"] = "unhealthy"
+		health["error"] = err.Error()
+	}
+
+	return health
+}
+
+// Generic methods for basic operations
+func (m *MemcachedClient) Set(key string, value []byte, expiration time.Duration) error {
+	return m.client.Set(&memcache.Item{
+		Key:        key,
+		Value:      value,
+		Expiration: ttlSeconds(expiration),
+	})
+}
+
+func (m *MemcachedClient) Get(key string) ([]byte, error) {
+	item, err := m.client.Get(key)
+	if err != nil {
+		return nil, err
+	}
+	return item.Value, nil
+}
+
+// GetMulti fetches many keys in one round trip per server; misses are omitted
+func (m *MemcachedClient) GetMulti(keys []string) (map[string][]byte, error) {
+	items, err := m.client.GetMulti(keys)
+	if err != nil {
+		return nil, err
+	}
+
+	values := make(map[string][]byte, len(items))
+	for key, item := range items {
+		values[key] = item.Value
+	}
+	return values, nil
+}
+
+func (m *MemcachedClient) Delete(key string) error {
+	err := m.client.Delete(key)
+	if errors.Is(err, memcache.ErrCacheMiss) {
+		return nil // Deleting a missing key is not an error, same as Redis DEL
+	}
+	return err
+}
+
+// Add stores value only if key does not already exist; it reports whether the value was stored
+func (m *MemcachedClient) Add(key string, value []byte, expiration time.Duration) (bool, error) {
+	err := m.client.Add(&memcache.Item{Key: key, Value: value, Expiration: ttlSeconds(expiration)})
+	if errors.Is(err, memcache.ErrNotStored) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+func (m *MemcachedClient) Increment(key string, delta uint64) (uint64, error) {
+	return m.client.Increment(key, delta)
+}
+
+// JSON methods
+func (m *MemcachedClient) SetJSON(key string, value interface{}, expiration time.Duration) error {
+	jsonBytes, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("failed to marshal JSON: %w", err)
+	}
+	return m.Set(key, m.codec.Encode(jsonBytes), expiration)
+}
+
+func (m *MemcachedClient) GetJSON(key string, dest interface{}) error {
+	raw, err := m.Get(key)
+	if err != nil {
+		return err
+	}
+	return DecodeJSON(raw, dest)
+}
+
+// DecodeJSON unmarshals a raw value written by SetJSON, decompressing it if needed
+func DecodeJSON(raw []byte, dest interface{}) error {
+	jsonBytes, err := codec.Decode(raw)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(jsonBytes, dest)
+}
+
+// UpdateJSON atomically rewrites the JSON value stored at key using
+// compare-and-swap. The update function receives the current decompressed
+// JSON and returns the replacement. memcache.ErrCacheMiss is returned if the key does
+// not exist.
+func (m *MemcachedClient) UpdateJSON(key string, expiration time.Duration, update func(current []byte) (interface{}, error)) error {
+	for attempt := 0; attempt < maxCASRetries; attempt++ {
+		item, err := m.client.Get(key)
+		if err != nil {
+			return err
+		}
+
+		current, err := codec.Decode(item.Value)
+		if err != nil {
+			return err
+		}
+
+		value, err := update(current)
+		if err != nil {
+			return err
+		}
+
+		jsonBytes, err := json.Marshal(value)
+		if err != nil {
+			return fmt.Errorf("failed to marshal JSON: %w", err)
+		}
+
+		item.Value = m.codec.Encode(jsonBytes)
+		item.Expiration = ttlSeconds(expiration)
+
+		err = m.client.CompareAndSwap(item)
+		if errors.Is(err, memcache.ErrCASConflict) {
+			continue // Value changed underneath us, retry with fresh copy
+		}
+		return err
+	}
+
+	return memcache.ErrCASConflict
+}
+
+// ttlSeconds converts a duration to the memcached expiration format
+func ttlSeconds(expiration time.Duration) int32 {
+	if expiration <= 0 {
+		return 0
+	}
+	return int32(expiration / time.Second)
+}
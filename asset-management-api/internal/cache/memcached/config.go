@@ -0,0 +1,72 @@
+package memcached
+
+import (
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MemcachedConfig holds memcached configuration
+type MemcachedConfig struct {
+	Servers      []string
+	Timeout      time.Duration
+	MaxIdleConns int
+
+	// Compression of JSON payloads: "none", "snappy" or "zstd"
+	Compression          string
+	CompressionThreshold int
+}
+
+// LoadMemcachedConfig loads memcached configuration from environment variables
+func LoadMemcachedConfig() *MemcachedConfig {
+	return &MemcachedConfig{
+		Servers:      getSliceEnv("MEMCACHED_SERVERS", []string{"localhost:11211"}),
+		Timeout:      getDurationEnv("MEMCACHED_TIMEOUT", 500*time.Millisecond),
+		MaxIdleConns: getIntEnv("MEMCACHED_MAX_IDLE_CONNS", 10),
+
+		Compression:          getEnv("CACHE_COMPRESSION", "none"),
+		CompressionThreshold: getIntEnv("CACHE_COMPRESSION_THRESHOLD", 1024),
+	}
+}
+
+// Helper functions for environment variable parsing
+func getEnv(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
+
+func getSliceEnv(key string, defaultValue []string) []string {
+	if value := os.Getenv(key); value != "" {
+		var result []string
+		for _, part := range strings.Split(value, ",") {
+			if trimmed := strings.TrimSpace(part); trimmed != "" {
+				result = append(result, trimmed)
+			}
+		}
+		if len(result) > 0 {
+			return result
+		}
+	}
+	return defaultValue
+}
+
+func getIntEnv(key string, defaultValue int) int {
+	if value := os.Getenv(key); value != "" {
+		if intValue, err := strconv.Atoi(value); err == nil {
+			return intValue
+		}
+	}
+	return defaultValue
+}
+
+func getDurationEnv(key string, defaultValue time.Duration) time.Duration {
+	if value := os.Getenv(key); value != "" {
+		if duration, err := time.ParseDuration(value); err == nil {
+			return duration
+		}
+	}
+	return defaultValue
+}
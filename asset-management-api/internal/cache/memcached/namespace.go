@@ -0,0 +1,103 @@
+package memcached
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"asset-management-api/pkg/cache"
+	"github.com/bradfitz/gomemcache/memcache"
+)
+
+// KeyVersion returns the namespace version currently prefixed to cache keys
+func (m *MemcachedCacheService) KeyVersion() int64 {
+	return m.namespace.Version()
+}
+
+// BumpKeyVersion increments the shared namespace version so existing entries
+// are orphaned and left to expire instead of flushing every server
+func (m *MemcachedCacheService) BumpKeyVersion(ctx context.Context) (int64, error) {
+	current := strconv.FormatInt(m.namespace.Version(), 10)
+	if _, err := m.client.Add(cache.KeyVersionKey, []byte(current), 0); err != nil {
+		return 0, fmt.Errorf("failed to initialize cache key version: %w", err)
+	}
+
+	version, err := m.client.Increment(cache.KeyVersionKey, 1)
+	if err != nil {
+		return 0, fmt.Errorf("failed to bump cache key version: %w", err)
+	}
+
+	m.namespace.SetVersion(int64(version))
+	log.Printf("Cache key namespace bumped to v%d", version)
+	return int64(version), nil
+}
+
+// SyncKeyVersion reconciles the configured version with the one stored in
+// memcached, keeping whichever is higher
+func (m *MemcachedCacheService) SyncKeyVersion(ctx context.Context, configured int64) (int64, error) {
+	if _, err := m.client.Add(cache.KeyVersionKey, []byte(strconv.FormatInt(configured, 10)), 0); err != nil {
+		return 0, fmt.Errorf("failed to initialize cache key version: %w", err)
+	}
+
+	stored, err := m.loadKeyVersion()
+	if err != nil {
+		return 0, err
+	}
+
+	if configured > stored {
+		if err := m.client.Set(cache.KeyVersionKey, []byte(strconv.FormatInt(configured, 10)), 0); err != nil {
+			return 0, fmt.Errorf("failed to store cache key version: %w", err)
+		}
+		stored = configured
+	}
+
+	m.namespace.SetVersion(stored)
+	return stored, nil
+}
+
+// WatchKeyVersion periodically refreshes the namespace version so bumps made
+// on another instance take effect here. The watcher stops when the service is closed.
+func (m *MemcachedCacheService) WatchKeyVersion(interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-m.stopWatch:
+				return
+			case <-ticker.C:
+				version, err := m.loadKeyVersion()
+				if err != nil {
+					log.Printf("Warning: failed to refresh cache key version: %v", err)
+					continue
+				}
+				if version > 0 && version != m.namespace.Version() {
+					log.Printf("Cache key namespace changed from v%d to v%d", m.namespace.Version(), version)
+					m.namespace.SetVersion(version)
+				}
+			}
+		}
+	}()
+}
+
+func (m *MemcachedCacheService) loadKeyVersion() (int64, error) {
+	value, err := m.client.Get(cache.KeyVersionKey)
+	if err != nil {
+		if errors.Is(err, memcache.ErrCacheMiss) {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("failed to read cache key version: %w", err)
+	}
+
+	// memcached INCR may leave trailing spaces when the value shrinks in width
+	version, err := strconv.ParseInt(strings.TrimSpace(string(value)), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid cache key version %q: %w", value, err)
+	}
+	return version, nil
+}
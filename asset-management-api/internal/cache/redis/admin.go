@@ -0,0 +1,100 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"asset-management-api/pkg/cache"
+)
+
+// InspectKey returns the type, remaining TTL and decoded value of a key in
+// the current namespace, or nil if the key does not exist
+func (r *RedisCacheService) InspectKey(ctx context.Context, key string) (*cache.KeyInfo, error) {
+	fullKey := r.keys.Raw(key)
+
+	keyType, err := r.client.Type(ctx, fullKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to inspect cache key: %w", err)
+	}
+	if keyType == "none" {
+		return nil, nil
+	}
+
+	info := &cache.KeyInfo{Key: fullKey, Type: keyType, TTLSeconds: -1}
+	if ttl, err := r.client.TTL(ctx, fullKey); err == nil && ttl > 0 {
+		info.TTLSeconds = int64(ttl.Seconds())
+	}
+
+	switch keyType {
+	case "string":
+		raw, err := r.client.Get(ctx, fullKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read cache key: %w", err)
+		}
+		var decoded interface{}
+		if DecodeJSON(raw, &decoded) == nil {
+			info.Value = decoded
+		} else {
+			info.Value = raw
+		}
+	case "set":
+		info.Value, err = r.client.SMembers(ctx, fullKey)
+	case "hash":
+		info.Value, err = r.client.HGetAll(ctx, fullKey)
+	case "list":
+		info.Value, err = r.client.LRange(ctx, fullKey, 0, -1)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cache key: %w", err)
+	}
+
+	return info, nil
+}
+
+// Stats counts the keys in the current namespace by their leading segment
+// (team, folder, note, asset) and reports Redis memory usage
+func (r *RedisCacheService) Stats(ctx context.Context) (*cache.CacheStats, error) {
+	prefix := r.namespace.Prefix()
+	stats := &cache.CacheStats{
+		Backend:    "redis",
+		KeyVersion: r.namespace.Version(),
+		KeyCounts:  make(map[string]int64),
+	}
+
+	var cursor uint64
+	for {
+		keys, next, err := r.client.Scan(ctx, cursor, prefix+"*", 500)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan cache keys: %w", err)
+		}
+
+		for _, key := range keys {
+			segment := strings.TrimPrefix(key, prefix)
+			if i := strings.Index(segment, ":"); i >= 0 {
+				segment = segment[:i]
+			}
+			stats.KeyCounts[segment]++
+			stats.TotalKeys++
+		}
+
+		cursor = next
+		if cursor == 0 {
+			break
+		}
+	}
+
+	memory, err := r.client.Info(ctx, "memory")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read Redis memory info: %w", err)
+	}
+	for _, line := range strings.Split(memory, "\r\n") {
+		if value, ok := strings.CutPrefix(line, "used_memory:"); ok {
+			stats.UsedMemoryBytes, _ = strconv.ParseInt(value, 10, 64)
+			break
+		}
+	}
+
+	return stats, nil
+}
@@ -0,0 +1,85 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"net"
+	"time"
+
+	"asset-management-api/internal/breaker"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/redis/go-redis/v9"
+)
+
+// ErrCircuitOpen is returned, wrapped, without contacting Redis while the
+// breaker is open
+var ErrCircuitOpen = breaker.ErrOpen
+
+var callBudgetExceededTotal = promauto.NewCounter(
+	prometheus.CounterOpts{
+		Name: "redis_call_budget_exceeded_total",
+		Help: "Total number of Redis calls aborted for exceeding the latency budget",
+	},
+)
+
+// breakerHook applies the latency budget and circuit breaker to every
+// command and pipeline sent through the client
+type breakerHook struct {
+	breaker *breaker.Breaker
+	budget  time.Duration
+}
+
+func (h *breakerHook) DialHook(next redis.DialHook) redis.DialHook {
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		return next(ctx, network, addr)
+	}
+}
+
+func (h *breakerHook) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		return h.guard(ctx, func(ctx context.Context) error {
+			return next(ctx, cmd)
+		})
+	}
+}
+
+func (h *breakerHook) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []redis.Cmder) error {
+		return h.guard(ctx, func(ctx context.Context) error {
+			return next(ctx, cmds)
+		})
+	}
+}
+
+func (h *breakerHook) guard(ctx context.Context, call func(ctx context.Context) error) error {
+	if h.budget > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, h.budget)
+		defer cancel()
+	}
+
+	return h.breaker.Do(func() error {
+		err := call(ctx)
+		if errors.Is(err, context.DeadlineExceeded) || isTimeout(err) {
+			callBudgetExceededTotal.Inc()
+		}
+		return err
+	}, isHealthyReply)
+}
+
+// isHealthyReply reports whether Redis answered, even if with a missing key
+// or a command error such as NOSCRIPT
+func isHealthyReply(err error) bool {
+	if err == nil || errors.Is(err, redis.Nil) {
+		return true
+	}
+	var replyErr redis.Error
+	return errors.As(err, &replyErr)
+}
+
+func isTimeout(err error) bool {
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"log"
 	"strconv"
+	"strings"
 
 	"asset-management-api/internal/models"
 	"asset-management-api/pkg/cache"
@@ -15,15 +16,20 @@ import (
 
 // RedisCacheService implements the CacheService interface using Redis
 type RedisCacheService struct {
-	client *RedisClient
-	keys   cache.CacheKeys
+	client    *RedisClient
+	keys      cache.CacheKeys
+	namespace *cache.KeyNamespace
+	stopWatch chan struct{}
 }
 
-// NewRedisCacheService creates a new Redis cache service
-func NewRedisCacheService(client *RedisClient) *RedisCacheService {
+// NewRedisCacheService creates a new Redis cache service whose keys are
+// prefixed with the given namespace version
+func NewRedisCacheService(client *RedisClient, namespace *cache.KeyNamespace) *RedisCacheService {
 	return &RedisCacheService{
-		client: client,
-		keys:   cache.CacheKeys{},
+		client:    client,
+		keys:      cache.NewCacheKeys(namespace),
+		namespace: namespace,
+		stopWatch: make(chan struct{}),
 	}
 }
 
@@ -31,35 +37,38 @@ func NewRedisCacheService(client *RedisClient) *RedisCacheService {
 func (r *RedisCacheService) CacheTeamMembers(ctx context.Context, teamID uuid.UUID, members []uuid.UUID) error {
 	key := r.keys.TeamMembers(teamID)
 	
-	// Clear existing members
-	if err := r.client.Del(ctx, key); err != nil {
-		log.Printf("Warning: failed to clear existing team members cache: %v", err)
-	}
-	
-	// Add all members to list
-	if len(members) > 0 {
-		memberStrs := make([]interface{}, len(members))
-		for i, member := range members {
-			memberStrs[i] = member.String()
+	// Rebuild under a lock so concurrent handlers on other instances can't interleave
+	return r.client.WithLock(ctx, key, func() error {
+		// Clear existing members
+		if err := r.client.Del(ctx, key); err != nil {
+			log.Printf("Warning: failed to clear existing team members cache: %v", err)
 		}
 		
-		if err := r.client.LPush(ctx, key, memberStrs...); err != nil {
-			return fmt.Errorf("failed to cache team members: %w", err)
+		// Add all members to set
+		if len(members) > 0 {
+			memberStrs := make([]interface{}, len(members))
+			for i, member := range members {
+				memberStrs[i] = member.String()
+			}
+			
+			if err := r.client.SAdd(ctx, key, memberStrs...); err != nil {
+				return fmt.Errorf("failed to cache team members: %w", err)
+			}
+			
+			// Set expiration
+			if err := r.client.Expire(ctx, key, jitterTTL(cache.DefaultTeamMembersTTL)); err != nil {
+				log.Printf("Warning: failed to set expiration for team members cache: %v", err)
+			}
 		}
 		
-		// Set expiration
-		if err := r.client.Expire(ctx, key, cache.DefaultTeamMembersTTL); err != nil {
-			log.Printf("Warning: failed to set expiration for team members cache: %v", err)
-		}
-	}
-	
-	return nil
+		return nil
+	})
 }
 
 func (r *RedisCacheService) GetTeamMembers(ctx context.Context, teamID uuid.UUID) ([]uuid.UUID, error) {
 	key := r.keys.TeamMembers(teamID)
 	
-	memberStrs, err := r.client.LRange(ctx, key, 0, -1)
+	memberStrs, err := r.client.SMembers(ctx, key)
 	if err != nil {
 		if errors.Is(err, redis.Nil) {
 			return nil, nil // Cache miss
@@ -67,41 +76,64 @@ func (r *RedisCacheService) GetTeamMembers(ctx context.Context, teamID uuid.UUID
 		return nil, fmt.Errorf("failed to get team members from cache: %w", err)
 	}
 	
-	members := make([]uuid.UUID, len(memberStrs))
-	for i, memberStr := range memberStrs {
+	if len(memberStrs) == 0 {
+		return nil, nil // Cache miss, Redis never stores an empty set
+	}
+	
+	members := make([]uuid.UUID, 0, len(memberStrs))
+	for _, memberStr := range memberStrs {
 		memberID, err := uuid.Parse(memberStr)
 		if err != nil {
 			log.Printf("Warning: invalid UUID in team members cache: %s", memberStr)
 			continue
 		}
-		members[i] = memberID
+		members = append(members, memberID)
 	}
 	
 	return members, nil
 }
 
-func (r *RedisCacheService) AddTeamMember(ctx context.Context, teamID, memberID uuid.UUID) error {
+func (r *RedisCacheService) IsTeamMember(ctx context.Context, teamID, userID uuid.UUID) (bool, bool, error) {
 	key := r.keys.TeamMembers(teamID)
 	
-	// Check if key exists, if not, skip (cache will be populated on next read)
-	exists, err := r.client.Exists(ctx, key)
-	if err != nil || !exists {
-		return nil
+	// Check existence and membership in a single round trip
+	pipe := r.client.Pipeline()
+	existsCmd := pipe.Exists(ctx, key)
+	isMemberCmd := pipe.SIsMember(ctx, key, userID.String())
+	if _, err := pipe.Exec(ctx); err != nil && !errors.Is(err, redis.Nil) {
+		return false, false, fmt.Errorf("failed to check team membership in cache: %w", err)
 	}
 	
-	// Add member to list
-	if err := r.client.LPush(ctx, key, memberID.String()); err != nil {
-		return fmt.Errorf("failed to add team member to cache: %w", err)
+	if existsCmd.Val() == 0 {
+		return false, false, nil // Cache miss
 	}
 	
-	return nil
+	return isMemberCmd.Val(), true, nil
+}
+
+func (r *RedisCacheService) AddTeamMember(ctx context.Context, teamID, memberID uuid.UUID) error {
+	key := r.keys.TeamMembers(teamID)
+	
+	return r.client.WithLock(ctx, key, func() error {
+		// Check if key exists, if not, skip (cache will be populated on next read)
+		exists, err := r.client.Exists(ctx, key)
+		if err != nil || !exists {
+			return nil
+		}
+		
+		// Add member to set (no-op if already present)
+		if err := r.client.SAdd(ctx, key, memberID.String()); err != nil {
+			return fmt.Errorf("failed to add team member to cache: %w", err)
+		}
+		
+		return nil
+	})
 }
 
 func (r *RedisCacheService) RemoveTeamMember(ctx context.Context, teamID, memberID uuid.UUID) error {
 	key := r.keys.TeamMembers(teamID)
 	
-	// Remove all occurrences of the member
-	if err := r.client.LRem(ctx, key, 0, memberID.String()); err != nil {
+	if err := r.client.SRem(ctx, key, memberID.String()); err != nil {
 		if !errors.Is(err, redis.Nil) {
 			return fmt.Errorf("failed to remove team member from cache: %w", err)
 		}
@@ -110,16 +142,142 @@ func (r *RedisCacheService) RemoveTeamMember(ctx context.Context, teamID, member
 	return nil
 }
 
+// MigrateTeamMemberKeys converts team member keys written by the old
+// List-based implementation into Sets, preserving their remaining TTL.
+func (r *RedisCacheService) MigrateTeamMemberKeys(ctx context.Context) (int, error) {
+	pattern := r.keys.TeamMembers(uuid.Nil)
+	pattern = strings.Replace(pattern, uuid.Nil.String(), "*", 1)
+	
+	migrated := 0
+	var cursor uint64
+	for {
+		keys, next, err := r.client.Scan(ctx, cursor, pattern, 100)
+		if err != nil {
+			return migrated, fmt.Errorf("failed to scan team member keys: %w", err)
+		}
+		
+		for _, key := range keys {
+			err := r.client.WithLock(ctx, key, func() error {
+				keyType, err := r.client.Type(ctx, key)
+				if err != nil || keyType != "list" {
+					return err
+				}
+				
+				memberStrs, err := r.client.LRange(ctx, key, 0, -1)
+				if err != nil {
+					return fmt.Errorf("failed to read legacy team members list: %w", err)
+				}
+				ttl, _ := r.client.TTL(ctx, key)
+				if ttl <= 0 {
+					ttl = jitterTTL(cache.DefaultTeamMembersTTL)
+				}
+				
+				pipe := r.client.TxPipeline()
+				pipe.Del(ctx, key)
+				if len(memberStrs) > 0 {
+					members := make([]interface{}, len(memberStrs))
+					for i, memberStr := range memberStrs {
+						members[i] = memberStr
+					}
+					pipe.SAdd(ctx, key, members...)
+					pipe.Expire(ctx, key, ttl)
+				}
+				if _, err := pipe.Exec(ctx); err != nil {
+					return err
+				}
+				migrated++
+				return nil
+			})
+			if err != nil {
+				log.Printf("Warning: failed to migrate team members key %s: %v", key, err)
+			}
+		}
+		
+		cursor = next
+		if cursor == 0 {
+			break
+		}
+	}
+	
+	return migrated, nil
+}
+
 func (r *RedisCacheService) InvalidateTeamMembers(ctx context.Context, teamID uuid.UUID) error {
 	key := r.keys.TeamMembers(teamID)
 	return r.client.Del(ctx, key)
 }
 
+// User teams caching methods
+func (r *RedisCacheService) CacheUserTeams(ctx context.Context, userID uuid.UUID, teams []*models.Team) error {
+	key := r.keys.UserTeams(userID)
+	
+	if teams == nil {
+		teams = []*models.Team{} // Cache "no teams" as a hit, not a miss
+	}
+	
+	if err := r.client.SetJSON(ctx, key, teams, jitterTTL(cache.DefaultUserTeamsTTL)); err != nil {
+		return fmt.Errorf("failed to cache user teams: %w", err)
+	}
+	
+	return nil
+}
+
+func (r *RedisCacheService) GetUserTeams(ctx context.Context, userID uuid.UUID) ([]*models.Team, error) {
+	key := r.keys.UserTeams(userID)
+	
+	var teams []*models.Team
+	err := r.client.GetJSON(ctx, key, &teams)
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, nil // Cache miss
+		}
+		return nil, fmt.Errorf("failed to get user teams from cache: %w", err)
+	}
+	
+	return teams, nil
+}
+
+func (r *RedisCacheService) InvalidateUserTeams(ctx context.Context, userID uuid.UUID) error {
+	key := r.keys.UserTeams(userID)
+	return r.client.Del(ctx, key)
+}
+
+// User profile caching methods
+func (r *RedisCacheService) CacheUserProfile(ctx context.Context, user *models.User) error {
+	key := r.keys.UserProfile(user.UserID)
+	
+	if err := r.client.SetJSON(ctx, key, user, jitterTTL(cache.DefaultUserProfileTTL)); err != nil {
+		return fmt.Errorf("failed to cache user profile: %w", err)
+	}
+	
+	return nil
+}
+
+func (r *RedisCacheService) GetUserProfile(ctx context.Context, userID uuid.UUID) (*models.User, error) {
+	key := r.keys.UserProfile(userID)
+	
+	var user models.User
+	err := r.client.GetJSON(ctx, key, &user)
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, nil // Cache miss
+		}
+		return nil, fmt.Errorf("failed to get user profile from cache: %w", err)
+	}
+	
+	return &user, nil
+}
+
+func (r *RedisCacheService) InvalidateUserProfile(ctx context.Context, userID uuid.UUID) error {
+	key := r.keys.UserProfile(userID)
+	return r.client.Del(ctx, key)
+}
+
 // Asset metadata caching methods
 func (r *RedisCacheService) CacheFolderMetadata(ctx context.Context, folder *models.Folder) error {
 	key := r.keys.FolderMetadata(folder.FolderID)
 	
-	if err := r.client.SetJSON(ctx, key, folder, cache.DefaultAssetTTL); err != nil {
+	if err := r.client.SetJSON(ctx, key, folder, jitterTTL(cache.DefaultAssetTTL)); err != nil {
 		return fmt.Errorf("failed to cache folder metadata: %w", err)
 	}
 	
@@ -144,7 +302,7 @@ func (r *RedisCacheService) GetFolderMetadata(ctx context.Context, folderID uuid
 func (r *RedisCacheService) CacheNoteMetadata(ctx context.Context, note *models.Note) error {
 	key := r.keys.NoteMetadata(note.NoteID)
 	
-	if err := r.client.SetJSON(ctx, key, note, cache.DefaultAssetTTL); err != nil {
+	if err := r.client.SetJSON(ctx, key, note, jitterTTL(cache.DefaultAssetTTL)); err != nil {
 		return fmt.Errorf("failed to cache note metadata: %w", err)
 	}
 	
@@ -166,6 +324,74 @@ func (r *RedisCacheService) GetNoteMetadata(ctx context.Context, noteID uuid.UUI
 	return &note, nil
 }
 
+// GetFolderMetadataBatch fetches many folders with a single MGET
+func (r *RedisCacheService) GetFolderMetadataBatch(ctx context.Context, folderIDs []uuid.UUID) (map[uuid.UUID]*models.Folder, error) {
+	folders := make(map[uuid.UUID]*models.Folder, len(folderIDs))
+	if len(folderIDs) == 0 {
+		return folders, nil
+	}
+	
+	keys := make([]string, len(folderIDs))
+	for i, folderID := range folderIDs {
+		keys[i] = r.keys.FolderMetadata(folderID)
+	}
+	
+	values, err := r.client.MGet(ctx, keys...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to batch get folder metadata from cache: %w", err)
+	}
+	
+	for i, value := range values {
+		jsonStr, ok := value.(string)
+		if !ok {
+			continue // Cache miss
+		}
+		
+		var folder models.Folder
+		if err := DecodeJSON(jsonStr, &folder); err != nil {
+			log.Printf("Warning: invalid folder metadata in cache for %s: %v", folderIDs[i], err)
+			continue
+		}
+		folders[folderIDs[i]] = &folder
+	}
+	
+	return folders, nil
+}
+
+// GetNoteMetadataBatch fetches many notes with a single MGET
+func (r *RedisCacheService) GetNoteMetadataBatch(ctx context.Context, noteIDs []uuid.UUID) (map[uuid.UUID]*models.Note, error) {
+	notes := make(map[uuid.UUID]*models.Note, len(noteIDs))
+	if len(noteIDs) == 0 {
+		return notes, nil
+	}
+	
+	keys := make([]string, len(noteIDs))
+	for i, noteID := range noteIDs {
+		keys[i] = r.keys.NoteMetadata(noteID)
+	}
+	
+	values, err := r.client.MGet(ctx, keys...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to batch get note metadata from cache: %w", err)
+	}
+	
+	for i, value := range values {
+		jsonStr, ok := value.(string)
+		if !ok {
+			continue // Cache miss
+		}
+		
+		var note models.Note
+		if err := DecodeJSON(jsonStr, &note); err != nil {
+			log.Printf("Warning: invalid note metadata in cache for %s: %v", noteIDs[i], err)
+			continue
+		}
+		notes[noteIDs[i]] = &note
+	}
+	
+	return notes, nil
+}
+
 func (r *RedisCacheService) InvalidateFolderMetadata(ctx context.Context, folderID uuid.UUID) error {
 	key := r.keys.FolderMetadata(folderID)
 	return r.client.Del(ctx, key)
@@ -186,18 +412,44 @@ func (r *RedisCacheService) CacheAssetACL(ctx context.Context, assetID uuid.UUID
 		fields = append(fields, userID, accessLevel)
 	}
 	
-	if len(fields) > 0 {
+	if len(fields) == 0 {
+		return nil
+	}
+	
+	return r.client.WithLock(ctx, key, func() error {
 		if err := r.client.HSet(ctx, key, fields...); err != nil {
 			return fmt.Errorf("failed to cache asset ACL: %w", err)
 		}
 		
 		// Set expiration
-		if err := r.client.Expire(ctx, key, cache.DefaultACLTTL); err != nil {
+		if err := r.client.Expire(ctx, key, jitterTTL(cache.DefaultACLTTL)); err != nil {
 			log.Printf("Warning: failed to set expiration for asset ACL cache: %v", err)
 		}
+		
+		return nil
+	})
+}
+
+func (r *RedisCacheService) ReplaceAssetACL(ctx context.Context, assetID uuid.UUID, acl map[string]string) error {
+	key := r.keys.AssetACL(assetID)
+
+	fields := make([]interface{}, 0, len(acl)*2)
+	for userID, accessLevel := range acl {
+		fields = append(fields, userID, accessLevel)
 	}
-	
-	return nil
+
+	return r.client.WithLock(ctx, key, func() error {
+		pipe := r.client.TxPipeline()
+		pipe.Del(ctx, key)
+		if len(fields) > 0 {
+			pipe.HSet(ctx, key, fields...)
+			pipe.Expire(ctx, key, jitterTTL(cache.DefaultACLTTL))
+		}
+		if _, err := pipe.Exec(ctx); err != nil {
+			return fmt.Errorf("failed to replace asset ACL in cache: %w", err)
+		}
+		return nil
+	})
 }
 
 func (r *RedisCacheService) GetAssetACL(ctx context.Context, assetID uuid.UUID) (map[string]string, error) {
@@ -221,18 +473,20 @@ func (r *RedisCacheService) GetAssetACL(ctx context.Context, assetID uuid.UUID)
 func (r *RedisCacheService) UpdateAssetACL(ctx context.Context, assetID, userID uuid.UUID, accessLevel string) error {
 	key := r.keys.AssetACL(assetID)
 	
-	// Check if key exists
-	exists, err := r.client.Exists(ctx, key)
-	if err != nil || !exists {
-		return nil // Cache doesn't exist, skip update
-	}
-	
-	// Update the specific user's access level
-	if err := r.client.HSet(ctx, key, userID.String(), accessLevel); err != nil {
-		return fmt.Errorf("failed to update asset ACL in cache: %w", err)
-	}
-	
-	return nil
+	return r.client.WithLock(ctx, key, func() error {
+		// Check if key exists
+		exists, err := r.client.Exists(ctx, key)
+		if err != nil || !exists {
+			return nil // Cache doesn't exist, skip update
+		}
+		
+		// Update the specific user's access level
+		if err := r.client.HSet(ctx, key, userID.String(), accessLevel); err != nil {
+			return fmt.Errorf("failed to update asset ACL in cache: %w", err)
+		}
+		
+		return nil
+	})
 }
 
 func (r *RedisCacheService) RemoveAssetACL(ctx context.Context, assetID, userID uuid.UUID) error {
@@ -254,9 +508,12 @@ func (r *RedisCacheService) InvalidateAssetACL(ctx context.Context, assetID uuid
 
 // Health check and cleanup
 func (r *RedisCacheService) HealthCheck() map[string]interface{} {
-	return r.client.Health()
+	health := r.client.Health()
+	health["key_version"] = r.namespace.Version()
+	return health
 }
 
 func (r *RedisCacheService) Close() error {
+	close(r.stopWatch)
 	return r.client.Close()
 }
\ No newline at end of file
@@ -7,20 +7,37 @@ import (
 	"log"
 	"time"
 
+	"asset-management-api/internal/breaker"
+	"asset-management-api/internal/cache/codec"
+	"github.com/redis/go-redis/extra/redisotel/v9"
 	"github.com/redis/go-redis/v9"
 )
 
 // RedisClient wraps the Redis client with additional functionality
 type RedisClient struct {
 	client *redis.Client
-	config *RedisConfig
+	config  *RedisConfig
+	codec   *codec.Codec
+	breaker *breaker.Breaker
 }
 
 // NewRedisClient creates a new Redis client instance
 func NewRedisClient(config *RedisConfig) (*RedisClient, error) {
+	payloadCodec, err := codec.New(config.Compression, config.CompressionThreshold)
+	if err != nil {
+		return nil, err
+	}
+
+	tlsConfig, err := config.BuildTLSConfig()
+	if err != nil {
+		return nil, err
+	}
+
 	rdb := redis.NewClient(&redis.Options{
 		Addr:               config.GetRedisAddress(),
+		Username:           config.Username,
 		Password:           config.Password,
+		TLSConfig:          tlsConfig,
 		DB:                 config.Database,
 		PoolSize:           config.PoolSize,
 		MinIdleConns:       config.MinIdleConns,
@@ -33,6 +50,9 @@ func NewRedisClient(config *RedisConfig) (*RedisClient, error) {
 		ReadTimeout:        config.ReadTimeout,
 		WriteTimeout:       config.WriteTimeout,
 		DialTimeout:        config.DialTimeout,
+
+		// Honour the per-call latency budget applied by the breaker hook
+		ContextTimeoutEnabled: true,
 	})
 
 	// Test connection
@@ -44,10 +64,22 @@ func NewRedisClient(config *RedisConfig) (*RedisClient, error) {
 	}
 
 	log.Printf("Successfully connected to Redis at %s", config.GetRedisAddress())
+
+	// Record a span per command; values are left out of the spans as cached
+	// payloads can be large and hold user data
+	if err := redisotel.InstrumentTracing(rdb, redisotel.WithDBStatement(false)); err != nil {
+		return nil, fmt.Errorf("failed to instrument Redis tracing: %w", err)
+	}
+
+	// Installed after the connection test so startup is not cut short by the budget
+	redisBreaker := breaker.New("redis", config.BreakerFailureThreshold, config.BreakerOpenTimeout)
+	rdb.AddHook(&breakerHook{breaker: redisBreaker, budget: config.CallBudget})
 	
 	return &RedisClient{
-		client: rdb,
-		config: config,
+		client:  rdb,
+		config:  config,
+		codec:   payloadCodec,
+		breaker: redisBreaker,
 	}, nil
 }
 
@@ -56,6 +88,12 @@ func (r *RedisClient) Close() error {
 	return r.client.Close()
 }
 
+// Ping checks that Redis answers, bypassing the circuit breaker so the check
+// sees Redis itself
+func (r *RedisClient) Ping(ctx context.Context) error {
+	return r.client.Ping(ctx).Err()
+}
+
 // Health returns the health status of Redis connection
 func (r *RedisClient) Health() map[string]interface{} {
 	ctx := context.Background()
@@ -69,6 +107,8 @@ func (r *RedisClient) Health() map[string]interface{} {
 		"latency_ms": latency.Milliseconds(),
 		"address":    r.config.GetRedisAddress(),
 		"database":   r.config.Database,
+		"circuit_breaker": r.breaker.State().String(),
+		"tls":        r.config.TLS.Enabled,
 	}
 	
 	if err != nil {
@@ -88,10 +128,22 @@ func (r *RedisClient) Get(ctx context.Context, key string) (string, error) {
 	return r.client.Get(ctx, key).Result()
 }
 
+func (r *RedisClient) MGet(ctx context.Context, keys ...string) ([]interface{}, error) {
+	return r.client.MGet(ctx, keys...).Result()
+}
+
 func (r *RedisClient) Del(ctx context.Context, keys ...string) error {
 	return r.client.Del(ctx, keys...).Err()
 }
 
+func (r *RedisClient) SetNX(ctx context.Context, key string, value interface{}, expiration time.Duration) (bool, error) {
+	return r.client.SetNX(ctx, key, value, expiration).Result()
+}
+
+func (r *RedisClient) Incr(ctx context.Context, key string) (int64, error) {
+	return r.client.Incr(ctx, key).Result()
+}
+
 func (r *RedisClient) Exists(ctx context.Context, key string) (bool, error) {
 	count, err := r.client.Exists(ctx, key).Result()
 	return count > 0, err
@@ -103,7 +155,7 @@ func (r *RedisClient) SetJSON(ctx context.Context, key string, value interface{}
 	if err != nil {
 		return fmt.Errorf("failed to marshal JSON: %w", err)
 	}
-	return r.Set(ctx, key, jsonBytes, expiration)
+	return r.Set(ctx, key, r.codec.Encode(jsonBytes), expiration)
 }
 
 func (r *RedisClient) GetJSON(ctx context.Context, key string, dest interface{}) error {
@@ -111,7 +163,16 @@ func (r *RedisClient) GetJSON(ctx context.Context, key string, dest interface{})
 	if err != nil {
 		return err
 	}
-	return json.Unmarshal([]byte(jsonStr), dest)
+	return DecodeJSON(jsonStr, dest)
+}
+
+// DecodeJSON unmarshals a raw value written by SetJSON, decompressing it if needed
+func DecodeJSON(raw string, dest interface{}) error {
+	jsonBytes, err := codec.Decode([]byte(raw))
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(jsonBytes, dest)
 }
 
 // List methods
@@ -131,6 +192,36 @@ func (r *RedisClient) LLen(ctx context.Context, key string) (int64, error) {
 	return r.client.LLen(ctx, key).Result()
 }
 
+// Set methods
+func (r *RedisClient) SAdd(ctx context.Context, key string, members ...interface{}) error {
+	return r.client.SAdd(ctx, key, members...).Err()
+}
+
+func (r *RedisClient) SRem(ctx context.Context, key string, members ...interface{}) error {
+	return r.client.SRem(ctx, key, members...).Err()
+}
+
+func (r *RedisClient) SMembers(ctx context.Context, key string) ([]string, error) {
+	return r.client.SMembers(ctx, key).Result()
+}
+
+func (r *RedisClient) SIsMember(ctx context.Context, key string, member interface{}) (bool, error) {
+	return r.client.SIsMember(ctx, key, member).Result()
+}
+
+// Key inspection methods
+func (r *RedisClient) Type(ctx context.Context, key string) (string, error) {
+	return r.client.Type(ctx, key).Result()
+}
+
+func (r *RedisClient) TTL(ctx context.Context, key string) (time.Duration, error) {
+	return r.client.TTL(ctx, key).Result()
+}
+
+func (r *RedisClient) Scan(ctx context.Context, cursor uint64, match string, count int64) ([]string, uint64, error) {
+	return r.client.Scan(ctx, cursor, match, count).Result()
+}
+
 // Hash methods
 func (r *RedisClient) HSet(ctx context.Context, key string, values ...interface{}) error {
 	return r.client.HSet(ctx, key, values...).Err()
@@ -157,6 +248,20 @@ func (r *RedisClient) Expire(ctx context.Context, key string, expiration time.Du
 	return r.client.Expire(ctx, key, expiration).Err()
 }
 
+// Info returns the raw INFO output for the given section
+func (r *RedisClient) Info(ctx context.Context, section string) (string, error) {
+	return r.client.Info(ctx, section).Result()
+}
+
+// Pub/sub methods
+func (r *RedisClient) Publish(ctx context.Context, channel string, message interface{}) error {
+	return r.client.Publish(ctx, channel, message).Err()
+}
+
+func (r *RedisClient) Subscribe(ctx context.Context, channels ...string) *redis.PubSub {
+	return r.client.Subscribe(ctx, channels...)
+}
+
 // Pipeline for batch operations
 func (r *RedisClient) Pipeline() redis.Pipeliner {
 	return r.client.Pipeline()
@@ -1,6 +1,9 @@
 package redis
 
 import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
 	"os"
 	"strconv"
 	"time"
@@ -10,6 +13,7 @@ import (
 type RedisConfig struct {
 	Host               string
 	Port               string
+	Username           string // ACL user (Redis 6+); empty uses the default user
 	Password           string
 	Database           int
 	PoolSize           int
@@ -23,6 +27,27 @@ type RedisConfig struct {
 	ReadTimeout        time.Duration
 	WriteTimeout       time.Duration
 	DialTimeout        time.Duration
+
+	// Compression of JSON payloads: "none", "snappy" or "zstd"
+	Compression          string
+	CompressionThreshold int
+
+	// Per-call latency budget and circuit breaker
+	CallBudget              time.Duration
+	BreakerFailureThreshold int
+	BreakerOpenTimeout      time.Duration
+
+	TLS RedisTLSConfig
+}
+
+// RedisTLSConfig holds TLS settings for managed Redis services
+type RedisTLSConfig struct {
+	Enabled            bool
+	CAFile             string
+	CertFile           string
+	KeyFile            string
+	ServerName         string
+	InsecureSkipVerify bool
 }
 
 // LoadRedisConfig loads Redis configuration from environment variables
@@ -30,6 +55,7 @@ func LoadRedisConfig() *RedisConfig {
 	return &RedisConfig{
 		Host:               getEnv("REDIS_HOST", "localhost"),
 		Port:               getEnv("REDIS_PORT", "6379"),
+		Username:           getEnv("REDIS_USERNAME", ""),
 		Password:           getEnv("REDIS_PASSWORD", ""),
 		Database:           getIntEnv("REDIS_DATABASE", 0),
 		PoolSize:           getIntEnv("REDIS_POOL_SIZE", 10),
@@ -43,7 +69,61 @@ func LoadRedisConfig() *RedisConfig {
 		ReadTimeout:        getDurationEnv("REDIS_READ_TIMEOUT", 3*time.Second),
 		WriteTimeout:       getDurationEnv("REDIS_WRITE_TIMEOUT", 3*time.Second),
 		DialTimeout:        getDurationEnv("REDIS_DIAL_TIMEOUT", 5*time.Second),
+
+		Compression:          getEnv("CACHE_COMPRESSION", "none"),
+		CompressionThreshold: getIntEnv("CACHE_COMPRESSION_THRESHOLD", 1024),
+
+		CallBudget:              getDurationEnv("REDIS_CALL_BUDGET", 50*time.Millisecond),
+		BreakerFailureThreshold: getIntEnv("REDIS_BREAKER_FAILURE_THRESHOLD", 5),
+		BreakerOpenTimeout:      getDurationEnv("REDIS_BREAKER_OPEN_TIMEOUT", 10*time.Second),
+
+		TLS: RedisTLSConfig{
+			Enabled:            getBoolEnv("REDIS_TLS_ENABLED", false),
+			CAFile:             getEnv("REDIS_TLS_CA_FILE", ""),
+			CertFile:           getEnv("REDIS_TLS_CERT_FILE", ""),
+			KeyFile:            getEnv("REDIS_TLS_KEY_FILE", ""),
+			ServerName:         getEnv("REDIS_TLS_SERVER_NAME", ""),
+			InsecureSkipVerify: getBoolEnv("REDIS_TLS_INSECURE_SKIP_VERIFY", false),
+		},
+	}
+}
+
+// BuildTLSConfig returns the client TLS configuration, or nil when TLS is disabled
+func (c *RedisConfig) BuildTLSConfig() (*tls.Config, error) {
+	if !c.TLS.Enabled {
+		return nil, nil
+	}
+
+	tlsConfig := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		ServerName:         c.TLS.ServerName,
+		InsecureSkipVerify: c.TLS.InsecureSkipVerify,
+	}
+	if tlsConfig.ServerName == "" {
+		tlsConfig.ServerName = c.Host
 	}
+
+	if c.TLS.CAFile != "" {
+		caCert, err := os.ReadFile(c.TLS.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read Redis CA file: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caCert) {
+			return nil, fmt.Errorf("no valid certificates in Redis CA file %s", c.TLS.CAFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if c.TLS.CertFile != "" || c.TLS.KeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(c.TLS.CertFile, c.TLS.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load Redis client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	return tlsConfig, nil
 }
 
 // GetRedisAddress returns the full Redis address
@@ -59,6 +139,15 @@ func getEnv(key, defaultValue string) string {
 	return defaultValue
 }
 
+func getBoolEnv(key string, defaultValue bool) bool {
+	if value := os.Getenv(key); value != "" {
+		if boolValue, err := strconv.ParseBool(value); err == nil {
+			return boolValue
+		}
+	}
+	return defaultValue
+}
+
 func getIntEnv(key string, defaultValue int) int {
 	if value := os.Getenv(key); value != "" {
 		if intValue, err := strconv.Atoi(value); err == nil {
@@ -0,0 +1,55 @@
+package redis
+
+import (
+	"context"
+	"time"
+
+	"asset-management-api/pkg/eventbus"
+)
+
+// dedupKeyPrefix namespaces dedup markers; they are not cache entries and
+// survive key version bumps
+const dedupKeyPrefix = "eventbus:dedup:"
+
+// Marker values; a processing claim expires on its own if the consumer dies
+const (
+	dedupProcessing = "processing"
+	dedupDone       = "done"
+)
+
+// RedisDeduplicator implements eventbus.Deduplicator with SET NX markers.
+// A claim is held for processingTTL while the handler runs, then replaced
+// by a "done" marker kept for ttl.
+type RedisDeduplicator struct {
+	client        *RedisClient
+	ttl           time.Duration
+	processingTTL time.Duration
+}
+
+var _ eventbus.Deduplicator = (*RedisDeduplicator)(nil)
+
+// NewRedisDeduplicator creates a deduplicator remembering handled events for ttl
+func NewRedisDeduplicator(client *RedisClient, ttl, processingTTL time.Duration) *RedisDeduplicator {
+	return &RedisDeduplicator{
+		client:        client,
+		ttl:           ttl,
+		processingTTL: processingTTL,
+	}
+}
+
+func (d *RedisDeduplicator) Claim(ctx context.Context, scope, eventID string) (bool, error) {
+	return d.client.client.SetNX(ctx, dedupKey(scope, eventID), dedupProcessing, d.processingTTL).Result()
+}
+
+func (d *RedisDeduplicator) Complete(ctx context.Context, scope, eventID string) error {
+	return d.client.client.Set(ctx, dedupKey(scope, eventID), dedupDone, d.ttl).Err()
+}
+
+// Release only drops an unfinished claim, never a "done" marker
+func (d *RedisDeduplicator) Release(ctx context.Context, scope, eventID string) error {
+	return releaseScript.Run(ctx, d.client.client, []string{dedupKey(scope, eventID)}, dedupProcessing).Err()
+}
+
+func dedupKey(scope, eventID string) string {
+	return dedupKeyPrefix + scope + ":" + eventID
+}
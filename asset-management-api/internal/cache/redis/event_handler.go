@@ -13,7 +13,8 @@ import (
 
 // CacheEventHandler handles cache invalidation based on Kafka events
 type CacheEventHandler struct {
-	cacheService cache.CacheService
+	cacheService  cache.CacheService
+	responseCache cache.ResponseCache
 }
 
 // NewCacheEventHandler creates a new cache event handler
@@ -23,8 +24,35 @@ func NewCacheEventHandler(cacheService cache.CacheService) *CacheEventHandler {
 	}
 }
 
+// SetResponseCache makes the handler invalidate cached API responses that
+// show the changed data
+func (h *CacheEventHandler) SetResponseCache(responseCache cache.ResponseCache) {
+	h.responseCache = responseCache
+}
+
+// openPayload unwraps the event envelope and returns the payload. Payloads are
+// decoded into the current event structs whatever the schema version: fields
+// added by newer producers are ignored and fields missing from older ones are
+// left zero, so only renamed or retyped fields need a version switch here.
+func openPayload(eventData []byte) ([]byte, error) {
+	envelope, err := types.OpenEnvelope(eventData)
+	if err != nil {
+		return nil, err
+	}
+	if envelope.SchemaVersion > types.CurrentSchemaVersion {
+		log.Printf("Event %s (%s) has schema version %d, newer than supported version %d; decoding known fields only",
+			envelope.EventID, envelope.EventType, envelope.SchemaVersion, types.CurrentSchemaVersion)
+	}
+	return envelope.Payload, nil
+}
+
 // HandleTeamEvent processes team-related events for cache invalidation/updates
 func (h *CacheEventHandler) HandleTeamEvent(ctx context.Context, eventData []byte) error {
+	eventData, err := openPayload(eventData)
+	if err != nil {
+		return err
+	}
+
 	// Parse the base event to get event type
 	var baseEvent struct {
 		EventType string `json:"eventType"`
@@ -68,15 +96,89 @@ func (h *CacheEventHandler) handleTeamCreated(ctx context.Context, eventData []b
 	}
 	
 	log.Printf("Cached team members for new team %s (%d members)", event.TeamID, len(allMembers))
+	
+	// Everyone on the new team now has a different team list
+	scopes := make([]string, 0, len(allMembers))
+	for _, userID := range allMembers {
+		h.invalidateUserTeams(ctx, userID)
+		scopes = append(scopes, cache.UserResponseScope(userID))
+	}
+	h.invalidateResponses(ctx, scopes...)
 	return nil
 }
 
+// invalidateUserTeams drops a user's cached team list after a membership change
+func (h *CacheEventHandler) invalidateUserTeams(ctx context.Context, userID uuid.UUID) {
+	if err := h.cacheService.InvalidateUserTeams(ctx, userID); err != nil {
+		log.Printf("Failed to invalidate cached teams for user %s: %v", userID, err)
+	}
+}
+
+// invalidateMembershipResponses drops the cached responses showing a team's
+// members and the changed user's teams
+func (h *CacheEventHandler) invalidateMembershipResponses(ctx context.Context, teamID, userID uuid.UUID) {
+	h.invalidateResponses(ctx, cache.TeamResponseScope(teamID), cache.UserResponseScope(userID))
+}
+
+// invalidateResponses drops cached API responses of the scopes. A failure
+// only leaves them stale until their short TTL runs out.
+func (h *CacheEventHandler) invalidateResponses(ctx context.Context, scopes ...string) {
+	if h.responseCache == nil || len(scopes) == 0 {
+		return
+	}
+	if err := h.responseCache.Invalidate(ctx, scopes...); err != nil {
+		log.Printf("Failed to invalidate cached responses for %v: %v", scopes, err)
+	}
+}
+
+// invalidateAssetResponses drops the cached responses of everyone an asset
+// change shows up for: its owner, the user who made it, the given users and
+// the users on the cached ACL of aclAssetID, if not uuid.Nil
+func (h *CacheEventHandler) invalidateAssetResponses(ctx context.Context, event types.BaseAssetEvent, aclAssetID uuid.UUID, users ...uuid.UUID) {
+	if h.responseCache == nil {
+		return
+	}
+
+	users = append(users, event.OwnerID, event.ActionBy)
+	if aclAssetID != uuid.Nil {
+		acl, err := h.cacheService.GetAssetACL(ctx, aclAssetID)
+		if err == nil {
+			users = append(users, aclUsers(acl)...)
+		}
+	}
+
+	seen := make(map[uuid.UUID]bool, len(users))
+	scopes := make([]string, 0, len(users))
+	for _, userID := range users {
+		if userID == uuid.Nil || seen[userID] {
+			continue
+		}
+		seen[userID] = true
+		scopes = append(scopes, cache.UserResponseScope(userID))
+	}
+	h.invalidateResponses(ctx, scopes...)
+}
+
+// aclUsers returns the users of an ACL, skipping malformed entries
+func aclUsers(acl map[string]string) []uuid.UUID {
+	users := make([]uuid.UUID, 0, len(acl))
+	for userID := range acl {
+		if id, err := uuid.Parse(userID); err == nil {
+			users = append(users, id)
+		}
+	}
+	return users
+}
+
 func (h *CacheEventHandler) handleMemberAdded(ctx context.Context, eventData []byte) error {
 	var event types.MemberChangedEvent
 	if err := json.Unmarshal(eventData, &event); err != nil {
 		return fmt.Errorf("failed to parse member added event: %w", err)
 	}
 	
+	h.invalidateUserTeams(ctx, event.TargetUserID)
+	h.invalidateMembershipResponses(ctx, event.TeamID, event.TargetUserID)
+	
 	// Add member to cache
 	if err := h.cacheService.AddTeamMember(ctx, event.TeamID, event.TargetUserID); err != nil {
 		log.Printf("Failed to add team member to cache for team %s: %v", event.TeamID, err)
@@ -94,6 +196,9 @@ func (h *CacheEventHandler) handleMemberRemoved(ctx context.Context, eventData [
 		return fmt.Errorf("failed to parse member removed event: %w", err)
 	}
 	
+	h.invalidateUserTeams(ctx, event.TargetUserID)
+	h.invalidateMembershipResponses(ctx, event.TeamID, event.TargetUserID)
+	
 	// Remove member from cache
 	if err := h.cacheService.RemoveTeamMember(ctx, event.TeamID, event.TargetUserID); err != nil {
 		log.Printf("Failed to remove team member from cache for team %s: %v", event.TeamID, err)
@@ -111,6 +216,9 @@ func (h *CacheEventHandler) handleManagerAdded(ctx context.Context, eventData []
 		return fmt.Errorf("failed to parse manager added event: %w", err)
 	}
 	
+	h.invalidateUserTeams(ctx, event.TargetUserID)
+	h.invalidateMembershipResponses(ctx, event.TeamID, event.TargetUserID)
+	
 	// Managers are also considered team members for caching purposes
 	if err := h.cacheService.AddTeamMember(ctx, event.TeamID, event.TargetUserID); err != nil {
 		log.Printf("Failed to add team manager to cache for team %s: %v", event.TeamID, err)
@@ -127,6 +235,9 @@ func (h *CacheEventHandler) handleManagerRemoved(ctx context.Context, eventData
 		return fmt.Errorf("failed to parse manager removed event: %w", err)
 	}
 	
+	h.invalidateUserTeams(ctx, event.TargetUserID)
+	h.invalidateMembershipResponses(ctx, event.TeamID, event.TargetUserID)
+	
 	// Remove manager from team members cache
 	if err := h.cacheService.RemoveTeamMember(ctx, event.TeamID, event.TargetUserID); err != nil {
 		log.Printf("Failed to remove team manager from cache for team %s: %v", event.TeamID, err)
@@ -139,6 +250,11 @@ func (h *CacheEventHandler) handleManagerRemoved(ctx context.Context, eventData
 
 // HandleAssetEvent processes asset-related events for cache invalidation/updates
 func (h *CacheEventHandler) HandleAssetEvent(ctx context.Context, eventData []byte) error {
+	eventData, err := openPayload(eventData)
+	if err != nil {
+		return err
+	}
+
 	// Parse the base event to get event type
 	var baseEvent struct {
 		EventType string `json:"eventType"`
@@ -172,7 +288,9 @@ func (h *CacheEventHandler) handleAssetCreated(ctx context.Context, eventData []
 		return fmt.Errorf("failed to parse asset created event: %w", err)
 	}
 	
-	// No need to cache on creation, cache will be populated on first read
+	// No need to cache on creation, cache will be populated on first read.
+	// A new note also shows up for everyone its folder is shared with.
+	h.invalidateAssetResponses(ctx, event.BaseAssetEvent, event.FolderID)
 	log.Printf("Asset %s (%s) created: %s", assetType, event.AssetID, event.Name)
 	return nil
 }
@@ -183,6 +301,8 @@ func (h *CacheEventHandler) handleAssetUpdated(ctx context.Context, eventData []
 		return fmt.Errorf("failed to parse asset updated event: %w", err)
 	}
 	
+	h.invalidateAssetResponses(ctx, event.BaseAssetEvent, event.AssetID)
+
 	// Invalidate metadata cache since asset was updated
 	if assetType == types.AssetTypeFolder {
 		if err := h.cacheService.InvalidateFolderMetadata(ctx, event.AssetID); err != nil {
@@ -204,6 +324,9 @@ func (h *CacheEventHandler) handleAssetDeleted(ctx context.Context, eventData []
 		return fmt.Errorf("failed to parse asset deleted event: %w", err)
 	}
 	
+	// The ACL is still cached here, so the users it was shared with are found
+	h.invalidateAssetResponses(ctx, event.BaseAssetEvent, event.AssetID)
+
 	// Invalidate all caches related to this asset
 	if assetType == types.AssetTypeFolder {
 		if err := h.cacheService.InvalidateFolderMetadata(ctx, event.AssetID); err != nil {
@@ -229,6 +352,10 @@ func (h *CacheEventHandler) handleAssetShared(ctx context.Context, eventData []b
 	if err := json.Unmarshal(eventData, &event); err != nil {
 		return fmt.Errorf("failed to parse asset shared event: %w", err)
 	}
+	h.invalidateAssetResponses(ctx, event.BaseAssetEvent, uuid.Nil, append(aclUsers(event.ACL), event.SharedWithUserID)...)
+	if event.ACL != nil {
+		return h.replaceACL(ctx, event.AssetID, event.ACL, assetType)
+	}
 	
 	// Update ACL cache
 	if err := h.cacheService.UpdateAssetACL(ctx, event.AssetID, event.SharedWithUserID, event.AccessLevel); err != nil {
@@ -249,6 +376,10 @@ func (h *CacheEventHandler) handleAssetUnshared(ctx context.Context, eventData [
 	if err := json.Unmarshal(eventData, &event); err != nil {
 		return fmt.Errorf("failed to parse asset unshared event: %w", err)
 	}
+	h.invalidateAssetResponses(ctx, event.BaseAssetEvent, uuid.Nil, append(aclUsers(event.ACL), event.UnsharedFromUserID)...)
+	if event.ACL != nil {
+		return h.replaceACL(ctx, event.AssetID, event.ACL, assetType)
+	}
 	
 	// Remove user from ACL cache
 	if err := h.cacheService.RemoveAssetACL(ctx, event.AssetID, event.UnsharedFromUserID); err != nil {
@@ -262,4 +393,45 @@ func (h *CacheEventHandler) handleAssetUnshared(ctx context.Context, eventData [
 	log.Printf("Removed user %s from ACL cache for %s %s", 
 		event.UnsharedFromUserID, assetType, event.AssetID)
 	return nil
-}
\ No newline at end of file
+}
+
+// replaceACL rebuilds the cached ACL from an event's snapshot, which stays
+// correct even if earlier share events were missed
+func (h *CacheEventHandler) replaceACL(ctx context.Context, assetID uuid.UUID, acl types.ACLSnapshot, assetType string) error {
+	if err := h.cacheService.ReplaceAssetACL(ctx, assetID, acl); err != nil {
+		log.Printf("Failed to replace asset ACL cache for %s: %v", assetID, err)
+		// Invalidate ACL cache as fallback
+		if err := h.cacheService.InvalidateAssetACL(ctx, assetID); err != nil {
+			log.Printf("Failed to invalidate asset ACL cache for %s: %v", assetID, err)
+		}
+	}
+
+	log.Printf("Rebuilt ACL cache for %s %s from snapshot with %d entries", assetType, assetID, len(acl))
+	return nil
+}
+
+// HandleUserEvent processes user-related events for cache invalidation
+func (h *CacheEventHandler) HandleUserEvent(ctx context.Context, eventData []byte) error {
+	eventData, err := openPayload(eventData)
+	if err != nil {
+		return err
+	}
+
+	var event types.UserChangedEvent
+	if err := json.Unmarshal(eventData, &event); err != nil {
+		return fmt.Errorf("failed to parse user event: %w", err)
+	}
+	
+	switch event.EventType {
+	case types.UserUpdated, types.UserRoleChanged, types.UserDeleted:
+		if err := h.cacheService.InvalidateUserProfile(ctx, event.UserID); err != nil {
+			log.Printf("Failed to invalidate user profile cache for %s: %v", event.UserID, err)
+			return err
+		}
+		log.Printf("Invalidated user profile cache for %s (%s)", event.UserID, event.EventType)
+		return nil
+	default:
+		log.Printf("Unknown user event type: %s", event.EventType)
+		return nil
+	}
+}
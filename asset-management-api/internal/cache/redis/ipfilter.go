@@ -0,0 +1,66 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"asset-management-api/pkg/ipfilter"
+)
+
+// denyListKey holds the deny list as a hash of CIDR to entry; like rate limit
+// windows it is not a cache entry and survives key version bumps
+const denyListKey = "ipfilter:deny"
+
+// RedisDenyList implements ipfilter.Store, sharing the deny list between all
+// instances of the service
+type RedisDenyList struct {
+	client *RedisClient
+}
+
+var _ ipfilter.Store = (*RedisDenyList)(nil)
+
+// NewRedisDenyList creates a deny list stored in Redis
+func NewRedisDenyList(client *RedisClient) *RedisDenyList {
+	return &RedisDenyList{client: client}
+}
+
+func (d *RedisDenyList) List(ctx context.Context) ([]ipfilter.Entry, error) {
+	values, err := d.client.client.HGetAll(ctx, denyListKey).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load deny list: %w", err)
+	}
+
+	entries := make([]ipfilter.Entry, 0, len(values))
+	for cidr, value := range values {
+		var entry ipfilter.Entry
+		if err := json.Unmarshal([]byte(value), &entry); err != nil {
+			// Keep blocking the network even if its details are unreadable
+			entry = ipfilter.Entry{}
+		}
+		entry.CIDR = cidr
+		entries = append(entries, entry)
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].CIDR < entries[j].CIDR })
+	return entries, nil
+}
+
+func (d *RedisDenyList) Add(ctx context.Context, entry ipfilter.Entry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to marshal deny list entry: %w", err)
+	}
+	if err := d.client.client.HSet(ctx, denyListKey, entry.CIDR, data).Err(); err != nil {
+		return fmt.Errorf("failed to add %s to deny list: %w", entry.CIDR, err)
+	}
+	return nil
+}
+
+func (d *RedisDenyList) Remove(ctx context.Context, cidr string) (bool, error) {
+	removed, err := d.client.client.HDel(ctx, denyListKey, cidr).Result()
+	if err != nil {
+		return false, fmt.Errorf("failed to remove %s from deny list: %w", cidr, err)
+	}
+	return removed > 0, nil
+}
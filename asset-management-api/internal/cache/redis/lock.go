@@ -0,0 +1,134 @@
+package redis
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// ErrLockNotObtained is returned when a lock could not be acquired before retries ran out
+var ErrLockNotObtained = errors.New("redis: lock not obtained")
+
+// ErrLockNotHeld is returned when releasing or extending a lock that has expired or been taken over
+var ErrLockNotHeld = errors.New("redis: lock not held")
+
+// lockKeyPrefix namespaces lock keys away from the cached data they protect
+const lockKeyPrefix = "lock:"
+
+// Only the holder of the token may delete or extend the lock
+var releaseScript = redis.NewScript(`
+if redis.call("get", KEYS[1]) == ARGV[1] then
+	return redis.call("del", KEYS[1])
+end
+return 0
+`)
+
+var extendScript = redis.NewScript(`
+if redis.call("get", KEYS[1]) == ARGV[1] then
+	return redis.call("pexpire", KEYS[1], ARGV[2])
+end
+return 0
+`)
+
+// LockOptions controls lock expiry and acquisition retries
+type LockOptions struct {
+	TTL        time.Duration
+	RetryDelay time.Duration
+	MaxRetries int
+}
+
+// DefaultLockOptions suits short cache mutation sections
+var DefaultLockOptions = LockOptions{
+	TTL:        5 * time.Second,
+	RetryDelay: 50 * time.Millisecond,
+	MaxRetries: 40,
+}
+
+// Lock is a single-instance Redis lock in the style of Redsync: SET NX PX
+// with a random token, released by a compare-and-delete script
+type Lock struct {
+	client *RedisClient
+	key    string
+	token  string
+	ttl    time.Duration
+}
+
+// ObtainLock acquires the named lock, retrying until opts.MaxRetries is exhausted
+func (r *RedisClient) ObtainLock(ctx context.Context, name string, opts LockOptions) (*Lock, error) {
+	token, err := newLockToken()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate lock token: %w", err)
+	}
+
+	key := lockKeyPrefix + name
+	for attempt := 0; attempt <= opts.MaxRetries; attempt++ {
+		ok, err := r.client.SetNX(ctx, key, token, opts.TTL).Result()
+		if err != nil {
+			return nil, fmt.Errorf("failed to obtain lock %s: %w", key, err)
+		}
+		if ok {
+			return &Lock{client: r, key: key, token: token, ttl: opts.TTL}, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(opts.RetryDelay):
+		}
+	}
+
+	return nil, ErrLockNotObtained
+}
+
+// WithLock runs fn while holding the named lock
+func (r *RedisClient) WithLock(ctx context.Context, name string, fn func() error) error {
+	lock, err := r.ObtainLock(ctx, name, DefaultLockOptions)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := lock.Release(context.Background()); err != nil {
+			log.Printf("Warning: failed to release lock %s: %v", lock.key, err)
+		}
+	}()
+
+	return fn()
+}
+
+// Release frees the lock if it is still held by this token
+func (l *Lock) Release(ctx context.Context) error {
+	released, err := releaseScript.Run(ctx, l.client.client, []string{l.key}, l.token).Int64()
+	if err != nil {
+		return err
+	}
+	if released == 0 {
+		return ErrLockNotHeld
+	}
+	return nil
+}
+
+// Extend resets the lock TTL if it is still held by this token
+func (l *Lock) Extend(ctx context.Context) error {
+	extended, err := extendScript.Run(ctx, l.client.client, []string{l.key}, l.token, l.ttl.Milliseconds()).Int64()
+	if err != nil {
+		return err
+	}
+	if extended == 0 {
+		return ErrLockNotHeld
+	}
+	return nil
+}
+
+func newLockToken() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
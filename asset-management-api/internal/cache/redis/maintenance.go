@@ -0,0 +1,65 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"asset-management-api/pkg/maintenance"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// maintenanceKey holds the maintenance state while maintenance mode is on;
+// like the deny list it is not a cache entry and survives key version bumps
+const maintenanceKey = "maintenance"
+
+// RedisMaintenanceStore implements maintenance.Store, sharing the flag
+// between all instances of the service
+type RedisMaintenanceStore struct {
+	client *RedisClient
+}
+
+var _ maintenance.Store = (*RedisMaintenanceStore)(nil)
+
+// NewRedisMaintenanceStore creates a maintenance flag stored in Redis
+func NewRedisMaintenanceStore(client *RedisClient) *RedisMaintenanceStore {
+	return &RedisMaintenanceStore{client: client}
+}
+
+func (s *RedisMaintenanceStore) Get(ctx context.Context) (*maintenance.State, error) {
+	value, err := s.client.client.Get(ctx, maintenanceKey).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load maintenance state: %w", err)
+	}
+
+	var state maintenance.State
+	if err := json.Unmarshal(value, &state); err != nil {
+		// Stay in maintenance even if its details are unreadable
+		return &maintenance.State{}, nil
+	}
+	return &state, nil
+}
+
+func (s *RedisMaintenanceStore) Set(ctx context.Context, state maintenance.State) error {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("failed to marshal maintenance state: %w", err)
+	}
+	if err := s.client.client.Set(ctx, maintenanceKey, data, 0).Err(); err != nil {
+		return fmt.Errorf("failed to enable maintenance mode: %w", err)
+	}
+	return nil
+}
+
+func (s *RedisMaintenanceStore) Clear(ctx context.Context) (bool, error) {
+	removed, err := s.client.client.Del(ctx, maintenanceKey).Result()
+	if err != nil {
+		return false, fmt.Errorf("failed to disable maintenance mode: %w", err)
+	}
+	return removed > 0, nil
+}
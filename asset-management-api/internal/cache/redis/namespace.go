@@ -0,0 +1,101 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	"asset-management-api/pkg/cache"
+	"github.com/redis/go-redis/v9"
+)
+
+// KeyVersion returns the namespace version currently prefixed to cache keys
+func (r *RedisCacheService) KeyVersion() int64 {
+	return r.namespace.Version()
+}
+
+// BumpKeyVersion increments the shared namespace version. Every instance
+// switches to the new prefix on its next refresh, leaving the old keys to
+// expire via their TTL instead of requiring a FLUSHDB.
+func (r *RedisCacheService) BumpKeyVersion(ctx context.Context) (int64, error) {
+	version, err := r.client.Incr(ctx, cache.KeyVersionKey)
+	if err != nil {
+		return 0, fmt.Errorf("failed to bump cache key version: %w", err)
+	}
+
+	r.namespace.SetVersion(version)
+	log.Printf("Cache key namespace bumped to v%d", version)
+	return version, nil
+}
+
+// SyncKeyVersion reconciles the configured version with the one stored in
+// Redis. The higher of the two wins so raising CACHE_KEY_VERSION in a deploy
+// invalidates the cache, while a bump made through the admin endpoint is not
+// undone by instances starting with an older config.
+func (r *RedisCacheService) SyncKeyVersion(ctx context.Context, configured int64) (int64, error) {
+	if _, err := r.client.SetNX(ctx, cache.KeyVersionKey, configured, 0); err != nil {
+		return 0, fmt.Errorf("failed to initialize cache key version: %w", err)
+	}
+
+	stored, err := r.loadKeyVersion(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	if configured > stored {
+		if err := r.client.Set(ctx, cache.KeyVersionKey, configured, 0); err != nil {
+			return 0, fmt.Errorf("failed to store cache key version: %w", err)
+		}
+		stored = configured
+	}
+
+	r.namespace.SetVersion(stored)
+	return stored, nil
+}
+
+// WatchKeyVersion periodically refreshes the namespace version so bumps made
+// on another instance take effect here. The watcher stops when the service is closed.
+func (r *RedisCacheService) WatchKeyVersion(interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-r.stopWatch:
+				return
+			case <-ticker.C:
+				ctx, cancel := context.WithTimeout(context.Background(), interval)
+				version, err := r.loadKeyVersion(ctx)
+				cancel()
+				if err != nil {
+					log.Printf("Warning: failed to refresh cache key version: %v", err)
+					continue
+				}
+				if version > 0 && version != r.namespace.Version() {
+					log.Printf("Cache key namespace changed from v%d to v%d", r.namespace.Version(), version)
+					r.namespace.SetVersion(version)
+				}
+			}
+		}
+	}()
+}
+
+func (r *RedisCacheService) loadKeyVersion(ctx context.Context) (int64, error) {
+	value, err := r.client.Get(ctx, cache.KeyVersionKey)
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("failed to read cache key version: %w", err)
+	}
+
+	version, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid cache key version %q: %w", value, err)
+	}
+	return version, nil
+}
@@ -0,0 +1,86 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"github.com/google/uuid"
+)
+
+// invalidationMessage is broadcast to every instance when cached entries change
+type invalidationMessage struct {
+	Origin string   `json:"origin"`
+	Keys   []string `json:"keys"`
+}
+
+// RedisInvalidator broadcasts L1 cache invalidations over a Redis pub/sub
+// channel. Messages published by this instance are ignored on receipt since
+// the local entries were already evicted before publishing.
+type RedisInvalidator struct {
+	client   *RedisClient
+	channel  string
+	instance string
+}
+
+// NewRedisInvalidator creates an invalidator publishing on the given channel
+func NewRedisInvalidator(client *RedisClient, channel string) *RedisInvalidator {
+	return &RedisInvalidator{
+		client:   client,
+		channel:  channel,
+		instance: uuid.New().String(),
+	}
+}
+
+// Publish announces that the given keys changed
+func (i *RedisInvalidator) Publish(ctx context.Context, keys ...string) error {
+	payload, err := json.Marshal(invalidationMessage{Origin: i.instance, Keys: keys})
+	if err != nil {
+		return fmt.Errorf("failed to marshal invalidation message: %w", err)
+	}
+
+	if err := i.client.Publish(ctx, i.channel, payload); err != nil {
+		return fmt.Errorf("failed to publish cache invalidation: %w", err)
+	}
+	return nil
+}
+
+// Subscribe calls evict for every key invalidated by another instance until
+// ctx is cancelled. go-redis reconnects and resubscribes automatically.
+func (i *RedisInvalidator) Subscribe(ctx context.Context, evict func(keys []string)) error {
+	pubsub := i.client.Subscribe(ctx, i.channel)
+	if _, err := pubsub.Receive(ctx); err != nil {
+		pubsub.Close()
+		return fmt.Errorf("failed to subscribe to cache invalidation channel: %w", err)
+	}
+
+	go func() {
+		defer pubsub.Close()
+
+		messages := pubsub.Channel()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case msg, ok := <-messages:
+				if !ok {
+					return
+				}
+
+				var message invalidationMessage
+				if err := json.Unmarshal([]byte(msg.Payload), &message); err != nil {
+					log.Printf("Warning: invalid cache invalidation message: %v", err)
+					continue
+				}
+				if message.Origin == i.instance {
+					continue
+				}
+				evict(message.Keys)
+			}
+		}
+	}()
+
+	log.Printf("Subscribed to cache invalidation channel %s", i.channel)
+	return nil
+}
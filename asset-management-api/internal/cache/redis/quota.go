@@ -0,0 +1,150 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"asset-management-api/pkg/quota"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// quotaKeyPrefix namespaces quota counters; like rate limit windows they are
+// not cache entries and survive key version bumps
+const quotaKeyPrefix = "quota:"
+
+// consumeQuotaScript counts a call in every period's counter, unless one of
+// them has reached its limit, in which case nothing is counted. KEYS are the
+// counters and ARGV holds each counter's limit and expiry in unix ms. It
+// returns {allowed, used of each counter}.
+var consumeQuotaScript = redis.NewScript(`
+local used = {}
+local allowed = 1
+for i, key in ipairs(KEYS) do
+	used[i] = tonumber(redis.call("get", key) or "0")
+	if used[i] >= tonumber(ARGV[i * 2 - 1]) then
+		allowed = 0
+	end
+end
+
+if allowed == 1 then
+	for i, key in ipairs(KEYS) do
+		used[i] = redis.call("incr", key)
+		redis.call("pexpireat", key, ARGV[i * 2])
+	end
+end
+table.insert(used, 1, allowed)
+return used
+`)
+
+// RedisQuotaTracker implements quota.Tracker with a counter per key and
+// calendar period, shared by every instance of the service
+type RedisQuotaTracker struct {
+	client *RedisClient
+}
+
+var _ quota.Tracker = (*RedisQuotaTracker)(nil)
+
+// NewRedisQuotaTracker creates a quota tracker storing its counters in Redis
+func NewRedisQuotaTracker(client *RedisClient) *RedisQuotaTracker {
+	return &RedisQuotaTracker{client: client}
+}
+
+// quotaWindow is the counter of one limited period
+type quotaWindow struct {
+	period  quota.Period
+	limit   int
+	key     string
+	resetAt time.Time
+}
+
+// quotaWindows returns the counters of the limited periods of q. The key is hash
+// tagged so a caller's counters live in the same cluster slot, as the script
+// requires.
+func quotaWindows(key string, q quota.Quota, now time.Time) []quotaWindow {
+	windows := make([]quotaWindow, 0, len(quota.Periods))
+	for _, period := range quota.Periods {
+		limit := q.Limit(period)
+		if limit <= 0 {
+			continue
+		}
+		id, resetAt := period.Window(now)
+		windows = append(windows, quotaWindow{
+			period:  period,
+			limit:   limit,
+			key:     fmt.Sprintf("%s{%s}:%s:%s", quotaKeyPrefix, key, period, id),
+			resetAt: resetAt,
+		})
+	}
+	return windows
+}
+
+func (w quotaWindow) usage(used int) quota.Usage {
+	remaining := w.limit - used
+	if remaining < 0 {
+		remaining = 0
+	}
+	return quota.Usage{
+		Period:    w.period,
+		Limit:     w.limit,
+		Used:      used,
+		Remaining: remaining,
+		ResetAt:   w.resetAt,
+	}
+}
+
+func (t *RedisQuotaTracker) Consume(ctx context.Context, key string, q quota.Quota) (*quota.Result, error) {
+	windows := quotaWindows(key, q, time.Now())
+	if len(windows) == 0 {
+		return &quota.Result{Allowed: true}, nil
+	}
+
+	keys := make([]string, len(windows))
+	args := make([]interface{}, 0, 2*len(windows))
+	for i, w := range windows {
+		keys[i] = w.key
+		args = append(args, w.limit, w.resetAt.UnixMilli())
+	}
+
+	values, err := consumeQuotaScript.Run(ctx, t.client.client, keys, args...).Int64Slice()
+	if err != nil {
+		return nil, fmt.Errorf("failed to check quota: %w", err)
+	}
+	if len(values) != len(windows)+1 {
+		return nil, fmt.Errorf("unexpected quota script result: %v", values)
+	}
+
+	result := &quota.Result{Allowed: values[0] == 1, Usage: make([]quota.Usage, len(windows))}
+	for i, w := range windows {
+		result.Usage[i] = w.usage(int(values[i+1]))
+	}
+	return result, nil
+}
+
+func (t *RedisQuotaTracker) Usage(ctx context.Context, key string, q quota.Quota) ([]quota.Usage, error) {
+	windows := quotaWindows(key, q, time.Now())
+	if len(windows) == 0 {
+		return []quota.Usage{}, nil
+	}
+
+	keys := make([]string, len(windows))
+	for i, w := range windows {
+		keys[i] = w.key
+	}
+	values, err := t.client.client.MGet(ctx, keys...).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get quota usage: %w", err)
+	}
+
+	usage := make([]quota.Usage, len(windows))
+	for i, w := range windows {
+		var used int
+		if value, ok := values[i].(string); ok {
+			used, _ = strconv.Atoi(value)
+		}
+		usage[i] = w.usage(used)
+	}
+	return usage, nil
+}
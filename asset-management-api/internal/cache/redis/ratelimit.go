@@ -0,0 +1,80 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"asset-management-api/pkg/ratelimit"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+// rateLimitKeyPrefix namespaces rate limit windows; like dedup markers they
+// are not cache entries and survive key version bumps
+const rateLimitKeyPrefix = "ratelimit:"
+
+// slidingWindowScript keeps one sorted set entry per request of the last
+// window, scored by the Redis clock so instances with skewed clocks agree.
+// It returns {allowed, remaining, retry after in ms, reset in ms}, the reset
+// being when the oldest request of the window leaves it.
+var slidingWindowScript = redis.NewScript(`
+local time = redis.call("time")
+local now = tonumber(time[1]) * 1000 + math.floor(tonumber(time[2]) / 1000)
+local window = tonumber(ARGV[1])
+local limit = tonumber(ARGV[2])
+
+local function reset()
+	local oldest = redis.call("zrange", KEYS[1], 0, 0, "withscores")
+	if oldest[2] then
+		return tonumber(oldest[2]) + window - now
+	end
+	return window
+end
+
+redis.call("zremrangebyscore", KEYS[1], "-inf", now - window)
+local count = redis.call("zcard", KEYS[1])
+if count < limit then
+	redis.call("zadd", KEYS[1], now, ARGV[3])
+	redis.call("pexpire", KEYS[1], window)
+	return {1, limit - count - 1, 0, reset()}
+end
+
+local retry = reset()
+return {0, 0, retry, retry}
+`)
+
+// RedisRateLimiter implements ratelimit.Limiter with a sliding window log,
+// shared by every instance of the service
+type RedisRateLimiter struct {
+	client *RedisClient
+}
+
+var _ ratelimit.Limiter = (*RedisRateLimiter)(nil)
+
+// NewRedisRateLimiter creates a rate limiter storing its windows in Redis
+func NewRedisRateLimiter(client *RedisClient) *RedisRateLimiter {
+	return &RedisRateLimiter{client: client}
+}
+
+func (l *RedisRateLimiter) Allow(ctx context.Context, key string, limit ratelimit.Limit) (*ratelimit.Result, error) {
+	if limit.Unlimited() {
+		return &ratelimit.Result{Allowed: true, Remaining: limit.Requests}, nil
+	}
+
+	values, err := slidingWindowScript.Run(ctx, l.client.client, []string{rateLimitKeyPrefix + key},
+		limit.Window.Milliseconds(), limit.Requests, uuid.NewString()).Int64Slice()
+	if err != nil {
+		return nil, fmt.Errorf("failed to check rate limit: %w", err)
+	}
+	if len(values) != 4 {
+		return nil, fmt.Errorf("unexpected rate limit script result: %v", values)
+	}
+
+	return &ratelimit.Result{
+		Allowed:    values[0] == 1,
+		Remaining:  int(values[1]),
+		RetryAfter: time.Duration(values[2]) * time.Millisecond,
+		ResetAfter: time.Duration(values[3]) * time.Millisecond,
+	}, nil
+}
@@ -0,0 +1,85 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"asset-management-api/pkg/cache"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	// responseCacheKeyPrefix namespaces cached responses; like rate limit
+	// windows they are short-lived and not versioned with the cache keys
+	responseCacheKeyPrefix = "httpcache:"
+
+	// responseGenerationTTL keeps scope generations well beyond the life of
+	// any cached response, so a generation never restarts under a live entry
+	responseGenerationTTL = 24 * time.Hour
+)
+
+// RedisResponseCache implements cache.ResponseCache. Each scope has a
+// generation counter that is part of its response keys; invalidating the
+// scope increments it, which orphans the old responses until they expire.
+type RedisResponseCache struct {
+	client *RedisClient
+}
+
+var _ cache.ResponseCache = (*RedisResponseCache)(nil)
+
+// NewRedisResponseCache creates a response cache storing responses in Redis
+func NewRedisResponseCache(client *RedisClient) *RedisResponseCache {
+	return &RedisResponseCache{client: client}
+}
+
+func (c *RedisResponseCache) Get(ctx context.Context, scope, key string) (*cache.CachedResponse, string, error) {
+	generation, err := c.client.Get(ctx, generationKey(scope))
+	if errors.Is(err, redis.Nil) {
+		generation = "0"
+	} else if err != nil {
+		return nil, "", fmt.Errorf("failed to get response cache generation: %w", err)
+	}
+
+	var response cache.CachedResponse
+	err = c.client.GetJSON(ctx, responseKey(scope, generation, key), &response)
+	if errors.Is(err, redis.Nil) {
+		return nil, generation, nil
+	}
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to get cached response: %w", err)
+	}
+	return &response, generation, nil
+}
+
+func (c *RedisResponseCache) Set(ctx context.Context, scope, generation, key string, response *cache.CachedResponse, ttl time.Duration) error {
+	if err := c.client.SetJSON(ctx, responseKey(scope, generation, key), response, ttl); err != nil {
+		return fmt.Errorf("failed to cache response: %w", err)
+	}
+	return nil
+}
+
+func (c *RedisResponseCache) Invalidate(ctx context.Context, scopes ...string) error {
+	if len(scopes) == 0 {
+		return nil
+	}
+
+	pipe := c.client.Pipeline()
+	for _, scope := range scopes {
+		pipe.Incr(ctx, generationKey(scope))
+		pipe.Expire(ctx, generationKey(scope), responseGenerationTTL)
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("failed to invalidate cached responses: %w", err)
+	}
+	return nil
+}
+
+func generationKey(scope string) string {
+	return responseCacheKeyPrefix + "gen:" + scope
+}
+
+func responseKey(scope, generation, key string) string {
+	return responseCacheKeyPrefix + scope + ":" + generation + ":" + key
+}
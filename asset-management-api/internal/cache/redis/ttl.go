@@ -0,0 +1,19 @@
+package redis
+
+import (
+	"math/rand"
+	"time"
+)
+
+// ttlJitter is the maximum fraction by which a TTL is randomly shortened or
+// lengthened, so keys written in a burst do not all expire at the same instant
+const ttlJitter = 0.15
+
+// jitterTTL returns ttl adjusted by a random offset within ±ttlJitter
+func jitterTTL(ttl time.Duration) time.Duration {
+	if ttl <= 0 {
+		return ttl
+	}
+	offset := (rand.Float64()*2 - 1) * ttlJitter
+	return ttl + time.Duration(float64(ttl)*offset)
+}
@@ -0,0 +1,233 @@
+// Package tiered layers a short-lived in-process (L1) cache in front of a
+// shared CacheService (L2). Writes and invalidations are broadcast through a
+// cache.Invalidator so every instance evicts its L1 copy.
+package tiered
+
+import (
+	"context"
+	"log"
+	"sync/atomic"
+	"time"
+
+	"asset-management-api/internal/models"
+	"asset-management-api/pkg/cache"
+	"github.com/google/uuid"
+)
+
+// flushAllKey asks every instance to drop its entire L1 cache
+const flushAllKey = "*"
+
+// TieredCacheService caches folder and note metadata in process. All other
+// operations pass straight through to the underlying CacheService.
+type TieredCacheService struct {
+	cache.CacheService
+	local       *localCache
+	invalidator cache.Invalidator
+	keyVersion  atomic.Int64
+	cancel      context.CancelFunc
+}
+
+// NewTieredCacheService wraps l2 with an L1 cache of the given TTL and size
+// and starts listening for invalidations from other instances
+func NewTieredCacheService(l2 cache.CacheService, invalidator cache.Invalidator, ttl time.Duration, maxEntries int) (*TieredCacheService, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t := &TieredCacheService{
+		CacheService: l2,
+		local:        newLocalCache(ttl, maxEntries),
+		invalidator:  invalidator,
+		cancel:       cancel,
+	}
+	t.keyVersion.Store(l2.KeyVersion())
+
+	if err := invalidator.Subscribe(ctx, t.evict); err != nil {
+		cancel()
+		return nil, err
+	}
+
+	return t, nil
+}
+
+// Folder metadata
+func (t *TieredCacheService) CacheFolderMetadata(ctx context.Context, folder *models.Folder) error {
+	if err := t.CacheService.CacheFolderMetadata(ctx, folder); err != nil {
+		return err
+	}
+	t.invalidate(ctx, folderKey(folder.FolderID))
+	return nil
+}
+
+func (t *TieredCacheService) GetFolderMetadata(ctx context.Context, folderID uuid.UUID) (*models.Folder, error) {
+	t.checkKeyVersion()
+
+	key := folderKey(folderID)
+	if value, ok := t.local.get(key); ok {
+		folder := *value.(*models.Folder)
+		return &folder, nil
+	}
+
+	folder, err := t.CacheService.GetFolderMetadata(ctx, folderID)
+	if err != nil || folder == nil {
+		return folder, err
+	}
+
+	cached := *folder
+	t.local.set(key, &cached)
+	return folder, nil
+}
+
+func (t *TieredCacheService) GetFolderMetadataBatch(ctx context.Context, folderIDs []uuid.UUID) (map[uuid.UUID]*models.Folder, error) {
+	t.checkKeyVersion()
+
+	folders := make(map[uuid.UUID]*models.Folder, len(folderIDs))
+	var missIDs []uuid.UUID
+	for _, folderID := range folderIDs {
+		if value, ok := t.local.get(folderKey(folderID)); ok {
+			folder := *value.(*models.Folder)
+			folders[folderID] = &folder
+		} else {
+			missIDs = append(missIDs, folderID)
+		}
+	}
+	if len(missIDs) == 0 {
+		return folders, nil
+	}
+
+	fetched, err := t.CacheService.GetFolderMetadataBatch(ctx, missIDs)
+	if err != nil {
+		return nil, err
+	}
+	for folderID, folder := range fetched {
+		cached := *folder
+		t.local.set(folderKey(folderID), &cached)
+		folders[folderID] = folder
+	}
+
+	return folders, nil
+}
+
+func (t *TieredCacheService) InvalidateFolderMetadata(ctx context.Context, folderID uuid.UUID) error {
+	err := t.CacheService.InvalidateFolderMetadata(ctx, folderID)
+	t.invalidate(ctx, folderKey(folderID))
+	return err
+}
+
+// Note metadata
+func (t *TieredCacheService) CacheNoteMetadata(ctx context.Context, note *models.Note) error {
+	if err := t.CacheService.CacheNoteMetadata(ctx, note); err != nil {
+		return err
+	}
+	t.invalidate(ctx, noteKey(note.NoteID))
+	return nil
+}
+
+func (t *TieredCacheService) GetNoteMetadata(ctx context.Context, noteID uuid.UUID) (*models.Note, error) {
+	t.checkKeyVersion()
+
+	key := noteKey(noteID)
+	if value, ok := t.local.get(key); ok {
+		note := *value.(*models.Note)
+		return &note, nil
+	}
+
+	note, err := t.CacheService.GetNoteMetadata(ctx, noteID)
+	if err != nil || note == nil {
+		return note, err
+	}
+
+	cached := *note
+	t.local.set(key, &cached)
+	return note, nil
+}
+
+func (t *TieredCacheService) GetNoteMetadataBatch(ctx context.Context, noteIDs []uuid.UUID) (map[uuid.UUID]*models.Note, error) {
+	t.checkKeyVersion()
+
+	notes := make(map[uuid.UUID]*models.Note, len(noteIDs))
+	var missIDs []uuid.UUID
+	for _, noteID := range noteIDs {
+		if value, ok := t.local.get(noteKey(noteID)); ok {
+			note := *value.(*models.Note)
+			notes[noteID] = &note
+		} else {
+			missIDs = append(missIDs, noteID)
+		}
+	}
+	if len(missIDs) == 0 {
+		return notes, nil
+	}
+
+	fetched, err := t.CacheService.GetNoteMetadataBatch(ctx, missIDs)
+	if err != nil {
+		return nil, err
+	}
+	for noteID, note := range fetched {
+		cached := *note
+		t.local.set(noteKey(noteID), &cached)
+		notes[noteID] = note
+	}
+
+	return notes, nil
+}
+
+func (t *TieredCacheService) InvalidateNoteMetadata(ctx context.Context, noteID uuid.UUID) error {
+	err := t.CacheService.InvalidateNoteMetadata(ctx, noteID)
+	t.invalidate(ctx, noteKey(noteID))
+	return err
+}
+
+// BumpKeyVersion drops every L1 entry here and on all other instances
+func (t *TieredCacheService) BumpKeyVersion(ctx context.Context) (int64, error) {
+	version, err := t.CacheService.BumpKeyVersion(ctx)
+	if err != nil {
+		return 0, err
+	}
+	t.keyVersion.Store(version)
+	t.invalidate(ctx, flushAllKey)
+	return version, nil
+}
+
+func (t *TieredCacheService) HealthCheck() map[string]interface{} {
+	health := t.CacheService.HealthCheck()
+	health["l1_entries"] = t.local.len()
+	return health
+}
+
+func (t *TieredCacheService) Close() error {
+	t.cancel()
+	return t.CacheService.Close()
+}
+
+// invalidate evicts keys locally and tells the other instances to do the same
+func (t *TieredCacheService) invalidate(ctx context.Context, keys ...string) {
+	t.evict(keys)
+	if err := t.invalidator.Publish(ctx, keys...); err != nil {
+		log.Printf("Warning: failed to broadcast L1 cache invalidation: %v", err)
+	}
+}
+
+func (t *TieredCacheService) evict(keys []string) {
+	for _, key := range keys {
+		if key == flushAllKey {
+			t.local.clear()
+			return
+		}
+	}
+	t.local.delete(keys...)
+}
+
+// checkKeyVersion drops the L1 cache when the L2 namespace version changed,
+// e.g. after a bump picked up by the version watcher
+func (t *TieredCacheService) checkKeyVersion() {
+	version := t.CacheService.KeyVersion()
+	if t.keyVersion.Swap(version) != version {
+		t.local.clear()
+	}
+}
+
+func folderKey(folderID uuid.UUID) string {
+	return "folder:" + folderID.String()
+}
+
+func noteKey(noteID uuid.UUID) string {
+	return "note:" + noteID.String()
+}
@@ -0,0 +1,94 @@
+package tiered
+
+import (
+	"sync"
+	"time"
+)
+
+type localEntry struct {
+	value     interface{}
+	expiresAt time.Time
+}
+
+// localCache is a bounded in-process map with per-entry expiry
+type localCache struct {
+	mu         sync.RWMutex
+	entries    map[string]localEntry
+	ttl        time.Duration
+	maxEntries int
+}
+
+func newLocalCache(ttl time.Duration, maxEntries int) *localCache {
+	return &localCache{
+		entries:    make(map[string]localEntry),
+		ttl:        ttl,
+		maxEntries: maxEntries,
+	}
+}
+
+func (c *localCache) get(key string) (interface{}, bool) {
+	c.mu.RLock()
+	entry, ok := c.entries[key]
+	c.mu.RUnlock()
+
+	if !ok || time.Now().After(entry.expiresAt) {
+		return nil, false
+	}
+	return entry.value, true
+}
+
+func (c *localCache) set(key string, value interface{}) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if len(c.entries) >= c.maxEntries {
+		c.evictLocked()
+	}
+	c.entries[key] = localEntry{value: value, expiresAt: time.Now().Add(c.ttl)}
+}
+
+func (c *localCache) delete(keys ...string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, key := range keys {
+		delete(c.entries, key)
+	}
+}
+
+func (c *localCache) clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries = make(map[string]localEntry)
+}
+
+func (c *localCache) len() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return len(c.entries)
+}
+
+// evictLocked drops expired entries and, if the cache is still full, an
+// arbitrary tenth of it. Callers must hold the write lock.
+func (c *localCache) evictLocked() {
+	now := time.Now()
+	for key, entry := range c.entries {
+		if now.After(entry.expiresAt) {
+			delete(c.entries, key)
+		}
+	}
+	if len(c.entries) < c.maxEntries {
+		return
+	}
+
+	excess := c.maxEntries/10 + 1
+	for key := range c.entries {
+		if excess == 0 {
+			break
+		}
+		delete(c.entries, key)
+		excess--
+	}
+}
@@ -6,35 +6,129 @@ import (
 	"strings"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/joho/godotenv"
 )
 
 type Config struct {
-	Server   ServerConfig
-	Database DatabaseConfig
-	JWT      JWTConfig
-	Kafka    KafkaConfig
-	Redis    RedisConfig // NEW: Added Redis configuration
+	Server         ServerConfig
+	Database       DatabaseConfig
+	JWT            JWTConfig
+	Kafka          KafkaConfig
+	Redis          RedisConfig // NEW: Added Redis configuration
+	Cache          CacheConfig
+	Memcached      MemcachedConfig
+	Webhook        WebhookConfig
+	Realtime       RealtimeConfig
+	RateLimit      RateLimitConfig
+	Quota          QuotaConfig
+	Tracing        TracingConfig
+	Compression    CompressionConfig
+	Security       SecurityConfig
+	ResponseCache  ResponseCacheConfig
+	IPFilter       IPFilterConfig
+	Maintenance    MaintenanceConfig
+	BodyLimit      BodyLimitConfig
+	Concurrency    ConcurrencyConfig
+	Batch          BatchConfig
+	Debug          DebugConfig
+	Logging        LoggingConfig
+	I18n           I18nConfig
+	ErrorReporting ErrorReportingConfig
+	APIAudit       APIAuditConfig
+	Retention      RetentionConfig
+	Backup         BackupConfig
+	Metrics        MetricsConfig
+	Health         HealthConfig
+	Secrets        SecretsConfig
+
+	// Profile is the configuration profile the service runs with, e.g. "prod"
+	Profile string
 }
 
 type ServerConfig struct {
 	Port         string
 	ReadTimeout  time.Duration
 	WriteTimeout time.Duration
+	// Proxies whose X-Forwarded-For header is believed for the client IP;
+	// with none, the client IP is the connection's remote address
+	TrustedProxies []string
+	// GRPCPort is the port of the gRPC API; empty disables it
+	GRPCPort string
+	// ErrorFormat is the format of error responses: "envelope" for the
+	// {"success": false} envelope, or "problem" for problem details (RFC
+	// 7807), which requests accepting application/problem+json always get
+	ErrorFormat string
 }
 
 type DatabaseConfig struct {
+	// Driver is "postgres", "mysql" or "sqlite"; with SQLite, DBName is the
+	// path of the database file and the server settings are unused
+	Driver string
+
 	Host     string
 	Port     string
 	User     string
 	Password string
 	DBName   string
 	SSLMode  string
+
+	// Read replicas ("host:port") serving the queries outside of
+	// transactions; writes always go to the primary at Host and Port
+	Replicas []string
+
+	// Secret references User and Password were resolved from, re-read
+	// every CredentialsRotationInterval when set to pick up rotated
+	// credentials; empty when given directly
+	UserSecret                  string
+	PasswordSecret              string
+	CredentialsRotationInterval time.Duration
+
+	// Circuit breaker in front of every query
+	BreakerFailureThreshold int
+	BreakerOpenTimeout      time.Duration
+
+	// Retries of statements and transactions failing on a transient error
+	// (serialization failure, deadlock, connection not established): at
+	// most RetryMaxAttempts attempts, with a jittered backoff from
+	// RetryInitialBackoff up to RetryMaxBackoff, within RetryBudget
+	RetryMaxAttempts    int
+	RetryInitialBackoff time.Duration
+	RetryMaxBackoff     time.Duration
+	RetryBudget         time.Duration
+
+	// Connection pool of the primary and of each replica: the most open
+	// and idle connections (0 for no limit on open connections) and how
+	// long a connection is reused in total and while idle (0 for no limit)
+	MaxOpenConns    int
+	MaxIdleConns    int
+	ConnMaxLifetime time.Duration
+	ConnMaxIdleTime time.Duration
+
+	// How often connection pool statistics are sampled into metrics
+	StatsInterval time.Duration
+
+	// Query logging: level ("silent", "error", "warn" or "info"), the
+	// duration above which a query is logged as slow, and whether bind
+	// values are included in logged SQL
+	LogLevel           string
+	SlowQueryThreshold time.Duration
+	LogQueryParams     bool
+
+	// Apply pending migrations at startup, as cmd/migrate up does
+	MigrateOnStart bool
+
+	// AccessChecks is "gorm", or "raw" to run the ownership, share and team
+	// membership checks of every request as raw SQL (Postgres only)
+	AccessChecks string
 }
 
 type JWTConfig struct {
 	SecretKey      string
 	ExpirationTime time.Duration
+	// TestLoginEnabled mounts /test/login, which hands a manager token to
+	// anyone; for local development only, it is refused in production
+	TestLoginEnabled bool
 }
 
 type KafkaConfig struct {
@@ -43,9 +137,92 @@ type KafkaConfig struct {
 	ProducerRetryMax      int
 	ProducerRequiredAcks  int
 	ProducerFlushTimeout  time.Duration
+	ProducerName          string
+
+	// Async publishing through a bounded in-memory queue
+	ProducerAsync          bool
+	ProducerQueueSize      int
+	ProducerWorkers        int
+	ProducerOverflowPolicy string // "block", "drop_newest", "drop_oldest" or "sync"
+	ProducerEnqueueTimeout time.Duration
+	ProducerDrainTimeout   time.Duration
+
+	// Circuit breaker in front of every write to the brokers
+	ProducerBreakerFailureThreshold int
+	ProducerBreakerOpenTimeout      time.Duration
+
 	ConsumerGroupID       string
 	ConsumerSessionTimeout time.Duration
 	AutoCommitInterval    time.Duration
+	ConsumerMaxRetries    int
+	DeadLetterEnabled     bool
+	AutoCommit            bool
+	ManualCommitTopics    []string // Topics committed only after successful handling
+
+	// Retry backoff before dead-lettering: ConsumerRetryBackoff grows by
+	// ConsumerRetryMultiplier per retry up to ConsumerRetryMaxBackoff, spread
+	// by ConsumerRetryJitter. ConsumerTopicRetry overrides it per topic, as
+	// "topic=max_attempts:5;backoff:500ms,topic=...".
+	ConsumerRetryBackoff    time.Duration
+	ConsumerRetryMultiplier float64
+	ConsumerRetryMaxBackoff time.Duration
+	ConsumerRetryJitter     float64
+	ConsumerTopicRetry      string
+
+	// Workers per topic; ConsumerTopicConcurrency overrides it per topic
+	ConsumerConcurrency      int
+	ConsumerTopicConcurrency map[string]int
+	ConsumerDrainTimeout     time.Duration // Shutdown wait for in-flight handlers
+
+	// Event-ID deduplication in Redis; handled events are remembered for
+	// ConsumerDedupTTL, in-progress claims expire after ConsumerDedupProcessingTTL
+	ConsumerDedupEnabled       bool
+	ConsumerDedupTTL           time.Duration
+	ConsumerDedupProcessingTTL time.Duration
+
+	// Wire format ("json" or "avro") and the schema registry used for Avro
+	Serialization              string
+	SchemaRegistryURL          string
+	SchemaRegistryUsername     string
+	SchemaRegistryPassword     string
+	SchemaRegistryAutoRegister bool
+	SchemaRegistryTimeout      time.Duration
+
+	// CloudEvents 1.0 structured JSON on CloudEventsTopics (every topic when empty)
+	CloudEventsEnabled    bool
+	CloudEventsTopics     []string
+	CloudEventsSource     string
+	CloudEventsTypePrefix string
+
+	// In-process event bus used when Kafka is disabled; a buffer size of zero
+	// dispatches synchronously. Off by default, so events are dropped without
+	// Kafka; the bundled .env turns it on for local development.
+	InMemoryEnabled    bool
+	InMemoryBufferSize int
+
+	// Broker TLS (mutual TLS when a client certificate is set) and SASL
+	TLSEnabled            bool
+	TLSCAFile             string
+	TLSCertFile           string
+	TLSKeyFile            string
+	TLSServerName         string
+	TLSInsecureSkipVerify bool
+	SASLMechanism         string
+	SASLUsername          string
+	SASLPassword          string
+
+	// Broker probes surfaced in /health; a zero interval probes only at startup
+	HealthCheckInterval time.Duration
+	HealthCheckTimeout  time.Duration
+
+	// Topics created at startup when TopicsAutoCreate is set; zero values
+	// use the broker defaults
+	TopicsAutoCreate       bool
+	Topics                 []string
+	TopicPartitions        int
+	TopicPartitionsByTopic map[string]int
+	TopicReplicationFactor int
+	TopicRetention         time.Duration
 }
 
 // NEW: Redis configuration struct
@@ -53,6 +230,7 @@ type RedisConfig struct {
 	Enabled            bool
 	Host               string
 	Port               string
+	Username           string
 	Password           string
 	Database           int
 	PoolSize           int
@@ -66,29 +244,380 @@ type RedisConfig struct {
 	ReadTimeout        time.Duration
 	WriteTimeout       time.Duration
 	DialTimeout        time.Duration
+
+	// Per-call latency budget and circuit breaker
+	CallBudget              time.Duration
+	BreakerFailureThreshold int
+	BreakerOpenTimeout      time.Duration
+
+	// TLS for managed Redis services
+	TLSEnabled            bool
+	TLSCAFile             string
+	TLSCertFile           string
+	TLSKeyFile            string
+	TLSServerName         string
+	TLSInsecureSkipVerify bool
+}
+
+// CacheConfig selects the cache backend ("redis" or "memcached") and the
+// key namespace version; raising KeyVersion invalidates every cached entry
+type CacheConfig struct {
+	Backend           string
+	KeyVersion        int64
+	KeyVersionRefresh time.Duration
+
+	// Compression of cached JSON payloads: "none", "snappy" or "zstd"
+	Compression          string
+	CompressionThreshold int
+
+	// In-process L1 cache in front of Redis, kept coherent via pub/sub
+	L1Enabled           bool
+	L1TTL               time.Duration
+	L1MaxEntries        int
+	InvalidationChannel string
+}
+
+// MemcachedConfig holds memcached configuration
+type MemcachedConfig struct {
+	Servers      []string
+	Timeout      time.Duration
+	MaxIdleConns int
+}
+
+// WebhookConfig controls outbound webhook delivery
+type WebhookConfig struct {
+	Enabled        bool
+	Workers        int
+	QueueSize      int
+	MaxAttempts    int
+	Timeout        time.Duration
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	PollInterval   time.Duration
+}
+
+// RealtimeConfig controls the Server-Sent Events stream of asset and team
+// events and the WebSocket that also delivers notifications
+type RealtimeConfig struct {
+	Enabled               bool
+	ClientBuffer          int
+	MaxConnectionsPerUser int
+	Heartbeat             time.Duration
+
+	// ReplayLimit caps the missed asset events replayed to a resuming event
+	// stream; a client that missed more has to refetch instead
+	ReplayLimit int
+
+	// AllowedOrigins lists the origins browsers may open the WebSocket from;
+	// empty allows only the API's own origin and "*" allows any
+	AllowedOrigins []string
+}
+
+// RateLimitConfig controls the per-user rate limits of the API, which are
+// counted in Redis. Default and the role limits are "requests/window";
+// Roles and Routes list "name=requests/window" entries separated by
+// semicolons, routes being named by method and route pattern.
+type RateLimitConfig struct {
+	Enabled bool
+	Default string
+	Roles   string
+	Routes  string
+}
+
+// QuotaConfig controls the daily and monthly API call quotas, counted per
+// user in Redis over calendar days and months (UTC). Daily and Monthly map
+// roles to quotas of their own; other roles get the defaults. A quota of
+// zero leaves the period unlimited.
+type QuotaConfig struct {
+	Enabled        bool
+	DailyDefault   int
+	MonthlyDefault int
+	Daily          map[string]int
+	Monthly        map[string]int
+}
+
+// TracingConfig controls the export of OpenTelemetry spans to an OTLP/HTTP
+// collector. When disabled the trace context is still propagated through
+// requests and Kafka messages, but no spans are recorded.
+type TracingConfig struct {
+	Enabled     bool
+	ServiceName string
+	Endpoint    string
+	SampleRatio float64
+}
+
+// CompressionConfig controls gzip/deflate compression of responses of at
+// least MinSize bytes with one of the listed content types
+type CompressionConfig struct {
+	Enabled      bool
+	MinSize      int
+	ContentTypes []string
+}
+
+// SecurityConfig controls the security headers of responses; an empty
+// value leaves a header out and an HSTSMaxAge of zero disables HSTS. Routes
+// whose Gin route pattern starts with one of RelaxedRoutes get the Relaxed
+// policies instead, e.g. public pages embedded in other sites.
+type SecurityConfig struct {
+	HSTSMaxAge            time.Duration
+	HSTSIncludeSubdomains bool
+	HSTSPreload           bool
+	ContentSecurityPolicy string
+	FrameOptions          string
+	ReferrerPolicy        string
+
+	RelaxedRoutes                []string
+	RelaxedContentSecurityPolicy string
+	RelaxedFrameOptions          string
+	RelaxedReferrerPolicy        string
+}
+
+// ResponseCacheConfig controls the short-lived Redis cache of GET responses.
+// Routes are Gin route patterns, e.g. "/api/v1/teams/:teamId".
+type ResponseCacheConfig struct {
+	Enabled bool
+	TTL     time.Duration
+	Routes  []string
+}
+
+// IPFilterConfig controls network-level restrictions. Allowlist holds the
+// CIDRs or addresses that may call the AllowlistRoutes, Gin route pattern
+// prefixes such as "/api/v1/admin/"; an empty allowlist leaves them open.
+// Denylist addresses are rejected on every route, as are those added through
+// the admin endpoints, which instances reload every RefreshInterval.
+type IPFilterConfig struct {
+	Enabled         bool
+	Allowlist       []string
+	AllowlistRoutes []string
+	Denylist        []string
+	RefreshInterval time.Duration
+}
+
+// MaintenanceConfig controls maintenance mode, switched on and off through
+// the admin endpoints. Message and RetryAfter apply to maintenance windows
+// started without their own; instances reload the flag every
+// RefreshInterval.
+type MaintenanceConfig struct {
+	Message         string
+	RetryAfter      time.Duration
+	RefreshInterval time.Duration
+}
+
+// BodyLimitConfig caps request body sizes, in bytes. Routes maps Gin route
+// pattern prefixes, e.g. "/api/v1/teams", to limits of their own.
+type BodyLimitConfig struct {
+	Enabled bool
+	Default int
+	Routes  map[string]int
+}
+
+// ConcurrencyConfig caps the API requests handled at once. Routes maps Gin
+// route pattern prefixes to limits of their own; 0 leaves a group unlimited.
+// A request waits up to QueueTimeout for a slot before it is shed with 503.
+type ConcurrencyConfig struct {
+	Enabled      bool
+	Default      int
+	Routes       map[string]int
+	QueueTimeout time.Duration
+}
+
+// BatchConfig controls POST /api/v1/batch, which runs up to MaxRequests API
+// requests one after the other
+type BatchConfig struct {
+	Enabled     bool
+	MaxRequests int
+}
+
+// DebugConfig controls the /debug endpoints (pprof and runtime statistics),
+// served only to the users in AllowedUserIDs. Block and mutex profiles stay
+// empty unless their rates are set, as sampling them costs throughput.
+type DebugConfig struct {
+	Enabled              bool
+	AllowedUserIDs       []uuid.UUID
+	BlockProfileRate     int
+	MutexProfileFraction int
+}
+
+// LoggingConfig controls the application logger: level, format ("json" or
+// "console") and outputs ("stdout", "stderr", "file"). The file is rotated
+// at FileMaxSizeMB and every FileRotateInterval, keeping FileMaxBackups old
+// files for up to FileMaxAge.
+type LoggingConfig struct {
+	Level              string
+	Format             string
+	Outputs            []string
+	FilePath           string
+	FileMaxSizeMB      int
+	FileMaxBackups     int
+	FileMaxAge         time.Duration
+	FileRotateInterval time.Duration
+	FileCompress       bool
+
+	// Request logging samples: the share of responses below 400 logged,
+	// overridden per Gin route pattern prefix, and the share of errors
+	SampleSuccessRate float64
+	SampleErrorRate   float64
+	SampleRoutes      map[string]float64
+}
+
+// I18nConfig controls the languages of error and validation messages.
+// CatalogDir holds a JSON message catalog per language, e.g. fr.json, read
+// again on SIGHUP; requests asking for no language with a catalog get
+// DefaultLanguage, that of the messages in the code.
+type I18nConfig struct {
+	CatalogDir      string
+	DefaultLanguage string
+}
+
+// ErrorReportingConfig sends panics and 5xx errors to Sentry or a compatible
+// service at DSN, tagged with the environment and release. SampleRate is
+// the share of events sent.
+type ErrorReportingConfig struct {
+	Enabled     bool
+	DSN         string
+	Environment string
+	Release     string
+	SampleRate  float64
+}
+
+// APIAuditConfig controls the api_audit record of mutating requests, which
+// are buffered (up to BufferSize) and written in batches of up to BatchSize
+// at least every FlushInterval
+type APIAuditConfig struct {
+	Enabled       bool
+	BufferSize    int
+	BatchSize     int
+	FlushInterval time.Duration
+}
+
+// RetentionConfig sets how many whole months of each time-partitioned table
+// are kept besides the current one; zero keeps every month. The partitions
+// are created and dropped every CheckInterval.
+type RetentionConfig struct {
+	CheckInterval      time.Duration
+	APIAuditMonths     int
+	UserActivityMonths int
+	AssetEventMonths   int
+	NotificationMonths int
+}
+
+// BackupConfig sets the S3 bucket teams are exported to; backups are
+// disabled without a bucket. Teams are exported on request and, with an
+// Interval, all of them on a schedule. The credentials are the AWS ones.
+// The export jobs of POST /exports are run by ExportWorkers per instance,
+// each bounded by ExportTimeout, and downloaded through links valid for
+// ExportLinkTTL.
+type BackupConfig struct {
+	S3Bucket      string
+	S3Prefix      string
+	S3Region      string
+	S3Endpoint    string
+	Interval      time.Duration
+	Timeout       time.Duration
+	ExportWorkers int
+	ExportTimeout time.Duration
+	ExportLinkTTL time.Duration
+}
+
+// MetricsConfig sets the bucket boundaries, in seconds, of the HTTP request
+// and database query latency histograms; empty lists keep the defaults
+type MetricsConfig struct {
+	HTTPLatencyBuckets []float64
+	DBLatencyBuckets   []float64
+}
+
+// HealthConfig controls the readiness probe: the time budget for all
+// dependency checks, and the dependencies ("database", "redis", "kafka")
+// without which the service is not ready; the others only degrade it
+type HealthConfig struct {
+	ReadinessTimeout     time.Duration
+	CriticalDependencies []string
 }
 
-func Load() (*Config, error) {
+// SecretsConfig describes the secret stores that secret references, values
+// of the form "vault://path#field" or "awssm://name#field", are read from at
+// startup. Credentials such as JWT_SECRET and DB_PASSWORD may be given as
+// references instead of in plain text. Timeout bounds each store call.
+type SecretsConfig struct {
+	VaultAddress   string
+	VaultToken     string
+	VaultNamespace string
+
+	AWSRegion          string
+	AWSEndpoint        string
+	AWSAccessKeyID     string
+	AWSSecretAccessKey string
+	AWSSessionToken    string
+
+	Timeout time.Duration
+}
+
+// Load reads the configuration from environment variables, which override
+// the settings of the configuration file at path and of its profile's file
+// (see loadFiles). An empty path or profile falls back to CONFIG_FILE and
+// CONFIG_PROFILE.
+func Load(path, profile string) (*Config, error) {
 	// Load .env file if exists
 	_ = godotenv.Load()
 
+	if path == "" {
+		path = os.Getenv("CONFIG_FILE")
+	}
+	if profile == "" {
+		profile = os.Getenv("CONFIG_PROFILE")
+	}
+	values, err := loadFiles(path, profile)
+	if err != nil {
+		return nil, err
+	}
+	fileValues = values
+	settingErrors = nil
+
 	config := &Config{
+		Profile: profile,
+
 		Server: ServerConfig{
-			Port:         getEnv("SERVER_PORT", "8000"),
-			ReadTimeout:  getDurationEnv("SERVER_READ_TIMEOUT", 30*time.Second),
-			WriteTimeout: getDurationEnv("SERVER_WRITE_TIMEOUT", 30*time.Second),
+			Port:           getEnv("SERVER_PORT", "8000"),
+			ReadTimeout:    getDurationEnv("SERVER_READ_TIMEOUT", 30*time.Second),
+			WriteTimeout:   getDurationEnv("SERVER_WRITE_TIMEOUT", 30*time.Second),
+			TrustedProxies: getSliceEnv("SERVER_TRUSTED_PROXIES", nil),
+			GRPCPort:       getEnv("GRPC_PORT", "9090"),
+			ErrorFormat:    getEnv("SERVER_ERROR_FORMAT", "envelope"),
 		},
 		Database: DatabaseConfig{
+			Driver:   getEnv("DB_DRIVER", "postgres"),
 			Host:     getEnv("DB_HOST", "localhost"),
 			Port:     getEnv("DB_PORT", "5432"),
 			User:     getEnv("DB_USER", "postgres"),
 			Password: getEnv("DB_PASSWORD", "password123"),
 			DBName:   getEnv("DB_NAME", "asset_db"),
 			SSLMode:  getEnv("DB_SSL_MODE", "disable"),
+			Replicas: getSliceEnv("DB_REPLICAS", nil),
+
+			BreakerFailureThreshold: getIntEnv("DB_BREAKER_FAILURE_THRESHOLD", 5),
+			BreakerOpenTimeout:      getDurationEnv("DB_BREAKER_OPEN_TIMEOUT", 10*time.Second),
+			RetryMaxAttempts:        getIntEnv("DB_RETRY_MAX_ATTEMPTS", 3),
+			RetryInitialBackoff:     getDurationEnv("DB_RETRY_INITIAL_BACKOFF", 20*time.Millisecond),
+			RetryMaxBackoff:         getDurationEnv("DB_RETRY_MAX_BACKOFF", 500*time.Millisecond),
+			RetryBudget:             getDurationEnv("DB_RETRY_BUDGET", 2*time.Second),
+			MaxOpenConns:            getIntEnv("DB_MAX_OPEN_CONNS", 100),
+			MaxIdleConns:            getIntEnv("DB_MAX_IDLE_CONNS", 10),
+			ConnMaxLifetime:         getDurationEnv("DB_CONN_MAX_LIFETIME", 30*time.Minute),
+			ConnMaxIdleTime:         getDurationEnv("DB_CONN_MAX_IDLE_TIME", 5*time.Minute),
+			StatsInterval:           getDurationEnv("DB_STATS_INTERVAL", 15*time.Second),
+			LogLevel:                getEnv("DB_LOG_LEVEL", "warn"),
+			SlowQueryThreshold:      getDurationEnv("DB_SLOW_QUERY_THRESHOLD", 200*time.Millisecond),
+			LogQueryParams:          getBoolEnv("DB_LOG_QUERY_PARAMS", false),
+
+			CredentialsRotationInterval: getDurationEnv("DB_CREDENTIALS_ROTATION_INTERVAL", 0),
+			MigrateOnStart:              getBoolEnv("DB_MIGRATE_ON_START", false),
+			AccessChecks:                getEnv("DB_ACCESS_CHECKS", "gorm"),
 		},
 		JWT: JWTConfig{
-			SecretKey:      getEnv("JWT_SECRET", "your-super-secret-key-change-in-production"),
+			SecretKey:      getEnv("JWT_SECRET", defaultJWTSecret),
 			ExpirationTime: getDurationEnv("JWT_EXPIRATION", 24*time.Hour),
+
+			TestLoginEnabled: getBoolEnv("TEST_LOGIN_ENABLED", false),
 		},
 		Kafka: KafkaConfig{
 			Enabled:               getBoolEnv("KAFKA_ENABLED", true),
@@ -96,15 +625,78 @@ func Load() (*Config, error) {
 			ProducerRetryMax:      getIntEnv("KAFKA_PRODUCER_RETRY_MAX", 3),
 			ProducerRequiredAcks:  getIntEnv("KAFKA_PRODUCER_REQUIRED_ACKS", 1),
 			ProducerFlushTimeout:  getDurationEnv("KAFKA_PRODUCER_FLUSH_TIMEOUT", 5*time.Second),
+			ProducerName:          getEnv("KAFKA_PRODUCER_NAME", "asset-management-api"),
+
+			ProducerAsync:          getBoolEnv("KAFKA_PRODUCER_ASYNC", false),
+			ProducerQueueSize:      getIntEnv("KAFKA_PRODUCER_QUEUE_SIZE", 10000),
+			ProducerWorkers:        getIntEnv("KAFKA_PRODUCER_WORKERS", 4),
+			ProducerOverflowPolicy: getEnv("KAFKA_PRODUCER_OVERFLOW_POLICY", "block"),
+			ProducerEnqueueTimeout: getDurationEnv("KAFKA_PRODUCER_ENQUEUE_TIMEOUT", 1*time.Second),
+			ProducerDrainTimeout:   getDurationEnv("KAFKA_PRODUCER_DRAIN_TIMEOUT", 10*time.Second),
+
+			ProducerBreakerFailureThreshold: getIntEnv("KAFKA_PRODUCER_BREAKER_FAILURE_THRESHOLD", 5),
+			ProducerBreakerOpenTimeout:      getDurationEnv("KAFKA_PRODUCER_BREAKER_OPEN_TIMEOUT", 10*time.Second),
+
 			ConsumerGroupID:       getEnv("KAFKA_CONSUMER_GROUP_ID", "asset-management-api"),
 			ConsumerSessionTimeout: getDurationEnv("KAFKA_CONSUMER_SESSION_TIMEOUT", 30*time.Second),
 			AutoCommitInterval:    getDurationEnv("KAFKA_CONSUMER_AUTO_COMMIT_INTERVAL", 1*time.Second),
+			ConsumerMaxRetries:    getIntEnv("KAFKA_CONSUMER_MAX_RETRIES", 3),
+			DeadLetterEnabled:     getBoolEnv("KAFKA_DLQ_ENABLED", true),
+			AutoCommit:            getBoolEnv("KAFKA_CONSUMER_AUTO_COMMIT", true),
+			ManualCommitTopics:    getSliceEnv("KAFKA_CONSUMER_MANUAL_COMMIT_TOPICS", nil),
+
+			ConsumerRetryBackoff:    getDurationEnv("KAFKA_CONSUMER_RETRY_BACKOFF", 1*time.Second),
+			ConsumerRetryMultiplier: getFloatEnv("KAFKA_CONSUMER_RETRY_MULTIPLIER", 2),
+			ConsumerRetryMaxBackoff: getDurationEnv("KAFKA_CONSUMER_RETRY_MAX_BACKOFF", 30*time.Second),
+			ConsumerRetryJitter:     getFloatEnv("KAFKA_CONSUMER_RETRY_JITTER", 0.2),
+			ConsumerTopicRetry:      getEnv("KAFKA_CONSUMER_TOPIC_RETRY", ""),
+
+			ConsumerConcurrency:      getIntEnv("KAFKA_CONSUMER_CONCURRENCY", 1),
+			ConsumerTopicConcurrency: getIntMapEnv("KAFKA_CONSUMER_TOPIC_CONCURRENCY"),
+			ConsumerDrainTimeout:     getDurationEnv("KAFKA_CONSUMER_DRAIN_TIMEOUT", 20*time.Second),
+
+			ConsumerDedupEnabled:       getBoolEnv("KAFKA_CONSUMER_DEDUP_ENABLED", true),
+			ConsumerDedupTTL:           getDurationEnv("KAFKA_CONSUMER_DEDUP_TTL", 24*time.Hour),
+			ConsumerDedupProcessingTTL: getDurationEnv("KAFKA_CONSUMER_DEDUP_PROCESSING_TTL", 5*time.Minute),
+
+			Serialization:              getEnv("KAFKA_SERIALIZATION", "json"),
+			SchemaRegistryURL:          getEnv("KAFKA_SCHEMA_REGISTRY_URL", ""),
+			SchemaRegistryUsername:     getEnv("KAFKA_SCHEMA_REGISTRY_USERNAME", ""),
+			SchemaRegistryPassword:     getEnv("KAFKA_SCHEMA_REGISTRY_PASSWORD", ""),
+			SchemaRegistryAutoRegister: getBoolEnv("KAFKA_SCHEMA_REGISTRY_AUTO_REGISTER", true),
+			SchemaRegistryTimeout:      getDurationEnv("KAFKA_SCHEMA_REGISTRY_TIMEOUT", 5*time.Second),
+			CloudEventsEnabled:         getBoolEnv("KAFKA_CLOUDEVENTS_ENABLED", false),
+			CloudEventsTopics:          getSliceEnv("KAFKA_CLOUDEVENTS_TOPICS", nil),
+			CloudEventsSource:          getEnv("KAFKA_CLOUDEVENTS_SOURCE", "/asset-management-api"),
+			CloudEventsTypePrefix:      getEnv("KAFKA_CLOUDEVENTS_TYPE_PREFIX", "com.assetmanagement."),
+
+			InMemoryEnabled:    getBoolEnv("EVENT_BUS_IN_MEMORY", false),
+			InMemoryBufferSize: getIntEnv("EVENT_BUS_IN_MEMORY_BUFFER", 0),
+
+			TLSEnabled:            getBoolEnv("KAFKA_TLS_ENABLED", false),
+			TLSCAFile:             getEnv("KAFKA_TLS_CA_FILE", ""),
+			TLSCertFile:           getEnv("KAFKA_TLS_CERT_FILE", ""),
+			TLSKeyFile:            getEnv("KAFKA_TLS_KEY_FILE", ""),
+			TLSServerName:         getEnv("KAFKA_TLS_SERVER_NAME", ""),
+			TLSInsecureSkipVerify: getBoolEnv("KAFKA_TLS_INSECURE_SKIP_VERIFY", false),
+			SASLMechanism:         getEnv("KAFKA_SASL_MECHANISM", ""),
+			SASLUsername:          getEnv("KAFKA_SASL_USERNAME", ""),
+			SASLPassword:          getEnv("KAFKA_SASL_PASSWORD", ""),
+			HealthCheckInterval:   getDurationEnv("KAFKA_HEALTH_CHECK_INTERVAL", 30*time.Second),
+			HealthCheckTimeout:    getDurationEnv("KAFKA_HEALTH_CHECK_TIMEOUT", 5*time.Second),
+			TopicsAutoCreate:       getBoolEnv("KAFKA_TOPICS_AUTO_CREATE", false),
+			Topics:                 getSliceEnv("KAFKA_TOPICS", []string{"team.activity", "asset.changes", "user.changes", "user.activity", "user.notifications"}),
+			TopicPartitions:        getIntEnv("KAFKA_TOPIC_PARTITIONS", 6),
+			TopicPartitionsByTopic: getIntMapEnv("KAFKA_TOPIC_PARTITIONS_BY_TOPIC"),
+			TopicReplicationFactor: getIntEnv("KAFKA_TOPIC_REPLICATION_FACTOR", 0),
+			TopicRetention:         getDurationEnv("KAFKA_TOPIC_RETENTION", 7*24*time.Hour),
 		},
 		// NEW: Redis configuration
 		Redis: RedisConfig{
 			Enabled:            getBoolEnv("REDIS_ENABLED", true),
 			Host:               getEnv("REDIS_HOST", "localhost"),
 			Port:               getEnv("REDIS_PORT", "6379"),
+			Username:           getEnv("REDIS_USERNAME", ""),
 			Password:           getEnv("REDIS_PASSWORD", ""),
 			Database:           getIntEnv("REDIS_DATABASE", 0),
 			PoolSize:           getIntEnv("REDIS_POOL_SIZE", 10),
@@ -118,48 +710,264 @@ func Load() (*Config, error) {
 			ReadTimeout:        getDurationEnv("REDIS_READ_TIMEOUT", 3*time.Second),
 			WriteTimeout:       getDurationEnv("REDIS_WRITE_TIMEOUT", 3*time.Second),
 			DialTimeout:        getDurationEnv("REDIS_DIAL_TIMEOUT", 5*time.Second),
+
+			CallBudget:              getDurationEnv("REDIS_CALL_BUDGET", 50*time.Millisecond),
+			BreakerFailureThreshold: getIntEnv("REDIS_BREAKER_FAILURE_THRESHOLD", 5),
+			BreakerOpenTimeout:      getDurationEnv("REDIS_BREAKER_OPEN_TIMEOUT", 10*time.Second),
+
+			TLSEnabled:            getBoolEnv("REDIS_TLS_ENABLED", false),
+			TLSCAFile:             getEnv("REDIS_TLS_CA_FILE", ""),
+			TLSCertFile:           getEnv("REDIS_TLS_CERT_FILE", ""),
+			TLSKeyFile:            getEnv("REDIS_TLS_KEY_FILE", ""),
+			TLSServerName:         getEnv("REDIS_TLS_SERVER_NAME", ""),
+			TLSInsecureSkipVerify: getBoolEnv("REDIS_TLS_INSECURE_SKIP_VERIFY", false),
+		},
+		Cache: CacheConfig{
+			Backend:           getEnv("CACHE_BACKEND", "redis"),
+			KeyVersion:        int64(getIntEnv("CACHE_KEY_VERSION", 1)),
+			KeyVersionRefresh: getDurationEnv("CACHE_KEY_VERSION_REFRESH", 10*time.Second),
+
+			Compression:          getEnv("CACHE_COMPRESSION", "none"),
+			CompressionThreshold: getIntEnv("CACHE_COMPRESSION_THRESHOLD", 1024),
+
+			L1Enabled:           getBoolEnv("CACHE_L1_ENABLED", false),
+			L1TTL:               getDurationEnv("CACHE_L1_TTL", 30*time.Second),
+			L1MaxEntries:        getIntEnv("CACHE_L1_MAX_ENTRIES", 10000),
+			InvalidationChannel: getEnv("CACHE_INVALIDATION_CHANNEL", "cache:invalidate"),
+		},
+		Memcached: MemcachedConfig{
+			Servers:      getSliceEnv("MEMCACHED_SERVERS", []string{"localhost:11211"}),
+			Timeout:      getDurationEnv("MEMCACHED_TIMEOUT", 500*time.Millisecond),
+			MaxIdleConns: getIntEnv("MEMCACHED_MAX_IDLE_CONNS", 10),
+		},
+		Webhook: WebhookConfig{
+			Enabled:        getBoolEnv("WEBHOOK_ENABLED", true),
+			Workers:        getIntEnv("WEBHOOK_WORKERS", 4),
+			QueueSize:      getIntEnv("WEBHOOK_QUEUE_SIZE", 1000),
+			MaxAttempts:    getIntEnv("WEBHOOK_MAX_ATTEMPTS", 5),
+			Timeout:        getDurationEnv("WEBHOOK_TIMEOUT", 10*time.Second),
+			InitialBackoff: getDurationEnv("WEBHOOK_INITIAL_BACKOFF", 1*time.Second),
+			MaxBackoff:     getDurationEnv("WEBHOOK_MAX_BACKOFF", 5*time.Minute),
+			PollInterval:   getDurationEnv("WEBHOOK_POLL_INTERVAL", 10*time.Second),
+		},
+		Realtime: RealtimeConfig{
+			Enabled:               getBoolEnv("REALTIME_ENABLED", true),
+			ClientBuffer:          getIntEnv("REALTIME_CLIENT_BUFFER", 64),
+			MaxConnectionsPerUser: getIntEnv("REALTIME_MAX_CONNECTIONS_PER_USER", 5),
+			Heartbeat:             getDurationEnv("REALTIME_HEARTBEAT", 25*time.Second),
+			ReplayLimit:           getIntEnv("REALTIME_REPLAY_LIMIT", 100),
+			AllowedOrigins:        getSliceEnv("REALTIME_ALLOWED_ORIGINS", nil),
+		},
+		RateLimit: RateLimitConfig{
+			Enabled: getBoolEnv("RATE_LIMIT_ENABLED", true),
+			Default: getEnv("RATE_LIMIT_DEFAULT", "300/1m"),
+			Roles:   getEnv("RATE_LIMIT_ROLES", "manager=600/1m"),
+			Routes:  getEnv("RATE_LIMIT_ROUTES", ""),
+		},
+		Quota: QuotaConfig{
+			Enabled:        getBoolEnv("QUOTA_ENABLED", true),
+			DailyDefault:   getIntEnv("QUOTA_DAILY_DEFAULT", 10000),
+			MonthlyDefault: getIntEnv("QUOTA_MONTHLY_DEFAULT", 200000),
+			Daily:          getIntMapEnv("QUOTA_DAILY_ROLES"),
+			Monthly:        getIntMapEnv("QUOTA_MONTHLY_ROLES"),
+		},
+		Tracing: TracingConfig{
+			Enabled:     getBoolEnv("OTEL_TRACING_ENABLED", false),
+			ServiceName: getEnv("OTEL_SERVICE_NAME", "asset-management-api"),
+			Endpoint:    getEnv("OTEL_EXPORTER_OTLP_ENDPOINT", "http://localhost:4318"),
+			SampleRatio: getFloatEnv("OTEL_TRACES_SAMPLER_ARG", 1.0),
+		},
+		Compression: CompressionConfig{
+			Enabled:      getBoolEnv("COMPRESSION_ENABLED", true),
+			MinSize:      getIntEnv("COMPRESSION_MIN_SIZE", 1024),
+			ContentTypes: getSliceEnv("COMPRESSION_CONTENT_TYPES", []string{"application/json", "text/plain", "text/csv"}),
+		},
+		Security: SecurityConfig{
+			HSTSMaxAge:            getDurationEnv("SECURITY_HSTS_MAX_AGE", 0),
+			HSTSIncludeSubdomains: getBoolEnv("SECURITY_HSTS_INCLUDE_SUBDOMAINS", false),
+			HSTSPreload:           getBoolEnv("SECURITY_HSTS_PRELOAD", false),
+			ContentSecurityPolicy: getEnv("SECURITY_CSP", "default-src 'self'"),
+			FrameOptions:          getEnv("SECURITY_FRAME_OPTIONS", "DENY"),
+			ReferrerPolicy:        getEnv("SECURITY_REFERRER_POLICY", "strict-origin-when-cross-origin"),
+
+			RelaxedRoutes:                getSliceEnv("SECURITY_RELAXED_ROUTES", nil),
+			RelaxedContentSecurityPolicy: getEnv("SECURITY_RELAXED_CSP", "default-src 'self'; img-src 'self' data:; frame-ancestors *"),
+			RelaxedFrameOptions:          getEnv("SECURITY_RELAXED_FRAME_OPTIONS", ""),
+			RelaxedReferrerPolicy:        getEnv("SECURITY_RELAXED_REFERRER_POLICY", "no-referrer"),
+		},
+		ResponseCache: ResponseCacheConfig{
+			Enabled: getBoolEnv("RESPONSE_CACHE_ENABLED", true),
+			TTL:     getDurationEnv("RESPONSE_CACHE_TTL", 15*time.Second),
+			Routes:  getSliceEnv("RESPONSE_CACHE_ROUTES", []string{"/api/v1/folders", "/api/v1/teams", "/api/v1/teams/:teamId"}),
+		},
+		IPFilter: IPFilterConfig{
+			Enabled:         getBoolEnv("IP_FILTER_ENABLED", true),
+			Allowlist:       getSliceEnv("IP_ALLOWLIST", nil),
+			AllowlistRoutes: getSliceEnv("IP_ALLOWLIST_ROUTES", []string{"/api/v1/admin/", "/api/v1/teams/:teamId/assets", "/api/v1/users/:userId/assets", "/debug/"}),
+			Denylist:        getSliceEnv("IP_DENYLIST", nil),
+			RefreshInterval: getDurationEnv("IP_DENYLIST_REFRESH_INTERVAL", 10*time.Second),
+		},
+		Maintenance: MaintenanceConfig{
+			Message:         getEnv("MAINTENANCE_MESSAGE", "The service is undergoing scheduled maintenance. Please try again shortly."),
+			RetryAfter:      getDurationEnv("MAINTENANCE_RETRY_AFTER", 5*time.Minute),
+			RefreshInterval: getDurationEnv("MAINTENANCE_REFRESH_INTERVAL", 5*time.Second),
+		},
+		BodyLimit: BodyLimitConfig{
+			Enabled: getBoolEnv("BODY_LIMIT_ENABLED", true),
+			Default: getIntEnv("BODY_LIMIT_DEFAULT", 1<<20),
+			Routes:  getIntMapEnv("BODY_LIMIT_ROUTES"),
+		},
+		Concurrency: ConcurrencyConfig{
+			Enabled:      getBoolEnv("CONCURRENCY_LIMIT_ENABLED", true),
+			Default:      getIntEnv("CONCURRENCY_LIMIT_DEFAULT", 100),
+			Routes:       getIntMapEnv("CONCURRENCY_LIMIT_ROUTES"),
+			QueueTimeout: getDurationEnv("CONCURRENCY_QUEUE_TIMEOUT", 100*time.Millisecond),
 		},
+		Batch: BatchConfig{
+			Enabled:     getBoolEnv("BATCH_ENABLED", true),
+			MaxRequests: getIntEnv("BATCH_MAX_REQUESTS", 20),
+		},
+		Debug: DebugConfig{
+			Enabled:              getBoolEnv("DEBUG_ENDPOINTS_ENABLED", false),
+			AllowedUserIDs:       getUUIDSliceEnv("DEBUG_ALLOWED_USER_IDS"),
+			BlockProfileRate:     getIntEnv("DEBUG_BLOCK_PROFILE_RATE", 0),
+			MutexProfileFraction: getIntEnv("DEBUG_MUTEX_PROFILE_FRACTION", 0),
+		},
+		Logging: LoggingConfig{
+			Level:              getEnv("LOG_LEVEL", "info"),
+			Format:             getEnv("LOG_FORMAT", "json"),
+			Outputs:            getSliceEnv("LOG_OUTPUTS", []string{"stdout", "file"}),
+			FilePath:           getEnv("LOG_FILE_PATH", "logs/api.log"),
+			FileMaxSizeMB:      getIntEnv("LOG_FILE_MAX_SIZE_MB", 100),
+			FileMaxBackups:     getIntEnv("LOG_FILE_MAX_BACKUPS", 7),
+			FileMaxAge:         getDurationEnv("LOG_FILE_MAX_AGE", 7*24*time.Hour),
+			FileRotateInterval: getDurationEnv("LOG_FILE_ROTATE_INTERVAL", 24*time.Hour),
+			FileCompress:       getBoolEnv("LOG_FILE_COMPRESS", true),
+			SampleSuccessRate:  getFloatEnv("LOG_SAMPLE_SUCCESS_RATE", 0.01),
+			SampleErrorRate:    getFloatEnv("LOG_SAMPLE_ERROR_RATE", 1.0),
+			SampleRoutes:       getFloatMapEnv("LOG_SAMPLE_ROUTES"),
+		},
+		I18n: I18nConfig{
+			CatalogDir:      getEnv("I18N_CATALOG_DIR", "locales"),
+			DefaultLanguage: getEnv("I18N_DEFAULT_LANGUAGE", "en"),
+		},
+		ErrorReporting: ErrorReportingConfig{
+			Enabled:     getBoolEnv("ERROR_REPORTING_ENABLED", false),
+			DSN:         getEnv("SENTRY_DSN", ""),
+			Environment: getEnv("SENTRY_ENVIRONMENT", "development"),
+			Release:     getEnv("SENTRY_RELEASE", "asset-management-api@1.0.0"),
+			SampleRate:  getFloatEnv("SENTRY_SAMPLE_RATE", 1.0),
+		},
+		APIAudit: APIAuditConfig{
+			Enabled:       getBoolEnv("API_AUDIT_ENABLED", true),
+			BufferSize:    getIntEnv("API_AUDIT_BUFFER_SIZE", 10000),
+			BatchSize:     getIntEnv("API_AUDIT_BATCH_SIZE", 100),
+			FlushInterval: getDurationEnv("API_AUDIT_FLUSH_INTERVAL", time.Second),
+		},
+		Retention: RetentionConfig{
+			CheckInterval:      getDurationEnv("RETENTION_CHECK_INTERVAL", 24*time.Hour),
+			APIAuditMonths:     getIntEnv("RETENTION_API_AUDIT_MONTHS", 12),
+			UserActivityMonths: getIntEnv("RETENTION_USER_ACTIVITY_MONTHS", 24),
+			AssetEventMonths:   getIntEnv("RETENTION_ASSET_EVENT_MONTHS", 0),
+			NotificationMonths: getIntEnv("RETENTION_NOTIFICATION_MONTHS", 3),
+		},
+		Backup: BackupConfig{
+			S3Bucket:      getEnv("BACKUP_S3_BUCKET", ""),
+			S3Prefix:      getEnv("BACKUP_S3_PREFIX", "backups"),
+			S3Region:      getEnv("BACKUP_S3_REGION", getEnv("AWS_REGION", "")),
+			S3Endpoint:    getEnv("BACKUP_S3_ENDPOINT", ""),
+			Interval:      getDurationEnv("BACKUP_INTERVAL", 0),
+			Timeout:       getDurationEnv("BACKUP_TIMEOUT", 5*time.Minute),
+			ExportWorkers: getIntEnv("BACKUP_EXPORT_WORKERS", 2),
+			ExportTimeout: getDurationEnv("BACKUP_EXPORT_TIMEOUT", 30*time.Minute),
+			ExportLinkTTL: getDurationEnv("BACKUP_EXPORT_LINK_TTL", 15*time.Minute),
+		},
+		Metrics: MetricsConfig{
+			HTTPLatencyBuckets: getFloatSliceEnv("METRICS_HTTP_LATENCY_BUCKETS"),
+			DBLatencyBuckets:   getFloatSliceEnv("METRICS_DB_LATENCY_BUCKETS"),
+		},
+		Health: HealthConfig{
+			ReadinessTimeout:     getDurationEnv("READINESS_TIMEOUT", 2*time.Second),
+			CriticalDependencies: getSliceEnv("READINESS_CRITICAL_DEPENDENCIES", []string{"database"}),
+		},
+		Secrets: SecretsConfig{
+			VaultAddress:   getEnv("VAULT_ADDR", ""),
+			VaultToken:     getEnv("VAULT_TOKEN", ""),
+			VaultNamespace: getEnv("VAULT_NAMESPACE", ""),
+
+			AWSRegion:          getEnv("AWS_REGION", ""),
+			AWSEndpoint:        getEnv("AWS_SECRETS_MANAGER_ENDPOINT", ""),
+			AWSAccessKeyID:     getEnv("AWS_ACCESS_KEY_ID", ""),
+			AWSSecretAccessKey: getEnv("AWS_SECRET_ACCESS_KEY", ""),
+			AWSSessionToken:    getEnv("AWS_SESSION_TOKEN", ""),
+
+			Timeout: getDurationEnv("SECRETS_TIMEOUT", 10*time.Second),
+		},
+	}
+
+	if err := config.resolveSecrets(); err != nil {
+		return nil, err
 	}
 
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
 	return config, nil
 }
 
 func getEnv(key, defaultValue string) string {
-	if value := os.Getenv(key); value != "" {
+	if value := lookupEnv(key); value != "" {
 		return value
 	}
 	return defaultValue
 }
 
 func getDurationEnv(key string, defaultValue time.Duration) time.Duration {
-	if value := os.Getenv(key); value != "" {
-		if duration, err := time.ParseDuration(value); err == nil {
+	if value := lookupEnv(key); value != "" {
+		duration, err := time.ParseDuration(value)
+		if err == nil {
 			return duration
 		}
+		invalidSetting(key, value, "a duration such as 30s or 5m")
 	}
 	return defaultValue
 }
 
 func getIntEnv(key string, defaultValue int) int {
-	if value := os.Getenv(key); value != "" {
-		if intValue, err := strconv.Atoi(value); err == nil {
+	if value := lookupEnv(key); value != "" {
+		intValue, err := strconv.Atoi(value)
+		if err == nil {
 			return intValue
 		}
+		invalidSetting(key, value, "an integer")
+	}
+	return defaultValue
+}
+
+func getFloatEnv(key string, defaultValue float64) float64 {
+	if value := lookupEnv(key); value != "" {
+		floatValue, err := strconv.ParseFloat(value, 64)
+		if err == nil {
+			return floatValue
+		}
+		invalidSetting(key, value, "a number")
 	}
 	return defaultValue
 }
 
 func getBoolEnv(key string, defaultValue bool) bool {
-	if value := os.Getenv(key); value != "" {
-		if boolValue, err := strconv.ParseBool(value); err == nil {
+	if value := lookupEnv(key); value != "" {
+		boolValue, err := strconv.ParseBool(value)
+		if err == nil {
 			return boolValue
 		}
+		invalidSetting(key, value, "true or false")
 	}
 	return defaultValue
 }
 
 func getSliceEnv(key string, defaultValue []string) []string {
-	if value := os.Getenv(key); value != "" {
+	if value := lookupEnv(key); value != "" {
 		var result []string
 		for _, v := range splitAndTrim(value, ",") {
 			if v != "" {
@@ -173,6 +981,72 @@ func getSliceEnv(key string, defaultValue []string) []string {
 	return defaultValue
 }
 
+// getUUIDSliceEnv parses "id,id,id"
+func getUUIDSliceEnv(key string) []uuid.UUID {
+	var result []uuid.UUID
+	for _, value := range getSliceEnv(key, nil) {
+		id, err := uuid.Parse(value)
+		if err != nil {
+			invalidSetting(key, value, "a comma-separated list of user IDs")
+			continue
+		}
+		result = append(result, id)
+	}
+	return result
+}
+
+// getFloatSliceEnv parses "x,x,x"
+func getFloatSliceEnv(key string) []float64 {
+	var result []float64
+	for _, value := range getSliceEnv(key, nil) {
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			invalidSetting(key, value, "a comma-separated list of numbers")
+			continue
+		}
+		result = append(result, f)
+	}
+	return result
+}
+
+// getFloatMapEnv parses "key=x,key=x" pairs
+func getFloatMapEnv(key string) map[string]float64 {
+	result := make(map[string]float64)
+	for _, pair := range getSliceEnv(key, nil) {
+		name, value, ok := strings.Cut(pair, "=")
+		if !ok {
+			invalidSetting(key, pair, "key=number pairs")
+			continue
+		}
+		f, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil {
+			invalidSetting(key, pair, "key=number pairs")
+			continue
+		}
+		result[strings.TrimSpace(name)] = f
+	}
+	return result
+}
+
+// getIntMapEnv parses "key=n,key=n" pairs
+func getIntMapEnv(key string) map[string]int {
+	result := make(map[string]int)
+	for _, pair := range getSliceEnv(key, nil) {
+		name, value, ok := strings.Cut(pair, "=")
+		if !ok {
+			invalidSetting(key, pair, "key=integer pairs")
+			continue
+		}
+		n, err := strconv.Atoi(strings.TrimSpace(value))
+		if err != nil {
+			invalidSetting(key, pair, "key=integer pairs")
+			continue
+		}
+		result[strings.TrimSpace(name)] = n
+	}
+	return result
+}
+
 func splitAndTrim(s, sep string) []string {
 	parts := make([]string, 0)
 	for _, part := range strings.Split(s, sep) {
@@ -0,0 +1,142 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pelletier/go-toml/v2"
+	"gopkg.in/yaml.v3"
+)
+
+// defaultConfigFile is read when no configuration file is named, if it exists
+const defaultConfigFile = "config.yaml"
+
+// fileValues holds the settings of the configuration files, keyed by the
+// environment variable each stands for. Environment variables override them.
+var fileValues = map[string]string{}
+
+// lookupEnv returns the value of an environment variable, falling back to
+// the configuration files
+func lookupEnv(key string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return fileValues[key]
+}
+
+// loadFiles reads the configuration file at path and then, when a profile
+// is given, the profile's file next to it, e.g. config.prod.yaml, whose
+// values take precedence. Without a path the default file is read if there
+// is one.
+func loadFiles(path, profile string) (map[string]string, error) {
+	required := path != ""
+	if !required {
+		path = defaultConfigFile
+	}
+
+	values := make(map[string]string)
+	if err := loadFile(path, required, values); err != nil {
+		return nil, err
+	}
+	if profile != "" {
+		ext := filepath.Ext(path)
+		profilePath := strings.TrimSuffix(path, ext) + "." + profile + ext
+		if err := loadFile(profilePath, false, values); err != nil {
+			return nil, err
+		}
+	}
+	return values, nil
+}
+
+// loadFile adds the settings of a YAML or TOML file to values
+func loadFile(path string, required bool, values map[string]string) error {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) && !required {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	settings := make(map[string]interface{})
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &settings)
+	case ".toml":
+		err = toml.Unmarshal(data, &settings)
+	default:
+		return fmt.Errorf("unsupported config file format %q: expected .yaml, .yml or .toml", ext)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+
+	return flatten("", settings, values)
+}
+
+// flatten names settings after the environment variables they stand for:
+// nested keys are joined with underscores and upper-cased, so server.port
+// sets SERVER_PORT. Lists are joined with commas; settings taking a map,
+// such as QUOTA_DAILY_ROLES, are written as in the environment.
+func flatten(prefix string, value interface{}, values map[string]string) error {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			name := strings.ToUpper(key)
+			if prefix != "" {
+				name = prefix + "_" + name
+			}
+			if err := flatten(name, v[key], values); err != nil {
+				return err
+			}
+		}
+	case []interface{}:
+		items := make([]string, 0, len(v))
+		for _, item := range v {
+			s, err := scalar(prefix, item)
+			if err != nil {
+				return err
+			}
+			items = append(items, s)
+		}
+		values[prefix] = strings.Join(items, ",")
+	default:
+		s, err := scalar(prefix, v)
+		if err != nil {
+			return err
+		}
+		values[prefix] = s
+	}
+	return nil
+}
+
+func scalar(name string, value interface{}) (string, error) {
+	switch v := value.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	case bool, int, int64, uint64:
+		return fmt.Sprint(v), nil
+	case time.Time:
+		return v.Format(time.RFC3339), nil
+	case fmt.Stringer:
+		// TOML local dates and times
+		return v.String(), nil
+	}
+	return "", fmt.Errorf("invalid config setting %s: unsupported value %v", name, value)
+}
@@ -0,0 +1,79 @@
+package config
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"sync"
+	"sync/atomic"
+	"syscall"
+)
+
+// Holder keeps the current configuration and replaces it when reloaded, so
+// that settings can be changed without a restart. Most settings are read once
+// at startup; components that support reloading register with OnReload to
+// apply the new values. As environment variables cannot change in a running
+// process, reloads pick up changes to the configuration files.
+type Holder struct {
+	path    string
+	profile string
+	current atomic.Pointer[Config]
+
+	mu        sync.Mutex // serializes reloads
+	listeners []func(*Config)
+}
+
+// NewHolder creates a holder of cfg, loaded from the configuration file at
+// path and profile, which reloads read again
+func NewHolder(cfg *Config, path, profile string) *Holder {
+	h := &Holder{path: path, profile: profile}
+	h.current.Store(cfg)
+	return h
+}
+
+// Current returns the configuration in effect
+func (h *Holder) Current() *Config {
+	return h.current.Load()
+}
+
+// OnReload registers fn to apply the configuration after each reload
+func (h *Holder) OnReload(fn func(*Config)) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.listeners = append(h.listeners, fn)
+}
+
+// Reload loads and validates the configuration and, if it is valid, makes it
+// current and applies it. An invalid configuration is rejected and the
+// current one stays in effect.
+func (h *Holder) Reload() error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	cfg, err := Load(h.path, h.profile)
+	if err != nil {
+		return err
+	}
+	h.current.Store(cfg)
+	for _, fn := range h.listeners {
+		fn(cfg)
+	}
+	return nil
+}
+
+// WatchSignals reloads the configuration on every SIGHUP until ctx is done,
+// passing the outcome of each reload to done
+func (h *Holder) WatchSignals(ctx context.Context, done func(error)) {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGHUP)
+	defer signal.Stop(signals)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-signals:
+			done(h.Reload())
+		}
+	}
+}
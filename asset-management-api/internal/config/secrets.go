@@ -0,0 +1,61 @@
+package config
+
+import (
+	"context"
+
+	"asset-management-api/internal/secrets"
+	secretRefs "asset-management-api/pkg/secrets"
+)
+
+// NewSecretResolver creates a resolver of the secret stores configured in
+// cfg: Vault when VAULT_ADDR is set and AWS Secrets Manager when AWS_REGION
+// is
+func NewSecretResolver(cfg *SecretsConfig) *secretRefs.Resolver {
+	resolver := secretRefs.NewResolver()
+	if cfg.VaultAddress != "" {
+		resolver.Register(secretRefs.SchemeVault, secrets.NewVaultProvider(secrets.VaultConfig{
+			Address:   cfg.VaultAddress,
+			Token:     cfg.VaultToken,
+			Namespace: cfg.VaultNamespace,
+			Timeout:   cfg.Timeout,
+		}))
+	}
+	if cfg.AWSRegion != "" {
+		resolver.Register(secretRefs.SchemeAWSSecretsManager, secrets.NewAWSSecretsManagerProvider(secrets.AWSSecretsManagerConfig{
+			Region:          cfg.AWSRegion,
+			Endpoint:        cfg.AWSEndpoint,
+			AccessKeyID:     cfg.AWSAccessKeyID,
+			SecretAccessKey: cfg.AWSSecretAccessKey,
+			SessionToken:    cfg.AWSSessionToken,
+			Timeout:         cfg.Timeout,
+		}))
+	}
+	return resolver
+}
+
+// resolveSecrets replaces the secret references among the credentials with
+// the secrets. The database user and password references are kept for
+// credential rotation.
+func (c *Config) resolveSecrets() error {
+	if _, ok := secretRefs.ParseReference(c.Database.User); ok {
+		c.Database.UserSecret = c.Database.User
+	}
+	if _, ok := secretRefs.ParseReference(c.Database.Password); ok {
+		c.Database.PasswordSecret = c.Database.Password
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), c.Secrets.Timeout)
+	defer cancel()
+	return NewSecretResolver(&c.Secrets).Resolve(ctx,
+		&c.JWT.SecretKey,
+		&c.Database.User,
+		&c.Database.Password,
+		&c.Redis.Username,
+		&c.Redis.Password,
+		&c.Kafka.SASLUsername,
+		&c.Kafka.SASLPassword,
+		&c.Kafka.SchemaRegistryUsername,
+		&c.Kafka.SchemaRegistryPassword,
+		&c.ErrorReporting.DSN,
+	)
+}
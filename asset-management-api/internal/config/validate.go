@@ -0,0 +1,265 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"asset-management-api/internal/i18n"
+)
+
+// defaultJWTSecret is the placeholder secret used when JWT_SECRET is unset
+const defaultJWTSecret = "your-super-secret-key-change-in-production"
+
+// minProductionJWTSecretLength is the shortest JWT secret accepted in
+// production, 256 bits for HS256
+const minProductionJWTSecretLength = 32
+
+// productionProfiles are the configuration profiles treated as production
+var productionProfiles = []string{"prod", "production"}
+
+// settingErrors collects the settings Load could not parse. Their defaults are
+// used instead, which would hide the mistake, so Validate reports them.
+var settingErrors []error
+
+func invalidSetting(key, value, expected string) {
+	settingErrors = append(settingErrors, fmt.Errorf("%s: invalid value %q, expected %s", key, value, expected))
+}
+
+// Production reports whether the service runs in production, as named by the
+// configuration profile or the error reporting environment
+func (c *Config) Production() bool {
+	for _, name := range []string{c.Profile, c.ErrorReporting.Environment} {
+		for _, profile := range productionProfiles {
+			if strings.EqualFold(name, profile) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Validate checks the configuration for settings the service cannot run with
+// and returns all of them joined in one error, so they can be fixed at once
+func (c *Config) Validate() error {
+	errs := append([]error(nil), settingErrors...)
+	check := func(ok bool, format string, args ...interface{}) {
+		if !ok {
+			errs = append(errs, fmt.Errorf(format, args...))
+		}
+	}
+	positive := func(key string, d time.Duration) {
+		check(d > 0, "%s: must be positive, got %s", key, d)
+	}
+	port := func(key, value string) {
+		if err := validatePort(value); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", key, err))
+		}
+	}
+	addresses := func(key string, values []string) {
+		check(len(values) > 0, "%s: at least one address is required", key)
+		for _, value := range values {
+			if err := validateAddress(value); err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", key, err))
+			}
+		}
+	}
+	ratio := func(key string, value float64) {
+		check(value >= 0 && value <= 1, "%s: must be between 0 and 1, got %g", key, value)
+	}
+
+	port("SERVER_PORT", c.Server.Port)
+	if c.Server.GRPCPort != "" {
+		port("GRPC_PORT", c.Server.GRPCPort)
+		check(c.Server.GRPCPort != c.Server.Port, "GRPC_PORT: must differ from SERVER_PORT")
+	}
+	positive("SERVER_READ_TIMEOUT", c.Server.ReadTimeout)
+	positive("SERVER_WRITE_TIMEOUT", c.Server.WriteTimeout)
+	check(c.Server.ErrorFormat == "envelope" || c.Server.ErrorFormat == "problem",
+		"SERVER_ERROR_FORMAT: must be envelope or problem, got %q", c.Server.ErrorFormat)
+
+	check(c.Database.DBName != "", "DB_NAME: must not be empty")
+	switch c.Database.Driver {
+	case "postgres", "mysql":
+		check(c.Database.Host != "", "DB_HOST: must not be empty")
+		port("DB_PORT", c.Database.Port)
+	case "sqlite":
+		check(len(c.Database.Replicas) == 0, "DB_REPLICAS: not supported with SQLite")
+	default:
+		errs = append(errs, fmt.Errorf("DB_DRIVER: must be postgres, mysql or sqlite, got %q", c.Database.Driver))
+	}
+	switch c.Database.AccessChecks {
+	case "gorm":
+	case "raw":
+		check(c.Database.Driver == "postgres", "DB_ACCESS_CHECKS: raw is only supported with Postgres")
+	default:
+		errs = append(errs, fmt.Errorf("DB_ACCESS_CHECKS: must be gorm or raw, got %q", c.Database.AccessChecks))
+	}
+	check(c.Database.RetryMaxAttempts >= 1, "DB_RETRY_MAX_ATTEMPTS: must be at least 1, got %d", c.Database.RetryMaxAttempts)
+	positive("DB_RETRY_INITIAL_BACKOFF", c.Database.RetryInitialBackoff)
+	check(c.Database.RetryMaxBackoff >= c.Database.RetryInitialBackoff,
+		"DB_RETRY_MAX_BACKOFF: must not be below DB_RETRY_INITIAL_BACKOFF (%s), got %s", c.Database.RetryInitialBackoff, c.Database.RetryMaxBackoff)
+	positive("DB_RETRY_BUDGET", c.Database.RetryBudget)
+	check(c.Database.MaxOpenConns >= 0, "DB_MAX_OPEN_CONNS: must not be negative, got %d", c.Database.MaxOpenConns)
+	check(c.Database.MaxIdleConns >= 0, "DB_MAX_IDLE_CONNS: must not be negative, got %d", c.Database.MaxIdleConns)
+	if c.Database.MaxOpenConns > 0 {
+		check(c.Database.MaxIdleConns <= c.Database.MaxOpenConns,
+			"DB_MAX_IDLE_CONNS: must not exceed DB_MAX_OPEN_CONNS (%d), got %d", c.Database.MaxOpenConns, c.Database.MaxIdleConns)
+	}
+	check(c.Database.ConnMaxLifetime >= 0, "DB_CONN_MAX_LIFETIME: must not be negative, got %s", c.Database.ConnMaxLifetime)
+	check(c.Database.ConnMaxIdleTime >= 0, "DB_CONN_MAX_IDLE_TIME: must not be negative, got %s", c.Database.ConnMaxIdleTime)
+	for _, replica := range c.Database.Replicas {
+		if err := validateAddress(replica); err != nil {
+			errs = append(errs, fmt.Errorf("DB_REPLICAS: %w", err))
+		}
+	}
+
+	check(c.JWT.SecretKey != "", "JWT_SECRET: must not be empty")
+	if c.Production() {
+		check(c.JWT.SecretKey != defaultJWTSecret, "JWT_SECRET: the default secret must not be used in production")
+		check(len(c.JWT.SecretKey) >= minProductionJWTSecretLength,
+			"JWT_SECRET: must be at least %d bytes in production", minProductionJWTSecretLength)
+		// Anyone could get a manager token and through every admin route
+		check(!c.JWT.TestLoginEnabled, "TEST_LOGIN_ENABLED: must not be enabled in production")
+	}
+	positive("JWT_EXPIRATION", c.JWT.ExpirationTime)
+
+	if c.Kafka.Enabled {
+		addresses("KAFKA_BROKERS", c.Kafka.Brokers)
+		check(c.Kafka.ProducerRequiredAcks >= -1 && c.Kafka.ProducerRequiredAcks <= 1,
+			"KAFKA_PRODUCER_REQUIRED_ACKS: must be -1, 0 or 1, got %d", c.Kafka.ProducerRequiredAcks)
+		check(c.Kafka.ConsumerGroupID != "", "KAFKA_CONSUMER_GROUP_ID: must not be empty")
+		positive("KAFKA_CONSUMER_SESSION_TIMEOUT", c.Kafka.ConsumerSessionTimeout)
+		check((c.Kafka.TLSCertFile == "") == (c.Kafka.TLSKeyFile == ""),
+			"KAFKA_TLS_CERT_FILE and KAFKA_TLS_KEY_FILE: must be set together")
+		if c.Kafka.SASLMechanism != "" {
+			check(c.Kafka.SASLUsername != "", "KAFKA_SASL_USERNAME: required with KAFKA_SASL_MECHANISM")
+		}
+	}
+
+	if c.Redis.Enabled {
+		check(c.Redis.Host != "", "REDIS_HOST: must not be empty")
+		port("REDIS_PORT", c.Redis.Port)
+		check(c.Redis.PoolSize > 0, "REDIS_POOL_SIZE: must be positive, got %d", c.Redis.PoolSize)
+		positive("REDIS_DIAL_TIMEOUT", c.Redis.DialTimeout)
+		check((c.Redis.TLSCertFile == "") == (c.Redis.TLSKeyFile == ""),
+			"REDIS_TLS_CERT_FILE and REDIS_TLS_KEY_FILE: must be set together")
+	}
+
+	switch c.Cache.Backend {
+	case "redis":
+	case "memcached":
+		addresses("MEMCACHED_SERVERS", c.Memcached.Servers)
+	default:
+		errs = append(errs, fmt.Errorf("CACHE_BACKEND: must be redis or memcached, got %q", c.Cache.Backend))
+	}
+
+	if c.Tracing.Enabled {
+		ratio("OTEL_TRACES_SAMPLER_ARG", c.Tracing.SampleRatio)
+	}
+	if c.Debug.Enabled {
+		// Profiles reveal memory contents, so no role is trusted with them
+		check(len(c.Debug.AllowedUserIDs) > 0, "DEBUG_ALLOWED_USER_IDS: required with DEBUG_ENDPOINTS_ENABLED")
+	}
+	if c.Batch.Enabled {
+		check(c.Batch.MaxRequests >= 1, "BATCH_MAX_REQUESTS: must be at least 1, got %d", c.Batch.MaxRequests)
+	}
+	if c.ErrorReporting.Enabled {
+		check(c.ErrorReporting.DSN != "", "SENTRY_DSN: required with ERROR_REPORTING_ENABLED")
+		ratio("SENTRY_SAMPLE_RATE", c.ErrorReporting.SampleRate)
+	}
+	switch strings.ToLower(c.Logging.Level) {
+	case "trace", "debug", "info", "warn", "warning", "error", "fatal", "panic":
+	default:
+		errs = append(errs, fmt.Errorf("LOG_LEVEL: must be debug, info, warn or error, got %q", c.Logging.Level))
+	}
+	ratio("LOG_SAMPLE_SUCCESS_RATE", c.Logging.SampleSuccessRate)
+	ratio("LOG_SAMPLE_ERROR_RATE", c.Logging.SampleErrorRate)
+	check(i18n.ValidTag(c.I18n.DefaultLanguage), "I18N_DEFAULT_LANGUAGE: must be a language tag such as en or pt-BR, got %q", c.I18n.DefaultLanguage)
+
+	positive("RETENTION_CHECK_INTERVAL", c.Retention.CheckInterval)
+	months := func(key string, value int) {
+		check(value >= 0, "%s: must not be negative, got %d", key, value)
+	}
+	months("RETENTION_API_AUDIT_MONTHS", c.Retention.APIAuditMonths)
+	months("RETENTION_USER_ACTIVITY_MONTHS", c.Retention.UserActivityMonths)
+	months("RETENTION_ASSET_EVENT_MONTHS", c.Retention.AssetEventMonths)
+	months("RETENTION_NOTIFICATION_MONTHS", c.Retention.NotificationMonths)
+
+	if c.Backup.S3Bucket != "" {
+		check(c.Backup.S3Region != "", "BACKUP_S3_REGION: required with BACKUP_S3_BUCKET")
+		check(c.Secrets.AWSAccessKeyID != "" && c.Secrets.AWSSecretAccessKey != "",
+			"AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY: required with BACKUP_S3_BUCKET")
+		check(c.Backup.Interval >= 0, "BACKUP_INTERVAL: must not be negative, got %s", c.Backup.Interval)
+		positive("BACKUP_TIMEOUT", c.Backup.Timeout)
+		check(c.Backup.ExportWorkers > 0, "BACKUP_EXPORT_WORKERS: must be positive, got %d", c.Backup.ExportWorkers)
+		positive("BACKUP_EXPORT_TIMEOUT", c.Backup.ExportTimeout)
+		// S3 refuses presigned URLs valid for longer than a week
+		check(c.Backup.ExportLinkTTL >= time.Second && c.Backup.ExportLinkTTL <= 7*24*time.Hour,
+			"BACKUP_EXPORT_LINK_TTL: must be between 1s and 168h, got %s", c.Backup.ExportLinkTTL)
+	}
+
+	positive("READINESS_TIMEOUT", c.Health.ReadinessTimeout)
+
+	positive("SECRETS_TIMEOUT", c.Secrets.Timeout)
+	if c.Secrets.VaultAddress != "" {
+		check(c.Secrets.VaultToken != "", "VAULT_TOKEN: required with VAULT_ADDR")
+	}
+	if c.Secrets.AWSRegion != "" {
+		check(c.Secrets.AWSAccessKeyID != "" && c.Secrets.AWSSecretAccessKey != "",
+			"AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY: required with AWS_REGION")
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+	return fmt.Errorf("invalid configuration:\n%w", errors.Join(errs...))
+}
+
+// Warnings returns settings that are allowed but likely mistakes, to be
+// logged at startup
+func (c *Config) Warnings() []string {
+	var warnings []string
+	if c.JWT.SecretKey == defaultJWTSecret {
+		warnings = append(warnings, "JWT_SECRET is the default secret; set it before deploying to production")
+	}
+	if c.Kafka.Enabled && c.Kafka.TLSInsecureSkipVerify {
+		warnings = append(warnings, "KAFKA_TLS_INSECURE_SKIP_VERIFY disables verification of the brokers' certificates")
+	}
+	if c.Redis.Enabled && c.Redis.TLSInsecureSkipVerify {
+		warnings = append(warnings, "REDIS_TLS_INSECURE_SKIP_VERIFY disables verification of the Redis certificate")
+	}
+	if c.Database.CredentialsRotationInterval > 0 && c.Database.UserSecret == "" && c.Database.PasswordSecret == "" {
+		warnings = append(warnings, "DB_CREDENTIALS_ROTATION_INTERVAL has no effect as DB_USER and DB_PASSWORD are not secret references")
+	}
+	if c.Production() && c.Debug.Enabled {
+		warnings = append(warnings, "DEBUG_ENDPOINTS_ENABLED exposes profiling endpoints in production")
+	}
+	return warnings
+}
+
+// validateAddress checks a host:port address
+func validateAddress(address string) error {
+	host, port, err := net.SplitHostPort(address)
+	if err != nil {
+		return fmt.Errorf("invalid address %q, expected host:port", address)
+	}
+	if host == "" {
+		return fmt.Errorf("invalid address %q, the host is missing", address)
+	}
+	if err := validatePort(port); err != nil {
+		return fmt.Errorf("invalid address %q: %w", address, err)
+	}
+	return nil
+}
+
+func validatePort(port string) error {
+	n, err := strconv.Atoi(port)
+	if err != nil || n < 1 || n > 65535 {
+		return fmt.Errorf("invalid port %q, expected 1-65535", port)
+	}
+	return nil
+}
@@ -0,0 +1,106 @@
+package database
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"net"
+
+	"asset-management-api/internal/breaker"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+)
+
+// breakerAllowedKey marks statements let through by the breaker, which must
+// report their outcome
+const breakerAllowedKey = "circuit_breaker:allowed"
+
+// breakerPlugin puts a circuit breaker in front of every statement run
+// through GORM, so repositories fail fast while Postgres is down instead of
+// each waiting on the connection pool
+type breakerPlugin struct {
+	breaker *breaker.Breaker
+}
+
+func (p *breakerPlugin) Name() string {
+	return "circuit_breaker"
+}
+
+func (p *breakerPlugin) Initialize(db *gorm.DB) error {
+	callbacks := db.Callback()
+	const before, after = "circuit_breaker:before", "circuit_breaker:after"
+
+	// The check runs before a transaction is begun, the report after it ends
+	errs := []error{
+		callbacks.Create().Before("*").Register(before, p.before),
+		callbacks.Create().After("*").Register(after, p.after),
+		callbacks.Query().Before("*").Register(before, p.before),
+		callbacks.Query().After("*").Register(after, p.after),
+		callbacks.Update().Before("*").Register(before, p.before),
+		callbacks.Update().After("*").Register(after, p.after),
+		callbacks.Delete().Before("*").Register(before, p.before),
+		callbacks.Delete().After("*").Register(after, p.after),
+		callbacks.Row().Before("*").Register(before, p.before),
+		callbacks.Row().After("*").Register(after, p.after),
+		callbacks.Raw().Before("*").Register(before, p.before),
+		callbacks.Raw().After("*").Register(after, p.after),
+	}
+	return errors.Join(errs...)
+}
+
+// before fails the statement while the breaker is open; GORM's own
+// callbacks skip statements that already have an error
+func (p *breakerPlugin) before(db *gorm.DB) {
+	if db.Error != nil {
+		return
+	}
+	if !p.breaker.Allow() {
+		_ = db.AddError(p.breaker.OpenError())
+		return
+	}
+	db.InstanceSet(breakerAllowedKey, true)
+}
+
+func (p *breakerPlugin) after(db *gorm.DB) {
+	if _, ok := db.InstanceGet(breakerAllowedKey); !ok {
+		return
+	}
+
+	switch {
+	case errors.Is(db.Error, context.Canceled):
+		// A caller giving up says nothing about the database's health
+		p.breaker.Skip()
+	default:
+		p.breaker.Record(!isConnectionFailure(db.Error))
+	}
+}
+
+// isConnectionFailure reports whether an error means Postgres could not be
+// reached or could not serve the statement, as opposed to an answer such as
+// a missing row or a constraint violation
+func isConnectionFailure(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		// Connection exceptions, insufficient resources, operator
+		// intervention (e.g. shutdown) and system errors
+		switch pgErr.Code[:2] {
+		case "08", "53", "57", "58":
+			return true
+		}
+		return false
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr) ||
+		errors.Is(err, context.DeadlineExceeded) ||
+		errors.Is(err, driver.ErrBadConn) ||
+		errors.Is(err, io.EOF) ||
+		errors.Is(err, io.ErrUnexpectedEOF) ||
+		pgconn.Timeout(err)
+}
@@ -3,32 +3,85 @@ package database
 import (
 	"fmt"
 	"log"
+	"net"
+	"time"
 
+	"asset-management-api/internal/breaker"
 	"asset-management-api/internal/config"
 	
-	"gorm.io/driver/postgres"
+	"github.com/sirupsen/logrus"
 	"gorm.io/gorm"
-	"gorm.io/gorm/logger"
+	"gorm.io/plugin/opentelemetry/tracing"
 )
 
-func NewConnection(cfg *config.DatabaseConfig) (*gorm.DB, error) {
-	dsn := fmt.Sprintf(
-		"host=%s user=%s password=%s dbname=%s port=%s sslmode=%s TimeZone=UTC",
-		cfg.Host,
-		cfg.User,
-		cfg.Password,
-		cfg.DBName,
-		cfg.Port,
-		cfg.SSLMode,
-	)
-
-	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{
-		Logger: logger.Default.LogMode(logger.Info),
+// NewConnection opens the connection pool to the database of cfg.Driver.
+// Connections log in with the current user and password of creds, which may
+// be rotated.
+func NewConnection(cfg *config.DatabaseConfig, creds *Credentials, logger *logrus.Logger) (*gorm.DB, error) {
+	dialector, err := openDialector(net.JoinHostPort(cfg.Host, cfg.Port), cfg, creds)
+	if err != nil {
+		return nil, err
+	}
+
+	logLevel, err := ParseLogLevel(cfg.LogLevel)
+	if err != nil {
+		return nil, err
+	}
+
+	db, err := gorm.Open(dialector, &gorm.Config{
+		// Foreign keys come from the SQL migrations; the models do not tell
+		// every relationship's direction apart for MySQL and SQLite tables
+		DisableForeignKeyConstraintWhenMigrating: true,
+		Logger: NewLogger(logger, LoggerConfig{
+			Level:              logLevel,
+			SlowQueryThreshold: cfg.SlowQueryThreshold,
+			LogQueryParams:     cfg.LogQueryParams,
+		}),
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to database: %w", err)
 	}
 
+	// Record a span per query; bind values are left out as they may hold user data
+	if err := db.Use(tracing.NewPlugin(tracing.WithDBName(cfg.DBName), tracing.WithoutQueryVariables(), tracing.WithoutMetrics())); err != nil {
+		return nil, fmt.Errorf("failed to install tracing plugin: %w", err)
+	}
+
+	// Only Postgres generates primary keys itself
+	if cfg.Driver != DriverPostgres {
+		if err := db.Use(uuidPlugin{}); err != nil {
+			return nil, fmt.Errorf("failed to install UUID plugin: %w", err)
+		}
+	}
+
+	// Serve reads from the replicas, if any
+	if len(cfg.Replicas) > 0 {
+		if err := useReplicas(db, cfg, creds); err != nil {
+			return nil, err
+		}
+	}
+
+	// Run statements failing on a transient error again, once a replica or
+	// the primary has been picked for them
+	if err := db.Use(&retryPlugin{policy: RetryPolicy{
+		MaxAttempts:    cfg.RetryMaxAttempts,
+		InitialBackoff: cfg.RetryInitialBackoff,
+		MaxBackoff:     cfg.RetryMaxBackoff,
+		Budget:         cfg.RetryBudget,
+	}}); err != nil {
+		return nil, fmt.Errorf("failed to install retry plugin: %w", err)
+	}
+
+	// Fail queries fast while the database is unreachable
+	if err := db.Use(&breakerPlugin{breaker: breaker.New(cfg.Driver, cfg.BreakerFailureThreshold, cfg.BreakerOpenTimeout)}); err != nil {
+		return nil, fmt.Errorf("failed to install circuit breaker: %w", err)
+	}
+
+	// Time every query for the db_query_* metrics
+	if err := db.Use(&metricsPlugin{}); err != nil {
+		return nil, fmt.Errorf("failed to install metrics plugin: %w", err)
+	}
+
 	// Get underlying sql.DB to configure connection pool
 	sqlDB, err := db.DB()
 	if err != nil {
@@ -36,8 +89,10 @@ func NewConnection(cfg *config.DatabaseConfig) (*gorm.DB, error) {
 	}
 
 	// Configure connection pool
-	sqlDB.SetMaxIdleConns(10)
-	sqlDB.SetMaxOpenConns(100)
+	sqlDB.SetMaxOpenConns(cfg.MaxOpenConns)
+	sqlDB.SetMaxIdleConns(cfg.MaxIdleConns)
+	sqlDB.SetConnMaxLifetime(connMaxLifetime(cfg))
+	sqlDB.SetConnMaxIdleTime(cfg.ConnMaxIdleTime)
 
 	// Test connection
 	if err := sqlDB.Ping(); err != nil {
@@ -46,4 +101,15 @@ func NewConnection(cfg *config.DatabaseConfig) (*gorm.DB, error) {
 
 	log.Println("Successfully connected to database")
 	return db, nil
+}
+
+// connMaxLifetime is how long a connection is reused: the configured
+// lifetime, shortened to the credential rotation interval so that no
+// connection outlives its credentials by more than that
+func connMaxLifetime(cfg *config.DatabaseConfig) time.Duration {
+	lifetime := cfg.ConnMaxLifetime
+	if rotation := cfg.CredentialsRotationInterval; rotation > 0 && (lifetime == 0 || rotation < lifetime) {
+		lifetime = rotation
+	}
+	return lifetime
 }
\ No newline at end of file
@@ -0,0 +1,70 @@
+package database
+
+import (
+	"context"
+	"log"
+	"sync/atomic"
+	"time"
+)
+
+// credentials are a database user and password
+type credentials struct {
+	user     string
+	password string
+}
+
+// Credentials holds the user and password new connections log in with, so
+// that they can be rotated while the pool is in use
+type Credentials struct {
+	current atomic.Pointer[credentials]
+}
+
+// NewCredentials creates the credentials of a connection pool
+func NewCredentials(user, password string) *Credentials {
+	c := &Credentials{}
+	c.Set(user, password)
+	return c
+}
+
+// Set replaces the credentials of connections opened from now on
+func (c *Credentials) Set(user, password string) {
+	c.current.Store(&credentials{user: user, password: password})
+}
+
+// Get returns the current user and password
+func (c *Credentials) Get() (string, string) {
+	current := c.current.Load()
+	return current.user, current.password
+}
+
+// CredentialsSource reads the latest credentials, e.g. from Vault
+type CredentialsSource func(ctx context.Context) (user, password string, err error)
+
+// RotateCredentials reads the credentials from source every interval until
+// ctx is done. New connections log in with the latest credentials; as
+// NewConnection limits the lifetime of connections to the interval, none
+// outlive the credentials they were opened with by more than that.
+func RotateCredentials(ctx context.Context, creds *Credentials, source CredentialsSource, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			fetchCtx, cancel := context.WithTimeout(ctx, interval)
+			user, password, err := source(fetchCtx)
+			cancel()
+			if err != nil {
+				// Keep the current credentials and try again next interval
+				log.Printf("Failed to refresh database credentials: %v", err)
+				continue
+			}
+			if currentUser, currentPassword := creds.Get(); user != currentUser || password != currentPassword {
+				creds.Set(user, password)
+				log.Printf("Database credentials rotated for user %s", user)
+			}
+		}
+	}
+}
@@ -0,0 +1,131 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/url"
+	"reflect"
+	"time"
+
+	"asset-management-api/internal/config"
+
+	"github.com/glebarez/sqlite"
+	"github.com/go-sql-driver/mysql"
+	"github.com/google/uuid"
+	gormmysql "gorm.io/driver/mysql"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// Database drivers, named as GORM names their dialects
+const (
+	DriverPostgres = "postgres"
+	DriverMySQL    = "mysql"
+	DriverSQLite   = "sqlite"
+)
+
+// openDialector opens a connection pool to the server at address
+// ("host:port"), logging in with the current user and password of creds.
+// SQLite databases are files, named by cfg.DBName, so address and creds are
+// not used for them.
+func openDialector(address string, cfg *config.DatabaseConfig, creds *Credentials) (gorm.Dialector, error) {
+	switch cfg.Driver {
+	case DriverMySQL:
+		pool, err := openMySQLPool(address, cfg, creds)
+		if err != nil {
+			return nil, err
+		}
+		return gormmysql.New(gormmysql.Config{Conn: pool}), nil
+	case DriverSQLite:
+		// Writers would otherwise fail rather than wait while another
+		// connection holds the write lock
+		pragmas := url.Values{"_pragma": {"busy_timeout(5000)", "journal_mode(WAL)"}}
+		return sqlite.Open(cfg.DBName + "?" + pragmas.Encode()), nil
+	default:
+		pool, err := openPool(address, cfg, creds)
+		if err != nil {
+			return nil, err
+		}
+		return postgres.New(postgres.Config{Conn: pool}), nil
+	}
+}
+
+// mysqlTLS maps the Postgres sslmode values of DB_SSL_MODE to the MySQL
+// driver's tls setting
+var mysqlTLS = map[string]string{
+	"disable":     "false",
+	"allow":       "preferred",
+	"prefer":      "preferred",
+	"require":     "skip-verify",
+	"verify-ca":   "true",
+	"verify-full": "true",
+}
+
+// openMySQLPool is openPool for MySQL servers
+func openMySQLPool(address string, cfg *config.DatabaseConfig, creds *Credentials) (*sql.DB, error) {
+	tlsConfig, ok := mysqlTLS[cfg.SSLMode]
+	if !ok {
+		return nil, fmt.Errorf("invalid database configuration: unknown sslmode %q", cfg.SSLMode)
+	}
+
+	mysqlConfig := mysql.NewConfig()
+	mysqlConfig.Net = "tcp"
+	mysqlConfig.Addr = address
+	mysqlConfig.DBName = cfg.DBName
+	mysqlConfig.TLSConfig = tlsConfig
+	mysqlConfig.ParseTime = true
+	mysqlConfig.Loc = time.UTC
+	err := mysqlConfig.Apply(mysql.BeforeConnect(func(ctx context.Context, mysqlConfig *mysql.Config) error {
+		mysqlConfig.User, mysqlConfig.Passwd = creds.Get()
+		return nil
+	}))
+	if err != nil {
+		return nil, fmt.Errorf("invalid database configuration: %w", err)
+	}
+
+	connector, err := mysql.NewConnector(mysqlConfig)
+	if err != nil {
+		return nil, fmt.Errorf("invalid database configuration: %w", err)
+	}
+	return sql.OpenDB(connector), nil
+}
+
+// uuidPlugin gives new rows a random UUID primary key when they have none,
+// as Postgres does with the gen_random_uuid() column defaults that MySQL and
+// SQLite lack
+type uuidPlugin struct{}
+
+func (uuidPlugin) Name() string {
+	return "uuid_primary_keys"
+}
+
+func (uuidPlugin) Initialize(db *gorm.DB) error {
+	return db.Callback().Create().Before("gorm:create").Register("uuid_primary_keys:generate", generateUUIDs)
+}
+
+var uuidType = reflect.TypeOf(uuid.UUID{})
+
+func generateUUIDs(db *gorm.DB) {
+	if db.Error != nil || db.Statement.Schema == nil {
+		return
+	}
+	field := db.Statement.Schema.PrioritizedPrimaryField
+	if field == nil || field.FieldType != uuidType {
+		return
+	}
+
+	generate := func(row reflect.Value) {
+		if _, zero := field.ValueOf(db.Statement.Context, row); zero {
+			_ = db.AddError(field.Set(db.Statement.Context, row, uuid.New()))
+		}
+	}
+	switch rows := db.Statement.ReflectValue; rows.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < rows.Len(); i++ {
+			generate(rows.Index(i))
+		}
+	case reflect.Struct:
+		generate(rows)
+	}
+}
@@ -0,0 +1,184 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"runtime"
+	"strings"
+	"time"
+
+	"asset-management-api/internal/tracing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+)
+
+var slowQueriesTotal = promauto.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "db_slow_queries_total",
+		Help: "Total number of database queries slower than the slow query threshold",
+	},
+	[]string{"operation"},
+)
+
+// unexplainedPlaceholder matches the marks the Postgres dialector leaves on
+// placeholders it was given no value for, e.g. "$1$"
+var unexplainedPlaceholder = regexp.MustCompile(`\$(\d+)\$`)
+
+// LoggerConfig controls what GORM logs. Queries slower than
+// SlowQueryThreshold are logged as warnings; zero disables slow query
+// logging. Bind values are left out unless LogQueryParams is set, as they
+// may hold user data.
+type LoggerConfig struct {
+	Level              gormlogger.LogLevel
+	SlowQueryThreshold time.Duration
+	LogQueryParams     bool
+}
+
+// ParseLogLevel parses "silent", "error", "warn" or "info"
+func ParseLogLevel(level string) (gormlogger.LogLevel, error) {
+	switch strings.ToLower(level) {
+	case "silent":
+		return gormlogger.Silent, nil
+	case "error":
+		return gormlogger.Error, nil
+	case "warn":
+		return gormlogger.Warn, nil
+	case "info":
+		return gormlogger.Info, nil
+	}
+	return 0, fmt.Errorf("invalid database log level %q: expected silent, error, warn or info", level)
+}
+
+// queryLogger writes GORM's logs as structured entries through the
+// application logger
+type queryLogger struct {
+	logger *logrus.Logger
+	config LoggerConfig
+}
+
+// NewLogger creates a GORM logger writing to logger
+func NewLogger(logger *logrus.Logger, config LoggerConfig) gormlogger.Interface {
+	return &queryLogger{logger: logger, config: config}
+}
+
+func (l *queryLogger) LogMode(level gormlogger.LogLevel) gormlogger.Interface {
+	clone := *l
+	clone.config.Level = level
+	return &clone
+}
+
+func (l *queryLogger) Info(ctx context.Context, msg string, args ...interface{}) {
+	if l.config.Level >= gormlogger.Info {
+		l.entry(ctx).Infof(msg, args...)
+	}
+}
+
+func (l *queryLogger) Warn(ctx context.Context, msg string, args ...interface{}) {
+	if l.config.Level >= gormlogger.Warn {
+		l.entry(ctx).Warnf(msg, args...)
+	}
+}
+
+func (l *queryLogger) Error(ctx context.Context, msg string, args ...interface{}) {
+	if l.config.Level >= gormlogger.Error {
+		l.entry(ctx).Errorf(msg, args...)
+	}
+}
+
+// Trace is called after every statement. Slow statements are counted even
+// when logging is silenced.
+func (l *queryLogger) Trace(ctx context.Context, begin time.Time, fc func() (sql string, rowsAffected int64), err error) {
+	elapsed := time.Since(begin)
+	slow := l.config.SlowQueryThreshold > 0 && elapsed > l.config.SlowQueryThreshold
+
+	var sql string
+	var rows int64
+	if slow {
+		sql, rows = fc()
+		slowQueriesTotal.WithLabelValues(sqlOperation(sql)).Inc()
+	}
+
+	switch {
+	case err != nil && !errors.Is(err, gorm.ErrRecordNotFound) && l.config.Level >= gormlogger.Error:
+		if !slow {
+			sql, rows = fc()
+		}
+		l.queryEntry(ctx, sql, rows, elapsed).WithError(err).Error("Database query failed")
+	case slow && l.config.Level >= gormlogger.Warn:
+		l.queryEntry(ctx, sql, rows, elapsed).
+			WithField("threshold_ms", l.config.SlowQueryThreshold.Milliseconds()).
+			Warn("Slow database query")
+	case l.config.Level >= gormlogger.Info:
+		if !slow {
+			sql, rows = fc()
+		}
+		l.queryEntry(ctx, sql, rows, elapsed).Info("Database query")
+	}
+}
+
+// ParamsFilter leaves bind values out of the logged SQL unless they are
+// configured to be logged
+func (l *queryLogger) ParamsFilter(ctx context.Context, sql string, params ...interface{}) (string, []interface{}) {
+	if l.config.LogQueryParams {
+		return sql, params
+	}
+	return sql, nil
+}
+
+func (l *queryLogger) queryEntry(ctx context.Context, sql string, rows int64, elapsed time.Duration) *logrus.Entry {
+	if !l.config.LogQueryParams {
+		sql = unexplainedPlaceholder.ReplaceAllString(sql, "$$$1")
+	}
+	return l.entry(ctx).WithFields(logrus.Fields{
+		"sql":         sql,
+		"rows":        rows,
+		"duration_ms": float64(elapsed.Microseconds()) / 1000,
+		"caller":      queryCaller(),
+	})
+}
+
+// entry tags the log entry with the request the query ran for
+func (l *queryLogger) entry(ctx context.Context) *logrus.Entry {
+	fields := logrus.Fields{"component": "database"}
+	if ctx != nil {
+		if requestID := tracing.RequestID(ctx); requestID != "" {
+			fields["request_id"] = requestID
+		}
+		if traceID := tracing.TraceID(ctx); traceID != "" {
+			fields["trace_id"] = traceID
+		}
+	}
+	return l.logger.WithFields(fields)
+}
+
+// queryCaller returns the file and line that ran the query: the first frame
+// outside GORM, its plugins and this package
+func queryCaller() string {
+	pcs := make([]uintptr, 32)
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(2, pcs)])
+	for {
+		frame, more := frames.Next()
+		if !strings.Contains(frame.File, "gorm.io/") && !strings.Contains(frame.Function, "/internal/database.") {
+			return fmt.Sprintf("%s:%d", frame.File, frame.Line)
+		}
+		if !more {
+			return ""
+		}
+	}
+}
+
+// sqlOperation names the kind of statement by its first keyword
+func sqlOperation(sql string) string {
+	keyword, _, _ := strings.Cut(strings.TrimSpace(sql), " ")
+	switch keyword = strings.ToLower(keyword); keyword {
+	case "select", "insert", "update", "delete":
+		return keyword
+	}
+	return "other"
+}
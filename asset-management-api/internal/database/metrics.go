@@ -0,0 +1,91 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"asset-management-api/internal/middleware"
+
+	"gorm.io/gorm"
+)
+
+// metricsStartKey holds the time a statement started
+const metricsStartKey = "metrics:start"
+
+// metricsPlugin times every statement run through GORM and records it by
+// operation and table
+type metricsPlugin struct{}
+
+func (p *metricsPlugin) Name() string {
+	return "metrics"
+}
+
+func (p *metricsPlugin) Initialize(db *gorm.DB) error {
+	callbacks := db.Callback()
+	const before, after = "metrics:before", "metrics:after"
+
+	errs := []error{
+		callbacks.Create().Before("*").Register(before, p.before),
+		callbacks.Create().After("*").Register(after, p.after("create")),
+		callbacks.Query().Before("*").Register(before, p.before),
+		callbacks.Query().After("*").Register(after, p.after("query")),
+		callbacks.Update().Before("*").Register(before, p.before),
+		callbacks.Update().After("*").Register(after, p.after("update")),
+		callbacks.Delete().Before("*").Register(before, p.before),
+		callbacks.Delete().After("*").Register(after, p.after("delete")),
+		callbacks.Row().Before("*").Register(before, p.before),
+		callbacks.Row().After("*").Register(after, p.after("row")),
+		callbacks.Raw().Before("*").Register(before, p.before),
+		callbacks.Raw().After("*").Register(after, p.after("raw")),
+	}
+	return errors.Join(errs...)
+}
+
+func (p *metricsPlugin) before(db *gorm.DB) {
+	db.InstanceSet(metricsStartKey, time.Now())
+}
+
+func (p *metricsPlugin) after(operation string) func(*gorm.DB) {
+	return func(db *gorm.DB) {
+		value, ok := db.InstanceGet(metricsStartKey)
+		if !ok {
+			return
+		}
+		start, ok := value.(time.Time)
+		if !ok {
+			return
+		}
+
+		// Raw statements name no table
+		table := db.Statement.Table
+		if table == "" {
+			table = "unknown"
+		}
+		middleware.RecordDBQuery(db.Statement.Context, operation, table, time.Since(start))
+	}
+}
+
+// SampleStats records the connection pool's statistics every interval until
+// ctx is done
+func SampleStats(ctx context.Context, db *gorm.DB, interval time.Duration) {
+	sqlDB, err := db.DB()
+	if err != nil {
+		log.Printf("Failed to sample database statistics: %v", err)
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	middleware.RecordDBStats(sqlDB.Stats())
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			middleware.RecordDBStats(sqlDB.Stats())
+		}
+	}
+}
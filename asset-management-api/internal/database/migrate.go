@@ -0,0 +1,157 @@
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"asset-management-api/internal/models"
+	"asset-management-api/migrations"
+
+	"github.com/pressly/goose/v3"
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+// migrationLockID identifies the advisory lock held while migrating, so that
+// instances started together do not run the migrations twice
+const migrationLockID = 7224310912
+
+// migrationLockName is migrationLockID for MySQL, whose locks are named
+const migrationLockName = "asset-management-api:migrate"
+
+func init() {
+	goose.SetBaseFS(migrations.FS)
+	if err := goose.SetDialect("postgres"); err != nil {
+		panic(err)
+	}
+}
+
+// Migrate runs a goose command on the built-in migrations: "up", "up-by-one",
+// "up-to VERSION", "down", "down-to VERSION", "redo", "reset", "status" or
+// "version". Concurrent runs wait for each other.
+//
+// The migrations are written for Postgres; MySQL and SQLite databases are
+// migrated by creating the tables and columns the models are missing, so
+// only "up" is supported for them.
+func Migrate(ctx context.Context, db *gorm.DB, command string, args ...string) error {
+	if name := db.Dialector.Name(); name != DriverPostgres {
+		if command != "up" {
+			return fmt.Errorf("migration %s is not supported with %s, only up", command, name)
+		}
+		return autoMigrate(ctx, db)
+	}
+
+	sqlDB, err := db.DB()
+	if err != nil {
+		return fmt.Errorf("failed to get underlying sql.DB: %w", err)
+	}
+
+	conn, err := sqlDB.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get connection for migration lock: %w", err)
+	}
+	defer conn.Close()
+	if _, err := conn.ExecContext(ctx, "SELECT pg_advisory_lock($1)", migrationLockID); err != nil {
+		return fmt.Errorf("failed to take migration lock: %w", err)
+	}
+	defer conn.ExecContext(context.Background(), "SELECT pg_advisory_unlock($1)", migrationLockID)
+
+	if err := goose.RunContext(ctx, command, sqlDB, ".", args...); err != nil {
+		return fmt.Errorf("migration %s failed: %w", command, err)
+	}
+	return nil
+}
+
+// schemaModels are the models whose tables autoMigrate creates
+var schemaModels = []interface{}{
+	&models.User{},
+	&models.Team{},
+	&models.TeamManager{},
+	&models.TeamMember{},
+	&models.Folder{},
+	&models.Note{},
+	&models.FolderShare{},
+	&models.NoteShare{},
+	&models.Webhook{},
+	&models.WebhookDelivery{},
+	&models.AssetEventLog{},
+	&models.Subscription{},
+	&models.Notification{},
+	&models.UserActivityLog{},
+	&models.APIAudit{},
+	&models.ExportJob{},
+}
+
+// autoMigrate creates the tables and columns of schemaModels missing from a
+// MySQL or SQLite database. On MySQL, concurrent runs wait for each other.
+func autoMigrate(ctx context.Context, db *gorm.DB) error {
+	db = db.WithContext(ctx)
+	if db.Dialector.Name() == DriverMySQL {
+		sqlDB, err := db.DB()
+		if err != nil {
+			return fmt.Errorf("failed to get underlying sql.DB: %w", err)
+		}
+		conn, err := sqlDB.Conn(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to get connection for migration lock: %w", err)
+		}
+		defer conn.Close()
+		if _, err := conn.ExecContext(ctx, "SELECT GET_LOCK(?, -1)", migrationLockName); err != nil {
+			return fmt.Errorf("failed to take migration lock: %w", err)
+		}
+		defer conn.ExecContext(context.Background(), "SELECT RELEASE_LOCK(?)", migrationLockName)
+	}
+
+	// The team join tables are the TeamManager and TeamMember models, so
+	// that their columns are ported like the others
+	joinTables := []struct {
+		field string
+		model interface{}
+	}{
+		{"Managers", &models.TeamManager{}},
+		{"Members", &models.TeamMember{}},
+	}
+	for _, joinTable := range joinTables {
+		if err := db.SetupJoinTable(&models.Team{}, joinTable.field, joinTable.model); err != nil {
+			return fmt.Errorf("failed to set up join table %T: %w", joinTable.model, err)
+		}
+	}
+
+	for _, model := range schemaModels {
+		stmt := &gorm.Statement{DB: db}
+		if err := stmt.Parse(model); err != nil {
+			return fmt.Errorf("failed to parse model %T: %w", model, err)
+		}
+		portColumnTypes(db.Dialector.Name(), stmt.Schema)
+	}
+	if err := db.AutoMigrate(schemaModels...); err != nil {
+		return fmt.Errorf("migration up failed: %w", err)
+	}
+	return nil
+}
+
+var jsonType = reflect.TypeOf(json.RawMessage{})
+
+// portColumnTypes replaces the Postgres column types and defaults of the
+// models, which are declared for the SQL migrations, with ones the dialect
+// has. Primary keys are generated by uuidPlugin instead.
+func portColumnTypes(dialect string, s *schema.Schema) {
+	for _, field := range s.Fields {
+		switch field.IndirectFieldType {
+		case uuidType:
+			if dialect == DriverMySQL {
+				field.DataType = "char(36)"
+			} else {
+				field.DataType = "text"
+			}
+			if field.DefaultValue == "gen_random_uuid()" {
+				field.DefaultValue = ""
+				field.DefaultValueInterface = nil
+			}
+		case jsonType:
+			field.DataType = "json"
+		}
+	}
+}
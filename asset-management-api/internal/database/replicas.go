@@ -0,0 +1,70 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net"
+
+	"asset-management-api/internal/config"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/stdlib"
+	"gorm.io/gorm"
+	"gorm.io/plugin/dbresolver"
+)
+
+// useReplicas sends the queries outside of transactions to the read
+// replicas, picked at random, keeping writes and transactions on the
+// primary. Reads that must see the latest writes are pinned to the primary
+// with the dbresolver.Write clause. Replicas log in with the same
+// credentials as the primary.
+func useReplicas(db *gorm.DB, cfg *config.DatabaseConfig, creds *Credentials) error {
+	replicas := make([]gorm.Dialector, 0, len(cfg.Replicas))
+	for _, address := range cfg.Replicas {
+		replica, err := openDialector(address, cfg, creds)
+		if err != nil {
+			return err
+		}
+		replicas = append(replicas, replica)
+	}
+
+	resolver := dbresolver.Register(dbresolver.Config{
+		Replicas:          replicas,
+		Policy:            dbresolver.RandomPolicy{},
+		TraceResolverMode: true,
+	}).
+		SetMaxOpenConns(cfg.MaxOpenConns).
+		SetMaxIdleConns(cfg.MaxIdleConns).
+		SetConnMaxLifetime(connMaxLifetime(cfg)).
+		SetConnMaxIdleTime(cfg.ConnMaxIdleTime)
+
+	if err := db.Use(resolver); err != nil {
+		return fmt.Errorf("failed to install read replicas: %w", err)
+	}
+	return nil
+}
+
+// openPool opens a connection pool to the Postgres server at address ("host:port"),
+// logging in with the current user and password of creds
+func openPool(address string, cfg *config.DatabaseConfig, creds *Credentials) (*sql.DB, error) {
+	host, port, err := net.SplitHostPort(address)
+	if err != nil {
+		return nil, fmt.Errorf("invalid database address %q: %w", address, err)
+	}
+	dsn := fmt.Sprintf(
+		"host=%s dbname=%s port=%s sslmode=%s TimeZone=UTC",
+		host,
+		cfg.DBName,
+		port,
+		cfg.SSLMode,
+	)
+	connConfig, err := pgx.ParseConfig(dsn)
+	if err != nil {
+		return nil, fmt.Errorf("invalid database configuration: %w", err)
+	}
+	return stdlib.OpenDB(*connConfig, stdlib.OptionBeforeConnect(func(ctx context.Context, connConfig *pgx.ConnConfig) error {
+		connConfig.User, connConfig.Password = creds.Get()
+		return nil
+	})), nil
+}
@@ -0,0 +1,212 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"math/rand"
+	"time"
+
+	"asset-management-api/internal/middleware"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+)
+
+// RetryPolicy is how statements and transactions failing on a transient
+// error are run again: at most MaxAttempts times in all, waiting a random
+// time up to a backoff doubling from InitialBackoff to MaxBackoff in
+// between, and never once Budget has passed since the first attempt
+type RetryPolicy struct {
+	MaxAttempts    int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	Budget         time.Duration
+}
+
+// Do runs fn until it succeeds, fails on an error that is not transient, or
+// the policy or ctx gives up, and returns its last error
+func (p RetryPolicy) Do(ctx context.Context, fn func() error) error {
+	start := time.Now()
+	for attempt := 1; ; attempt++ {
+		err := fn()
+		reason := retryReason(err)
+		if reason == "" || attempt >= p.MaxAttempts {
+			return err
+		}
+
+		backoff := p.backoff(attempt)
+		if time.Since(start)+backoff > p.Budget {
+			return err
+		}
+		middleware.RecordDBRetry(reason)
+
+		timer := time.NewTimer(backoff)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		}
+	}
+}
+
+// backoff is the wait before the attempt after the given one, with full
+// jitter so that callers failing together do not retry together
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	ceiling := p.MaxBackoff
+	if attempt <= 30 {
+		if doubled := p.InitialBackoff << (attempt - 1); doubled < ceiling {
+			ceiling = doubled
+		}
+	}
+	if ceiling <= 0 {
+		return 0
+	}
+	return time.Duration(rand.Int63n(int64(ceiling) + 1))
+}
+
+// retryReason names the transient error err is, or returns "" when running
+// the statement again would not help or might apply it twice. A failed
+// statement outside a transaction was rolled back, as was a transaction
+// failing on a serialization failure or deadlock; connection failures are
+// only retried when the statement never reached the database.
+func retryReason(err error) string {
+	if err == nil {
+		return ""
+	}
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		switch pgErr.Code {
+		case "40001":
+			return "serialization_failure"
+		case "40P01":
+			return "deadlock"
+		}
+		return ""
+	}
+
+	var mysqlErr *mysql.MySQLError
+	if errors.As(err, &mysqlErr) {
+		switch mysqlErr.Number {
+		case 1213:
+			return "deadlock"
+		case 1205:
+			return "lock_timeout"
+		}
+		return ""
+	}
+
+	if isConnectionFailure(err) && pgconn.SafeToRetry(err) {
+		return "connection"
+	}
+	return ""
+}
+
+// RetryTransaction runs fn, a whole transaction, under the retry policy of
+// db. The statements of a transaction cannot be retried one by one, as a
+// failed statement aborts the transaction.
+func RetryTransaction(ctx context.Context, db *gorm.DB, fn func() error) error {
+	plugin, ok := db.Config.Plugins[retryPluginName].(*retryPlugin)
+	if !ok {
+		return fn()
+	}
+	return plugin.policy.Do(ctx, fn)
+}
+
+const retryPluginName = "retry"
+
+// retryPlugin runs the statements outside of transactions again when they
+// fail on a transient error, such as a deadlock. It wraps the connection
+// pool each statement runs on, once the read replica or primary has been
+// picked for it.
+type retryPlugin struct {
+	policy RetryPolicy
+}
+
+func (p *retryPlugin) Name() string {
+	return retryPluginName
+}
+
+func (p *retryPlugin) Initialize(db *gorm.DB) error {
+	callbacks := db.Callback()
+	const name, resolver = "retry:wrap", "gorm:db_resolver"
+
+	// Creates, updates and deletes run in a transaction of their own, of
+	// which only the begin is retried
+	errs := []error{
+		callbacks.Create().Before("gorm:begin_transaction").After(resolver).Register(name, p.wrap),
+		callbacks.Query().Before("gorm:query").After(resolver).Register(name, p.wrap),
+		callbacks.Update().Before("gorm:begin_transaction").After(resolver).Register(name, p.wrap),
+		callbacks.Delete().Before("gorm:begin_transaction").After(resolver).Register(name, p.wrap),
+		callbacks.Row().Before("gorm:row").After(resolver).Register(name, p.wrap),
+		callbacks.Raw().Before("gorm:raw").After(resolver).Register(name, p.wrap),
+	}
+	return errors.Join(errs...)
+}
+
+func (p *retryPlugin) wrap(db *gorm.DB) {
+	switch db.Statement.ConnPool.(type) {
+	case gorm.TxCommitter, *retryingPool:
+		// Transactions are retried as a whole by whoever began them
+		return
+	}
+	db.Statement.ConnPool = &retryingPool{ConnPool: db.Statement.ConnPool, policy: p.policy}
+}
+
+// retryingPool runs statements on its pool under a retry policy. Rows are
+// not retried once returned, nor is QueryRowContext, whose error only shows
+// when the row is scanned.
+type retryingPool struct {
+	gorm.ConnPool
+	policy RetryPolicy
+}
+
+func (p *retryingPool) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	var result sql.Result
+	err := p.policy.Do(ctx, func() error {
+		var err error
+		result, err = p.ConnPool.ExecContext(ctx, query, args...)
+		return err
+	})
+	return result, err
+}
+
+func (p *retryingPool) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	var rows *sql.Rows
+	err := p.policy.Do(ctx, func() error {
+		var err error
+		rows, err = p.ConnPool.QueryContext(ctx, query, args...)
+		return err
+	})
+	return rows, err
+}
+
+func (p *retryingPool) BeginTx(ctx context.Context, opts *sql.TxOptions) (gorm.ConnPool, error) {
+	var tx gorm.ConnPool
+	err := p.policy.Do(ctx, func() error {
+		var err error
+		switch beginner := p.ConnPool.(type) {
+		case gorm.TxBeginner:
+			tx, err = beginner.BeginTx(ctx, opts)
+		case gorm.ConnPoolBeginner:
+			tx, err = beginner.BeginTx(ctx, opts)
+		default:
+			err = gorm.ErrInvalidTransaction
+		}
+		return err
+	})
+	return tx, err
+}
+
+func (p *retryingPool) GetDBConn() (*sql.DB, error) {
+	switch pool := p.ConnPool.(type) {
+	case *sql.DB:
+		return pool, nil
+	case gorm.GetDBConnector:
+		return pool.GetDBConn()
+	}
+	return nil, gorm.ErrInvalidDB
+}
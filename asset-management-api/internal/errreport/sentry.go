@@ -0,0 +1,84 @@
+// Package errreport sends panics and server errors to Sentry, or any error
+// tracker speaking its protocol, such as GlitchTip.
+package errreport
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"asset-management-api/pkg/errreport"
+
+	"github.com/getsentry/sentry-go"
+)
+
+// SentryConfig identifies the project events are sent to (DSN) and tags
+// them with the environment and release. SampleRate is the share of events
+// sent.
+type SentryConfig struct {
+	DSN         string
+	Environment string
+	Release     string
+	SampleRate  float64
+}
+
+// SentryReporter implements errreport.Reporter with the Sentry SDK
+type SentryReporter struct {
+	hub *sentry.Hub
+}
+
+var _ errreport.Reporter = (*SentryReporter)(nil)
+
+// NewSentryReporter creates a reporter sending events to cfg.DSN
+func NewSentryReporter(cfg SentryConfig) (*SentryReporter, error) {
+	if cfg.DSN == "" {
+		return nil, fmt.Errorf("error reporting requires a DSN")
+	}
+
+	client, err := sentry.NewClient(sentry.ClientOptions{
+		Dsn:              cfg.DSN,
+		Environment:      cfg.Environment,
+		Release:          cfg.Release,
+		SampleRate:       cfg.SampleRate,
+		AttachStacktrace: true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Sentry client: %w", err)
+	}
+	return &SentryReporter{hub: sentry.NewHub(client, sentry.NewScope())}, nil
+}
+
+// Report sends the event with its request as tags, so events can be
+// searched by route or request ID and linked to logs and traces. A panic
+// must be reported from the deferred function that recovered it, so the
+// stack trace shows where it was raised.
+func (r *SentryReporter) Report(ctx context.Context, event *errreport.Event) {
+	hub := r.hub.Clone()
+	hub.ConfigureScope(func(scope *sentry.Scope) {
+		if event.UserID != "" {
+			scope.SetUser(sentry.User{ID: event.UserID})
+		}
+		scope.SetTags(map[string]string{
+			"request_id": event.RequestID,
+			"trace_id":   event.TraceID,
+			"method":     event.Method,
+			"route":      event.Route,
+			"status":     strconv.Itoa(event.Status),
+		})
+		scope.SetContext("request", sentry.Context{
+			"method": event.Method,
+			"path":   event.Path,
+		})
+	})
+
+	if event.Panic != nil {
+		hub.RecoverWithContext(ctx, event.Panic)
+		return
+	}
+	hub.CaptureException(event.Err)
+}
+
+func (r *SentryReporter) Flush(timeout time.Duration) bool {
+	return r.hub.Flush(timeout)
+}
@@ -0,0 +1,325 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"log"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"asset-management-api/internal/events/types"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// Overflow policies applied when the async publish queue is full
+const (
+	OverflowBlock      = "block"       // Wait for room, up to EnqueueTimeout
+	OverflowDropNewest = "drop_newest" // Reject the event being published
+	OverflowDropOldest = "drop_oldest" // Evict the oldest queued event
+	OverflowSync       = "sync"        // Publish synchronously in the caller
+)
+
+var (
+	// ErrPublishQueueFull is returned when an event is rejected by a full queue
+	ErrPublishQueueFull = errors.New("kafka publish queue is full")
+
+	// ErrPublisherClosed is returned when publishing after Close
+	ErrPublisherClosed = errors.New("kafka publisher is closed")
+)
+
+var (
+	publishQueueDepth = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "kafka_producer_queue_depth",
+			Help: "Number of events waiting in the async Kafka publish queue",
+		},
+	)
+
+	publishQueueCapacity = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "kafka_producer_queue_capacity",
+			Help: "Capacity of the async Kafka publish queue",
+		},
+	)
+
+	publishDroppedTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "kafka_producer_events_dropped_total",
+			Help: "Total number of events dropped by the async Kafka publish queue",
+		},
+		[]string{"topic", "reason"},
+	)
+
+	asyncPublishErrorsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "kafka_producer_async_errors_total",
+			Help: "Total number of queued events that failed to be written to Kafka",
+		},
+		[]string{"topic"},
+	)
+)
+
+// queuedEvent is a unit of the queue: one event, or the events of a batch
+// that share a shard, written together
+type queuedEvent struct {
+	topic  string
+	events []outboundEvent
+}
+
+// AsyncPublisher queues events in memory and writes them to Kafka from
+// worker goroutines, keeping broker latency out of request handlers. Each
+// worker owns a shard of the queue and events are sharded by partition key,
+// so events for the same entity are still written in order. The envelope is
+// built at publish time so it keeps the caller's trace ID and timestamp.
+type AsyncPublisher struct {
+	producer *KafkaProducer
+	config   ProducerConfig
+
+	// mu guards closed; it is held while queueing so Close never closes a
+	// shard mid-send
+	mu     sync.RWMutex
+	shards []chan queuedEvent
+	closed bool
+	next   uint32 // Round-robin shard for events without a key
+	depth  int64  // Queued events; a shard slot may hold a whole batch
+	wg     sync.WaitGroup
+}
+
+// NewAsyncPublisher creates an async publisher and starts its workers
+func NewAsyncPublisher(producer *KafkaProducer, config ProducerConfig) *AsyncPublisher {
+	workers := config.AsyncWorkers
+	if workers <= 0 {
+		workers = 1
+	}
+	shardSize := config.AsyncQueueSize / workers
+	if shardSize < 1 {
+		shardSize = 1
+	}
+
+	p := &AsyncPublisher{
+		producer: producer,
+		config:   config,
+		shards:   make([]chan queuedEvent, workers),
+	}
+	for i := range p.shards {
+		p.shards[i] = make(chan queuedEvent, shardSize)
+		p.wg.Add(1)
+		go p.work(p.shards[i])
+	}
+	publishQueueCapacity.Set(float64(shardSize * workers))
+
+	return p
+}
+
+// Publish queues an event for the topic, applying the overflow policy when
+// its shard is full. Errors writing to Kafka are logged, not returned.
+func (p *AsyncPublisher) Publish(ctx context.Context, topic string, event interface{}) error {
+	envelope, err := types.NewEnvelope(ctx, p.config.Name, event)
+	if err != nil {
+		return err
+	}
+	key := partitionKey(event)
+	return p.enqueue(ctx, p.shardFor(key), queuedEvent{topic: topic, events: []outboundEvent{{envelope: envelope, key: key}}})
+}
+
+// PublishBatch queues events for the topic. The events of each shard are
+// queued, and later written, together; when a batch spans several shards
+// and one of them is full, the other shards' events may still be queued.
+func (p *AsyncPublisher) PublishBatch(ctx context.Context, topic string, events []interface{}) error {
+	batch, err := newOutboundEvents(ctx, p.config.Name, events)
+	if err != nil {
+		return err
+	}
+
+	// Keyless events stay together on a single shard
+	keyless := -1
+	groups := make(map[int][]outboundEvent)
+	order := make([]int, 0, len(p.shards))
+	for _, item := range batch {
+		var shard int
+		if len(item.key) == 0 {
+			if keyless < 0 {
+				keyless = p.shardFor(nil)
+			}
+			shard = keyless
+		} else {
+			shard = p.shardFor(item.key)
+		}
+		if _, ok := groups[shard]; !ok {
+			order = append(order, shard)
+		}
+		groups[shard] = append(groups[shard], item)
+	}
+
+	var errs []error
+	for _, shard := range order {
+		if err := p.enqueue(ctx, shard, queuedEvent{topic: topic, events: groups[shard]}); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// enqueue puts an item on a shard, applying the overflow policy when it is full
+func (p *AsyncPublisher) enqueue(ctx context.Context, index int, item queuedEvent) error {
+	topic, count := item.topic, len(item.events)
+
+	p.mu.RLock()
+	if p.closed {
+		p.mu.RUnlock()
+		return ErrPublisherClosed
+	}
+	shard := p.shards[index]
+
+	select {
+	case shard <- item:
+		p.mu.RUnlock()
+		p.queued(count)
+		return nil
+	default:
+	}
+
+	switch p.config.OverflowPolicy {
+	case OverflowDropNewest:
+		p.mu.RUnlock()
+		publishDroppedTotal.WithLabelValues(topic, "queue_full").Add(float64(count))
+		return ErrPublishQueueFull
+
+	case OverflowDropOldest:
+		defer p.mu.RUnlock()
+		for {
+			select {
+			case shard <- item:
+				p.queued(count)
+				return nil
+			default:
+			}
+			select {
+			case evicted := <-shard:
+				p.queued(-len(evicted.events))
+				publishDroppedTotal.WithLabelValues(evicted.topic, "evicted").Add(float64(len(evicted.events)))
+				log.Printf("Publish queue full, dropped %d event(s) starting at %s for topic %s",
+					len(evicted.events), evicted.events[0].envelope.EventID, evicted.topic)
+			default:
+			}
+		}
+
+	case OverflowSync:
+		p.mu.RUnlock()
+		return p.producer.publishEnvelopes(ctx, topic, item.events)
+
+	default: // OverflowBlock
+		defer p.mu.RUnlock()
+		var timeout <-chan time.Time // Without a timeout, wait as long as ctx allows
+		if p.config.EnqueueTimeout > 0 {
+			timer := time.NewTimer(p.config.EnqueueTimeout)
+			defer timer.Stop()
+			timeout = timer.C
+		}
+
+		select {
+		case shard <- item:
+			p.queued(count)
+			return nil
+		case <-timeout:
+			publishDroppedTotal.WithLabelValues(topic, "enqueue_timeout").Add(float64(count))
+			return ErrPublishQueueFull
+		case <-ctx.Done():
+			publishDroppedTotal.WithLabelValues(topic, "canceled").Add(float64(count))
+			return fmt.Errorf("failed to queue event for topic %s: %w", topic, ctx.Err())
+		}
+	}
+}
+
+// queued adjusts the queue depth by n events
+func (p *AsyncPublisher) queued(n int) {
+	atomic.AddInt64(&p.depth, int64(n))
+	publishQueueDepth.Add(float64(n))
+}
+
+// shardFor picks the shard for a partition key
+func (p *AsyncPublisher) shardFor(key []byte) int {
+	if len(p.shards) == 1 {
+		return 0
+	}
+	if len(key) == 0 {
+		return int(atomic.AddUint32(&p.next, 1) % uint32(len(p.shards)))
+	}
+	h := fnv.New32a()
+	h.Write(key)
+	return int(h.Sum32() % uint32(len(p.shards)))
+}
+
+// work writes queued events until the shard is closed and drained
+func (p *AsyncPublisher) work(shard chan queuedEvent) {
+	defer p.wg.Done()
+
+	for item := range shard {
+		p.queued(-len(item.events))
+
+		// The publishing request has usually finished by now, so the write
+		// gets its own deadline instead of the caller's context
+		ctx, cancel := context.WithTimeout(types.ContextWithTraceID(context.Background(), item.events[0].envelope.TraceID), p.config.FlushTimeout)
+		err := p.producer.publishEnvelopes(ctx, item.topic, item.events)
+		cancel()
+
+		if err != nil {
+			asyncPublishErrorsTotal.WithLabelValues(item.topic).Add(float64(len(item.events)))
+			log.Printf("Failed to publish %d queued event(s) starting at %s to topic %s: %v",
+				len(item.events), item.events[0].envelope.EventID, item.topic, err)
+		}
+	}
+}
+
+// Depth returns the number of queued events
+func (p *AsyncPublisher) Depth() int {
+	return int(atomic.LoadInt64(&p.depth))
+}
+
+// Close stops accepting events and flushes the queue to Kafka. It gives up
+// waiting once ctx is done; events still queued then are lost.
+func (p *AsyncPublisher) Close(ctx context.Context) error {
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		return nil
+	}
+	p.closed = true
+	for _, shard := range p.shards {
+		close(shard)
+	}
+	p.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		p.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("flushing publish queue: %d events not written: %w", p.Depth(), ctx.Err())
+	}
+}
+
+// HealthCheck returns the queue status
+func (p *AsyncPublisher) HealthCheck() map[string]interface{} {
+	capacity := 0
+	for _, shard := range p.shards {
+		capacity += cap(shard)
+	}
+
+	return map[string]interface{}{
+		"queue_depth":     p.Depth(),
+		"queue_capacity":  capacity,
+		"workers":         len(p.shards),
+		"overflow_policy": p.config.OverflowPolicy,
+	}
+}
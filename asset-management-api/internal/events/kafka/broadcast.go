@@ -0,0 +1,40 @@
+package kafka
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"asset-management-api/pkg/eventbus"
+
+	"github.com/google/uuid"
+)
+
+var _ eventbus.BroadcastSubscriber = (*KafkaEventBus)(nil)
+
+// SubscribeBroadcast subscribes with a consumer group of this instance's
+// own, so the handler sees every event of the topic rather than this
+// instance's share of the partitions. The group starts at the latest offset
+// on every start, and failed events are neither retried nor dead-lettered.
+func (b *KafkaEventBus) SubscribeBroadcast(ctx context.Context, topic string, handler eventbus.EventHandler) error {
+	b.broadcastOnce.Do(func() {
+		config := *b.config
+		config.ConsumerConfig.GroupID = fmt.Sprintf("%s.broadcast.%s", b.config.ConsumerConfig.GroupID, instanceID())
+		config.ConsumerConfig.AutoCommit = true
+		config.ConsumerConfig.ManualCommitTopics = nil
+		config.ConsumerConfig.Retry = RetryPolicy{MaxAttempts: 1}
+		config.ConsumerConfig.TopicRetry = nil
+		config.ConsumerConfig.DeadLetterEnabled = false
+		b.broadcast = NewKafkaConsumer(&config, nil, b.serializer, b.connections.dialer)
+	})
+	return b.broadcast.Subscribe(ctx, topic, handler)
+}
+
+// instanceID identifies this process among the instances sharing a host name
+func instanceID() string {
+	host, err := os.Hostname()
+	if err != nil || host == "" {
+		host = "unknown"
+	}
+	return host + "-" + uuid.NewString()[:8]
+}
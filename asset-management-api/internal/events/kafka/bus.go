@@ -0,0 +1,173 @@
+package kafka
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+
+	"asset-management-api/pkg/eventbus"
+)
+
+// KafkaEventBus implements EventBus by publishing through a KafkaProducer and
+// subscribing through a KafkaConsumer that dead-letters via the same producer.
+// With async publishing enabled, events go through an AsyncPublisher queue.
+type KafkaEventBus struct {
+	producer    *KafkaProducer
+	async       *AsyncPublisher
+	consumer    *KafkaConsumer
+	config      *KafkaConfig
+	serializer  Serializer
+	connections *connections
+	brokers     *brokerMonitor
+	redriveMu   sync.Mutex
+
+	// Consumer of this instance's own group, created by SubscribeBroadcast
+	broadcastOnce sync.Once
+	broadcast     *KafkaConsumer
+}
+
+// NewKafkaEventBus creates a new Kafka event bus using the configured serializer
+func NewKafkaEventBus(config *KafkaConfig) (*KafkaEventBus, error) {
+	serializer, err := NewSerializer(config)
+	if err != nil {
+		return nil, err
+	}
+
+	conns, err := newConnections(config)
+	if err != nil {
+		return nil, err
+	}
+
+	producer := NewKafkaProducer(config, serializer, conns.transport)
+	bus := &KafkaEventBus{
+		producer:    producer,
+		consumer:    NewKafkaConsumer(config, producer, serializer, conns.dialer),
+		config:      config,
+		serializer:  serializer,
+		connections: conns,
+	}
+	bus.brokers = newBrokerMonitor(conns.dialer, config.Brokers, config.HealthCheck, producer.reconnect)
+
+	if config.ProducerConfig.Async {
+		switch config.ProducerConfig.OverflowPolicy {
+		case OverflowBlock, OverflowDropNewest, OverflowDropOldest, OverflowSync:
+		default:
+			return nil, fmt.Errorf("unsupported publish overflow policy %q", config.ProducerConfig.OverflowPolicy)
+		}
+		bus.async = NewAsyncPublisher(producer, config.ProducerConfig)
+	}
+
+	return bus, nil
+}
+
+// CheckBrokers probes every broker with a metadata request and fails when
+// none answers. The result is also reported by HealthCheck.
+func (b *KafkaEventBus) CheckBrokers(ctx context.Context) error {
+	status := b.brokers.check(ctx)
+	if !status.Healthy {
+		return fmt.Errorf("%w: %v", ErrNoBrokers, status.Brokers)
+	}
+	return nil
+}
+
+// StartBrokerMonitor probes the brokers every configured interval until the
+// bus is shut down, reconnecting the producer after a broker failover
+func (b *KafkaEventBus) StartBrokerMonitor() {
+	b.brokers.start()
+}
+
+// Pause stops consuming the topic on this instance until Resume
+func (b *KafkaEventBus) Pause(topic string) error {
+	return b.consumer.Pause(topic)
+}
+
+// Resume continues consuming a paused topic on this instance
+func (b *KafkaEventBus) Resume(topic string) error {
+	return b.consumer.Resume(topic)
+}
+
+// PausedTopics returns the topics paused on this instance
+func (b *KafkaEventBus) PausedTopics() []string {
+	return b.consumer.PausedTopics()
+}
+
+// SetDeduplicator makes the consumer skip redelivered events; call it before subscribing
+func (b *KafkaEventBus) SetDeduplicator(dedup eventbus.Deduplicator) {
+	b.consumer.SetDeduplicator(dedup)
+}
+
+// Publish sends an event to the specified Kafka topic, or queues it when
+// async publishing is enabled
+func (b *KafkaEventBus) Publish(ctx context.Context, topic string, event interface{}) error {
+	if b.async != nil {
+		return b.async.Publish(ctx, topic, event)
+	}
+	return b.producer.Publish(ctx, topic, event)
+}
+
+// PublishBatch sends events to the specified Kafka topic in one write, or
+// queues them when async publishing is enabled
+func (b *KafkaEventBus) PublishBatch(ctx context.Context, topic string, events []interface{}) error {
+	if b.async != nil {
+		return b.async.PublishBatch(ctx, topic, events)
+	}
+	return b.producer.PublishBatch(ctx, topic, events)
+}
+
+// Subscribe starts consuming messages from the specified topic
+func (b *KafkaEventBus) Subscribe(ctx context.Context, topic string, handler eventbus.EventHandler) error {
+	return b.consumer.Subscribe(ctx, topic, handler)
+}
+
+// Close shuts the bus down, draining the consumer for up to its configured drain timeout
+func (b *KafkaEventBus) Close() error {
+	ctx, cancel := context.WithTimeout(context.Background(), b.config.ConsumerConfig.DrainTimeout)
+	defer cancel()
+	return b.Shutdown(ctx)
+}
+
+// Shutdown drains the consumer until ctx is done and flushes the publish
+// queue before closing the producer, so in-flight messages can still be
+// dead-lettered
+func (b *KafkaEventBus) Shutdown(ctx context.Context) error {
+	b.brokers.close()
+	consumerErr := b.consumer.Shutdown(ctx)
+	if b.broadcast != nil {
+		if err := b.broadcast.Shutdown(ctx); err != nil {
+			log.Printf("Error closing Kafka broadcast consumer: %v", err)
+		}
+	}
+	if b.async != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), b.config.ProducerConfig.DrainTimeout)
+		if err := b.async.Close(ctx); err != nil {
+			log.Printf("Error flushing Kafka publish queue: %v", err)
+		}
+		cancel()
+	}
+	if err := b.producer.Close(); err != nil {
+		log.Printf("Error closing Kafka producer: %v", err)
+		return err
+	}
+	return consumerErr
+}
+
+// HealthCheck returns the health status of the event bus
+func (b *KafkaEventBus) HealthCheck() map[string]interface{} {
+	health := b.consumer.HealthCheck()
+	health["dead_letter_enabled"] = b.config.ConsumerConfig.DeadLetterEnabled
+	health["serialization"] = b.config.Serialization
+	health["tls"] = b.config.TLS.Enabled
+	health["sasl_mechanism"] = b.config.SASL.Mechanism
+	health["producer_circuit_breaker"] = b.producer.breaker.State().String()
+	if b.async != nil {
+		health["publish_queue"] = b.async.HealthCheck()
+	}
+	if status := b.brokers.Status(); !status.CheckedAt.IsZero() {
+		health["brokers"] = status
+		if !status.Healthy {
+			health["status"] = "unhealthy"
+		}
+	}
+	return health
+}
@@ -0,0 +1,72 @@
+package kafka
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"asset-management-api/internal/events/types"
+	"asset-management-api/pkg/eventbus"
+)
+
+// cloudEventsContentType marks values in the CloudEvents structured JSON mode
+const cloudEventsContentType = "application/cloudevents+json; charset=UTF-8"
+
+// cloudEventsSerializer publishes envelopes as CloudEvents on the configured
+// topics and defers to the wrapped serializer otherwise. CloudEvents values
+// are decoded on every topic, so a topic can switch formats without a cutover.
+type cloudEventsSerializer struct {
+	next   Serializer
+	config CloudEventsConfig
+}
+
+func (s *cloudEventsSerializer) enabled(topic string) bool {
+	if !s.config.Enabled {
+		return false
+	}
+	if len(s.config.Topics) == 0 {
+		return true
+	}
+	for _, t := range s.config.Topics {
+		if t == topic {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *cloudEventsSerializer) ContentType(topic string) string {
+	if s.enabled(topic) {
+		return cloudEventsContentType
+	}
+	return s.next.ContentType(topic)
+}
+
+func (s *cloudEventsSerializer) Serialize(ctx context.Context, topic string, envelope *types.Envelope) ([]byte, error) {
+	if !s.enabled(topic) {
+		return s.next.Serialize(ctx, topic, envelope)
+	}
+
+	data, err := json.Marshal(types.NewCloudEvent(envelope, s.config.Source, s.config.TypePrefix))
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal CloudEvent: %w", err)
+	}
+	return data, nil
+}
+
+func (s *cloudEventsSerializer) Deserialize(ctx context.Context, topic string, data []byte) ([]byte, error) {
+	if len(data) == 0 || data[0] != '{' || !bytes.Contains(data, []byte(`"specversion"`)) {
+		return s.next.Deserialize(ctx, topic, data)
+	}
+
+	var event types.CloudEvent
+	if err := json.Unmarshal(data, &event); err != nil || event.SpecVersion == "" {
+		return s.next.Deserialize(ctx, topic, data)
+	}
+	envelope, err := event.Envelope(s.config.TypePrefix)
+	if err != nil {
+		return nil, eventbus.Permanent(err)
+	}
+	return json.Marshal(envelope)
+}
@@ -12,10 +12,73 @@ type KafkaConfig struct {
 	Brokers        []string
 	ProducerConfig ProducerConfig
 	ConsumerConfig ConsumerConfig
+
+	// Serialization selects the wire format: "json" (default) or "avro"
+	Serialization  string
+	SchemaRegistry SchemaRegistryConfig
+	CloudEvents    CloudEventsConfig
+
+	TLS  TLSConfig
+	SASL SASLConfig
+
+	HealthCheck HealthCheckConfig
+	Topics      TopicsConfig
+}
+
+// TopicsConfig controls the topics created at startup when AutoCreate is set
+type TopicsConfig struct {
+	AutoCreate        bool
+	Names             []string
+	Partitions        int            // Zero uses the broker default
+	TopicPartitions   map[string]int // Per-topic overrides of Partitions
+	ReplicationFactor int            // Zero uses the broker default
+	Retention         time.Duration  // Zero uses the broker default
+}
+
+// HealthCheckConfig controls the broker probes behind the health check
+type HealthCheckConfig struct {
+	Interval time.Duration // Between periodic probes; zero disables them
+	Timeout  time.Duration // For one probe of every broker
+}
+
+// TLSConfig holds TLS settings for the broker connections
+type TLSConfig struct {
+	Enabled            bool
+	CAFile             string
+	CertFile           string // Client certificate for mutual TLS
+	KeyFile            string
+	ServerName         string
+	InsecureSkipVerify bool
+}
+
+// SASLConfig holds SASL authentication settings
+type SASLConfig struct {
+	Mechanism string // "", "PLAIN", "SCRAM-SHA-256" or "SCRAM-SHA-512"
+	Username  string
+	Password  string
+}
+
+// SchemaRegistryConfig holds Confluent Schema Registry configuration
+type SchemaRegistryConfig struct {
+	URL          string
+	Username     string
+	Password     string
+	AutoRegister bool // Register the bundled schemas on first publish
+	Timeout      time.Duration
+}
+
+// CloudEventsConfig selects the topics published in the CloudEvents 1.0
+// structured JSON format
+type CloudEventsConfig struct {
+	Enabled    bool
+	Topics     []string // Empty applies to every topic
+	Source     string   // The "source" attribute, a URI reference
+	TypePrefix string   // Prepended to the event type, e.g. "com.example.assets."
 }
 
 // ProducerConfig holds Kafka producer configuration
 type ProducerConfig struct {
+	Name             string // Recorded as the producer in event envelopes
 	RetryMax         int
 	RequiredAcks     int
 	FlushTimeout     time.Duration
@@ -23,6 +86,20 @@ type ProducerConfig struct {
 	FlushMessages    int
 	CompressionType  string
 	IdempotentWrites bool
+
+	// Async queues published events and writes them from AsyncWorkers
+	// goroutines; OverflowPolicy decides what happens when the queue is full
+	Async          bool
+	AsyncQueueSize int
+	AsyncWorkers   int
+	OverflowPolicy string        // "block", "drop_newest", "drop_oldest" or "sync"
+	EnqueueTimeout time.Duration // Longest a "block" publish waits for room
+	DrainTimeout   time.Duration // Longest Close waits for the queue to flush
+
+	// Writes fail fast once BreakerFailureThreshold consecutive ones failed,
+	// until BreakerOpenTimeout has passed; 0 disables the breaker
+	BreakerFailureThreshold int
+	BreakerOpenTimeout      time.Duration
 }
 
 // ConsumerConfig holds Kafka consumer configuration
@@ -33,13 +110,41 @@ type ConsumerConfig struct {
 	RebalanceTimeout  time.Duration
 	AutoCommit       bool
 	AutoCommitInterval time.Duration
+
+	// Topics that commit offsets only after their handler succeeded,
+	// regardless of AutoCommit
+	ManualCommitTopics []string
+
+	// Failed messages are retried as Retry, or the policy in TopicRetry for
+	// that topic, says. Messages that still fail, or fail with a permanent
+	// error, are published to "<topic>.dlq" when DeadLetterEnabled is set.
+	Retry             RetryPolicy
+	TopicRetry        map[string]RetryPolicy
+	DeadLetterEnabled bool
+
+	// Messages of a topic are handled by Concurrency workers, or the count
+	// in TopicConcurrency for that topic; ordering is kept per message key
+	Concurrency      int
+	TopicConcurrency map[string]int
+
+	// DrainTimeout bounds how long Close waits for in-flight messages
+	DrainTimeout time.Duration
 }
 
 // LoadKafkaConfig loads Kafka configuration from environment variables
 func LoadKafkaConfig() *KafkaConfig {
+	retry := RetryPolicy{
+		MaxAttempts: getIntEnv("KAFKA_CONSUMER_MAX_RETRIES", 3),
+		Backoff:     getDurationEnv("KAFKA_CONSUMER_RETRY_BACKOFF", 1*time.Second),
+		Multiplier:  getFloatEnv("KAFKA_CONSUMER_RETRY_MULTIPLIER", 2),
+		MaxBackoff:  getDurationEnv("KAFKA_CONSUMER_RETRY_MAX_BACKOFF", 30*time.Second),
+		Jitter:      getFloatEnv("KAFKA_CONSUMER_RETRY_JITTER", 0.2),
+	}
+
 	return &KafkaConfig{
 		Brokers: getBrokers(),
 		ProducerConfig: ProducerConfig{
+			Name:             getEnv("KAFKA_PRODUCER_NAME", "asset-management-api"),
 			RetryMax:         getIntEnv("KAFKA_PRODUCER_RETRY_MAX", 3),
 			RequiredAcks:     getIntEnv("KAFKA_PRODUCER_REQUIRED_ACKS", 1),
 			FlushTimeout:     getDurationEnv("KAFKA_PRODUCER_FLUSH_TIMEOUT", 5*time.Second),
@@ -47,6 +152,12 @@ func LoadKafkaConfig() *KafkaConfig {
 			FlushMessages:    getIntEnv("KAFKA_PRODUCER_FLUSH_MESSAGES", 100),
 			CompressionType:  getEnv("KAFKA_PRODUCER_COMPRESSION", "snappy"),
 			IdempotentWrites: getBoolEnv("KAFKA_PRODUCER_IDEMPOTENT", true),
+			Async:            getBoolEnv("KAFKA_PRODUCER_ASYNC", false),
+			AsyncQueueSize:   getIntEnv("KAFKA_PRODUCER_QUEUE_SIZE", 10000),
+			AsyncWorkers:     getIntEnv("KAFKA_PRODUCER_WORKERS", 4),
+			OverflowPolicy:   getEnv("KAFKA_PRODUCER_OVERFLOW_POLICY", OverflowBlock),
+			EnqueueTimeout:   getDurationEnv("KAFKA_PRODUCER_ENQUEUE_TIMEOUT", 1*time.Second),
+			DrainTimeout:     getDurationEnv("KAFKA_PRODUCER_DRAIN_TIMEOUT", 10*time.Second),
 		},
 		ConsumerConfig: ConsumerConfig{
 			GroupID:            getEnv("KAFKA_CONSUMER_GROUP_ID", "asset-management-api"),
@@ -55,8 +166,91 @@ func LoadKafkaConfig() *KafkaConfig {
 			RebalanceTimeout:   getDurationEnv("KAFKA_CONSUMER_REBALANCE_TIMEOUT", 60*time.Second),
 			AutoCommit:         getBoolEnv("KAFKA_CONSUMER_AUTO_COMMIT", true),
 			AutoCommitInterval: getDurationEnv("KAFKA_CONSUMER_AUTO_COMMIT_INTERVAL", 1*time.Second),
+			ManualCommitTopics: getSliceEnv("KAFKA_CONSUMER_MANUAL_COMMIT_TOPICS", nil),
+			Retry:              retry,
+			TopicRetry:         ParseTopicRetryPolicies(getEnv("KAFKA_CONSUMER_TOPIC_RETRY", ""), retry),
+			DeadLetterEnabled:  getBoolEnv("KAFKA_DLQ_ENABLED", true),
+			Concurrency:        getIntEnv("KAFKA_CONSUMER_CONCURRENCY", 1),
+			TopicConcurrency:   getIntMapEnv("KAFKA_CONSUMER_TOPIC_CONCURRENCY"),
+			DrainTimeout:       getDurationEnv("KAFKA_CONSUMER_DRAIN_TIMEOUT", 20*time.Second),
 		},
+		Serialization: getEnv("KAFKA_SERIALIZATION", "json"),
+		SchemaRegistry: SchemaRegistryConfig{
+			URL:          getEnv("KAFKA_SCHEMA_REGISTRY_URL", ""),
+			Username:     getEnv("KAFKA_SCHEMA_REGISTRY_USERNAME", ""),
+			Password:     getEnv("KAFKA_SCHEMA_REGISTRY_PASSWORD", ""),
+			AutoRegister: getBoolEnv("KAFKA_SCHEMA_REGISTRY_AUTO_REGISTER", true),
+			Timeout:      getDurationEnv("KAFKA_SCHEMA_REGISTRY_TIMEOUT", 5*time.Second),
+		},
+		CloudEvents: CloudEventsConfig{
+			Enabled:    getBoolEnv("KAFKA_CLOUDEVENTS_ENABLED", false),
+			Topics:     getSliceEnv("KAFKA_CLOUDEVENTS_TOPICS", nil),
+			Source:     getEnv("KAFKA_CLOUDEVENTS_SOURCE", "/asset-management-api"),
+			TypePrefix: getEnv("KAFKA_CLOUDEVENTS_TYPE_PREFIX", "com.assetmanagement."),
+		},
+		TLS: TLSConfig{
+			Enabled:            getBoolEnv("KAFKA_TLS_ENABLED", false),
+			CAFile:             getEnv("KAFKA_TLS_CA_FILE", ""),
+			CertFile:           getEnv("KAFKA_TLS_CERT_FILE", ""),
+			KeyFile:            getEnv("KAFKA_TLS_KEY_FILE", ""),
+			ServerName:         getEnv("KAFKA_TLS_SERVER_NAME", ""),
+			InsecureSkipVerify: getBoolEnv("KAFKA_TLS_INSECURE_SKIP_VERIFY", false),
+		},
+		SASL: SASLConfig{
+			Mechanism: getEnv("KAFKA_SASL_MECHANISM", ""),
+			Username:  getEnv("KAFKA_SASL_USERNAME", ""),
+			Password:  getEnv("KAFKA_SASL_PASSWORD", ""),
+		},
+		HealthCheck: HealthCheckConfig{
+			Interval: getDurationEnv("KAFKA_HEALTH_CHECK_INTERVAL", 30*time.Second),
+			Timeout:  getDurationEnv("KAFKA_HEALTH_CHECK_TIMEOUT", 5*time.Second),
+		},
+		Topics: TopicsConfig{
+			AutoCreate:        getBoolEnv("KAFKA_TOPICS_AUTO_CREATE", false),
+			Names:             getSliceEnv("KAFKA_TOPICS", []string{"team.activity", "asset.changes", "user.changes", "user.activity", "user.notifications"}),
+			Partitions:        getIntEnv("KAFKA_TOPIC_PARTITIONS", 6),
+			TopicPartitions:   getIntMapEnv("KAFKA_TOPIC_PARTITIONS_BY_TOPIC"),
+			ReplicationFactor: getIntEnv("KAFKA_TOPIC_REPLICATION_FACTOR", 0),
+			Retention:         getDurationEnv("KAFKA_TOPIC_RETENTION", 7*24*time.Hour),
+		},
+	}
+}
+
+// ManualCommit reports whether offsets for the topic are committed explicitly
+// after handling. This is the case for every topic when AutoCommit is disabled.
+func (c ConsumerConfig) ManualCommit(topic string) bool {
+	if !c.AutoCommit {
+		return true
+	}
+	for _, t := range c.ManualCommitTopics {
+		if t == topic {
+			return true
+		}
+	}
+	return false
+}
+
+// ConcurrencyFor returns the number of workers handling the topic's messages
+func (c ConsumerConfig) ConcurrencyFor(topic string) int {
+	if n, ok := c.TopicConcurrency[topic]; ok && n > 0 {
+		return n
+	}
+	if c.Concurrency > 0 {
+		return c.Concurrency
 	}
+	return 1
+}
+
+// PartitionsFor returns the partition count a topic is created with, or -1
+// for the broker default
+func (c TopicsConfig) PartitionsFor(topic string) int {
+	if n, ok := c.TopicPartitions[topic]; ok && n > 0 {
+		return n
+	}
+	if c.Partitions > 0 {
+		return c.Partitions
+	}
+	return -1
 }
 
 // getBrokers returns Kafka broker addresses from environment
@@ -73,6 +267,36 @@ func getEnv(key, defaultValue string) string {
 	return defaultValue
 }
 
+func getSliceEnv(key string, defaultValue []string) []string {
+	if value := os.Getenv(key); value != "" {
+		var result []string
+		for _, part := range strings.Split(value, ",") {
+			if trimmed := strings.TrimSpace(part); trimmed != "" {
+				result = append(result, trimmed)
+			}
+		}
+		if len(result) > 0 {
+			return result
+		}
+	}
+	return defaultValue
+}
+
+// getIntMapEnv parses "key=n,key=n" pairs, skipping malformed entries
+func getIntMapEnv(key string) map[string]int {
+	result := make(map[string]int)
+	for _, pair := range getSliceEnv(key, nil) {
+		name, value, ok := strings.Cut(pair, "=")
+		if !ok {
+			continue
+		}
+		if n, err := strconv.Atoi(strings.TrimSpace(value)); err == nil {
+			result[strings.TrimSpace(name)] = n
+		}
+	}
+	return result
+}
+
 func getIntEnv(key string, defaultValue int) int {
 	if value := os.Getenv(key); value != "" {
 		if intValue, err := strconv.Atoi(value); err == nil {
@@ -82,6 +306,15 @@ func getIntEnv(key string, defaultValue int) int {
 	return defaultValue
 }
 
+func getFloatEnv(key string, defaultValue float64) float64 {
+	if value := os.Getenv(key); value != "" {
+		if floatValue, err := strconv.ParseFloat(value, 64); err == nil {
+			return floatValue
+		}
+	}
+	return defaultValue
+}
+
 func getBoolEnv(key string, defaultValue bool) bool {
 	if value := os.Getenv(key); value != "" {
 		if boolValue, err := strconv.ParseBool(value); err == nil {
@@ -4,34 +4,72 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"sort"
 	"sync"
 	"time"
 
+	"asset-management-api/internal/tracing"
 	"asset-management-api/pkg/eventbus"
 	
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
 	"github.com/segmentio/kafka-go"
 )
 
+var consumerPaused = promauto.NewGaugeVec(
+	prometheus.GaugeOpts{
+		Name: "kafka_consumer_paused",
+		Help: "Whether consumption of a topic is paused (1) or running (0)",
+	},
+	[]string{"topic"},
+)
+
 // KafkaConsumer implements EventBus interface for consuming messages
 type KafkaConsumer struct {
 	readers    map[string]*kafka.Reader
 	config     *KafkaConfig
 	handlers   map[string]eventbus.EventHandler
+	deadLetters *KafkaProducer
+	serializer  Serializer
+	dialer      *kafka.Dialer
+	dedup       eventbus.Deduplicator
 	mu         sync.RWMutex
 	ctx        context.Context
 	cancel     context.CancelFunc
 	wg         sync.WaitGroup
+
+	// Handler contexts outlive ctx so in-flight messages can finish while
+	// draining; abortHandlers cancels them once the drain deadline passes
+	handlerCtx    context.Context
+	abortHandlers context.CancelFunc
+
+	// paused maps each paused topic to a channel closed when it resumes
+	paused map[string]chan struct{}
 }
 
-// NewKafkaConsumer creates a new Kafka consumer
-func NewKafkaConsumer(config *KafkaConfig) *KafkaConsumer {
+// abortGracePeriod is how long Shutdown still waits for handlers after
+// cancelling them, before closing the readers regardless
+const abortGracePeriod = 5 * time.Second
+
+// NewKafkaConsumer creates a new Kafka consumer. Messages that exhaust their
+// retries are forwarded through deadLetters; pass nil to only log them.
+func NewKafkaConsumer(config *KafkaConfig, deadLetters *KafkaProducer, serializer Serializer, dialer *kafka.Dialer) *KafkaConsumer {
 	ctx, cancel := context.WithCancel(context.Background())
+	handlerCtx, abortHandlers := context.WithCancel(context.Background())
 	return &KafkaConsumer{
-		readers:  make(map[string]*kafka.Reader),
-		handlers: make(map[string]eventbus.EventHandler),
-		config:   config,
-		ctx:      ctx,
-		cancel:   cancel,
+		readers:     make(map[string]*kafka.Reader),
+		handlers:    make(map[string]eventbus.EventHandler),
+		deadLetters: deadLetters,
+		serializer:  serializer,
+		dialer:      dialer,
+		config:      config,
+		ctx:         ctx,
+		cancel:      cancel,
+
+		handlerCtx:    handlerCtx,
+		abortHandlers: abortHandlers,
+
+		paused: make(map[string]chan struct{}),
 	}
 }
 
@@ -40,6 +78,11 @@ func (c *KafkaConsumer) Publish(ctx context.Context, topic string, event interfa
 	return fmt.Errorf("publish not supported by consumer")
 }
 
+// PublishBatch is not implemented for consumer (only for producer)
+func (c *KafkaConsumer) PublishBatch(ctx context.Context, topic string, events []interface{}) error {
+	return fmt.Errorf("publish not supported by consumer")
+}
+
 // Subscribe starts consuming messages from the specified topic
 func (c *KafkaConsumer) Subscribe(ctx context.Context, topic string, handler eventbus.EventHandler) error {
 	c.mu.Lock()
@@ -50,14 +93,23 @@ func (c *KafkaConsumer) Subscribe(ctx context.Context, topic string, handler eve
 		return fmt.Errorf("already subscribed to topic %s", topic)
 	}
 
+	// In manual commit mode offsets are committed synchronously, one message
+	// at a time, once the message has been handled
+	manualCommit := c.config.ConsumerConfig.ManualCommit(topic)
+	commitInterval := c.config.ConsumerConfig.AutoCommitInterval
+	if manualCommit {
+		commitInterval = 0
+	}
+
 	// Create reader
 	reader := kafka.NewReader(kafka.ReaderConfig{
 		Brokers:        c.config.Brokers,
 		Topic:          topic,
 		GroupID:        c.config.ConsumerConfig.GroupID,
+		Dialer:         c.dialer,
 		MinBytes:       1,
 		MaxBytes:       10e6, // 10MB
-		CommitInterval: c.config.ConsumerConfig.AutoCommitInterval,
+		CommitInterval: commitInterval,
 		StartOffset:    kafka.LastOffset, // Start from latest messages
 		Logger:         kafka.LoggerFunc(log.Printf),
 		ErrorLogger:    kafka.LoggerFunc(log.Printf),
@@ -65,18 +117,27 @@ func (c *KafkaConsumer) Subscribe(ctx context.Context, topic string, handler eve
 
 	c.readers[topic] = reader
 	c.handlers[topic] = handler
+	consumerPaused.WithLabelValues(topic).Set(0)
 
 	// Start consuming in a separate goroutine
 	c.wg.Add(1)
-	go c.consumeMessages(topic, reader, handler)
+	go c.consumeMessages(topic, reader, handler, manualCommit)
 
-	log.Printf("Subscribed to Kafka topic: %s", topic)
+	log.Printf("Subscribed to Kafka topic: %s (manual commit: %t, workers: %d)",
+		topic, manualCommit, c.config.ConsumerConfig.ConcurrencyFor(topic))
 	return nil
 }
 
-// consumeMessages consumes messages from a topic in a separate goroutine
-func (c *KafkaConsumer) consumeMessages(topic string, reader *kafka.Reader, handler eventbus.EventHandler) {
+// consumeMessages consumes messages from a topic in a separate goroutine and
+// hands them to the topic's worker pool.
+// In manual commit mode messages are fetched without committing and the
+// offset is committed only after the message was handled or dead-lettered,
+// so a crash mid-handler redelivers the message instead of losing it.
+func (c *KafkaConsumer) consumeMessages(topic string, reader *kafka.Reader, handler eventbus.EventHandler, manualCommit bool) {
 	defer c.wg.Done()
+
+	pool := newWorkerPool(c, topic, reader, handler, manualCommit, c.config.ConsumerConfig.ConcurrencyFor(topic))
+	defer pool.stop()
 	
 	for {
 		select {
@@ -84,9 +145,24 @@ func (c *KafkaConsumer) consumeMessages(topic string, reader *kafka.Reader, hand
 			log.Printf("Stopping consumer for topic %s", topic)
 			return
 		default:
+			// Hold off fetching while the topic is paused
+			if resumed := c.pausedUntil(topic); resumed != nil {
+				select {
+				case <-resumed:
+				case <-c.ctx.Done():
+				}
+				continue
+			}
+
 			// Read message with timeout
 			ctx, cancel := context.WithTimeout(c.ctx, 10*time.Second)
-			message, err := reader.ReadMessage(ctx)
+			var message kafka.Message
+			var err error
+			if manualCommit {
+				message, err = reader.FetchMessage(ctx)
+			} else {
+				message, err = reader.ReadMessage(ctx)
+			}
 			cancel()
 
 			if err != nil {
@@ -101,52 +177,188 @@ func (c *KafkaConsumer) consumeMessages(topic string, reader *kafka.Reader, hand
 				continue
 			}
 
-			// Process message
-			if err := c.processMessage(topic, message, handler); err != nil {
-				log.Printf("Error processing message from topic %s: %v", topic, err)
-				// In production, you might want to send failed messages to a dead letter queue
+			// Process message on its worker
+			if !pool.dispatch(message) {
+				return // Consumer closed; the message stays uncommitted
 			}
 		}
 	}
 }
 
-// processMessage processes a single message with retry logic
-func (c *KafkaConsumer) processMessage(topic string, message kafka.Message, handler eventbus.EventHandler) error {
-	maxRetries := 3
+// Pause stops fetching messages from the topic until Resume. Messages already
+// handed to workers still finish, and a fetch in progress may still deliver
+// one more message. The reader stays in its consumer group, so the topic's
+// partitions are not reassigned to other instances while paused.
+func (c *KafkaConsumer) Pause(topic string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, exists := c.readers[topic]; !exists {
+		return fmt.Errorf("%w: %s", eventbus.ErrNotSubscribed, topic)
+	}
+	if _, paused := c.paused[topic]; paused {
+		return nil
+	}
+
+	c.paused[topic] = make(chan struct{})
+	consumerPaused.WithLabelValues(topic).Set(1)
+	log.Printf("Paused consumption of Kafka topic %s", topic)
+	return nil
+}
+
+// Resume continues fetching from a paused topic at its last committed offset
+func (c *KafkaConsumer) Resume(topic string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, exists := c.readers[topic]; !exists {
+		return fmt.Errorf("%w: %s", eventbus.ErrNotSubscribed, topic)
+	}
+	resumed, paused := c.paused[topic]
+	if !paused {
+		return nil
+	}
+
+	delete(c.paused, topic)
+	close(resumed)
+	consumerPaused.WithLabelValues(topic).Set(0)
+	log.Printf("Resumed consumption of Kafka topic %s", topic)
+	return nil
+}
+
+// PausedTopics returns the paused topics in name order
+func (c *KafkaConsumer) PausedTopics() []string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	topics := make([]string, 0, len(c.paused))
+	for topic := range c.paused {
+		topics = append(topics, topic)
+	}
+	sort.Strings(topics)
+	return topics
+}
+
+// pausedUntil returns a channel closed when the topic resumes, or nil if it
+// is not paused
+func (c *KafkaConsumer) pausedUntil(topic string) <-chan struct{} {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if resumed, paused := c.paused[topic]; paused {
+		return resumed
+	}
+	return nil
+}
+
+// processMessage processes a single message, retrying failures as the
+// topic's retry policy says. It reports the message as settled when the
+// handler succeeded or it was dead-lettered. Permanent errors, such as values
+// that cannot be decoded, are dead-lettered without retrying.
+func (c *KafkaConsumer) processMessage(topic string, message kafka.Message, handler eventbus.EventHandler) (bool, error) {
+	policy := c.config.ConsumerConfig.RetryPolicyFor(topic)
+	maxAttempts := policy.attempts()
+
+	// Skip events already handled before a retry or rebalance redelivered them
+	eventType := messageEventType(message)
+	eventID, handle := c.claimEvent(topic, message)
+	if !handle {
+		consumerMessagesTotal.WithLabelValues(topic, eventType, outcomeDuplicate).Inc()
+		return true, nil
+	}
 	var err error
+	attempts := 0
+
+	// Handlers continue the trace of the request that published the event
+	handlerCtx, span := startConsumerSpan(c.handlerCtx, topic, message)
+	defer func() { endSpan(span, err) }()
+	traceID := tracing.TraceID(handlerCtx)
+	requestID := messageRequestID(message)
+	if requestID != "" {
+		handlerCtx = tracing.ContextWithRequestID(handlerCtx, requestID)
+	}
+
+	for attempts < maxAttempts {
+		attempts++
+		if attempts > 1 {
+			consumerRetriesTotal.WithLabelValues(topic, eventType).Inc()
+		}
 
-	for attempt := 0; attempt < maxRetries; attempt++ {
 		// Create context with timeout for handler execution
-		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		ctx, cancel := context.WithTimeout(handlerCtx, 30*time.Second)
 		
-		// Call the handler
-		err = handler(ctx, message.Value)
+		// Decode the value and call the handler
+		start := time.Now()
+		var value []byte
+		value, err = c.serializer.Deserialize(ctx, topic, message.Value)
+		if err == nil {
+			err = handler(ctx, value)
+		}
 		cancel()
+		recordHandlerAttempt(topic, eventType, time.Since(start), err)
 
 		if err == nil {
+			consumerMessagesTotal.WithLabelValues(topic, eventType, outcomeHandled).Inc()
 			// Log successful processing
-			log.Printf("Successfully processed message from topic %s, partition %d, offset %d", 
-				topic, message.Partition, message.Offset)
-			return nil
+			log.Printf("Successfully processed message from topic %s, partition %d, offset %d (trace %s, request %s)", 
+				topic, message.Partition, message.Offset, traceID, requestID)
+			c.settleEvent(topic, eventID, true)
+			return true, nil
 		}
 
-		log.Printf("Attempt %d/%d failed for message from topic %s: %v", 
-			attempt+1, maxRetries, topic, err)
+		log.Printf("Attempt %d/%d failed for message from topic %s (trace %s, request %s): %v", 
+			attempts, maxAttempts, topic, traceID, requestID, err)
+
+		// While shutting down a failed message is neither retried nor
+		// dead-lettered; it stays uncommitted and is redelivered later
+		if c.ctx.Err() != nil {
+			consumerMessagesTotal.WithLabelValues(topic, eventType, outcomeAbandoned).Inc()
+			c.settleEvent(topic, eventID, false)
+			return false, fmt.Errorf("consumer closing, message left for redelivery: %w", err)
+		}
+
+		if eventbus.IsPermanent(err) {
+			log.Printf("Permanent error for message from topic %s, not retrying", topic)
+			break
+		}
 		
-		if attempt < maxRetries-1 {
-			// Exponential backoff
-			backoffTime := time.Duration(attempt+1) * time.Second
-			time.Sleep(backoffTime)
+		if attempts < maxAttempts {
+			select {
+			case <-time.After(policy.Delay(attempts)):
+			case <-c.ctx.Done():
+			}
 		}
 	}
 
 	// All retries failed
 	log.Printf("Failed to process message after %d attempts from topic %s: %v", 
-		maxRetries, topic, err)
+		attempts, topic, err)
 	
-	// In production, you might want to send this to a dead letter queue
 	c.logFailedMessage(topic, message, err)
-	return err
+	c.settleEvent(topic, eventID, false)
+	deadLettered, dlqErr := c.publishDeadLetter(topic, message, attempts, err)
+	if dlqErr != nil {
+		log.Printf("Failed to dead-letter message from topic %s, partition %d, offset %d: %v",
+			topic, message.Partition, message.Offset, dlqErr)
+	}
+	if deadLettered {
+		consumerMessagesTotal.WithLabelValues(topic, eventType, outcomeDeadLettered).Inc()
+	} else {
+		consumerMessagesTotal.WithLabelValues(topic, eventType, outcomeFailed).Inc()
+	}
+	return deadLettered, err
+}
+
+// commitMessage commits the offset of a settled message. It does not use the
+// consumer context so the final commit still goes through during shutdown.
+func (c *KafkaConsumer) commitMessage(topic string, reader *kafka.Reader, message kafka.Message) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := reader.CommitMessages(ctx, message); err != nil {
+		log.Printf("Failed to commit offset %d of topic %s partition %d: %v",
+			message.Offset, topic, message.Partition, err)
+	}
 }
 
 // logFailedMessage logs details about failed message processing
@@ -155,15 +367,41 @@ func (c *KafkaConsumer) logFailedMessage(topic string, message kafka.Message, er
 		topic, message.Partition, message.Offset, err, string(message.Value))
 }
 
-// Close closes all readers and stops consuming
+// Close stops consuming, draining in-flight messages for up to the configured drain timeout
 func (c *KafkaConsumer) Close() error {
+	ctx, cancel := context.WithTimeout(context.Background(), c.config.ConsumerConfig.DrainTimeout)
+	defer cancel()
+	return c.Shutdown(ctx)
+}
+
+// Shutdown stops fetching, lets the workers finish the messages they hold
+// and commits their offsets, then closes the readers. When ctx is done first
+// the remaining handlers are cancelled; their messages stay uncommitted.
+func (c *KafkaConsumer) Shutdown(ctx context.Context) error {
 	log.Println("Closing Kafka consumer...")
 	
-	// Cancel context to stop all consumers
+	// Cancel context to stop fetching
 	c.cancel()
 	
-	// Wait for all consumer goroutines to finish
-	c.wg.Wait()
+	// Wait for in-flight messages, up to the drain deadline
+	done := make(chan struct{})
+	go func() {
+		c.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+		log.Println("Kafka consumer drain deadline exceeded, cancelling in-flight handlers")
+		c.abortHandlers()
+		select {
+		case <-done:
+		case <-time.After(abortGracePeriod):
+			log.Println("Kafka handlers did not stop after cancellation, closing readers anyway")
+		}
+	}
+	defer c.abortHandlers()
 	
 	c.mu.Lock()
 	defer c.mu.Unlock()
@@ -191,9 +429,19 @@ func (c *KafkaConsumer) HealthCheck() map[string]interface{} {
 		"topics":           make([]string, 0, len(c.readers)),
 	}
 	
+	workers := make(map[string]int, len(c.readers))
 	for topic := range c.readers {
 		health["topics"] = append(health["topics"].([]string), topic)
+		workers[topic] = c.config.ConsumerConfig.ConcurrencyFor(topic)
+	}
+	health["workers"] = workers
+
+	paused := make([]string, 0, len(c.paused))
+	for topic := range c.paused {
+		paused = append(paused, topic)
 	}
+	sort.Strings(paused)
+	health["paused_topics"] = paused
 	
 	return health
 }
\ No newline at end of file
@@ -0,0 +1,87 @@
+package kafka
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"asset-management-api/pkg/eventbus"
+
+	"github.com/segmentio/kafka-go"
+)
+
+// HeaderEventID carries the envelope's event ID, set by the producer
+const HeaderEventID = "event-id"
+
+// dedupTimeout bounds each call to the deduplication store
+const dedupTimeout = 2 * time.Second
+
+// SetDeduplicator makes the consumer skip events it has already handled.
+// It must be called before subscribing.
+func (c *KafkaConsumer) SetDeduplicator(dedup eventbus.Deduplicator) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.dedup = dedup
+}
+
+// claimEvent reports whether the message should be handled. Messages without
+// an event ID, and any failure of the store, fail open: the event is handled.
+func (c *KafkaConsumer) claimEvent(topic string, message kafka.Message) (eventID string, handle bool) {
+	if c.dedup == nil {
+		return "", true
+	}
+	eventID = headerValue(message, HeaderEventID)
+	if eventID == "" {
+		return "", true
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), dedupTimeout)
+	defer cancel()
+
+	claimed, err := c.dedup.Claim(ctx, c.dedupScope(topic), eventID)
+	if err != nil {
+		log.Printf("Deduplication check failed for event %s on topic %s, handling anyway: %v", eventID, topic, err)
+		return "", true
+	}
+	if !claimed {
+		log.Printf("Skipping duplicate event %s on topic %s, partition %d, offset %d",
+			eventID, topic, message.Partition, message.Offset)
+		return eventID, false
+	}
+	return eventID, true
+}
+
+// settleEvent records the outcome of a claimed event: handled events are
+// remembered, failed ones released so a redelivery or re-drive can run them
+func (c *KafkaConsumer) settleEvent(topic, eventID string, handled bool) {
+	if c.dedup == nil || eventID == "" {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), dedupTimeout)
+	defer cancel()
+
+	var err error
+	if handled {
+		err = c.dedup.Complete(ctx, c.dedupScope(topic), eventID)
+	} else {
+		err = c.dedup.Release(ctx, c.dedupScope(topic), eventID)
+	}
+	if err != nil {
+		log.Printf("Failed to record deduplication state of event %s on topic %s: %v", eventID, topic, err)
+	}
+}
+
+// dedupScope keeps the markers of different consumer groups and topics apart
+func (c *KafkaConsumer) dedupScope(topic string) string {
+	return c.config.ConsumerConfig.GroupID + ":" + topic
+}
+
+func headerValue(message kafka.Message, key string) string {
+	for _, header := range message.Headers {
+		if header.Key == key {
+			return string(header.Value)
+		}
+	}
+	return ""
+}
@@ -0,0 +1,157 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"asset-management-api/pkg/eventbus"
+
+	"github.com/segmentio/kafka-go"
+)
+
+// Headers describing why and where a message was dead-lettered
+const (
+	HeaderDLQOriginalTopic     = "x-dlq-original-topic"
+	HeaderDLQOriginalPartition = "x-dlq-original-partition"
+	HeaderDLQOriginalOffset    = "x-dlq-original-offset"
+	HeaderDLQConsumerGroup     = "x-dlq-consumer-group"
+	HeaderDLQError             = "x-dlq-error"
+	HeaderDLQAttempts          = "x-dlq-attempts"
+	HeaderDLQFailedAt          = "x-dlq-failed-at"
+	HeaderRedriveCount         = "x-redrive-count"
+
+	dlqHeaderPrefix = "x-dlq-"
+
+	// redriveIdleTimeout bounds how long a re-drive waits for the next message
+	// (including the initial consumer group join) before it considers the
+	// dead letter queue drained
+	redriveIdleTimeout = 10 * time.Second
+)
+
+// publishDeadLetter forwards a message that exhausted its retries to
+// "<topic>.dlq", keeping its key, value and headers and adding failure
+// metadata. It reports false when dead-lettering is disabled.
+func (c *KafkaConsumer) publishDeadLetter(topic string, message kafka.Message, attempts int, cause error) (bool, error) {
+	if c.deadLetters == nil || !c.config.ConsumerConfig.DeadLetterEnabled {
+		return false, nil
+	}
+
+	headers := make([]kafka.Header, 0, len(message.Headers)+7)
+	for _, header := range message.Headers {
+		if !strings.HasPrefix(header.Key, dlqHeaderPrefix) {
+			headers = append(headers, header)
+		}
+	}
+	headers = append(headers,
+		kafka.Header{Key: HeaderDLQOriginalTopic, Value: []byte(topic)},
+		kafka.Header{Key: HeaderDLQOriginalPartition, Value: []byte(strconv.Itoa(message.Partition))},
+		kafka.Header{Key: HeaderDLQOriginalOffset, Value: []byte(strconv.FormatInt(message.Offset, 10))},
+		kafka.Header{Key: HeaderDLQConsumerGroup, Value: []byte(c.config.ConsumerConfig.GroupID)},
+		kafka.Header{Key: HeaderDLQError, Value: []byte(cause.Error())},
+		kafka.Header{Key: HeaderDLQAttempts, Value: []byte(strconv.Itoa(attempts))},
+		kafka.Header{Key: HeaderDLQFailedAt, Value: []byte(time.Now().UTC().Format(time.RFC3339))},
+	)
+
+	ctx, cancel := context.WithTimeout(context.Background(), c.config.ProducerConfig.FlushTimeout)
+	defer cancel()
+
+	dlqTopic := eventbus.DeadLetterTopic(topic)
+	if err := c.deadLetters.PublishMessage(ctx, dlqTopic, kafka.Message{
+		Key:     message.Key,
+		Value:   message.Value,
+		Headers: headers,
+	}); err != nil {
+		return false, err
+	}
+
+	log.Printf("Dead-lettered message from topic %s, partition %d, offset %d to %s",
+		topic, message.Partition, message.Offset, dlqTopic)
+	return true, nil
+}
+
+// Redrive replays up to limit messages from "<topic>.dlq" onto the topic they
+// originally failed on. The re-drive reader uses its own consumer group and
+// commits each message only after it was republished, so an interrupted
+// re-drive resumes where it stopped and nothing is replayed twice.
+func (b *KafkaEventBus) Redrive(ctx context.Context, topic string, limit int) (*eventbus.RedriveResult, error) {
+	if strings.HasSuffix(topic, eventbus.DeadLetterSuffix) {
+		return nil, fmt.Errorf("topic %s is already a dead letter topic", topic)
+	}
+	if !b.redriveMu.TryLock() {
+		return nil, eventbus.ErrRedriveInProgress
+	}
+	defer b.redriveMu.Unlock()
+
+	result := &eventbus.RedriveResult{
+		Topic:           topic,
+		DeadLetterTopic: eventbus.DeadLetterTopic(topic),
+	}
+
+	reader := kafka.NewReader(kafka.ReaderConfig{
+		Brokers:     b.config.Brokers,
+		Topic:       result.DeadLetterTopic,
+		GroupID:     b.config.ConsumerConfig.GroupID + "-dlq-redrive",
+		Dialer:      b.connections.dialer,
+		MinBytes:    1,
+		MaxBytes:    10e6, // 10MB
+		StartOffset: kafka.FirstOffset,
+		Logger:      kafka.LoggerFunc(log.Printf),
+		ErrorLogger: kafka.LoggerFunc(log.Printf),
+	})
+	defer func() {
+		if err := reader.Close(); err != nil {
+			log.Printf("Error closing re-drive reader for topic %s: %v", result.DeadLetterTopic, err)
+		}
+	}()
+
+	for limit <= 0 || result.Redriven < limit {
+		fetchCtx, cancel := context.WithTimeout(ctx, redriveIdleTimeout)
+		message, err := reader.FetchMessage(fetchCtx)
+		cancel()
+		if err != nil {
+			if errors.Is(err, context.DeadlineExceeded) && ctx.Err() == nil {
+				break // Dead letter queue drained
+			}
+			return result, fmt.Errorf("failed to read from %s: %w", result.DeadLetterTopic, err)
+		}
+
+		if err := b.producer.PublishMessage(ctx, topic, redriveMessage(message)); err != nil {
+			return result, fmt.Errorf("failed to republish offset %d of %s: %w", message.Offset, result.DeadLetterTopic, err)
+		}
+		if err := reader.CommitMessages(ctx, message); err != nil {
+			return result, fmt.Errorf("failed to commit offset %d of %s: %w", message.Offset, result.DeadLetterTopic, err)
+		}
+		result.Redriven++
+	}
+
+	log.Printf("Re-drove %d messages from %s to %s", result.Redriven, result.DeadLetterTopic, topic)
+	return result, nil
+}
+
+// redriveMessage strips the dead letter metadata from a message and counts
+// the re-drive, so a message that keeps failing can be recognized
+func redriveMessage(message kafka.Message) kafka.Message {
+	redrives := 0
+	headers := make([]kafka.Header, 0, len(message.Headers)+1)
+	for _, header := range message.Headers {
+		switch {
+		case header.Key == HeaderRedriveCount:
+			redrives, _ = strconv.Atoi(string(header.Value))
+		case strings.HasPrefix(header.Key, dlqHeaderPrefix):
+		default:
+			headers = append(headers, header)
+		}
+	}
+	headers = append(headers, kafka.Header{Key: HeaderRedriveCount, Value: []byte(strconv.Itoa(redrives + 1))})
+
+	return kafka.Message{
+		Key:     message.Key,
+		Value:   message.Value,
+		Headers: headers,
+	}
+}
@@ -0,0 +1,174 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+)
+
+// ErrNoBrokers is returned when none of the configured brokers can be reached
+var ErrNoBrokers = errors.New("no Kafka broker reachable")
+
+// BrokerStatus is the outcome of the latest broker probe
+type BrokerStatus struct {
+	Healthy             bool              `json:"healthy"`
+	Controller          string            `json:"controller,omitempty"`
+	Brokers             map[string]string `json:"brokers"` // Configured address to "ok" or the probe error
+	ConsecutiveFailures int               `json:"consecutive_failures"`
+	CheckedAt           time.Time         `json:"checked_at"`
+	LastHealthyAt       time.Time         `json:"last_healthy_at,omitempty"`
+}
+
+// probeBrokers dials every configured broker and asks it for the cluster
+// controller, a metadata request that touches no topics. The cluster is
+// healthy when at least one broker answers.
+func probeBrokers(ctx context.Context, dialer *kafka.Dialer, brokers []string) BrokerStatus {
+	status := BrokerStatus{
+		Brokers:   make(map[string]string, len(brokers)),
+		CheckedAt: time.Now().UTC(),
+	}
+
+	for _, broker := range brokers {
+		controller, err := probeBroker(ctx, dialer, broker)
+		if err != nil {
+			status.Brokers[broker] = err.Error()
+			continue
+		}
+		status.Brokers[broker] = "ok"
+		status.Healthy = true
+		if status.Controller == "" {
+			status.Controller = controller
+		}
+	}
+	return status
+}
+
+func probeBroker(ctx context.Context, dialer *kafka.Dialer, broker string) (string, error) {
+	conn, err := dialer.DialContext(ctx, "tcp", broker)
+	if err != nil {
+		return "", err
+	}
+	defer conn.Close()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+	controller, err := conn.Controller()
+	if err != nil {
+		return "", fmt.Errorf("metadata request failed: %w", err)
+	}
+	return net.JoinHostPort(controller.Host, strconv.Itoa(controller.Port)), nil
+}
+
+// brokerMonitor probes the brokers periodically and keeps the latest status.
+// When the cluster recovers from an outage or its controller moves, it calls
+// onFailover so the producer drops connections to the old brokers.
+type brokerMonitor struct {
+	dialer     *kafka.Dialer
+	brokers    []string
+	config     HealthCheckConfig
+	onFailover func()
+
+	mu     sync.RWMutex
+	status BrokerStatus
+	probed bool
+
+	started  atomic.Bool
+	stopOnce sync.Once
+	stop     chan struct{}
+	done     chan struct{}
+}
+
+func newBrokerMonitor(dialer *kafka.Dialer, brokers []string, config HealthCheckConfig, onFailover func()) *brokerMonitor {
+	return &brokerMonitor{
+		dialer:     dialer,
+		brokers:    brokers,
+		config:     config,
+		onFailover: onFailover,
+		stop:       make(chan struct{}),
+		done:       make(chan struct{}),
+	}
+}
+
+// check probes the brokers once and records the result
+func (m *brokerMonitor) check(ctx context.Context) BrokerStatus {
+	if m.config.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, m.config.Timeout)
+		defer cancel()
+	}
+	status := probeBrokers(ctx, m.dialer, m.brokers)
+
+	m.mu.Lock()
+	previous, probed := m.status, m.probed
+	if status.Healthy {
+		status.LastHealthyAt = status.CheckedAt
+	} else {
+		status.LastHealthyAt = previous.LastHealthyAt
+		status.ConsecutiveFailures = previous.ConsecutiveFailures + 1
+	}
+	m.status, m.probed = status, true
+	m.mu.Unlock()
+
+	if !probed {
+		return status
+	}
+	switch {
+	case previous.Healthy && !status.Healthy:
+		log.Printf("Kafka brokers unreachable: %v", status.Brokers)
+	case !previous.Healthy && status.Healthy:
+		log.Printf("Kafka brokers reachable again after %d failed probes, reconnecting producer", previous.ConsecutiveFailures)
+		m.onFailover()
+	case status.Healthy && previous.Controller != "" && status.Controller != previous.Controller:
+		log.Printf("Kafka controller moved from %s to %s, reconnecting producer", previous.Controller, status.Controller)
+		m.onFailover()
+	}
+	return status
+}
+
+// Status returns the latest probe result
+func (m *brokerMonitor) Status() BrokerStatus {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.status
+}
+
+// start probes the brokers every interval until close; a zero interval
+// disables the periodic probes
+func (m *brokerMonitor) start() {
+	if m.config.Interval <= 0 || !m.started.CompareAndSwap(false, true) {
+		return
+	}
+	go func() {
+		defer close(m.done)
+
+		ticker := time.NewTicker(m.config.Interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				m.check(context.Background())
+			case <-m.stop:
+				return
+			}
+		}
+	}()
+}
+
+// close stops the probes started by start and waits for the current one
+func (m *brokerMonitor) close() {
+	m.stopOnce.Do(func() {
+		close(m.stop)
+		if m.started.Load() {
+			<-m.done
+		}
+	})
+}
@@ -0,0 +1,123 @@
+package kafka
+
+import (
+	"errors"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/segmentio/kafka-go"
+)
+
+// HeaderEventType carries the envelope's event type, set by the producer so
+// consumers can label metrics without decoding the value
+const HeaderEventType = "event-type"
+
+// unknownEventType labels messages published without an event type header,
+// such as those written before the header was added
+const unknownEventType = "unknown"
+
+// Consumer outcomes of a message
+const (
+	outcomeHandled      = "handled"       // The handler succeeded
+	outcomeDuplicate    = "duplicate"     // Already handled; skipped
+	outcomeDeadLettered = "dead_lettered" // Failed and moved to the dead letter topic
+	outcomeFailed       = "failed"        // Failed and could not be dead-lettered
+	outcomeAbandoned    = "abandoned"     // Left uncommitted for redelivery at shutdown
+)
+
+// Publish and handling latencies of events, in seconds: 1ms up to about 16s
+var eventLatencyBuckets = prometheus.ExponentialBuckets(0.001, 2, 15)
+
+var (
+	publishAttemptsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "kafka_publish_attempts_total",
+			Help: "Total number of events the producer attempted to write to Kafka",
+		},
+		[]string{"topic", "event_type"},
+	)
+
+	publishFailuresTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "kafka_publish_failures_total",
+			Help: "Total number of events that could not be written to Kafka",
+		},
+		[]string{"topic", "event_type"},
+	)
+
+	publishRetriesTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "kafka_publish_retries_total",
+			Help: "Total number of writes retried on a new writer after the previous one was closed",
+		},
+		[]string{"topic"},
+	)
+
+	publishDuration = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "kafka_publish_duration_seconds",
+			Help:    "Time taken to write events to Kafka, observed once per event",
+			Buckets: eventLatencyBuckets,
+		},
+		[]string{"topic", "event_type"},
+	)
+
+	consumerMessagesTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "kafka_consumer_messages_total",
+			Help: "Total number of consumed messages by outcome",
+		},
+		[]string{"topic", "event_type", "outcome"},
+	)
+
+	consumerRetriesTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "kafka_consumer_retries_total",
+			Help: "Total number of handler attempts retried after a failure",
+		},
+		[]string{"topic", "event_type"},
+	)
+
+	consumerHandlerDuration = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "kafka_consumer_handler_duration_seconds",
+			Help:    "Time taken by a handler attempt, labelled by whether it succeeded",
+			Buckets: eventLatencyBuckets,
+		},
+		[]string{"topic", "event_type", "result"},
+	)
+)
+
+// messageEventType returns the event type a message was published with
+func messageEventType(message kafka.Message) string {
+	if eventType := headerValue(message, HeaderEventType); eventType != "" {
+		return eventType
+	}
+	return unknownEventType
+}
+
+// recordPublish records the outcome of writing messages to a topic. When
+// only some messages of a batch failed, only those count as failures.
+func recordPublish(topic string, messages []kafka.Message, duration time.Duration, err error) {
+	var writeErrs kafka.WriteErrors
+	partial := errors.As(err, &writeErrs) && len(writeErrs) == len(messages)
+
+	for i, message := range messages {
+		eventType := messageEventType(message)
+		publishAttemptsTotal.WithLabelValues(topic, eventType).Inc()
+		publishDuration.WithLabelValues(topic, eventType).Observe(duration.Seconds())
+		if err != nil && (!partial || writeErrs[i] != nil) {
+			publishFailuresTotal.WithLabelValues(topic, eventType).Inc()
+		}
+	}
+}
+
+// recordHandlerAttempt records the duration of a single handler attempt
+func recordHandlerAttempt(topic, eventType string, duration time.Duration, err error) {
+	result := "success"
+	if err != nil {
+		result = "failure"
+	}
+	consumerHandlerDuration.WithLabelValues(topic, eventType, result).Observe(duration.Seconds())
+}
@@ -2,11 +2,17 @@ package kafka
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"log"
+	"strconv"
+	"sync"
 	"time"
 
+	"asset-management-api/internal/breaker"
+	"asset-management-api/internal/events/types"
+	"asset-management-api/internal/tracing"
 	"asset-management-api/pkg/eventbus"
 	
 	"github.com/segmentio/kafka-go"
@@ -15,53 +21,167 @@ import (
 
 // KafkaProducer implements EventBus interface for producing messages
 type KafkaProducer struct {
-	writers map[string]*kafka.Writer
-	config  *KafkaConfig
+	writers    map[string]*kafka.Writer
+	config     *KafkaConfig
+	serializer Serializer
+	transport  *kafka.Transport
+	breaker    *breaker.Breaker
+	mu         sync.Mutex
 }
 
 // NewKafkaProducer creates a new Kafka producer
-func NewKafkaProducer(config *KafkaConfig) *KafkaProducer {
+func NewKafkaProducer(config *KafkaConfig, serializer Serializer, transport *kafka.Transport) *KafkaProducer {
 	return &KafkaProducer{
-		writers: make(map[string]*kafka.Writer),
-		config:  config,
+		writers:    make(map[string]*kafka.Writer),
+		config:     config,
+		serializer: serializer,
+		transport:  transport,
+		breaker:    breaker.New("kafka", config.ProducerConfig.BreakerFailureThreshold, config.ProducerConfig.BreakerOpenTimeout),
 	}
 }
 
 // Publish sends an event to the specified Kafka topic
-func (p *KafkaProducer) Publish(ctx context.Context, topic string, event interface{}) error {
+func (p *KafkaProducer) Publish(ctx context.Context, topic string, event interface{}) (err error) {
+	ctx, span := startProducerSpan(ctx, topic, 1)
+	defer func() { endSpan(span, err) }()
+
+	// Wrap the event in a versioned envelope
+	batch, err := newOutboundEvents(ctx, p.config.ProducerConfig.Name, []interface{}{event})
+	if err != nil {
+		return err
+	}
+	return p.publishEnvelopes(ctx, topic, batch)
+}
+
+// PublishBatch sends several events to the specified Kafka topic in a single write
+func (p *KafkaProducer) PublishBatch(ctx context.Context, topic string, events []interface{}) (err error) {
+	ctx, span := startProducerSpan(ctx, topic, len(events))
+	defer func() { endSpan(span, err) }()
+
+	batch, err := newOutboundEvents(ctx, p.config.ProducerConfig.Name, events)
+	if err != nil {
+		return err
+	}
+	return p.publishEnvelopes(ctx, topic, batch)
+}
+
+// outboundEvent is an enveloped event waiting to be written, with its
+// partition key and the span and request it was published in
+type outboundEvent struct {
+	envelope  *types.Envelope
+	key       []byte
+	trace     tracing.SpanContext
+	requestID string
+}
+
+// newOutboundEvents wraps each event in an envelope. The span and request ID
+// are captured now because queued events are written after the request has
+// finished.
+func newOutboundEvents(ctx context.Context, producer string, events []interface{}) ([]outboundEvent, error) {
+	trace, _ := tracing.FromContext(ctx)
+	requestID := tracing.RequestID(ctx)
+	batch := make([]outboundEvent, 0, len(events))
+	for _, event := range events {
+		envelope, err := types.NewEnvelope(ctx, producer, event)
+		if err != nil {
+			return nil, err
+		}
+		batch = append(batch, outboundEvent{envelope: envelope, key: partitionKey(event), trace: trace, requestID: requestID})
+	}
+	return batch, nil
+}
+
+// publishEnvelopes serializes envelopes and writes them to the topic in one call
+func (p *KafkaProducer) publishEnvelopes(ctx context.Context, topic string, batch []outboundEvent) error {
+	if len(batch) == 0 {
+		return nil
+	}
+
 	writer, err := p.getWriter(topic)
 	if err != nil {
 		return fmt.Errorf("failed to get writer for topic %s: %w", topic, err)
 	}
 
-	// Serialize event to JSON
-	eventBytes, err := json.Marshal(event)
+	messages := make([]kafka.Message, 0, len(batch))
+	for _, item := range batch {
+		eventBytes, err := p.serializer.Serialize(ctx, topic, item.envelope)
+		if err != nil {
+			return err
+		}
+
+		// Create Kafka message; the writer sets the topic
+		messages = append(messages, kafka.Message{
+			Key:   item.key,
+			Value: eventBytes,
+			Time:  time.Now(),
+			Headers: append([]kafka.Header{
+				{Key: "content-type", Value: []byte(p.serializer.ContentType(topic))},
+				{Key: "schema-version", Value: []byte(strconv.Itoa(item.envelope.SchemaVersion))},
+				{Key: HeaderEventID, Value: []byte(item.envelope.EventID.String())},
+				{Key: HeaderEventType, Value: []byte(item.envelope.EventType)},
+			}, traceHeaders(item.trace, item.requestID)...),
+		})
+	}
+
+	// Write messages; a writer closed by reconnect is replaced and retried once
+	start := time.Now()
+	err = p.breaker.Do(func() error {
+		err := writer.WriteMessages(ctx, messages...)
+		if errors.Is(err, io.ErrClosedPipe) {
+			publishRetriesTotal.WithLabelValues(topic).Inc()
+			if writer, err = p.getWriter(topic); err == nil {
+				err = writer.WriteMessages(ctx, messages...)
+			}
+		}
+		return err
+	}, nil)
+	recordPublish(topic, messages, time.Since(start), err)
 	if err != nil {
-		return fmt.Errorf("failed to marshal event: %w", err)
+		var writeErrs kafka.WriteErrors
+		if errors.As(err, &writeErrs) {
+			return fmt.Errorf("failed to write %d of %d messages to topic %s: %w", writeErrs.Count(), len(messages), topic, err)
+		}
+		return fmt.Errorf("failed to write message to topic %s: %w", topic, err)
 	}
 
-	// Create Kafka message
-	message := kafka.Message{
-		Topic:     topic,
-		Value:     eventBytes,
-		Time:      time.Now(),
-		Headers: []kafka.Header{
-			{Key: "content-type", Value: []byte("application/json")},
-		},
+	if len(batch) == 1 {
+		log.Printf("Published event %s (%s) to topic %s", batch[0].envelope.EventID, batch[0].envelope.EventType, topic)
+	} else {
+		log.Printf("Published batch of %d events to topic %s", len(batch), topic)
 	}
+	return nil
+}
 
-	// Add partition key if available (for ordering)
+// partitionKey returns the event's partition key (for ordering), if it has one
+func partitionKey(event interface{}) []byte {
 	if keyProvider, ok := event.(EventKeyProvider); ok {
-		message.Key = []byte(keyProvider.GetPartitionKey())
+		return []byte(keyProvider.GetPartitionKey())
 	}
+	return nil
+}
 
-	// Write message
-	err = writer.WriteMessages(ctx, message)
+// PublishMessage writes a pre-built message to the specified topic as-is.
+// It is used to forward raw payloads, e.g. to and from dead letter topics.
+func (p *KafkaProducer) PublishMessage(ctx context.Context, topic string, message kafka.Message) error {
+	writer, err := p.getWriter(topic)
 	if err != nil {
-		return fmt.Errorf("failed to write message to topic %s: %w", topic, err)
+		return fmt.Errorf("failed to get writer for topic %s: %w", topic, err)
 	}
 
-	log.Printf("Published event to topic %s: %s", topic, string(eventBytes))
+	// The writer owns the topic; kafka-go rejects messages that also set one
+	message.Topic = ""
+	message.Partition = 0
+	message.Offset = 0
+	message.Time = time.Now()
+
+	start := time.Now()
+	err = p.breaker.Do(func() error {
+		return writer.WriteMessages(ctx, message)
+	}, nil)
+	recordPublish(topic, []kafka.Message{message}, time.Since(start), err)
+	if err != nil {
+		return fmt.Errorf("failed to write message to topic %s: %w", topic, err)
+	}
 	return nil
 }
 
@@ -72,6 +192,9 @@ func (p *KafkaProducer) Subscribe(ctx context.Context, topic string, handler eve
 
 // getWriter returns or creates a writer for the specified topic
 func (p *KafkaProducer) getWriter(topic string) (*kafka.Writer, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
 	if writer, exists := p.writers[topic]; exists {
 		return writer, nil
 	}
@@ -93,13 +216,14 @@ func (p *KafkaProducer) getWriter(topic string) (*kafka.Writer, error) {
 	writer := &kafka.Writer{
 		Addr:         kafka.TCP(p.config.Brokers...),
 		Topic:        topic,
-		Balancer:     &kafka.LeastBytes{}, // Balance messages across partitions
+		Balancer:     &kafka.Hash{}, // Same key, same partition; keyless messages round-robin
 		RequiredAcks: kafka.RequiredAcks(p.config.ProducerConfig.RequiredAcks),
 		BatchSize:    p.config.ProducerConfig.FlushMessages,
 		BatchTimeout: p.config.ProducerConfig.FlushFrequency,
 		ReadTimeout:  p.config.ProducerConfig.FlushTimeout,
 		WriteTimeout: p.config.ProducerConfig.FlushTimeout,
 		Compression:  compressionCodec,
+		Transport:    p.transport,
 		Logger:       kafka.LoggerFunc(log.Printf),
 		ErrorLogger:  kafka.LoggerFunc(log.Printf),
 	}
@@ -113,8 +237,33 @@ func (p *KafkaProducer) getWriter(topic string) (*kafka.Writer, error) {
 	return writer, nil
 }
 
+// reconnect replaces every writer and drops the transport's idle
+// connections, so the next writes fetch fresh metadata and dial the brokers
+// that currently lead the partitions. The old writers are closed in the
+// background once their pending writes finish.
+func (p *KafkaProducer) reconnect() {
+	p.mu.Lock()
+	writers := p.writers
+	p.writers = make(map[string]*kafka.Writer)
+	p.mu.Unlock()
+
+	if p.transport != nil {
+		p.transport.CloseIdleConnections()
+	}
+	go func() {
+		for topic, writer := range writers {
+			if err := writer.Close(); err != nil {
+				log.Printf("Error closing replaced writer for topic %s: %v", topic, err)
+			}
+		}
+	}()
+}
+
 // Close closes all writers
 func (p *KafkaProducer) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
 	var lastErr error
 	for topic, writer := range p.writers {
 		if err := writer.Close(); err != nil {
@@ -125,25 +274,16 @@ func (p *KafkaProducer) Close() error {
 	return lastErr
 }
 
-// EventKeyProvider interface for events that need custom partitioning
+// EventKeyProvider is implemented by events that choose their partition
+// key; events with the same key are written to the same partition, in order.
+// The event types in internal/events/types all implement it.
 type EventKeyProvider interface {
 	GetPartitionKey() string
 }
 
-// Ensure team events implement EventKeyProvider for proper partitioning
-func (e *BaseTeamEvent) GetPartitionKey() string {
-	return e.TeamID.String()
-}
-
-func (e *BaseAssetEvent) GetPartitionKey() string {
-	return e.AssetID.String()
-}
-
-// Add the event types import here (normally this would be in a separate file)
-type BaseTeamEvent struct {
-	TeamID string `json:"teamId"`
-}
-
-type BaseAssetEvent struct {
-	AssetID string `json:"assetId"`
-}
\ No newline at end of file
+// Every published event type carries a partition key
+var (
+	_ EventKeyProvider = types.BaseAssetEvent{}
+	_ EventKeyProvider = types.BaseTeamEvent{}
+	_ EventKeyProvider = types.UserChangedEvent{}
+)
@@ -0,0 +1,115 @@
+package kafka
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"math/rand"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RetryPolicy decides how often and how quickly a failed message is retried
+// before it is dead-lettered
+type RetryPolicy struct {
+	MaxAttempts int           // Including the first attempt
+	Backoff     time.Duration // Wait before the first retry
+	Multiplier  float64       // Growth of the wait per retry; 1 keeps it constant
+	MaxBackoff  time.Duration // Zero leaves the wait uncapped
+	Jitter      float64       // Random spread of each wait, as a fraction of it (0 to 1)
+}
+
+// attempts returns the number of attempts, at least one
+func (p RetryPolicy) attempts() int {
+	if p.MaxAttempts < 1 {
+		return 1
+	}
+	return p.MaxAttempts
+}
+
+// Delay returns the wait before the given retry, counting from 1. Jitter
+// spreads retries of messages that failed together, e.g. during an outage of
+// a dependency, so they do not all hit it again at the same moment.
+func (p RetryPolicy) Delay(retry int) time.Duration {
+	multiplier := p.Multiplier
+	if multiplier <= 0 {
+		multiplier = 1
+	}
+	delay := float64(p.Backoff) * math.Pow(multiplier, float64(retry-1))
+	if p.MaxBackoff > 0 && delay > float64(p.MaxBackoff) {
+		delay = float64(p.MaxBackoff)
+	}
+
+	if jitter := math.Min(math.Max(p.Jitter, 0), 1); jitter > 0 {
+		delay *= 1 + jitter*(2*rand.Float64()-1)
+	}
+	return time.Duration(delay)
+}
+
+// RetryPolicyFor returns the retry policy of the topic
+func (c ConsumerConfig) RetryPolicyFor(topic string) RetryPolicy {
+	if policy, ok := c.TopicRetry[topic]; ok {
+		return policy
+	}
+	return c.Retry
+}
+
+// ParseTopicRetryPolicies parses per-topic overrides of the base policy, in
+// the form "topic=key:value;key:value,topic=...". The keys are max_attempts,
+// backoff, multiplier, max_backoff and jitter; unset keys keep the base value.
+// Malformed entries are logged and skipped.
+func ParseTopicRetryPolicies(spec string, base RetryPolicy) map[string]RetryPolicy {
+	policies := make(map[string]RetryPolicy)
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		topic, settings, ok := strings.Cut(entry, "=")
+		topic = strings.TrimSpace(topic)
+		if !ok || topic == "" {
+			log.Printf("Ignoring malformed retry policy %q", entry)
+			continue
+		}
+
+		policy, err := parseRetryPolicy(settings, base)
+		if err != nil {
+			log.Printf("Ignoring retry policy of topic %s: %v", topic, err)
+			continue
+		}
+		policies[topic] = policy
+	}
+	return policies
+}
+
+func parseRetryPolicy(settings string, policy RetryPolicy) (RetryPolicy, error) {
+	for _, setting := range strings.Split(settings, ";") {
+		key, value, ok := strings.Cut(setting, ":")
+		if !ok {
+			return policy, fmt.Errorf("malformed setting %q", setting)
+		}
+		value = strings.TrimSpace(value)
+
+		var err error
+		switch strings.TrimSpace(key) {
+		case "max_attempts":
+			policy.MaxAttempts, err = strconv.Atoi(value)
+		case "backoff":
+			policy.Backoff, err = time.ParseDuration(value)
+		case "multiplier":
+			policy.Multiplier, err = strconv.ParseFloat(value, 64)
+		case "max_backoff":
+			policy.MaxBackoff, err = time.ParseDuration(value)
+		case "jitter":
+			policy.Jitter, err = strconv.ParseFloat(value, 64)
+		default:
+			return policy, fmt.Errorf("unknown setting %q", key)
+		}
+		if err != nil {
+			return policy, fmt.Errorf("invalid %s: %w", strings.TrimSpace(key), err)
+		}
+	}
+	return policy, nil
+}
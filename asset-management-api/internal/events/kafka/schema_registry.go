@@ -0,0 +1,138 @@
+package kafka
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/hamba/avro/v2"
+)
+
+const schemaRegistryContentType = "application/vnd.schemaregistry.v1+json"
+
+// schemaRegistryClient is a minimal Confluent Schema Registry client. Schema
+// IDs and parsed schemas are immutable in the registry, so both are cached
+// for the lifetime of the process.
+type schemaRegistryClient struct {
+	baseURL      string
+	username     string
+	password     string
+	autoRegister bool
+	httpClient   *http.Client
+
+	mu        sync.RWMutex
+	subjectID map[string]int
+	byID      map[int]avro.Schema
+}
+
+func newSchemaRegistryClient(config SchemaRegistryConfig) (*schemaRegistryClient, error) {
+	if config.URL == "" {
+		return nil, fmt.Errorf("schema registry URL is required")
+	}
+	return &schemaRegistryClient{
+		baseURL:      strings.TrimRight(config.URL, "/"),
+		username:     config.Username,
+		password:     config.Password,
+		autoRegister: config.AutoRegister,
+		httpClient:   &http.Client{Timeout: config.Timeout},
+		subjectID:    make(map[string]int),
+		byID:         make(map[int]avro.Schema),
+	}, nil
+}
+
+// SchemaID returns the registry ID of a schema under the subject. The schema
+// is registered first when auto-registration is enabled; otherwise it must
+// already exist.
+func (c *schemaRegistryClient) SchemaID(ctx context.Context, subject, schema string) (int, error) {
+	c.mu.RLock()
+	id, ok := c.subjectID[subject]
+	c.mu.RUnlock()
+	if ok {
+		return id, nil
+	}
+
+	path := "/subjects/" + url.PathEscape(subject)
+	if c.autoRegister {
+		path += "/versions"
+	}
+
+	var response struct {
+		ID int `json:"id"`
+	}
+	if err := c.do(ctx, http.MethodPost, path, map[string]string{"schema": schema}, &response); err != nil {
+		return 0, fmt.Errorf("failed to resolve schema for subject %s: %w", subject, err)
+	}
+
+	c.mu.Lock()
+	c.subjectID[subject] = response.ID
+	c.mu.Unlock()
+	return response.ID, nil
+}
+
+// SchemaByID returns the parsed schema with the given registry ID
+func (c *schemaRegistryClient) SchemaByID(ctx context.Context, id int) (avro.Schema, error) {
+	c.mu.RLock()
+	schema, ok := c.byID[id]
+	c.mu.RUnlock()
+	if ok {
+		return schema, nil
+	}
+
+	var response struct {
+		Schema string `json:"schema"`
+	}
+	if err := c.do(ctx, http.MethodGet, fmt.Sprintf("/schemas/ids/%d", id), nil, &response); err != nil {
+		return nil, fmt.Errorf("failed to fetch schema %d: %w", id, err)
+	}
+
+	schema, err := avro.Parse(response.Schema)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse schema %d: %w", id, err)
+	}
+
+	c.mu.Lock()
+	c.byID[id] = schema
+	c.mu.Unlock()
+	return schema, nil
+}
+
+func (c *schemaRegistryClient) do(ctx context.Context, method, path string, body, dest interface{}) error {
+	var reader io.Reader
+	if body != nil {
+		payload, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, reader)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", schemaRegistryContentType)
+	if body != nil {
+		req.Header.Set("Content-Type", schemaRegistryContentType)
+	}
+	if c.username != "" {
+		req.SetBasicAuth(c.username, c.password)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("schema registry returned %s: %s", resp.Status, strings.TrimSpace(string(message)))
+	}
+	return json.NewDecoder(resp.Body).Decode(dest)
+}
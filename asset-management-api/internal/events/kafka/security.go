@@ -0,0 +1,115 @@
+package kafka
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+	"github.com/segmentio/kafka-go/sasl"
+	"github.com/segmentio/kafka-go/sasl/plain"
+	"github.com/segmentio/kafka-go/sasl/scram"
+)
+
+// Supported SASL mechanisms
+const (
+	SASLMechanismPlain       = "PLAIN"
+	SASLMechanismScramSHA256 = "SCRAM-SHA-256"
+	SASLMechanismScramSHA512 = "SCRAM-SHA-512"
+)
+
+// dialTimeout bounds connection setup, including the TLS and SASL handshakes
+const dialTimeout = 10 * time.Second
+
+// BuildTLSConfig returns the client TLS configuration, or nil when TLS is disabled.
+// Setting a client certificate and key enables mutual TLS.
+func (c *KafkaConfig) BuildTLSConfig() (*tls.Config, error) {
+	if !c.TLS.Enabled {
+		return nil, nil
+	}
+
+	tlsConfig := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		ServerName:         c.TLS.ServerName,
+		InsecureSkipVerify: c.TLS.InsecureSkipVerify,
+	}
+
+	if c.TLS.CAFile != "" {
+		caCert, err := os.ReadFile(c.TLS.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read Kafka CA file: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caCert) {
+			return nil, fmt.Errorf("no valid certificates in Kafka CA file %s", c.TLS.CAFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if c.TLS.CertFile != "" || c.TLS.KeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(c.TLS.CertFile, c.TLS.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load Kafka client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	return tlsConfig, nil
+}
+
+// SASLMechanism returns the configured SASL mechanism, or nil when SASL is disabled
+func (c *KafkaConfig) SASLMechanism() (sasl.Mechanism, error) {
+	mechanism := strings.ToUpper(c.SASL.Mechanism)
+	if mechanism == "" {
+		return nil, nil
+	}
+	if c.SASL.Username == "" {
+		return nil, fmt.Errorf("SASL mechanism %s requires a username", mechanism)
+	}
+
+	switch mechanism {
+	case SASLMechanismPlain:
+		return plain.Mechanism{Username: c.SASL.Username, Password: c.SASL.Password}, nil
+	case SASLMechanismScramSHA256:
+		return scram.Mechanism(scram.SHA256, c.SASL.Username, c.SASL.Password)
+	case SASLMechanismScramSHA512:
+		return scram.Mechanism(scram.SHA512, c.SASL.Username, c.SASL.Password)
+	default:
+		return nil, fmt.Errorf("unsupported SASL mechanism %q", c.SASL.Mechanism)
+	}
+}
+
+// connections holds the transport used by writers and the dialer used by
+// readers, both carrying the same TLS and SASL settings
+type connections struct {
+	transport *kafka.Transport
+	dialer    *kafka.Dialer
+}
+
+func newConnections(config *KafkaConfig) (*connections, error) {
+	tlsConfig, err := config.BuildTLSConfig()
+	if err != nil {
+		return nil, err
+	}
+	mechanism, err := config.SASLMechanism()
+	if err != nil {
+		return nil, err
+	}
+
+	return &connections{
+		transport: &kafka.Transport{
+			DialTimeout: dialTimeout,
+			TLS:         tlsConfig,
+			SASL:        mechanism,
+		},
+		dialer: &kafka.Dialer{
+			Timeout:       dialTimeout,
+			DualStack:     true,
+			TLS:           tlsConfig,
+			SASLMechanism: mechanism,
+		},
+	}, nil
+}
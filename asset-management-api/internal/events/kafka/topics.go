@@ -0,0 +1,105 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+
+	"asset-management-api/pkg/eventbus"
+
+	"github.com/segmentio/kafka-go"
+)
+
+// ProvisionTopics creates the configured topics that do not exist yet, plus
+// their dead letter topics when dead-lettering is enabled. Existing topics
+// are left as they are, so only a fresh environment needs a principal
+// allowed to create topics; missing permissions are logged, not returned.
+func (b *KafkaEventBus) ProvisionTopics(ctx context.Context) error {
+	config := b.config.Topics
+	names := append([]string(nil), config.Names...)
+	if b.config.ConsumerConfig.DeadLetterEnabled {
+		for _, name := range config.Names {
+			names = append(names, eventbus.DeadLetterTopic(name))
+		}
+	}
+	if len(names) == 0 {
+		return nil
+	}
+
+	client := &kafka.Client{
+		Addr:      kafka.TCP(b.config.Brokers...),
+		Transport: b.connections.transport,
+	}
+
+	metadata, err := client.Metadata(ctx, &kafka.MetadataRequest{Topics: names})
+	if err != nil {
+		return fmt.Errorf("failed to describe topics: %w", err)
+	}
+	described := make(map[string]kafka.Topic, len(metadata.Topics))
+	for _, topic := range metadata.Topics {
+		described[topic.Name] = topic
+	}
+
+	var missing []kafka.TopicConfig
+	for _, name := range names {
+		topic, ok := described[name]
+		switch {
+		case !ok || errors.Is(topic.Error, kafka.UnknownTopicOrPartition):
+			missing = append(missing, config.topicConfig(name))
+		case errors.Is(topic.Error, kafka.TopicAuthorizationFailed):
+			log.Printf("Not authorized to describe Kafka topic %s, skipping provisioning", name)
+		case topic.Error != nil:
+			log.Printf("Failed to describe Kafka topic %s: %v", name, topic.Error)
+		case len(topic.Partitions) < config.PartitionsFor(name):
+			// Partitions are never added automatically: that would remap keys
+			log.Printf("Kafka topic %s has %d partitions, %d configured; leaving it unchanged",
+				name, len(topic.Partitions), config.PartitionsFor(name))
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+
+	response, err := client.CreateTopics(ctx, &kafka.CreateTopicsRequest{Topics: missing})
+	if err != nil {
+		return fmt.Errorf("failed to create topics: %w", err)
+	}
+
+	var errs []error
+	for _, topic := range missing {
+		err := response.Errors[topic.Topic]
+		switch {
+		case err == nil:
+			log.Printf("Created Kafka topic %s (partitions: %d, replication factor: %d)",
+				topic.Topic, topic.NumPartitions, topic.ReplicationFactor)
+		case errors.Is(err, kafka.TopicAlreadyExists):
+			// Created concurrently by another instance
+		case errors.Is(err, kafka.TopicAuthorizationFailed), errors.Is(err, kafka.ClusterAuthorizationFailed):
+			log.Printf("Not authorized to create Kafka topic %s; create it with an admin account", topic.Topic)
+		default:
+			errs = append(errs, fmt.Errorf("failed to create topic %s: %w", topic.Topic, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// topicConfig returns the settings a topic is created with
+func (c TopicsConfig) topicConfig(name string) kafka.TopicConfig {
+	topic := kafka.TopicConfig{
+		Topic:             name,
+		NumPartitions:     c.PartitionsFor(name),
+		ReplicationFactor: c.ReplicationFactor,
+	}
+	if topic.ReplicationFactor <= 0 {
+		topic.ReplicationFactor = -1 // Broker default
+	}
+	if c.Retention > 0 {
+		topic.ConfigEntries = append(topic.ConfigEntries, kafka.ConfigEntry{
+			ConfigName:  "retention.ms",
+			ConfigValue: strconv.FormatInt(c.Retention.Milliseconds(), 10),
+		})
+	}
+	return topic
+}
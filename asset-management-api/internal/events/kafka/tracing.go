@@ -0,0 +1,92 @@
+package kafka
+
+import (
+	"context"
+
+	"asset-management-api/internal/tracing"
+
+	"github.com/segmentio/kafka-go"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// traceHeaders returns the W3C trace context headers of a message published
+// within the span, and the ID of the request that published it
+func traceHeaders(sc tracing.SpanContext, requestID string) []kafka.Header {
+	var headers []kafka.Header
+	if sc.IsValid() {
+		headers = append(headers, kafka.Header{Key: tracing.TraceparentHeader, Value: []byte(sc.Traceparent())})
+		if sc.Tracestate != "" {
+			headers = append(headers, kafka.Header{Key: tracing.TracestateHeader, Value: []byte(sc.Tracestate)})
+		}
+	}
+	if requestID != "" {
+		headers = append(headers, kafka.Header{Key: tracing.RequestIDHeader, Value: []byte(requestID)})
+	}
+	return headers
+}
+
+// messageTrace returns the span that published the message, if it carries one
+func messageTrace(message kafka.Message) (tracing.SpanContext, bool) {
+	var traceparent, tracestate string
+	for _, header := range message.Headers {
+		switch header.Key {
+		case tracing.TraceparentHeader:
+			traceparent = string(header.Value)
+		case tracing.TracestateHeader:
+			tracestate = string(header.Value)
+		}
+	}
+	return tracing.Parse(traceparent, tracestate)
+}
+
+// startProducerSpan starts the span of publishing events to a topic. The
+// messages carry it as their parent.
+func startProducerSpan(ctx context.Context, topic string, count int) (context.Context, trace.Span) {
+	return tracing.StartSpan(ctx, topic+" publish",
+		trace.WithSpanKind(trace.SpanKindProducer),
+		trace.WithAttributes(
+			semconv.MessagingSystem("kafka"),
+			semconv.MessagingDestinationName(topic),
+			semconv.MessagingOperationPublish,
+			semconv.MessagingBatchMessageCount(count),
+		))
+}
+
+// startConsumerSpan starts the span handling a message: a child of the span
+// that published it, or the root of a new trace when the message carries none
+func startConsumerSpan(ctx context.Context, topic string, message kafka.Message) (context.Context, trace.Span) {
+	if parent, ok := messageTrace(message); ok {
+		ctx = tracing.ContextWith(ctx, parent)
+	}
+	return tracing.StartSpan(ctx, topic+" process",
+		trace.WithSpanKind(trace.SpanKindConsumer),
+		trace.WithAttributes(
+			semconv.MessagingSystem("kafka"),
+			semconv.MessagingDestinationName(topic),
+			semconv.MessagingOperationProcess,
+			semconv.MessagingKafkaDestinationPartition(message.Partition),
+			semconv.MessagingKafkaMessageOffset(int(message.Offset)),
+		))
+}
+
+// endSpan ends a producer or consumer span, recording the error it failed with
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// messageRequestID returns the ID of the request that published the message,
+// or an empty string for messages published outside a request
+func messageRequestID(message kafka.Message) string {
+	for _, header := range message.Headers {
+		if header.Key == tracing.RequestIDHeader && tracing.ValidRequestID(string(header.Value)) {
+			return string(header.Value)
+		}
+	}
+	return ""
+}
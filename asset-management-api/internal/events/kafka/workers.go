@@ -0,0 +1,184 @@
+package kafka
+
+import (
+	"hash/fnv"
+	"log"
+	"sort"
+	"sync"
+
+	"asset-management-api/pkg/eventbus"
+
+	"github.com/segmentio/kafka-go"
+)
+
+// workerPool handles the messages of one topic on several goroutines. A
+// message goes to the worker picked by its key (or, without a key, its
+// partition), so messages for the same asset or team are still handled in
+// order while unrelated ones run in parallel.
+type workerPool struct {
+	consumer     *KafkaConsumer
+	topic        string
+	reader       *kafka.Reader
+	handler      eventbus.EventHandler
+	manualCommit bool
+
+	workers []chan kafka.Message
+	wg      sync.WaitGroup
+	offsets *offsetTracker
+}
+
+func newWorkerPool(c *KafkaConsumer, topic string, reader *kafka.Reader, handler eventbus.EventHandler, manualCommit bool, size int) *workerPool {
+	if size < 1 {
+		size = 1
+	}
+
+	p := &workerPool{
+		consumer:     c,
+		topic:        topic,
+		reader:       reader,
+		handler:      handler,
+		manualCommit: manualCommit,
+		workers:      make([]chan kafka.Message, size),
+		offsets:      newOffsetTracker(),
+	}
+	for i := range p.workers {
+		// Unbuffered, so fetching stops while every worker is busy
+		p.workers[i] = make(chan kafka.Message)
+		p.wg.Add(1)
+		go p.work(p.workers[i])
+	}
+	return p
+}
+
+// dispatch hands a message to its worker, blocking until the worker is free
+// or the consumer is closed. It reports whether the message was dispatched.
+func (p *workerPool) dispatch(message kafka.Message) bool {
+	if p.manualCommit {
+		p.offsets.fetched(message.Partition, message.Offset)
+	}
+
+	select {
+	case p.workers[p.workerFor(message)] <- message:
+		return true
+	case <-p.consumer.ctx.Done():
+		return false
+	}
+}
+
+func (p *workerPool) workerFor(message kafka.Message) int {
+	if len(p.workers) == 1 {
+		return 0
+	}
+	h := fnv.New32a()
+	if len(message.Key) > 0 {
+		h.Write(message.Key)
+	} else {
+		h.Write([]byte{byte(message.Partition >> 24), byte(message.Partition >> 16), byte(message.Partition >> 8), byte(message.Partition)})
+	}
+	return int(h.Sum32() % uint32(len(p.workers)))
+}
+
+func (p *workerPool) work(messages chan kafka.Message) {
+	defer p.wg.Done()
+
+	for message := range messages {
+		settled, err := p.consumer.processMessage(p.topic, message, p.handler)
+		if err != nil {
+			log.Printf("Error processing message from topic %s: %v", p.topic, err)
+		}
+
+		if p.manualCommit {
+			if !settled && p.consumer.ctx.Err() != nil {
+				// Cut short by shutdown; blocks later commits on the
+				// partition so everything from here on is redelivered
+				continue
+			}
+			if !settled {
+				// Committed past once later offsets on the partition complete;
+				// only a crash before then redelivers it
+				log.Printf("Offset %d of topic %s partition %d was neither handled nor dead-lettered",
+					message.Offset, p.topic, message.Partition)
+			}
+			p.commitCompleted(message)
+		}
+	}
+}
+
+// commitCompleted marks the message done and commits the partition up to the
+// last offset whose predecessors are all done. The tracker lock is held while
+// committing so commits on a partition never go backwards.
+func (p *workerPool) commitCompleted(message kafka.Message) {
+	p.offsets.mu.Lock()
+	defer p.offsets.mu.Unlock()
+
+	offset, ok := p.offsets.complete(message.Partition, message.Offset)
+	if !ok {
+		return
+	}
+	p.consumer.commitMessage(p.topic, p.reader, kafka.Message{
+		Topic:     p.topic,
+		Partition: message.Partition,
+		Offset:    offset,
+	})
+}
+
+// stop waits for the workers to finish the messages already dispatched
+func (p *workerPool) stop() {
+	for _, worker := range p.workers {
+		close(worker)
+	}
+	p.wg.Wait()
+}
+
+// offsetTracker tracks fetched offsets per partition so that, with messages
+// completing out of order, only contiguous completed offsets are committed
+type offsetTracker struct {
+	mu         sync.Mutex
+	partitions map[int]*partitionOffsets
+}
+
+type partitionOffsets struct {
+	pending []int64 // Fetched offsets in ascending order, not yet committed
+	done    map[int64]bool
+}
+
+func newOffsetTracker() *offsetTracker {
+	return &offsetTracker{partitions: make(map[int]*partitionOffsets)}
+}
+
+func (t *offsetTracker) fetched(partition int, offset int64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	offsets, ok := t.partitions[partition]
+	if !ok {
+		offsets = &partitionOffsets{done: make(map[int64]bool)}
+		t.partitions[partition] = offsets
+	}
+	// Fetches are in order except after a rebalance rewinds the partition
+	i := sort.Search(len(offsets.pending), func(i int) bool { return offsets.pending[i] >= offset })
+	if i < len(offsets.pending) && offsets.pending[i] == offset {
+		return
+	}
+	offsets.pending = append(offsets.pending, 0)
+	copy(offsets.pending[i+1:], offsets.pending[i:])
+	offsets.pending[i] = offset
+}
+
+// complete marks an offset done and returns the highest offset that can now
+// be committed, if any. The caller must hold t.mu.
+func (t *offsetTracker) complete(partition int, offset int64) (int64, bool) {
+	offsets, ok := t.partitions[partition]
+	if !ok {
+		return 0, false
+	}
+	offsets.done[offset] = true
+
+	committable, found := int64(0), false
+	for len(offsets.pending) > 0 && offsets.done[offsets.pending[0]] {
+		committable, found = offsets.pending[0], true
+		delete(offsets.done, offsets.pending[0])
+		offsets.pending = offsets.pending[1:]
+	}
+	return committable, found
+}
@@ -0,0 +1,233 @@
+package memory
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"asset-management-api/internal/events/types"
+	"asset-management-api/internal/tracing"
+	"asset-management-api/pkg/eventbus"
+)
+
+// handlerTimeout bounds a single handler invocation, matching the Kafka consumer
+const handlerTimeout = 30 * time.Second
+
+// InMemoryEventBus implements EventBus within the process, for local
+// development and tests without Kafka. Events are wrapped in the same
+// envelope as on Kafka, so subscribers see identical payloads. With a buffer
+// size of zero Publish runs the subscribers synchronously; otherwise each
+// topic gets a queue drained in order by its own goroutine.
+type InMemoryEventBus struct {
+	producer   string
+	bufferSize int
+
+	// mu guards the queues and closed flag; it is held while queueing so
+	// Close never closes a queue mid-send. Handlers have their own lock so the
+	// drain goroutines never wait on mu.
+	mu     sync.RWMutex
+	queues map[string]chan queuedEvent
+	closed bool
+	wg     sync.WaitGroup
+
+	handlersMu sync.RWMutex
+	handlers   map[string][]eventbus.EventHandler
+}
+
+// NewInMemoryEventBus creates a new in-memory event bus
+func NewInMemoryEventBus(producer string, bufferSize int) *InMemoryEventBus {
+	return &InMemoryEventBus{
+		producer:   producer,
+		bufferSize: bufferSize,
+		handlers:   make(map[string][]eventbus.EventHandler),
+		queues:     make(map[string]chan queuedEvent),
+	}
+}
+
+// queuedEvent is an encoded event with the span and request it was published in
+type queuedEvent struct {
+	data      []byte
+	span      tracing.SpanContext
+	requestID string
+}
+
+// Publish delivers an event to the subscribers of the topic
+func (b *InMemoryEventBus) Publish(ctx context.Context, topic string, event interface{}) error {
+	data, err := b.encode(ctx, event)
+	if err != nil {
+		return err
+	}
+	return b.deliver(ctx, topic, data)
+}
+
+// PublishBatch delivers events to the subscribers of the topic in order.
+// Every event is encoded before the first is delivered.
+func (b *InMemoryEventBus) PublishBatch(ctx context.Context, topic string, events []interface{}) error {
+	batch := make([][]byte, 0, len(events))
+	for _, event := range events {
+		data, err := b.encode(ctx, event)
+		if err != nil {
+			return err
+		}
+		batch = append(batch, data)
+	}
+
+	for _, data := range batch {
+		if err := b.deliver(ctx, topic, data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// encode wraps an event in an envelope, as on Kafka
+func (b *InMemoryEventBus) encode(ctx context.Context, event interface{}) ([]byte, error) {
+	envelope, err := types.NewEnvelope(ctx, b.producer, event)
+	if err != nil {
+		return nil, err
+	}
+	data, err := json.Marshal(envelope)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal event: %w", err)
+	}
+	return data, nil
+}
+
+// deliver runs the subscribers or queues the event for them
+func (b *InMemoryEventBus) deliver(ctx context.Context, topic string, data []byte) error {
+	if b.bufferSize <= 0 {
+		b.mu.RLock()
+		closed := b.closed
+		b.mu.RUnlock()
+		if closed {
+			return fmt.Errorf("event bus is closed")
+		}
+
+		b.dispatch(topic, b.topicHandlers(topic), newQueuedEvent(ctx, data))
+		return nil
+	}
+
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	if b.closed {
+		return fmt.Errorf("event bus is closed")
+	}
+
+	queue, ok := b.queues[topic]
+	if !ok {
+		return nil // No subscribers
+	}
+	select {
+	case queue <- newQueuedEvent(ctx, data):
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("failed to queue event for topic %s: %w", topic, ctx.Err())
+	}
+}
+
+// Subscribe registers a handler for the topic
+func (b *InMemoryEventBus) Subscribe(ctx context.Context, topic string, handler eventbus.EventHandler) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		return fmt.Errorf("event bus is closed")
+	}
+
+	b.handlersMu.Lock()
+	b.handlers[topic] = append(b.handlers[topic], handler)
+	b.handlersMu.Unlock()
+
+	if _, ok := b.queues[topic]; !ok && b.bufferSize > 0 {
+		queue := make(chan queuedEvent, b.bufferSize)
+		b.queues[topic] = queue
+		b.wg.Add(1)
+		go b.drain(topic, queue)
+	}
+
+	log.Printf("Subscribed to in-memory topic: %s", topic)
+	return nil
+}
+
+// drain dispatches queued events of a topic until the queue is closed
+func (b *InMemoryEventBus) drain(topic string, queue chan queuedEvent) {
+	defer b.wg.Done()
+
+	for event := range queue {
+		b.dispatch(topic, b.topicHandlers(topic), event)
+	}
+}
+
+func (b *InMemoryEventBus) topicHandlers(topic string) []eventbus.EventHandler {
+	b.handlersMu.RLock()
+	defer b.handlersMu.RUnlock()
+	return b.handlers[topic]
+}
+
+// newQueuedEvent pairs the event with the publisher's span and request ID
+func newQueuedEvent(ctx context.Context, data []byte) queuedEvent {
+	span, _ := tracing.FromContext(ctx)
+	return queuedEvent{data: data, span: span, requestID: tracing.RequestID(ctx)}
+}
+
+// dispatch runs every handler once, in a child span of the publisher's;
+// failures are logged, not retried
+func (b *InMemoryEventBus) dispatch(topic string, handlers []eventbus.EventHandler, event queuedEvent) {
+	span := tracing.New()
+	if event.span.IsValid() {
+		span = event.span.NewChild()
+	}
+	handlerCtx := tracing.ContextWith(context.Background(), span)
+	if event.requestID != "" {
+		handlerCtx = tracing.ContextWithRequestID(handlerCtx, event.requestID)
+	}
+
+	for _, handler := range handlers {
+		ctx, cancel := context.WithTimeout(handlerCtx, handlerTimeout)
+		err := handler(ctx, event.data)
+		cancel()
+
+		if err != nil {
+			log.Printf("In-memory handler failed for topic %s: %v", topic, err)
+		}
+	}
+}
+
+// Close stops accepting events and waits for queued events to be handled
+func (b *InMemoryEventBus) Close() error {
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		return nil
+	}
+	b.closed = true
+	for _, queue := range b.queues {
+		close(queue)
+	}
+	b.mu.Unlock()
+
+	b.wg.Wait()
+	return nil
+}
+
+// HealthCheck returns the health status of the event bus
+func (b *InMemoryEventBus) HealthCheck() map[string]interface{} {
+	b.handlersMu.RLock()
+	defer b.handlersMu.RUnlock()
+
+	topics := make([]string, 0, len(b.handlers))
+	for topic := range b.handlers {
+		topics = append(topics, topic)
+	}
+
+	return map[string]interface{}{
+		"status":      "healthy",
+		"type":        "in-memory",
+		"buffer_size": b.bufferSize,
+		"topics":      topics,
+	}
+}
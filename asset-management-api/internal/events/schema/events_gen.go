@@ -0,0 +1,82 @@
+package schema
+
+// Code generated by avro/gen. DO NOT EDIT.
+
+import (
+	"time"
+)
+
+// TeamActivityPayload is a generated struct.
+type TeamActivityPayload struct {
+	EventType    string    `avro:"eventType" json:"eventType"`
+	TeamID       string    `avro:"teamId" json:"teamId"`
+	PerformedBy  string    `avro:"performedBy" json:"performedBy"`
+	Timestamp    time.Time `avro:"timestamp" json:"timestamp"`
+	TeamName     *string   `avro:"teamName" json:"teamName"`
+	Managers     []string  `avro:"managers" json:"managers"`
+	Members      []string  `avro:"members" json:"members"`
+	TargetUserID *string   `avro:"targetUserId" json:"targetUserId"`
+	UserName     *string   `avro:"userName" json:"userName"`
+}
+
+// TeamActivityEvent is a generated struct.
+type TeamActivityEvent struct {
+	SchemaVersion int                 `avro:"schemaVersion" json:"schemaVersion"`
+	EventID       string              `avro:"eventId" json:"eventId"`
+	EventType     string              `avro:"eventType" json:"eventType"`
+	Producer      string              `avro:"producer" json:"producer"`
+	TraceID       string              `avro:"traceId" json:"traceId"`
+	Timestamp     time.Time           `avro:"timestamp" json:"timestamp"`
+	Payload       TeamActivityPayload `avro:"payload" json:"payload"`
+}
+
+// AssetChangePayload is a generated struct.
+type AssetChangePayload struct {
+	EventType          string             `avro:"eventType" json:"eventType"`
+	AssetType          string             `avro:"assetType" json:"assetType"`
+	AssetID            string             `avro:"assetId" json:"assetId"`
+	OwnerID            string             `avro:"ownerId" json:"ownerId"`
+	ActionBy           string             `avro:"actionBy" json:"actionBy"`
+	Timestamp          time.Time          `avro:"timestamp" json:"timestamp"`
+	Name               *string            `avro:"name" json:"name"`
+	Description        *string            `avro:"description" json:"description"`
+	FolderID           *string            `avro:"folderId" json:"folderId"`
+	Changes            []string           `avro:"changes" json:"changes"`
+	SharedWithUserID   *string            `avro:"sharedWithUserId" json:"sharedWithUserId"`
+	AccessLevel        *string            `avro:"accessLevel" json:"accessLevel"`
+	SharedByUserName   *string            `avro:"sharedByUserName" json:"sharedByUserName"`
+	UnsharedFromUserID *string            `avro:"unsharedFromUserId" json:"unsharedFromUserId"`
+	UnsharedByUserName *string            `avro:"unsharedByUserName" json:"unsharedByUserName"`
+	ACL                *map[string]string `avro:"acl" json:"acl"`
+}
+
+// AssetChangeEvent is a generated struct.
+type AssetChangeEvent struct {
+	SchemaVersion int                `avro:"schemaVersion" json:"schemaVersion"`
+	EventID       string             `avro:"eventId" json:"eventId"`
+	EventType     string             `avro:"eventType" json:"eventType"`
+	Producer      string             `avro:"producer" json:"producer"`
+	TraceID       string             `avro:"traceId" json:"traceId"`
+	Timestamp     time.Time          `avro:"timestamp" json:"timestamp"`
+	Payload       AssetChangePayload `avro:"payload" json:"payload"`
+}
+
+// UserChangePayload is a generated struct.
+type UserChangePayload struct {
+	EventType   string    `avro:"eventType" json:"eventType"`
+	UserID      string    `avro:"userId" json:"userId"`
+	PerformedBy string    `avro:"performedBy" json:"performedBy"`
+	Role        *string   `avro:"role" json:"role"`
+	Timestamp   time.Time `avro:"timestamp" json:"timestamp"`
+}
+
+// UserChangeEvent is a generated struct.
+type UserChangeEvent struct {
+	SchemaVersion int               `avro:"schemaVersion" json:"schemaVersion"`
+	EventID       string            `avro:"eventId" json:"eventId"`
+	EventType     string            `avro:"eventType" json:"eventType"`
+	Producer      string            `avro:"producer" json:"producer"`
+	TraceID       string            `avro:"traceId" json:"traceId"`
+	Timestamp     time.Time         `avro:"timestamp" json:"timestamp"`
+	Payload       UserChangePayload `avro:"payload" json:"payload"`
+}
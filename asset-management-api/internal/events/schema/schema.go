@@ -0,0 +1,52 @@
+// Package schema holds the Avro schemas of the published event envelopes and
+// the Go types generated from them.
+package schema
+
+//go:generate go run github.com/hamba/avro/v2/cmd/avrogen@v2.20.0 -pkg schema -tags json:camel -o events_gen.go team_activity.avsc asset_changes.avsc user_changes.avsc
+
+import (
+	_ "embed"
+
+	"asset-management-api/internal/events/types"
+)
+
+var (
+	//go:embed team_activity.avsc
+	teamActivitySchema string
+
+	//go:embed asset_changes.avsc
+	assetChangesSchema string
+
+	//go:embed user_changes.avsc
+	userChangesSchema string
+)
+
+// Definition describes the value schema of a topic
+type Definition struct {
+	// Schema is the Avro schema in JSON form, as registered with the registry
+	Schema string
+
+	// New returns an empty generated record to encode from or decode into
+	New func() interface{}
+}
+
+var definitions = map[string]Definition{
+	types.TeamActivityTopic: {
+		Schema: teamActivitySchema,
+		New:    func() interface{} { return &TeamActivityEvent{} },
+	},
+	types.AssetChangesTopic: {
+		Schema: assetChangesSchema,
+		New:    func() interface{} { return &AssetChangeEvent{} },
+	},
+	types.UserChangesTopic: {
+		Schema: userChangesSchema,
+		New:    func() interface{} { return &UserChangeEvent{} },
+	},
+}
+
+// ForTopic returns the value schema of a topic, if it has one
+func ForTopic(topic string) (Definition, bool) {
+	definition, ok := definitions[topic]
+	return definition, ok
+}
@@ -0,0 +1,68 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"asset-management-api/internal/events/types"
+	"asset-management-api/internal/models"
+	"asset-management-api/internal/repository/interfaces"
+	"asset-management-api/pkg/eventbus"
+)
+
+// UserActivityRecorder appends every user.activity event to the audit store,
+// which is kept apart from the asset event store
+type UserActivityRecorder struct {
+	repo interfaces.UserActivityRepository
+}
+
+// NewUserActivityRecorder creates a new user activity recorder
+func NewUserActivityRecorder(repo interfaces.UserActivityRepository) *UserActivityRecorder {
+	return &UserActivityRecorder{repo: repo}
+}
+
+// HandleActivityEvent records a user activity event. Recording is idempotent,
+// so redelivered events are safe to pass in again.
+func (r *UserActivityRecorder) HandleActivityEvent(ctx context.Context, eventData []byte) error {
+	envelope, err := types.OpenEnvelope(eventData)
+	if err != nil {
+		return err
+	}
+	// Activity events have always been enveloped; without an event ID a
+	// redelivery could not be told apart from a repeated request
+	if envelope.SchemaVersion < types.CurrentSchemaVersion {
+		return eventbus.Permanent(errors.New("user activity event has no envelope"))
+	}
+
+	var event types.UserActivityEvent
+	if err := json.Unmarshal(envelope.Payload, &event); err != nil {
+		return eventbus.Permanent(fmt.Errorf("failed to unmarshal user activity event: %w", err))
+	}
+
+	entry := &models.UserActivityLog{
+		EventID:    envelope.EventID,
+		ActorID:    event.ActorID,
+		ActorRole:  event.ActorRole,
+		Operation:  event.Operation,
+		Method:     event.Method,
+		Endpoint:   event.Endpoint,
+		EntityType: event.EntityType,
+		EntityID:   event.EntityID,
+		Result:     event.Result,
+		HTTPStatus: event.HTTPStatus,
+		ClientIP:   event.ClientIP,
+		Payload:    envelope.Payload,
+		TraceID:    envelope.TraceID,
+		OccurredAt: event.Timestamp,
+	}
+	if entry.OccurredAt.IsZero() {
+		entry.OccurredAt = envelope.Timestamp
+	}
+
+	if err := r.repo.Append(ctx, entry); err != nil {
+		return fmt.Errorf("failed to record user activity %s: %w", event.Operation, err)
+	}
+	return nil
+}
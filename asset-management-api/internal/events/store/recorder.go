@@ -0,0 +1,62 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"asset-management-api/internal/events/types"
+	"asset-management-api/internal/models"
+	"asset-management-api/internal/repository/interfaces"
+	"asset-management-api/pkg/eventbus"
+)
+
+// AssetEventRecorder appends every asset.changes event to the event store,
+// giving assets a durable change history
+type AssetEventRecorder struct {
+	repo interfaces.AssetEventRepository
+}
+
+// NewAssetEventRecorder creates a new asset event recorder
+func NewAssetEventRecorder(repo interfaces.AssetEventRepository) *AssetEventRecorder {
+	return &AssetEventRecorder{repo: repo}
+}
+
+// HandleAssetEvent records an asset event. Recording is idempotent, so
+// redelivered events are safe to pass in again.
+func (r *AssetEventRecorder) HandleAssetEvent(ctx context.Context, eventData []byte) error {
+	envelope, err := types.OpenEnvelope(eventData)
+	if err != nil {
+		return err
+	}
+
+	var event types.BaseAssetEvent
+	if err := json.Unmarshal(envelope.Payload, &event); err != nil {
+		return eventbus.Permanent(fmt.Errorf("failed to unmarshal asset event: %w", err))
+	}
+
+	entry := &models.AssetEventLog{
+		EventType:     event.EventType,
+		AssetType:     event.AssetType,
+		AssetID:       event.AssetID,
+		OwnerID:       event.OwnerID,
+		ActionBy:      event.ActionBy,
+		Payload:       envelope.Payload,
+		SchemaVersion: envelope.SchemaVersion,
+		Producer:      envelope.Producer,
+		TraceID:       envelope.TraceID,
+		OccurredAt:    event.Timestamp,
+	}
+	if envelope.SchemaVersion >= types.CurrentSchemaVersion {
+		eventID := envelope.EventID
+		entry.EventID = &eventID
+	}
+	if entry.OccurredAt.IsZero() {
+		entry.OccurredAt = envelope.Timestamp
+	}
+
+	if err := r.repo.Append(ctx, entry); err != nil {
+		return fmt.Errorf("failed to record asset event %s: %w", event.EventType, err)
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+package types
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// User activity event types
+const (
+	UserActivity = "USER_ACTIVITY"
+)
+
+// Topics
+const (
+	// UserActivityTopic carries the audit trail of user requests. It is kept
+	// apart from the business event topics so it can have its own retention
+	// and access controls.
+	UserActivityTopic = "user.activity"
+)
+
+// Activity results
+const (
+	ActivitySucceeded = "success"
+	ActivityFailed    = "error"
+)
+
+// UserActivityEvent records one mutating request made by an authenticated user
+type UserActivityEvent struct {
+	EventType  string            `json:"eventType"`
+	ActorID    uuid.UUID         `json:"actorId"`
+	ActorRole  string            `json:"actorRole"`
+	Operation  string            `json:"operation"`
+	Method     string            `json:"method"`
+	Endpoint   string            `json:"endpoint"` // The route pattern, e.g. /api/v1/folders/:folderId
+	EntityType string            `json:"entityType,omitempty"`
+	EntityID   string            `json:"entityId,omitempty"`
+	Params     map[string]string `json:"params,omitempty"` // All path parameters, e.g. the target of a share
+	Result     string            `json:"result"`
+	HTTPStatus int               `json:"httpStatus"`
+	ClientIP   string            `json:"clientIp,omitempty"`
+	DurationMs int64             `json:"durationMs"`
+	Timestamp  time.Time         `json:"timestamp"`
+}
+
+// GetPartitionKey keys activity by actor, so a user's requests stay ordered
+func (e UserActivityEvent) GetPartitionKey() string {
+	return e.ActorID.String()
+}
+
+// ActivityResult maps an HTTP status to an activity result
+func ActivityResult(httpStatus int) string {
+	if httpStatus >= 400 {
+		return ActivityFailed
+	}
+	return ActivitySucceeded
+}
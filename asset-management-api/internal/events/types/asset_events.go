@@ -1,6 +1,8 @@
 package types
 
 import (
+	"bytes"
+	"encoding/json"
 	"time"
 	"github.com/google/uuid"
 )
@@ -43,6 +45,11 @@ type BaseAssetEvent struct {
 	Timestamp time.Time `json:"timestamp"`
 }
 
+// GetPartitionKey keys asset events by asset, so changes to one asset stay ordered
+func (e BaseAssetEvent) GetPartitionKey() string {
+	return e.AssetID.String()
+}
+
 // AssetCreatedEvent represents asset creation events
 type AssetCreatedEvent struct {
 	BaseAssetEvent
@@ -68,16 +75,49 @@ type AssetDeletedEvent struct {
 // AssetSharedEvent represents asset sharing events
 type AssetSharedEvent struct {
 	BaseAssetEvent
-	SharedWithUserID uuid.UUID `json:"sharedWithUserId"`
-	AccessLevel      string    `json:"accessLevel"`
-	SharedByUserName string    `json:"sharedByUserName"`
+	SharedWithUserID uuid.UUID   `json:"sharedWithUserId"`
+	AccessLevel      string      `json:"accessLevel"`
+	SharedByUserName string      `json:"sharedByUserName"`
+	ACL              ACLSnapshot `json:"acl,omitempty"`
 }
 
 // AssetUnsharedEvent represents asset unsharing events
 type AssetUnsharedEvent struct {
 	BaseAssetEvent
-	UnsharedFromUserID uuid.UUID `json:"unsharedFromUserId"`
-	UnsharedByUserName string    `json:"unsharedByUserName"`
+	UnsharedFromUserID uuid.UUID   `json:"unsharedFromUserId"`
+	UnsharedByUserName string      `json:"unsharedByUserName"`
+	ACL                ACLSnapshot `json:"acl,omitempty"`
+}
+
+// ACLSnapshot is the full ACL of an asset after a share change, user ID ->
+// access level with the owner included. Consumers that missed earlier share
+// events rebuild the ACL from it instead of applying the delta; it is absent
+// from events published before snapshots were added, or when the ACL could not
+// be loaded.
+type ACLSnapshot map[string]string
+
+// WithoutACLSnapshot returns an asset event payload without its ACL snapshot.
+// The snapshot lists everyone the asset is shared with, which only the owner
+// may see, so it is dropped before events are passed on to users or webhooks.
+func WithoutACLSnapshot(payload json.RawMessage) json.RawMessage {
+	if !bytes.Contains(payload, []byte(`"acl"`)) {
+		return payload
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(payload, &fields); err != nil {
+		return payload
+	}
+	if _, ok := fields["acl"]; !ok {
+		return payload
+	}
+	delete(fields, "acl")
+
+	stripped, err := json.Marshal(fields)
+	if err != nil {
+		return payload
+	}
+	return stripped
 }
 
 // Constructor functions for folder events
@@ -0,0 +1,74 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CloudEventsSpecVersion is the CloudEvents version events are published as
+const CloudEventsSpecVersion = "1.0"
+
+// CloudEvent is an envelope in the CloudEvents 1.0 structured JSON format.
+// The envelope fields without a CloudEvents attribute are carried as
+// extension attributes.
+type CloudEvent struct {
+	SpecVersion     string          `json:"specversion"`
+	ID              string          `json:"id"`
+	Source          string          `json:"source"`
+	Type            string          `json:"type"`
+	Time            time.Time       `json:"time"`
+	DataContentType string          `json:"datacontenttype"`
+	Data            json.RawMessage `json:"data"`
+
+	// Extension attributes
+	SchemaVersion int    `json:"schemaversion"`
+	Producer      string `json:"producer,omitempty"`
+	TraceID       string `json:"traceid,omitempty"`
+}
+
+// NewCloudEvent converts an envelope to a CloudEvent. The event type is
+// prefixed with typePrefix, e.g. "com.example.assets." for reverse-DNS types.
+func NewCloudEvent(envelope *Envelope, source, typePrefix string) *CloudEvent {
+	return &CloudEvent{
+		SpecVersion:     CloudEventsSpecVersion,
+		ID:              envelope.EventID.String(),
+		Source:          source,
+		Type:            typePrefix + envelope.EventType,
+		Time:            envelope.Timestamp,
+		DataContentType: "application/json",
+		Data:            envelope.Payload,
+		SchemaVersion:   envelope.SchemaVersion,
+		Producer:        envelope.Producer,
+		TraceID:         envelope.TraceID,
+	}
+}
+
+// Envelope converts the CloudEvent back to an envelope, removing typePrefix
+// from the event type
+func (e *CloudEvent) Envelope(typePrefix string) (*Envelope, error) {
+	eventID, err := uuid.Parse(e.ID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid CloudEvent id %q: %w", e.ID, err)
+	}
+
+	envelope := &Envelope{
+		SchemaVersion: e.SchemaVersion,
+		EventID:       eventID,
+		EventType:     strings.TrimPrefix(e.Type, typePrefix),
+		Producer:      e.Producer,
+		TraceID:       e.TraceID,
+		Timestamp:     e.Time,
+		Payload:       e.Data,
+	}
+	if envelope.SchemaVersion == 0 {
+		envelope.SchemaVersion = CurrentSchemaVersion // Published by another CloudEvents producer
+	}
+	if envelope.Producer == "" {
+		envelope.Producer = e.Source
+	}
+	return envelope, nil
+}
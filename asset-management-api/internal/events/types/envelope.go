@@ -0,0 +1,110 @@
+package types
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"asset-management-api/pkg/eventbus"
+
+	"github.com/google/uuid"
+)
+
+// Envelope schema versions. Version 1 is the bare event payload published
+// before envelopes existed; it carries no metadata.
+const (
+	LegacySchemaVersion  = 1
+	CurrentSchemaVersion = 2
+)
+
+// Envelope wraps every published event with metadata that lets the payload
+// structs evolve independently of the consumers
+type Envelope struct {
+	SchemaVersion int             `json:"schemaVersion"`
+	EventID       uuid.UUID       `json:"eventId"`
+	EventType     string          `json:"eventType"`
+	Producer      string          `json:"producer"`
+	TraceID       string          `json:"traceId,omitempty"`
+	Timestamp     time.Time       `json:"timestamp"`
+	Payload       json.RawMessage `json:"payload"`
+}
+
+type traceIDKey struct{}
+
+// ContextWithTraceID returns a context carrying the trace ID recorded in envelopes
+func ContextWithTraceID(ctx context.Context, traceID string) context.Context {
+	return context.WithValue(ctx, traceIDKey{}, traceID)
+}
+
+// TraceIDFromContext returns the trace ID stored in the context, if any
+func TraceIDFromContext(ctx context.Context) string {
+	traceID, _ := ctx.Value(traceIDKey{}).(string)
+	return traceID
+}
+
+// NewEnvelope wraps an event payload in a current-version envelope. The event
+// type is taken from the payload's eventType field.
+func NewEnvelope(ctx context.Context, producer string, event interface{}) (*Envelope, error) {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal event payload: %w", err)
+	}
+
+	var base struct {
+		EventType string `json:"eventType"`
+	}
+	// Payloads that are not JSON objects simply have no event type
+	_ = json.Unmarshal(payload, &base)
+
+	return &Envelope{
+		SchemaVersion: CurrentSchemaVersion,
+		EventID:       uuid.New(),
+		EventType:     base.EventType,
+		Producer:      producer,
+		TraceID:       TraceIDFromContext(ctx),
+		Timestamp:     time.Now().UTC(),
+		Payload:       payload,
+	}, nil
+}
+
+// RedactACLSnapshot drops the ACL snapshot from the envelope's payload (see
+// WithoutACLSnapshot) and reports whether there was one
+func (e *Envelope) RedactACLSnapshot() bool {
+	payload := WithoutACLSnapshot(e.Payload)
+	if len(payload) == len(e.Payload) {
+		return false
+	}
+	e.Payload = payload
+	return true
+}
+
+// OpenEnvelope decodes an envelope from a message value. Bare payloads from
+// producers that predate envelopes are accepted and reported as
+// LegacySchemaVersion, with the whole message as the payload. Envelopes newer
+// than CurrentSchemaVersion are decoded as far as the known fields allow.
+// Values that are not JSON are reported as permanent errors.
+func OpenEnvelope(data []byte) (*Envelope, error) {
+	var envelope Envelope
+	if err := json.Unmarshal(data, &envelope); err != nil {
+		return nil, eventbus.Permanent(fmt.Errorf("failed to parse event envelope: %w", err))
+	}
+
+	if envelope.SchemaVersion < CurrentSchemaVersion || len(envelope.Payload) == 0 {
+		var legacy struct {
+			EventType string    `json:"eventType"`
+			Timestamp time.Time `json:"timestamp"`
+		}
+		if err := json.Unmarshal(data, &legacy); err != nil {
+			return nil, eventbus.Permanent(fmt.Errorf("failed to parse legacy event: %w", err))
+		}
+		return &Envelope{
+			SchemaVersion: LegacySchemaVersion,
+			EventType:     legacy.EventType,
+			Timestamp:     legacy.Timestamp,
+			Payload:       json.RawMessage(data),
+		}, nil
+	}
+
+	return &envelope, nil
+}
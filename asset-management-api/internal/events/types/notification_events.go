@@ -0,0 +1,41 @@
+package types
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Notification event types
+const (
+	NotificationCreated = "NOTIFICATION_CREATED"
+)
+
+// Topics
+const (
+	// UserNotificationsTopic announces the notifications recorded for
+	// subscriptions, so that every instance can push them to the connected
+	// user
+	UserNotificationsTopic = "user.notifications"
+)
+
+// NotificationCreatedEvent announces a notification recorded for a user. It
+// identifies the change rather than repeating it; clients read the
+// notification itself from the API.
+type NotificationCreatedEvent struct {
+	EventType       string    `json:"eventType"`
+	NotificationID  uuid.UUID `json:"notificationId"`
+	UserID          uuid.UUID `json:"userId"`
+	SourceEventID   uuid.UUID `json:"sourceEventId"`
+	SourceEventType string    `json:"sourceEventType"`
+	AssetType       string    `json:"assetType"`
+	AssetID         uuid.UUID `json:"assetId"`
+	ActionBy        uuid.UUID `json:"actionBy"`
+	Timestamp       time.Time `json:"timestamp"`
+}
+
+// GetPartitionKey keys notification events by user, so a user's
+// notifications stay ordered
+func (e NotificationCreatedEvent) GetPartitionKey() string {
+	return e.UserID.String()
+}
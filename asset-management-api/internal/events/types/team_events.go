@@ -27,6 +27,11 @@ type BaseTeamEvent struct {
 	Timestamp     time.Time `json:"timestamp"`
 }
 
+// GetPartitionKey keys team events by team, so activity within a team stays ordered
+func (e BaseTeamEvent) GetPartitionKey() string {
+	return e.TeamID.String()
+}
+
 // TeamCreatedEvent represents a team creation event
 type TeamCreatedEvent struct {
 	BaseTeamEvent
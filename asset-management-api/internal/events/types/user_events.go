@@ -0,0 +1,44 @@
+package types
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// User event types
+const (
+	UserUpdated     = "USER_UPDATED"
+	UserRoleChanged = "USER_ROLE_CHANGED"
+	UserDeleted     = "USER_DELETED"
+)
+
+// Topics
+const (
+	UserChangesTopic = "user.changes"
+)
+
+// UserChangedEvent represents a change to a user's profile or role
+type UserChangedEvent struct {
+	EventType   string    `json:"eventType"`
+	UserID      uuid.UUID `json:"userId"`
+	PerformedBy uuid.UUID `json:"performedBy"`
+	Role        string    `json:"role,omitempty"`
+	Timestamp   time.Time `json:"timestamp"`
+}
+
+// GetPartitionKey keys user events by user, so changes to one user stay ordered
+func (e UserChangedEvent) GetPartitionKey() string {
+	return e.UserID.String()
+}
+
+// NewUserChangedEvent creates a new user change event
+func NewUserChangedEvent(eventType string, userID, performedBy uuid.UUID, role string) *UserChangedEvent {
+	return &UserChangedEvent{
+		EventType:   eventType,
+		UserID:      userID,
+		PerformedBy: performedBy,
+		Role:        role,
+		Timestamp:   time.Now().UTC(),
+	}
+}
@@ -0,0 +1,66 @@
+package graph
+
+import (
+	"context"
+
+	"asset-management-api/internal/models"
+
+	"github.com/google/uuid"
+	"github.com/graph-gophers/dataloader/v7"
+)
+
+type contextKey int
+
+const requestKey contextKey = iota
+
+// request is the state of one GraphQL request: its caller, and the loaders
+// that batch the nested fields of all the folders and notes of a query into
+// one service call each
+type request struct {
+	userID        uuid.UUID
+	notesByFolder *dataloader.Loader[uuid.UUID, []*models.Note]
+	folderShares  *dataloader.Loader[uuid.UUID, []*models.FolderShare]
+	noteShares    *dataloader.Loader[uuid.UUID, []*models.NoteShare]
+}
+
+// withRequest starts a request of userID. The loaders cache what they load for
+// the request only, so a request never sees another caller's assets.
+func (r *Resolver) withRequest(ctx context.Context, userID uuid.UUID) context.Context {
+	req := &request{userID: userID}
+	req.notesByFolder = dataloader.NewBatchedLoader(batch(func(ctx context.Context, folderIDs []uuid.UUID) (map[uuid.UUID][]*models.Note, error) {
+		return r.notes.GetNotesByFolders(ctx, folderIDs, userID)
+	}))
+	req.folderShares = dataloader.NewBatchedLoader(batch(func(ctx context.Context, folderIDs []uuid.UUID) (map[uuid.UUID][]*models.FolderShare, error) {
+		return r.shares.GetSharesByFolders(ctx, folderIDs, userID)
+	}))
+	req.noteShares = dataloader.NewBatchedLoader(batch(func(ctx context.Context, noteIDs []uuid.UUID) (map[uuid.UUID][]*models.NoteShare, error) {
+		return r.shares.GetSharesByNotes(ctx, noteIDs, userID)
+	}))
+	return context.WithValue(ctx, requestKey, req)
+}
+
+func requestFrom(ctx context.Context) *request {
+	return ctx.Value(requestKey).(*request)
+}
+
+// userID is the caller of the request
+func userID(ctx context.Context) uuid.UUID {
+	return requestFrom(ctx).userID
+}
+
+// batch makes a batch function of a service call returning the values by key.
+// Keys the service leaves out, which the caller may not read, load as nil.
+func batch[V any](load func(ctx context.Context, keys []uuid.UUID) (map[uuid.UUID]V, error)) dataloader.BatchFunc[uuid.UUID, V] {
+	return func(ctx context.Context, keys []uuid.UUID) []*dataloader.Result[V] {
+		results := make([]*dataloader.Result[V], len(keys))
+		values, err := load(ctx, keys)
+		for i, key := range keys {
+			if err != nil {
+				results[i] = &dataloader.Result[V]{Error: err}
+				continue
+			}
+			results[i] = &dataloader.Result[V]{Data: values[key]}
+		}
+		return results
+	}
+}
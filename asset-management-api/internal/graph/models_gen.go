@@ -0,0 +1,19 @@
+// Code generated by github.com/99designs/gqlgen, DO NOT EDIT.
+
+package graph
+
+import (
+	"asset-management-api/internal/models"
+)
+
+type FolderPage struct {
+	Items []*models.Folder `json:"items"`
+	// Cursor of the next page, null on the last
+	NextCursor *string `json:"nextCursor,omitempty"`
+}
+
+type NotePage struct {
+	Items []*models.Note `json:"items"`
+	// Cursor of the next page, null on the last
+	NextCursor *string `json:"nextCursor,omitempty"`
+}
@@ -0,0 +1,16 @@
+package graph
+
+import (
+	serviceInterfaces "asset-management-api/internal/service/interfaces"
+)
+
+//go:generate go run github.com/99designs/gqlgen generate
+
+// Resolver resolves the schema through the services, which authorize every
+// read against the caller from the context
+type Resolver struct {
+	folders serviceInterfaces.FolderService
+	notes   serviceInterfaces.NoteService
+	shares  serviceInterfaces.ShareService
+	teams   serviceInterfaces.TeamService
+}
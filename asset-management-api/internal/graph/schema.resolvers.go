@@ -0,0 +1,91 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.40
+
+import (
+	"asset-management-api/internal/models"
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// Notes is the resolver for the notes field.
+func (r *folderResolver) Notes(ctx context.Context, obj *models.Folder) ([]*models.Note, error) {
+	return requestFrom(ctx).notesByFolder.Load(ctx, obj.FolderID)()
+}
+
+// Shares is the resolver for the shares field.
+func (r *folderResolver) Shares(ctx context.Context, obj *models.Folder) ([]*models.FolderShare, error) {
+	return requestFrom(ctx).folderShares.Load(ctx, obj.FolderID)()
+}
+
+// Shares is the resolver for the shares field.
+func (r *noteResolver) Shares(ctx context.Context, obj *models.Note) ([]*models.NoteShare, error) {
+	return requestFrom(ctx).noteShares.Load(ctx, obj.NoteID)()
+}
+
+// Folder is the resolver for the folder field.
+func (r *queryResolver) Folder(ctx context.Context, id uuid.UUID) (*models.Folder, error) {
+	return r.folders.GetFolder(ctx, id, userID(ctx))
+}
+
+// Folders is the resolver for the folders field.
+func (r *queryResolver) Folders(ctx context.Context, first *int, after *string) (*FolderPage, error) {
+	req, err := pageRequest(first, after)
+	if err != nil {
+		return nil, err
+	}
+	page, err := r.folders.ListUserFolders(ctx, userID(ctx), req)
+	if err != nil {
+		return nil, err
+	}
+	return &FolderPage{Items: page.Items, NextCursor: nextCursor(page)}, nil
+}
+
+// Note is the resolver for the note field.
+func (r *queryResolver) Note(ctx context.Context, id uuid.UUID) (*models.Note, error) {
+	return r.notes.GetNote(ctx, id, userID(ctx))
+}
+
+// Notes is the resolver for the notes field.
+func (r *queryResolver) Notes(ctx context.Context, folderID *uuid.UUID, first *int, after *string) (*NotePage, error) {
+	req, err := pageRequest(first, after)
+	if err != nil {
+		return nil, err
+	}
+	var page *models.Page[*models.Note]
+	if folderID == nil {
+		page, err = r.notes.ListUserNotes(ctx, userID(ctx), req)
+	} else {
+		page, err = r.notes.ListNotesByFolder(ctx, *folderID, userID(ctx), req)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &NotePage{Items: page.Items, NextCursor: nextCursor(page)}, nil
+}
+
+// Team is the resolver for the team field.
+func (r *queryResolver) Team(ctx context.Context, id uuid.UUID) (*models.Team, error) {
+	return r.teams.GetTeam(ctx, id, userID(ctx))
+}
+
+// Teams is the resolver for the teams field.
+func (r *queryResolver) Teams(ctx context.Context) ([]*models.Team, error) {
+	return r.teams.GetUserTeams(ctx, userID(ctx))
+}
+
+// Folder returns FolderResolver implementation.
+func (r *Resolver) Folder() FolderResolver { return &folderResolver{r} }
+
+// Note returns NoteResolver implementation.
+func (r *Resolver) Note() NoteResolver { return &noteResolver{r} }
+
+// Query returns QueryResolver implementation.
+func (r *Resolver) Query() QueryResolver { return &queryResolver{r} }
+
+type folderResolver struct{ *Resolver }
+type noteResolver struct{ *Resolver }
+type queryResolver struct{ *Resolver }
@@ -0,0 +1,131 @@
+package graph
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"asset-management-api/internal/models"
+	"asset-management-api/internal/service"
+	serviceInterfaces "asset-management-api/internal/service/interfaces"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/99designs/gqlgen/graphql/handler"
+	"github.com/99designs/gqlgen/graphql/handler/extension"
+	"github.com/99designs/gqlgen/graphql/handler/transport"
+	"github.com/google/uuid"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+// Page sizes of the list fields, as in the REST API
+const (
+	defaultPageSize = 50
+	maxPageSize     = 100
+)
+
+// maxComplexity bounds the number of fields a query may select
+const maxComplexity = 1000
+
+// Services are the services the schema resolves through
+type Services struct {
+	Folders serviceInterfaces.FolderService
+	Notes   serviceInterfaces.NoteService
+	Shares  serviceInterfaces.ShareService
+	Teams   serviceInterfaces.TeamService
+}
+
+// Server executes GraphQL requests over HTTP
+type Server struct {
+	resolver *Resolver
+	handler  *handler.Server
+}
+
+// NewServer creates the GraphQL server of services
+func NewServer(services Services) *Server {
+	resolver := &Resolver{
+		folders: services.Folders,
+		notes:   services.Notes,
+		shares:  services.Shares,
+		teams:   services.Teams,
+	}
+
+	srv := handler.New(NewExecutableSchema(Config{Resolvers: resolver}))
+	srv.AddTransport(transport.GET{})
+	srv.AddTransport(transport.POST{})
+	srv.Use(extension.Introspection{})
+	srv.Use(extension.FixedComplexityLimit(maxComplexity))
+	srv.SetErrorPresenter(presentError)
+
+	return &Server{resolver: resolver, handler: srv}
+}
+
+// ServeHTTP executes the GraphQL request of r on behalf of userID
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request, userID uuid.UUID) {
+	ctx := s.resolver.withRequest(r.Context(), userID)
+	s.handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// presentError adds the kind of service errors as the code extension, e.g.
+// NOT_FOUND, the way the REST API maps them to statuses
+func presentError(ctx context.Context, err error) *gqlerror.Error {
+	gqlErr := graphql.DefaultErrorPresenter(ctx, err)
+
+	var code string
+	switch {
+	case errors.Is(err, service.ErrNotFound):
+		code = "NOT_FOUND"
+	case errors.Is(err, service.ErrForbidden):
+		code = "FORBIDDEN"
+	case errors.Is(err, service.ErrConflict):
+		code = "CONFLICT"
+	case errors.Is(err, service.ErrInvalid):
+		code = "BAD_USER_INPUT"
+	default:
+		return gqlErr
+	}
+	if gqlErr.Extensions == nil {
+		gqlErr.Extensions = map[string]interface{}{}
+	}
+	gqlErr.Extensions["code"] = code
+	return gqlErr
+}
+
+// invalidArgument is an argument the services would refuse as invalid
+type invalidArgument string
+
+func (e invalidArgument) Error() string {
+	return string(e)
+}
+
+func (e invalidArgument) Unwrap() error {
+	return service.ErrInvalid
+}
+
+// pageRequest reads the first and after arguments of a list field
+func pageRequest(first *int, after *string) (models.PageRequest, error) {
+	req := models.PageRequest{Limit: defaultPageSize}
+	if first != nil {
+		if *first < 1 || *first > maxPageSize {
+			return req, invalidArgument(fmt.Sprintf("first must be between 1 and %d", maxPageSize))
+		}
+		req.Limit = *first
+	}
+	if after != nil {
+		cursor, err := models.ParseCursor(*after)
+		if err != nil {
+			return req, invalidArgument("after is not a cursor returned by this field")
+		}
+		req.After = cursor
+	}
+	return req, nil
+}
+
+// nextCursor is the cursor of the page following page, nil on the last
+func nextCursor[T any](page *models.Page[T]) *string {
+	if page.Next == nil {
+		return nil
+	}
+	next := page.Next.String()
+	return &next
+}
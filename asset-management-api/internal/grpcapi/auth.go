@@ -0,0 +1,48 @@
+package grpcapi
+
+import (
+	"context"
+	"strings"
+
+	"asset-management-api/internal/utils"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+type claimsKey struct{}
+
+// authenticate checks the JWT in the authorization metadata of each call,
+// sent as "Bearer <token>" as in the REST API's Authorization header
+func authenticate(jwtUtil *utils.JWTUtil) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		md, _ := metadata.FromIncomingContext(ctx)
+		values := md.Get("authorization")
+		if len(values) == 0 {
+			return nil, status.Error(codes.Unauthenticated, "authorization metadata is required")
+		}
+
+		scheme, token, ok := strings.Cut(values[0], " ")
+		if !ok || scheme != "Bearer" {
+			return nil, status.Error(codes.Unauthenticated, "invalid authorization metadata format")
+		}
+
+		claims, err := jwtUtil.ValidateToken(token)
+		if err != nil {
+			return nil, status.Error(codes.Unauthenticated, "invalid or expired token")
+		}
+		return handler(context.WithValue(ctx, claimsKey{}, claims), req)
+	}
+}
+
+// userID is the ID of the user making the call
+func userID(ctx context.Context) uuid.UUID {
+	claims, _ := ctx.Value(claimsKey{}).(*utils.Claims)
+	if claims == nil {
+		return uuid.Nil
+	}
+	return claims.UserID
+}
@@ -0,0 +1,136 @@
+package grpcapi
+
+import (
+	"fmt"
+	"time"
+	"unicode/utf8"
+
+	"asset-management-api/internal/models"
+	"asset-management-api/pkg/api/assetv1"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Page sizes of list calls, as in the REST API
+const (
+	defaultPageSize = 50
+	maxPageSize     = 100
+)
+
+// parseID parses the ID in field of a request
+func parseID(field, value string) (uuid.UUID, error) {
+	id, err := uuid.Parse(value)
+	if err != nil {
+		return uuid.Nil, status.Errorf(codes.InvalidArgument, "%s must be a UUID", field)
+	}
+	return id, nil
+}
+
+// checkLength checks that a field of a request has between min and max
+// characters, the limits the REST API's requests declare
+func checkLength(field, value string, min, max int) error {
+	switch length := utf8.RuneCountInString(value); {
+	case length == 0 && min > 0:
+		return status.Errorf(codes.InvalidArgument, "%s is required", field)
+	case length < min || length > max:
+		return status.Errorf(codes.InvalidArgument, "%s must have between %d and %d characters", field, min, max)
+	}
+	return nil
+}
+
+// pageRequest reads the limit and cursor of a list request
+func pageRequest(limit int32, cursor string) (models.PageRequest, error) {
+	req := models.PageRequest{Limit: defaultPageSize}
+	if limit != 0 {
+		if limit < 1 || limit > maxPageSize {
+			return req, status.Error(codes.InvalidArgument, fmt.Sprintf("limit must be between 1 and %d", maxPageSize))
+		}
+		req.Limit = int(limit)
+	}
+	if cursor != "" {
+		after, err := models.ParseCursor(cursor)
+		if err != nil {
+			return req, status.Error(codes.InvalidArgument, "cursor is not a cursor returned by this call")
+		}
+		req.After = after
+	}
+	return req, nil
+}
+
+// nextCursor is the cursor of the page following page, empty on the last
+func nextCursor[T any](page *models.Page[T]) string {
+	if page.Next == nil {
+		return ""
+	}
+	return page.Next.String()
+}
+
+func timestamp(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(t)
+}
+
+func folderMessage(folder *models.Folder) *assetv1.Folder {
+	return &assetv1.Folder{
+		FolderId:    folder.FolderID.String(),
+		Name:        folder.Name,
+		Description: folder.Description,
+		OwnerId:     folder.OwnerID.String(),
+		Version:     folder.Version,
+		CreatedAt:   timestamp(folder.CreatedAt),
+		UpdatedAt:   timestamp(folder.UpdatedAt),
+	}
+}
+
+func noteMessage(note *models.Note) *assetv1.Note {
+	return &assetv1.Note{
+		NoteId:    note.NoteID.String(),
+		Title:     note.Title,
+		Body:      note.Body,
+		FolderId:  note.FolderID.String(),
+		OwnerId:   note.OwnerID.String(),
+		Version:   note.Version,
+		CreatedAt: timestamp(note.CreatedAt),
+		UpdatedAt: timestamp(note.UpdatedAt),
+	}
+}
+
+func shareMessage(userID uuid.UUID, accessLevel string, sharedBy uuid.UUID, createdAt time.Time) *assetv1.Share {
+	return &assetv1.Share{
+		UserId:      userID.String(),
+		AccessLevel: accessLevel,
+		SharedBy:    sharedBy.String(),
+		CreatedAt:   timestamp(createdAt),
+	}
+}
+
+func teamMessage(team *models.Team) *assetv1.Team {
+	message := &assetv1.Team{
+		TeamId:    team.TeamID.String(),
+		TeamName:  team.TeamName,
+		CreatedBy: team.CreatedBy.String(),
+		Version:   team.Version,
+		CreatedAt: timestamp(team.CreatedAt),
+		UpdatedAt: timestamp(team.UpdatedAt),
+	}
+	for _, manager := range team.Managers {
+		message.Managers = append(message.Managers, teamUserMessage(manager))
+	}
+	for _, member := range team.Members {
+		message.Members = append(message.Members, teamUserMessage(member))
+	}
+	return message
+}
+
+func teamUserMessage(user models.User) *assetv1.TeamUser {
+	return &assetv1.TeamUser{
+		UserId:   user.UserID.String(),
+		Username: user.Username,
+		Email:    user.Email,
+	}
+}
@@ -0,0 +1,29 @@
+package grpcapi
+
+import (
+	"errors"
+
+	"asset-management-api/internal/service"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// serviceError converts an error returned by a service to a status. Refused
+// requests get the code of their kind and their own message; anything else
+// is an internal error reported as message. Conflicts are Aborted, which
+// gateways map to 409 as the REST API answers them.
+func serviceError(message string, err error) error {
+	switch {
+	case errors.Is(err, service.ErrNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, service.ErrForbidden):
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, service.ErrConflict):
+		return status.Error(codes.Aborted, err.Error())
+	case errors.Is(err, service.ErrInvalid):
+		return status.Error(codes.InvalidArgument, err.Error())
+	default:
+		return status.Errorf(codes.Internal, "%s: %v", message, err)
+	}
+}
@@ -0,0 +1,92 @@
+package grpcapi
+
+import (
+	"context"
+
+	serviceInterfaces "asset-management-api/internal/service/interfaces"
+	"asset-management-api/pkg/api/assetv1"
+
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+type folderServer struct {
+	assetv1.UnimplementedFolderServiceServer
+	folders serviceInterfaces.FolderService
+}
+
+func (s *folderServer) CreateFolder(ctx context.Context, req *assetv1.CreateFolderRequest) (*assetv1.Folder, error) {
+	if err := checkFolder(req.Name, req.Description); err != nil {
+		return nil, err
+	}
+
+	folder, err := s.folders.CreateFolder(ctx, userID(ctx), req.Name, req.Description)
+	if err != nil {
+		return nil, serviceError("failed to create folder", err)
+	}
+	return folderMessage(folder), nil
+}
+
+func (s *folderServer) GetFolder(ctx context.Context, req *assetv1.GetFolderRequest) (*assetv1.Folder, error) {
+	folderID, err := parseID("folder_id", req.FolderId)
+	if err != nil {
+		return nil, err
+	}
+
+	folder, err := s.folders.GetFolder(ctx, folderID, userID(ctx))
+	if err != nil {
+		return nil, serviceError("failed to get folder", err)
+	}
+	return folderMessage(folder), nil
+}
+
+func (s *folderServer) UpdateFolder(ctx context.Context, req *assetv1.UpdateFolderRequest) (*assetv1.Folder, error) {
+	folderID, err := parseID("folder_id", req.FolderId)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkFolder(req.Name, req.Description); err != nil {
+		return nil, err
+	}
+
+	folder, err := s.folders.UpdateFolder(ctx, folderID, userID(ctx), req.Name, req.Description)
+	if err != nil {
+		return nil, serviceError("failed to update folder", err)
+	}
+	return folderMessage(folder), nil
+}
+
+func (s *folderServer) DeleteFolder(ctx context.Context, req *assetv1.DeleteFolderRequest) (*emptypb.Empty, error) {
+	folderID, err := parseID("folder_id", req.FolderId)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.folders.DeleteFolder(ctx, folderID, userID(ctx)); err != nil {
+		return nil, serviceError("failed to delete folder", err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *folderServer) ListFolders(ctx context.Context, req *assetv1.ListFoldersRequest) (*assetv1.ListFoldersResponse, error) {
+	pageReq, err := pageRequest(req.Limit, req.Cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	page, err := s.folders.ListUserFolders(ctx, userID(ctx), pageReq)
+	if err != nil {
+		return nil, serviceError("failed to list folders", err)
+	}
+	resp := &assetv1.ListFoldersResponse{NextCursor: nextCursor(page)}
+	for _, folder := range page.Items {
+		resp.Folders = append(resp.Folders, folderMessage(folder))
+	}
+	return resp, nil
+}
+
+func checkFolder(name, description string) error {
+	if err := checkLength("name", name, 1, 255); err != nil {
+		return err
+	}
+	return checkLength("description", description, 0, 1000)
+}
@@ -0,0 +1,107 @@
+package grpcapi
+
+import (
+	"context"
+
+	"asset-management-api/internal/models"
+	serviceInterfaces "asset-management-api/internal/service/interfaces"
+	"asset-management-api/pkg/api/assetv1"
+
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+type noteServer struct {
+	assetv1.UnimplementedNoteServiceServer
+	notes serviceInterfaces.NoteService
+}
+
+func (s *noteServer) CreateNote(ctx context.Context, req *assetv1.CreateNoteRequest) (*assetv1.Note, error) {
+	folderID, err := parseID("folder_id", req.FolderId)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkNote(req.Title, req.Body); err != nil {
+		return nil, err
+	}
+
+	note, err := s.notes.CreateNote(ctx, userID(ctx), folderID, req.Title, req.Body)
+	if err != nil {
+		return nil, serviceError("failed to create note", err)
+	}
+	return noteMessage(note), nil
+}
+
+func (s *noteServer) GetNote(ctx context.Context, req *assetv1.GetNoteRequest) (*assetv1.Note, error) {
+	noteID, err := parseID("note_id", req.NoteId)
+	if err != nil {
+		return nil, err
+	}
+
+	note, err := s.notes.GetNote(ctx, noteID, userID(ctx))
+	if err != nil {
+		return nil, serviceError("failed to get note", err)
+	}
+	return noteMessage(note), nil
+}
+
+func (s *noteServer) UpdateNote(ctx context.Context, req *assetv1.UpdateNoteRequest) (*assetv1.Note, error) {
+	noteID, err := parseID("note_id", req.NoteId)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkNote(req.Title, req.Body); err != nil {
+		return nil, err
+	}
+
+	note, err := s.notes.UpdateNote(ctx, noteID, userID(ctx), req.Title, req.Body)
+	if err != nil {
+		return nil, serviceError("failed to update note", err)
+	}
+	return noteMessage(note), nil
+}
+
+func (s *noteServer) DeleteNote(ctx context.Context, req *assetv1.DeleteNoteRequest) (*emptypb.Empty, error) {
+	noteID, err := parseID("note_id", req.NoteId)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.notes.DeleteNote(ctx, noteID, userID(ctx)); err != nil {
+		return nil, serviceError("failed to delete note", err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *noteServer) ListNotes(ctx context.Context, req *assetv1.ListNotesRequest) (*assetv1.ListNotesResponse, error) {
+	pageReq, err := pageRequest(req.Limit, req.Cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	var page *models.Page[*models.Note]
+	if req.FolderId == "" {
+		page, err = s.notes.ListUserNotes(ctx, userID(ctx), pageReq)
+	} else {
+		folderID, parseErr := parseID("folder_id", req.FolderId)
+		if parseErr != nil {
+			return nil, parseErr
+		}
+		page, err = s.notes.ListNotesByFolder(ctx, folderID, userID(ctx), pageReq)
+	}
+	if err != nil {
+		return nil, serviceError("failed to list notes", err)
+	}
+
+	resp := &assetv1.ListNotesResponse{NextCursor: nextCursor(page)}
+	for _, note := range page.Items {
+		resp.Notes = append(resp.Notes, noteMessage(note))
+	}
+	return resp, nil
+}
+
+func checkNote(title, body string) error {
+	if err := checkLength("title", title, 1, 255); err != nil {
+		return err
+	}
+	return checkLength("body", body, 0, 10000)
+}
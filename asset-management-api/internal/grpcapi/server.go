@@ -0,0 +1,97 @@
+// Package grpcapi serves the folder, note, share and team services over
+// gRPC, as defined in proto/asset/v1/asset.proto. Calls are authenticated
+// with the JWT of the REST API and go through the same services, so they
+// are authorized the same way.
+package grpcapi
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"asset-management-api/internal/middleware"
+	serviceInterfaces "asset-management-api/internal/service/interfaces"
+	"asset-management-api/internal/utils"
+	"asset-management-api/pkg/api/assetv1"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	grpcRequestsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "grpc_requests_total",
+			Help: "Total number of gRPC calls by method and status code",
+		},
+		[]string{"method", "code"},
+	)
+
+	grpcRequestDuration = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "grpc_request_duration_seconds",
+			Help:    "gRPC call latency by method",
+			Buckets: prometheus.DefBuckets,
+		},
+		[]string{"method"},
+	)
+)
+
+// Services are the services exposed over gRPC
+type Services struct {
+	Folders serviceInterfaces.FolderService
+	Notes   serviceInterfaces.NoteService
+	Shares  serviceInterfaces.ShareService
+	Teams   serviceInterfaces.TeamService
+}
+
+// NewServer creates a gRPC server exposing the services to callers
+// authenticated by jwtUtil
+func NewServer(jwtUtil *utils.JWTUtil, services Services) *grpc.Server {
+	server := grpc.NewServer(grpc.ChainUnaryInterceptor(
+		observe,
+		recoverPanics,
+		authenticate(jwtUtil),
+	))
+	assetv1.RegisterFolderServiceServer(server, &folderServer{folders: services.Folders})
+	assetv1.RegisterNoteServiceServer(server, &noteServer{notes: services.Notes})
+	assetv1.RegisterShareServiceServer(server, &shareServer{shares: services.Shares})
+	assetv1.RegisterTeamServiceServer(server, &teamServer{teams: services.Teams})
+	return server
+}
+
+// observe records the outcome and latency of each call
+func observe(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	start := time.Now()
+	resp, err := handler(ctx, req)
+	code := status.Code(err)
+	grpcRequestsTotal.WithLabelValues(info.FullMethod, code.String()).Inc()
+	grpcRequestDuration.WithLabelValues(info.FullMethod).Observe(time.Since(start).Seconds())
+
+	if code == codes.Internal || code == codes.Unknown {
+		middleware.LogError(err, map[string]interface{}{
+			"component": "grpc_server",
+			"method":    info.FullMethod,
+			"code":      code.String(),
+		})
+	}
+	return resp, err
+}
+
+// recoverPanics turns a panic in a call into an internal error, as the
+// REST API's recovery middleware does
+func recoverPanics(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			middleware.LogError(fmt.Errorf("panic: %v", r), map[string]interface{}{
+				"component": "grpc_server",
+				"method":    info.FullMethod,
+			})
+			err = status.Error(codes.Internal, "internal server error")
+		}
+	}()
+	return handler(ctx, req)
+}
@@ -0,0 +1,136 @@
+package grpcapi
+
+import (
+	"context"
+
+	serviceInterfaces "asset-management-api/internal/service/interfaces"
+	"asset-management-api/pkg/api/assetv1"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+type shareServer struct {
+	assetv1.UnimplementedShareServiceServer
+	shares serviceInterfaces.ShareService
+}
+
+func (s *shareServer) ShareFolder(ctx context.Context, req *assetv1.ShareFolderRequest) (*emptypb.Empty, error) {
+	folderID, err := parseID("folder_id", req.FolderId)
+	if err != nil {
+		return nil, err
+	}
+	targetUserID, err := parseID("user_id", req.UserId)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkAccessLevel(req.AccessLevel); err != nil {
+		return nil, err
+	}
+
+	if err := s.shares.ShareFolder(ctx, folderID, userID(ctx), targetUserID, req.AccessLevel); err != nil {
+		return nil, serviceError("failed to share folder", err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *shareServer) UnshareFolder(ctx context.Context, req *assetv1.UnshareFolderRequest) (*emptypb.Empty, error) {
+	folderID, err := parseID("folder_id", req.FolderId)
+	if err != nil {
+		return nil, err
+	}
+	targetUserID, err := parseID("user_id", req.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.shares.UnshareFolder(ctx, folderID, userID(ctx), targetUserID); err != nil {
+		return nil, serviceError("failed to unshare folder", err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *shareServer) ListFolderShares(ctx context.Context, req *assetv1.ListFolderSharesRequest) (*assetv1.ListSharesResponse, error) {
+	folderID, err := parseID("folder_id", req.FolderId)
+	if err != nil {
+		return nil, err
+	}
+	pageReq, err := pageRequest(req.Limit, req.Cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	page, err := s.shares.ListFolderShares(ctx, folderID, userID(ctx), pageReq)
+	if err != nil {
+		return nil, serviceError("failed to list folder shares", err)
+	}
+	resp := &assetv1.ListSharesResponse{NextCursor: nextCursor(page)}
+	for _, share := range page.Items {
+		resp.Shares = append(resp.Shares, shareMessage(share.SharedWithUserID, share.AccessLevel, share.SharedBy, share.CreatedAt))
+	}
+	return resp, nil
+}
+
+func (s *shareServer) ShareNote(ctx context.Context, req *assetv1.ShareNoteRequest) (*emptypb.Empty, error) {
+	noteID, err := parseID("note_id", req.NoteId)
+	if err != nil {
+		return nil, err
+	}
+	targetUserID, err := parseID("user_id", req.UserId)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkAccessLevel(req.AccessLevel); err != nil {
+		return nil, err
+	}
+
+	if err := s.shares.ShareNote(ctx, noteID, userID(ctx), targetUserID, req.AccessLevel); err != nil {
+		return nil, serviceError("failed to share note", err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *shareServer) UnshareNote(ctx context.Context, req *assetv1.UnshareNoteRequest) (*emptypb.Empty, error) {
+	noteID, err := parseID("note_id", req.NoteId)
+	if err != nil {
+		return nil, err
+	}
+	targetUserID, err := parseID("user_id", req.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.shares.UnshareNote(ctx, noteID, userID(ctx), targetUserID); err != nil {
+		return nil, serviceError("failed to unshare note", err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *shareServer) ListNoteShares(ctx context.Context, req *assetv1.ListNoteSharesRequest) (*assetv1.ListSharesResponse, error) {
+	noteID, err := parseID("note_id", req.NoteId)
+	if err != nil {
+		return nil, err
+	}
+	pageReq, err := pageRequest(req.Limit, req.Cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	page, err := s.shares.ListNoteShares(ctx, noteID, userID(ctx), pageReq)
+	if err != nil {
+		return nil, serviceError("failed to list note shares", err)
+	}
+	resp := &assetv1.ListSharesResponse{NextCursor: nextCursor(page)}
+	for _, share := range page.Items {
+		resp.Shares = append(resp.Shares, shareMessage(share.SharedWithUserID, share.AccessLevel, share.SharedBy, share.CreatedAt))
+	}
+	return resp, nil
+}
+
+func checkAccessLevel(accessLevel string) error {
+	if accessLevel != "read" && accessLevel != "write" {
+		return status.Error(codes.InvalidArgument, `access_level must be "read" or "write"`)
+	}
+	return nil
+}
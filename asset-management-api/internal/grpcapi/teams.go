@@ -0,0 +1,98 @@
+package grpcapi
+
+import (
+	"context"
+
+	serviceInterfaces "asset-management-api/internal/service/interfaces"
+	"asset-management-api/pkg/api/assetv1"
+
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+type teamServer struct {
+	assetv1.UnimplementedTeamServiceServer
+	teams serviceInterfaces.TeamService
+}
+
+func (s *teamServer) CreateTeam(ctx context.Context, req *assetv1.CreateTeamRequest) (*assetv1.Team, error) {
+	if err := checkLength("team_name", req.TeamName, 1, 255); err != nil {
+		return nil, err
+	}
+
+	team, err := s.teams.CreateTeam(ctx, userID(ctx), req.TeamName, teamMembers(req.Managers), teamMembers(req.Members))
+	if err != nil {
+		return nil, serviceError("failed to create team", err)
+	}
+	return teamMessage(team), nil
+}
+
+func (s *teamServer) GetTeam(ctx context.Context, req *assetv1.GetTeamRequest) (*assetv1.Team, error) {
+	teamID, err := parseID("team_id", req.TeamId)
+	if err != nil {
+		return nil, err
+	}
+
+	team, err := s.teams.GetTeam(ctx, teamID, userID(ctx))
+	if err != nil {
+		return nil, serviceError("failed to get team", err)
+	}
+	return teamMessage(team), nil
+}
+
+func (s *teamServer) ListTeams(ctx context.Context, req *assetv1.ListTeamsRequest) (*assetv1.ListTeamsResponse, error) {
+	teams, err := s.teams.GetUserTeams(ctx, userID(ctx))
+	if err != nil {
+		return nil, serviceError("failed to list teams", err)
+	}
+	resp := &assetv1.ListTeamsResponse{}
+	for _, team := range teams {
+		resp.Teams = append(resp.Teams, teamMessage(team))
+	}
+	return resp, nil
+}
+
+func (s *teamServer) AddMember(ctx context.Context, req *assetv1.TeamUserRequest) (*emptypb.Empty, error) {
+	return s.changeTeam(ctx, req, "failed to add member", s.teams.AddMember)
+}
+
+func (s *teamServer) RemoveMember(ctx context.Context, req *assetv1.TeamUserRequest) (*emptypb.Empty, error) {
+	return s.changeTeam(ctx, req, "failed to remove member", s.teams.RemoveMember)
+}
+
+func (s *teamServer) AddManager(ctx context.Context, req *assetv1.TeamUserRequest) (*emptypb.Empty, error) {
+	return s.changeTeam(ctx, req, "failed to add manager", s.teams.AddManager)
+}
+
+func (s *teamServer) RemoveManager(ctx context.Context, req *assetv1.TeamUserRequest) (*emptypb.Empty, error) {
+	return s.changeTeam(ctx, req, "failed to remove manager", s.teams.RemoveManager)
+}
+
+// changeTeam adds or removes the user of req as a member or manager
+func (s *teamServer) changeTeam(ctx context.Context, req *assetv1.TeamUserRequest, message string,
+	change func(ctx context.Context, teamID, requestorID, userID uuid.UUID) error) (*emptypb.Empty, error) {
+	teamID, err := parseID("team_id", req.TeamId)
+	if err != nil {
+		return nil, err
+	}
+	targetUserID, err := parseID("user_id", req.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := change(ctx, teamID, userID(ctx), targetUserID); err != nil {
+		return nil, serviceError(message, err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func teamMembers(members []*assetv1.TeamMember) []serviceInterfaces.TeamMemberInfo {
+	infos := make([]serviceInterfaces.TeamMemberInfo, 0, len(members))
+	for _, member := range members {
+		infos = append(infos, serviceInterfaces.TeamMemberInfo{
+			UserID:   member.UserId,
+			UserName: member.UserName,
+		})
+	}
+	return infos
+}
@@ -0,0 +1,85 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"asset-management-api/internal/audit"
+	"asset-management-api/internal/models"
+	"asset-management-api/internal/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// Upper bound on audit records returned by a single query
+const maxAuditQueryLimit = 1000
+
+// AuditHandler queries the API audit log
+type AuditHandler struct {
+	auditLog *audit.APIAuditLog
+}
+
+// NewAuditHandler creates a new audit handler; auditLog is nil when API auditing is disabled
+func NewAuditHandler(auditLog *audit.APIAuditLog) *AuditHandler {
+	return &AuditHandler{auditLog: auditLog}
+}
+
+// GET /admin/audit?actor_id=&method=&route=&entity_type=&entity_id=&outcome=&from=&to=&limit=100
+// from and to are RFC 3339 times; results are the most recent first.
+func (h *AuditHandler) ListRequests(c *gin.Context) {
+	if h.auditLog == nil {
+		utils.ErrorResponse(c, http.StatusServiceUnavailable, "Audit log unavailable", "API auditing is not enabled")
+		return
+	}
+
+	filter := models.APIAuditFilter{
+		Method:     strings.ToUpper(c.Query("method")),
+		Route:      c.Query("route"),
+		EntityType: c.Query("entity_type"),
+		EntityID:   c.Query("entity_id"),
+		Outcome:    c.Query("outcome"),
+	}
+
+	if actor := c.Query("actor_id"); actor != "" {
+		actorID, err := uuid.Parse(actor)
+		if err != nil {
+			utils.BadRequestResponse(c, "Invalid actor ID format", err)
+			return
+		}
+		filter.ActorID = &actorID
+	}
+
+	var err error
+	if from := c.Query("from"); from != "" {
+		if filter.From, err = time.Parse(time.RFC3339, from); err != nil {
+			utils.BadRequestResponse(c, "Query parameter 'from' must be an RFC 3339 time", err)
+			return
+		}
+	}
+	if to := c.Query("to"); to != "" {
+		if filter.To, err = time.Parse(time.RFC3339, to); err != nil {
+			utils.BadRequestResponse(c, "Query parameter 'to' must be an RFC 3339 time", err)
+			return
+		}
+	}
+
+	filter.Limit, err = strconv.Atoi(c.DefaultQuery("limit", "100"))
+	if err != nil || filter.Limit < 1 || filter.Limit > maxAuditQueryLimit {
+		utils.BadRequestResponse(c, "Query parameter 'limit' must be between 1 and 1000", err)
+		return
+	}
+
+	records, err := h.auditLog.Query(c.Request.Context(), filter)
+	if err != nil {
+		utils.InternalServerErrorResponse(c, "Failed to query audit log", err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Audit log retrieved successfully", gin.H{
+		"records": records,
+		"count":   len(records),
+	})
+}
@@ -0,0 +1,182 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"asset-management-api/internal/backup"
+	"asset-management-api/internal/middleware"
+	"asset-management-api/internal/models"
+	"asset-management-api/internal/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// Number of export jobs listed by GET /exports
+const (
+	defaultExportsLimit = 20
+	maxExportsLimit     = 100
+)
+
+// exportPollInterval is the Retry-After of export jobs still running, in
+// seconds
+const exportPollInterval = 5
+
+// BackupHandler exports teams on request, at once for the admin backups and
+// in the background for the export jobs
+type BackupHandler struct {
+	job      *backup.Job
+	exporter *backup.Exporter
+}
+
+// NewBackupHandler creates a new backup handler; job and exporter are nil
+// when backups are not configured
+func NewBackupHandler(job *backup.Job, exporter *backup.Exporter) *BackupHandler {
+	return &BackupHandler{job: job, exporter: exporter}
+}
+
+// POST /admin/backups/teams/:teamId
+// Exports the team to the backup bucket and returns where it was stored.
+func (h *BackupHandler) ExportTeam(c *gin.Context) {
+	if h.job == nil {
+		utils.ErrorResponse(c, http.StatusServiceUnavailable, "Backups unavailable", "Backups are not configured")
+		return
+	}
+
+	teamID, err := uuid.Parse(c.Param("teamId"))
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid team ID format", err)
+		return
+	}
+
+	result, err := h.job.ExportTeam(c.Request.Context(), teamID)
+	if err != nil {
+		if errors.Is(err, backup.ErrTeamNotFound) {
+			utils.ErrorResponse(c, http.StatusNotFound, "Team not found", err.Error())
+			return
+		}
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to export team", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusCreated, "Team exported successfully", result)
+}
+
+// POST /exports
+// Queues an export of a team, which a worker builds in the background; poll
+// the Location returned to follow it.
+func (h *BackupHandler) CreateExport(c *gin.Context) {
+	userID, ok := h.exportRequestor(c)
+	if !ok {
+		return
+	}
+
+	req, ok := utils.BindJSON[models.CreateExportRequest](c)
+	if !ok {
+		return
+	}
+	teamID, err := uuid.Parse(req.TeamID)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid team ID format", err)
+		return
+	}
+
+	job, err := h.exporter.Request(c.Request.Context(), teamID, userID)
+	if err != nil {
+		switch {
+		case errors.Is(err, backup.ErrTeamNotFound):
+			utils.NotFoundResponse(c, "Team not found")
+		case errors.Is(err, backup.ErrNotTeamManager):
+			utils.ForbiddenResponse(c, err.Error())
+		default:
+			utils.InternalServerErrorResponse(c, "Failed to queue export", err)
+		}
+		return
+	}
+
+	location := exportPath(job.ExportID)
+	c.Header("Location", location)
+	c.Header("Retry-After", strconv.Itoa(exportPollInterval))
+	utils.NewResponse(c).Link("self", location).Send(http.StatusAccepted, "Export queued successfully", job)
+}
+
+// GET /exports?limit=20
+// Lists the latest exports requested by the user, newest first.
+func (h *BackupHandler) ListExports(c *gin.Context) {
+	userID, ok := h.exportRequestor(c)
+	if !ok {
+		return
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", strconv.Itoa(defaultExportsLimit)))
+	if err != nil || limit < 1 || limit > maxExportsLimit {
+		utils.BadRequestResponse(c, fmt.Sprintf("Query parameter 'limit' must be between 1 and %d", maxExportsLimit), err)
+		return
+	}
+
+	jobs, err := h.exporter.List(c.Request.Context(), userID, limit)
+	if err != nil {
+		utils.InternalServerErrorResponse(c, "Failed to list exports", err)
+		return
+	}
+	if jobs == nil {
+		jobs = []*models.ExportJob{}
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Exports retrieved successfully", jobs)
+}
+
+// GET /exports/:exportId
+// Reports the progress of an export and, once it succeeded, a link to
+// download it that expires after a while; get the export again for a new one.
+func (h *BackupHandler) GetExport(c *gin.Context) {
+	userID, ok := h.exportRequestor(c)
+	if !ok {
+		return
+	}
+
+	exportID, err := uuid.Parse(c.Param("exportId"))
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid export ID format", err)
+		return
+	}
+
+	status, err := h.exporter.Get(c.Request.Context(), exportID, userID)
+	if err != nil {
+		if errors.Is(err, backup.ErrExportNotFound) {
+			utils.NotFoundResponse(c, "Export not found")
+			return
+		}
+		utils.InternalServerErrorResponse(c, "Failed to get export", err)
+		return
+	}
+
+	if !status.Done() {
+		c.Header("Retry-After", strconv.Itoa(exportPollInterval))
+	}
+	// The download link is a credential of its own
+	c.Header("Cache-Control", "no-store")
+	utils.NewResponse(c).Link("self", exportPath(exportID)).Send(http.StatusOK, "Export retrieved successfully", status)
+}
+
+// exportRequestor returns the user calling an export endpoint, answering the
+// request when there is none or exports are not configured
+func (h *BackupHandler) exportRequestor(c *gin.Context) (uuid.UUID, bool) {
+	if h.exporter == nil {
+		utils.ErrorResponse(c, http.StatusServiceUnavailable, "Exports unavailable", "Backups are not configured")
+		return uuid.Nil, false
+	}
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		utils.UnauthorizedResponse(c, "User not authenticated")
+		return uuid.Nil, false
+	}
+	return userID, true
+}
+
+func exportPath(exportID uuid.UUID) string {
+	return apiBasePath + "/exports/" + exportID.String()
+}
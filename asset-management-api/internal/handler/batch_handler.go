@@ -0,0 +1,223 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+
+	"asset-management-api/internal/middleware"
+	"asset-management-api/internal/models"
+	"asset-management-api/internal/tracing"
+	"asset-management-api/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// batchPathPrefix is the prefix of the paths a batch may request
+const batchPathPrefix = "/api/v1/"
+
+// unbatchablePaths cannot be requested in a batch: batches do not nest, and
+// streams never end
+var unbatchablePaths = map[string]bool{
+	"/api/v1/batch":         true,
+	"/api/v1/events/stream": true,
+	"/api/v1/ws":            true,
+}
+
+// batchRequestHeaders are the headers of the batch request its items do not
+// inherit. Items get the body headers of their own body, and uncompressed
+// responses so they can be embedded.
+var batchRequestHeaders = []string{"Content-Length", "Content-Type", "Accept-Encoding", "If-Match", "If-None-Match", "Last-Event-ID"}
+
+// batchItemHeaders are the headers an item may not set, as they identify the
+// caller or the connection
+var batchItemHeaders = map[string]bool{
+	"Authorization":     true,
+	"Cookie":            true,
+	"Host":              true,
+	"Content-Length":    true,
+	"Accept-Encoding":   true,
+	"Connection":        true,
+	"Upgrade":           true,
+	"X-Forwarded-For":   true,
+	"X-Real-Ip":         true,
+	"X-Request-Id":      true,
+	"Sec-Websocket-Key": true,
+}
+
+// batchResponseHeaders are the response headers returned with an item
+var batchResponseHeaders = []string{"Location", "ETag", "Retry-After", tracing.RequestIDHeader}
+
+type BatchHandler struct {
+	router      http.Handler
+	maxRequests int
+}
+
+func NewBatchHandler(maxRequests int) *BatchHandler {
+	return &BatchHandler{maxRequests: maxRequests}
+}
+
+// Mount sets the router the items of a batch are served by, the one the
+// batch route is on
+func (h *BatchHandler) Mount(router http.Handler) {
+	h.router = router
+}
+
+// POST /batch
+// Runs the requests of the batch one after the other through the API, with
+// the caller's authorization, and returns their responses in order. Each
+// request passes the same middleware as when sent on its own, so it is rate
+// limited and counted against the quota separately. A failed request does not
+// stop the batch.
+func (h *BatchHandler) Execute(c *gin.Context) {
+	if _, exists := middleware.GetUserIDFromContext(c); !exists {
+		utils.UnauthorizedResponse(c, "User not authenticated")
+		return
+	}
+
+	req, ok := utils.BindJSON[models.BatchRequest](c)
+	if !ok {
+		return
+	}
+	if len(req.Requests) > h.maxRequests {
+		utils.ValidationErrorResponse(c, []string{fmt.Sprintf("requests: at most %d requests are allowed", h.maxRequests)})
+		return
+	}
+	var problems []string
+	for i, item := range req.Requests {
+		if err := checkBatchPath(item.Path); err != nil {
+			problems = append(problems, fmt.Sprintf("requests[%d].path: %v", i, err))
+		}
+		for name := range item.Headers {
+			if batchItemHeaders[http.CanonicalHeaderKey(name)] {
+				problems = append(problems, fmt.Sprintf("requests[%d].headers: %s cannot be set", i, name))
+			}
+		}
+	}
+	if len(problems) > 0 {
+		utils.ValidationErrorResponse(c, problems)
+		return
+	}
+
+	requestID := middleware.GetRequestIDFromContext(c)
+	responses := make([]models.BatchItemResponse, 0, len(req.Requests))
+	for i, item := range req.Requests {
+		if err := c.Request.Context().Err(); err != nil {
+			// The caller went away; nobody reads the rest
+			return
+		}
+		responses = append(responses, h.serve(c, item, fmt.Sprintf("%s-%d", requestID, i+1)))
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Batch executed", responses)
+}
+
+// serve runs one request of the batch
+func (h *BatchHandler) serve(c *gin.Context, item models.BatchItem, requestID string) models.BatchItemResponse {
+	var body []byte
+	if len(item.Body) > 0 && string(item.Body) != "null" {
+		body = item.Body
+	}
+
+	req, err := http.NewRequestWithContext(c.Request.Context(), item.Method, item.Path, bytes.NewReader(body))
+	if err != nil {
+		return batchErrorResponse(http.StatusBadRequest, "Invalid request", err.Error())
+	}
+	req.Header = c.Request.Header.Clone()
+	for _, name := range batchRequestHeaders {
+		req.Header.Del(name)
+	}
+	for name, value := range item.Headers {
+		req.Header.Set(name, value)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if tracing.ValidRequestID(requestID) {
+		req.Header.Set(tracing.RequestIDHeader, requestID)
+	}
+	req.Host = c.Request.Host
+	req.RemoteAddr = c.Request.RemoteAddr
+
+	recorder := newBatchRecorder()
+	h.router.ServeHTTP(recorder, req)
+
+	response := models.BatchItemResponse{Status: recorder.status}
+	for _, name := range batchResponseHeaders {
+		if value := recorder.header.Get(name); value != "" {
+			if response.Headers == nil {
+				response.Headers = make(map[string]string)
+			}
+			response.Headers[name] = value
+		}
+	}
+	switch {
+	case recorder.body.Len() == 0:
+	case json.Valid(recorder.body.Bytes()):
+		response.Body = recorder.body.Bytes()
+	default:
+		// Bodies that are not JSON are embedded as a string
+		response.Body, _ = json.Marshal(recorder.body.String())
+	}
+	return response
+}
+
+// checkBatchPath checks that a batch may request the path
+func checkBatchPath(rawPath string) error {
+	u, err := url.Parse(rawPath)
+	if err != nil || u.IsAbs() || u.Host != "" || u.Fragment != "" {
+		return fmt.Errorf("must be a path with an optional query string")
+	}
+	if !strings.HasPrefix(u.Path, batchPathPrefix) {
+		return fmt.Errorf("must start with %s", batchPathPrefix)
+	}
+	if path.Clean(u.Path) != strings.TrimSuffix(u.Path, "/") {
+		return fmt.Errorf("must not contain empty, . or .. segments")
+	}
+	if unbatchablePaths[strings.TrimSuffix(u.Path, "/")] {
+		return fmt.Errorf("%s cannot be requested in a batch", u.Path)
+	}
+	return nil
+}
+
+// batchErrorResponse is the response to an item the API could not be asked
+func batchErrorResponse(status int, message, detail string) models.BatchItemResponse {
+	body, _ := json.Marshal(utils.Response{Success: false, Message: message, Error: detail})
+	return models.BatchItemResponse{Status: status, Body: body}
+}
+
+// batchRecorder keeps the response to a request of a batch
+type batchRecorder struct {
+	header      http.Header
+	body        bytes.Buffer
+	status      int
+	wroteHeader bool
+}
+
+func newBatchRecorder() *batchRecorder {
+	return &batchRecorder{header: make(http.Header), status: http.StatusOK}
+}
+
+func (r *batchRecorder) Header() http.Header {
+	return r.header
+}
+
+func (r *batchRecorder) WriteHeader(status int) {
+	if r.wroteHeader {
+		return
+	}
+	r.status = status
+	r.wroteHeader = true
+}
+
+func (r *batchRecorder) Write(data []byte) (int, error) {
+	r.WriteHeader(http.StatusOK)
+	return r.body.Write(data)
+}
+
+// Flush lets handlers that flush as they write run in a batch
+func (r *batchRecorder) Flush() {}
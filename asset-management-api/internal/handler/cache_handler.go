@@ -0,0 +1,154 @@
+package handler
+
+import (
+	"asset-management-api/internal/middleware"
+	"asset-management-api/internal/utils"
+	"asset-management-api/pkg/cache"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type CacheHandler struct {
+	cacheService cache.CacheService
+}
+
+func NewCacheHandler(cacheService cache.CacheService) *CacheHandler {
+	return &CacheHandler{cacheService: cacheService}
+}
+
+// GET /admin/cache/version
+func (h *CacheHandler) GetKeyVersion(c *gin.Context) {
+	utils.SuccessResponse(c, http.StatusOK, "Cache key version retrieved successfully", gin.H{
+		"key_version": h.cacheService.KeyVersion(),
+	})
+}
+
+// POST /admin/cache/version/bump
+func (h *CacheHandler) BumpKeyVersion(c *gin.Context) {
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		utils.UnauthorizedResponse(c, "User not authenticated")
+		return
+	}
+
+	previous := h.cacheService.KeyVersion()
+	version, err := h.cacheService.BumpKeyVersion(c.Request.Context())
+	if err != nil {
+		utils.InternalServerErrorResponse(c, "Failed to bump cache key version", err)
+		return
+	}
+
+	middleware.LogBusinessEvent("cache_key_version_bumped", map[string]interface{}{
+		"user_id":          userID,
+		"previous_version": previous,
+		"key_version":      version,
+	})
+
+	utils.SuccessResponse(c, http.StatusOK, "Cache key version bumped successfully", gin.H{
+		"previous_version": previous,
+		"key_version":      version,
+	})
+}
+
+// GET /admin/cache/keys?key=folder:<id>
+func (h *CacheHandler) InspectKey(c *gin.Context) {
+	key := c.Query("key")
+	if key == "" {
+		utils.BadRequestResponse(c, "Query parameter 'key' is required", nil)
+		return
+	}
+
+	info, err := h.cacheService.InspectKey(c.Request.Context(), key)
+	if err != nil {
+		utils.InternalServerErrorResponse(c, "Failed to inspect cache key", err)
+		return
+	}
+	if info == nil {
+		utils.NotFoundResponse(c, "Cache key not found")
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Cache key retrieved successfully", info)
+}
+
+// GET /admin/cache/stats
+func (h *CacheHandler) GetStats(c *gin.Context) {
+	stats, err := h.cacheService.Stats(c.Request.Context())
+	if err != nil {
+		if errors.Is(err, cache.ErrNotSupported) {
+			utils.ErrorResponse(c, http.StatusNotImplemented, "Cache stats not supported by this backend", err.Error())
+			return
+		}
+		utils.InternalServerErrorResponse(c, "Failed to get cache stats", err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Cache stats retrieved successfully", stats)
+}
+
+// DELETE /admin/cache/teams/:teamId
+func (h *CacheHandler) FlushTeam(c *gin.Context) {
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		utils.UnauthorizedResponse(c, "User not authenticated")
+		return
+	}
+
+	teamID, err := uuid.Parse(c.Param("teamId"))
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid team ID format", err)
+		return
+	}
+
+	if err := h.cacheService.InvalidateTeamMembers(c.Request.Context(), teamID); err != nil {
+		utils.InternalServerErrorResponse(c, "Failed to flush team cache", err)
+		return
+	}
+
+	middleware.LogBusinessEvent("team_cache_flushed", map[string]interface{}{
+		"user_id": userID,
+		"team_id": teamID,
+	})
+
+	utils.SuccessResponse(c, http.StatusOK, "Team cache flushed successfully", nil)
+}
+
+// DELETE /admin/cache/assets/:assetId
+// Flushes folder and note metadata as well as the ACL, since the asset type is not known here
+func (h *CacheHandler) FlushAsset(c *gin.Context) {
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		utils.UnauthorizedResponse(c, "User not authenticated")
+		return
+	}
+
+	assetID, err := uuid.Parse(c.Param("assetId"))
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid asset ID format", err)
+		return
+	}
+
+	ctx := c.Request.Context()
+	if err := h.cacheService.InvalidateFolderMetadata(ctx, assetID); err != nil {
+		utils.InternalServerErrorResponse(c, "Failed to flush asset cache", err)
+		return
+	}
+	if err := h.cacheService.InvalidateNoteMetadata(ctx, assetID); err != nil {
+		utils.InternalServerErrorResponse(c, "Failed to flush asset cache", err)
+		return
+	}
+	if err := h.cacheService.InvalidateAssetACL(ctx, assetID); err != nil {
+		utils.InternalServerErrorResponse(c, "Failed to flush asset cache", err)
+		return
+	}
+
+	middleware.LogBusinessEvent("asset_cache_flushed", map[string]interface{}{
+		"user_id":  userID,
+		"asset_id": assetID,
+	})
+
+	utils.SuccessResponse(c, http.StatusOK, "Asset cache flushed successfully", nil)
+}
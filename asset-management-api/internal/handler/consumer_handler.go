@@ -0,0 +1,85 @@
+package handler
+
+import (
+	"asset-management-api/internal/middleware"
+	"asset-management-api/internal/utils"
+	"asset-management-api/pkg/eventbus"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ConsumerHandler pauses and resumes event consumption. Consumption state is
+// per instance: to stop a topic everywhere, pause it on every instance.
+type ConsumerHandler struct {
+	controller eventbus.ConsumptionController
+}
+
+// NewConsumerHandler creates a new consumer handler; controller is nil when Kafka is disabled
+func NewConsumerHandler(controller eventbus.ConsumptionController) *ConsumerHandler {
+	return &ConsumerHandler{controller: controller}
+}
+
+// GET /admin/events/consumers
+func (h *ConsumerHandler) GetStatus(c *gin.Context) {
+	if h.controller == nil {
+		utils.ErrorResponse(c, http.StatusServiceUnavailable, "Consumer administration unavailable", "Kafka event bus is not enabled")
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Consumer status retrieved successfully", gin.H{
+		"paused_topics": h.controller.PausedTopics(),
+	})
+}
+
+// POST /admin/events/consumers/:topic/pause
+func (h *ConsumerHandler) Pause(c *gin.Context) {
+	h.setPaused(c, true)
+}
+
+// POST /admin/events/consumers/:topic/resume
+func (h *ConsumerHandler) Resume(c *gin.Context) {
+	h.setPaused(c, false)
+}
+
+func (h *ConsumerHandler) setPaused(c *gin.Context, pause bool) {
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		utils.UnauthorizedResponse(c, "User not authenticated")
+		return
+	}
+
+	if h.controller == nil {
+		utils.ErrorResponse(c, http.StatusServiceUnavailable, "Consumer administration unavailable", "Kafka event bus is not enabled")
+		return
+	}
+
+	topic := c.Param("topic")
+	action, event, message := "resume", "consumer_resumed", "Consumption resumed successfully"
+	var err error
+	if pause {
+		action, event, message = "pause", "consumer_paused", "Consumption paused successfully"
+		err = h.controller.Pause(topic)
+	} else {
+		err = h.controller.Resume(topic)
+	}
+	if err != nil {
+		if errors.Is(err, eventbus.ErrNotSubscribed) {
+			utils.NotFoundResponse(c, "Topic is not consumed by this instance")
+			return
+		}
+		utils.InternalServerErrorResponse(c, "Failed to "+action+" consumption", err)
+		return
+	}
+
+	middleware.LogBusinessEvent(event, map[string]interface{}{
+		"user_id": userID,
+		"topic":   topic,
+	})
+
+	utils.SuccessResponse(c, http.StatusOK, message, gin.H{
+		"topic":         topic,
+		"paused_topics": h.controller.PausedTopics(),
+	})
+}
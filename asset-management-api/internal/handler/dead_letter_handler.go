@@ -0,0 +1,78 @@
+package handler
+
+import (
+	"asset-management-api/internal/middleware"
+	"asset-management-api/internal/utils"
+	"asset-management-api/pkg/eventbus"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Upper bound on messages replayed by a single re-drive request
+const maxRedriveLimit = 10000
+
+type DeadLetterHandler struct {
+	redriver eventbus.DeadLetterRedriver
+}
+
+// NewDeadLetterHandler creates a new dead letter handler; redriver is nil when Kafka is disabled
+func NewDeadLetterHandler(redriver eventbus.DeadLetterRedriver) *DeadLetterHandler {
+	return &DeadLetterHandler{redriver: redriver}
+}
+
+// POST /admin/events/dlq/:topic/redrive?limit=100
+func (h *DeadLetterHandler) Redrive(c *gin.Context) {
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		utils.UnauthorizedResponse(c, "User not authenticated")
+		return
+	}
+
+	if h.redriver == nil {
+		utils.ErrorResponse(c, http.StatusServiceUnavailable, "Dead letter re-drive unavailable", "Kafka event bus is not enabled")
+		return
+	}
+
+	topic := c.Param("topic")
+	if topic == "" || strings.HasSuffix(topic, eventbus.DeadLetterSuffix) {
+		utils.BadRequestResponse(c, "Topic must be the original topic name, not its dead letter topic", nil)
+		return
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "100"))
+	if err != nil || limit < 1 || limit > maxRedriveLimit {
+		utils.BadRequestResponse(c, "Query parameter 'limit' must be between 1 and 10000", err)
+		return
+	}
+
+	result, err := h.redriver.Redrive(c.Request.Context(), topic, limit)
+	if err != nil {
+		if errors.Is(err, eventbus.ErrRedriveInProgress) {
+			utils.ErrorResponse(c, http.StatusConflict, "Dead letter re-drive already running", err.Error())
+			return
+		}
+		// A partial re-drive still reports how many messages were replayed
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to re-drive dead letter queue", err.Error())
+		if result != nil {
+			middleware.LogBusinessEvent("dead_letter_redrive_failed", map[string]interface{}{
+				"user_id":  userID,
+				"topic":    topic,
+				"redriven": result.Redriven,
+				"error":    err.Error(),
+			})
+		}
+		return
+	}
+
+	middleware.LogBusinessEvent("dead_letter_redriven", map[string]interface{}{
+		"user_id":  userID,
+		"topic":    topic,
+		"redriven": result.Redriven,
+	})
+
+	utils.SuccessResponse(c, http.StatusOK, "Dead letter queue re-driven successfully", result)
+}
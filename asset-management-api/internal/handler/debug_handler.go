@@ -0,0 +1,111 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/pprof"
+	"runtime"
+	"time"
+
+	"asset-management-api/internal/middleware"
+	"asset-management-api/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DebugHandler serves runtime diagnostics and the pprof profiles. It must
+// only be routed behind the DEBUG_ALLOWED_USER_IDS allowlist: profiles reveal
+// source paths and memory contents.
+type DebugHandler struct {
+	startedAt time.Time
+}
+
+func NewDebugHandler() *DebugHandler {
+	return &DebugHandler{startedAt: time.Now()}
+}
+
+// GET /debug/runtime
+func (h *DebugHandler) GetRuntimeStats(c *gin.Context) {
+	var mem runtime.MemStats
+	runtime.ReadMemStats(&mem)
+
+	var lastGC interface{}
+	if mem.LastGC > 0 {
+		lastGC = time.Unix(0, int64(mem.LastGC)).UTC()
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Runtime statistics retrieved successfully", gin.H{
+		"go_version": runtime.Version(),
+		"uptime":     time.Since(h.startedAt).Round(time.Second).String(),
+		"num_cpu":    runtime.NumCPU(),
+		"gomaxprocs": runtime.GOMAXPROCS(0),
+		"goroutines": runtime.NumGoroutine(),
+		"heap": gin.H{
+			"alloc_bytes":    mem.HeapAlloc,
+			"inuse_bytes":    mem.HeapInuse,
+			"idle_bytes":     mem.HeapIdle,
+			"released_bytes": mem.HeapReleased,
+			"sys_bytes":      mem.HeapSys,
+			"objects":        mem.HeapObjects,
+		},
+		"memory": gin.H{
+			"total_alloc_bytes": mem.TotalAlloc,
+			"sys_bytes":         mem.Sys,
+			"mallocs":           mem.Mallocs,
+			"frees":             mem.Frees,
+			"stack_inuse_bytes": mem.StackInuse,
+		},
+		"gc": gin.H{
+			"num_gc":          mem.NumGC,
+			"num_forced_gc":   mem.NumForcedGC,
+			"last_gc":         lastGC,
+			"last_pause":      time.Duration(mem.PauseNs[(mem.NumGC+255)%256]).String(),
+			"pause_total":     time.Duration(mem.PauseTotalNs).String(),
+			"next_gc_bytes":   mem.NextGC,
+			"gc_cpu_fraction": mem.GCCPUFraction,
+		},
+	})
+}
+
+// GET /debug/pprof/ and /debug/pprof/<profile>
+// The index lists the profiles and serves them by name; the name is taken
+// from the path, so the route must stay under /debug/pprof/.
+func (h *DebugHandler) Index(c *gin.Context) {
+	h.logAccess(c)
+	pprof.Index(c.Writer, c.Request)
+}
+
+// GET /debug/pprof/cmdline
+func (h *DebugHandler) Cmdline(c *gin.Context) {
+	h.logAccess(c)
+	pprof.Cmdline(c.Writer, c.Request)
+}
+
+// GET /debug/pprof/profile?seconds=
+// The CPU profile may not run longer than the server's write timeout.
+func (h *DebugHandler) Profile(c *gin.Context) {
+	h.logAccess(c)
+	pprof.Profile(c.Writer, c.Request)
+}
+
+// GET|POST /debug/pprof/symbol
+func (h *DebugHandler) Symbol(c *gin.Context) {
+	h.logAccess(c)
+	pprof.Symbol(c.Writer, c.Request)
+}
+
+// GET /debug/pprof/trace?seconds=
+func (h *DebugHandler) Trace(c *gin.Context) {
+	h.logAccess(c)
+	pprof.Trace(c.Writer, c.Request)
+}
+
+// logAccess records who profiled the service, as profiles expose internals
+func (h *DebugHandler) logAccess(c *gin.Context) {
+	userID, _ := middleware.GetUserIDFromContext(c)
+	middleware.LogSecurityEvent("debug_profile_accessed", map[string]interface{}{
+		"user_id":   userID,
+		"path":      c.Request.URL.Path,
+		"query":     c.Request.URL.RawQuery,
+		"client_ip": c.ClientIP(),
+	})
+}
@@ -0,0 +1,40 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"unicode"
+	"unicode/utf8"
+
+	"asset-management-api/internal/service"
+	"asset-management-api/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// serviceErrorResponse writes the response for an error returned by a
+// service. Refused requests get the status of their kind and their own
+// message; anything else is an internal error reported as message.
+func serviceErrorResponse(c *gin.Context, message string, err error) {
+	switch {
+	case errors.Is(err, service.ErrNotFound):
+		utils.NotFoundResponse(c, sentence(err.Error()))
+	case errors.Is(err, service.ErrForbidden):
+		utils.ForbiddenResponse(c, sentence(err.Error()))
+	case errors.Is(err, service.ErrConflict):
+		utils.ErrorResponse(c, http.StatusConflict, sentence(err.Error()), "Conflict")
+	case errors.Is(err, service.ErrInvalid):
+		utils.ErrorResponse(c, http.StatusBadRequest, sentence(err.Error()), "Invalid request")
+	default:
+		utils.InternalServerErrorResponse(c, message, err)
+	}
+}
+
+// sentence capitalizes a service error message for display
+func sentence(message string) string {
+	if message == "" {
+		return message
+	}
+	r, size := utf8.DecodeRuneInString(message)
+	return string(unicode.ToUpper(r)) + message[size:]
+}
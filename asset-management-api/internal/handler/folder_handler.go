@@ -36,25 +36,18 @@ func (h *FolderHandler) CreateFolder(c *gin.Context) {
 		return
 	}
 
-	var req CreateFolderRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		utils.BadRequestResponse(c, "Invalid request format", err)
+	req, ok := utils.BindJSON[CreateFolderRequest](c)
+	if !ok {
 		return
 	}
 
-	// Validate request
-	if errors := utils.ValidateStruct(req); len(errors) > 0 {
-		utils.ValidationErrorResponse(c, utils.GetValidationErrorMessages(errors))
-		return
-	}
-
-	folder, err := h.folderService.CreateFolder(userID, req.Name, req.Description)
+	folder, err := h.folderService.CreateFolder(c.Request.Context(), userID, req.Name, req.Description)
 	if err != nil {
-		utils.InternalServerErrorResponse(c, "Failed to create folder", err)
+		serviceErrorResponse(c, "Failed to create folder", err)
 		return
 	}
 
-	utils.SuccessResponse(c, http.StatusCreated, "Folder created successfully", folder)
+	utils.NewResponse(c).Links(folderLinks(c, folder)).Send(http.StatusCreated, "Folder created successfully", folder)
 }
 
 // GET /folders/:folderId
@@ -72,21 +65,19 @@ func (h *FolderHandler) GetFolder(c *gin.Context) {
 		return
 	}
 
-	folder, err := h.folderService.GetFolder(folderID, userID)
+	folder, err := h.folderService.GetFolder(c.Request.Context(), folderID, userID)
 	if err != nil {
-		if err.Error() == "folder not found" {
-			utils.NotFoundResponse(c, "Folder not found")
-			return
-		}
-		if err.Error() == "access denied: you don't have permission to view this folder" {
-			utils.ForbiddenResponse(c, "Access denied")
-			return
-		}
-		utils.InternalServerErrorResponse(c, "Failed to get folder", err)
+		serviceErrorResponse(c, "Failed to get folder", err)
 		return
 	}
 
-	utils.SuccessResponse(c, http.StatusOK, "Folder retrieved successfully", folder)
+	// A client holding the current version gets 304, without the folder being
+	// serialized again; on a metadata cache hit that skips the database as well
+	if utils.NotModified(c, utils.WeakETag(folder.UpdatedAt)) {
+		return
+	}
+
+	utils.NewResponse(c).Links(folderLinks(c, folder)).Send(http.StatusOK, "Folder retrieved successfully", folder)
 }
 
 // PUT /folders/:folderId
@@ -104,33 +95,18 @@ func (h *FolderHandler) UpdateFolder(c *gin.Context) {
 		return
 	}
 
-	var req UpdateFolderRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		utils.BadRequestResponse(c, "Invalid request format", err)
-		return
-	}
-
-	// Validate request
-	if errors := utils.ValidateStruct(req); len(errors) > 0 {
-		utils.ValidationErrorResponse(c, utils.GetValidationErrorMessages(errors))
+	req, ok := utils.BindJSON[UpdateFolderRequest](c)
+	if !ok {
 		return
 	}
 
-	folder, err := h.folderService.UpdateFolder(folderID, userID, req.Name, req.Description)
+	folder, err := h.folderService.UpdateFolder(c.Request.Context(), folderID, userID, req.Name, req.Description)
 	if err != nil {
-		if err.Error() == "folder not found" {
-			utils.NotFoundResponse(c, "Folder not found")
-			return
-		}
-		if err.Error() == "access denied: you don't have write permission for this folder" {
-			utils.ForbiddenResponse(c, "Access denied")
-			return
-		}
-		utils.InternalServerErrorResponse(c, "Failed to update folder", err)
+		serviceErrorResponse(c, "Failed to update folder", err)
 		return
 	}
 
-	utils.SuccessResponse(c, http.StatusOK, "Folder updated successfully", folder)
+	utils.NewResponse(c).Links(folderLinks(c, folder)).Send(http.StatusOK, "Folder updated successfully", folder)
 }
 
 // DELETE /folders/:folderId
@@ -148,20 +124,16 @@ func (h *FolderHandler) DeleteFolder(c *gin.Context) {
 		return
 	}
 
-	err = h.folderService.DeleteFolder(folderID, userID)
+	err = h.folderService.DeleteFolder(c.Request.Context(), folderID, userID)
 	if err != nil {
-		if err.Error() == "access denied: only the folder owner can delete it" {
-			utils.ForbiddenResponse(c, "Access denied")
-			return
-		}
-		utils.InternalServerErrorResponse(c, "Failed to delete folder", err)
+		serviceErrorResponse(c, "Failed to delete folder", err)
 		return
 	}
 
 	utils.SuccessResponse(c, http.StatusOK, "Folder deleted successfully", nil)
 }
 
-// GET /folders (Get user's folders)
+// GET /folders?limit=50&cursor= (Get user's folders; paged when limit or cursor is given)
 func (h *FolderHandler) GetUserFolders(c *gin.Context) {
 	userID, exists := middleware.GetUserIDFromContext(c)
 	if !exists {
@@ -169,7 +141,21 @@ func (h *FolderHandler) GetUserFolders(c *gin.Context) {
 		return
 	}
 
-	folders, err := h.folderService.GetUserFolders(userID)
+	req, ok := pageRequest(c)
+	if !ok {
+		return
+	}
+	if req != nil {
+		page, err := h.folderService.ListUserFolders(c.Request.Context(), userID, *req)
+		if err != nil {
+			utils.InternalServerErrorResponse(c, "Failed to get folders", err)
+			return
+		}
+		pageResponse(c, "Folders retrieved successfully", page, req)
+		return
+	}
+
+	folders, err := h.folderService.GetUserFolders(c.Request.Context(), userID)
 	if err != nil {
 		utils.InternalServerErrorResponse(c, "Failed to get folders", err)
 		return
@@ -0,0 +1,31 @@
+package handler
+
+import (
+	"asset-management-api/internal/graph"
+	"asset-management-api/internal/middleware"
+	"asset-management-api/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GraphQLHandler serves the GraphQL API
+type GraphQLHandler struct {
+	server *graph.Server
+}
+
+// NewGraphQLHandler creates a new GraphQL handler
+func NewGraphQLHandler(server *graph.Server) *GraphQLHandler {
+	return &GraphQLHandler{server: server}
+}
+
+// GET/POST /graphql
+// Executes a GraphQL query on behalf of the caller.
+func (h *GraphQLHandler) Query(c *gin.Context) {
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		utils.UnauthorizedResponse(c, "User not authenticated")
+		return
+	}
+
+	h.server.ServeHTTP(c.Writer, c.Request, userID)
+}
@@ -0,0 +1,44 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"asset-management-api/internal/health"
+
+	"github.com/gin-gonic/gin"
+)
+
+// HealthHandler serves the Kubernetes probes. Their bodies are the bare
+// status rather than the API response envelope, for probes and tooling.
+type HealthHandler struct {
+	checker   *health.Checker
+	startedAt time.Time
+}
+
+func NewHealthHandler(checker *health.Checker) *HealthHandler {
+	return &HealthHandler{checker: checker, startedAt: time.Now()}
+}
+
+// GET /live
+// Liveness only says the process serves HTTP; it checks no dependencies, so
+// an outage elsewhere does not get the pod restarted.
+func (h *HealthHandler) Live(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{
+		"status": health.StatusUp,
+		"uptime": time.Since(h.startedAt).Round(time.Second).String(),
+	})
+}
+
+// GET /ready
+// Readiness fails (503) while a critical dependency is down, taking the pod
+// out of rotation; a degraded service stays ready.
+func (h *HealthHandler) Ready(c *gin.Context) {
+	report := h.checker.Run(c.Request.Context())
+
+	status := http.StatusOK
+	if report.Status == health.StatusDown {
+		status = http.StatusServiceUnavailable
+	}
+	c.JSON(status, report)
+}
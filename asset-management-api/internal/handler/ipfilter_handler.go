@@ -0,0 +1,133 @@
+package handler
+
+import (
+	"asset-management-api/internal/middleware"
+	"asset-management-api/internal/utils"
+	"asset-management-api/pkg/ipfilter"
+	"net/http"
+	"net/netip"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// IPFilterHandler maintains the deny list of the IP filter
+type IPFilterHandler struct {
+	filter *middleware.IPFilter
+}
+
+type DenyNetworkRequest struct {
+	CIDR   string `json:"cidr" validate:"required"`
+	Reason string `json:"reason" validate:"max=255"`
+}
+
+// NewIPFilterHandler creates a new IP filter handler; filter is nil when IP filtering is disabled
+func NewIPFilterHandler(filter *middleware.IPFilter) *IPFilterHandler {
+	return &IPFilterHandler{filter: filter}
+}
+
+// GET /admin/network/denylist
+func (h *IPFilterHandler) ListDenied(c *gin.Context) {
+	if h.filter == nil {
+		utils.ErrorResponse(c, http.StatusServiceUnavailable, "IP filter administration unavailable", "IP filtering is not enabled")
+		return
+	}
+
+	entries, err := h.filter.Entries(c.Request.Context())
+	if err != nil {
+		utils.InternalServerErrorResponse(c, "Failed to retrieve deny list", err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Deny list retrieved successfully", gin.H{
+		"entries": entries,
+	})
+}
+
+// POST /admin/network/denylist
+func (h *IPFilterHandler) Deny(c *gin.Context) {
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		utils.UnauthorizedResponse(c, "User not authenticated")
+		return
+	}
+
+	if h.filter == nil {
+		utils.ErrorResponse(c, http.StatusServiceUnavailable, "IP filter administration unavailable", "IP filtering is not enabled")
+		return
+	}
+
+	req, ok := utils.BindJSON[DenyNetworkRequest](c)
+	if !ok {
+		return
+	}
+
+	prefix, err := ipfilter.ParsePrefix(req.CIDR)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid CIDR", err)
+		return
+	}
+	// Refuse to lock the caller out, and with them the way to undo it
+	if addr, err := netip.ParseAddr(c.ClientIP()); err == nil && prefix.Contains(addr.Unmap()) {
+		utils.BadRequestResponse(c, "Deny list entry would block your own address", nil)
+		return
+	}
+
+	entry := ipfilter.Entry{
+		CIDR:      prefix.String(),
+		Reason:    req.Reason,
+		CreatedBy: userID.String(),
+		CreatedAt: time.Now().UTC(),
+	}
+	if err := h.filter.Deny(c.Request.Context(), entry); err != nil {
+		utils.InternalServerErrorResponse(c, "Failed to update deny list", err)
+		return
+	}
+
+	middleware.LogSecurityEvent("ip_denylisted", map[string]interface{}{
+		"user_id": userID,
+		"cidr":    entry.CIDR,
+		"reason":  entry.Reason,
+	})
+
+	utils.SuccessResponse(c, http.StatusCreated, "Network denied successfully", entry)
+}
+
+// DELETE /admin/network/denylist?cidr=
+func (h *IPFilterHandler) Undeny(c *gin.Context) {
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		utils.UnauthorizedResponse(c, "User not authenticated")
+		return
+	}
+
+	if h.filter == nil {
+		utils.ErrorResponse(c, http.StatusServiceUnavailable, "IP filter administration unavailable", "IP filtering is not enabled")
+		return
+	}
+
+	prefix, err := ipfilter.ParsePrefix(c.Query("cidr"))
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid CIDR", err)
+		return
+	}
+
+	removed, err := h.filter.Undeny(c.Request.Context(), prefix.String())
+	if err != nil {
+		utils.InternalServerErrorResponse(c, "Failed to update deny list", err)
+		return
+	}
+	if !removed {
+		utils.NotFoundResponse(c, "Network is not on the deny list")
+		return
+	}
+
+	middleware.LogSecurityEvent("ip_denylist_removed", map[string]interface{}{
+		"user_id": userID,
+		"cidr":    prefix.String(),
+	})
+
+	utils.SuccessResponse(c, http.StatusOK, "Network removed from deny list successfully", gin.H{
+		"cidr": prefix.String(),
+	})
+}
@@ -0,0 +1,68 @@
+package handler
+
+import (
+	"asset-management-api/internal/middleware"
+	"asset-management-api/internal/models"
+	"asset-management-api/internal/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// apiBasePath is the path the handlers are served under
+const apiBasePath = "/api/v1"
+
+func folderPath(folderID uuid.UUID) string {
+	return apiBasePath + "/folders/" + folderID.String()
+}
+
+func notePath(noteID uuid.UUID) string {
+	return apiBasePath + "/notes/" + noteID.String()
+}
+
+func teamPath(teamID uuid.UUID) string {
+	return apiBasePath + "/teams/" + teamID.String()
+}
+
+// folderLinks links a folder to itself, its notes, its shares and its owner
+func folderLinks(c *gin.Context, folder *models.Folder) utils.Links {
+	self := folderPath(folder.FolderID)
+	links := utils.Links{
+		"self":   {Href: self},
+		"notes":  {Href: self + "/notes"},
+		"shares": {Href: self + "/shares"},
+	}
+	addOwnerLink(c, links, folder.OwnerID)
+	return links
+}
+
+// noteLinks links a note to itself, its folder, its shares and its owner
+func noteLinks(c *gin.Context, note *models.Note) utils.Links {
+	self := notePath(note.NoteID)
+	links := utils.Links{
+		"self":   {Href: self},
+		"folder": {Href: folderPath(note.FolderID)},
+		"shares": {Href: self + "/shares"},
+	}
+	addOwnerLink(c, links, note.OwnerID)
+	return links
+}
+
+// teamAssetsLinks links a team's assets to themselves, the team and its
+// webhooks
+func teamAssetsLinks(teamID uuid.UUID) utils.Links {
+	team := teamPath(teamID)
+	return utils.Links{
+		"self":     {Href: team + "/assets"},
+		"team":     {Href: team},
+		"webhooks": {Href: team + "/webhooks"},
+	}
+}
+
+// addOwnerLink links an asset to its owner's assets. Only managers can list
+// another user's assets, so nobody else gets a link they cannot follow.
+func addOwnerLink(c *gin.Context, links utils.Links, ownerID uuid.UUID) {
+	if role, _ := middleware.GetUserRoleFromContext(c); role == "manager" {
+		links["owner"] = utils.Link{Href: apiBasePath + "/users/" + ownerID.String() + "/assets"}
+	}
+}
@@ -0,0 +1,105 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"asset-management-api/internal/middleware"
+	"asset-management-api/internal/utils"
+	"asset-management-api/pkg/maintenance"
+
+	"github.com/gin-gonic/gin"
+)
+
+// MaintenanceHandler switches maintenance mode on and off
+type MaintenanceHandler struct {
+	maintenance *middleware.Maintenance
+}
+
+type EnableMaintenanceRequest struct {
+	Message    string `json:"message" validate:"max=500"`
+	RetryAfter int    `json:"retry_after_seconds" validate:"min=0,max=86400"`
+}
+
+// NewMaintenanceHandler creates a new maintenance handler
+func NewMaintenanceHandler(maintenance *middleware.Maintenance) *MaintenanceHandler {
+	return &MaintenanceHandler{maintenance: maintenance}
+}
+
+// GET /admin/maintenance
+func (h *MaintenanceHandler) GetStatus(c *gin.Context) {
+	state := h.maintenance.State()
+	utils.SuccessResponse(c, http.StatusOK, "Maintenance status retrieved successfully", gin.H{
+		"enabled": state != nil,
+		"state":   state,
+	})
+}
+
+// PUT /admin/maintenance
+func (h *MaintenanceHandler) Enable(c *gin.Context) {
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		utils.UnauthorizedResponse(c, "User not authenticated")
+		return
+	}
+
+	var req EnableMaintenanceRequest
+	// The body is optional: the configured message and retry delay apply
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			utils.BadRequestResponse(c, "Invalid request format", err)
+			return
+		}
+	}
+
+	// Validate request
+	if errors := utils.ValidateRequest(c, req); len(errors) > 0 {
+		utils.ValidationErrorResponse(c, utils.GetValidationErrorMessages(errors))
+		return
+	}
+
+	state, err := h.maintenance.Enable(c.Request.Context(), maintenance.State{
+		Message:    req.Message,
+		RetryAfter: req.RetryAfter,
+		StartedBy:  userID.String(),
+		StartedAt:  time.Now().UTC(),
+	})
+	if err != nil {
+		utils.InternalServerErrorResponse(c, "Failed to enable maintenance mode", err)
+		return
+	}
+
+	middleware.LogSecurityEvent("maintenance_enabled", map[string]interface{}{
+		"user_id": userID,
+		"message": state.Message,
+	})
+
+	utils.SuccessResponse(c, http.StatusOK, "Maintenance mode enabled successfully", state)
+}
+
+// DELETE /admin/maintenance
+func (h *MaintenanceHandler) Disable(c *gin.Context) {
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		utils.UnauthorizedResponse(c, "User not authenticated")
+		return
+	}
+
+	disabled, err := h.maintenance.Disable(c.Request.Context())
+	if err != nil {
+		utils.InternalServerErrorResponse(c, "Failed to disable maintenance mode", err)
+		return
+	}
+	if !disabled {
+		utils.NotFoundResponse(c, "Maintenance mode is not enabled")
+		return
+	}
+
+	middleware.LogSecurityEvent("maintenance_disabled", map[string]interface{}{
+		"user_id": userID,
+	})
+
+	utils.SuccessResponse(c, http.StatusOK, "Maintenance mode disabled successfully", gin.H{
+		"enabled": false,
+	})
+}
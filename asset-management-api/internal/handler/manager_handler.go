@@ -33,25 +33,13 @@ func (h *ManagerHandler) GetTeamAssets(c *gin.Context) {
 		return
 	}
 
-	assets, err := h.managerService.GetTeamAssets(teamID, managerID)
+	assets, err := h.managerService.GetTeamAssets(c.Request.Context(), teamID, managerID)
 	if err != nil {
-		if err.Error() == "access denied: only managers can view team assets" {
-			utils.ForbiddenResponse(c, "Manager role required")
-			return
-		}
-		if err.Error() == "access denied: you are not a manager of this team" {
-			utils.ForbiddenResponse(c, "Access denied")
-			return
-		}
-		if err.Error() == "team not found: record not found" {
-			utils.NotFoundResponse(c, "Team not found")
-			return
-		}
-		utils.InternalServerErrorResponse(c, "Failed to get team assets", err)
+		serviceErrorResponse(c, "Failed to get team assets", err)
 		return
 	}
 
-	utils.SuccessResponse(c, http.StatusOK, "Team assets retrieved successfully", assets)
+	utils.NewResponse(c).Links(teamAssetsLinks(teamID)).Send(http.StatusOK, "Team assets retrieved successfully", assets)
 }
 
 // GET /users/:userId/assets
@@ -69,17 +57,9 @@ func (h *ManagerHandler) GetUserAssets(c *gin.Context) {
 		return
 	}
 
-	assets, err := h.managerService.GetUserAssets(targetUserID, managerID)
+	assets, err := h.managerService.GetUserAssets(c.Request.Context(), targetUserID, managerID)
 	if err != nil {
-		if err.Error() == "access denied: only managers can view user assets" {
-			utils.ForbiddenResponse(c, "Manager role required")
-			return
-		}
-		if err.Error() == "access denied: you can only view assets of users in your teams" {
-			utils.ForbiddenResponse(c, "Access denied")
-			return
-		}
-		utils.InternalServerErrorResponse(c, "Failed to get user assets", err)
+		serviceErrorResponse(c, "Failed to get user assets", err)
 		return
 	}
 
@@ -43,29 +43,18 @@ func (h *NoteHandler) CreateNote(c *gin.Context) {
 		return
 	}
 
-	var req CreateNoteRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		utils.BadRequestResponse(c, "Invalid request format", err)
+	req, ok := utils.BindJSON[CreateNoteRequest](c)
+	if !ok {
 		return
 	}
 
-	// Validate request
-	if errors := utils.ValidateStruct(req); len(errors) > 0 {
-		utils.ValidationErrorResponse(c, utils.GetValidationErrorMessages(errors))
-		return
-	}
-
-	note, err := h.noteService.CreateNote(userID, folderID, req.Title, req.Body)
+	note, err := h.noteService.CreateNote(c.Request.Context(), userID, folderID, req.Title, req.Body)
 	if err != nil {
-		if err.Error() == "access denied: you don't have write permission for this folder" {
-			utils.ForbiddenResponse(c, "Access denied")
-			return
-		}
-		utils.InternalServerErrorResponse(c, "Failed to create note", err)
+		serviceErrorResponse(c, "Failed to create note", err)
 		return
 	}
 
-	utils.SuccessResponse(c, http.StatusCreated, "Note created successfully", note)
+	utils.NewResponse(c).Links(noteLinks(c, note)).Send(http.StatusCreated, "Note created successfully", note)
 }
 
 // GET /notes/:noteId
@@ -83,21 +72,19 @@ func (h *NoteHandler) GetNote(c *gin.Context) {
 		return
 	}
 
-	note, err := h.noteService.GetNote(noteID, userID)
+	note, err := h.noteService.GetNote(c.Request.Context(), noteID, userID)
 	if err != nil {
-		if err.Error() == "note not found" {
-			utils.NotFoundResponse(c, "Note not found")
-			return
-		}
-		if err.Error() == "access denied: you don't have permission to view this note" {
-			utils.ForbiddenResponse(c, "Access denied")
-			return
-		}
-		utils.InternalServerErrorResponse(c, "Failed to get note", err)
+		serviceErrorResponse(c, "Failed to get note", err)
 		return
 	}
 
-	utils.SuccessResponse(c, http.StatusOK, "Note retrieved successfully", note)
+	// A client holding the current version gets 304, without the note being
+	// serialized again; on a metadata cache hit that skips the database as well
+	if utils.NotModified(c, utils.WeakETag(note.UpdatedAt)) {
+		return
+	}
+
+	utils.NewResponse(c).Links(noteLinks(c, note)).Send(http.StatusOK, "Note retrieved successfully", note)
 }
 
 // PUT /notes/:noteId
@@ -115,33 +102,18 @@ func (h *NoteHandler) UpdateNote(c *gin.Context) {
 		return
 	}
 
-	var req UpdateNoteRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		utils.BadRequestResponse(c, "Invalid request format", err)
+	req, ok := utils.BindJSON[UpdateNoteRequest](c)
+	if !ok {
 		return
 	}
 
-	// Validate request
-	if errors := utils.ValidateStruct(req); len(errors) > 0 {
-		utils.ValidationErrorResponse(c, utils.GetValidationErrorMessages(errors))
-		return
-	}
-
-	note, err := h.noteService.UpdateNote(noteID, userID, req.Title, req.Body)
+	note, err := h.noteService.UpdateNote(c.Request.Context(), noteID, userID, req.Title, req.Body)
 	if err != nil {
-		if err.Error() == "note not found" {
-			utils.NotFoundResponse(c, "Note not found")
-			return
-		}
-		if err.Error() == "access denied: you don't have write permission for this note" {
-			utils.ForbiddenResponse(c, "Access denied")
-			return
-		}
-		utils.InternalServerErrorResponse(c, "Failed to update note", err)
+		serviceErrorResponse(c, "Failed to update note", err)
 		return
 	}
 
-	utils.SuccessResponse(c, http.StatusOK, "Note updated successfully", note)
+	utils.NewResponse(c).Links(noteLinks(c, note)).Send(http.StatusOK, "Note updated successfully", note)
 }
 
 // DELETE /notes/:noteId
@@ -159,20 +131,16 @@ func (h *NoteHandler) DeleteNote(c *gin.Context) {
 		return
 	}
 
-	err = h.noteService.DeleteNote(noteID, userID)
+	err = h.noteService.DeleteNote(c.Request.Context(), noteID, userID)
 	if err != nil {
-		if err.Error() == "access denied: only the note owner can delete it" {
-			utils.ForbiddenResponse(c, "Access denied")
-			return
-		}
-		utils.InternalServerErrorResponse(c, "Failed to delete note", err)
+		serviceErrorResponse(c, "Failed to delete note", err)
 		return
 	}
 
 	utils.SuccessResponse(c, http.StatusOK, "Note deleted successfully", nil)
 }
 
-// GET /folders/:folderId/notes
+// GET /folders/:folderId/notes?limit=50&cursor=
 func (h *NoteHandler) GetNotesByFolder(c *gin.Context) {
 	userID, exists := middleware.GetUserIDFromContext(c)
 	if !exists {
@@ -187,20 +155,30 @@ func (h *NoteHandler) GetNotesByFolder(c *gin.Context) {
 		return
 	}
 
-	notes, err := h.noteService.GetNotesByFolder(folderID, userID)
-	if err != nil {
-		if err.Error() == "access denied: you don't have permission to view this folder" {
-			utils.ForbiddenResponse(c, "Access denied")
+	req, ok := pageRequest(c)
+	if !ok {
+		return
+	}
+	if req != nil {
+		page, err := h.noteService.ListNotesByFolder(c.Request.Context(), folderID, userID, *req)
+		if err != nil {
+			serviceErrorResponse(c, "Failed to get notes", err)
 			return
 		}
-		utils.InternalServerErrorResponse(c, "Failed to get notes", err)
+		pageResponse(c, "Notes retrieved successfully", page, req)
+		return
+	}
+
+	notes, err := h.noteService.GetNotesByFolder(c.Request.Context(), folderID, userID)
+	if err != nil {
+		serviceErrorResponse(c, "Failed to get notes", err)
 		return
 	}
 
 	utils.SuccessResponse(c, http.StatusOK, "Notes retrieved successfully", notes)
 }
 
-// GET /notes (Get user's notes)
+// GET /notes?limit=50&cursor= (Get user's notes; paged when limit or cursor is given)
 func (h *NoteHandler) GetUserNotes(c *gin.Context) {
 	userID, exists := middleware.GetUserIDFromContext(c)
 	if !exists {
@@ -208,7 +186,21 @@ func (h *NoteHandler) GetUserNotes(c *gin.Context) {
 		return
 	}
 
-	notes, err := h.noteService.GetUserNotes(userID)
+	req, ok := pageRequest(c)
+	if !ok {
+		return
+	}
+	if req != nil {
+		page, err := h.noteService.ListUserNotes(c.Request.Context(), userID, *req)
+		if err != nil {
+			utils.InternalServerErrorResponse(c, "Failed to get notes", err)
+			return
+		}
+		pageResponse(c, "Notes retrieved successfully", page, req)
+		return
+	}
+
+	notes, err := h.noteService.GetUserNotes(c.Request.Context(), userID)
 	if err != nil {
 		utils.InternalServerErrorResponse(c, "Failed to get notes", err)
 		return
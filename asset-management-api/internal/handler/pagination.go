@@ -0,0 +1,62 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"asset-management-api/internal/models"
+	"asset-management-api/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Page sizes of list endpoints read by cursor
+const (
+	defaultPageSize = 50
+	maxPageSize     = 100
+)
+
+// pageRequest reads the limit and cursor query parameters of a list request.
+// It returns nil when neither is given, in which case the whole list is
+// returned as before pagination was added. Invalid parameters are answered
+// with 400 and reported by ok being false.
+func pageRequest(c *gin.Context) (req *models.PageRequest, ok bool) {
+	limitParam, hasLimit := c.GetQuery("limit")
+	cursorParam, hasCursor := c.GetQuery("cursor")
+	if !hasLimit && !hasCursor {
+		return nil, true
+	}
+
+	req = &models.PageRequest{Limit: defaultPageSize}
+	if hasLimit {
+		limit, err := strconv.Atoi(limitParam)
+		if err != nil || limit < 1 || limit > maxPageSize {
+			utils.BadRequestResponse(c, fmt.Sprintf("Query parameter 'limit' must be between 1 and %d", maxPageSize), err)
+			return nil, false
+		}
+		req.Limit = limit
+	}
+	if cursorParam != "" {
+		after, err := models.ParseCursor(cursorParam)
+		if err != nil {
+			utils.BadRequestResponse(c, "Query parameter 'cursor' is not a cursor returned by this endpoint", err)
+			return nil, false
+		}
+		req.After = after
+	}
+	return req, true
+}
+
+// pageResponse writes a page of a list with the cursor and link of the next page
+func pageResponse[T any](c *gin.Context, message string, page *models.Page[T], req *models.PageRequest) {
+	pagination := &utils.CursorPagination{Limit: req.Limit, HasMore: page.Next != nil}
+	if page.Next != nil {
+		pagination.NextCursor = page.Next.String()
+	}
+	items := page.Items
+	if items == nil {
+		items = []T{}
+	}
+	utils.NewResponse(c).PageLinks(pagination).SendPage(http.StatusOK, message, items, pagination)
+}
@@ -0,0 +1,43 @@
+package handler
+
+import (
+	"net/http"
+	"sort"
+
+	"asset-management-api/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ProblemHandler documents the codes of the problem details, whose type URIs
+// point at it
+type ProblemHandler struct{}
+
+// NewProblemHandler creates a new problem type handler
+func NewProblemHandler() *ProblemHandler {
+	return &ProblemHandler{}
+}
+
+// GET /problems
+func (h *ProblemHandler) ListProblemTypes(c *gin.Context) {
+	types := make([]utils.ProblemType, 0, len(utils.ProblemTypes()))
+	for _, problemType := range utils.ProblemTypes() {
+		problemType.Title = utils.Translate(c, problemType.Title)
+		types = append(types, problemType)
+	}
+	sort.Slice(types, func(i, j int) bool {
+		return types[i].Code < types[j].Code
+	})
+	utils.SuccessResponse(c, http.StatusOK, "Problem types retrieved successfully", types)
+}
+
+// GET /problems/:code
+func (h *ProblemHandler) GetProblemType(c *gin.Context) {
+	problemType, ok := utils.ProblemTypes()[c.Param("code")]
+	if !ok {
+		utils.NotFoundResponse(c, "Problem type not found")
+		return
+	}
+	problemType.Title = utils.Translate(c, problemType.Title)
+	utils.SuccessResponse(c, http.StatusOK, "Problem type retrieved successfully", problemType)
+}
@@ -0,0 +1,40 @@
+package handler
+
+import (
+	"net/http"
+
+	"asset-management-api/internal/middleware"
+	"asset-management-api/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// QuotaHandler reports callers' consumption of their API quotas
+type QuotaHandler struct {
+	quotas *middleware.Quotas
+}
+
+// NewQuotaHandler creates a new quota handler; quotas is nil when quotas are disabled
+func NewQuotaHandler(quotas *middleware.Quotas) *QuotaHandler {
+	return &QuotaHandler{quotas: quotas}
+}
+
+// GET /quota
+func (h *QuotaHandler) GetUsage(c *gin.Context) {
+	if h.quotas == nil {
+		utils.ErrorResponse(c, http.StatusServiceUnavailable, "Quota usage unavailable", "API quotas are not enabled")
+		return
+	}
+
+	usage, err := h.quotas.Usage(c)
+	if err != nil {
+		utils.InternalServerErrorResponse(c, "Failed to get quota usage", err)
+		return
+	}
+
+	role, _ := middleware.GetUserRoleFromContext(c)
+	utils.SuccessResponse(c, http.StatusOK, "Quota usage retrieved successfully", gin.H{
+		"role":  role,
+		"usage": usage,
+	})
+}
@@ -0,0 +1,300 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"asset-management-api/internal/middleware"
+	"asset-management-api/internal/realtime"
+	"asset-management-api/internal/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
+)
+
+const (
+	// socketWriteTimeout bounds each write to a WebSocket
+	socketWriteTimeout = 10 * time.Second
+
+	// maxSocketMessage bounds the messages read from a WebSocket; clients
+	// only send control frames
+	maxSocketMessage = 512
+)
+
+type RealtimeHandler struct {
+	hub       *realtime.Hub
+	heartbeat time.Duration
+	upgrader  websocket.Upgrader
+}
+
+// NewRealtimeHandler creates the realtime handler; allowedOrigins lists the
+// origins browsers may open the WebSocket from, see RealtimeConfig
+func NewRealtimeHandler(hub *realtime.Hub, heartbeat time.Duration, allowedOrigins []string) *RealtimeHandler {
+	if heartbeat <= 0 {
+		heartbeat = 25 * time.Second
+	}
+	return &RealtimeHandler{
+		hub:       hub,
+		heartbeat: heartbeat,
+		upgrader: websocket.Upgrader{
+			Subprotocols: []string{middleware.WebSocketBearerProtocol},
+			CheckOrigin:  originChecker(allowedOrigins),
+		},
+	}
+}
+
+// originChecker allows WebSocket handshakes from the allowed origins. Without
+// any only the API's own origin is allowed, and requests without an Origin
+// header do not come from a browser.
+func originChecker(allowedOrigins []string) func(r *http.Request) bool {
+	allowed := make(map[string]bool, len(allowedOrigins))
+	for _, origin := range allowedOrigins {
+		allowed[strings.ToLower(strings.TrimSuffix(origin, "/"))] = true
+	}
+	return func(r *http.Request) bool {
+		origin := r.Header.Get("Origin")
+		if origin == "" || allowed["*"] || allowed[strings.ToLower(origin)] {
+			return true
+		}
+		if len(allowed) > 0 {
+			return false
+		}
+		u, err := url.Parse(origin)
+		return err == nil && strings.EqualFold(u.Host, r.Host)
+	}
+}
+
+// GET /events/stream
+// Streams the asset and team events visible to the user as Server-Sent
+// Events. The stream ends when the client falls behind. Only asset events
+// carry an ID: a client reconnecting with the Last-Event-ID header (or the
+// lastEventId query parameter) first gets the asset events it missed, or a
+// "resync" event when they cannot be replayed and it has to refetch the
+// assets it displays.
+func (h *RealtimeHandler) StreamEvents(c *gin.Context) {
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		utils.UnauthorizedResponse(c, "User not authenticated")
+		return
+	}
+
+	lastEventID := c.GetHeader("Last-Event-ID")
+	if lastEventID == "" {
+		lastEventID = c.Query("lastEventId")
+	}
+
+	client, err := h.hub.Register(userID)
+	if err != nil {
+		if errors.Is(err, realtime.ErrTooManyConnections) {
+			utils.ErrorResponse(c, http.StatusTooManyRequests, "Too many open event streams", err.Error())
+			return
+		}
+		utils.ErrorResponse(c, http.StatusServiceUnavailable, "Event stream unavailable", err.Error())
+		return
+	}
+	defer h.hub.Unregister(client)
+
+	// The server write timeout would otherwise cut the stream short
+	if err := http.NewResponseController(c.Writer).SetWriteDeadline(time.Time{}); err != nil {
+		middleware.LogError(err, map[string]interface{}{
+			"component": "realtime",
+			"action":    "clear_write_deadline",
+		})
+	}
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no") // Disable proxy buffering
+	c.Status(http.StatusOK)
+	c.Writer.Flush()
+
+	// The client is registered first, so events published while the missed
+	// ones are read are not lost; replayed events that also arrive live are
+	// skipped
+	var replayed map[string]bool
+	if lastEventID != "" {
+		messages := h.replay(c, userID, lastEventID)
+		if messages == nil {
+			if _, err := fmt.Fprint(c.Writer, "event: resync\ndata: {}\n\n"); err != nil {
+				return
+			}
+		}
+		replayed = make(map[string]bool, len(messages))
+		for _, message := range messages {
+			if err := writeStreamEvent(c.Writer, message); err != nil {
+				return
+			}
+			replayed[message.ID] = true
+		}
+		c.Writer.Flush()
+	}
+
+	heartbeat := time.NewTicker(h.heartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-c.Request.Context().Done():
+			return
+
+		case message, ok := <-client.Events():
+			if !ok {
+				return
+			}
+			if replayed[message.ID] {
+				continue
+			}
+			if err := writeStreamEvent(c.Writer, message); err != nil {
+				return
+			}
+			c.Writer.Flush()
+
+		case <-heartbeat.C:
+			// A comment line keeps proxies from closing an idle stream
+			if _, err := fmt.Fprint(c.Writer, ": heartbeat\n\n"); err != nil {
+				return
+			}
+			c.Writer.Flush()
+		}
+	}
+}
+
+// replay returns the asset events the user missed since lastEventID, or nil
+// when the stream cannot resume
+func (h *RealtimeHandler) replay(c *gin.Context, userID uuid.UUID, lastEventID string) []realtime.Message {
+	eventID, err := uuid.Parse(lastEventID)
+	if err != nil {
+		return nil
+	}
+	messages, err := h.hub.Replay(c.Request.Context(), userID, eventID)
+	if err != nil {
+		if !errors.Is(err, realtime.ErrCannotResume) {
+			middleware.LogError(err, map[string]interface{}{
+				"component": "realtime",
+				"action":    "replay_events",
+				"user_id":   userID,
+			})
+		}
+		return nil
+	}
+	if messages == nil {
+		messages = []realtime.Message{}
+	}
+	return messages
+}
+
+// writeStreamEvent writes a message as a Server-Sent Event. Only resumable
+// messages set the ID, so the client's Last-Event-ID stays on the last event
+// it can resume after.
+func writeStreamEvent(w io.Writer, message realtime.Message) error {
+	if message.Resumable() {
+		if _, err := fmt.Fprintf(w, "id: %s\n", message.ID); err != nil {
+			return err
+		}
+	}
+	_, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", message.Event, message.Data)
+	return err
+}
+
+// socketFrame is a message sent over the WebSocket
+type socketFrame struct {
+	ID    string          `json:"id"`
+	Topic string          `json:"topic"`
+	Event string          `json:"event"`
+	Data  json.RawMessage `json:"data"`
+}
+
+// GET /ws
+// Upgrades to a WebSocket delivering the user's notifications along with the
+// asset and team events of the event stream, one JSON frame per event.
+// Browsers, which cannot set the Authorization header, offer the token as the
+// subprotocols "bearer, <token>". The server pings every heartbeat and closes
+// the socket when the client falls behind or stops answering; clients
+// reconnect and refetch what they display.
+func (h *RealtimeHandler) Connect(c *gin.Context) {
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		utils.UnauthorizedResponse(c, "User not authenticated")
+		return
+	}
+	if !websocket.IsWebSocketUpgrade(c.Request) {
+		utils.ErrorResponse(c, http.StatusUpgradeRequired, "WebSocket upgrade required", "")
+		return
+	}
+
+	client, err := h.hub.Register(userID)
+	if err != nil {
+		if errors.Is(err, realtime.ErrTooManyConnections) {
+			utils.ErrorResponse(c, http.StatusTooManyRequests, "Too many open event streams", err.Error())
+			return
+		}
+		utils.ErrorResponse(c, http.StatusServiceUnavailable, "Event stream unavailable", err.Error())
+		return
+	}
+	defer h.hub.Unregister(client)
+
+	// The upgrader writes the handshake error response itself
+	conn, err := h.upgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	// Reading notices pongs and the client going away; the read deadline
+	// replaces the server's read timeout, which would cut the socket short
+	closed := make(chan struct{})
+	pongWait := 2 * h.heartbeat
+	conn.SetReadLimit(maxSocketMessage)
+	_ = conn.SetReadDeadline(time.Now().Add(pongWait))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(pongWait))
+	})
+	go func() {
+		defer close(closed)
+		for {
+			if _, _, err := conn.NextReader(); err != nil {
+				return
+			}
+		}
+	}()
+
+	heartbeat := time.NewTicker(h.heartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-closed:
+			return
+
+		case message, ok := <-client.Events():
+			if !ok {
+				h.closeSocket(conn, websocket.CloseGoingAway, "event stream ended")
+				return
+			}
+			_ = conn.SetWriteDeadline(time.Now().Add(socketWriteTimeout))
+			frame := socketFrame{ID: message.ID, Topic: message.Topic, Event: message.Event, Data: message.Data}
+			if err := conn.WriteJSON(frame); err != nil {
+				return
+			}
+
+		case <-heartbeat.C:
+			if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(socketWriteTimeout)); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// closeSocket tells the client why the socket is closing
+func (h *RealtimeHandler) closeSocket(conn *websocket.Conn, code int, reason string) {
+	message := websocket.FormatCloseMessage(code, reason)
+	_ = conn.WriteControl(websocket.CloseMessage, message, time.Now().Add(socketWriteTimeout))
+}
@@ -34,15 +34,8 @@ func (h *ShareHandler) ShareFolder(c *gin.Context) {
 		return
 	}
 
-	var req models.ShareRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		utils.BadRequestResponse(c, "Invalid request format", err)
-		return
-	}
-
-	// Validate request
-	if errors := utils.ValidateStruct(req); len(errors) > 0 {
-		utils.ValidationErrorResponse(c, utils.GetValidationErrorMessages(errors))
+	req, ok := utils.BindJSON[models.ShareRequest](c)
+	if !ok {
 		return
 	}
 
@@ -52,17 +45,9 @@ func (h *ShareHandler) ShareFolder(c *gin.Context) {
 		return
 	}
 
-	err = h.shareService.ShareFolder(folderID, userID, targetUserID, req.AccessLevel)
+	err = h.shareService.ShareFolder(c.Request.Context(), folderID, userID, targetUserID, req.AccessLevel)
 	if err != nil {
-		if err.Error() == "access denied: only the folder owner can share it" {
-			utils.ForbiddenResponse(c, "Access denied")
-			return
-		}
-		if err.Error() == "cannot share folder with yourself" {
-			utils.BadRequestResponse(c, "Cannot share with yourself", err)
-			return
-		}
-		utils.InternalServerErrorResponse(c, "Failed to share folder", err)
+		serviceErrorResponse(c, "Failed to share folder", err)
 		return
 	}
 
@@ -91,20 +76,16 @@ func (h *ShareHandler) UnshareFolder(c *gin.Context) {
 		return
 	}
 
-	err = h.shareService.UnshareFolder(folderID, userID, targetUserID)
+	err = h.shareService.UnshareFolder(c.Request.Context(), folderID, userID, targetUserID)
 	if err != nil {
-		if err.Error() == "access denied: only the folder owner can unshare it" {
-			utils.ForbiddenResponse(c, "Access denied")
-			return
-		}
-		utils.InternalServerErrorResponse(c, "Failed to unshare folder", err)
+		serviceErrorResponse(c, "Failed to unshare folder", err)
 		return
 	}
 
 	utils.SuccessResponse(c, http.StatusOK, "Folder unshared successfully", nil)
 }
 
-// GET /folders/:folderId/shares
+// GET /folders/:folderId/shares?limit=50&cursor=
 func (h *ShareHandler) GetFolderShares(c *gin.Context) {
 	userID, exists := middleware.GetUserIDFromContext(c)
 	if !exists {
@@ -119,13 +100,23 @@ func (h *ShareHandler) GetFolderShares(c *gin.Context) {
 		return
 	}
 
-	shares, err := h.shareService.GetFolderShares(folderID, userID)
-	if err != nil {
-		if err.Error() == "access denied: only the folder owner can view shares" {
-			utils.ForbiddenResponse(c, "Access denied")
+	req, ok := pageRequest(c)
+	if !ok {
+		return
+	}
+	if req != nil {
+		page, err := h.shareService.ListFolderShares(c.Request.Context(), folderID, userID, *req)
+		if err != nil {
+			serviceErrorResponse(c, "Failed to get folder shares", err)
 			return
 		}
-		utils.InternalServerErrorResponse(c, "Failed to get folder shares", err)
+		pageResponse(c, "Folder shares retrieved successfully", page, req)
+		return
+	}
+
+	shares, err := h.shareService.GetFolderShares(c.Request.Context(), folderID, userID)
+	if err != nil {
+		serviceErrorResponse(c, "Failed to get folder shares", err)
 		return
 	}
 
@@ -147,15 +138,8 @@ func (h *ShareHandler) ShareNote(c *gin.Context) {
 		return
 	}
 
-	var req models.ShareRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		utils.BadRequestResponse(c, "Invalid request format", err)
-		return
-	}
-
-	// Validate request
-	if errors := utils.ValidateStruct(req); len(errors) > 0 {
-		utils.ValidationErrorResponse(c, utils.GetValidationErrorMessages(errors))
+	req, ok := utils.BindJSON[models.ShareRequest](c)
+	if !ok {
 		return
 	}
 
@@ -165,17 +149,9 @@ func (h *ShareHandler) ShareNote(c *gin.Context) {
 		return
 	}
 
-	err = h.shareService.ShareNote(noteID, userID, targetUserID, req.AccessLevel)
+	err = h.shareService.ShareNote(c.Request.Context(), noteID, userID, targetUserID, req.AccessLevel)
 	if err != nil {
-		if err.Error() == "access denied: only the note owner can share it" {
-			utils.ForbiddenResponse(c, "Access denied")
-			return
-		}
-		if err.Error() == "cannot share note with yourself" {
-			utils.BadRequestResponse(c, "Cannot share with yourself", err)
-			return
-		}
-		utils.InternalServerErrorResponse(c, "Failed to share note", err)
+		serviceErrorResponse(c, "Failed to share note", err)
 		return
 	}
 
@@ -204,20 +180,16 @@ func (h *ShareHandler) UnshareNote(c *gin.Context) {
 		return
 	}
 
-	err = h.shareService.UnshareNote(noteID, userID, targetUserID)
+	err = h.shareService.UnshareNote(c.Request.Context(), noteID, userID, targetUserID)
 	if err != nil {
-		if err.Error() == "access denied: only the note owner can unshare it" {
-			utils.ForbiddenResponse(c, "Access denied")
-			return
-		}
-		utils.InternalServerErrorResponse(c, "Failed to unshare note", err)
+		serviceErrorResponse(c, "Failed to unshare note", err)
 		return
 	}
 
 	utils.SuccessResponse(c, http.StatusOK, "Note unshared successfully", nil)
 }
 
-// GET /notes/:noteId/shares
+// GET /notes/:noteId/shares?limit=50&cursor=
 func (h *ShareHandler) GetNoteShares(c *gin.Context) {
 	userID, exists := middleware.GetUserIDFromContext(c)
 	if !exists {
@@ -232,13 +204,23 @@ func (h *ShareHandler) GetNoteShares(c *gin.Context) {
 		return
 	}
 
-	shares, err := h.shareService.GetNoteShares(noteID, userID)
-	if err != nil {
-		if err.Error() == "access denied: only the note owner can view shares" {
-			utils.ForbiddenResponse(c, "Access denied")
+	req, ok := pageRequest(c)
+	if !ok {
+		return
+	}
+	if req != nil {
+		page, err := h.shareService.ListNoteShares(c.Request.Context(), noteID, userID, *req)
+		if err != nil {
+			serviceErrorResponse(c, "Failed to get note shares", err)
 			return
 		}
-		utils.InternalServerErrorResponse(c, "Failed to get note shares", err)
+		pageResponse(c, "Note shares retrieved successfully", page, req)
+		return
+	}
+
+	shares, err := h.shareService.GetNoteShares(c.Request.Context(), noteID, userID)
+	if err != nil {
+		serviceErrorResponse(c, "Failed to get note shares", err)
 		return
 	}
 
@@ -0,0 +1,153 @@
+package handler
+
+import (
+	"asset-management-api/internal/middleware"
+	"asset-management-api/internal/models"
+	"asset-management-api/internal/service/interfaces"
+	"asset-management-api/internal/utils"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type SubscriptionHandler struct {
+	subscriptionService interfaces.SubscriptionService
+}
+
+func NewSubscriptionHandler(subscriptionService interfaces.SubscriptionService) *SubscriptionHandler {
+	return &SubscriptionHandler{subscriptionService: subscriptionService}
+}
+
+// POST /subscriptions
+func (h *SubscriptionHandler) Subscribe(c *gin.Context) {
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		utils.UnauthorizedResponse(c, "User not authenticated")
+		return
+	}
+
+	req, ok := utils.BindJSON[models.CreateSubscriptionRequest](c)
+	if !ok {
+		return
+	}
+
+	assetID, err := uuid.Parse(req.AssetID)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid asset ID format", err)
+		return
+	}
+
+	subscription, err := h.subscriptionService.Subscribe(c.Request.Context(), userID, req.AssetType, assetID, req.EventTypes)
+	if err != nil {
+		serviceErrorResponse(c, "Failed to create subscription", err)
+		return
+	}
+
+	middleware.LogBusinessEvent("subscription_created", map[string]interface{}{
+		"user_id":         userID,
+		"subscription_id": subscription.SubscriptionID,
+		"asset_type":      subscription.AssetType,
+		"asset_id":        subscription.AssetID,
+	})
+
+	utils.SuccessResponse(c, http.StatusCreated, "Subscription created successfully", subscription)
+}
+
+// GET /subscriptions
+func (h *SubscriptionHandler) ListSubscriptions(c *gin.Context) {
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		utils.UnauthorizedResponse(c, "User not authenticated")
+		return
+	}
+
+	subscriptions, err := h.subscriptionService.ListSubscriptions(c.Request.Context(), userID)
+	if err != nil {
+		utils.InternalServerErrorResponse(c, "Failed to get subscriptions", err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Subscriptions retrieved successfully", subscriptions)
+}
+
+// DELETE /subscriptions/:subscriptionId
+func (h *SubscriptionHandler) Unsubscribe(c *gin.Context) {
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		utils.UnauthorizedResponse(c, "User not authenticated")
+		return
+	}
+
+	subscriptionID, err := uuid.Parse(c.Param("subscriptionId"))
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid subscription ID format", err)
+		return
+	}
+
+	err = h.subscriptionService.Unsubscribe(c.Request.Context(), subscriptionID, userID)
+	if err != nil {
+		serviceErrorResponse(c, "Failed to delete subscription", err)
+		return
+	}
+
+	middleware.LogBusinessEvent("subscription_deleted", map[string]interface{}{
+		"user_id":         userID,
+		"subscription_id": subscriptionID,
+	})
+
+	utils.SuccessResponse(c, http.StatusOK, "Subscription deleted successfully", nil)
+}
+
+// GET /notifications?unread=true&limit=50
+func (h *SubscriptionHandler) GetNotifications(c *gin.Context) {
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		utils.UnauthorizedResponse(c, "User not authenticated")
+		return
+	}
+
+	unreadOnly, err := strconv.ParseBool(c.DefaultQuery("unread", "false"))
+	if err != nil {
+		utils.BadRequestResponse(c, "Query parameter 'unread' must be a boolean", err)
+		return
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	if err != nil || limit < 1 {
+		utils.BadRequestResponse(c, "Query parameter 'limit' must be a positive integer", err)
+		return
+	}
+
+	notifications, err := h.subscriptionService.GetNotifications(c.Request.Context(), userID, unreadOnly, limit)
+	if err != nil {
+		utils.InternalServerErrorResponse(c, "Failed to get notifications", err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Notifications retrieved successfully", notifications)
+}
+
+// POST /notifications/:notificationId/read
+func (h *SubscriptionHandler) MarkNotificationRead(c *gin.Context) {
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		utils.UnauthorizedResponse(c, "User not authenticated")
+		return
+	}
+
+	notificationID, err := uuid.Parse(c.Param("notificationId"))
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid notification ID format", err)
+		return
+	}
+
+	err = h.subscriptionService.MarkNotificationRead(c.Request.Context(), notificationID, userID)
+	if err != nil {
+		serviceErrorResponse(c, "Failed to mark notification read", err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Notification marked as read", nil)
+}
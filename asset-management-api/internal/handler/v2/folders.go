@@ -0,0 +1,139 @@
+package v2
+
+import (
+	"asset-management-api/internal/service/interfaces"
+	"asset-management-api/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type FolderHandler struct {
+	folderService interfaces.FolderService
+}
+
+// folderRequest is the body creating a folder, and a folder after a patch
+type folderRequest struct {
+	Name        string `json:"name" validate:"required,min=1,max=255"`
+	Description string `json:"description" validate:"max=1000"`
+}
+
+func NewFolderHandler(folderService interfaces.FolderService) *FolderHandler {
+	return &FolderHandler{folderService: folderService}
+}
+
+// POST /api/v2/folders
+func (h *FolderHandler) CreateFolder(c *gin.Context) {
+	userID, ok := currentUser(c)
+	if !ok {
+		return
+	}
+	req, ok := bindJSON[folderRequest](c)
+	if !ok {
+		return
+	}
+
+	folder, err := h.folderService.CreateFolder(c.Request.Context(), userID, req.Name, req.Description)
+	if err != nil {
+		serviceError(c, err)
+		return
+	}
+
+	created(c, basePath+"/folders/"+folder.FolderID.String(), folder)
+}
+
+// GET /api/v2/folders?limit=50&cursor=
+func (h *FolderHandler) ListFolders(c *gin.Context) {
+	userID, ok := currentUser(c)
+	if !ok {
+		return
+	}
+	req, ok := pageRequest(c)
+	if !ok {
+		return
+	}
+
+	page, err := h.folderService.ListUserFolders(c.Request.Context(), userID, req)
+	if err != nil {
+		serviceError(c, err)
+		return
+	}
+
+	pageResponse(c, page, req)
+}
+
+// GET /api/v2/folders/:folderId
+func (h *FolderHandler) GetFolder(c *gin.Context) {
+	userID, ok := currentUser(c)
+	if !ok {
+		return
+	}
+	folderID, ok := pathID(c, "folderId")
+	if !ok {
+		return
+	}
+
+	folder, err := h.folderService.GetFolder(c.Request.Context(), folderID, userID)
+	if err != nil {
+		serviceError(c, err)
+		return
+	}
+	if utils.NotModified(c, utils.WeakETag(folder.UpdatedAt)) {
+		return
+	}
+
+	success(c, folder)
+}
+
+// PATCH /api/v2/folders/:folderId
+// Takes a merge patch of name and description.
+func (h *FolderHandler) UpdateFolder(c *gin.Context) {
+	userID, ok := currentUser(c)
+	if !ok {
+		return
+	}
+	folderID, ok := pathID(c, "folderId")
+	if !ok {
+		return
+	}
+	patch, ok := bindMergePatch(c, "name", "description")
+	if !ok {
+		return
+	}
+
+	folder, err := h.folderService.GetFolder(c.Request.Context(), folderID, userID)
+	if err != nil {
+		serviceError(c, err)
+		return
+	}
+	req := folderRequest{Name: folder.Name, Description: folder.Description}
+	if !patch.applyString(c, "name", &req.Name) || !patch.applyString(c, "description", &req.Description) || !validate(c, req) {
+		return
+	}
+
+	folder, err = h.folderService.UpdateFolder(c.Request.Context(), folderID, userID, req.Name, req.Description)
+	if err != nil {
+		serviceError(c, err)
+		return
+	}
+
+	success(c, folder)
+}
+
+// DELETE /api/v2/folders/:folderId
+func (h *FolderHandler) DeleteFolder(c *gin.Context) {
+	userID, ok := currentUser(c)
+	if !ok {
+		return
+	}
+	folderID, ok := pathID(c, "folderId")
+	if !ok {
+		return
+	}
+
+	if err := h.folderService.DeleteFolder(c.Request.Context(), folderID, userID); err != nil {
+		serviceError(c, err)
+		return
+	}
+
+	noContent(c)
+}
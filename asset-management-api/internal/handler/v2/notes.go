@@ -0,0 +1,167 @@
+package v2
+
+import (
+	"asset-management-api/internal/service/interfaces"
+	"asset-management-api/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type NoteHandler struct {
+	noteService interfaces.NoteService
+}
+
+// noteRequest is the body creating a note, and a note after a patch
+type noteRequest struct {
+	Title string `json:"title" validate:"required,min=1,max=255"`
+	Body  string `json:"body" validate:"max=10000"`
+}
+
+func NewNoteHandler(noteService interfaces.NoteService) *NoteHandler {
+	return &NoteHandler{noteService: noteService}
+}
+
+// POST /api/v2/folders/:folderId/notes
+func (h *NoteHandler) CreateNote(c *gin.Context) {
+	userID, ok := currentUser(c)
+	if !ok {
+		return
+	}
+	folderID, ok := pathID(c, "folderId")
+	if !ok {
+		return
+	}
+	req, ok := bindJSON[noteRequest](c)
+	if !ok {
+		return
+	}
+
+	note, err := h.noteService.CreateNote(c.Request.Context(), userID, folderID, req.Title, req.Body)
+	if err != nil {
+		serviceError(c, err)
+		return
+	}
+
+	created(c, basePath+"/notes/"+note.NoteID.String(), note)
+}
+
+// GET /api/v2/folders/:folderId/notes?limit=50&cursor=
+func (h *NoteHandler) ListFolderNotes(c *gin.Context) {
+	userID, ok := currentUser(c)
+	if !ok {
+		return
+	}
+	folderID, ok := pathID(c, "folderId")
+	if !ok {
+		return
+	}
+	req, ok := pageRequest(c)
+	if !ok {
+		return
+	}
+
+	page, err := h.noteService.ListNotesByFolder(c.Request.Context(), folderID, userID, req)
+	if err != nil {
+		serviceError(c, err)
+		return
+	}
+
+	pageResponse(c, page, req)
+}
+
+// GET /api/v2/notes?limit=50&cursor=
+func (h *NoteHandler) ListNotes(c *gin.Context) {
+	userID, ok := currentUser(c)
+	if !ok {
+		return
+	}
+	req, ok := pageRequest(c)
+	if !ok {
+		return
+	}
+
+	page, err := h.noteService.ListUserNotes(c.Request.Context(), userID, req)
+	if err != nil {
+		serviceError(c, err)
+		return
+	}
+
+	pageResponse(c, page, req)
+}
+
+// GET /api/v2/notes/:noteId
+func (h *NoteHandler) GetNote(c *gin.Context) {
+	userID, ok := currentUser(c)
+	if !ok {
+		return
+	}
+	noteID, ok := pathID(c, "noteId")
+	if !ok {
+		return
+	}
+
+	note, err := h.noteService.GetNote(c.Request.Context(), noteID, userID)
+	if err != nil {
+		serviceError(c, err)
+		return
+	}
+	if utils.NotModified(c, utils.WeakETag(note.UpdatedAt)) {
+		return
+	}
+
+	success(c, note)
+}
+
+// PATCH /api/v2/notes/:noteId
+// Takes a merge patch of title and body.
+func (h *NoteHandler) UpdateNote(c *gin.Context) {
+	userID, ok := currentUser(c)
+	if !ok {
+		return
+	}
+	noteID, ok := pathID(c, "noteId")
+	if !ok {
+		return
+	}
+	patch, ok := bindMergePatch(c, "title", "body")
+	if !ok {
+		return
+	}
+
+	note, err := h.noteService.GetNote(c.Request.Context(), noteID, userID)
+	if err != nil {
+		serviceError(c, err)
+		return
+	}
+	req := noteRequest{Title: note.Title, Body: note.Body}
+	if !patch.applyString(c, "title", &req.Title) || !patch.applyString(c, "body", &req.Body) || !validate(c, req) {
+		return
+	}
+
+	note, err = h.noteService.UpdateNote(c.Request.Context(), noteID, userID, req.Title, req.Body)
+	if err != nil {
+		serviceError(c, err)
+		return
+	}
+
+	success(c, note)
+}
+
+// DELETE /api/v2/notes/:noteId
+func (h *NoteHandler) DeleteNote(c *gin.Context) {
+	userID, ok := currentUser(c)
+	if !ok {
+		return
+	}
+	noteID, ok := pathID(c, "noteId")
+	if !ok {
+		return
+	}
+
+	if err := h.noteService.DeleteNote(c.Request.Context(), noteID, userID); err != nil {
+		serviceError(c, err)
+		return
+	}
+
+	noContent(c)
+}
@@ -0,0 +1,152 @@
+package v2
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net/http"
+	"sort"
+	"strconv"
+
+	"asset-management-api/internal/middleware"
+	"asset-management-api/internal/models"
+	"asset-management-api/internal/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// Page sizes of collections
+const (
+	defaultPageSize = 50
+	maxPageSize     = 100
+)
+
+// mergePatchType is the media type of JSON merge patches
+const mergePatchType = "application/merge-patch+json"
+
+// currentUser is the authenticated caller; without one it answers 401
+func currentUser(c *gin.Context) (uuid.UUID, bool) {
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		errorJSON(c, http.StatusUnauthorized, CodeUnauthenticated, "Authentication required")
+	}
+	return userID, exists
+}
+
+// pathID parses the UUID in the path parameter param, answering 400 when it
+// is not one
+func pathID(c *gin.Context, param string) (uuid.UUID, bool) {
+	id, err := uuid.Parse(c.Param(param))
+	if err != nil {
+		errorJSON(c, http.StatusBadRequest, CodeInvalidRequest, fmt.Sprintf("Path parameter '%s' must be a UUID", param))
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+// pageRequest reads the limit and cursor query parameters of a collection.
+// Unlike v1 a collection is always paged, with defaultPageSize items when no
+// limit is given.
+func pageRequest(c *gin.Context) (models.PageRequest, bool) {
+	req := models.PageRequest{Limit: defaultPageSize}
+	if limitParam, ok := c.GetQuery("limit"); ok {
+		limit, err := strconv.Atoi(limitParam)
+		if err != nil || limit < 1 || limit > maxPageSize {
+			errorJSON(c, http.StatusBadRequest, CodeInvalidRequest, fmt.Sprintf("Query parameter 'limit' must be between 1 and %d", maxPageSize))
+			return req, false
+		}
+		req.Limit = limit
+	}
+	if cursorParam := c.Query("cursor"); cursorParam != "" {
+		after, err := models.ParseCursor(cursorParam)
+		if err != nil {
+			errorJSON(c, http.StatusBadRequest, CodeInvalidRequest, "Query parameter 'cursor' is not a cursor returned by this endpoint")
+			return req, false
+		}
+		req.After = after
+	}
+	return req, true
+}
+
+// bindJSON binds the request body to a T and validates it, answering 400
+// for a body that is not JSON and 422 for one that fails validation
+func bindJSON[T any](c *gin.Context) (T, bool) {
+	var req T
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errorJSON(c, http.StatusBadRequest, CodeInvalidRequest, "The request body is not valid JSON for this endpoint")
+		return req, false
+	}
+	return req, validate(c, req)
+}
+
+// validate validates a request, answering 422 when it fails
+func validate(c *gin.Context, req interface{}) bool {
+	if details := utils.ValidateRequest(c, req); len(details) > 0 {
+		validationError(c, details)
+		return false
+	}
+	return true
+}
+
+// mergePatch is a JSON merge patch (RFC 7396) of the fields of a resource:
+// fields it leaves out keep their value and fields set to null are cleared
+type mergePatch map[string]json.RawMessage
+
+// bindMergePatch reads the merge patch in the request body, which may only
+// change fields. It answers 415 for a body of another media type than
+// application/merge-patch+json or application/json.
+func bindMergePatch(c *gin.Context, fields ...string) (mergePatch, bool) {
+	mediaType, _, err := mime.ParseMediaType(c.ContentType())
+	if err != nil || (mediaType != mergePatchType && mediaType != "application/json") {
+		c.Header("Accept-Patch", mergePatchType)
+		errorJSON(c, http.StatusUnsupportedMediaType, CodeUnsupportedMediaType, "PATCH requests take a body of type "+mergePatchType)
+		return nil, false
+	}
+
+	var patch mergePatch
+	body, err := c.GetRawData()
+	if err == nil {
+		err = json.Unmarshal(body, &patch)
+	}
+	if err != nil || patch == nil {
+		errorJSON(c, http.StatusBadRequest, CodeInvalidRequest, "A merge patch must be a JSON object")
+		return nil, false
+	}
+
+	var details []utils.ValidationError
+	for field := range patch {
+		known := false
+		for _, f := range fields {
+			known = known || f == field
+		}
+		if !known {
+			details = append(details, utils.ValidationError{Field: field, Message: "This field cannot be changed"})
+		}
+	}
+	if len(details) > 0 {
+		sort.Slice(details, func(i, j int) bool { return details[i].Field < details[j].Field })
+		validationError(c, details)
+		return nil, false
+	}
+	return patch, true
+}
+
+// applyString applies the patch of a string field to value, answering 422
+// when the patch sets it to something other than a string or null
+func (p mergePatch) applyString(c *gin.Context, field string, value *string) bool {
+	raw, ok := p[field]
+	if !ok {
+		return true
+	}
+	if bytes.Equal(raw, []byte("null")) {
+		*value = ""
+		return true
+	}
+	if err := json.Unmarshal(raw, value); err != nil {
+		validationError(c, []utils.ValidationError{{Field: field, Message: "Must be a string or null"}})
+		return false
+	}
+	return true
+}
@@ -0,0 +1,131 @@
+// Package v2 serves version 2 of the REST API, next to v1. Unlike v1 it
+// answers creations with 201 Created and the Location of the new resource,
+// updates with JSON merge patches (RFC 7396), names every collection in the
+// plural, pages every collection and gives every error a code clients can
+// switch on.
+package v2
+
+import (
+	"errors"
+	"net/http"
+
+	"asset-management-api/internal/models"
+	"asset-management-api/internal/service"
+	"asset-management-api/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// basePath is the prefix of the v2 routes, which Location headers start with
+const basePath = "/api/v2"
+
+// Codes of error responses. Messages are for people and may change; codes
+// are part of the API, shared with the problem details of v1.
+const (
+	CodeUnauthenticated      = utils.CodeUnauthenticated
+	CodeInvalidRequest       = utils.CodeInvalidRequest
+	CodeValidationFailed     = utils.CodeValidationFailed
+	CodeUnsupportedMediaType = utils.CodeUnsupportedMediaType
+	CodeForbidden            = utils.CodeForbidden
+	CodeNotFound             = utils.CodeNotFound
+	CodeConflict             = utils.CodeConflict
+	CodeInternal             = utils.CodeInternal
+)
+
+// Error is the error of an error response
+type Error struct {
+	Code      string                  `json:"code"`
+	Message   string                  `json:"message"`
+	Details   []utils.ValidationError `json:"details,omitempty"`
+	RequestID string                  `json:"request_id,omitempty"`
+}
+
+type errorResponse struct {
+	Error Error `json:"error"`
+}
+
+type response struct {
+	Data interface{} `json:"data"`
+}
+
+type listResponse struct {
+	Data       interface{}            `json:"data"`
+	Pagination utils.CursorPagination `json:"pagination"`
+}
+
+// errorJSON writes an error response and aborts the request. The message is
+// in the language of the request; the code stays the same in every language
+// and is that of the problem details for requests that take them.
+func errorJSON(c *gin.Context, status int, code, message string) {
+	if utils.WantsProblem(c) {
+		utils.ProblemResponse(c, utils.Problem{Status: status, Code: code, Detail: message})
+		c.Abort()
+		return
+	}
+	c.AbortWithStatusJSON(status, errorResponse{Error: Error{
+		Code:      code,
+		Message:   utils.Translate(c, message),
+		RequestID: c.GetString(utils.RequestIDKey),
+	}})
+}
+
+// validationError writes the 422 response of a request body failing validation
+func validationError(c *gin.Context, details []utils.ValidationError) {
+	if utils.WantsProblem(c) {
+		utils.ProblemResponse(c, utils.Problem{Status: http.StatusUnprocessableEntity, Code: CodeValidationFailed, Errors: details})
+		c.Abort()
+		return
+	}
+	c.AbortWithStatusJSON(http.StatusUnprocessableEntity, errorResponse{Error: Error{
+		Code:      CodeValidationFailed,
+		Message:   utils.Translate(c, "The request body failed validation"),
+		Details:   details,
+		RequestID: c.GetString(utils.RequestIDKey),
+	}})
+}
+
+// serviceError writes the response of an error returned by a service.
+// Refused requests get the status and code of their kind with the service's
+// message; anything else is an internal error, reported but not shown.
+func serviceError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrNotFound):
+		errorJSON(c, http.StatusNotFound, CodeNotFound, err.Error())
+	case errors.Is(err, service.ErrForbidden):
+		errorJSON(c, http.StatusForbidden, CodeForbidden, err.Error())
+	case errors.Is(err, service.ErrConflict):
+		errorJSON(c, http.StatusConflict, CodeConflict, err.Error())
+	case errors.Is(err, service.ErrInvalid):
+		errorJSON(c, http.StatusBadRequest, CodeInvalidRequest, err.Error())
+	default:
+		_ = c.Error(err)
+		errorJSON(c, http.StatusInternalServerError, CodeInternal, "The request could not be completed")
+	}
+}
+
+func success(c *gin.Context, data interface{}) {
+	c.JSON(http.StatusOK, response{Data: data})
+}
+
+// created answers a creation with the new resource and where it lives
+func created(c *gin.Context, location string, data interface{}) {
+	c.Header("Location", location)
+	c.JSON(http.StatusCreated, response{Data: data})
+}
+
+func noContent(c *gin.Context) {
+	c.Status(http.StatusNoContent)
+}
+
+// pageResponse writes a page of a collection with the cursor of the next page
+func pageResponse[T any](c *gin.Context, page *models.Page[T], req models.PageRequest) {
+	pagination := utils.CursorPagination{Limit: req.Limit, HasMore: page.Next != nil}
+	if page.Next != nil {
+		pagination.NextCursor = page.Next.String()
+	}
+	items := page.Items
+	if items == nil {
+		items = []T{}
+	}
+	c.JSON(http.StatusOK, listResponse{Data: items, Pagination: pagination})
+}
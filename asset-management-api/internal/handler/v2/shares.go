@@ -0,0 +1,256 @@
+package v2
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"asset-management-api/internal/models"
+	"asset-management-api/internal/service/interfaces"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type ShareHandler struct {
+	shareService interfaces.ShareService
+}
+
+type shareRequest struct {
+	UserID      string `json:"user_id" validate:"required,uuid"`
+	AccessLevel string `json:"access_level" validate:"required,oneof=read write"`
+}
+
+// Share is a folder or note share. v1 returns the share models, with an
+// empty copy of the shared folder or note.
+type Share struct {
+	User        models.User `json:"user"`
+	AccessLevel string      `json:"access_level"`
+	SharedBy    models.User `json:"shared_by"`
+	CreatedAt   time.Time   `json:"created_at"`
+}
+
+func NewShareHandler(shareService interfaces.ShareService) *ShareHandler {
+	return &ShareHandler{shareService: shareService}
+}
+
+// POST /api/v2/folders/:folderId/shares
+func (h *ShareHandler) CreateFolderShare(c *gin.Context) {
+	h.createShare(c, "folderId", "/folders/", h.shareService.ShareFolder, h.folderShare)
+}
+
+// GET /api/v2/folders/:folderId/shares?limit=50&cursor=
+func (h *ShareHandler) ListFolderShares(c *gin.Context) {
+	userID, ok := currentUser(c)
+	if !ok {
+		return
+	}
+	folderID, ok := pathID(c, "folderId")
+	if !ok {
+		return
+	}
+	req, ok := pageRequest(c)
+	if !ok {
+		return
+	}
+
+	page, err := h.shareService.ListFolderShares(c.Request.Context(), folderID, userID, req)
+	if err != nil {
+		serviceError(c, err)
+		return
+	}
+
+	shares := make([]Share, 0, len(page.Items))
+	for _, share := range page.Items {
+		shares = append(shares, folderShare(share))
+	}
+	pageResponse(c, &models.Page[Share]{Items: shares, Next: page.Next}, req)
+}
+
+// GET /api/v2/folders/:folderId/shares/:userId
+func (h *ShareHandler) GetFolderShare(c *gin.Context) {
+	h.getShare(c, "folderId", h.folderShare)
+}
+
+// DELETE /api/v2/folders/:folderId/shares/:userId
+func (h *ShareHandler) DeleteFolderShare(c *gin.Context) {
+	h.deleteShare(c, "folderId", h.shareService.UnshareFolder)
+}
+
+// POST /api/v2/notes/:noteId/shares
+func (h *ShareHandler) CreateNoteShare(c *gin.Context) {
+	h.createShare(c, "noteId", "/notes/", h.shareService.ShareNote, h.noteShare)
+}
+
+// GET /api/v2/notes/:noteId/shares?limit=50&cursor=
+func (h *ShareHandler) ListNoteShares(c *gin.Context) {
+	userID, ok := currentUser(c)
+	if !ok {
+		return
+	}
+	noteID, ok := pathID(c, "noteId")
+	if !ok {
+		return
+	}
+	req, ok := pageRequest(c)
+	if !ok {
+		return
+	}
+
+	page, err := h.shareService.ListNoteShares(c.Request.Context(), noteID, userID, req)
+	if err != nil {
+		serviceError(c, err)
+		return
+	}
+
+	shares := make([]Share, 0, len(page.Items))
+	for _, share := range page.Items {
+		shares = append(shares, noteShare(share))
+	}
+	pageResponse(c, &models.Page[Share]{Items: shares, Next: page.Next}, req)
+}
+
+// GET /api/v2/notes/:noteId/shares/:userId
+func (h *ShareHandler) GetNoteShare(c *gin.Context) {
+	h.getShare(c, "noteId", h.noteShare)
+}
+
+// DELETE /api/v2/notes/:noteId/shares/:userId
+func (h *ShareHandler) DeleteNoteShare(c *gin.Context) {
+	h.deleteShare(c, "noteId", h.shareService.UnshareNote)
+}
+
+// findShare finds the share of an asset with a user, nil when there is none
+type findShare func(ctx context.Context, assetID, ownerID, targetUserID uuid.UUID) (*Share, error)
+
+// createShare shares the asset in the path parameter param and answers with
+// the share created, at collection/<asset>/shares/<user>
+func (h *ShareHandler) createShare(c *gin.Context, param, collection string,
+	shareAsset func(ctx context.Context, assetID, ownerID, targetUserID uuid.UUID, accessLevel string) error, find findShare) {
+	userID, ok := currentUser(c)
+	if !ok {
+		return
+	}
+	assetID, ok := pathID(c, param)
+	if !ok {
+		return
+	}
+	req, ok := bindJSON[shareRequest](c)
+	if !ok {
+		return
+	}
+	targetUserID := uuid.MustParse(req.UserID)
+
+	if err := shareAsset(c.Request.Context(), assetID, userID, targetUserID, req.AccessLevel); err != nil {
+		serviceError(c, err)
+		return
+	}
+	share, err := find(c.Request.Context(), assetID, userID, targetUserID)
+	if err != nil {
+		serviceError(c, err)
+		return
+	}
+	if share == nil {
+		// Unshared again in the meantime
+		errorJSON(c, http.StatusConflict, CodeConflict, "The share was removed while it was created")
+		return
+	}
+
+	created(c, basePath+collection+assetID.String()+"/shares/"+targetUserID.String(), share)
+}
+
+func (h *ShareHandler) getShare(c *gin.Context, param string, find findShare) {
+	userID, ok := currentUser(c)
+	if !ok {
+		return
+	}
+	assetID, ok := pathID(c, param)
+	if !ok {
+		return
+	}
+	targetUserID, ok := pathID(c, "userId")
+	if !ok {
+		return
+	}
+
+	share, err := find(c.Request.Context(), assetID, userID, targetUserID)
+	if err != nil {
+		serviceError(c, err)
+		return
+	}
+	if share == nil {
+		errorJSON(c, http.StatusNotFound, CodeNotFound, "share not found")
+		return
+	}
+
+	success(c, share)
+}
+
+func (h *ShareHandler) deleteShare(c *gin.Context, param string,
+	unshare func(ctx context.Context, assetID, ownerID, targetUserID uuid.UUID) error) {
+	userID, ok := currentUser(c)
+	if !ok {
+		return
+	}
+	assetID, ok := pathID(c, param)
+	if !ok {
+		return
+	}
+	targetUserID, ok := pathID(c, "userId")
+	if !ok {
+		return
+	}
+
+	if err := unshare(c.Request.Context(), assetID, userID, targetUserID); err != nil {
+		serviceError(c, err)
+		return
+	}
+
+	noContent(c)
+}
+
+func (h *ShareHandler) folderShare(ctx context.Context, folderID, ownerID, targetUserID uuid.UUID) (*Share, error) {
+	shares, err := h.shareService.GetFolderShares(ctx, folderID, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	for _, share := range shares {
+		if share.SharedWithUserID == targetUserID {
+			found := folderShare(share)
+			return &found, nil
+		}
+	}
+	return nil, nil
+}
+
+func (h *ShareHandler) noteShare(ctx context.Context, noteID, ownerID, targetUserID uuid.UUID) (*Share, error) {
+	shares, err := h.shareService.GetNoteShares(ctx, noteID, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	for _, share := range shares {
+		if share.SharedWithUserID == targetUserID {
+			found := noteShare(share)
+			return &found, nil
+		}
+	}
+	return nil, nil
+}
+
+func folderShare(share *models.FolderShare) Share {
+	return Share{
+		User:        share.SharedWithUser,
+		AccessLevel: share.AccessLevel,
+		SharedBy:    share.SharedByUser,
+		CreatedAt:   share.CreatedAt,
+	}
+}
+
+func noteShare(share *models.NoteShare) Share {
+	return Share{
+		User:        share.SharedWithUser,
+		AccessLevel: share.AccessLevel,
+		SharedBy:    share.SharedByUser,
+		CreatedAt:   share.CreatedAt,
+	}
+}
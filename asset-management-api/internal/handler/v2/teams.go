@@ -0,0 +1,289 @@
+package v2
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"sort"
+
+	"asset-management-api/internal/models"
+	"asset-management-api/internal/service/interfaces"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type TeamHandler struct {
+	teamService interfaces.TeamService
+}
+
+type createTeamRequest struct {
+	Name       string   `json:"name" validate:"required,min=1,max=255"`
+	ManagerIDs []string `json:"manager_ids" validate:"dive,uuid"`
+	MemberIDs  []string `json:"member_ids" validate:"dive,uuid"`
+}
+
+type teamUserRequest struct {
+	UserID string `json:"user_id" validate:"required,uuid"`
+}
+
+// teamRole is the members or managers collection of a team
+type teamRole struct {
+	collection string
+	users      func(team *models.Team) []models.User
+	add        func(ctx context.Context, teamID, requestorID, userID uuid.UUID) error
+	remove     func(ctx context.Context, teamID, requestorID, userID uuid.UUID) error
+}
+
+func NewTeamHandler(teamService interfaces.TeamService) *TeamHandler {
+	return &TeamHandler{teamService: teamService}
+}
+
+// POST /api/v2/teams
+func (h *TeamHandler) CreateTeam(c *gin.Context) {
+	userID, ok := currentUser(c)
+	if !ok {
+		return
+	}
+	req, ok := bindJSON[createTeamRequest](c)
+	if !ok {
+		return
+	}
+
+	team, err := h.teamService.CreateTeam(c.Request.Context(), userID, req.Name, teamMembers(req.ManagerIDs), teamMembers(req.MemberIDs))
+	if err != nil {
+		serviceError(c, err)
+		return
+	}
+
+	created(c, basePath+"/teams/"+team.TeamID.String(), team)
+}
+
+// GET /api/v2/teams?limit=50&cursor=
+func (h *TeamHandler) ListTeams(c *gin.Context) {
+	userID, ok := currentUser(c)
+	if !ok {
+		return
+	}
+	req, ok := pageRequest(c)
+	if !ok {
+		return
+	}
+
+	page, err := h.teamService.ListUserTeams(c.Request.Context(), userID, req)
+	if err != nil {
+		serviceError(c, err)
+		return
+	}
+
+	pageResponse(c, page, req)
+}
+
+// GET /api/v2/teams/:teamId
+func (h *TeamHandler) GetTeam(c *gin.Context) {
+	userID, ok := currentUser(c)
+	if !ok {
+		return
+	}
+	teamID, ok := pathID(c, "teamId")
+	if !ok {
+		return
+	}
+
+	team, err := h.teamService.GetTeam(c.Request.Context(), teamID, userID)
+	if err != nil {
+		serviceError(c, err)
+		return
+	}
+
+	success(c, team)
+}
+
+// POST /api/v2/teams/:teamId/members
+func (h *TeamHandler) AddMember(c *gin.Context) { h.addUser(c, h.members()) }
+
+// GET /api/v2/teams/:teamId/members?limit=50&cursor=
+func (h *TeamHandler) ListMembers(c *gin.Context) { h.listUsers(c, h.members()) }
+
+// GET /api/v2/teams/:teamId/members/:userId
+func (h *TeamHandler) GetMember(c *gin.Context) { h.getUser(c, h.members()) }
+
+// DELETE /api/v2/teams/:teamId/members/:userId
+func (h *TeamHandler) RemoveMember(c *gin.Context) { h.removeUser(c, h.members()) }
+
+// POST /api/v2/teams/:teamId/managers
+func (h *TeamHandler) AddManager(c *gin.Context) { h.addUser(c, h.managers()) }
+
+// GET /api/v2/teams/:teamId/managers?limit=50&cursor=
+func (h *TeamHandler) ListManagers(c *gin.Context) { h.listUsers(c, h.managers()) }
+
+// GET /api/v2/teams/:teamId/managers/:userId
+func (h *TeamHandler) GetManager(c *gin.Context) { h.getUser(c, h.managers()) }
+
+// DELETE /api/v2/teams/:teamId/managers/:userId
+func (h *TeamHandler) RemoveManager(c *gin.Context) { h.removeUser(c, h.managers()) }
+
+func (h *TeamHandler) members() teamRole {
+	return teamRole{
+		collection: "members",
+		users:      func(team *models.Team) []models.User { return team.Members },
+		add:        h.teamService.AddMember,
+		remove:     h.teamService.RemoveMember,
+	}
+}
+
+func (h *TeamHandler) managers() teamRole {
+	return teamRole{
+		collection: "managers",
+		users:      func(team *models.Team) []models.User { return team.Managers },
+		add:        h.teamService.AddManager,
+		remove:     h.teamService.RemoveManager,
+	}
+}
+
+// addUser adds a user to a team in role and answers with the user
+func (h *TeamHandler) addUser(c *gin.Context, role teamRole) {
+	userID, ok := currentUser(c)
+	if !ok {
+		return
+	}
+	teamID, ok := pathID(c, "teamId")
+	if !ok {
+		return
+	}
+	req, ok := bindJSON[teamUserRequest](c)
+	if !ok {
+		return
+	}
+	targetUserID := uuid.MustParse(req.UserID)
+
+	if err := role.add(c.Request.Context(), teamID, userID, targetUserID); err != nil {
+		serviceError(c, err)
+		return
+	}
+	user, ok := h.findUser(c, role, teamID, userID, targetUserID)
+	if !ok {
+		return
+	}
+
+	created(c, basePath+"/teams/"+teamID.String()+"/"+role.collection+"/"+targetUserID.String(), user)
+}
+
+// listUsers lists the users of a team in role. The team holds them all, so
+// they are paged here, in the order of the other collections.
+func (h *TeamHandler) listUsers(c *gin.Context, role teamRole) {
+	userID, ok := currentUser(c)
+	if !ok {
+		return
+	}
+	teamID, ok := pathID(c, "teamId")
+	if !ok {
+		return
+	}
+	req, ok := pageRequest(c)
+	if !ok {
+		return
+	}
+
+	team, err := h.teamService.GetTeam(c.Request.Context(), teamID, userID)
+	if err != nil {
+		serviceError(c, err)
+		return
+	}
+
+	pageResponse(c, pageUsers(role.users(team), req), req)
+}
+
+func (h *TeamHandler) getUser(c *gin.Context, role teamRole) {
+	userID, ok := currentUser(c)
+	if !ok {
+		return
+	}
+	teamID, ok := pathID(c, "teamId")
+	if !ok {
+		return
+	}
+	targetUserID, ok := pathID(c, "userId")
+	if !ok {
+		return
+	}
+
+	user, ok := h.findUser(c, role, teamID, userID, targetUserID)
+	if !ok {
+		return
+	}
+
+	success(c, user)
+}
+
+func (h *TeamHandler) removeUser(c *gin.Context, role teamRole) {
+	userID, ok := currentUser(c)
+	if !ok {
+		return
+	}
+	teamID, ok := pathID(c, "teamId")
+	if !ok {
+		return
+	}
+	targetUserID, ok := pathID(c, "userId")
+	if !ok {
+		return
+	}
+
+	if err := role.remove(c.Request.Context(), teamID, userID, targetUserID); err != nil {
+		serviceError(c, err)
+		return
+	}
+
+	noContent(c)
+}
+
+// findUser finds a user of a team in role, answering 404 when the user does
+// not have it
+func (h *TeamHandler) findUser(c *gin.Context, role teamRole, teamID, userID, targetUserID uuid.UUID) (*models.User, bool) {
+	team, err := h.teamService.GetTeam(c.Request.Context(), teamID, userID)
+	if err != nil {
+		serviceError(c, err)
+		return nil, false
+	}
+	for _, user := range role.users(team) {
+		if user.UserID == targetUserID {
+			return &user, true
+		}
+	}
+	errorJSON(c, http.StatusNotFound, CodeNotFound, "user is not one of the team's "+role.collection)
+	return nil, false
+}
+
+// pageUsers reads the page req asks for of users
+func pageUsers(users []models.User, req models.PageRequest) *models.Page[models.User] {
+	cursorOf := func(user models.User) models.Cursor {
+		return models.Cursor{CreatedAt: user.CreatedAt, ID: user.UserID}
+	}
+	before := func(a, b models.Cursor) bool {
+		if !a.CreatedAt.Equal(b.CreatedAt) {
+			return a.CreatedAt.Before(b.CreatedAt)
+		}
+		return bytes.Compare(a.ID[:], b.ID[:]) < 0
+	}
+
+	sorted := make([]models.User, 0, len(users))
+	for _, user := range users {
+		if req.After == nil || before(cursorOf(user), *req.After) {
+			sorted = append(sorted, user)
+		}
+	}
+	sort.Slice(sorted, func(i, j int) bool { return before(cursorOf(sorted[j]), cursorOf(sorted[i])) })
+	if len(sorted) > req.Limit+1 {
+		sorted = sorted[:req.Limit+1]
+	}
+	return models.NewPage(sorted, req, cursorOf)
+}
+
+func teamMembers(userIDs []string) []interfaces.TeamMemberInfo {
+	members := make([]interfaces.TeamMemberInfo, 0, len(userIDs))
+	for _, userID := range userIDs {
+		members = append(members, interfaces.TeamMemberInfo{UserID: userID})
+	}
+	return members
+}
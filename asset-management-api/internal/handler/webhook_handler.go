@@ -0,0 +1,385 @@
+package handler
+
+import (
+	"asset-management-api/internal/middleware"
+	"asset-management-api/internal/models"
+	"asset-management-api/internal/service/interfaces"
+	"asset-management-api/internal/utils"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type WebhookHandler struct {
+	webhookService interfaces.WebhookService
+}
+
+func NewWebhookHandler(webhookService interfaces.WebhookService) *WebhookHandler {
+	return &WebhookHandler{webhookService: webhookService}
+}
+
+// POST /teams/:teamId/webhooks
+func (h *WebhookHandler) RegisterWebhook(c *gin.Context) {
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		utils.UnauthorizedResponse(c, "User not authenticated")
+		return
+	}
+
+	teamID, err := uuid.Parse(c.Param("teamId"))
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid team ID format", err)
+		return
+	}
+
+	req, ok := utils.BindJSON[models.CreateWebhookRequest](c)
+	if !ok {
+		return
+	}
+
+	webhook, err := h.webhookService.RegisterWebhook(c.Request.Context(), teamID, userID, req.URL, req.Secret, req.EventTypes)
+	if err != nil {
+		serviceErrorResponse(c, "Failed to register webhook", err)
+		return
+	}
+
+	middleware.LogBusinessEvent("webhook_registered", map[string]interface{}{
+		"user_id":    userID,
+		"team_id":    teamID,
+		"webhook_id": webhook.WebhookID,
+	})
+
+	utils.SuccessResponse(c, http.StatusCreated, "Webhook registered successfully", models.CreateWebhookResponse{
+		Webhook: webhook,
+		Secret:  webhook.Secret,
+	})
+}
+
+// GET /teams/:teamId/webhooks
+func (h *WebhookHandler) ListWebhooks(c *gin.Context) {
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		utils.UnauthorizedResponse(c, "User not authenticated")
+		return
+	}
+
+	teamID, err := uuid.Parse(c.Param("teamId"))
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid team ID format", err)
+		return
+	}
+
+	webhooks, err := h.webhookService.ListWebhooks(c.Request.Context(), teamID, userID)
+	if err != nil {
+		serviceErrorResponse(c, "Failed to get webhooks", err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Webhooks retrieved successfully", webhooks)
+}
+
+// DELETE /teams/:teamId/webhooks/:webhookId
+func (h *WebhookHandler) DeleteWebhook(c *gin.Context) {
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		utils.UnauthorizedResponse(c, "User not authenticated")
+		return
+	}
+
+	teamID, err := uuid.Parse(c.Param("teamId"))
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid team ID format", err)
+		return
+	}
+
+	webhookID, err := uuid.Parse(c.Param("webhookId"))
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid webhook ID format", err)
+		return
+	}
+
+	err = h.webhookService.DeleteWebhook(c.Request.Context(), teamID, webhookID, userID)
+	if err != nil {
+		serviceErrorResponse(c, "Failed to delete webhook", err)
+		return
+	}
+
+	middleware.LogBusinessEvent("webhook_deleted", map[string]interface{}{
+		"user_id":    userID,
+		"team_id":    teamID,
+		"webhook_id": webhookID,
+	})
+
+	utils.SuccessResponse(c, http.StatusOK, "Webhook deleted successfully", nil)
+}
+
+// GET /teams/:teamId/webhooks/:webhookId/deliveries?limit=50
+func (h *WebhookHandler) GetDeliveries(c *gin.Context) {
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		utils.UnauthorizedResponse(c, "User not authenticated")
+		return
+	}
+
+	teamID, err := uuid.Parse(c.Param("teamId"))
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid team ID format", err)
+		return
+	}
+
+	webhookID, err := uuid.Parse(c.Param("webhookId"))
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid webhook ID format", err)
+		return
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	if err != nil || limit < 1 {
+		utils.BadRequestResponse(c, "Query parameter 'limit' must be a positive integer", err)
+		return
+	}
+
+	deliveries, err := h.webhookService.GetDeliveries(c.Request.Context(), teamID, webhookID, userID, limit)
+	if err != nil {
+		serviceErrorResponse(c, "Failed to get webhook deliveries", err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Webhook deliveries retrieved successfully", deliveries)
+}
+
+// POST /webhooks
+func (h *WebhookHandler) CreateWebhook(c *gin.Context) {
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		utils.UnauthorizedResponse(c, "User not authenticated")
+		return
+	}
+
+	req, ok := utils.BindJSON[models.RegisterWebhookRequest](c)
+	if !ok {
+		return
+	}
+	teamID := uuid.MustParse(req.TeamID) // Validated as a UUID
+
+	webhook, err := h.webhookService.RegisterWebhook(c.Request.Context(), teamID, userID, req.URL, req.Secret, req.EventTypes)
+	if err != nil {
+		serviceErrorResponse(c, "Failed to register webhook", err)
+		return
+	}
+
+	middleware.LogBusinessEvent("webhook_registered", map[string]interface{}{
+		"user_id":    userID,
+		"team_id":    teamID,
+		"webhook_id": webhook.WebhookID,
+	})
+
+	c.Header("Location", webhookPath(webhook.WebhookID))
+	utils.SuccessResponse(c, http.StatusCreated, "Webhook registered successfully", models.CreateWebhookResponse{
+		Webhook: webhook,
+		Secret:  webhook.Secret,
+	})
+}
+
+// GET /webhooks?team_id= (the webhooks of every team the user manages, or of one)
+func (h *WebhookHandler) ListManagedWebhooks(c *gin.Context) {
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		utils.UnauthorizedResponse(c, "User not authenticated")
+		return
+	}
+
+	var webhooks []*models.Webhook
+	if teamIDStr := c.Query("team_id"); teamIDStr != "" {
+		teamID, err := uuid.Parse(teamIDStr)
+		if err != nil {
+			utils.BadRequestResponse(c, "Invalid team ID format", err)
+			return
+		}
+		webhooks, err = h.webhookService.ListWebhooks(c.Request.Context(), teamID, userID)
+		if err != nil {
+			serviceErrorResponse(c, "Failed to get webhooks", err)
+			return
+		}
+	} else {
+		var err error
+		webhooks, err = h.webhookService.ListManagedWebhooks(c.Request.Context(), userID)
+		if err != nil {
+			serviceErrorResponse(c, "Failed to get webhooks", err)
+			return
+		}
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Webhooks retrieved successfully", webhooks)
+}
+
+// GET /webhooks/:webhookId
+func (h *WebhookHandler) GetWebhook(c *gin.Context) {
+	userID, webhookID, ok := webhookRequest(c)
+	if !ok {
+		return
+	}
+
+	webhook, err := h.webhookService.GetWebhook(c.Request.Context(), webhookID, userID)
+	if err != nil {
+		serviceErrorResponse(c, "Failed to get webhook", err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Webhook retrieved successfully", webhook)
+}
+
+// PATCH /webhooks/:webhookId
+func (h *WebhookHandler) UpdateWebhook(c *gin.Context) {
+	userID, webhookID, ok := webhookRequest(c)
+	if !ok {
+		return
+	}
+
+	req, ok := utils.BindJSON[models.UpdateWebhookRequest](c)
+	if !ok {
+		return
+	}
+
+	webhook, err := h.webhookService.UpdateWebhook(c.Request.Context(), webhookID, userID, req)
+	if err != nil {
+		serviceErrorResponse(c, "Failed to update webhook", err)
+		return
+	}
+
+	middleware.LogBusinessEvent("webhook_updated", map[string]interface{}{
+		"user_id":    userID,
+		"team_id":    webhook.TeamID,
+		"webhook_id": webhookID,
+	})
+
+	utils.SuccessResponse(c, http.StatusOK, "Webhook updated successfully", webhook)
+}
+
+// DELETE /webhooks/:webhookId
+func (h *WebhookHandler) DeleteWebhookByID(c *gin.Context) {
+	userID, webhookID, ok := webhookRequest(c)
+	if !ok {
+		return
+	}
+
+	webhook, err := h.webhookService.GetWebhook(c.Request.Context(), webhookID, userID)
+	if err != nil {
+		serviceErrorResponse(c, "Failed to delete webhook", err)
+		return
+	}
+	if err := h.webhookService.DeleteWebhook(c.Request.Context(), webhook.TeamID, webhookID, userID); err != nil {
+		serviceErrorResponse(c, "Failed to delete webhook", err)
+		return
+	}
+
+	middleware.LogBusinessEvent("webhook_deleted", map[string]interface{}{
+		"user_id":    userID,
+		"team_id":    webhook.TeamID,
+		"webhook_id": webhookID,
+	})
+
+	utils.SuccessResponse(c, http.StatusOK, "Webhook deleted successfully", nil)
+}
+
+// GET /webhooks/:webhookId/deliveries?limit=50
+func (h *WebhookHandler) GetWebhookDeliveries(c *gin.Context) {
+	userID, webhookID, ok := webhookRequest(c)
+	if !ok {
+		return
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	if err != nil || limit < 1 {
+		utils.BadRequestResponse(c, "Query parameter 'limit' must be a positive integer", err)
+		return
+	}
+
+	webhook, err := h.webhookService.GetWebhook(c.Request.Context(), webhookID, userID)
+	if err != nil {
+		serviceErrorResponse(c, "Failed to get webhook deliveries", err)
+		return
+	}
+	deliveries, err := h.webhookService.GetDeliveries(c.Request.Context(), webhook.TeamID, webhookID, userID, limit)
+	if err != nil {
+		serviceErrorResponse(c, "Failed to get webhook deliveries", err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Webhook deliveries retrieved successfully", deliveries)
+}
+
+// GET /webhooks/:webhookId/secret
+func (h *WebhookHandler) GetWebhookSecret(c *gin.Context) {
+	userID, webhookID, ok := webhookRequest(c)
+	if !ok {
+		return
+	}
+
+	webhook, err := h.webhookService.GetWebhook(c.Request.Context(), webhookID, userID)
+	if err != nil {
+		serviceErrorResponse(c, "Failed to get webhook secret", err)
+		return
+	}
+
+	middleware.LogSecurityEvent("webhook_secret_revealed", map[string]interface{}{
+		"user_id":    userID,
+		"team_id":    webhook.TeamID,
+		"webhook_id": webhookID,
+	})
+
+	c.Header("Cache-Control", "no-store")
+	utils.SuccessResponse(c, http.StatusOK, "Webhook secret retrieved successfully", models.WebhookSecretResponse{
+		WebhookID: webhook.WebhookID,
+		Secret:    webhook.Secret,
+	})
+}
+
+// POST /webhooks/:webhookId/secret/rotate
+func (h *WebhookHandler) RotateWebhookSecret(c *gin.Context) {
+	userID, webhookID, ok := webhookRequest(c)
+	if !ok {
+		return
+	}
+
+	webhook, err := h.webhookService.RotateWebhookSecret(c.Request.Context(), webhookID, userID)
+	if err != nil {
+		serviceErrorResponse(c, "Failed to rotate webhook secret", err)
+		return
+	}
+
+	middleware.LogSecurityEvent("webhook_secret_rotated", map[string]interface{}{
+		"user_id":    userID,
+		"team_id":    webhook.TeamID,
+		"webhook_id": webhookID,
+	})
+
+	c.Header("Cache-Control", "no-store")
+	utils.SuccessResponse(c, http.StatusOK, "Webhook secret rotated successfully", models.WebhookSecretResponse{
+		WebhookID: webhook.WebhookID,
+		Secret:    webhook.Secret,
+	})
+}
+
+// webhookRequest reads the user and webhook ID of a /webhooks/:webhookId
+// request, answering it when either is missing
+func webhookRequest(c *gin.Context) (userID, webhookID uuid.UUID, ok bool) {
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		utils.UnauthorizedResponse(c, "User not authenticated")
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	webhookID, err := uuid.Parse(c.Param("webhookId"))
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid webhook ID format", err)
+		return uuid.Nil, uuid.Nil, false
+	}
+	return userID, webhookID, true
+}
+
+func webhookPath(webhookID uuid.UUID) string {
+	return apiBasePath + "/webhooks/" + webhookID.String()
+}
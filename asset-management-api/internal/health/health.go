@@ -0,0 +1,119 @@
+// Package health checks whether the service's dependencies can be reached,
+// for readiness probes.
+package health
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Status of a dependency, or of the service as a whole
+const (
+	StatusUp       = "up"
+	StatusDegraded = "degraded" // A non-critical dependency is down
+	StatusDown     = "down"     // A critical dependency is down
+)
+
+// Check probes one dependency. The service cannot serve requests without a
+// critical dependency; without the others it runs degraded, e.g. uncached
+// or without publishing events.
+type Check struct {
+	Name     string
+	Critical bool
+	Probe    func(ctx context.Context) error
+}
+
+// Result is the outcome of a check
+type Result struct {
+	Status    string `json:"status"`
+	Critical  bool   `json:"critical"`
+	LatencyMs int64  `json:"latency_ms"`
+	Error     string `json:"error,omitempty"`
+}
+
+// Report is the outcome of all checks
+type Report struct {
+	Status       string            `json:"status"`
+	Dependencies map[string]Result `json:"dependencies"`
+	CheckedAt    time.Time         `json:"checked_at"`
+}
+
+// Checker runs the checks concurrently, all within a shared time budget, so
+// a hanging dependency cannot make the probe itself time out
+type Checker struct {
+	budget time.Duration
+	checks []Check
+}
+
+// NewChecker creates a checker giving the checks budget to complete
+func NewChecker(budget time.Duration, checks ...Check) *Checker {
+	return &Checker{budget: budget, checks: checks}
+}
+
+// Run runs every check and reports the service down when a critical check
+// failed and degraded when only other checks did
+func (c *Checker) Run(ctx context.Context) Report {
+	if c.budget > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.budget)
+		defer cancel()
+	}
+
+	results := make([]Result, len(c.checks))
+	var wg sync.WaitGroup
+	for i, check := range c.checks {
+		wg.Add(1)
+		go func(i int, check Check) {
+			defer wg.Done()
+			results[i] = run(ctx, check)
+		}(i, check)
+	}
+	wg.Wait()
+
+	report := Report{
+		Status:       StatusUp,
+		Dependencies: make(map[string]Result, len(c.checks)),
+		CheckedAt:    time.Now().UTC(),
+	}
+	for i, check := range c.checks {
+		result := results[i]
+		report.Dependencies[check.Name] = result
+		switch {
+		case result.Status == StatusUp:
+		case check.Critical:
+			report.Status = StatusDown
+		case report.Status == StatusUp:
+			report.Status = StatusDegraded
+		}
+	}
+	return report
+}
+
+// run probes a dependency, giving up when the budget runs out even if the
+// probe ignores its context
+func run(ctx context.Context, check Check) Result {
+	start := time.Now()
+	done := make(chan error, 1)
+	go func() {
+		done <- check.Probe(ctx)
+	}()
+
+	var err error
+	select {
+	case err = <-done:
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+
+	result := Result{
+		Status:    StatusUp,
+		Critical:  check.Critical,
+		LatencyMs: time.Since(start).Milliseconds(),
+	}
+	if err != nil {
+		result.Status = StatusDown
+		result.Error = err.Error()
+	}
+	return result
+}
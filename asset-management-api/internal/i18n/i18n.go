@@ -0,0 +1,214 @@
+package i18n
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync/atomic"
+)
+
+// Catalog holds the translations of the API's messages. Messages are looked
+// up by their English text, so a message missing from a catalog is answered
+// in English. Messages may take arguments as {name} placeholders, filled in
+// after translation.
+//
+// Translations are read from a directory of JSON files named after their
+// language tag, e.g. fr.json or pt-BR.json, each mapping English messages to
+// their translation. Load reads them again while serving.
+type Catalog struct {
+	fallback string
+	messages atomic.Pointer[map[string]map[string]string]
+}
+
+// NewCatalog creates a catalog without translations, answering every
+// message in the fallback language, that of the messages in the code
+func NewCatalog(fallback string) *Catalog {
+	c := &Catalog{fallback: fallback}
+	c.messages.Store(&map[string]map[string]string{})
+	return c
+}
+
+// Load replaces the translations with those of the catalog files of dir. On
+// error the translations loaded before are kept.
+func (c *Catalog) Load(dir string) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return fmt.Errorf("failed to read message catalogs: %w", err)
+	}
+
+	messages := make(map[string]map[string]string)
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || filepath.Ext(name) != ".json" {
+			continue
+		}
+		lang := strings.TrimSuffix(name, ".json")
+		if !ValidTag(lang) {
+			return fmt.Errorf("message catalog %s: %q is not a language tag", name, lang)
+		}
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			return fmt.Errorf("failed to read message catalog %s: %w", name, err)
+		}
+		var translations map[string]string
+		if err := json.Unmarshal(data, &translations); err != nil {
+			return fmt.Errorf("invalid message catalog %s: %w", name, err)
+		}
+		messages[strings.ToLower(lang)] = translations
+	}
+
+	c.messages.Store(&messages)
+	return nil
+}
+
+// Languages lists the languages messages are available in: the fallback
+// and those of the loaded catalogs
+func (c *Catalog) Languages() []string {
+	languages := []string{c.fallback}
+	for lang := range *c.messages.Load() {
+		if !strings.EqualFold(lang, c.fallback) {
+			languages = append(languages, lang)
+		}
+	}
+	sort.Strings(languages[1:])
+	return languages
+}
+
+// Negotiate picks the language of a response from an Accept-Language header,
+// e.g. "fr-CH, fr;q=0.9, en;q=0.8": the most preferred language with a
+// catalog, matched exactly or by its primary language, or else the fallback
+func (c *Catalog) Negotiate(acceptLanguage string) string {
+	messages := *c.messages.Load()
+	for _, tag := range parseAcceptLanguage(acceptLanguage) {
+		if tag == "*" {
+			break
+		}
+		for _, candidate := range []string{tag, primary(tag)} {
+			if strings.EqualFold(candidate, c.fallback) {
+				return c.fallback
+			}
+			if _, ok := messages[candidate]; ok {
+				return candidate
+			}
+		}
+	}
+	return c.fallback
+}
+
+// Translate returns a message in a language, with its arguments given as
+// name and value pairs
+func (c *Catalog) Translate(lang, message string, args ...string) string {
+	if c != nil {
+		messages := *c.messages.Load()
+		lang = strings.ToLower(lang)
+		for _, candidate := range []string{lang, primary(lang)} {
+			if translation, ok := messages[candidate][message]; ok && translation != "" {
+				message = translation
+				break
+			}
+		}
+	}
+	return format(message, args)
+}
+
+// Localizer translates messages into the language of a request
+type Localizer struct {
+	catalog *Catalog
+	lang    string
+}
+
+// Localizer returns the localizer of a language. The zero Localizer answers
+// in the language of the messages in the code.
+func (c *Catalog) Localizer(lang string) Localizer {
+	return Localizer{catalog: c, lang: lang}
+}
+
+// Language is the language of the localizer, empty for the zero Localizer
+func (l Localizer) Language() string {
+	return l.lang
+}
+
+// Translate returns a message in the localizer's language, see
+// Catalog.Translate
+func (l Localizer) Translate(message string, args ...string) string {
+	return l.catalog.Translate(l.lang, message, args...)
+}
+
+// format fills in the {name} placeholders of a message
+func format(message string, args []string) string {
+	if len(args) < 2 {
+		return message
+	}
+	pairs := make([]string, 0, len(args))
+	for i := 0; i+1 < len(args); i += 2 {
+		pairs = append(pairs, "{"+args[i]+"}", args[i+1])
+	}
+	return strings.NewReplacer(pairs...).Replace(message)
+}
+
+// parseAcceptLanguage returns the language tags of an Accept-Language header,
+// lowercased and the most preferred first, leaving out those refused with q=0
+func parseAcceptLanguage(header string) []string {
+	type weighted struct {
+		tag string
+		q   float64
+	}
+	var tags []weighted
+	for _, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag != "*" && !ValidTag(tag) {
+			continue
+		}
+		q := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			q = parsed
+		}
+		if q > 0 {
+			tags = append(tags, weighted{tag: tag, q: q})
+		}
+	}
+	sort.SliceStable(tags, func(i, j int) bool {
+		return tags[i].q > tags[j].q
+	})
+
+	result := make([]string, len(tags))
+	for i, tag := range tags {
+		result[i] = tag.tag
+	}
+	return result
+}
+
+// primary returns the primary language of a tag, e.g. "pt" of "pt-br"
+func primary(tag string) string {
+	lang, _, _ := strings.Cut(tag, "-")
+	return lang
+}
+
+// ValidTag reports whether s looks like a language tag: subtags of letters
+// and digits, separated by hyphens
+func ValidTag(s string) bool {
+	if s == "" || len(s) > 35 {
+		return false
+	}
+	for _, subtag := range strings.Split(s, "-") {
+		if subtag == "" || len(subtag) > 8 {
+			return false
+		}
+		for i := 0; i < len(subtag); i++ {
+			c := subtag[i]
+			if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9') {
+				return false
+			}
+		}
+	}
+	return true
+}
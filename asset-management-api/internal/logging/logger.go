@@ -0,0 +1,152 @@
+// Package logging builds the application logger from configuration. The
+// logger is constructed once at startup and handed to the components that
+// log; nothing is opened or configured at import time.
+package logging
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"gopkg.in/natefinch/lumberjack.v2"
+)
+
+// Log formats
+const (
+	FormatJSON    = "json"
+	FormatConsole = "console"
+)
+
+// Output targets
+const (
+	OutputStdout = "stdout"
+	OutputStderr = "stderr"
+	OutputFile   = "file"
+)
+
+// Config describes the logger. Outputs lists where entries are written; the
+// file is rotated once it reaches MaxSizeMB and, if RotateInterval is set,
+// on that interval, keeping MaxBackups old files for up to MaxAge.
+type Config struct {
+	Level   string // "debug", "info", "warn" or "error"
+	Format  string // FormatJSON or FormatConsole
+	Outputs []string
+	File    FileConfig
+}
+
+// FileConfig describes the rotated log file
+type FileConfig struct {
+	Path           string
+	MaxSizeMB      int
+	MaxBackups     int
+	MaxAge         time.Duration
+	RotateInterval time.Duration
+	Compress       bool
+}
+
+// New builds a logger from the configuration. The returned function stops
+// rotation and closes the log file; call it on shutdown.
+func New(cfg Config) (*logrus.Logger, func() error, error) {
+	level, err := logrus.ParseLevel(cfg.Level)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid log level %q: %w", cfg.Level, err)
+	}
+
+	logger := logrus.New()
+	logger.SetLevel(level)
+
+	switch strings.ToLower(cfg.Format) {
+	case FormatJSON, "":
+		logger.SetFormatter(&logrus.JSONFormatter{TimestampFormat: time.RFC3339})
+	case FormatConsole:
+		logger.SetFormatter(&logrus.TextFormatter{FullTimestamp: true, TimestampFormat: time.RFC3339})
+	default:
+		return nil, nil, fmt.Errorf("invalid log format %q: expected %q or %q", cfg.Format, FormatJSON, FormatConsole)
+	}
+
+	var writers []io.Writer
+	closeLogger := func() error { return nil }
+	for _, output := range cfg.Outputs {
+		switch strings.ToLower(strings.TrimSpace(output)) {
+		case OutputStdout:
+			writers = append(writers, os.Stdout)
+		case OutputStderr:
+			writers = append(writers, os.Stderr)
+		case OutputFile:
+			file, err := newRotatingFile(cfg.File)
+			if err != nil {
+				return nil, nil, err
+			}
+			writers = append(writers, file)
+			closeLogger = file.Close
+		default:
+			return nil, nil, fmt.Errorf("invalid log output %q: expected %q, %q or %q", output, OutputStdout, OutputStderr, OutputFile)
+		}
+	}
+
+	switch len(writers) {
+	case 0:
+		logger.SetOutput(io.Discard)
+	case 1:
+		logger.SetOutput(writers[0])
+	default:
+		logger.SetOutput(io.MultiWriter(writers...))
+	}
+	return logger, closeLogger, nil
+}
+
+// rotatingFile is a log file rotated by size and, optionally, on an interval
+type rotatingFile struct {
+	*lumberjack.Logger
+	stop chan struct{}
+}
+
+func newRotatingFile(cfg FileConfig) (*rotatingFile, error) {
+	if cfg.Path == "" {
+		return nil, fmt.Errorf("log file output requires a file path")
+	}
+	if err := os.MkdirAll(filepath.Dir(cfg.Path), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create log directory: %w", err)
+	}
+
+	file := &rotatingFile{
+		Logger: &lumberjack.Logger{
+			Filename:   cfg.Path,
+			MaxSize:    cfg.MaxSizeMB,
+			MaxBackups: cfg.MaxBackups,
+			// lumberjack counts whole days; round partial days up
+			MaxAge:   int((cfg.MaxAge + 24*time.Hour - 1) / (24 * time.Hour)),
+			Compress: cfg.Compress,
+		},
+		stop: make(chan struct{}),
+	}
+	if cfg.RotateInterval > 0 {
+		go file.rotateEvery(cfg.RotateInterval)
+	}
+	return file, nil
+}
+
+func (f *rotatingFile) rotateEvery(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-f.stop:
+			return
+		case <-ticker.C:
+			if err := f.Rotate(); err != nil {
+				fmt.Fprintf(os.Stderr, "failed to rotate log file: %v\n", err)
+			}
+		}
+	}
+}
+
+func (f *rotatingFile) Close() error {
+	close(f.stop)
+	return f.Logger.Close()
+}
@@ -8,6 +8,10 @@ import (
 	"github.com/google/uuid"
 )
 
+// WebSocketBearerProtocol is the subprotocol a WebSocket handshake offers
+// ahead of its token
+const WebSocketBearerProtocol = "bearer"
+
 type AuthMiddleware struct {
 	jwtUtil *utils.JWTUtil
 }
@@ -19,6 +23,9 @@ func NewAuthMiddleware(jwtUtil *utils.JWTUtil) *AuthMiddleware {
 func (m *AuthMiddleware) RequireAuth() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		authHeader := c.GetHeader("Authorization")
+		if authHeader == "" {
+			authHeader = webSocketAuthorization(c)
+		}
 		if authHeader == "" {
 			utils.UnauthorizedResponse(c, "Authorization header is required")
 			c.Abort()
@@ -52,6 +59,20 @@ func (m *AuthMiddleware) RequireAuth() gin.HandlerFunc {
 	}
 }
 
+// webSocketAuthorization returns the Authorization header a WebSocket
+// handshake offers as the subprotocols "bearer, <token>", since browsers
+// cannot set headers on it
+func webSocketAuthorization(c *gin.Context) string {
+	if !strings.EqualFold(c.GetHeader("Upgrade"), "websocket") {
+		return ""
+	}
+	protocols := strings.Split(c.GetHeader("Sec-WebSocket-Protocol"), ",")
+	if len(protocols) != 2 || strings.TrimSpace(protocols[0]) != WebSocketBearerProtocol {
+		return ""
+	}
+	return "Bearer " + strings.TrimSpace(protocols[1])
+}
+
 func (m *AuthMiddleware) RequireManagerRole() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		// This middleware should be used after RequireAuth
@@ -72,6 +93,33 @@ func (m *AuthMiddleware) RequireManagerRole() gin.HandlerFunc {
 	}
 }
 
+// RequireUserIDs lets through only the listed users, for endpoints no role
+// is trusted with
+func (m *AuthMiddleware) RequireUserIDs(userIDs []uuid.UUID) gin.HandlerFunc {
+	allowed := make(map[uuid.UUID]bool, len(userIDs))
+	for _, userID := range userIDs {
+		allowed[userID] = true
+	}
+
+	return func(c *gin.Context) {
+		// This middleware should be used after RequireAuth
+		userID, exists := GetUserIDFromContext(c)
+		if !exists {
+			utils.UnauthorizedResponse(c, "Authentication required")
+			c.Abort()
+			return
+		}
+
+		if !allowed[userID] {
+			utils.ForbiddenResponse(c, "Access to this endpoint is restricted")
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
+
 // Helper function to get user ID from context
 func GetUserIDFromContext(c *gin.Context) (uuid.UUID, bool) {
 	userID, exists := c.Get("user_id")
@@ -0,0 +1,282 @@
+package middleware
+
+import (
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+	"github.com/klauspost/compress/gzip"
+	"github.com/klauspost/compress/zlib"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// Content codings the API responds with, in order of preference
+const (
+	encodingGzip    = "gzip"
+	encodingDeflate = "deflate"
+)
+
+var compressedResponsesTotal = promauto.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "http_compressed_responses_total",
+		Help: "Total number of responses sent compressed, by content coding",
+	},
+	[]string{"encoding"},
+)
+
+var (
+	gzipWriters = sync.Pool{New: func() interface{} { return gzip.NewWriter(io.Discard) }}
+	zlibWriters = sync.Pool{New: func() interface{} { return zlib.NewWriter(io.Discard) }}
+)
+
+// CompressionPolicy decides which responses are compressed. Responses are
+// buffered up to MinSize, so small ones are sent as they are. ContentTypes
+// lists media types such as "application/json"; "text/*" matches a whole
+// type.
+type CompressionPolicy struct {
+	MinSize      int
+	ContentTypes []string
+}
+
+// allows reports whether responses of the content type may be compressed
+func (p CompressionPolicy) allows(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	for _, allowed := range p.ContentTypes {
+		allowed = strings.ToLower(strings.TrimSpace(allowed))
+		if allowed == mediaType || strings.HasSuffix(allowed, "/*") && strings.HasPrefix(mediaType, strings.TrimSuffix(allowed, "*")) {
+			return true
+		}
+	}
+	return false
+}
+
+// CompressionMiddleware compresses responses with gzip or deflate, as the
+// client's Accept-Encoding allows, when they are at least MinSize bytes of an
+// allowed content type. Responses that already have a Content-Encoding, such
+// as the metrics endpoint's, are left alone, and streams are flushed as
+// they are written.
+func CompressionMiddleware(policy CompressionPolicy) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Writer.Header().Add("Vary", "Accept-Encoding")
+		encoding := negotiateEncoding(c.GetHeader("Accept-Encoding"))
+		if encoding == "" || c.Request.Method == http.MethodHead {
+			c.Next()
+			return
+		}
+
+		writer := &compressWriter{ResponseWriter: c.Writer, policy: policy, encoding: encoding}
+		c.Writer = writer
+		completed := false
+		defer func() {
+			c.Writer = writer.ResponseWriter
+			// A panicking handler's response is dropped, so the recovery
+			// middleware can still write its error if nothing has been sent
+			if !completed {
+				writer.discard()
+				return
+			}
+			// An error here means the client went away mid-response
+			_ = writer.close()
+		}()
+		c.Next()
+		completed = true
+	}
+}
+
+// negotiateEncoding picks the content coding for an Accept-Encoding header,
+// or "" when the response must be sent uncompressed
+func negotiateEncoding(acceptEncoding string) string {
+	best, bestQ := "", 0.0
+	for _, part := range strings.Split(acceptEncoding, ",") {
+		coding, params, _ := strings.Cut(part, ";")
+		coding = strings.ToLower(strings.TrimSpace(coding))
+		q := 1.0
+		if name, value, ok := strings.Cut(strings.TrimSpace(params), "="); ok && strings.TrimSpace(name) == "q" {
+			parsed, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+			if err != nil {
+				continue
+			}
+			q = parsed
+		}
+
+		if coding == "*" {
+			coding = encodingGzip
+		}
+		if coding != encodingGzip && coding != encodingDeflate {
+			continue
+		}
+		// On equal weight gzip wins, as it is listed first by most clients
+		if q > bestQ || q == bestQ && q > 0 && coding == encodingGzip {
+			best, bestQ = coding, q
+		}
+	}
+	return best
+}
+
+// compressWriter holds back the status and the first MinSize bytes of a
+// response until it can tell whether to compress it
+type compressWriter struct {
+	gin.ResponseWriter
+	policy   CompressionPolicy
+	encoding string
+	status   int
+	buf      []byte
+	decided  bool
+	encoder  io.WriteCloser
+}
+
+func (w *compressWriter) WriteHeader(code int) {
+	if !w.decided {
+		w.status = code
+	}
+}
+
+// WriteHeaderNow is deferred until the body, a flush or the end of the handler
+func (w *compressWriter) WriteHeaderNow() {}
+
+func (w *compressWriter) Write(data []byte) (int, error) {
+	if !w.decided {
+		w.buf = append(w.buf, data...)
+		if len(w.buf) < w.policy.MinSize {
+			return len(data), nil
+		}
+		if err := w.decide(); err != nil {
+			return 0, err
+		}
+		return len(data), nil
+	}
+	if w.encoder != nil {
+		return w.encoder.Write(data)
+	}
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *compressWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+func (w *compressWriter) Status() int {
+	if !w.decided && w.status != 0 {
+		return w.status
+	}
+	return w.ResponseWriter.Status()
+}
+
+func (w *compressWriter) Written() bool {
+	return w.decided || len(w.buf) > 0
+}
+
+func (w *compressWriter) Flush() {
+	if !w.decided {
+		if err := w.decide(); err != nil {
+			return
+		}
+	}
+	if flusher, ok := w.encoder.(interface{ Flush() error }); ok {
+		if err := flusher.Flush(); err != nil {
+			return
+		}
+	}
+	w.ResponseWriter.Flush()
+}
+
+// Unwrap lets http.ResponseController reach the connection
+func (w *compressWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// decide sends the status and headers, compressing the body if the response
+// qualifies, and writes out what was held back
+func (w *compressWriter) decide() error {
+	w.decided = true
+	if w.status != 0 {
+		w.ResponseWriter.WriteHeader(w.status)
+	}
+
+	if w.compressible() {
+		header := w.Header()
+		header.Set("Content-Encoding", w.encoding)
+		header.Del("Content-Length")
+		w.encoder = newEncoder(w.encoding, w.ResponseWriter)
+		compressedResponsesTotal.WithLabelValues(w.encoding).Inc()
+	}
+	w.ResponseWriter.WriteHeaderNow()
+
+	buf := w.buf
+	w.buf = nil
+	if len(buf) == 0 {
+		return nil
+	}
+	if w.encoder != nil {
+		_, err := w.encoder.Write(buf)
+		return err
+	}
+	_, err := w.ResponseWriter.Write(buf)
+	return err
+}
+
+func (w *compressWriter) compressible() bool {
+	status := w.ResponseWriter.Status()
+	if len(w.buf) < w.policy.MinSize || len(w.buf) == 0 {
+		return false
+	}
+	if status < http.StatusOK || status == http.StatusNoContent || status == http.StatusPartialContent || status == http.StatusNotModified {
+		return false
+	}
+	header := w.Header()
+	return header.Get("Content-Encoding") == "" && w.policy.allows(header.Get("Content-Type"))
+}
+
+// close writes out a response that never reached MinSize and finishes the
+// compressed stream
+func (w *compressWriter) close() error {
+	if !w.decided {
+		if err := w.decide(); err != nil {
+			return err
+		}
+	}
+	if w.encoder == nil {
+		return nil
+	}
+	err := w.encoder.Close()
+	releaseEncoder(w.encoding, w.encoder)
+	w.encoder = nil
+	return err
+}
+
+// discard drops the held back response, so another one can be written instead
+func (w *compressWriter) discard() {
+	w.buf = nil
+	if w.encoder != nil {
+		w.encoder.Close()
+		releaseEncoder(w.encoding, w.encoder)
+		w.encoder = nil
+	}
+}
+
+func newEncoder(encoding string, dst io.Writer) io.WriteCloser {
+	if encoding == encodingDeflate {
+		encoder := zlibWriters.Get().(*zlib.Writer)
+		encoder.Reset(dst)
+		return encoder
+	}
+	encoder := gzipWriters.Get().(*gzip.Writer)
+	encoder.Reset(dst)
+	return encoder
+}
+
+func releaseEncoder(encoding string, encoder io.WriteCloser) {
+	if encoding == encodingDeflate {
+		zlibWriters.Put(encoder)
+		return
+	}
+	gzipWriters.Put(encoder)
+}
@@ -0,0 +1,132 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"asset-management-api/internal/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// defaultConcurrencyGroup labels the requests of routes without a group
+const defaultConcurrencyGroup = "default"
+
+var (
+	inFlightRequests = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "http_in_flight_requests",
+			Help: "Number of requests being handled, by concurrency group",
+		},
+		[]string{"group"},
+	)
+
+	shedRequestsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "http_shed_requests_total",
+			Help: "Total number of requests rejected because their concurrency group was full",
+		},
+		[]string{"group"},
+	)
+)
+
+// ConcurrencyPolicy caps the requests handled at once. Routes maps Gin route
+// pattern prefixes, e.g. "/api/v1/admin/", to groups with a limit of their
+// own, and the longest matching prefix wins; all other requests share the
+// Default limit. A limit of zero or less leaves a group unlimited, which
+// long-lived streams need. A request finding its group full waits up to
+// QueueTimeout for a slot.
+type ConcurrencyPolicy struct {
+	Default      int
+	Routes       map[string]int
+	QueueTimeout time.Duration
+}
+
+// ConcurrencyLimitMiddleware sheds load: when a group has as many requests in
+// flight as its limit and no slot frees up within the queue timeout, the
+// request is rejected with 503 and Retry-After, rather than queueing for
+// database connections until it times out.
+func ConcurrencyLimitMiddleware(policy ConcurrencyPolicy) gin.HandlerFunc {
+	slots := make(map[string]chan struct{}, len(policy.Routes)+1)
+	if policy.Default > 0 {
+		slots[defaultConcurrencyGroup] = make(chan struct{}, policy.Default)
+	}
+	for prefix, limit := range policy.Routes {
+		if limit > 0 {
+			slots[prefix] = make(chan struct{}, limit)
+		}
+	}
+
+	return func(c *gin.Context) {
+		group := concurrencyGroup(policy.Routes, c.FullPath())
+		sem, ok := slots[group]
+		if !ok {
+			c.Next()
+			return
+		}
+
+		select {
+		case sem <- struct{}{}:
+		default:
+			if !waitForSlot(c, sem, policy.QueueTimeout) {
+				if c.Request.Context().Err() != nil {
+					// The client gave up while queued; nobody reads a response
+					c.Abort()
+					return
+				}
+				shedRequestsTotal.WithLabelValues(group).Inc()
+				LogInfo("Request shed", map[string]interface{}{
+					"group":    group,
+					"method":   c.Request.Method,
+					"endpoint": c.FullPath(),
+				})
+				c.Header("Retry-After", strconv.Itoa(1))
+				utils.CodedErrorResponse(c, http.StatusServiceUnavailable, utils.CodeOverloaded, "Service overloaded",
+					"Too many requests in progress, please retry shortly")
+				c.Abort()
+				return
+			}
+		}
+
+		inFlightRequests.WithLabelValues(group).Inc()
+		defer func() {
+			<-sem
+			inFlightRequests.WithLabelValues(group).Dec()
+		}()
+		c.Next()
+	}
+}
+
+// concurrencyGroup returns the route prefix whose group a route belongs to
+func concurrencyGroup(routes map[string]int, route string) string {
+	group, matched := defaultConcurrencyGroup, -1
+	for prefix := range routes {
+		if len(prefix) > matched && strings.HasPrefix(route, prefix) {
+			group, matched = prefix, len(prefix)
+		}
+	}
+	return group
+}
+
+// waitForSlot waits up to timeout for a slot, giving up early if the
+// client goes away
+func waitForSlot(c *gin.Context, sem chan struct{}, timeout time.Duration) bool {
+	if timeout <= 0 {
+		return false
+	}
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	select {
+	case sem <- struct{}{}:
+		return true
+	case <-timer.C:
+		return false
+	case <-c.Request.Context().Done():
+		return false
+	}
+}
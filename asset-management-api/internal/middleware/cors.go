@@ -3,6 +3,8 @@ package middleware
 import (
 	"net/http"
 
+	"asset-management-api/internal/tracing"
+
 	"github.com/gin-gonic/gin"
 )
 
@@ -10,7 +12,8 @@ func CORSMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		c.Header("Access-Control-Allow-Origin", "*")
 		c.Header("Access-Control-Allow-Credentials", "true")
-		c.Header("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With")
+		c.Header("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With, X-Request-ID, If-None-Match, Last-Event-ID, traceparent, tracestate")
+		c.Header("Access-Control-Expose-Headers", tracing.RequestIDHeader+", "+TraceIDHeader+", Retry-After, X-RateLimit-Limit, X-RateLimit-Remaining, X-RateLimit-Reset, X-Quota-Daily-Limit, X-Quota-Daily-Remaining, X-Quota-Daily-Reset, X-Quota-Monthly-Limit, X-Quota-Monthly-Remaining, X-Quota-Monthly-Reset, ETag, X-Cache")
 		c.Header("Access-Control-Allow-Methods", "POST, HEAD, PATCH, OPTIONS, GET, PUT, DELETE")
 
 		if c.Request.Method == "OPTIONS" {
@@ -22,22 +25,6 @@ func CORSMiddleware() gin.HandlerFunc {
 	}
 }
 
-func SecurityMiddleware() gin.HandlerFunc {
-	return func(c *gin.Context) {
-		c.Header("X-Content-Type-Options", "nosniff")
-		c.Header("X-Frame-Options", "DENY")
-		c.Header("X-XSS-Protection", "1; mode=block")
-		c.Header("Referrer-Policy", "strict-origin-when-cross-origin")
-		c.Header("Content-Security-Policy", "default-src 'self'")
-		
-		c.Next()
-	}
-}
-
 func RequestLoggingMiddleware() gin.HandlerFunc {
 	return gin.Logger()
-}
-
-func RecoveryMiddleware() gin.HandlerFunc {
-	return gin.Recovery()
 }
\ No newline at end of file
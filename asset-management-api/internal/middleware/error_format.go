@@ -0,0 +1,24 @@
+package middleware
+
+import (
+	"asset-management-api/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ErrorFormatMiddleware picks the format of the error responses: problem
+// details (RFC 7807) for requests accepting application/problem+json, or for
+// every request when problemByDefault is set, and the {"success": false}
+// envelope otherwise. The v2 API answers its own error format in place of
+// the envelope.
+func ErrorFormatMiddleware(problemByDefault bool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if problemByDefault || utils.AcceptsProblem(c.GetHeader("Accept")) {
+			c.Set(utils.ProblemDetailsKey, true)
+		}
+		if !problemByDefault {
+			c.Writer.Header().Add("Vary", "Accept")
+		}
+		c.Next()
+	}
+}
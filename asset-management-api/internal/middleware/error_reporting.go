@@ -0,0 +1,75 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"runtime/debug"
+
+	"asset-management-api/internal/tracing"
+	"asset-management-api/pkg/errreport"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RecoveryMiddleware turns panics into 500 responses. With a reporter the
+// panic is reported along with the request it happened in.
+func RecoveryMiddleware(reporter errreport.Reporter) gin.HandlerFunc {
+	if reporter == nil {
+		return gin.Recovery()
+	}
+
+	return gin.CustomRecovery(func(c *gin.Context, recovered interface{}) {
+		event := requestEvent(c, nil, http.StatusInternalServerError)
+		event.Panic = recovered
+		event.Stack = debug.Stack()
+		reporter.Report(c.Request.Context(), event)
+
+		c.AbortWithStatus(http.StatusInternalServerError)
+	})
+}
+
+// ErrorReportingMiddleware reports requests answered with a server error.
+// 503s are left out: they are returned on purpose while shedding load or
+// while a dependency is down, which the circuit breakers already record.
+func ErrorReportingMiddleware(reporter errreport.Reporter) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+
+		status := c.Writer.Status()
+		if status < http.StatusInternalServerError || status == http.StatusServiceUnavailable {
+			return
+		}
+
+		var err error
+		if last := c.Errors.Last(); last != nil {
+			err = last.Err
+		} else {
+			err = fmt.Errorf("%s %s responded %d", c.Request.Method, routeOf(c), status)
+		}
+		reporter.Report(c.Request.Context(), requestEvent(c, err, status))
+	}
+}
+
+func requestEvent(c *gin.Context, err error, status int) *errreport.Event {
+	event := &errreport.Event{
+		Err:       err,
+		RequestID: tracing.RequestID(c.Request.Context()),
+		TraceID:   tracing.TraceID(c.Request.Context()),
+		Method:    c.Request.Method,
+		Route:     routeOf(c),
+		Path:      c.Request.URL.Path,
+		Status:    status,
+	}
+	if userID, ok := GetUserIDFromContext(c); ok {
+		event.UserID = userID.String()
+	}
+	return event
+}
+
+// routeOf names the request's route, or its path when no route matched
+func routeOf(c *gin.Context) string {
+	if route := c.FullPath(); route != "" {
+		return route
+	}
+	return c.Request.URL.Path
+}
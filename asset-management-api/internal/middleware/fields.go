@@ -0,0 +1,33 @@
+package middleware
+
+import (
+	"net/http"
+
+	"asset-management-api/internal/models"
+	"asset-management-api/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SparseFieldsetsMiddleware reads the fields query parameter of reads, e.g.
+// GET /notes?fields=note_id,title,updated_at, into the request context:
+// the success responses keep only those fields of their data, and
+// repositories skip the heavy columns left out. Writes ignore the parameter.
+func SparseFieldsetsMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		param, ok := c.GetQuery("fields")
+		if !ok || (c.Request.Method != http.MethodGet && c.Request.Method != http.MethodHead) {
+			c.Next()
+			return
+		}
+
+		fields, err := models.ParseFields(param)
+		if err != nil {
+			utils.BadRequestResponse(c, "Query parameter 'fields' must be a comma separated list of field names", err)
+			c.Abort()
+			return
+		}
+		c.Request = c.Request.WithContext(models.ContextWithFields(c.Request.Context(), fields))
+		c.Next()
+	}
+}
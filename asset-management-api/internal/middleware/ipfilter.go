@@ -0,0 +1,173 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/netip"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"asset-management-api/internal/utils"
+	"asset-management-api/pkg/ipfilter"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// Reasons a request is rejected by the IP filter
+const (
+	ipRejectDenied         = "denied"
+	ipRejectNotAllowlisted = "not_allowlisted"
+)
+
+var ipFilterRejectionsTotal = promauto.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "http_ip_filter_rejections_total",
+		Help: "Total number of requests rejected by the IP filter, by reason (denied, not_allowlisted)",
+	},
+	[]string{"reason"},
+)
+
+// IPFilterPolicy holds the configured network restrictions. Only Allowlist
+// networks may call routes whose Gin route pattern starts with one of
+// AllowlistRoutes, e.g. "/api/v1/admin/"; with no Allowlist they are open.
+// Denylist networks are rejected on every route.
+type IPFilterPolicy struct {
+	Allowlist       []netip.Prefix
+	AllowlistRoutes []string
+	Denylist        []netip.Prefix
+}
+
+// restricted reports whether a route is limited to the allowlist
+func (p IPFilterPolicy) restricted(route string) bool {
+	if len(p.Allowlist) == 0 || route == "" {
+		return false
+	}
+	for _, prefix := range p.AllowlistRoutes {
+		if strings.HasPrefix(route, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// IPFilter rejects requests by client IP before they are authenticated. On
+// top of the policy's deny list it enforces the entries of a Store, kept in
+// memory and reloaded by Watch, so that all instances pick up the entries
+// added through the admin endpoints.
+type IPFilter struct {
+	policy IPFilterPolicy
+	store  ipfilter.Store
+	denied atomic.Pointer[[]netip.Prefix]
+}
+
+// NewIPFilter creates an IP filter; call Refresh to load the store's entries
+func NewIPFilter(policy IPFilterPolicy, store ipfilter.Store) *IPFilter {
+	f := &IPFilter{policy: policy, store: store}
+	f.denied.Store(&[]netip.Prefix{})
+	return f
+}
+
+// Middleware rejects denied clients, and clients outside the allowlist on
+// restricted routes, with 403. It relies on Gin's client IP, so only
+// trusted proxies may be allowed to set X-Forwarded-For.
+func (f *IPFilter) Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		clientIP := c.ClientIP()
+		addr, err := netip.ParseAddr(clientIP)
+		route := c.FullPath()
+
+		switch {
+		case err == nil && (ipfilter.Contains(f.policy.Denylist, addr) || ipfilter.Contains(*f.denied.Load(), addr)):
+			f.reject(c, ipRejectDenied, clientIP, route)
+		case f.policy.restricted(route) && (err != nil || !ipfilter.Contains(f.policy.Allowlist, addr)):
+			f.reject(c, ipRejectNotAllowlisted, clientIP, route)
+		default:
+			c.Next()
+		}
+	}
+}
+
+func (f *IPFilter) reject(c *gin.Context, reason, clientIP, route string) {
+	ipFilterRejectionsTotal.WithLabelValues(reason).Inc()
+	LogSecurityEvent("ip_rejected", map[string]interface{}{
+		"reason":    reason,
+		"client_ip": clientIP,
+		"method":    c.Request.Method,
+		"endpoint":  route,
+		"path":      c.Request.URL.Path,
+	})
+	utils.CodedErrorResponse(c, http.StatusForbidden, utils.CodeNetworkDenied, "Access denied from this network", "Access denied")
+	c.Abort()
+}
+
+// Entries returns the deny list maintained through the admin endpoints
+func (f *IPFilter) Entries(ctx context.Context) ([]ipfilter.Entry, error) {
+	return f.store.List(ctx)
+}
+
+// Deny adds an entry to the deny list; it applies on this instance at once
+// and on the others at their next refresh
+func (f *IPFilter) Deny(ctx context.Context, entry ipfilter.Entry) error {
+	if err := f.store.Add(ctx, entry); err != nil {
+		return err
+	}
+	return f.Refresh(ctx)
+}
+
+// Undeny removes the entry for cidr from the deny list, reporting whether
+// there was one
+func (f *IPFilter) Undeny(ctx context.Context, cidr string) (bool, error) {
+	removed, err := f.store.Remove(ctx, cidr)
+	if err != nil || !removed {
+		return removed, err
+	}
+	return true, f.Refresh(ctx)
+}
+
+// Refresh reloads the deny list from the store. Unparsable entries are
+// skipped, as the admin endpoints only add valid ones.
+func (f *IPFilter) Refresh(ctx context.Context) error {
+	entries, err := f.store.List(ctx)
+	if err != nil {
+		return err
+	}
+
+	denied := make([]netip.Prefix, 0, len(entries))
+	for _, entry := range entries {
+		prefix, err := ipfilter.ParsePrefix(entry.CIDR)
+		if err != nil {
+			LogError(err, map[string]interface{}{
+				"component": "ip_filter",
+				"action":    "refresh",
+			})
+			continue
+		}
+		denied = append(denied, prefix)
+	}
+	f.denied.Store(&denied)
+	return nil
+}
+
+// Watch refreshes the deny list every interval until ctx is done. If the
+// store fails, the last loaded list stays in force.
+func (f *IPFilter) Watch(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := f.Refresh(ctx); err != nil && ctx.Err() == nil {
+				LogError(err, map[string]interface{}{
+					"component": "ip_filter",
+					"action":    "refresh",
+				})
+			}
+		}
+	}
+}
@@ -0,0 +1,21 @@
+package middleware
+
+import (
+	"asset-management-api/internal/i18n"
+	"asset-management-api/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// LanguageMiddleware picks the language of the error and validation messages
+// from the Accept-Language header among those of the catalog, and names it
+// in Content-Language. Requests without a known language get English.
+func LanguageMiddleware(catalog *i18n.Catalog) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		lang := catalog.Negotiate(c.GetHeader("Accept-Language"))
+		c.Set(utils.LocalizerKey, catalog.Localizer(lang))
+		c.Header("Content-Language", lang)
+		c.Writer.Header().Add("Vary", "Accept-Language")
+		c.Next()
+	}
+}
@@ -2,100 +2,189 @@ package middleware
 
 import (
 	"bytes"
-	"encoding/json"
 	"io"
+	"math/rand"
+	"net/http"
 	"os"
-	"path/filepath"
+	"strings"
 	"time"
 
+	"asset-management-api/internal/tracing"
+
 	"github.com/gin-gonic/gin"
 	"github.com/sirupsen/logrus"
 )
 
+// maxLoggedResponseBody is the largest response body included in the log
+const maxLoggedResponseBody = 1024
+
+// maxLoggedRequestBody bounds the request body kept for the log; larger
+// bodies are not logged
+const maxLoggedRequestBody = 1024
+
+// slowRequestThreshold marks requests logged as slow, and never sampled out
+const slowRequestThreshold = 1 * time.Second
+
+// responseBodyWriter keeps the start of the response body for logging; the
+// rest of a large or streamed response is not buffered
 type responseBodyWriter struct {
 	gin.ResponseWriter
 	body *bytes.Buffer
 }
 
 func (r responseBodyWriter) Write(b []byte) (int, error) {
-	r.body.Write(b)
+	if r.body.Len() < maxLoggedResponseBody {
+		r.body.Write(b)
+	}
 	return r.ResponseWriter.Write(b)
 }
 
-var logger *logrus.Logger
+// Unwrap lets http.ResponseController reach the underlying writer
+func (r responseBodyWriter) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
 
-func init() {
-	logger = logrus.New()
-	
-	// Create logs directory if it doesn't exist
-	if err := os.MkdirAll("logs", 0755); err != nil {
-		logger.Error("Failed to create logs directory:", err)
+// requestBodyRecorder keeps the start of the request body as the handler
+// reads it, so the body is not buffered up front for requests that end up
+// not being logged
+type requestBodyRecorder struct {
+	io.ReadCloser
+	body bytes.Buffer
+	size int
+	eof  bool
+}
+
+func (r *requestBodyRecorder) Read(p []byte) (int, error) {
+	n, err := r.ReadCloser.Read(p)
+	if room := maxLoggedRequestBody - r.body.Len(); room > 0 {
+		r.body.Write(p[:min(n, room)])
+	}
+	r.size += n
+	if err == io.EOF {
+		r.eof = true
 	}
+	return n, err
+}
 
-	// Set up file output
-	logFile, err := os.OpenFile(filepath.Join("logs", "api.log"), 
-		os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0666)
-	if err != nil {
-		logger.Error("Failed to open log file:", err)
-	} else {
-		logger.SetOutput(io.MultiWriter(os.Stdout, logFile))
+// loggable returns the whole body if it is small enough to log, reading
+// what the handler left unread
+func (r *requestBodyRecorder) loggable() (string, bool) {
+	if !r.eof && r.size < maxLoggedRequestBody {
+		_, _ = io.Copy(io.Discard, io.LimitReader(r, int64(maxLoggedRequestBody-r.size)))
+	}
+	if !r.eof || r.size == 0 || r.size >= maxLoggedRequestBody {
+		return "", false
 	}
+	return r.body.String(), true
+}
+
+// LogSamplingPolicy decides which requests RequestResponseLoggingMiddleware
+// logs. Responses below 400 are logged at SuccessRate, or the rate of the
+// longest matching Routes prefix (Gin route patterns, e.g. "/api/v1/admin/");
+// error responses at ErrorRate. Slow requests are always logged. Rates range
+// from 0 (none) to 1 (all).
+type LogSamplingPolicy struct {
+	SuccessRate float64
+	ErrorRate   float64
+	Routes      map[string]float64
+}
 
-	// Set JSON formatter for structured logging
-	logger.SetFormatter(&logrus.JSONFormatter{
+// rate returns the share of requests like this one that are logged
+func (p LogSamplingPolicy) rate(route string, status int, latency time.Duration) float64 {
+	if latency > slowRequestThreshold {
+		return 1
+	}
+	if status >= 400 {
+		return p.ErrorRate
+	}
+	rate, matched := p.SuccessRate, -1
+	for prefix, routeRate := range p.Routes {
+		if len(prefix) > matched && strings.HasPrefix(route, prefix) {
+			rate, matched = routeRate, len(prefix)
+		}
+	}
+	return rate
+}
+
+// sampled reports whether to log a request logged at rate
+func sampled(rate float64) bool {
+	return rate >= 1 || rate > 0 && rand.Float64() < rate
+}
+
+// logger backs the Log* helpers. Until SetLogger is called at startup it
+// writes JSON to stdout at info level.
+var logger = newDefaultLogger()
+
+func newDefaultLogger() *logrus.Logger {
+	l := logrus.New()
+	l.SetOutput(os.Stdout)
+	l.SetFormatter(&logrus.JSONFormatter{
 		TimestampFormat: time.RFC3339,
 	})
+	l.SetLevel(logrus.InfoLevel)
+	return l
+}
 
-	logger.SetLevel(logrus.InfoLevel)
+// SetLogger replaces the logger behind the Log* helpers with the one built
+// from configuration. Call it once at startup, before serving requests.
+func SetLogger(l *logrus.Logger) {
+	logger = l
 }
 
-func StructuredLoggingMiddleware() gin.HandlerFunc {
-	return gin.LoggerWithFormatter(func(param gin.LogFormatterParams) string {
-		logData := map[string]interface{}{
-			"timestamp":    param.TimeStamp.Format(time.RFC3339),
-			"status":       param.StatusCode,
-			"latency":      param.Latency.String(),
-			"client_ip":    param.ClientIP,
-			"method":       param.Method,
-			"path":         param.Path,
-			"user_agent":   param.Request.UserAgent(),
-			"error":        param.ErrorMessage,
-			"body_size":    param.BodySize,
+// StructuredLoggingMiddleware writes one access log entry per request, at a
+// level by status code
+func StructuredLoggingMiddleware(logger *logrus.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		path := c.Request.URL.Path
+		if raw := c.Request.URL.RawQuery; raw != "" {
+			path += "?" + raw
+		}
+
+		c.Next()
+
+		logData := logrus.Fields{
+			"status":     c.Writer.Status(),
+			"latency":    time.Since(start).String(),
+			"client_ip":  c.ClientIP(),
+			"method":     c.Request.Method,
+			"path":       path,
+			"user_agent": c.Request.UserAgent(),
+			"error":      c.Errors.ByType(gin.ErrorTypePrivate).String(),
+			"body_size":  c.Writer.Size(),
 		}
 
 		// Add request ID if available
-		if requestID := param.Request.Header.Get("X-Request-ID"); requestID != "" {
+		if requestID := tracing.RequestID(c.Request.Context()); requestID != "" {
 			logData["request_id"] = requestID
 		}
+		if traceID := tracing.TraceID(c.Request.Context()); traceID != "" {
+			logData["trace_id"] = traceID
+		}
 
 		// Log level based on status code
-		var level string
+		level := logrus.InfoLevel
 		switch {
-		case param.StatusCode >= 500:
-			level = "ERROR"
-		case param.StatusCode >= 400:
-			level = "WARN"
-		case param.StatusCode >= 300:
-			level = "INFO"
-		default:
-			level = "INFO"
+		case c.Writer.Status() >= 500:
+			level = logrus.ErrorLevel
+		case c.Writer.Status() >= 400:
+			level = logrus.WarnLevel
 		}
-		logData["level"] = level
-
-		logJSON, _ := json.Marshal(logData)
-		return string(logJSON) + "\n"
-	})
+		logger.WithFields(logData).Log(level, "HTTP Request")
+	}
 }
 
-func RequestResponseLoggingMiddleware() gin.HandlerFunc {
+// RequestResponseLoggingMiddleware logs a sample of requests, as the policy
+// sets, with their bodies when they are small
+func RequestResponseLoggingMiddleware(logger *logrus.Logger, policy LogSamplingPolicy) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		start := time.Now()
-		
-		// Read request body
-		var requestBody []byte
-		if c.Request.Body != nil {
-			requestBody, _ = io.ReadAll(c.Request.Body)
-			c.Request.Body = io.NopCloser(bytes.NewBuffer(requestBody))
+
+		// Keep the start of the request body as it is read
+		var requestBody *requestBodyRecorder
+		if c.Request.Body != nil && c.Request.Body != http.NoBody {
+			requestBody = &requestBodyRecorder{ReadCloser: c.Request.Body}
+			c.Request.Body = requestBody
 		}
 
 		// Create response writer wrapper
@@ -106,6 +195,10 @@ func RequestResponseLoggingMiddleware() gin.HandlerFunc {
 		c.Next()
 
 		latency := time.Since(start)
+		rate := policy.rate(c.FullPath(), c.Writer.Status(), latency)
+		if !sampled(rate) {
+			return
+		}
 
 		// Prepare log data
 		logData := logrus.Fields{
@@ -118,27 +211,37 @@ func RequestResponseLoggingMiddleware() gin.HandlerFunc {
 			"client_ip":     c.ClientIP(),
 			"user_agent":    c.Request.UserAgent(),
 			"request_size":  c.Request.ContentLength,
-			"response_size": w.body.Len(),
+			"response_size": max(w.Size(), 0),
 		}
 
-		// Add user context if available
+		// Add request and user context if available
+		if requestID := GetRequestIDFromContext(c); requestID != "" {
+			logData["request_id"] = requestID
+		}
+		if traceID := tracing.TraceID(c.Request.Context()); traceID != "" {
+			logData["trace_id"] = traceID
+		}
 		if userID, exists := c.Get("user_id"); exists {
 			logData["user_id"] = userID
 		}
 		if userRole, exists := c.Get("user_role"); exists {
 			logData["user_role"] = userRole
 		}
+		// Each logged request stands for 1/rate requests
+		if rate < 1 {
+			logData["sample_rate"] = rate
+		}
 
 		// Add request body for non-GET requests (excluding sensitive data)
-		if c.Request.Method != "GET" && len(requestBody) > 0 && len(requestBody) < 1024 {
+		if c.Request.Method != "GET" && requestBody != nil {
 			// Don't log passwords or other sensitive fields
-			if !containsSensitiveData(string(requestBody)) {
-				logData["request_body"] = string(requestBody)
+			if body, ok := requestBody.loggable(); ok && !containsSensitiveData(body) {
+				logData["request_body"] = body
 			}
 		}
 
 		// Add response body for errors
-		if c.Writer.Status() >= 400 && w.body.Len() > 0 && w.body.Len() < 1024 {
+		if c.Writer.Status() >= 400 && w.body.Len() > 0 && w.body.Len() < maxLoggedResponseBody {
 			logData["response_body"] = w.body.String()
 		}
 
@@ -155,7 +258,7 @@ func RequestResponseLoggingMiddleware() gin.HandlerFunc {
 			logger.WithFields(logData).Warn("Client Error")
 		case c.Writer.Status() >= 300:
 			logger.WithFields(logData).Info("Redirect")
-		case latency > 1*time.Second:
+		case latency > slowRequestThreshold:
 			logger.WithFields(logData).Warn("Slow Request")
 		default:
 			logger.WithFields(logData).Info("Request Completed")
@@ -195,6 +298,15 @@ func LogInfo(message string, context map[string]interface{}) {
 	logger.WithFields(fields).Info(message)
 }
 
+// Warning logging function
+func LogWarn(message string, context map[string]interface{}) {
+	fields := logrus.Fields{}
+	for k, v := range context {
+		fields[k] = v
+	}
+	logger.WithFields(fields).Warn(message)
+}
+
 // Debug logging function
 func LogDebug(message string, context map[string]interface{}) {
 	fields := logrus.Fields{}
@@ -0,0 +1,168 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"asset-management-api/internal/utils"
+	"asset-management-api/pkg/maintenance"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	maintenanceRejectionsTotal = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "http_maintenance_rejections_total",
+			Help: "Total number of requests turned away while in maintenance mode",
+		},
+	)
+
+	maintenanceMode = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "maintenance_mode",
+			Help: "Whether this instance is in maintenance mode (1) or not (0)",
+		},
+	)
+)
+
+// Maintenance turns API traffic away while maintenance mode is on, e.g.
+// during migrations. Like the IP filter's deny list, the flag is kept in
+// memory and reloaded from its Store by Watch, so all instances follow the
+// admin endpoints without a Redis round trip per request.
+type Maintenance struct {
+	store      maintenance.Store
+	message    string
+	retryAfter time.Duration
+	state      atomic.Pointer[maintenance.State]
+}
+
+// NewMaintenance creates a maintenance switch using message and retryAfter
+// for windows started without their own; call Refresh to load the store's
+// state
+func NewMaintenance(store maintenance.Store, message string, retryAfter time.Duration) *Maintenance {
+	return &Maintenance{store: store, message: message, retryAfter: retryAfter}
+}
+
+// Middleware rejects requests with 503 while maintenance mode is on, except
+// those of managers so they can run the maintenance and switch it off again.
+// It must run after RequireAuth; health checks and metrics are served
+// outside the routes it guards.
+func (m *Maintenance) Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		state := m.state.Load()
+		if state == nil {
+			c.Next()
+			return
+		}
+		if role, _ := GetUserRoleFromContext(c); role == "manager" {
+			c.Next()
+			return
+		}
+
+		maintenanceRejectionsTotal.Inc()
+		if state.RetryAfter > 0 {
+			c.Header("Retry-After", strconv.Itoa(state.RetryAfter))
+		}
+		if utils.WantsProblem(c) {
+			utils.ProblemResponse(c, utils.Problem{
+				Status: http.StatusServiceUnavailable,
+				Code:   utils.CodeUnderMaintenance,
+				Detail: state.Message,
+				Extensions: map[string]interface{}{
+					"started_at":          state.StartedAt,
+					"retry_after_seconds": state.RetryAfter,
+				},
+			})
+			c.Abort()
+			return
+		}
+		c.AbortWithStatusJSON(http.StatusServiceUnavailable, utils.Response{
+			Success: false,
+			Message: state.Message,
+			Error:   "Service under maintenance",
+			Data: gin.H{
+				"maintenance":         true,
+				"started_at":          state.StartedAt,
+				"retry_after_seconds": state.RetryAfter,
+			},
+			RequestID: c.GetString(utils.RequestIDKey),
+		})
+	}
+}
+
+// State returns the current maintenance window, or nil when maintenance mode
+// is off
+func (m *Maintenance) State() *maintenance.State {
+	return m.state.Load()
+}
+
+// Enable switches maintenance mode on, filling in the default message and
+// retry delay when state has none; it applies on this instance at once and
+// on the others at their next refresh
+func (m *Maintenance) Enable(ctx context.Context, state maintenance.State) (*maintenance.State, error) {
+	if state.Message == "" {
+		state.Message = m.message
+	}
+	if state.RetryAfter <= 0 {
+		state.RetryAfter = int(m.retryAfter.Seconds())
+	}
+	if err := m.store.Set(ctx, state); err != nil {
+		return nil, err
+	}
+	return &state, m.Refresh(ctx)
+}
+
+// Disable switches maintenance mode off, reporting whether it was on
+func (m *Maintenance) Disable(ctx context.Context) (bool, error) {
+	on, err := m.store.Clear(ctx)
+	if err != nil {
+		return false, err
+	}
+	return on, m.Refresh(ctx)
+}
+
+// Refresh reloads the maintenance state from the store
+func (m *Maintenance) Refresh(ctx context.Context) error {
+	state, err := m.store.Get(ctx)
+	if err != nil {
+		return err
+	}
+	if state != nil && state.Message == "" {
+		state.Message = m.message
+	}
+
+	m.state.Store(state)
+	if state != nil {
+		maintenanceMode.Set(1)
+	} else {
+		maintenanceMode.Set(0)
+	}
+	return nil
+}
+
+// Watch refreshes the maintenance state every interval until ctx is done. If
+// the store fails, the last loaded state stays in force.
+func (m *Maintenance) Watch(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := m.Refresh(ctx); err != nil && ctx.Err() == nil {
+				LogError(err, map[string]interface{}{
+					"component": "maintenance",
+					"action":    "refresh",
+				})
+			}
+		}
+	}
+}
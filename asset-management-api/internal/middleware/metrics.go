@@ -1,14 +1,25 @@
 package middleware
 
 import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
 	"strconv"
+	"sync"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
+	"go.opentelemetry.io/otel/trace"
 )
 
+// DefaultLatencyBuckets are the latency histogram buckets, in seconds, unless
+// configured otherwise. Most requests finish within 50ms, so the buckets are
+// finest below that.
+var DefaultLatencyBuckets = []float64{0.001, 0.0025, 0.005, 0.01, 0.02, 0.035, 0.05, 0.075, 0.1, 0.25, 0.5, 1, 2.5, 5}
+
 var (
 	// HTTP request metrics
 	httpRequestsTotal = promauto.NewCounterVec(
@@ -19,14 +30,7 @@ var (
 		[]string{"method", "endpoint", "status"},
 	)
 
-	httpRequestDuration = promauto.NewHistogramVec(
-		prometheus.HistogramOpts{
-			Name:    "http_request_duration_seconds",
-			Help:    "Duration of HTTP requests in seconds",
-			Buckets: prometheus.DefBuckets,
-		},
-		[]string{"method", "endpoint", "status"},
-	)
+	httpRequestDuration = newHTTPRequestDuration(DefaultLatencyBuckets)
 
 	httpRequestSize = promauto.NewHistogramVec(
 		prometheus.HistogramOpts{
@@ -86,6 +90,41 @@ var (
 		},
 	)
 
+	dbConnectionsIdle = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "db_connections_idle",
+			Help: "Number of idle database connections",
+		},
+	)
+
+	dbConnectionsOpen = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "db_connections_open",
+			Help: "Number of open database connections, in use or idle",
+		},
+	)
+
+	dbConnectionsMaxOpen = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "db_connections_max_open",
+			Help: "Maximum number of open database connections",
+		},
+	)
+
+	dbConnectionWaitsTotal = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "db_connection_waits_total",
+			Help: "Total number of queries that waited for a free database connection",
+		},
+	)
+
+	dbConnectionWaitSecondsTotal = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "db_connection_wait_seconds_total",
+			Help: "Total time spent waiting for a free database connection",
+		},
+	)
+
 	dbQueriesTotal = promauto.NewCounterVec(
 		prometheus.CounterOpts{
 			Name: "db_queries_total",
@@ -94,13 +133,14 @@ var (
 		[]string{"operation", "table"},
 	)
 
-	dbQueryDuration = promauto.NewHistogramVec(
-		prometheus.HistogramOpts{
-			Name:    "db_query_duration_seconds",
-			Help:    "Duration of database queries in seconds",
-			Buckets: prometheus.DefBuckets,
+	dbQueryDuration = newDBQueryDuration(DefaultLatencyBuckets)
+
+	dbRetriesTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "db_retries_total",
+			Help: "Total number of database statements and transactions retried by transient error",
 		},
-		[]string{"operation", "table"},
+		[]string{"reason"},
 	)
 
 	// Error metrics
@@ -129,6 +169,74 @@ var (
 	)
 )
 
+func newHTTPRequestDuration(buckets []float64) *prometheus.HistogramVec {
+	return promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "http_request_duration_seconds",
+			Help:    "Duration of HTTP requests in seconds",
+			Buckets: buckets,
+		},
+		[]string{"method", "endpoint", "status"},
+	)
+}
+
+func newDBQueryDuration(buckets []float64) *prometheus.HistogramVec {
+	return promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "db_query_duration_seconds",
+			Help:    "Duration of database queries in seconds",
+			Buckets: buckets,
+		},
+		[]string{"operation", "table"},
+	)
+}
+
+// SetLatencyBuckets replaces the buckets of the HTTP request and database
+// query latency histograms; empty lists keep the defaults. Call it at
+// startup, before anything is observed.
+func SetLatencyBuckets(httpBuckets, dbBuckets []float64) error {
+	for _, buckets := range [][]float64{httpBuckets, dbBuckets} {
+		if err := validateBuckets(buckets); err != nil {
+			return err
+		}
+	}
+
+	if len(httpBuckets) > 0 {
+		prometheus.Unregister(httpRequestDuration)
+		httpRequestDuration = newHTTPRequestDuration(httpBuckets)
+	}
+	if len(dbBuckets) > 0 {
+		prometheus.Unregister(dbQueryDuration)
+		dbQueryDuration = newDBQueryDuration(dbBuckets)
+	}
+	return nil
+}
+
+func validateBuckets(buckets []float64) error {
+	if !sort.Float64sAreSorted(buckets) {
+		return fmt.Errorf("histogram buckets %v must be in increasing order", buckets)
+	}
+	for i := 1; i < len(buckets); i++ {
+		if buckets[i] == buckets[i-1] {
+			return fmt.Errorf("histogram buckets %v contain %v twice", buckets, buckets[i])
+		}
+	}
+	return nil
+}
+
+// observeWithTraceExemplar records a latency, attaching the trace ID as an
+// exemplar when the trace is sampled, so the bucket links to a trace that
+// was exported
+func observeWithTraceExemplar(ctx context.Context, observer prometheus.Observer, seconds float64) {
+	if spanContext := trace.SpanContextFromContext(ctx); spanContext.IsSampled() {
+		if exemplarObserver, ok := observer.(prometheus.ExemplarObserver); ok {
+			exemplarObserver.ObserveWithExemplar(seconds, prometheus.Labels{"trace_id": spanContext.TraceID().String()})
+			return
+		}
+	}
+	observer.Observe(seconds)
+}
+
 // PrometheusMiddleware collects HTTP metrics
 func PrometheusMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -157,7 +265,7 @@ func PrometheusMiddleware() gin.HandlerFunc {
 
 		// Record metrics
 		httpRequestsTotal.WithLabelValues(method, endpoint, status).Inc()
-		httpRequestDuration.WithLabelValues(method, endpoint, status).Observe(duration.Seconds())
+		observeWithTraceExemplar(c.Request.Context(), httpRequestDuration.WithLabelValues(method, endpoint, status), duration.Seconds())
 		
 		if requestSize > 0 {
 			httpRequestSize.WithLabelValues(method, endpoint).Observe(requestSize)
@@ -198,15 +306,46 @@ func RecordShareCreated(resourceType, accessLevel string) {
 }
 
 // Database metrics functions
-func RecordDBQuery(operation, table string, duration time.Duration) {
+func RecordDBQuery(ctx context.Context, operation, table string, duration time.Duration) {
 	dbQueriesTotal.WithLabelValues(operation, table).Inc()
-	dbQueryDuration.WithLabelValues(operation, table).Observe(duration.Seconds())
+	observeWithTraceExemplar(ctx, dbQueryDuration.WithLabelValues(operation, table), duration.Seconds())
+}
+
+// RecordDBRetry counts a statement or transaction run again after a
+// transient error such as a deadlock
+func RecordDBRetry(reason string) {
+	dbRetriesTotal.WithLabelValues(reason).Inc()
 }
 
 func SetActiveDBConnections(count int) {
 	dbConnectionsActive.Set(float64(count))
 }
 
+// lastDBStats holds the previous sample, as the pool's wait statistics are
+// cumulative and the counters are advanced by the difference
+var (
+	lastDBStatsMu sync.Mutex
+	lastDBStats   sql.DBStats
+)
+
+// RecordDBStats records a sample of the connection pool's statistics
+func RecordDBStats(stats sql.DBStats) {
+	SetActiveDBConnections(stats.InUse)
+	dbConnectionsIdle.Set(float64(stats.Idle))
+	dbConnectionsOpen.Set(float64(stats.OpenConnections))
+	dbConnectionsMaxOpen.Set(float64(stats.MaxOpenConnections))
+
+	lastDBStatsMu.Lock()
+	defer lastDBStatsMu.Unlock()
+	if waits := stats.WaitCount - lastDBStats.WaitCount; waits > 0 {
+		dbConnectionWaitsTotal.Add(float64(waits))
+	}
+	if waited := stats.WaitDuration - lastDBStats.WaitDuration; waited > 0 {
+		dbConnectionWaitSecondsTotal.Add(waited.Seconds())
+	}
+	lastDBStats = stats
+}
+
 // JWT metrics functions
 func RecordJWTGenerated() {
 	jwtTokensGenerated.Inc()
@@ -0,0 +1,165 @@
+package middleware
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"asset-management-api/internal/utils"
+	"asset-management-api/pkg/quota"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	quotaExceededTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "http_quota_exceeded_total",
+			Help: "Total number of requests rejected because the caller's quota was used up",
+		},
+		[]string{"role", "period"},
+	)
+
+	quotaErrorsTotal = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "http_quota_errors_total",
+			Help: "Total number of requests let through because the quota tracker failed",
+		},
+	)
+)
+
+// quotaHeaders names the usage headers of each period
+var quotaHeaders = map[quota.Period]string{
+	quota.Daily:   "X-Quota-Daily",
+	quota.Monthly: "X-Quota-Monthly",
+}
+
+// QuotaPolicy holds the daily and monthly call quotas of each role; roles
+// without one get Default
+type QuotaPolicy struct {
+	Default quota.Quota
+	Roles   map[string]quota.Quota
+}
+
+// quotaFor returns the quota of a role
+func (p QuotaPolicy) quotaFor(role string) quota.Quota {
+	if q, ok := p.Roles[role]; ok {
+		return q
+	}
+	return p.Default
+}
+
+// Quotas enforces call quotas over calendar days and months. Unlike the rate
+// limiter, which smooths out bursts, quotas cap a caller's total usage. Its
+// policy can be replaced while serving, e.g. on a configuration reload.
+type Quotas struct {
+	tracker quota.Tracker
+	policy  atomic.Pointer[QuotaPolicy]
+}
+
+// NewQuotas creates the quota enforcement of a policy; a nil policy lets
+// every request through
+func NewQuotas(tracker quota.Tracker, policy *QuotaPolicy) *Quotas {
+	q := &Quotas{tracker: tracker}
+	q.policy.Store(policy)
+	return q
+}
+
+// SetPolicy replaces the quotas; nil disables them
+func (q *Quotas) SetPolicy(policy *QuotaPolicy) {
+	q.policy.Store(policy)
+}
+
+// Middleware counts every request against the caller's quota and rejects it
+// with 429 once a period is used up, until the period ends. Usage is reported
+// in X-Quota-{Daily,Monthly}-{Limit,Remaining,Reset} headers. Like the rate
+// limiter it must run after RequireAuth, and lets requests through if the
+// tracker fails.
+func (q *Quotas) Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		policy := q.policy.Load()
+		if policy == nil {
+			c.Next()
+			return
+		}
+
+		role, _ := GetUserRoleFromContext(c)
+		limits := policy.quotaFor(role)
+		if limits.Unlimited() {
+			c.Next()
+			return
+		}
+
+		key := rateLimitSubject(c)
+		result, err := q.tracker.Consume(c.Request.Context(), key, limits)
+		if err != nil {
+			quotaErrorsTotal.Inc()
+			LogError(err, map[string]interface{}{
+				"component": "quota",
+				"key":       key,
+			})
+			c.Next()
+			return
+		}
+
+		setQuotaHeaders(c, result.Usage)
+		if !result.Allowed {
+			q.reject(c, key, role, result.Usage)
+			return
+		}
+
+		c.Next()
+	}
+}
+
+func (q *Quotas) reject(c *gin.Context, key, role string, usage []quota.Usage) {
+	// The period to wait for is the exhausted one ending last
+	var exhausted quota.Usage
+	for _, u := range usage {
+		if u.Exhausted() && u.ResetAt.After(exhausted.ResetAt) {
+			exhausted = u
+		}
+	}
+
+	retryAfter := int(math.Ceil(time.Until(exhausted.ResetAt).Seconds()))
+	if retryAfter < 1 {
+		retryAfter = 1
+	}
+	c.Header("Retry-After", strconv.Itoa(retryAfter))
+	quotaExceededTotal.WithLabelValues(role, string(exhausted.Period)).Inc()
+	LogSecurityEvent("quota_exceeded", map[string]interface{}{
+		"key":      key,
+		"role":     role,
+		"period":   exhausted.Period,
+		"limit":    exhausted.Limit,
+		"reset_at": exhausted.ResetAt,
+	})
+	utils.CodedErrorResponse(c, http.StatusTooManyRequests, utils.CodeQuotaExceeded, "Quota exceeded",
+		fmt.Sprintf("The %s quota of %d requests is used up until %s", exhausted.Period, exhausted.Limit, exhausted.ResetAt.Format(time.RFC3339)))
+	c.Abort()
+}
+
+// Usage returns the caller's quota usage of each limited period, without
+// counting a call; none while quotas are disabled
+func (q *Quotas) Usage(c *gin.Context) ([]quota.Usage, error) {
+	policy := q.policy.Load()
+	if policy == nil {
+		return []quota.Usage{}, nil
+	}
+	role, _ := GetUserRoleFromContext(c)
+	return q.tracker.Usage(c.Request.Context(), rateLimitSubject(c), policy.quotaFor(role))
+}
+
+func setQuotaHeaders(c *gin.Context, usage []quota.Usage) {
+	for _, u := range usage {
+		prefix := quotaHeaders[u.Period]
+		c.Header(prefix+"-Limit", strconv.Itoa(u.Limit))
+		c.Header(prefix+"-Remaining", strconv.Itoa(u.Remaining))
+		c.Header(prefix+"-Reset", strconv.FormatInt(u.ResetAt.Unix(), 10))
+	}
+}
@@ -0,0 +1,146 @@
+package middleware
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"asset-management-api/internal/utils"
+	"asset-management-api/pkg/ratelimit"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	rateLimitedRequestsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "http_rate_limited_requests_total",
+			Help: "Total number of requests rejected by the rate limiter",
+		},
+		[]string{"method", "endpoint"},
+	)
+
+	rateLimitErrorsTotal = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "http_rate_limit_errors_total",
+			Help: "Total number of requests let through because the rate limiter failed",
+		},
+	)
+)
+
+// RateLimitPolicy holds the centrally configured limits. Routes are keyed by
+// method and Gin route pattern, e.g. "POST /api/v1/folders/:folderId/share",
+// and are counted in a window of their own; all other requests share the
+// caller's role limit, or Default for roles without one.
+type RateLimitPolicy struct {
+	Default ratelimit.Limit
+	Roles   map[string]ratelimit.Limit
+	Routes  map[string]ratelimit.Limit
+}
+
+// limitFor returns the limit of a request and the window it is counted in
+func (p RateLimitPolicy) limitFor(route, role string) (string, ratelimit.Limit) {
+	if limit, ok := p.Routes[route]; ok {
+		return route, limit
+	}
+	if limit, ok := p.Roles[role]; ok {
+		return "", limit
+	}
+	return "", p.Default
+}
+
+// RateLimit rejects callers that exceed their limit. Its policy can be
+// replaced while serving, e.g. on a configuration reload.
+type RateLimit struct {
+	limiter ratelimit.Limiter
+	policy  atomic.Pointer[RateLimitPolicy]
+}
+
+// NewRateLimit creates a rate limiter enforcing policy; a nil policy lets
+// every request through
+func NewRateLimit(limiter ratelimit.Limiter, policy *RateLimitPolicy) *RateLimit {
+	r := &RateLimit{limiter: limiter}
+	r.policy.Store(policy)
+	return r
+}
+
+// SetPolicy replaces the limits; nil disables rate limiting
+func (r *RateLimit) SetPolicy(policy *RateLimitPolicy) {
+	r.policy.Store(policy)
+}
+
+// Middleware rejects callers that exceed their limit with 429 and a
+// Retry-After header. Every response it counts carries the caller's limit in
+// X-RateLimit-Limit, the requests left in the window in X-RateLimit-Remaining
+// and, in X-RateLimit-Reset, the Unix time at which a request frees up, so
+// clients can pace themselves. Callers are identified by user ID, so it must run after
+// RequireAuth; unauthenticated requests are keyed by client IP. If the limiter
+// fails, requests are let through rather than failing the API with it.
+func (r *RateLimit) Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		policy := r.policy.Load()
+		if policy == nil {
+			c.Next()
+			return
+		}
+
+		role, _ := GetUserRoleFromContext(c)
+		route := c.Request.Method + " " + c.FullPath()
+		window, limit := policy.limitFor(route, role)
+		if limit.Unlimited() {
+			c.Next()
+			return
+		}
+
+		key := rateLimitSubject(c)
+		if window != "" {
+			key += ":" + window
+		}
+		result, err := r.limiter.Allow(c.Request.Context(), key, limit)
+		if err != nil {
+			rateLimitErrorsTotal.Inc()
+			LogError(err, map[string]interface{}{
+				"component": "rate_limiter",
+				"key":       key,
+			})
+			c.Next()
+			return
+		}
+
+		c.Header("X-RateLimit-Limit", strconv.Itoa(limit.Requests))
+		c.Header("X-RateLimit-Remaining", strconv.Itoa(result.Remaining))
+		resetAt := time.Now().Add(result.ResetAfter)
+		c.Header("X-RateLimit-Reset", strconv.FormatInt(int64(math.Ceil(float64(resetAt.UnixMilli())/1000)), 10))
+		if !result.Allowed {
+			retryAfter := int(math.Ceil(result.RetryAfter.Seconds()))
+			if retryAfter < 1 {
+				retryAfter = 1
+			}
+			c.Header("Retry-After", strconv.Itoa(retryAfter))
+			rateLimitedRequestsTotal.WithLabelValues(c.Request.Method, c.FullPath()).Inc()
+			LogSecurityEvent("rate_limit_exceeded", map[string]interface{}{
+				"key":         key,
+				"endpoint":    route,
+				"retry_after": retryAfter,
+			})
+			utils.CodedErrorResponse(c, http.StatusTooManyRequests, utils.CodeRateLimited, "Too many requests",
+				fmt.Sprintf("Rate limit of %d requests per %s exceeded", limit.Requests, limit.Window))
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
+
+func rateLimitSubject(c *gin.Context) string {
+	if userID, ok := GetUserIDFromContext(c); ok {
+		return "user:" + userID.String()
+	}
+	return "ip:" + c.ClientIP()
+}
@@ -0,0 +1,31 @@
+package middleware
+
+import (
+	"asset-management-api/internal/tracing"
+	"asset-management-api/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RequestIDMiddleware adopts the X-Request-ID of the incoming request, or
+// generates one, and returns it in the response. The ID is stored in the Gin
+// context for logs and error responses and in the request context, so the
+// services and the Kafka messages they publish carry it too.
+func RequestIDMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		requestID := c.GetHeader(tracing.RequestIDHeader)
+		if !tracing.ValidRequestID(requestID) {
+			requestID = tracing.NewRequestID()
+		}
+
+		c.Set(utils.RequestIDKey, requestID)
+		c.Request = c.Request.WithContext(tracing.ContextWithRequestID(c.Request.Context(), requestID))
+		c.Header(tracing.RequestIDHeader, requestID)
+		c.Next()
+	}
+}
+
+// Helper function to get the request ID from context
+func GetRequestIDFromContext(c *gin.Context) string {
+	return c.GetString(utils.RequestIDKey)
+}
@@ -0,0 +1,201 @@
+package middleware
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/url"
+	"sync/atomic"
+	"time"
+
+	"asset-management-api/internal/utils"
+	"asset-management-api/pkg/cache"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// maxCachedResponseSize keeps large responses out of the response cache
+const maxCachedResponseSize = 1 << 20
+
+var responseCacheRequestsTotal = promauto.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "http_response_cache_requests_total",
+		Help: "Total number of requests to cached routes, by result (hit, miss, error)",
+	},
+	[]string{"endpoint", "result"},
+)
+
+// ResponseCachePolicy lists the GET routes, by Gin route pattern such as
+// "/api/v1/folders", whose responses are cached, and for how long
+type ResponseCachePolicy struct {
+	Routes []string
+	TTL    time.Duration
+}
+
+// ResponseCaching serves repeated GETs of the policy's routes from a
+// short-lived cache. Its policy can be replaced while serving, e.g. on a
+// configuration reload.
+type ResponseCaching struct {
+	store  cache.ResponseCache
+	policy atomic.Pointer[responseCacheRules]
+}
+
+// responseCacheRules is a ResponseCachePolicy indexed by route
+type responseCacheRules struct {
+	routes map[string]bool
+	ttl    time.Duration
+}
+
+// NewResponseCaching creates the response caching of a policy; a nil policy
+// caches nothing
+func NewResponseCaching(store cache.ResponseCache, policy *ResponseCachePolicy) *ResponseCaching {
+	r := &ResponseCaching{store: store}
+	r.SetPolicy(policy)
+	return r
+}
+
+// SetPolicy replaces the cached routes and TTL; nil disables caching.
+// Writes keep invalidating cached responses while it is disabled, so none
+// are stale once it is enabled again.
+func (r *ResponseCaching) SetPolicy(policy *ResponseCachePolicy) {
+	if policy == nil {
+		r.policy.Store(nil)
+		return
+	}
+	routes := make(map[string]bool, len(policy.Routes))
+	for _, route := range policy.Routes {
+		routes[route] = true
+	}
+	r.policy.Store(&responseCacheRules{routes: routes, ttl: policy.TTL})
+}
+
+// Middleware serves cached responses, keyed by user, language and query. Responses of
+// routes with a :teamId are scoped to the team, all others to the user, and
+// the cache event handlers invalidate the scopes when their data changes. A
+// successful write invalidates the caller's scopes at once, so users see
+// their own changes before the events are handled. It must run after
+// RequireAuth.
+func (r *ResponseCaching) Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, ok := GetUserIDFromContext(c)
+		if !ok {
+			c.Next()
+			return
+		}
+
+		switch c.Request.Method {
+		case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+			c.Next()
+			if c.Writer.Status() < http.StatusBadRequest {
+				if err := r.store.Invalidate(c.Request.Context(), responseScopes(c, userID)...); err != nil {
+					LogError(err, map[string]interface{}{
+						"component": "response_cache",
+						"action":    "invalidate",
+					})
+				}
+			}
+			return
+		case http.MethodGet:
+		default:
+			c.Next()
+			return
+		}
+
+		policy := r.policy.Load()
+		route := c.FullPath()
+		if policy == nil || !policy.routes[route] {
+			c.Next()
+			return
+		}
+
+		scope := responseScopes(c, userID)[0]
+		key := responseCacheKey(userID, utils.Language(c), c.Request.URL)
+		cached, generation, err := r.store.Get(c.Request.Context(), scope, key)
+		if err != nil {
+			responseCacheRequestsTotal.WithLabelValues(route, "error").Inc()
+			LogError(err, map[string]interface{}{
+				"component": "response_cache",
+				"action":    "get",
+			})
+			c.Next()
+			return
+		}
+		if cached != nil {
+			responseCacheRequestsTotal.WithLabelValues(route, "hit").Inc()
+			c.Header("X-Cache", "HIT")
+			c.Data(cached.Status, cached.ContentType, cached.Body)
+			c.Abort()
+			return
+		}
+
+		responseCacheRequestsTotal.WithLabelValues(route, "miss").Inc()
+		c.Header("X-Cache", "MISS")
+		recorder := &responseRecorder{ResponseWriter: c.Writer}
+		c.Writer = recorder
+		c.Next()
+		c.Writer = recorder.ResponseWriter
+
+		if recorder.Status() != http.StatusOK || recorder.overflow {
+			return
+		}
+		response := &cache.CachedResponse{
+			Status:      http.StatusOK,
+			ContentType: recorder.Header().Get("Content-Type"),
+			Body:        recorder.body,
+		}
+		if err := r.store.Set(c.Request.Context(), scope, generation, key, response, policy.ttl); err != nil {
+			LogError(err, map[string]interface{}{
+				"component": "response_cache",
+				"action":    "set",
+			})
+		}
+	}
+}
+
+// responseScopes returns the scopes a request reads or writes: the route's
+// team, if it has one, then the user
+func responseScopes(c *gin.Context, userID uuid.UUID) []string {
+	if teamID, err := uuid.Parse(c.Param("teamId")); err == nil {
+		return []string{cache.TeamResponseScope(teamID), cache.UserResponseScope(userID)}
+	}
+	return []string{cache.UserResponseScope(userID)}
+}
+
+// responseCacheKey identifies a response by user, language, path and query,
+// with the query parameters in a canonical order
+func responseCacheKey(userID uuid.UUID, lang string, u *url.URL) string {
+	sum := sha256.Sum256([]byte(userID.String() + "\n" + lang + "\n" + u.Path + "?" + u.Query().Encode()))
+	return hex.EncodeToString(sum[:16])
+}
+
+// responseRecorder passes a response through while keeping a copy of its body
+type responseRecorder struct {
+	gin.ResponseWriter
+	body     []byte
+	overflow bool
+}
+
+func (w *responseRecorder) Write(data []byte) (int, error) {
+	w.record(data)
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *responseRecorder) WriteString(s string) (int, error) {
+	w.record([]byte(s))
+	return w.ResponseWriter.WriteString(s)
+}
+
+func (w *responseRecorder) record(data []byte) {
+	if w.overflow {
+		return
+	}
+	if len(w.body)+len(data) > maxCachedResponseSize {
+		w.overflow = true
+		w.body = nil
+		return
+	}
+	w.body = append(w.body, data...)
+}
@@ -0,0 +1,78 @@
+package middleware
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SecurityHeaders are the configurable response headers; an empty value
+// leaves the header out
+type SecurityHeaders struct {
+	StrictTransportSecurity string
+	ContentSecurityPolicy   string
+	FrameOptions            string
+	ReferrerPolicy          string
+}
+
+// SecurityPolicy sets the Default headers on every response, except on
+// routes whose Gin route pattern starts with one of RelaxedRoutes, e.g.
+// public pages meant to be embedded, which get the Relaxed headers instead
+type SecurityPolicy struct {
+	Default       SecurityHeaders
+	Relaxed       SecurityHeaders
+	RelaxedRoutes []string
+}
+
+// headers returns the headers of a route
+func (p SecurityPolicy) headers(route string) SecurityHeaders {
+	if route != "" {
+		for _, prefix := range p.RelaxedRoutes {
+			if strings.HasPrefix(route, prefix) {
+				return p.Relaxed
+			}
+		}
+	}
+	return p.Default
+}
+
+// StrictTransportSecurity formats an HSTS header value; a maxAge of zero or
+// less disables HSTS
+func StrictTransportSecurity(maxAge time.Duration, includeSubdomains, preload bool) string {
+	if maxAge <= 0 {
+		return ""
+	}
+	value := fmt.Sprintf("max-age=%d", int64(maxAge.Seconds()))
+	if includeSubdomains {
+		value += "; includeSubDomains"
+	}
+	if preload {
+		value += "; preload"
+	}
+	return value
+}
+
+// SecurityMiddleware sets the security headers of the policy. Headers that
+// are not configurable are set on every route.
+func SecurityMiddleware(policy SecurityPolicy) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Header("X-Content-Type-Options", "nosniff")
+		c.Header("X-XSS-Protection", "1; mode=block")
+
+		headers := policy.headers(c.FullPath())
+		setHeader(c, "Strict-Transport-Security", headers.StrictTransportSecurity)
+		setHeader(c, "Content-Security-Policy", headers.ContentSecurityPolicy)
+		setHeader(c, "X-Frame-Options", headers.FrameOptions)
+		setHeader(c, "Referrer-Policy", headers.ReferrerPolicy)
+
+		c.Next()
+	}
+}
+
+func setHeader(c *gin.Context, name, value string) {
+	if value != "" {
+		c.Header(name, value)
+	}
+}
@@ -0,0 +1,47 @@
+package middleware
+
+import (
+	"net/http"
+
+	"asset-management-api/internal/tracing"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TraceIDHeader returns the request's trace ID to the client, for support requests
+const TraceIDHeader = "X-Trace-ID"
+
+// OTelMiddleware records a server span per request, named after the route.
+// Metrics scrapes and health checks and probes are left out.
+func OTelMiddleware(serviceName string) gin.HandlerFunc {
+	return otelgin.Middleware(serviceName, otelgin.WithFilter(func(r *http.Request) bool {
+		switch r.URL.Path {
+		case "/metrics", "/health", "/live", "/ready":
+			return false
+		}
+		return true
+	}))
+}
+
+// TracingMiddleware continues the W3C trace context of the incoming request,
+// or starts a new trace, and stores the request's span in the request
+// context. Events published with that context carry the trace on to their
+// consumers. Behind OTelMiddleware the request's span is the recorded server
+// span.
+func TracingMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		span, ok := tracing.FromSpan(trace.SpanFromContext(c.Request.Context()))
+		if !ok {
+			span = tracing.New()
+			if parent, ok := tracing.Parse(c.GetHeader(tracing.TraceparentHeader), c.GetHeader(tracing.TracestateHeader)); ok {
+				span = parent.NewChild()
+			}
+		}
+
+		c.Request = c.Request.WithContext(tracing.ContextWith(c.Request.Context(), span))
+		c.Header(TraceIDHeader, span.TraceID)
+		c.Next()
+	}
+}
@@ -0,0 +1,45 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// APIAudit is one mutating API request as recorded in the api_audit table
+type APIAudit struct {
+	ID         int64           `json:"id" gorm:"primaryKey;autoIncrement"`
+	RequestID  string          `json:"request_id,omitempty"`
+	ActorID    *uuid.UUID      `json:"actor_id,omitempty" gorm:"type:uuid"`
+	ActorRole  string          `json:"actor_role,omitempty"`
+	Method     string          `json:"method" gorm:"not null"`
+	Route      string          `json:"route" gorm:"not null"`
+	Path       string          `json:"path" gorm:"not null"`
+	EntityType string          `json:"entity_type,omitempty"`
+	EntityID   string          `json:"entity_id,omitempty"`
+	Params     json.RawMessage `json:"params,omitempty" gorm:"type:jsonb"`
+	Outcome    string          `json:"outcome" gorm:"not null"`
+	HTTPStatus int             `json:"http_status" gorm:"column:http_status;not null"`
+	LatencyMs  int64           `json:"latency_ms" gorm:"not null"`
+	ClientIP   string          `json:"client_ip,omitempty"`
+	TraceID    string          `json:"trace_id,omitempty"`
+	OccurredAt time.Time       `json:"occurred_at" gorm:"primaryKey;not null"`
+}
+
+func (APIAudit) TableName() string {
+	return "api_audit"
+}
+
+// APIAuditFilter selects audit records; zero fields match everything
+type APIAuditFilter struct {
+	ActorID    *uuid.UUID
+	Method     string
+	Route      string
+	EntityType string
+	EntityID   string
+	Outcome    string
+	From       time.Time
+	To         time.Time
+	Limit      int
+}
@@ -0,0 +1,29 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AssetEventLog is one asset.changes event as recorded in the event store
+type AssetEventLog struct {
+	ID            int64           `json:"id" gorm:"primaryKey;autoIncrement"`
+	EventID       *uuid.UUID      `json:"event_id,omitempty" gorm:"type:uuid;uniqueIndex:idx_asset_event_log_event"` // Nil for events published before envelopes
+	EventType     string          `json:"event_type" gorm:"not null"`
+	AssetType     string          `json:"asset_type" gorm:"not null"`
+	AssetID       uuid.UUID       `json:"asset_id" gorm:"type:uuid;not null;index"`
+	OwnerID       uuid.UUID       `json:"owner_id" gorm:"type:uuid;not null"`
+	ActionBy      uuid.UUID       `json:"action_by" gorm:"type:uuid;not null"`
+	Payload       json.RawMessage `json:"payload" gorm:"type:jsonb;not null"`
+	SchemaVersion int             `json:"schema_version" gorm:"not null"`
+	Producer      string          `json:"producer,omitempty"`
+	TraceID       string          `json:"trace_id,omitempty"`
+	OccurredAt    time.Time       `json:"occurred_at" gorm:"not null;uniqueIndex:idx_asset_event_log_event"`
+	RecordedAt    time.Time       `json:"recorded_at" gorm:"autoCreateTime"`
+}
+
+func (AssetEventLog) TableName() string {
+	return "asset_event_log"
+}
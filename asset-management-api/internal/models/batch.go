@@ -0,0 +1,25 @@
+package models
+
+import "encoding/json"
+
+// BatchRequest runs API requests one after the other, as the caller
+type BatchRequest struct {
+	Requests []BatchItem `json:"requests" validate:"required,min=1,dive"`
+}
+
+// BatchItem is one request of a batch. Path is an /api/v1 path with an
+// optional query string; Headers add to the headers of the batch request,
+// whose authorization every item uses.
+type BatchItem struct {
+	Method  string            `json:"method" validate:"required,oneof=GET POST PUT PATCH DELETE"`
+	Path    string            `json:"path" validate:"required"`
+	Headers map[string]string `json:"headers,omitempty"`
+	Body    json.RawMessage   `json:"body,omitempty"`
+}
+
+// BatchItemResponse is the response to one request of a batch
+type BatchItemResponse struct {
+	Status  int               `json:"status"`
+	Headers map[string]string `json:"headers,omitempty"`
+	Body    json.RawMessage   `json:"body,omitempty"`
+}
@@ -0,0 +1,46 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Export job statuses
+const (
+	ExportQueued    = "queued"
+	ExportRunning   = "running"
+	ExportSucceeded = "succeeded"
+	ExportFailed    = "failed"
+)
+
+// ExportJob is a team export requested through the API and built in the
+// background; the export is stored under ObjectKey once it succeeded
+type ExportJob struct {
+	ExportID    uuid.UUID  `json:"export_id" gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	TeamID      uuid.UUID  `json:"team_id" gorm:"type:uuid;not null"`
+	RequestedBy uuid.UUID  `json:"requested_by" gorm:"type:uuid;not null"`
+	Status      string     `json:"status" gorm:"not null"`
+	Progress    int        `json:"progress" gorm:"not null;default:0"` // Percent done
+	Attempts    int        `json:"attempts" gorm:"not null;default:0"`
+	ObjectKey   string     `json:"-"`
+	SizeBytes   int        `json:"size_bytes,omitempty"`
+	LastError   string     `json:"last_error,omitempty"`
+	StartedAt   *time.Time `json:"started_at,omitempty"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+func (ExportJob) TableName() string {
+	return "export_jobs"
+}
+
+// Done reports whether the job has settled
+func (j *ExportJob) Done() bool {
+	return j.Status == ExportSucceeded || j.Status == ExportFailed
+}
+
+type CreateExportRequest struct {
+	TeamID string `json:"team_id" validate:"required,uuid"`
+}
@@ -0,0 +1,76 @@
+package models
+
+import (
+	"context"
+	"errors"
+	"strings"
+)
+
+// ErrInvalidFields is returned for a fields parameter that is not a comma
+// separated list of field names
+var ErrInvalidFields = errors.New("invalid field list")
+
+// maxFields bounds the fields a request can select
+const maxFields = 50
+
+// Fields are the top-level JSON fields of the resources a client asked for
+// with ?fields=, in the order given. A nil Fields selects every field.
+type Fields []string
+
+// ParseFields parses a fields parameter such as "note_id,title,updated_at"
+func ParseFields(param string) (Fields, error) {
+	fields := Fields{}
+	for _, name := range strings.Split(param, ",") {
+		name = strings.TrimSpace(name)
+		if !validFieldName(name) {
+			return nil, ErrInvalidFields
+		}
+		if !fields.Has(name) {
+			fields = append(fields, name)
+		}
+	}
+	if len(fields) > maxFields {
+		return nil, ErrInvalidFields
+	}
+	return fields, nil
+}
+
+func validFieldName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for i := 0; i < len(name); i++ {
+		c := name[i]
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '_') {
+			return false
+		}
+	}
+	return true
+}
+
+// Has reports whether the field is selected
+func (f Fields) Has(name string) bool {
+	if f == nil {
+		return true
+	}
+	for _, field := range f {
+		if field == name {
+			return true
+		}
+	}
+	return false
+}
+
+type fieldsKey struct{}
+
+// ContextWithFields returns a context carrying the fields selected by the
+// request, so repositories can leave out the columns nobody asked for
+func ContextWithFields(ctx context.Context, fields Fields) context.Context {
+	return context.WithValue(ctx, fieldsKey{}, fields)
+}
+
+// FieldsFromContext returns the fields selected by the request, or nil
+func FieldsFromContext(ctx context.Context) Fields {
+	fields, _ := ctx.Value(fieldsKey{}).(Fields)
+	return fields
+}
@@ -4,15 +4,18 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
 type Folder struct {
-	FolderID    uuid.UUID `json:"folder_id" gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
-	Name        string    `json:"name" gorm:"not null"`
-	Description string    `json:"description"`
-	OwnerID     uuid.UUID `json:"owner_id" gorm:"not null"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
+	FolderID    uuid.UUID      `json:"folder_id" gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	Name        string         `json:"name" gorm:"not null"`
+	Description string         `json:"description"`
+	OwnerID     uuid.UUID      `json:"owner_id" gorm:"not null"`
+	Version     int64          `json:"version" gorm:"not null;default:1"`
+	CreatedAt   time.Time      `json:"created_at"`
+	UpdatedAt   time.Time      `json:"updated_at"`
+	DeletedAt   gorm.DeletedAt `json:"-" gorm:"index"`
 
 	// Relationships
 	Owner User   `json:"owner" gorm:"foreignKey:OwnerID"`
@@ -24,11 +27,12 @@ func (Folder) TableName() string {
 }
 
 type FolderShare struct {
-	FolderID         uuid.UUID `json:"folder_id" gorm:"primaryKey"`
-	SharedWithUserID uuid.UUID `json:"shared_with_user_id" gorm:"primaryKey"`
-	AccessLevel      string    `json:"access_level" gorm:"not null;check:access_level IN ('read','write')"`
-	SharedBy         uuid.UUID `json:"shared_by" gorm:"not null"`
-	CreatedAt        time.Time `json:"created_at"`
+	FolderID         uuid.UUID      `json:"folder_id" gorm:"primaryKey"`
+	SharedWithUserID uuid.UUID      `json:"shared_with_user_id" gorm:"primaryKey"`
+	AccessLevel      string         `json:"access_level" gorm:"not null;check:access_level IN ('read','write')"`
+	SharedBy         uuid.UUID      `json:"shared_by" gorm:"not null"`
+	CreatedAt        time.Time      `json:"created_at"`
+	DeletedAt        gorm.DeletedAt `json:"-" gorm:"index"`
 
 	// Relationships
 	Folder         Folder `json:"folder" gorm:"foreignKey:FolderID"`
@@ -4,16 +4,19 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
 type Note struct {
-	NoteID    uuid.UUID `json:"note_id" gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
-	Title     string    `json:"title" gorm:"not null"`
-	Body      string    `json:"body"`
-	FolderID  uuid.UUID `json:"folder_id" gorm:"not null"`
-	OwnerID   uuid.UUID `json:"owner_id" gorm:"not null"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	NoteID    uuid.UUID      `json:"note_id" gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	Title     string         `json:"title" gorm:"not null"`
+	Body      string         `json:"body"`
+	FolderID  uuid.UUID      `json:"folder_id" gorm:"not null"`
+	OwnerID   uuid.UUID      `json:"owner_id" gorm:"not null"`
+	Version   int64          `json:"version" gorm:"not null;default:1"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
 
 	// Relationships
 	Folder Folder `json:"folder" gorm:"foreignKey:FolderID"`
@@ -25,11 +28,12 @@ func (Note) TableName() string {
 }
 
 type NoteShare struct {
-	NoteID           uuid.UUID `json:"note_id" gorm:"primaryKey"`
-	SharedWithUserID uuid.UUID `json:"shared_with_user_id" gorm:"primaryKey"`
-	AccessLevel      string    `json:"access_level" gorm:"not null;check:access_level IN ('read','write')"`
-	SharedBy         uuid.UUID `json:"shared_by" gorm:"not null"`
-	CreatedAt        time.Time `json:"created_at"`
+	NoteID           uuid.UUID      `json:"note_id" gorm:"primaryKey"`
+	SharedWithUserID uuid.UUID      `json:"shared_with_user_id" gorm:"primaryKey"`
+	AccessLevel      string         `json:"access_level" gorm:"not null;check:access_level IN ('read','write')"`
+	SharedBy         uuid.UUID      `json:"shared_by" gorm:"not null"`
+	CreatedAt        time.Time      `json:"created_at"`
+	DeletedAt        gorm.DeletedAt `json:"-" gorm:"index"`
 
 	// Relationships
 	Note           Note `json:"note" gorm:"foreignKey:NoteID"`
@@ -0,0 +1,71 @@
+package models
+
+import (
+	"encoding/base64"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ErrInvalidCursor is returned for cursors that were not issued by NewPage
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// Cursor identifies an item of a list by the keys the list is ordered by,
+// newest first: its creation time and its ID
+type Cursor struct {
+	CreatedAt time.Time
+	ID        uuid.UUID
+}
+
+// String encodes the cursor as an opaque token for API clients
+func (c Cursor) String() string {
+	raw := c.CreatedAt.UTC().Format(time.RFC3339Nano) + "," + c.ID.String()
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// ParseCursor decodes a token made by Cursor.String
+func ParseCursor(token string) (*Cursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	createdAt, id, ok := strings.Cut(string(raw), ",")
+	if !ok {
+		return nil, ErrInvalidCursor
+	}
+	cursor := &Cursor{}
+	if cursor.CreatedAt, err = time.Parse(time.RFC3339Nano, createdAt); err != nil {
+		return nil, ErrInvalidCursor
+	}
+	if cursor.ID, err = uuid.Parse(id); err != nil {
+		return nil, ErrInvalidCursor
+	}
+	return cursor, nil
+}
+
+// PageRequest asks for up to Limit items following the one After points to,
+// or for the first items without After
+type PageRequest struct {
+	Limit int
+	After *Cursor
+}
+
+// Page is one page of a list; Next points to its last item when more follow
+type Page[T any] struct {
+	Items []T
+	Next  *Cursor
+}
+
+// NewPage makes a page of the items read for req, which include one item
+// more than req.Limit when another page follows
+func NewPage[T any](items []T, req PageRequest, cursorOf func(T) Cursor) *Page[T] {
+	page := &Page[T]{Items: items}
+	if len(items) > req.Limit {
+		page.Items = items[:req.Limit]
+		next := cursorOf(page.Items[req.Limit-1])
+		page.Next = &next
+	}
+	return page
+}
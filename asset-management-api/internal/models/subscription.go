@@ -0,0 +1,61 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Subscription is a user's interest in the changes to a folder or note. A
+// folder subscription also matches changes to the notes inside the folder.
+type Subscription struct {
+	SubscriptionID uuid.UUID  `json:"subscription_id" gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	UserID         uuid.UUID  `json:"user_id" gorm:"type:uuid;not null"`
+	AssetType      string     `json:"asset_type" gorm:"not null"`
+	AssetID        uuid.UUID  `json:"asset_id" gorm:"type:uuid;not null"`
+	EventTypes     StringList `json:"event_types" gorm:"type:jsonb;not null"` // Empty matches every event
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+func (Subscription) TableName() string {
+	return "subscriptions"
+}
+
+// Accepts reports whether the subscription's event filter matches the event type
+func (s *Subscription) Accepts(eventType string) bool {
+	if len(s.EventTypes) == 0 {
+		return true
+	}
+	for _, t := range s.EventTypes {
+		if t == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// Notification is an event a user was notified about through a subscription
+type Notification struct {
+	NotificationID uuid.UUID       `json:"notification_id" gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	UserID         uuid.UUID       `json:"user_id" gorm:"type:uuid;not null;uniqueIndex:idx_notifications_user_event"`
+	SubscriptionID *uuid.UUID      `json:"subscription_id,omitempty" gorm:"type:uuid"` // Nil once the subscription is removed
+	EventID        uuid.UUID       `json:"event_id" gorm:"type:uuid;not null;uniqueIndex:idx_notifications_user_event"`
+	EventType      string          `json:"event_type" gorm:"not null"`
+	AssetType      string          `json:"asset_type" gorm:"not null"`
+	AssetID        uuid.UUID       `json:"asset_id" gorm:"type:uuid;not null"`
+	ActionBy       uuid.UUID       `json:"action_by" gorm:"type:uuid"`
+	Payload        json.RawMessage `json:"payload" gorm:"type:jsonb;not null"`
+	ReadAt         *time.Time      `json:"read_at,omitempty"`
+	CreatedAt      time.Time       `json:"created_at" gorm:"uniqueIndex:idx_notifications_user_event"` // The time of the event
+}
+
+func (Notification) TableName() string {
+	return "notifications"
+}
+
+type CreateSubscriptionRequest struct {
+	AssetType  string   `json:"asset_type" validate:"required,oneof=folder note"`
+	AssetID    string   `json:"asset_id" validate:"required,uuid"`
+	EventTypes []string `json:"event_types,omitempty"`
+}
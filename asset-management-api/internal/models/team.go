@@ -4,14 +4,17 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
 type Team struct {
-	TeamID    uuid.UUID `json:"team_id" gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
-	TeamName  string    `json:"team_name" gorm:"not null"`
-	CreatedBy uuid.UUID `json:"created_by" gorm:"not null"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	TeamID    uuid.UUID      `json:"team_id" gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	TeamName  string         `json:"team_name" gorm:"not null"`
+	CreatedBy uuid.UUID      `json:"created_by" gorm:"not null"`
+	Version   int64          `json:"version" gorm:"not null;default:1"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
 
 	// Relationships
 	Managers []User `json:"managers" gorm:"many2many:team_managers;joinForeignKey:team_id;joinReferences:manager_id"`
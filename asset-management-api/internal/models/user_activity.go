@@ -0,0 +1,32 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// UserActivityLog is one user.activity event as recorded in the audit store
+type UserActivityLog struct {
+	ID         int64           `json:"id" gorm:"primaryKey;autoIncrement"`
+	EventID    uuid.UUID       `json:"event_id" gorm:"type:uuid;uniqueIndex:idx_user_activity_log_event;not null"`
+	ActorID    uuid.UUID       `json:"actor_id" gorm:"type:uuid;not null;index"`
+	ActorRole  string          `json:"actor_role"`
+	Operation  string          `json:"operation" gorm:"not null"`
+	Method     string          `json:"method" gorm:"not null"`
+	Endpoint   string          `json:"endpoint" gorm:"not null"`
+	EntityType string          `json:"entity_type,omitempty"`
+	EntityID   string          `json:"entity_id,omitempty"`
+	Result     string          `json:"result" gorm:"not null"`
+	HTTPStatus int             `json:"http_status" gorm:"column:http_status;not null"`
+	ClientIP   string          `json:"client_ip,omitempty"`
+	Payload    json.RawMessage `json:"payload" gorm:"type:jsonb;not null"`
+	TraceID    string          `json:"trace_id,omitempty"`
+	OccurredAt time.Time       `json:"occurred_at" gorm:"not null;uniqueIndex:idx_user_activity_log_event"`
+	RecordedAt time.Time       `json:"recorded_at" gorm:"autoCreateTime"`
+}
+
+func (UserActivityLog) TableName() string {
+	return "user_activity_log"
+}
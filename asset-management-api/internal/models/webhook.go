@@ -0,0 +1,126 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Webhook delivery statuses
+const (
+	WebhookDeliveryPending   = "pending"
+	WebhookDeliverySucceeded = "succeeded"
+	WebhookDeliveryFailed    = "failed"
+)
+
+// StringList is a list of strings stored as a JSONB array
+type StringList []string
+
+func (l StringList) Value() (driver.Value, error) {
+	if l == nil {
+		return "[]", nil
+	}
+	data, err := json.Marshal([]string(l))
+	return string(data), err
+}
+
+func (l *StringList) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		*l = nil
+		return nil
+	case []byte:
+		return json.Unmarshal(v, l)
+	case string:
+		return json.Unmarshal([]byte(v), l)
+	default:
+		return fmt.Errorf("cannot scan %T into StringList", value)
+	}
+}
+
+// Webhook is an endpoint a team registered to receive events
+type Webhook struct {
+	WebhookID  uuid.UUID  `json:"webhook_id" gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	TeamID     uuid.UUID  `json:"team_id" gorm:"type:uuid;not null"`
+	URL        string     `json:"url" gorm:"not null"`
+	Secret     string     `json:"-" gorm:"not null"`
+	EventTypes StringList `json:"event_types" gorm:"type:jsonb;not null"` // Empty receives every event
+	Active     bool       `json:"active" gorm:"not null;default:true"`
+	CreatedBy  uuid.UUID  `json:"created_by" gorm:"type:uuid;not null"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+}
+
+func (Webhook) TableName() string {
+	return "webhooks"
+}
+
+// Accepts reports whether the webhook's event filter matches the event type
+func (w *Webhook) Accepts(eventType string) bool {
+	if len(w.EventTypes) == 0 {
+		return true
+	}
+	for _, t := range w.EventTypes {
+		if t == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// WebhookDelivery records one event delivered (or being delivered) to a webhook
+type WebhookDelivery struct {
+	DeliveryID     uuid.UUID       `json:"delivery_id" gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	WebhookID      uuid.UUID       `json:"webhook_id" gorm:"type:uuid;not null"`
+	EventID        uuid.UUID       `json:"event_id" gorm:"type:uuid"`
+	EventType      string          `json:"event_type" gorm:"not null"`
+	Payload        json.RawMessage `json:"payload" gorm:"type:jsonb;not null"`
+	Status         string          `json:"status" gorm:"not null"`
+	Attempts       int             `json:"attempts" gorm:"not null;default:0"`
+	ResponseStatus int             `json:"response_status,omitempty"`
+	LastError      string          `json:"last_error,omitempty"`
+	NextAttemptAt  *time.Time      `json:"next_attempt_at,omitempty"`
+	CompletedAt    *time.Time      `json:"completed_at,omitempty"`
+	CreatedAt      time.Time       `json:"created_at"`
+	UpdatedAt      time.Time       `json:"updated_at"`
+}
+
+func (WebhookDelivery) TableName() string {
+	return "webhook_deliveries"
+}
+
+type CreateWebhookRequest struct {
+	URL        string   `json:"url" validate:"required,url,max=2048"`
+	Secret     string   `json:"secret,omitempty" validate:"omitempty,min=16,max=255"`
+	EventTypes []string `json:"event_types,omitempty"`
+}
+
+// RegisterWebhookRequest registers a webhook through /webhooks, which names
+// the team in the body
+type RegisterWebhookRequest struct {
+	TeamID string `json:"team_id" validate:"required,uuid"`
+	CreateWebhookRequest
+}
+
+// UpdateWebhookRequest changes the fields it sets. An empty event type list
+// makes the webhook receive every event.
+type UpdateWebhookRequest struct {
+	URL        *string   `json:"url,omitempty" validate:"omitempty,url,max=2048"`
+	EventTypes *[]string `json:"event_types,omitempty"`
+	Active     *bool     `json:"active,omitempty"`
+}
+
+// CreateWebhookResponse and WebhookSecretResponse are the only responses that
+// include the signing secret
+type CreateWebhookResponse struct {
+	*Webhook
+	Secret string `json:"secret"`
+}
+
+type WebhookSecretResponse struct {
+	WebhookID uuid.UUID `json:"webhook_id"`
+	Secret    string    `json:"secret"`
+}
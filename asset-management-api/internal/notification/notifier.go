@@ -0,0 +1,269 @@
+package notification
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+
+	"asset-management-api/internal/events/types"
+	"asset-management-api/internal/models"
+	"asset-management-api/internal/repository/interfaces"
+	"asset-management-api/pkg/eventbus"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ACLSource returns the user ID -> access level map of an asset, as the
+// service ACLLoader does
+type ACLSource interface {
+	FolderACL(ctx context.Context, folderID uuid.UUID) (map[string]string, error)
+	NoteACL(ctx context.Context, noteID uuid.UUID) (map[string]string, error)
+}
+
+// Notifier matches asset.changes events against user subscriptions and
+// records a notification for every subscriber who can still see the asset.
+// A change to a note matches the subscriptions to the note and to its folder;
+// a user is notified at most once per event, and never about their own
+// changes. Recorded notifications are announced on the user.notifications
+// topic for the realtime clients of their users.
+type Notifier struct {
+	subscriptionRepo interfaces.SubscriptionRepository
+	noteRepo         interfaces.NoteRepository
+	acl              ACLSource
+	publisher        eventbus.EventBus
+}
+
+// NewNotifier creates a new subscription notifier; publisher is nil when
+// notifications are not announced
+func NewNotifier(subscriptionRepo interfaces.SubscriptionRepository, noteRepo interfaces.NoteRepository, acl ACLSource, publisher eventbus.EventBus) *Notifier {
+	return &Notifier{
+		subscriptionRepo: subscriptionRepo,
+		noteRepo:         noteRepo,
+		acl:              acl,
+		publisher:        publisher,
+	}
+}
+
+// HandleAssetEvent records notifications for the subscriptions an asset event
+// matches. Subscriptions to a deleted asset are removed afterwards.
+func (n *Notifier) HandleAssetEvent(ctx context.Context, eventData []byte) error {
+	envelope, err := types.OpenEnvelope(eventData)
+	if err != nil {
+		return err
+	}
+
+	var event struct {
+		types.BaseAssetEvent
+		FolderID uuid.UUID `json:"folderId"`
+	}
+	if err := json.Unmarshal(envelope.Payload, &event); err != nil {
+		return eventbus.Permanent(fmt.Errorf("failed to unmarshal asset event: %w", err))
+	}
+
+	folderID, err := n.noteFolderID(ctx, event.AssetType, event.AssetID, event.FolderID)
+	if err != nil {
+		return err
+	}
+
+	assetIDs := []uuid.UUID{event.AssetID}
+	if folderID != uuid.Nil {
+		assetIDs = append(assetIDs, folderID)
+	}
+	subscriptions, err := n.subscriptionRepo.GetByAssetIDs(ctx, assetIDs)
+	if err != nil {
+		return fmt.Errorf("failed to get subscriptions: %w", err)
+	}
+
+	matched := n.match(subscriptions, &event.BaseAssetEvent, envelope.EventType)
+	if len(matched) > 0 {
+		access := &accessChecker{acl: n.acl, event: &event.BaseAssetEvent, folderID: folderID}
+		// Subscribers other than the owner must not see a share event's full ACL
+		payload := types.WithoutACLSnapshot(envelope.Payload)
+
+		var notifications []*models.Notification
+		for _, subscription := range matched {
+			allowed, err := access.canView(ctx, subscription.UserID)
+			if err != nil {
+				return err
+			}
+			if !allowed {
+				continue
+			}
+
+			subscriptionID := subscription.SubscriptionID
+			notifications = append(notifications, &models.Notification{
+				NotificationID: notificationID(subscription.UserID, envelope.EventID),
+				UserID:         subscription.UserID,
+				SubscriptionID: &subscriptionID,
+				EventID:        envelope.EventID,
+				EventType:      envelope.EventType,
+				AssetType:      event.AssetType,
+				AssetID:        event.AssetID,
+				ActionBy:       event.ActionBy,
+				Payload:        payload,
+				// Dated by the event, so a redelivery hits the same key
+				CreatedAt: envelope.Timestamp,
+			})
+		}
+
+		if err := n.subscriptionRepo.CreateNotifications(ctx, notifications); err != nil {
+			return fmt.Errorf("failed to record notifications: %w", err)
+		}
+		if len(notifications) > 0 {
+			log.Printf("Recorded %d notifications for %s event %s", len(notifications), envelope.EventType, envelope.EventID)
+			n.announce(ctx, notifications)
+		}
+	}
+
+	if envelope.EventType == types.FolderDeleted || envelope.EventType == types.NoteDeleted {
+		if err := n.subscriptionRepo.DeleteByAssetID(ctx, event.AssetID); err != nil {
+			return fmt.Errorf("failed to remove subscriptions of deleted asset: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// notificationNamespace names the IDs of notifications, see notificationID
+var notificationNamespace = uuid.MustParse("6f1c2b9e-4d3a-5e8f-9a7b-0c1d2e3f4a5b")
+
+// notificationID is the ID of the user's notification of an event. It is
+// derived from both, so a redelivered event announces the notification
+// recorded the first time rather than one that was never stored.
+func notificationID(userID, eventID uuid.UUID) uuid.UUID {
+	return uuid.NewSHA1(notificationNamespace, append(userID[:], eventID[:]...))
+}
+
+// announce publishes the notifications on the user.notifications topic.
+// Realtime delivery is best effort, so a failure is logged rather than
+// making the consumer retry an event whose notifications are recorded.
+func (n *Notifier) announce(ctx context.Context, notifications []*models.Notification) {
+	if n.publisher == nil {
+		return
+	}
+
+	events := make([]interface{}, 0, len(notifications))
+	for _, notification := range notifications {
+		events = append(events, &types.NotificationCreatedEvent{
+			EventType:       types.NotificationCreated,
+			NotificationID:  notification.NotificationID,
+			UserID:          notification.UserID,
+			SourceEventID:   notification.EventID,
+			SourceEventType: notification.EventType,
+			AssetType:       notification.AssetType,
+			AssetID:         notification.AssetID,
+			ActionBy:        notification.ActionBy,
+			Timestamp:       notification.CreatedAt,
+		})
+	}
+	if err := n.publisher.PublishBatch(ctx, types.UserNotificationsTopic, events); err != nil {
+		log.Printf("Failed to announce %d notifications: %v", len(notifications), err)
+	}
+}
+
+// noteFolderID returns the folder of a note event. Events that do not carry
+// it are resolved from the note, unless it was already deleted.
+func (n *Notifier) noteFolderID(ctx context.Context, assetType string, noteID, folderID uuid.UUID) (uuid.UUID, error) {
+	if assetType != types.AssetTypeNote || folderID != uuid.Nil {
+		return folderID, nil
+	}
+
+	note, err := n.noteRepo.GetByID(ctx, noteID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return uuid.Nil, nil
+		}
+		return uuid.Nil, fmt.Errorf("failed to get note: %w", err)
+	}
+	return note.FolderID, nil
+}
+
+// match returns one matching subscription per user, preferring a subscription
+// to the asset itself over one to its folder
+func (n *Notifier) match(subscriptions []*models.Subscription, event *types.BaseAssetEvent, eventType string) []*models.Subscription {
+	byUser := make(map[uuid.UUID]*models.Subscription)
+	var users []uuid.UUID
+	for _, subscription := range subscriptions {
+		if subscription.UserID == event.ActionBy || !subscription.Accepts(eventType) {
+			continue
+		}
+
+		current, ok := byUser[subscription.UserID]
+		if !ok {
+			users = append(users, subscription.UserID)
+		}
+		if !ok || (current.AssetID != event.AssetID && subscription.AssetID == event.AssetID) {
+			byUser[subscription.UserID] = subscription
+		}
+	}
+
+	matched := make([]*models.Subscription, 0, len(users))
+	for _, userID := range users {
+		matched = append(matched, byUser[userID])
+	}
+	return matched
+}
+
+// accessChecker decides whether a subscriber can still see the asset of an
+// event, loading each ACL at most once
+type accessChecker struct {
+	acl      ACLSource
+	event    *types.BaseAssetEvent
+	folderID uuid.UUID
+
+	loaded    bool
+	gone      bool
+	assetACL  map[string]string
+	folderACL map[string]string
+}
+
+func (c *accessChecker) canView(ctx context.Context, userID uuid.UUID) (bool, error) {
+	if err := c.load(ctx); err != nil {
+		return false, err
+	}
+	if c.gone {
+		// A deleted asset has no ACL left; its subscribers were allowed to
+		// see it when they subscribed
+		return true, nil
+	}
+
+	key := userID.String()
+	if _, ok := c.assetACL[key]; ok {
+		return true, nil
+	}
+	_, ok := c.folderACL[key]
+	return ok, nil
+}
+
+func (c *accessChecker) load(ctx context.Context) error {
+	if c.loaded {
+		return nil
+	}
+
+	var err error
+	if c.event.AssetType == types.AssetTypeNote {
+		c.assetACL, err = c.acl.NoteACL(ctx, c.event.AssetID)
+	} else {
+		c.assetACL, err = c.acl.FolderACL(ctx, c.event.AssetID)
+	}
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		c.gone, c.loaded = true, true
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to load asset ACL: %w", err)
+	}
+
+	if c.folderID != uuid.Nil {
+		c.folderACL, err = c.acl.FolderACL(ctx, c.folderID)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return fmt.Errorf("failed to load folder ACL: %w", err)
+		}
+	}
+
+	c.loaded = true
+	return nil
+}
@@ -0,0 +1,298 @@
+package realtime
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+
+	"asset-management-api/internal/events/types"
+
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	// ErrTooManyConnections is returned when a user already has the maximum number of streams open
+	ErrTooManyConnections = errors.New("too many realtime connections for this user")
+
+	// ErrHubClosed is returned when connecting after the hub was closed
+	ErrHubClosed = errors.New("realtime hub is closed")
+
+	// ErrCannotResume is returned when the events missed since an event can
+	// not be replayed: the event is not stored, or too many were missed
+	ErrCannotResume = errors.New("cannot resume the event stream")
+)
+
+var (
+	connectedClients = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "realtime_connected_clients",
+			Help: "Number of clients connected to the realtime event stream",
+		},
+	)
+
+	pushedEventsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "realtime_events_pushed_total",
+			Help: "Total number of events pushed to realtime clients",
+		},
+		[]string{"topic"},
+	)
+
+	slowClientsTotal = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "realtime_slow_clients_disconnected_total",
+			Help: "Total number of realtime clients disconnected for falling behind",
+		},
+	)
+)
+
+// Config controls the realtime hub
+type Config struct {
+	ClientBuffer          int // Events buffered per client before it is disconnected
+	MaxConnectionsPerUser int // Zero means unlimited
+	ReplayLimit           int // Events replayed to a resuming client at most
+}
+
+// Message is an event pushed to a client
+type Message struct {
+	ID    string          // The event ID
+	Event string          // The event type
+	Topic string          // The topic the event was published to
+	Data  json.RawMessage // The event envelope
+}
+
+// Resumable reports whether a stream can later resume after the message,
+// which holds for the asset events kept in the event store
+func (m Message) Resumable() bool {
+	return m.Topic == types.AssetChangesTopic && m.ID != uuid.Nil.String()
+}
+
+// Client is one open event stream of a user
+type Client struct {
+	UserID uuid.UUID
+	events chan Message
+}
+
+// Events returns the client's messages. The channel is closed when the client
+// falls behind or the hub closes; the stream should then end so the client
+// reconnects and refetches what it missed.
+func (c *Client) Events() <-chan Message {
+	return c.events
+}
+
+// Hub pushes asset, team and notification events to the connected users allowed to see
+// them. Delivery is best effort: events are never retried, and a client whose
+// buffer is full is disconnected rather than slowing down the others.
+type Hub struct {
+	config   Config
+	resolver *recipientResolver
+	eventLog EventLogSource
+
+	mu      sync.RWMutex
+	clients map[uuid.UUID]map[*Client]struct{}
+	closed  bool
+}
+
+// NewHub creates a realtime hub; eventLog is nil when streams cannot resume
+func NewHub(config Config, acl ACLSource, noteRepo NoteSource, teamRepo TeamSource, eventLog EventLogSource) *Hub {
+	if config.ClientBuffer <= 0 {
+		config.ClientBuffer = 64
+	}
+	if config.ReplayLimit <= 0 {
+		config.ReplayLimit = 100
+	}
+	return &Hub{
+		config:   config,
+		resolver: &recipientResolver{acl: acl, notes: noteRepo, teams: teamRepo},
+		eventLog: eventLog,
+		clients:  make(map[uuid.UUID]map[*Client]struct{}),
+	}
+}
+
+// Register connects a new client for the user
+func (h *Hub) Register(userID uuid.UUID) (*Client, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.closed {
+		return nil, ErrHubClosed
+	}
+	userClients := h.clients[userID]
+	if h.config.MaxConnectionsPerUser > 0 && len(userClients) >= h.config.MaxConnectionsPerUser {
+		return nil, ErrTooManyConnections
+	}
+	if userClients == nil {
+		userClients = make(map[*Client]struct{})
+		h.clients[userID] = userClients
+	}
+
+	client := &Client{UserID: userID, events: make(chan Message, h.config.ClientBuffer)}
+	userClients[client] = struct{}{}
+	connectedClients.Inc()
+	return client, nil
+}
+
+// Unregister disconnects a client; it is safe to call more than once
+func (h *Hub) Unregister(client *Client) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.remove(client)
+}
+
+// remove drops a client and closes its channel; the caller must hold h.mu
+func (h *Hub) remove(client *Client) {
+	userClients, ok := h.clients[client.UserID]
+	if !ok {
+		return
+	}
+	if _, ok := userClients[client]; !ok {
+		return
+	}
+	delete(userClients, client)
+	if len(userClients) == 0 {
+		delete(h.clients, client.UserID)
+	}
+	close(client.events)
+	connectedClients.Dec()
+}
+
+// HandleAssetEvent pushes an asset.changes event to the connected users who
+// can see the asset
+func (h *Hub) HandleAssetEvent(ctx context.Context, eventData []byte) error {
+	return h.handle(ctx, types.AssetChangesTopic, eventData, h.resolver.assetRecipients)
+}
+
+// HandleTeamEvent pushes a team.activity event to the connected managers and
+// members of the team
+func (h *Hub) HandleTeamEvent(ctx context.Context, eventData []byte) error {
+	return h.handle(ctx, types.TeamActivityTopic, eventData, h.resolver.teamRecipients)
+}
+
+// HandleNotificationEvent pushes a user.notifications event to the connected
+// clients of the notified user
+func (h *Hub) HandleNotificationEvent(ctx context.Context, eventData []byte) error {
+	return h.handle(ctx, types.UserNotificationsTopic, eventData, notificationRecipients)
+}
+
+type resolveFunc func(ctx context.Context, payload json.RawMessage) ([]uuid.UUID, error)
+
+// handle never fails: realtime delivery is best effort and must not make the
+// consumer retry an event the other handlers already applied
+func (h *Hub) handle(ctx context.Context, topic string, eventData []byte, resolve resolveFunc) error {
+	if !h.hasClients() {
+		return nil
+	}
+
+	envelope, err := types.OpenEnvelope(eventData)
+	if err != nil {
+		log.Printf("Realtime hub skipping unreadable %s event: %v", topic, err)
+		return nil
+	}
+	recipients, err := resolve(ctx, envelope.Payload)
+	if err != nil {
+		log.Printf("Realtime hub failed to resolve recipients of %s event %s: %v", topic, envelope.EventID, err)
+		return nil
+	}
+
+	message, err := newMessage(topic, envelope, eventData)
+	if err != nil {
+		log.Printf("Realtime hub skipping %s event %s: %v", topic, envelope.EventID, err)
+		return nil
+	}
+	h.push(recipients, message)
+	return nil
+}
+
+// newMessage builds the message of an event, eventData being its envelope as
+// published
+func newMessage(topic string, envelope *types.Envelope, eventData []byte) (Message, error) {
+	// Share events carry the asset's full ACL, which recipients other than
+	// the owner must not see
+	if envelope.RedactACLSnapshot() {
+		var err error
+		if eventData, err = json.Marshal(envelope); err != nil {
+			return Message{}, fmt.Errorf("failed to redact event: %w", err)
+		}
+	}
+
+	// Server-Sent Events data must fit on one line
+	var data bytes.Buffer
+	if err := json.Compact(&data, eventData); err != nil {
+		return Message{}, fmt.Errorf("malformed event: %w", err)
+	}
+
+	return Message{
+		ID:    envelope.EventID.String(),
+		Event: envelope.EventType,
+		Topic: topic,
+		Data:  data.Bytes(),
+	}, nil
+}
+
+func (h *Hub) hasClients() bool {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return len(h.clients) > 0
+}
+
+// push queues the message for every client of the recipients
+func (h *Hub) push(recipients []uuid.UUID, message Message) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for _, userID := range recipients {
+		for client := range h.clients[userID] {
+			select {
+			case client.events <- message:
+				pushedEventsTotal.WithLabelValues(message.Topic).Inc()
+			default:
+				log.Printf("Realtime client of user %s fell behind, disconnecting", userID)
+				slowClientsTotal.Inc()
+				h.remove(client)
+			}
+		}
+	}
+}
+
+// Close disconnects every client and rejects new ones
+func (h *Hub) Close() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.closed = true
+	for _, userClients := range h.clients {
+		for client := range userClients {
+			h.remove(client)
+		}
+	}
+}
+
+// HealthCheck returns the hub status
+func (h *Hub) HealthCheck() map[string]interface{} {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	clients := 0
+	for _, userClients := range h.clients {
+		clients += len(userClients)
+	}
+	return map[string]interface{}{
+		"status":          "healthy",
+		"connected_users": len(h.clients),
+		"clients":         clients,
+	}
+}
+
+// unmarshalPayload decodes an event payload
+func unmarshalPayload(payload json.RawMessage, event interface{}) error {
+	if err := json.Unmarshal(payload, event); err != nil {
+		return fmt.Errorf("failed to unmarshal event: %w", err)
+	}
+	return nil
+}
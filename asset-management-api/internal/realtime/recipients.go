@@ -0,0 +1,169 @@
+package realtime
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"asset-management-api/internal/events/types"
+	"asset-management-api/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ACLSource returns the user ID -> access level map of an asset, as the
+// service ACLLoader does
+type ACLSource interface {
+	FolderACL(ctx context.Context, folderID uuid.UUID) (map[string]string, error)
+	NoteACL(ctx context.Context, noteID uuid.UUID) (map[string]string, error)
+}
+
+// NoteSource looks up notes, to find the folder a note inherits shares from
+type NoteSource interface {
+	GetByID(ctx context.Context, noteID uuid.UUID) (*models.Note, error)
+}
+
+// TeamSource looks up teams with their managers and members
+type TeamSource interface {
+	GetByID(ctx context.Context, teamID uuid.UUID) (*models.Team, error)
+}
+
+// recipientResolver works out which users may see an event
+type recipientResolver struct {
+	acl   ACLSource
+	notes NoteSource
+	teams TeamSource
+}
+
+// assetRecipients returns the owner and everyone the asset is shared with,
+// including the shares of a note's folder. The user a share was just revoked
+// from is included so their client can drop the asset.
+func (r *recipientResolver) assetRecipients(ctx context.Context, payload json.RawMessage) ([]uuid.UUID, error) {
+	var event struct {
+		types.BaseAssetEvent
+		FolderID           uuid.UUID `json:"folderId"`
+		SharedWithUserID   uuid.UUID `json:"sharedWithUserId"`
+		UnsharedFromUserID uuid.UUID `json:"unsharedFromUserId"`
+	}
+	if err := unmarshalPayload(payload, &event); err != nil {
+		return nil, err
+	}
+
+	recipients := newUserSet(event.OwnerID, event.SharedWithUserID, event.UnsharedFromUserID)
+
+	switch event.AssetType {
+	case types.AssetTypeFolder:
+		acl, err := r.acl.FolderACL(ctx, event.AssetID)
+		if err := ignoreNotFound(err); err != nil {
+			return nil, err
+		}
+		recipients.addACL(acl)
+
+	case types.AssetTypeNote:
+		acl, err := r.acl.NoteACL(ctx, event.AssetID)
+		if err := ignoreNotFound(err); err != nil {
+			return nil, err
+		}
+		recipients.addACL(acl)
+
+		folderID := event.FolderID
+		if folderID == uuid.Nil {
+			note, err := r.notes.GetByID(ctx, event.AssetID)
+			if err := ignoreNotFound(err); err != nil {
+				return nil, err
+			}
+			if note != nil {
+				folderID = note.FolderID
+			}
+		}
+		if folderID != uuid.Nil {
+			acl, err := r.acl.FolderACL(ctx, folderID)
+			if err := ignoreNotFound(err); err != nil {
+				return nil, err
+			}
+			recipients.addACL(acl)
+		}
+	}
+
+	return recipients.list(), nil
+}
+
+// teamRecipients returns the team's managers and members. A user who was
+// just removed is included so their client learns about it.
+func (r *recipientResolver) teamRecipients(ctx context.Context, payload json.RawMessage) ([]uuid.UUID, error) {
+	var event struct {
+		types.BaseTeamEvent
+		TargetUserID uuid.UUID `json:"targetUserId"`
+	}
+	if err := unmarshalPayload(payload, &event); err != nil {
+		return nil, err
+	}
+
+	recipients := newUserSet(event.PerformedBy, event.TargetUserID)
+
+	team, err := r.teams.GetByID(ctx, event.TeamID)
+	if err := ignoreNotFound(err); err != nil {
+		return nil, err
+	}
+	if team != nil {
+		for _, user := range append(team.Managers, team.Members...) {
+			recipients.add(user.UserID)
+		}
+	}
+
+	return recipients.list(), nil
+}
+
+// notificationRecipients returns the user a notification was recorded for
+func notificationRecipients(_ context.Context, payload json.RawMessage) ([]uuid.UUID, error) {
+	var event types.NotificationCreatedEvent
+	if err := unmarshalPayload(payload, &event); err != nil {
+		return nil, err
+	}
+	return newUserSet(event.UserID).list(), nil
+}
+
+func ignoreNotFound(err error) error {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	return err
+}
+
+type userSet map[uuid.UUID]struct{}
+
+func newUserSet(userIDs ...uuid.UUID) userSet {
+	set := make(userSet)
+	for _, userID := range userIDs {
+		set.add(userID)
+	}
+	return set
+}
+
+func (s userSet) add(userID uuid.UUID) {
+	if userID != uuid.Nil {
+		s[userID] = struct{}{}
+	}
+}
+
+func (s userSet) has(userID uuid.UUID) bool {
+	_, ok := s[userID]
+	return ok
+}
+
+func (s userSet) addACL(acl map[string]string) {
+	for userID := range acl {
+		if id, err := uuid.Parse(userID); err == nil {
+			s.add(id)
+		}
+	}
+}
+
+func (s userSet) list() []uuid.UUID {
+	users := make([]uuid.UUID, 0, len(s))
+	for userID := range s {
+		users = append(users, userID)
+	}
+	return users
+}
@@ -0,0 +1,76 @@
+package realtime
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"asset-management-api/internal/events/types"
+	"asset-management-api/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// EventLogSource reads the event store, to replay the asset events a
+// resuming client missed
+type EventLogSource interface {
+	ListAfterEvent(ctx context.Context, eventID uuid.UUID, limit int) ([]*models.AssetEventLog, error)
+}
+
+// Replay returns the asset events recorded after lastEventID that the user
+// can see, oldest first. Visibility is checked against the assets' current
+// shares. It fails with ErrCannotResume when the event is not stored or more
+// than the replay limit were recorded since; the client then has to refetch.
+func (h *Hub) Replay(ctx context.Context, userID, lastEventID uuid.UUID) ([]Message, error) {
+	if h.eventLog == nil {
+		return nil, ErrCannotResume
+	}
+
+	entries, err := h.eventLog.ListAfterEvent(ctx, lastEventID, h.config.ReplayLimit+1)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrCannotResume
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read missed events: %w", err)
+	}
+	if len(entries) > h.config.ReplayLimit {
+		return nil, ErrCannotResume
+	}
+
+	var messages []Message
+	for _, entry := range entries {
+		// Events from before envelopes have no ID to resume after
+		if entry.EventID == nil {
+			continue
+		}
+		recipients, err := h.resolver.assetRecipients(ctx, entry.Payload)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve recipients of event %s: %w", *entry.EventID, err)
+		}
+		if !newUserSet(recipients...).has(userID) {
+			continue
+		}
+
+		envelope := &types.Envelope{
+			SchemaVersion: entry.SchemaVersion,
+			EventID:       *entry.EventID,
+			EventType:     entry.EventType,
+			Producer:      entry.Producer,
+			TraceID:       entry.TraceID,
+			Timestamp:     entry.OccurredAt,
+			Payload:       entry.Payload,
+		}
+		eventData, err := json.Marshal(envelope)
+		if err != nil {
+			return nil, fmt.Errorf("failed to rebuild event %s: %w", *entry.EventID, err)
+		}
+		message, err := newMessage(types.AssetChangesTopic, envelope, eventData)
+		if err != nil {
+			return nil, fmt.Errorf("failed to replay event %s: %w", *entry.EventID, err)
+		}
+		messages = append(messages, message)
+	}
+	return messages, nil
+}
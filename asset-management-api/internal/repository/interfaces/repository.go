@@ -2,63 +2,197 @@ package interfaces
 
 import (
 	"asset-management-api/internal/models"
+	"context"
+	"errors"
 	"github.com/google/uuid"
+	"time"
 )
 
+// ErrVersionConflict is returned by the updates of folders, notes and teams
+// that were changed or deleted since they were read
+var ErrVersionConflict = errors.New("modified concurrently")
+
 type FolderRepository interface {
-	Create(folder *models.Folder) error
-	GetByID(folderID uuid.UUID) (*models.Folder, error)
-	GetByOwnerID(ownerID uuid.UUID) ([]*models.Folder, error)
-	Update(folder *models.Folder) error
-	Delete(folderID uuid.UUID) error
-	CheckOwnership(folderID, userID uuid.UUID) (bool, error)
-	GetSharedFolders(userID uuid.UUID) ([]*models.Folder, error)
+	Create(ctx context.Context, folder *models.Folder) error
+	GetByID(ctx context.Context, folderID uuid.UUID) (*models.Folder, error)
+	GetByIDs(ctx context.Context, folderIDs []uuid.UUID) ([]*models.Folder, error)
+	GetByOwnerID(ctx context.Context, ownerID uuid.UUID) ([]*models.Folder, error)
+	// Update saves a folder read at folder.Version and moves it to the next version
+	Update(ctx context.Context, folder *models.Folder) error
+	Delete(ctx context.Context, folderID uuid.UUID) error
+	CheckOwnership(ctx context.Context, folderID, userID uuid.UUID) (bool, error)
+	GetSharedFolders(ctx context.Context, userID uuid.UUID) ([]*models.Folder, error)
+	GetIDsByOwnerID(ctx context.Context, ownerID uuid.UUID) ([]uuid.UUID, error)
+	GetSharedFolderIDs(ctx context.Context, userID uuid.UUID) ([]uuid.UUID, error)
+	// ListAccessible pages through the folders the user owns or that are shared with them, newest first
+	ListAccessible(ctx context.Context, userID uuid.UUID, req models.PageRequest) (*models.Page[*models.Folder], error)
 }
 
 type NoteRepository interface {
-	Create(note *models.Note) error
-	GetByID(noteID uuid.UUID) (*models.Note, error)
-	GetByFolderID(folderID uuid.UUID) ([]*models.Note, error)
-	GetByOwnerID(ownerID uuid.UUID) ([]*models.Note, error)
-	Update(note *models.Note) error
-	Delete(noteID uuid.UUID) error
-	CheckOwnership(noteID, userID uuid.UUID) (bool, error)
-	GetSharedNotes(userID uuid.UUID) ([]*models.Note, error)
+	Create(ctx context.Context, note *models.Note) error
+	GetByID(ctx context.Context, noteID uuid.UUID) (*models.Note, error)
+	GetByIDs(ctx context.Context, noteIDs []uuid.UUID) ([]*models.Note, error)
+	GetByFolderID(ctx context.Context, folderID uuid.UUID) ([]*models.Note, error)
+	GetByFolderIDs(ctx context.Context, folderIDs []uuid.UUID) ([]*models.Note, error)
+	GetByOwnerID(ctx context.Context, ownerID uuid.UUID) ([]*models.Note, error)
+	// Update saves a note read at note.Version and moves it to the next version
+	Update(ctx context.Context, note *models.Note) error
+	Delete(ctx context.Context, noteID uuid.UUID) error
+	CheckOwnership(ctx context.Context, noteID, userID uuid.UUID) (bool, error)
+	GetSharedNotes(ctx context.Context, userID uuid.UUID) ([]*models.Note, error)
+	GetIDsByOwnerID(ctx context.Context, ownerID uuid.UUID) ([]uuid.UUID, error)
+	GetSharedNoteIDs(ctx context.Context, userID uuid.UUID) ([]uuid.UUID, error)
+	// Pages through the notes of a folder, and those the user owns or that are shared with them, newest first
+	ListByFolderID(ctx context.Context, folderID uuid.UUID, req models.PageRequest) (*models.Page[*models.Note], error)
+	ListAccessible(ctx context.Context, userID uuid.UUID, req models.PageRequest) (*models.Page[*models.Note], error)
 }
 
 type ShareRepository interface {
 	// Folder sharing
-	ShareFolder(folderShare *models.FolderShare) error
-	UnshareFolder(folderID, userID uuid.UUID) error
-	GetFolderShares(folderID uuid.UUID) ([]*models.FolderShare, error)
-	CheckFolderAccess(folderID, userID uuid.UUID) (string, error) // returns access level or empty
+	ShareFolder(ctx context.Context, folderShare *models.FolderShare) error
+	UnshareFolder(ctx context.Context, folderID, userID uuid.UUID) error
+	GetFolderShares(ctx context.Context, folderID uuid.UUID) ([]*models.FolderShare, error)
+	GetSharesByFolderIDs(ctx context.Context, folderIDs []uuid.UUID) ([]*models.FolderShare, error)
+	CheckFolderAccess(ctx context.Context, folderID, userID uuid.UUID) (string, error) // returns access level or empty
+	DeleteFolderShares(ctx context.Context, folderID uuid.UUID) error                  // removes the shares of the folder and of its notes
+
+	// ListFolderShares pages through the shares of a folder, newest first
+	ListFolderShares(ctx context.Context, folderID uuid.UUID, req models.PageRequest) (*models.Page[*models.FolderShare], error)
 
 	// Note sharing
-	ShareNote(noteShare *models.NoteShare) error
-	UnshareNote(noteID, userID uuid.UUID) error
-	GetNoteShares(noteID uuid.UUID) ([]*models.NoteShare, error)
-	CheckNoteAccess(noteID, userID uuid.UUID) (string, error) // returns access level or empty
+	ShareNote(ctx context.Context, noteShare *models.NoteShare) error
+	UnshareNote(ctx context.Context, noteID, userID uuid.UUID) error
+	GetNoteShares(ctx context.Context, noteID uuid.UUID) ([]*models.NoteShare, error)
+	GetSharesByNoteIDs(ctx context.Context, noteIDs []uuid.UUID) ([]*models.NoteShare, error)
+	CheckNoteAccess(ctx context.Context, noteID, userID uuid.UUID) (string, error) // returns access level or empty
+
+	// ListNoteShares pages through the shares of a note, newest first
+	ListNoteShares(ctx context.Context, noteID uuid.UUID, req models.PageRequest) (*models.Page[*models.NoteShare], error)
 }
 
 type UserRepository interface {
-	GetByID(userID uuid.UUID) (*models.User, error)
-	GetByEmail(email string) (*models.User, error)
-	GetTeamMembers(teamID uuid.UUID) ([]*models.User, error)
-	CheckIfUserInTeam(userID, teamID uuid.UUID) (bool, error)
-	CheckIfManager(userID uuid.UUID) (bool, error)
+	Create(ctx context.Context, user *models.User) error
+	GetByID(ctx context.Context, userID uuid.UUID) (*models.User, error)
+	GetByEmail(ctx context.Context, email string) (*models.User, error)
+	GetTeamMembers(ctx context.Context, teamID uuid.UUID) ([]*models.User, error)
+	CheckIfUserInTeam(ctx context.Context, userID, teamID uuid.UUID) (bool, error)
+	CheckIfManager(ctx context.Context, userID uuid.UUID) (bool, error)
 }
 
 type TeamRepository interface {
-	Create(team *models.Team) error
-	GetByID(teamID uuid.UUID) (*models.Team, error)
-	GetTeamsByManagerID(managerID uuid.UUID) ([]*models.Team, error)
-	GetTeamsByMemberID(memberID uuid.UUID) ([]*models.Team, error)
-	AddManager(teamID, managerID uuid.UUID) error
-	RemoveManager(teamID, managerID uuid.UUID) error
-	AddMember(teamID, memberID uuid.UUID) error
-	RemoveMember(teamID, memberID uuid.UUID) error
-	IsTeamManager(teamID, userID uuid.UUID) (bool, error)
-	IsTeamMember(teamID, userID uuid.UUID) (bool, error)
-	Update(team *models.Team) error
-	Delete(teamID uuid.UUID) error
+	Create(ctx context.Context, team *models.Team) error
+	GetByID(ctx context.Context, teamID uuid.UUID) (*models.Team, error)
+	GetAllIDs(ctx context.Context) ([]uuid.UUID, error)
+	GetTeamsByManagerID(ctx context.Context, managerID uuid.UUID) ([]*models.Team, error)
+	GetTeamsByMemberID(ctx context.Context, memberID uuid.UUID) ([]*models.Team, error)
+	// ListByUserID reads a page of the teams the user manages or is a member of
+	ListByUserID(ctx context.Context, userID uuid.UUID, req models.PageRequest) (*models.Page[*models.Team], error)
+	AddManager(ctx context.Context, teamID, managerID uuid.UUID) error
+	RemoveManager(ctx context.Context, teamID, managerID uuid.UUID) error
+	AddMember(ctx context.Context, teamID, memberID uuid.UUID) error
+	RemoveMember(ctx context.Context, teamID, memberID uuid.UUID) error
+	IsTeamManager(ctx context.Context, teamID, userID uuid.UUID) (bool, error)
+	IsTeamMember(ctx context.Context, teamID, userID uuid.UUID) (bool, error)
+	// Update saves a team read at team.Version and moves it to the next version
+	Update(ctx context.Context, team *models.Team) error
+	Delete(ctx context.Context, teamID uuid.UUID) error
+}
+type WebhookRepository interface {
+	Create(ctx context.Context, webhook *models.Webhook) error
+	GetByID(ctx context.Context, webhookID uuid.UUID) (*models.Webhook, error)
+	GetByTeamID(ctx context.Context, teamID uuid.UUID) ([]*models.Webhook, error)
+	GetByTeamIDs(ctx context.Context, teamIDs []uuid.UUID) ([]*models.Webhook, error)
+	GetActiveByTeamIDs(ctx context.Context, teamIDs []uuid.UUID) ([]*models.Webhook, error)
+	Update(ctx context.Context, webhook *models.Webhook) error
+	Delete(ctx context.Context, webhookID uuid.UUID) error
+
+	// Delivery history
+	CreateDelivery(ctx context.Context, delivery *models.WebhookDelivery) (bool, error) // False when the webhook already has a delivery of the event
+	GetDelivery(ctx context.Context, deliveryID uuid.UUID) (*models.WebhookDelivery, error)
+	UpdateDelivery(ctx context.Context, delivery *models.WebhookDelivery) error
+	GetDeliveries(ctx context.Context, webhookID uuid.UUID, limit int) ([]*models.WebhookDelivery, error)
+	GetDueDeliveries(ctx context.Context, now time.Time, limit int) ([]*models.WebhookDelivery, error) // Pending deliveries whose next attempt is due
+}
+
+// ExportJobRepository keeps the export jobs. A job is pending while queued,
+// or while running but not updated since staleBefore, which means its worker
+// went away; workers claim pending jobs before running them.
+type ExportJobRepository interface {
+	Create(ctx context.Context, job *models.ExportJob) error
+	GetByID(ctx context.Context, exportID uuid.UUID) (*models.ExportJob, error)
+	// ListByRequester returns up to limit jobs the user requested, newest first
+	ListByRequester(ctx context.Context, userID uuid.UUID, limit int) ([]*models.ExportJob, error)
+	// ListPending returns up to limit pending jobs, oldest first
+	ListPending(ctx context.Context, staleBefore time.Time, limit int) ([]*models.ExportJob, error)
+	// Claim marks a pending job running, reporting false when it is not
+	// pending, e.g. because another worker claimed it first
+	Claim(ctx context.Context, exportID uuid.UUID, staleBefore, now time.Time) (bool, error)
+	// SetProgress records how far a running job got, which also shows its
+	// worker is alive
+	SetProgress(ctx context.Context, exportID uuid.UUID, progress int, now time.Time) error
+	Update(ctx context.Context, job *models.ExportJob) error
+}
+
+type AssetEventRepository interface {
+	// Append stores an event; events already stored (by event ID) are ignored
+	Append(ctx context.Context, event *models.AssetEventLog) error
+	GetByAssetID(ctx context.Context, assetID uuid.UUID, limit int) ([]*models.AssetEventLog, error)
+	// ListAfterEvent returns up to limit events recorded after the event,
+	// oldest first, or gorm.ErrRecordNotFound if the event is not stored
+	ListAfterEvent(ctx context.Context, eventID uuid.UUID, limit int) ([]*models.AssetEventLog, error)
+}
+
+type UserActivityRepository interface {
+	// Append stores an activity record; records already stored (by event ID) are ignored
+	Append(ctx context.Context, activity *models.UserActivityLog) error
+	// ListByActorIDs returns the activity of the users, oldest first
+	ListByActorIDs(ctx context.Context, actorIDs []uuid.UUID) ([]*models.UserActivityLog, error)
+}
+
+type APIAuditRepository interface {
+	AppendBatch(ctx context.Context, records []*models.APIAudit) error
+	// List returns the records matching the filter, most recent first
+	List(ctx context.Context, filter models.APIAuditFilter) ([]*models.APIAudit, error)
+}
+
+// PartitionRepository maintains the monthly partitions of the tables
+// partitioned by time. Only Postgres partitions them; elsewhere both methods
+// do nothing.
+type PartitionRepository interface {
+	// EnsurePartition creates the partition of the month containing t
+	EnsurePartition(ctx context.Context, table string, t time.Time) error
+	// DropPartitionsBefore drops the partitions of the months before the one
+	// containing cutoff and returns their names
+	DropPartitionsBefore(ctx context.Context, table string, cutoff time.Time) ([]string, error)
+}
+
+type SubscriptionRepository interface {
+	Create(ctx context.Context, subscription *models.Subscription) error
+	GetByID(ctx context.Context, subscriptionID uuid.UUID) (*models.Subscription, error)
+	GetByUserID(ctx context.Context, userID uuid.UUID) ([]*models.Subscription, error)
+	GetByUserAndAsset(ctx context.Context, userID, assetID uuid.UUID) (*models.Subscription, error)
+	GetByAssetIDs(ctx context.Context, assetIDs []uuid.UUID) ([]*models.Subscription, error)
+	Delete(ctx context.Context, subscriptionID uuid.UUID) error
+	DeleteByAssetID(ctx context.Context, assetID uuid.UUID) error
+
+	// Notifications
+	CreateNotifications(ctx context.Context, notifications []*models.Notification) error // Notifications of an event the user was already notified about are skipped
+	GetNotifications(ctx context.Context, userID uuid.UUID, unreadOnly bool, limit int) ([]*models.Notification, error)
+	MarkNotificationRead(ctx context.Context, notificationID, userID uuid.UUID, readAt time.Time) (bool, error) // False when the user has no such notification
+}
+
+// Repositories are the repositories a unit of work can use, bound to its transaction
+type Repositories struct {
+	Folders FolderRepository
+	Notes   NoteRepository
+	Shares  ShareRepository
+	Users   UserRepository
+	Teams   TeamRepository
+}
+
+type TxManager interface {
+	// WithinTransaction runs fn in one database transaction, committed when fn
+	// returns nil and rolled back when it returns an error or panics. fn is
+	// run again when the transaction fails on a transient error.
+	WithinTransaction(ctx context.Context, fn func(repos Repositories) error) error
 }
\ No newline at end of file
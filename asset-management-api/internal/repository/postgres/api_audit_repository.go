@@ -0,0 +1,59 @@
+package postgres
+
+import (
+	"context"
+
+	"asset-management-api/internal/models"
+	"asset-management-api/internal/repository/interfaces"
+	"gorm.io/gorm"
+)
+
+type apiAuditRepository struct {
+	db *gorm.DB
+}
+
+func NewAPIAuditRepository(db *gorm.DB) interfaces.APIAuditRepository {
+	return &apiAuditRepository{db: db}
+}
+
+func (r *apiAuditRepository) AppendBatch(ctx context.Context, records []*models.APIAudit) error {
+	if len(records) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).CreateInBatches(records, len(records)).Error
+}
+
+func (r *apiAuditRepository) List(ctx context.Context, filter models.APIAuditFilter) ([]*models.APIAudit, error) {
+	query := r.db.WithContext(ctx).Model(&models.APIAudit{})
+	if filter.ActorID != nil {
+		query = query.Where("actor_id = ?", *filter.ActorID)
+	}
+	if filter.Method != "" {
+		query = query.Where("method = ?", filter.Method)
+	}
+	if filter.Route != "" {
+		query = query.Where("route = ?", filter.Route)
+	}
+	if filter.EntityType != "" {
+		query = query.Where("entity_type = ?", filter.EntityType)
+	}
+	if filter.EntityID != "" {
+		query = query.Where("entity_id = ?", filter.EntityID)
+	}
+	if filter.Outcome != "" {
+		query = query.Where("outcome = ?", filter.Outcome)
+	}
+	// Bounding occurred_at lets Postgres skip the other months' partitions
+	if !filter.From.IsZero() {
+		query = query.Where("occurred_at >= ?", filter.From)
+	}
+	if !filter.To.IsZero() {
+		query = query.Where("occurred_at < ?", filter.To)
+	}
+
+	var records []*models.APIAudit
+	err := query.Order("occurred_at DESC, id DESC").
+		Limit(filter.Limit).
+		Find(&records).Error
+	return records, err
+}
@@ -0,0 +1,50 @@
+package postgres
+
+import (
+	"asset-management-api/internal/models"
+	"asset-management-api/internal/repository/interfaces"
+	"context"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type assetEventRepository struct {
+	db *gorm.DB
+}
+
+func NewAssetEventRepository(db *gorm.DB) interfaces.AssetEventRepository {
+	return &assetEventRepository{db: db}
+}
+
+func (r *assetEventRepository) Append(ctx context.Context, event *models.AssetEventLog) error {
+	// Redelivered events hit the unique event ID and time and are skipped
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "event_id"}, {Name: "occurred_at"}},
+		DoNothing: true,
+	}).Create(event).Error
+}
+
+func (r *assetEventRepository) GetByAssetID(ctx context.Context, assetID uuid.UUID, limit int) ([]*models.AssetEventLog, error) {
+	var events []*models.AssetEventLog
+	err := r.db.WithContext(ctx).Where("asset_id = ?", assetID).
+		Order("occurred_at DESC").
+		Limit(limit).
+		Find(&events).Error
+	return events, err
+}
+
+func (r *assetEventRepository) ListAfterEvent(ctx context.Context, eventID uuid.UUID, limit int) ([]*models.AssetEventLog, error) {
+	var last models.AssetEventLog
+	if err := r.db.WithContext(ctx).Select("id").Where("event_id = ?", eventID).Take(&last).Error; err != nil {
+		return nil, err
+	}
+
+	// IDs follow the order the events were recorded in
+	var events []*models.AssetEventLog
+	err := r.db.WithContext(ctx).Where("id > ?", last.ID).
+		Order("id ASC").
+		Limit(limit).
+		Find(&events).Error
+	return events, err
+}
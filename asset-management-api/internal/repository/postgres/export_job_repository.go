@@ -0,0 +1,80 @@
+package postgres
+
+import (
+	"asset-management-api/internal/models"
+	"asset-management-api/internal/repository/interfaces"
+	"context"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/plugin/dbresolver"
+	"time"
+)
+
+type exportJobRepository struct {
+	db *gorm.DB
+}
+
+func NewExportJobRepository(db *gorm.DB) interfaces.ExportJobRepository {
+	return &exportJobRepository{db: db}
+}
+
+func (r *exportJobRepository) Create(ctx context.Context, job *models.ExportJob) error {
+	return r.db.WithContext(ctx).Create(job).Error
+}
+
+func (r *exportJobRepository) GetByID(ctx context.Context, exportID uuid.UUID) (*models.ExportJob, error) {
+	var job models.ExportJob
+	// Read from the primary: clients poll a job right after requesting it
+	err := r.db.WithContext(ctx).Clauses(dbresolver.Write).First(&job, "export_id = ?", exportID).Error
+	if err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+func (r *exportJobRepository) ListByRequester(ctx context.Context, userID uuid.UUID, limit int) ([]*models.ExportJob, error) {
+	var jobs []*models.ExportJob
+	err := r.db.WithContext(ctx).Clauses(dbresolver.Write).Where("requested_by = ?", userID).
+		Order("created_at DESC").
+		Limit(limit).
+		Find(&jobs).Error
+	return jobs, err
+}
+
+func (r *exportJobRepository) ListPending(ctx context.Context, staleBefore time.Time, limit int) ([]*models.ExportJob, error) {
+	var jobs []*models.ExportJob
+	err := r.pending(r.db.WithContext(ctx).Clauses(dbresolver.Write), staleBefore).
+		Order("created_at").
+		Limit(limit).
+		Find(&jobs).Error
+	return jobs, err
+}
+
+func (r *exportJobRepository) Claim(ctx context.Context, exportID uuid.UUID, staleBefore, now time.Time) (bool, error) {
+	// The condition is checked by the update itself, so only one of the
+	// workers racing for a job gets it
+	result := r.pending(r.db.WithContext(ctx).Model(&models.ExportJob{}).Where("export_id = ?", exportID), staleBefore).
+		Updates(map[string]interface{}{
+			"status":     models.ExportRunning,
+			"progress":   0,
+			"attempts":   gorm.Expr("attempts + 1"),
+			"started_at": now,
+			"updated_at": now,
+		})
+	return result.RowsAffected == 1, result.Error
+}
+
+func (r *exportJobRepository) SetProgress(ctx context.Context, exportID uuid.UUID, progress int, now time.Time) error {
+	return r.db.WithContext(ctx).Model(&models.ExportJob{}).
+		Where("export_id = ? AND status = ?", exportID, models.ExportRunning).
+		Updates(map[string]interface{}{"progress": progress, "updated_at": now}).Error
+}
+
+func (r *exportJobRepository) Update(ctx context.Context, job *models.ExportJob) error {
+	return r.db.WithContext(ctx).Save(job).Error
+}
+
+// pending restricts a query to the pending jobs
+func (r *exportJobRepository) pending(db *gorm.DB, staleBefore time.Time) *gorm.DB {
+	return db.Where("status = ? OR (status = ? AND updated_at < ?)", models.ExportQueued, models.ExportRunning, staleBefore)
+}
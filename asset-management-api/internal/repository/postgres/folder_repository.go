@@ -3,6 +3,7 @@ package postgres
 import (
 	"asset-management-api/internal/models"
 	"asset-management-api/internal/repository/interfaces"
+	"context"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
@@ -15,47 +16,86 @@ func NewFolderRepository(db *gorm.DB) interfaces.FolderRepository {
 	return &folderRepository{db: db}
 }
 
-func (r *folderRepository) Create(folder *models.Folder) error {
-	return r.db.Create(folder).Error
+func (r *folderRepository) Create(ctx context.Context, folder *models.Folder) error {
+	return r.db.WithContext(ctx).Create(folder).Error
 }
 
-func (r *folderRepository) GetByID(folderID uuid.UUID) (*models.Folder, error) {
+func (r *folderRepository) GetByID(ctx context.Context, folderID uuid.UUID) (*models.Folder, error) {
 	var folder models.Folder
-	err := r.db.Preload("Owner").Preload("Notes").First(&folder, "folder_id = ?", folderID).Error
+	err := r.db.WithContext(ctx).Preload("Owner").Preload("Notes").First(&folder, "folder_id = ?", folderID).Error
 	if err != nil {
 		return nil, err
 	}
 	return &folder, nil
 }
 
-func (r *folderRepository) GetByOwnerID(ownerID uuid.UUID) ([]*models.Folder, error) {
+func (r *folderRepository) GetByIDs(ctx context.Context, folderIDs []uuid.UUID) ([]*models.Folder, error) {
 	var folders []*models.Folder
-	err := r.db.Preload("Owner").Where("owner_id = ?", ownerID).Find(&folders).Error
+	err := r.db.WithContext(ctx).Preload("Owner").Where("folder_id IN ?", folderIDs).Find(&folders).Error
 	return folders, err
 }
 
-func (r *folderRepository) Update(folder *models.Folder) error {
-	return r.db.Save(folder).Error
+func (r *folderRepository) GetByOwnerID(ctx context.Context, ownerID uuid.UUID) ([]*models.Folder, error) {
+	var folders []*models.Folder
+	err := r.db.WithContext(ctx).Preload("Owner").Where("owner_id = ?", ownerID).Find(&folders).Error
+	return folders, err
+}
+
+func (r *folderRepository) Update(ctx context.Context, folder *models.Folder) error {
+	return updateVersioned(r.db.WithContext(ctx), folder, &folder.Version, "name", "description")
 }
 
-func (r *folderRepository) Delete(folderID uuid.UUID) error {
-	// This will cascade delete notes and shares due to foreign key constraints
-	return r.db.Delete(&models.Folder{}, "folder_id = ?", folderID).Error
+func (r *folderRepository) Delete(ctx context.Context, folderID uuid.UUID) error {
+	// Folders are soft-deleted, which foreign keys do not cascade, so the
+	// notes go with the folder here; shares are removed by
+	// ShareRepository.DeleteFolderShares
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&models.Note{}, "folder_id = ?", folderID).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&models.Folder{}, "folder_id = ?", folderID).Error
+	})
 }
 
-func (r *folderRepository) CheckOwnership(folderID, userID uuid.UUID) (bool, error) {
+func (r *folderRepository) CheckOwnership(ctx context.Context, folderID, userID uuid.UUID) (bool, error) {
 	var count int64
-	err := r.db.Model(&models.Folder{}).Where("folder_id = ? AND owner_id = ?", folderID, userID).Count(&count).Error
+	err := r.db.WithContext(ctx).Model(&models.Folder{}).Where("folder_id = ? AND owner_id = ?", folderID, userID).Count(&count).Error
 	return count > 0, err
 }
 
-func (r *folderRepository) GetSharedFolders(userID uuid.UUID) ([]*models.Folder, error) {
+func (r *folderRepository) GetSharedFolders(ctx context.Context, userID uuid.UUID) ([]*models.Folder, error) {
 	var folders []*models.Folder
-	err := r.db.Table("folders").
+	err := r.db.WithContext(ctx).Table("folders").
 		Select("folders.*").
-		Joins("JOIN folder_shares ON folders.folder_id = folder_shares.folder_id").
+		Joins("JOIN folder_shares ON folders.folder_id = folder_shares.folder_id AND folder_shares.deleted_at IS NULL").
 		Where("folder_shares.shared_with_user_id = ?", userID).
 		Preload("Owner").
 		Find(&folders).Error
 	return folders, err
+}
+
+func (r *folderRepository) GetIDsByOwnerID(ctx context.Context, ownerID uuid.UUID) ([]uuid.UUID, error) {
+	var folderIDs []uuid.UUID
+	err := r.db.WithContext(ctx).Model(&models.Folder{}).Where("owner_id = ?", ownerID).Pluck("folder_id", &folderIDs).Error
+	return folderIDs, err
+}
+
+func (r *folderRepository) GetSharedFolderIDs(ctx context.Context, userID uuid.UUID) ([]uuid.UUID, error) {
+	var folderIDs []uuid.UUID
+	err := r.db.WithContext(ctx).Model(&models.FolderShare{}).Where("shared_with_user_id = ?", userID).Pluck("folder_id", &folderIDs).Error
+	return folderIDs, err
+}
+
+func (r *folderRepository) ListAccessible(ctx context.Context, userID uuid.UUID, req models.PageRequest) (*models.Page[*models.Folder], error) {
+	var folders []*models.Folder
+	query := r.db.WithContext(ctx).Preload("Owner").
+		Where("owner_id = ? OR folder_id IN (?)", userID,
+			r.db.WithContext(ctx).Model(&models.FolderShare{}).Select("folder_id").Where("shared_with_user_id = ?", userID))
+	err := paginate(query, req, "created_at", "folder_id").Find(&folders).Error
+	if err != nil {
+		return nil, err
+	}
+	return models.NewPage(folders, req, func(folder *models.Folder) models.Cursor {
+		return models.Cursor{CreatedAt: folder.CreatedAt, ID: folder.FolderID}
+	}), nil
 }
\ No newline at end of file
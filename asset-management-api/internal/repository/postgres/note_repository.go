@@ -3,6 +3,9 @@ package postgres
 import (
 	"asset-management-api/internal/models"
 	"asset-management-api/internal/repository/interfaces"
+	"context"
+	"strings"
+
 	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
@@ -15,54 +18,128 @@ func NewNoteRepository(db *gorm.DB) interfaces.NoteRepository {
 	return &noteRepository{db: db}
 }
 
-func (r *noteRepository) Create(note *models.Note) error {
-	return r.db.Create(note).Error
+func (r *noteRepository) Create(ctx context.Context, note *models.Note) error {
+	return r.db.WithContext(ctx).Create(note).Error
 }
 
-func (r *noteRepository) GetByID(noteID uuid.UUID) (*models.Note, error) {
+func (r *noteRepository) GetByID(ctx context.Context, noteID uuid.UUID) (*models.Note, error) {
 	var note models.Note
-	err := r.db.Preload("Owner").Preload("Folder").First(&note, "note_id = ?", noteID).Error
+	err := r.db.WithContext(ctx).Preload("Owner").Preload("Folder").First(&note, "note_id = ?", noteID).Error
 	if err != nil {
 		return nil, err
 	}
 	return &note, nil
 }
 
-func (r *noteRepository) GetByFolderID(folderID uuid.UUID) ([]*models.Note, error) {
+func (r *noteRepository) GetByIDs(ctx context.Context, noteIDs []uuid.UUID) ([]*models.Note, error) {
+	var notes []*models.Note
+	err := r.db.WithContext(ctx).Preload("Owner").Preload("Folder").Where("note_id IN ?", noteIDs).Find(&notes).Error
+	return notes, err
+}
+
+func (r *noteRepository) GetByFolderID(ctx context.Context, folderID uuid.UUID) ([]*models.Note, error) {
+	var notes []*models.Note
+	err := r.listQuery(ctx, "Owner").Where("folder_id = ?", folderID).Find(&notes).Error
+	return notes, err
+}
+
+func (r *noteRepository) GetByFolderIDs(ctx context.Context, folderIDs []uuid.UUID) ([]*models.Note, error) {
 	var notes []*models.Note
-	err := r.db.Preload("Owner").Where("folder_id = ?", folderID).Find(&notes).Error
+	err := r.db.WithContext(ctx).Preload("Owner").Where("folder_id IN ?", folderIDs).Find(&notes).Error
 	return notes, err
 }
 
-func (r *noteRepository) GetByOwnerID(ownerID uuid.UUID) ([]*models.Note, error) {
+func (r *noteRepository) GetByOwnerID(ctx context.Context, ownerID uuid.UUID) ([]*models.Note, error) {
 	var notes []*models.Note
-	err := r.db.Preload("Owner").Preload("Folder").Where("owner_id = ?", ownerID).Find(&notes).Error
+	err := r.db.WithContext(ctx).Preload("Owner").Preload("Folder").Where("owner_id = ?", ownerID).Find(&notes).Error
 	return notes, err
 }
 
-func (r *noteRepository) Update(note *models.Note) error {
-	return r.db.Save(note).Error
+func (r *noteRepository) Update(ctx context.Context, note *models.Note) error {
+	return updateVersioned(r.db.WithContext(ctx), note, &note.Version, "title", "body")
 }
 
-func (r *noteRepository) Delete(noteID uuid.UUID) error {
-	// This will cascade delete shares due to foreign key constraints
-	return r.db.Delete(&models.Note{}, "note_id = ?", noteID).Error
+func (r *noteRepository) Delete(ctx context.Context, noteID uuid.UUID) error {
+	// Notes are soft-deleted, which foreign keys do not cascade, so the
+	// shares go with the note here
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&models.NoteShare{}, "note_id = ?", noteID).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&models.Note{}, "note_id = ?", noteID).Error
+	})
 }
 
-func (r *noteRepository) CheckOwnership(noteID, userID uuid.UUID) (bool, error) {
+func (r *noteRepository) CheckOwnership(ctx context.Context, noteID, userID uuid.UUID) (bool, error) {
 	var count int64
-	err := r.db.Model(&models.Note{}).Where("note_id = ? AND owner_id = ?", noteID, userID).Count(&count).Error
+	err := r.db.WithContext(ctx).Model(&models.Note{}).Where("note_id = ? AND owner_id = ?", noteID, userID).Count(&count).Error
 	return count > 0, err
 }
 
-func (r *noteRepository) GetSharedNotes(userID uuid.UUID) ([]*models.Note, error) {
+func (r *noteRepository) GetSharedNotes(ctx context.Context, userID uuid.UUID) ([]*models.Note, error) {
 	var notes []*models.Note
-	err := r.db.Table("notes").
+	err := r.db.WithContext(ctx).Table("notes").
 		Select("notes.*").
-		Joins("JOIN note_shares ON notes.note_id = note_shares.note_id").
+		Joins("JOIN note_shares ON notes.note_id = note_shares.note_id AND note_shares.deleted_at IS NULL").
 		Where("note_shares.shared_with_user_id = ?", userID).
 		Preload("Owner").
 		Preload("Folder").
 		Find(&notes).Error
 	return notes, err
+}
+
+func (r *noteRepository) GetIDsByOwnerID(ctx context.Context, ownerID uuid.UUID) ([]uuid.UUID, error) {
+	var noteIDs []uuid.UUID
+	err := r.db.WithContext(ctx).Model(&models.Note{}).Where("owner_id = ?", ownerID).Pluck("note_id", &noteIDs).Error
+	return noteIDs, err
+}
+
+func (r *noteRepository) GetSharedNoteIDs(ctx context.Context, userID uuid.UUID) ([]uuid.UUID, error) {
+	var noteIDs []uuid.UUID
+	err := r.db.WithContext(ctx).Model(&models.NoteShare{}).Where("shared_with_user_id = ?", userID).Pluck("note_id", &noteIDs).Error
+	return noteIDs, err
+}
+
+func (r *noteRepository) ListByFolderID(ctx context.Context, folderID uuid.UUID, req models.PageRequest) (*models.Page[*models.Note], error) {
+	var notes []*models.Note
+	err := paginate(r.listQuery(ctx, "Owner").Where("folder_id = ?", folderID), req, "created_at", "note_id").Find(&notes).Error
+	if err != nil {
+		return nil, err
+	}
+	return models.NewPage(notes, req, noteCursor), nil
+}
+
+func (r *noteRepository) ListAccessible(ctx context.Context, userID uuid.UUID, req models.PageRequest) (*models.Page[*models.Note], error) {
+	var notes []*models.Note
+	query := r.listQuery(ctx, "Owner", "Folder").
+		Where("owner_id = ? OR note_id IN (?)", userID,
+			r.db.WithContext(ctx).Model(&models.NoteShare{}).Select("note_id").Where("shared_with_user_id = ?", userID))
+	err := paginate(query, req, "created_at", "note_id").Find(&notes).Error
+	if err != nil {
+		return nil, err
+	}
+	return models.NewPage(notes, req, noteCursor), nil
+}
+
+// listQuery starts a query of a list of notes preloading relations, such as
+// "Owner", for the request of ctx. The relations, read with queries of their
+// own, and the body, by far the largest column, are left out when the
+// request selected fields without them. Notes read this way must not be
+// cached.
+func (r *noteRepository) listQuery(ctx context.Context, relations ...string) *gorm.DB {
+	fields := models.FieldsFromContext(ctx)
+	db := r.db.WithContext(ctx)
+	if !fields.Has("body") {
+		db = db.Omit("body")
+	}
+	for _, relation := range relations {
+		if fields.Has(strings.ToLower(relation)) {
+			db = db.Preload(relation)
+		}
+	}
+	return db
+}
+
+func noteCursor(note *models.Note) models.Cursor {
+	return models.Cursor{CreatedAt: note.CreatedAt, ID: note.NoteID}
 }
\ No newline at end of file
@@ -0,0 +1,18 @@
+package postgres
+
+import (
+	"asset-management-api/internal/models"
+	"fmt"
+	"gorm.io/gorm"
+)
+
+// paginate orders a query newest first by the createdAt and id columns and
+// limits it to the page requested, reading one row more to tell whether
+// another page follows. Rows are found by their keys rather than skipped
+// with OFFSET, so later pages cost no more than the first.
+func paginate(db *gorm.DB, req models.PageRequest, createdAt, id string) *gorm.DB {
+	if req.After != nil {
+		db = db.Where(fmt.Sprintf("(%s, %s) < (?, ?)", createdAt, id), req.After.CreatedAt, req.After.ID)
+	}
+	return db.Order(createdAt + " DESC").Order(id + " DESC").Limit(req.Limit + 1)
+}
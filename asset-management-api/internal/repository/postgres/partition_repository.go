@@ -0,0 +1,37 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"asset-management-api/internal/repository/interfaces"
+	"gorm.io/gorm"
+	"gorm.io/plugin/dbresolver"
+)
+
+type partitionRepository struct {
+	db *gorm.DB
+}
+
+func NewPartitionRepository(db *gorm.DB) interfaces.PartitionRepository {
+	return &partitionRepository{db: db}
+}
+
+func (r *partitionRepository) EnsurePartition(ctx context.Context, table string, t time.Time) error {
+	// Only Postgres partitions the tables
+	if r.db.Dialector.Name() != "postgres" {
+		return nil
+	}
+	// The functions change the schema, so they must not go to a replica
+	// despite being called with SELECT
+	return r.db.WithContext(ctx).Clauses(dbresolver.Write).Exec("SELECT create_monthly_partition(?, ?)", table, t).Error
+}
+
+func (r *partitionRepository) DropPartitionsBefore(ctx context.Context, table string, cutoff time.Time) ([]string, error) {
+	if r.db.Dialector.Name() != "postgres" {
+		return nil, nil
+	}
+	var dropped []string
+	err := r.db.WithContext(ctx).Clauses(dbresolver.Write).Raw("SELECT drop_monthly_partitions_before(?, ?)", table, cutoff).Scan(&dropped).Error
+	return dropped, err
+}
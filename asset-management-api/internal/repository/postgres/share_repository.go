@@ -3,8 +3,10 @@ package postgres
 import (
 	"asset-management-api/internal/models"
 	"asset-management-api/internal/repository/interfaces"
+	"context"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type shareRepository struct {
@@ -16,23 +18,29 @@ func NewShareRepository(db *gorm.DB) interfaces.ShareRepository {
 }
 
 // Folder sharing methods
-func (r *shareRepository) ShareFolder(folderShare *models.FolderShare) error {
-	return r.db.Create(folderShare).Error
+func (r *shareRepository) ShareFolder(ctx context.Context, folderShare *models.FolderShare) error {
+	return r.share(ctx, folderShare, folderShare.AccessLevel, folderShare.SharedBy)
 }
 
-func (r *shareRepository) UnshareFolder(folderID, userID uuid.UUID) error {
-	return r.db.Delete(&models.FolderShare{}, "folder_id = ? AND shared_with_user_id = ?", folderID, userID).Error
+func (r *shareRepository) UnshareFolder(ctx context.Context, folderID, userID uuid.UUID) error {
+	return r.db.WithContext(ctx).Delete(&models.FolderShare{}, "folder_id = ? AND shared_with_user_id = ?", folderID, userID).Error
 }
 
-func (r *shareRepository) GetFolderShares(folderID uuid.UUID) ([]*models.FolderShare, error) {
+func (r *shareRepository) GetFolderShares(ctx context.Context, folderID uuid.UUID) ([]*models.FolderShare, error) {
 	var shares []*models.FolderShare
-	err := r.db.Preload("SharedWithUser").Preload("SharedByUser").Where("folder_id = ?", folderID).Find(&shares).Error
+	err := r.db.WithContext(ctx).Preload("SharedWithUser").Preload("SharedByUser").Where("folder_id = ?", folderID).Find(&shares).Error
 	return shares, err
 }
 
-func (r *shareRepository) CheckFolderAccess(folderID, userID uuid.UUID) (string, error) {
+func (r *shareRepository) GetSharesByFolderIDs(ctx context.Context, folderIDs []uuid.UUID) ([]*models.FolderShare, error) {
+	var shares []*models.FolderShare
+	err := r.db.WithContext(ctx).Preload("SharedWithUser").Preload("SharedByUser").Where("folder_id IN ?", folderIDs).Find(&shares).Error
+	return shares, err
+}
+
+func (r *shareRepository) CheckFolderAccess(ctx context.Context, folderID, userID uuid.UUID) (string, error) {
 	var share models.FolderShare
-	err := r.db.First(&share, "folder_id = ? AND shared_with_user_id = ?", folderID, userID).Error
+	err := r.db.WithContext(ctx).First(&share, "folder_id = ? AND shared_with_user_id = ?", folderID, userID).Error
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return "", nil
@@ -42,24 +50,94 @@ func (r *shareRepository) CheckFolderAccess(folderID, userID uuid.UUID) (string,
 	return share.AccessLevel, nil
 }
 
+func (r *shareRepository) ListFolderShares(ctx context.Context, folderID uuid.UUID, req models.PageRequest) (*models.Page[*models.FolderShare], error) {
+	var shares []*models.FolderShare
+	query := r.db.WithContext(ctx).Preload("SharedWithUser").Preload("SharedByUser").Where("folder_id = ?", folderID)
+	err := paginate(query, req, "created_at", "shared_with_user_id").Find(&shares).Error
+	if err != nil {
+		return nil, err
+	}
+	return models.NewPage(shares, req, func(share *models.FolderShare) models.Cursor {
+		return models.Cursor{CreatedAt: share.CreatedAt, ID: share.SharedWithUserID}
+	}), nil
+}
+
+func (r *shareRepository) DeleteFolderShares(ctx context.Context, folderID uuid.UUID) error {
+	err := r.db.WithContext(ctx).Where("note_id IN (?)", r.db.WithContext(ctx).Model(&models.Note{}).Select("note_id").Where("folder_id = ?", folderID)).
+		Delete(&models.NoteShare{}).Error
+	if err != nil {
+		return err
+	}
+	return r.db.WithContext(ctx).Delete(&models.FolderShare{}, "folder_id = ?", folderID).Error
+}
+
 // Note sharing methods
-func (r *shareRepository) ShareNote(noteShare *models.NoteShare) error {
-	return r.db.Create(noteShare).Error
+func (r *shareRepository) ShareNote(ctx context.Context, noteShare *models.NoteShare) error {
+	return r.share(ctx, noteShare, noteShare.AccessLevel, noteShare.SharedBy)
 }
 
-func (r *shareRepository) UnshareNote(noteID, userID uuid.UUID) error {
-	return r.db.Delete(&models.NoteShare{}, "note_id = ? AND shared_with_user_id = ?", noteID, userID).Error
+// share creates a share, restoring it if it was removed, as removed shares
+// are soft-deleted and keep their key. A share that exists is left as it is
+// and reported with gorm.ErrDuplicatedKey.
+func (r *shareRepository) share(ctx context.Context, share interface{}, accessLevel string, sharedBy uuid.UUID) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		restored := tx.Unscoped().Model(share).Where("deleted_at IS NOT NULL").Updates(map[string]interface{}{
+			"access_level": accessLevel,
+			"shared_by":    sharedBy,
+			"created_at":   tx.NowFunc(),
+			"deleted_at":   nil,
+		})
+		if restored.Error != nil {
+			return restored.Error
+		}
+		if restored.RowsAffected > 0 {
+			return tx.First(share).Error
+		}
+
+		// Conflicts are skipped the same way on every dialect, unlike
+		// conditional upserts, which MySQL lacks
+		created := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(share)
+		if created.Error != nil {
+			return created.Error
+		}
+		if created.RowsAffected == 0 {
+			return gorm.ErrDuplicatedKey
+		}
+		return nil
+	})
+}
+
+func (r *shareRepository) UnshareNote(ctx context.Context, noteID, userID uuid.UUID) error {
+	return r.db.WithContext(ctx).Delete(&models.NoteShare{}, "note_id = ? AND shared_with_user_id = ?", noteID, userID).Error
+}
+
+func (r *shareRepository) GetNoteShares(ctx context.Context, noteID uuid.UUID) ([]*models.NoteShare, error) {
+	var shares []*models.NoteShare
+	err := r.db.WithContext(ctx).Preload("SharedWithUser").Preload("SharedByUser").Where("note_id = ?", noteID).Find(&shares).Error
+	return shares, err
 }
 
-func (r *shareRepository) GetNoteShares(noteID uuid.UUID) ([]*models.NoteShare, error) {
+func (r *shareRepository) GetSharesByNoteIDs(ctx context.Context, noteIDs []uuid.UUID) ([]*models.NoteShare, error) {
 	var shares []*models.NoteShare
-	err := r.db.Preload("SharedWithUser").Preload("SharedByUser").Where("note_id = ?", noteID).Find(&shares).Error
+	err := r.db.WithContext(ctx).Preload("SharedWithUser").Preload("SharedByUser").Where("note_id IN ?", noteIDs).Find(&shares).Error
 	return shares, err
 }
 
-func (r *shareRepository) CheckNoteAccess(noteID, userID uuid.UUID) (string, error) {
+func (r *shareRepository) ListNoteShares(ctx context.Context, noteID uuid.UUID, req models.PageRequest) (*models.Page[*models.NoteShare], error) {
+	var shares []*models.NoteShare
+	query := r.db.WithContext(ctx).Preload("SharedWithUser").Preload("SharedByUser").Where("note_id = ?", noteID)
+	err := paginate(query, req, "created_at", "shared_with_user_id").Find(&shares).Error
+	if err != nil {
+		return nil, err
+	}
+	return models.NewPage(shares, req, func(share *models.NoteShare) models.Cursor {
+		return models.Cursor{CreatedAt: share.CreatedAt, ID: share.SharedWithUserID}
+	}), nil
+}
+
+func (r *shareRepository) CheckNoteAccess(ctx context.Context, noteID, userID uuid.UUID) (string, error) {
 	var share models.NoteShare
-	err := r.db.First(&share, "note_id = ? AND shared_with_user_id = ?", noteID, userID).Error
+	err := r.db.WithContext(ctx).First(&share, "note_id = ? AND shared_with_user_id = ?", noteID, userID).Error
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return "", nil
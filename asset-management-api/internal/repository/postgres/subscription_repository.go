@@ -0,0 +1,105 @@
+package postgres
+
+import (
+	"asset-management-api/internal/models"
+	"asset-management-api/internal/repository/interfaces"
+	"context"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
+)
+
+type subscriptionRepository struct {
+	db *gorm.DB
+}
+
+func NewSubscriptionRepository(db *gorm.DB) interfaces.SubscriptionRepository {
+	return &subscriptionRepository{db: db}
+}
+
+func (r *subscriptionRepository) Create(ctx context.Context, subscription *models.Subscription) error {
+	return r.db.WithContext(ctx).Create(subscription).Error
+}
+
+func (r *subscriptionRepository) GetByID(ctx context.Context, subscriptionID uuid.UUID) (*models.Subscription, error) {
+	var subscription models.Subscription
+	err := r.db.WithContext(ctx).First(&subscription, "subscription_id = ?", subscriptionID).Error
+	if err != nil {
+		return nil, err
+	}
+	return &subscription, nil
+}
+
+func (r *subscriptionRepository) GetByUserID(ctx context.Context, userID uuid.UUID) ([]*models.Subscription, error) {
+	var subscriptions []*models.Subscription
+	err := r.db.WithContext(ctx).Where("user_id = ?", userID).Order("created_at").Find(&subscriptions).Error
+	return subscriptions, err
+}
+
+func (r *subscriptionRepository) GetByUserAndAsset(ctx context.Context, userID, assetID uuid.UUID) (*models.Subscription, error) {
+	var subscription models.Subscription
+	err := r.db.WithContext(ctx).First(&subscription, "user_id = ? AND asset_id = ?", userID, assetID).Error
+	if err != nil {
+		return nil, err
+	}
+	return &subscription, nil
+}
+
+func (r *subscriptionRepository) GetByAssetIDs(ctx context.Context, assetIDs []uuid.UUID) ([]*models.Subscription, error) {
+	var subscriptions []*models.Subscription
+	if len(assetIDs) == 0 {
+		return subscriptions, nil
+	}
+	err := r.db.WithContext(ctx).Where("asset_id IN ?", assetIDs).Find(&subscriptions).Error
+	return subscriptions, err
+}
+
+func (r *subscriptionRepository) Delete(ctx context.Context, subscriptionID uuid.UUID) error {
+	return r.db.WithContext(ctx).Delete(&models.Subscription{}, "subscription_id = ?", subscriptionID).Error
+}
+
+func (r *subscriptionRepository) DeleteByAssetID(ctx context.Context, assetID uuid.UUID) error {
+	return r.db.WithContext(ctx).Delete(&models.Subscription{}, "asset_id = ?", assetID).Error
+}
+
+func (r *subscriptionRepository) CreateNotifications(ctx context.Context, notifications []*models.Notification) error {
+	if len(notifications) == 0 {
+		return nil
+	}
+	// Redelivered events hit the unique user, event ID and time and are skipped
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}, {Name: "event_id"}, {Name: "created_at"}},
+		DoNothing: true,
+	}).Create(&notifications).Error
+}
+
+func (r *subscriptionRepository) GetNotifications(ctx context.Context, userID uuid.UUID, unreadOnly bool, limit int) ([]*models.Notification, error) {
+	var notifications []*models.Notification
+	query := r.db.WithContext(ctx).Where("user_id = ?", userID)
+	if unreadOnly {
+		query = query.Where("read_at IS NULL")
+	}
+	err := query.Order("created_at DESC").Limit(limit).Find(&notifications).Error
+	return notifications, err
+}
+
+func (r *subscriptionRepository) MarkNotificationRead(ctx context.Context, notificationID, userID uuid.UUID, readAt time.Time) (bool, error) {
+	result := r.db.WithContext(ctx).Model(&models.Notification{}).
+		Where("notification_id = ? AND user_id = ?", notificationID, userID).
+		Where("read_at IS NULL").
+		Update("read_at", readAt)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	if result.RowsAffected > 0 {
+		return true, nil
+	}
+
+	// Already read notifications still exist
+	var count int64
+	err := r.db.WithContext(ctx).Model(&models.Notification{}).
+		Where("notification_id = ? AND user_id = ?", notificationID, userID).
+		Count(&count).Error
+	return count > 0, err
+}
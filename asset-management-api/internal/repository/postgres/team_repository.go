@@ -3,6 +3,7 @@ package postgres
 import (
 	"asset-management-api/internal/models"
 	"asset-management-api/internal/repository/interfaces"
+	"context"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
@@ -15,22 +16,28 @@ func NewTeamRepository(db *gorm.DB) interfaces.TeamRepository {
 	return &teamRepository{db: db}
 }
 
-func (r *teamRepository) Create(team *models.Team) error {
-	return r.db.Create(team).Error
+func (r *teamRepository) Create(ctx context.Context, team *models.Team) error {
+	return r.db.WithContext(ctx).Create(team).Error
 }
 
-func (r *teamRepository) GetByID(teamID uuid.UUID) (*models.Team, error) {
+func (r *teamRepository) GetByID(ctx context.Context, teamID uuid.UUID) (*models.Team, error) {
 	var team models.Team
-	err := r.db.Preload("Managers").Preload("Members").First(&team, "team_id = ?", teamID).Error
+	err := r.db.WithContext(ctx).Preload("Managers").Preload("Members").First(&team, "team_id = ?", teamID).Error
 	if err != nil {
 		return nil, err
 	}
 	return &team, nil
 }
 
-func (r *teamRepository) GetTeamsByManagerID(managerID uuid.UUID) ([]*models.Team, error) {
+func (r *teamRepository) GetAllIDs(ctx context.Context) ([]uuid.UUID, error) {
+	var teamIDs []uuid.UUID
+	err := r.db.WithContext(ctx).Model(&models.Team{}).Order("created_at").Pluck("team_id", &teamIDs).Error
+	return teamIDs, err
+}
+
+func (r *teamRepository) GetTeamsByManagerID(ctx context.Context, managerID uuid.UUID) ([]*models.Team, error) {
 	var teams []*models.Team
-	err := r.db.Table("teams").
+	err := r.db.WithContext(ctx).Table("teams").
 		Select("teams.*").
 		Joins("JOIN team_managers ON teams.team_id = team_managers.team_id").
 		Where("team_managers.manager_id = ?", managerID).
@@ -40,9 +47,9 @@ func (r *teamRepository) GetTeamsByManagerID(managerID uuid.UUID) ([]*models.Tea
 	return teams, err
 }
 
-func (r *teamRepository) GetTeamsByMemberID(memberID uuid.UUID) ([]*models.Team, error) {
+func (r *teamRepository) GetTeamsByMemberID(ctx context.Context, memberID uuid.UUID) ([]*models.Team, error) {
 	var teams []*models.Team
-	err := r.db.Table("teams").
+	err := r.db.WithContext(ctx).Table("teams").
 		Select("teams.*").
 		Joins("JOIN team_members ON teams.team_id = team_members.team_id").
 		Where("team_members.member_id = ?", memberID).
@@ -52,46 +59,61 @@ func (r *teamRepository) GetTeamsByMemberID(memberID uuid.UUID) ([]*models.Team,
 	return teams, err
 }
 
-func (r *teamRepository) AddManager(teamID, managerID uuid.UUID) error {
+func (r *teamRepository) ListByUserID(ctx context.Context, userID uuid.UUID, req models.PageRequest) (*models.Page[*models.Team], error) {
+	var teams []*models.Team
+	query := r.db.WithContext(ctx).Preload("Managers").Preload("Members").
+		Where("team_id IN (?) OR team_id IN (?)",
+			r.db.WithContext(ctx).Model(&models.TeamManager{}).Select("team_id").Where("manager_id = ?", userID),
+			r.db.WithContext(ctx).Model(&models.TeamMember{}).Select("team_id").Where("member_id = ?", userID))
+	err := paginate(query, req, "created_at", "team_id").Find(&teams).Error
+	if err != nil {
+		return nil, err
+	}
+	return models.NewPage(teams, req, func(team *models.Team) models.Cursor {
+		return models.Cursor{CreatedAt: team.CreatedAt, ID: team.TeamID}
+	}), nil
+}
+
+func (r *teamRepository) AddManager(ctx context.Context, teamID, managerID uuid.UUID) error {
 	teamManager := &models.TeamManager{
 		TeamID:    teamID,
 		ManagerID: managerID,
 	}
-	return r.db.Create(teamManager).Error
+	return r.db.WithContext(ctx).Create(teamManager).Error
 }
 
-func (r *teamRepository) RemoveManager(teamID, managerID uuid.UUID) error {
-	return r.db.Delete(&models.TeamManager{}, "team_id = ? AND manager_id = ?", teamID, managerID).Error
+func (r *teamRepository) RemoveManager(ctx context.Context, teamID, managerID uuid.UUID) error {
+	return r.db.WithContext(ctx).Delete(&models.TeamManager{}, "team_id = ? AND manager_id = ?", teamID, managerID).Error
 }
 
-func (r *teamRepository) AddMember(teamID, memberID uuid.UUID) error {
+func (r *teamRepository) AddMember(ctx context.Context, teamID, memberID uuid.UUID) error {
 	teamMember := &models.TeamMember{
 		TeamID:   teamID,
 		MemberID: memberID,
 	}
-	return r.db.Create(teamMember).Error
+	return r.db.WithContext(ctx).Create(teamMember).Error
 }
 
-func (r *teamRepository) RemoveMember(teamID, memberID uuid.UUID) error {
-	return r.db.Delete(&models.TeamMember{}, "team_id = ? AND member_id = ?", teamID, memberID).Error
+func (r *teamRepository) RemoveMember(ctx context.Context, teamID, memberID uuid.UUID) error {
+	return r.db.WithContext(ctx).Delete(&models.TeamMember{}, "team_id = ? AND member_id = ?", teamID, memberID).Error
 }
 
-func (r *teamRepository) IsTeamManager(teamID, userID uuid.UUID) (bool, error) {
+func (r *teamRepository) IsTeamManager(ctx context.Context, teamID, userID uuid.UUID) (bool, error) {
 	var count int64
-	err := r.db.Model(&models.TeamManager{}).Where("team_id = ? AND manager_id = ?", teamID, userID).Count(&count).Error
+	err := r.db.WithContext(ctx).Model(&models.TeamManager{}).Where("team_id = ? AND manager_id = ?", teamID, userID).Count(&count).Error
 	return count > 0, err
 }
 
-func (r *teamRepository) IsTeamMember(teamID, userID uuid.UUID) (bool, error) {
+func (r *teamRepository) IsTeamMember(ctx context.Context, teamID, userID uuid.UUID) (bool, error) {
 	var count int64
-	err := r.db.Model(&models.TeamMember{}).Where("team_id = ? AND member_id = ?", teamID, userID).Count(&count).Error
+	err := r.db.WithContext(ctx).Model(&models.TeamMember{}).Where("team_id = ? AND member_id = ?", teamID, userID).Count(&count).Error
 	return count > 0, err
 }
 
-func (r *teamRepository) Update(team *models.Team) error {
-	return r.db.Save(team).Error
+func (r *teamRepository) Update(ctx context.Context, team *models.Team) error {
+	return updateVersioned(r.db.WithContext(ctx), team, &team.Version, "team_name")
 }
 
-func (r *teamRepository) Delete(teamID uuid.UUID) error {
-	return r.db.Delete(&models.Team{}, "team_id = ?", teamID).Error
+func (r *teamRepository) Delete(ctx context.Context, teamID uuid.UUID) error {
+	return r.db.WithContext(ctx).Delete(&models.Team{}, "team_id = ?", teamID).Error
 }
\ No newline at end of file
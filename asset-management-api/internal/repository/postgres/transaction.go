@@ -0,0 +1,44 @@
+package postgres
+
+import (
+	"asset-management-api/internal/database"
+	"asset-management-api/internal/repository/interfaces"
+	"context"
+	"gorm.io/gorm"
+)
+
+type txManager struct {
+	db *gorm.DB
+}
+
+func NewTxManager(db *gorm.DB) interfaces.TxManager {
+	return &txManager{db: db}
+}
+
+func (m *txManager) WithinTransaction(ctx context.Context, fn func(repos interfaces.Repositories) error) error {
+	// Transactions run on the primary, so reads inside see the unit's own
+	// writes. A unit failing on a transient error, such as a deadlock, is
+	// run again as a whole, so fn must not have effects outside of it.
+	return database.RetryTransaction(ctx, m.db, func() error {
+		return m.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			return fn(newRepositories(tx))
+		})
+	})
+}
+
+// NewUnscopedRepositories creates repositories that also see soft-deleted
+// folders, notes, shares and teams, for administration such as restoring
+// deleted assets. Deletes through them are permanent.
+func NewUnscopedRepositories(db *gorm.DB) interfaces.Repositories {
+	return newRepositories(db.Unscoped().Session(&gorm.Session{}))
+}
+
+func newRepositories(db *gorm.DB) interfaces.Repositories {
+	return interfaces.Repositories{
+		Folders: NewFolderRepository(db),
+		Notes:   NewNoteRepository(db),
+		Shares:  NewShareRepository(db),
+		Users:   NewUserRepository(db),
+		Teams:   NewTeamRepository(db),
+	}
+}
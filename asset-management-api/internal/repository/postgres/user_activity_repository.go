@@ -0,0 +1,32 @@
+package postgres
+
+import (
+	"asset-management-api/internal/models"
+	"asset-management-api/internal/repository/interfaces"
+	"context"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type userActivityRepository struct {
+	db *gorm.DB
+}
+
+func NewUserActivityRepository(db *gorm.DB) interfaces.UserActivityRepository {
+	return &userActivityRepository{db: db}
+}
+
+func (r *userActivityRepository) Append(ctx context.Context, activity *models.UserActivityLog) error {
+	// Redelivered events hit the unique event ID and time and are skipped
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "event_id"}, {Name: "occurred_at"}},
+		DoNothing: true,
+	}).Create(activity).Error
+}
+
+func (r *userActivityRepository) ListByActorIDs(ctx context.Context, actorIDs []uuid.UUID) ([]*models.UserActivityLog, error) {
+	var activities []*models.UserActivityLog
+	err := r.db.WithContext(ctx).Where("actor_id IN ?", actorIDs).Order("occurred_at, id").Find(&activities).Error
+	return activities, err
+}
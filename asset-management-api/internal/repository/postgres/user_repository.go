@@ -3,6 +3,7 @@ package postgres
 import (
 	"asset-management-api/internal/models"
 	"asset-management-api/internal/repository/interfaces"
+	"context"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
@@ -15,27 +16,31 @@ func NewUserRepository(db *gorm.DB) interfaces.UserRepository {
 	return &userRepository{db: db}
 }
 
-func (r *userRepository) GetByID(userID uuid.UUID) (*models.User, error) {
+func (r *userRepository) Create(ctx context.Context, user *models.User) error {
+	return r.db.WithContext(ctx).Create(user).Error
+}
+
+func (r *userRepository) GetByID(ctx context.Context, userID uuid.UUID) (*models.User, error) {
 	var user models.User
-	err := r.db.First(&user, "user_id = ?", userID).Error
+	err := r.db.WithContext(ctx).First(&user, "user_id = ?", userID).Error
 	if err != nil {
 		return nil, err
 	}
 	return &user, nil
 }
 
-func (r *userRepository) GetByEmail(email string) (*models.User, error) {
+func (r *userRepository) GetByEmail(ctx context.Context, email string) (*models.User, error) {
 	var user models.User
-	err := r.db.First(&user, "email = ?", email).Error
+	err := r.db.WithContext(ctx).First(&user, "email = ?", email).Error
 	if err != nil {
 		return nil, err
 	}
 	return &user, nil
 }
 
-func (r *userRepository) GetTeamMembers(teamID uuid.UUID) ([]*models.User, error) {
+func (r *userRepository) GetTeamMembers(ctx context.Context, teamID uuid.UUID) ([]*models.User, error) {
 	var users []*models.User
-	err := r.db.Table("users").
+	err := r.db.WithContext(ctx).Table("users").
 		Select("users.*").
 		Joins("JOIN team_members ON users.user_id = team_members.member_id").
 		Where("team_members.team_id = ?", teamID).
@@ -43,11 +48,11 @@ func (r *userRepository) GetTeamMembers(teamID uuid.UUID) ([]*models.User, error
 	return users, err
 }
 
-func (r *userRepository) CheckIfUserInTeam(userID, teamID uuid.UUID) (bool, error) {
+func (r *userRepository) CheckIfUserInTeam(ctx context.Context, userID, teamID uuid.UUID) (bool, error) {
 	var count int64
 	
 	// Check if user is a member
-	err := r.db.Model(&models.TeamMember{}).Where("member_id = ? AND team_id = ?", userID, teamID).Count(&count).Error
+	err := r.db.WithContext(ctx).Model(&models.TeamMember{}).Where("member_id = ? AND team_id = ?", userID, teamID).Count(&count).Error
 	if err != nil {
 		return false, err
 	}
@@ -56,7 +61,7 @@ func (r *userRepository) CheckIfUserInTeam(userID, teamID uuid.UUID) (bool, erro
 	}
 	
 	// Check if user is a manager
-	err = r.db.Model(&models.TeamManager{}).Where("manager_id = ? AND team_id = ?", userID, teamID).Count(&count).Error
+	err = r.db.WithContext(ctx).Model(&models.TeamManager{}).Where("manager_id = ? AND team_id = ?", userID, teamID).Count(&count).Error
 	if err != nil {
 		return false, err
 	}
@@ -64,9 +69,9 @@ func (r *userRepository) CheckIfUserInTeam(userID, teamID uuid.UUID) (bool, erro
 	return count > 0, nil
 }
 
-func (r *userRepository) CheckIfManager(userID uuid.UUID) (bool, error) {
+func (r *userRepository) CheckIfManager(ctx context.Context, userID uuid.UUID) (bool, error) {
 	var user models.User
-	err := r.db.First(&user, "user_id = ? AND role = 'manager'", userID).Error
+	err := r.db.WithContext(ctx).First(&user, "user_id = ? AND role = 'manager'", userID).Error
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return false, nil
@@ -0,0 +1,29 @@
+package postgres
+
+import (
+	"asset-management-api/internal/repository/interfaces"
+	"gorm.io/gorm"
+)
+
+// updateVersioned saves the columns of model, a row read at *version, only
+// if the row is still at that version, and moves it to the next one. Rows
+// changed or deleted in the meantime are left alone and reported with
+// interfaces.ErrVersionConflict. Only the columns given, the version and
+// updated_at are written, never the associations.
+func updateVersioned(db *gorm.DB, model interface{}, version *int64, columns ...string) error {
+	read := *version
+	*version = read + 1
+
+	result := db.Model(model).
+		Where("version = ?", read).
+		Select(append(columns, "version", "updated_at")).
+		Updates(model)
+	if result.Error == nil && result.RowsAffected == 0 {
+		result.Error = interfaces.ErrVersionConflict
+	}
+	if result.Error != nil {
+		*version = read
+		return result.Error
+	}
+	return nil
+}
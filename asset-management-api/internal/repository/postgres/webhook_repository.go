@@ -0,0 +1,108 @@
+package postgres
+
+import (
+	"asset-management-api/internal/models"
+	"asset-management-api/internal/repository/interfaces"
+	"context"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/plugin/dbresolver"
+	"time"
+)
+
+type webhookRepository struct {
+	db *gorm.DB
+}
+
+func NewWebhookRepository(db *gorm.DB) interfaces.WebhookRepository {
+	return &webhookRepository{db: db}
+}
+
+func (r *webhookRepository) Create(ctx context.Context, webhook *models.Webhook) error {
+	return r.db.WithContext(ctx).Create(webhook).Error
+}
+
+func (r *webhookRepository) GetByID(ctx context.Context, webhookID uuid.UUID) (*models.Webhook, error) {
+	var webhook models.Webhook
+	err := r.db.WithContext(ctx).First(&webhook, "webhook_id = ?", webhookID).Error
+	if err != nil {
+		return nil, err
+	}
+	return &webhook, nil
+}
+
+func (r *webhookRepository) GetByTeamID(ctx context.Context, teamID uuid.UUID) ([]*models.Webhook, error) {
+	var webhooks []*models.Webhook
+	err := r.db.WithContext(ctx).Where("team_id = ?", teamID).Order("created_at").Find(&webhooks).Error
+	return webhooks, err
+}
+
+func (r *webhookRepository) GetByTeamIDs(ctx context.Context, teamIDs []uuid.UUID) ([]*models.Webhook, error) {
+	var webhooks []*models.Webhook
+	if len(teamIDs) == 0 {
+		return webhooks, nil
+	}
+	err := r.db.WithContext(ctx).Where("team_id IN ?", teamIDs).Order("created_at").Find(&webhooks).Error
+	return webhooks, err
+}
+
+func (r *webhookRepository) GetActiveByTeamIDs(ctx context.Context, teamIDs []uuid.UUID) ([]*models.Webhook, error) {
+	var webhooks []*models.Webhook
+	if len(teamIDs) == 0 {
+		return webhooks, nil
+	}
+	err := r.db.WithContext(ctx).Where("team_id IN ? AND active = ?", teamIDs, true).Find(&webhooks).Error
+	return webhooks, err
+}
+
+func (r *webhookRepository) Update(ctx context.Context, webhook *models.Webhook) error {
+	return r.db.WithContext(ctx).Save(webhook).Error
+}
+
+func (r *webhookRepository) Delete(ctx context.Context, webhookID uuid.UUID) error {
+	return r.db.WithContext(ctx).Delete(&models.Webhook{}, "webhook_id = ?", webhookID).Error
+}
+
+func (r *webhookRepository) CreateDelivery(ctx context.Context, delivery *models.WebhookDelivery) (bool, error) {
+	// Redelivered events hit the unique webhook and event ID and are skipped
+	created := r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "webhook_id"}, {Name: "event_id"}},
+		DoNothing: true,
+	}).Create(delivery)
+	return created.RowsAffected > 0, created.Error
+}
+
+func (r *webhookRepository) GetDelivery(ctx context.Context, deliveryID uuid.UUID) (*models.WebhookDelivery, error) {
+	var delivery models.WebhookDelivery
+	// Read from the primary: the delivery was just created or updated
+	err := r.db.WithContext(ctx).Clauses(dbresolver.Write).First(&delivery, "delivery_id = ?", deliveryID).Error
+	if err != nil {
+		return nil, err
+	}
+	return &delivery, nil
+}
+
+func (r *webhookRepository) UpdateDelivery(ctx context.Context, delivery *models.WebhookDelivery) error {
+	return r.db.WithContext(ctx).Save(delivery).Error
+}
+
+func (r *webhookRepository) GetDeliveries(ctx context.Context, webhookID uuid.UUID, limit int) ([]*models.WebhookDelivery, error) {
+	var deliveries []*models.WebhookDelivery
+	err := r.db.WithContext(ctx).Where("webhook_id = ?", webhookID).
+		Order("created_at DESC").
+		Limit(limit).
+		Find(&deliveries).Error
+	return deliveries, err
+}
+
+func (r *webhookRepository) GetDueDeliveries(ctx context.Context, now time.Time, limit int) ([]*models.WebhookDelivery, error) {
+	var deliveries []*models.WebhookDelivery
+	// Read from the primary, or a lagging replica would return deliveries
+	// that were already made
+	err := r.db.WithContext(ctx).Clauses(dbresolver.Write).Where("status = ? AND (next_attempt_at IS NULL OR next_attempt_at <= ?)", models.WebhookDeliveryPending, now).
+		Order("next_attempt_at").
+		Limit(limit).
+		Find(&deliveries).Error
+	return deliveries, err
+}
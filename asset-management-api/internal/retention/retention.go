@@ -0,0 +1,131 @@
+// Package retention maintains the monthly partitions of the audit and event
+// tables: it creates them ahead of time and drops those past retention.
+package retention
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"asset-management-api/internal/repository/interfaces"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	partitionsDroppedTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "retention_partitions_dropped_total",
+			Help: "Total number of monthly partitions dropped past retention by table",
+		},
+		[]string{"table"},
+	)
+
+	partitionMaintenanceFailuresTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "retention_partition_failures_total",
+			Help: "Total number of failed partition creations and drops by table",
+		},
+		[]string{"table"},
+	)
+)
+
+// Policy is how many whole months of a partitioned table are kept besides
+// the current one; with zero months nothing is dropped
+type Policy struct {
+	Table  string
+	Months int
+}
+
+// Config lists the partitioned tables and how often they are maintained
+type Config struct {
+	Interval time.Duration
+	Policies []Policy
+}
+
+// Job creates the partitions of the current and next month of every table,
+// so rows never fall back to the default partition, and drops the months
+// past the table's policy. Dropping a partition removes its rows at once,
+// without the cost of deleting them.
+type Job struct {
+	config Config
+	repo   interfaces.PartitionRepository
+
+	stop chan struct{}
+	wg   sync.WaitGroup
+	once sync.Once
+}
+
+// NewJob creates a new retention job
+func NewJob(config Config, repo interfaces.PartitionRepository) *Job {
+	if config.Interval <= 0 {
+		config.Interval = 24 * time.Hour
+	}
+
+	return &Job{
+		config: config,
+		repo:   repo,
+		stop:   make(chan struct{}),
+	}
+}
+
+// Start maintains the partitions now and then every interval
+func (j *Job) Start() {
+	j.wg.Add(1)
+	go j.run()
+}
+
+func (j *Job) run() {
+	defer j.wg.Done()
+
+	ticker := time.NewTicker(j.config.Interval)
+	defer ticker.Stop()
+
+	ctx := context.Background()
+	for {
+		j.maintain(ctx, time.Now().UTC())
+
+		select {
+		case <-ticker.C:
+		case <-j.stop:
+			return
+		}
+	}
+}
+
+func (j *Job) maintain(ctx context.Context, now time.Time) {
+	month := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	for _, policy := range j.config.Policies {
+		for _, partition := range []time.Time{month, month.AddDate(0, 1, 0)} {
+			if err := j.repo.EnsurePartition(ctx, policy.Table, partition); err != nil {
+				partitionMaintenanceFailuresTotal.WithLabelValues(policy.Table).Inc()
+				log.Printf("Failed to create %s partition for %s: %v", policy.Table, partition.Format("2006-01"), err)
+			}
+		}
+
+		if policy.Months <= 0 {
+			continue
+		}
+		dropped, err := j.repo.DropPartitionsBefore(ctx, policy.Table, month.AddDate(0, -policy.Months, 0))
+		if err != nil {
+			partitionMaintenanceFailuresTotal.WithLabelValues(policy.Table).Inc()
+			log.Printf("Failed to drop expired %s partitions: %v", policy.Table, err)
+			continue
+		}
+		if len(dropped) > 0 {
+			partitionsDroppedTotal.WithLabelValues(policy.Table).Add(float64(len(dropped)))
+			log.Printf("Dropped %d %s partitions past %d months of retention: %v", len(dropped), policy.Table, policy.Months, dropped)
+		}
+	}
+}
+
+// Close stops the job, waiting for a run in progress to finish
+func (j *Job) Close() error {
+	j.once.Do(func() {
+		close(j.stop)
+	})
+	j.wg.Wait()
+	return nil
+}
@@ -0,0 +1,33 @@
+// Package secrets implements secret providers over the HTTP APIs of the
+// secret stores, for resolving secret references in the configuration.
+package secrets
+
+import "encoding/json"
+
+// maxSecretSize caps the responses read from a secret store
+const maxSecretSize = 1 << 20
+
+// fieldsOf returns the fields of a secret, keeping strings as they are and
+// other JSON values in their JSON form
+func fieldsOf(data map[string]json.RawMessage) map[string]string {
+	fields := make(map[string]string, len(data))
+	for name, raw := range data {
+		var s string
+		if err := json.Unmarshal(raw, &s); err == nil {
+			fields[name] = s
+			continue
+		}
+		fields[name] = string(raw)
+	}
+	return fields
+}
+
+// parseSecretString returns the fields of a secret stored as a string: those
+// of a JSON object, or else the string itself as the field named ""
+func parseSecretString(value string) map[string]string {
+	var data map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(value), &data); err != nil || data == nil {
+		return map[string]string{"": value}
+	}
+	return fieldsOf(data)
+}
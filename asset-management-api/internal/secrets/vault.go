@@ -0,0 +1,84 @@
+package secrets
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"asset-management-api/pkg/secrets"
+)
+
+// VaultConfig describes the Vault server and the token to read secrets with
+type VaultConfig struct {
+	Address   string
+	Token     string
+	Namespace string // Vault Enterprise namespace, if any
+	Timeout   time.Duration
+}
+
+// VaultProvider reads secrets from HashiCorp Vault. Secrets are named by
+// their API path, e.g. "secret/data/asset-api" for a KV version 2 secret or
+// "database/creds/asset-api" for dynamic database credentials.
+type VaultProvider struct {
+	config VaultConfig
+	client *http.Client
+}
+
+var _ secrets.Provider = (*VaultProvider)(nil)
+
+// NewVaultProvider creates a Vault secret provider
+func NewVaultProvider(config VaultConfig) *VaultProvider {
+	return &VaultProvider{
+		config: config,
+		client: &http.Client{Timeout: config.Timeout},
+	}
+}
+
+func (p *VaultProvider) Get(ctx context.Context, name string) (map[string]string, error) {
+	url := strings.TrimSuffix(p.config.Address, "/") + "/v1/" + strings.TrimPrefix(name, "/")
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Vault request: %w", err)
+	}
+	req.Header.Set("X-Vault-Token", p.config.Token)
+	if p.config.Namespace != "" {
+		req.Header.Set("X-Vault-Namespace", p.config.Namespace)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call Vault: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxSecretSize))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read Vault response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("vault returned %s", resp.Status)
+	}
+
+	var secret struct {
+		Data map[string]json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal(body, &secret); err != nil {
+		return nil, fmt.Errorf("failed to decode Vault response: %w", err)
+	}
+
+	// KV version 2 nests the secret's fields next to its metadata
+	data := secret.Data
+	if nested, ok := data["data"]; ok {
+		if _, versioned := data["metadata"]; versioned {
+			data = nil
+			if err := json.Unmarshal(nested, &data); err != nil {
+				return nil, fmt.Errorf("failed to decode Vault secret: %w", err)
+			}
+		}
+	}
+	return fieldsOf(data), nil
+}
@@ -0,0 +1,126 @@
+// Package seed fills a database with the users, teams, folders, notes and
+// shares described by a fixture file.
+package seed
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Fixture is the data to seed. Users are referred to by their email
+// address throughout.
+type Fixture struct {
+	Users   []User   `yaml:"users"`
+	Teams   []Team   `yaml:"teams"`
+	Folders []Folder `yaml:"folders"`
+}
+
+type User struct {
+	Username string `yaml:"username"`
+	Email    string `yaml:"email"`
+	Password string `yaml:"password"`
+	Role     string `yaml:"role"`
+}
+
+type Team struct {
+	Name     string   `yaml:"name"`
+	Managers []string `yaml:"managers"`
+	Members  []string `yaml:"members"`
+}
+
+type Folder struct {
+	Name        string  `yaml:"name"`
+	Description string  `yaml:"description"`
+	Owner       string  `yaml:"owner"`
+	Shares      []Share `yaml:"shares"`
+	Notes       []Note  `yaml:"notes"`
+}
+
+// Note is a note of a folder, owned by the folder's owner
+type Note struct {
+	Title  string  `yaml:"title"`
+	Body   string  `yaml:"body"`
+	Shares []Share `yaml:"shares"`
+}
+
+type Share struct {
+	User   string `yaml:"user"`
+	Access string `yaml:"access"`
+}
+
+// LoadFixture reads and checks the YAML fixture file at path. Unknown keys
+// are rejected, as they are most likely misspelt.
+func LoadFixture(path string) (*Fixture, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read fixture file: %w", err)
+	}
+
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	var fixture Fixture
+	if err := decoder.Decode(&fixture); err != nil {
+		return nil, fmt.Errorf("failed to parse fixture file %s: %w", path, err)
+	}
+	if err := fixture.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid fixture file %s: %w", path, err)
+	}
+	return &fixture, nil
+}
+
+// Validate reports every problem found in the fixture; users it refers to
+// must be declared in it
+func (f *Fixture) Validate() error {
+	var errs []error
+	check := func(ok bool, format string, args ...interface{}) {
+		if !ok {
+			errs = append(errs, fmt.Errorf(format, args...))
+		}
+	}
+
+	users := make(map[string]bool, len(f.Users))
+	for i, user := range f.Users {
+		check(user.Email != "", "users[%d]: email must not be empty", i)
+		check(!users[user.Email], "users[%d]: duplicate email %q", i, user.Email)
+		check(user.Username != "", "users[%d]: username must not be empty", i)
+		check(user.Password != "", "users[%d]: password must not be empty", i)
+		check(user.Role == "manager" || user.Role == "member", "users[%d]: role must be manager or member, got %q", i, user.Role)
+		users[user.Email] = true
+	}
+	declared := func(path, email string) {
+		check(users[email], "%s: unknown user %q", path, email)
+	}
+	shares := func(path string, shares []Share) {
+		for i, share := range shares {
+			declared(fmt.Sprintf("%s.shares[%d]", path, i), share.User)
+			check(share.Access == "read" || share.Access == "write", "%s.shares[%d]: access must be read or write, got %q", path, i, share.Access)
+		}
+	}
+
+	for i, team := range f.Teams {
+		check(team.Name != "", "teams[%d]: name must not be empty", i)
+		check(len(team.Managers) > 0, "teams[%d]: must have a manager", i)
+		for j, email := range team.Managers {
+			declared(fmt.Sprintf("teams[%d].managers[%d]", i, j), email)
+		}
+		for j, email := range team.Members {
+			declared(fmt.Sprintf("teams[%d].members[%d]", i, j), email)
+		}
+	}
+	for i, folder := range f.Folders {
+		path := fmt.Sprintf("folders[%d]", i)
+		check(folder.Name != "", "%s: name must not be empty", path)
+		declared(path+".owner", folder.Owner)
+		shares(path, folder.Shares)
+		for j, note := range folder.Notes {
+			notePath := fmt.Sprintf("%s.notes[%d]", path, j)
+			check(note.Title != "", "%s: title must not be empty", notePath)
+			shares(notePath, note.Shares)
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,164 @@
+package seed
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"asset-management-api/internal/models"
+	"asset-management-api/internal/repository/interfaces"
+
+	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+// Result counts the rows Seed created
+type Result struct {
+	Users         int
+	ExistingUsers int
+	Teams         int
+	Folders       int
+	Notes         int
+	Shares        int
+}
+
+// Seed creates the data of the fixture in one transaction, so a failed run
+// leaves nothing behind. Users whose email address is taken are reused, so
+// fixtures may refer to existing accounts; everything else is created anew
+// on every run. No events are published for the seeded data.
+func Seed(ctx context.Context, txManager interfaces.TxManager, fixture *Fixture) (*Result, error) {
+	result := &Result{}
+	err := txManager.WithinTransaction(ctx, func(repos interfaces.Repositories) error {
+		// Start over when the transaction is retried
+		*result = Result{}
+
+		users, err := seedUsers(ctx, repos.Users, fixture.Users, result)
+		if err != nil {
+			return err
+		}
+		if err := seedTeams(ctx, repos.Teams, fixture.Teams, users, result); err != nil {
+			return err
+		}
+		return seedFolders(ctx, repos, fixture.Folders, users, result)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// seedUsers creates the users missing from the database and returns the ID
+// of every user of the fixture by email address
+func seedUsers(ctx context.Context, userRepo interfaces.UserRepository, fixtureUsers []User, result *Result) (map[string]uuid.UUID, error) {
+	users := make(map[string]uuid.UUID, len(fixtureUsers))
+	for _, fixtureUser := range fixtureUsers {
+		existing, err := userRepo.GetByEmail(ctx, fixtureUser.Email)
+		if err == nil {
+			users[fixtureUser.Email] = existing.UserID
+			result.ExistingUsers++
+			continue
+		}
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("failed to look up user %s: %w", fixtureUser.Email, err)
+		}
+
+		hash, err := bcrypt.GenerateFromPassword([]byte(fixtureUser.Password), bcrypt.DefaultCost)
+		if err != nil {
+			return nil, fmt.Errorf("failed to hash password of user %s: %w", fixtureUser.Email, err)
+		}
+		user := &models.User{
+			Username:     fixtureUser.Username,
+			Email:        fixtureUser.Email,
+			PasswordHash: string(hash),
+			Role:         fixtureUser.Role,
+		}
+		if err := userRepo.Create(ctx, user); err != nil {
+			return nil, fmt.Errorf("failed to create user %s: %w", fixtureUser.Email, err)
+		}
+		users[fixtureUser.Email] = user.UserID
+		result.Users++
+	}
+	return users, nil
+}
+
+// seedTeams creates the teams, each created by its first manager
+func seedTeams(ctx context.Context, teamRepo interfaces.TeamRepository, fixtureTeams []Team, users map[string]uuid.UUID, result *Result) error {
+	for _, fixtureTeam := range fixtureTeams {
+		team := &models.Team{
+			TeamName:  fixtureTeam.Name,
+			CreatedBy: users[fixtureTeam.Managers[0]],
+		}
+		if err := teamRepo.Create(ctx, team); err != nil {
+			return fmt.Errorf("failed to create team %s: %w", fixtureTeam.Name, err)
+		}
+		for _, email := range fixtureTeam.Managers {
+			if err := teamRepo.AddManager(ctx, team.TeamID, users[email]); err != nil {
+				return fmt.Errorf("failed to add manager %s to team %s: %w", email, fixtureTeam.Name, err)
+			}
+		}
+		for _, email := range fixtureTeam.Members {
+			if err := teamRepo.AddMember(ctx, team.TeamID, users[email]); err != nil {
+				return fmt.Errorf("failed to add member %s to team %s: %w", email, fixtureTeam.Name, err)
+			}
+		}
+		result.Teams++
+	}
+	return nil
+}
+
+// seedFolders creates the folders with their notes and the shares of both
+func seedFolders(ctx context.Context, repos interfaces.Repositories, fixtureFolders []Folder, users map[string]uuid.UUID, result *Result) error {
+	for _, fixtureFolder := range fixtureFolders {
+		ownerID := users[fixtureFolder.Owner]
+		folder := &models.Folder{
+			Name:        fixtureFolder.Name,
+			Description: fixtureFolder.Description,
+			OwnerID:     ownerID,
+		}
+		if err := repos.Folders.Create(ctx, folder); err != nil {
+			return fmt.Errorf("failed to create folder %s: %w", fixtureFolder.Name, err)
+		}
+		result.Folders++
+
+		for _, share := range fixtureFolder.Shares {
+			err := repos.Shares.ShareFolder(ctx, &models.FolderShare{
+				FolderID:         folder.FolderID,
+				SharedWithUserID: users[share.User],
+				AccessLevel:      share.Access,
+				SharedBy:         ownerID,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to share folder %s with %s: %w", fixtureFolder.Name, share.User, err)
+			}
+			result.Shares++
+		}
+
+		for _, fixtureNote := range fixtureFolder.Notes {
+			note := &models.Note{
+				Title:    fixtureNote.Title,
+				Body:     fixtureNote.Body,
+				FolderID: folder.FolderID,
+				OwnerID:  ownerID,
+			}
+			if err := repos.Notes.Create(ctx, note); err != nil {
+				return fmt.Errorf("failed to create note %s: %w", fixtureNote.Title, err)
+			}
+			result.Notes++
+
+			for _, share := range fixtureNote.Shares {
+				err := repos.Shares.ShareNote(ctx, &models.NoteShare{
+					NoteID:           note.NoteID,
+					SharedWithUserID: users[share.User],
+					AccessLevel:      share.Access,
+					SharedBy:         ownerID,
+				})
+				if err != nil {
+					return fmt.Errorf("failed to share note %s with %s: %w", fixtureNote.Title, share.User, err)
+				}
+				result.Shares++
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,162 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"asset-management-api/internal/repository/interfaces"
+	"asset-management-api/pkg/cache"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ACLLoader resolves asset ACLs through the cache. On a miss the full ACL is
+// rebuilt from the asset owner and share records and written back, so a
+// partial hash is never treated as authoritative. The owner is recorded with
+// cache.AccessLevelOwner, which also keeps the ACL of an unshared asset
+// non-empty and therefore cacheable.
+type ACLLoader struct {
+	folderRepo   interfaces.FolderRepository
+	noteRepo     interfaces.NoteRepository
+	shareRepo    interfaces.ShareRepository
+	cacheService cache.CacheService
+}
+
+// NewACLLoader creates a new ACL loader
+func NewACLLoader(folderRepo interfaces.FolderRepository, noteRepo interfaces.NoteRepository, shareRepo interfaces.ShareRepository, cacheService cache.CacheService) *ACLLoader {
+	return &ACLLoader{
+		folderRepo:   folderRepo,
+		noteRepo:     noteRepo,
+		shareRepo:    shareRepo,
+		cacheService: cacheService,
+	}
+}
+
+// FolderACL returns the user ID -> access level map for a folder
+func (l *ACLLoader) FolderACL(ctx context.Context, folderID uuid.UUID) (map[string]string, error) {
+	if acl := l.cachedACL(ctx, folderID); acl != nil {
+		return acl, nil
+	}
+
+	acl, err := l.loadFolderACL(ctx, folderID)
+	if err != nil {
+		return nil, err
+	}
+
+	l.storeACL(ctx, folderID, acl)
+	return acl, nil
+}
+
+// NoteACL returns the user ID -> access level map for a note
+func (l *ACLLoader) NoteACL(ctx context.Context, noteID uuid.UUID) (map[string]string, error) {
+	if acl := l.cachedACL(ctx, noteID); acl != nil {
+		return acl, nil
+	}
+
+	acl, err := l.loadNoteACL(ctx, noteID)
+	if err != nil {
+		return nil, err
+	}
+
+	l.storeACL(ctx, noteID, acl)
+	return acl, nil
+}
+
+// AssetACL returns the ACL for an asset whose type is unknown, trying the
+// folders table before the notes table
+func (l *ACLLoader) AssetACL(ctx context.Context, assetID uuid.UUID) (map[string]string, error) {
+	if acl := l.cachedACL(ctx, assetID); acl != nil {
+		return acl, nil
+	}
+
+	acl, err := l.loadFolderACL(ctx, assetID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		acl, err = l.loadNoteACL(ctx, assetID)
+	}
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return map[string]string{}, nil // Unknown asset: nobody has access
+		}
+		return nil, err
+	}
+
+	l.storeACL(ctx, assetID, acl)
+	return acl, nil
+}
+
+// FolderAccessLevel returns the access level shared with the user, or empty if none
+func (l *ACLLoader) FolderAccessLevel(ctx context.Context, folderID, userID uuid.UUID) (string, error) {
+	acl, err := l.FolderACL(ctx, folderID)
+	if err != nil {
+		return "", err
+	}
+	return acl[userID.String()], nil
+}
+
+// NoteAccessLevel returns the access level shared with the user, or empty if none
+func (l *ACLLoader) NoteAccessLevel(ctx context.Context, noteID, userID uuid.UUID) (string, error) {
+	acl, err := l.NoteACL(ctx, noteID)
+	if err != nil {
+		return "", err
+	}
+	return acl[userID.String()], nil
+}
+
+func (l *ACLLoader) cachedACL(ctx context.Context, assetID uuid.UUID) map[string]string {
+	acl, err := l.cacheService.GetAssetACL(ctx, assetID)
+	if err != nil {
+		log.Printf("Failed to get asset ACL from cache for %s: %v", assetID, err)
+		return nil
+	}
+	if acl != nil {
+		log.Printf("Cache HIT for asset %s ACL", assetID)
+	}
+	return acl
+}
+
+func (l *ACLLoader) storeACL(ctx context.Context, assetID uuid.UUID, acl map[string]string) {
+	log.Printf("Cache MISS for asset %s ACL, loaded %d entries from database", assetID, len(acl))
+	if err := l.cacheService.CacheAssetACL(ctx, assetID, acl); err != nil {
+		log.Printf("Failed to cache asset ACL for %s: %v", assetID, err)
+	}
+}
+
+func (l *ACLLoader) loadFolderACL(ctx context.Context, folderID uuid.UUID) (map[string]string, error) {
+	folder, err := l.folderRepo.GetByID(ctx, folderID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load folder: %w", err)
+	}
+
+	shares, err := l.shareRepo.GetFolderShares(ctx, folderID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load folder shares: %w", err)
+	}
+
+	acl := make(map[string]string, len(shares)+1)
+	for _, share := range shares {
+		acl[share.SharedWithUserID.String()] = share.AccessLevel
+	}
+	acl[folder.OwnerID.String()] = cache.AccessLevelOwner
+	return acl, nil
+}
+
+func (l *ACLLoader) loadNoteACL(ctx context.Context, noteID uuid.UUID) (map[string]string, error) {
+	note, err := l.noteRepo.GetByID(ctx, noteID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load note: %w", err)
+	}
+
+	shares, err := l.shareRepo.GetNoteShares(ctx, noteID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load note shares: %w", err)
+	}
+
+	acl := make(map[string]string, len(shares)+1)
+	for _, share := range shares {
+		acl[share.SharedWithUserID.String()] = share.AccessLevel
+	}
+	acl[note.OwnerID.String()] = cache.AccessLevelOwner
+	return acl, nil
+}
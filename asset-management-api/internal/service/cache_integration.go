@@ -2,41 +2,51 @@ package service
 
 import (
 	"context"
+	"fmt"
 	"log"
 
 	"asset-management-api/internal/models"
+	"asset-management-api/internal/repository/interfaces"
+	serviceInterfaces "asset-management-api/internal/service/interfaces"
 	"asset-management-api/pkg/cache"
 	"github.com/google/uuid"
 )
 
 // CacheIntegratedFolderService wraps the folder service with caching capabilities
 type CacheIntegratedFolderService struct {
-	folderService FolderService
+	folderService serviceInterfaces.FolderService
+	folderRepo    interfaces.FolderRepository
 	cacheService  cache.CacheService
+	aclLoader     *ACLLoader
 }
 
 // NewCacheIntegratedFolderService creates a new cache-integrated folder service
-func NewCacheIntegratedFolderService(folderService FolderService, cacheService cache.CacheService) *CacheIntegratedFolderService {
+func NewCacheIntegratedFolderService(folderService serviceInterfaces.FolderService, folderRepo interfaces.FolderRepository, cacheService cache.CacheService, aclLoader *ACLLoader) *CacheIntegratedFolderService {
 	return &CacheIntegratedFolderService{
 		folderService: folderService,
+		folderRepo:    folderRepo,
 		cacheService:  cacheService,
+		aclLoader:     aclLoader,
 	}
 }
 
 // GetFolder attempts to get folder from cache first, then falls back to database
-func (s *CacheIntegratedFolderService) GetFolder(folderID, userID uuid.UUID) (*models.Folder, error) {
-	ctx := context.Background()
+func (s *CacheIntegratedFolderService) GetFolder(ctx context.Context, folderID, userID uuid.UUID) (*models.Folder, error) {
 	
 	// Try to get from cache first
 	if cachedFolder, err := s.cacheService.GetFolderMetadata(ctx, folderID); err == nil && cachedFolder != nil {
-		log.Printf("Cache HIT for folder %s", folderID)
-		return cachedFolder, nil
+		if s.canView(ctx, cachedFolder, userID) {
+			log.Printf("Cache HIT for folder %s", folderID)
+			return cachedFolder, nil
+		}
+		// Let the folder service produce the access error
+		return s.folderService.GetFolder(ctx, folderID, userID)
 	}
 	
 	log.Printf("Cache MISS for folder %s, fetching from database", folderID)
 	
 	// Cache miss, get from database
-	folder, err := s.folderService.GetFolder(folderID, userID)
+	folder, err := s.folderService.GetFolder(ctx, folderID, userID)
 	if err != nil {
 		return nil, err
 	}
@@ -49,15 +59,23 @@ func (s *CacheIntegratedFolderService) GetFolder(folderID, userID uuid.UUID) (*m
 	return folder, nil
 }
 
+// canView checks a cached folder against its owner and cached ACL
+func (s *CacheIntegratedFolderService) canView(ctx context.Context, folder *models.Folder, userID uuid.UUID) bool {
+	if folder.OwnerID == userID {
+		return true
+	}
+	accessLevel, err := s.aclLoader.FolderAccessLevel(ctx, folder.FolderID, userID)
+	return err == nil && accessLevel != ""
+}
+
 // CreateFolder creates folder and caches it
-func (s *CacheIntegratedFolderService) CreateFolder(userID uuid.UUID, name, description string) (*models.Folder, error) {
-	folder, err := s.folderService.CreateFolder(userID, name, description)
+func (s *CacheIntegratedFolderService) CreateFolder(ctx context.Context, userID uuid.UUID, name, description string) (*models.Folder, error) {
+	folder, err := s.folderService.CreateFolder(ctx, userID, name, description)
 	if err != nil {
 		return nil, err
 	}
 	
 	// Cache the newly created folder
-	ctx := context.Background()
 	if err := s.cacheService.CacheFolderMetadata(ctx, folder); err != nil {
 		log.Printf("Failed to cache newly created folder %s: %v", folder.FolderID, err)
 	}
@@ -66,15 +84,14 @@ func (s *CacheIntegratedFolderService) CreateFolder(userID uuid.UUID, name, desc
 }
 
 // UpdateFolder updates folder and invalidates cache
-func (s *CacheIntegratedFolderService) UpdateFolder(folderID, userID uuid.UUID, name, description string) (*models.Folder, error) {
-	folder, err := s.folderService.UpdateFolder(folderID, userID, name, description)
+func (s *CacheIntegratedFolderService) UpdateFolder(ctx context.Context, folderID, userID uuid.UUID, name, description string) (*models.Folder, error) {
+	folder, err := s.folderService.UpdateFolder(ctx, folderID, userID, name, description)
 	if err != nil {
 		return nil, err
 	}
 	
 	// Cache invalidation is handled by Kafka event handler
 	// but we can also update the cache directly for immediate consistency
-	ctx := context.Background()
 	if err := s.cacheService.CacheFolderMetadata(ctx, folder); err != nil {
 		log.Printf("Failed to cache updated folder %s: %v", folder.FolderID, err)
 	}
@@ -83,51 +100,123 @@ func (s *CacheIntegratedFolderService) UpdateFolder(folderID, userID uuid.UUID,
 }
 
 // DeleteFolder deletes folder and invalidates cache
-func (s *CacheIntegratedFolderService) DeleteFolder(folderID, userID uuid.UUID) error {
-	err := s.folderService.DeleteFolder(folderID, userID)
+func (s *CacheIntegratedFolderService) DeleteFolder(ctx context.Context, folderID, userID uuid.UUID) error {
+	// The folder's notes are deleted with it, without events of their own
+	var noteIDs []uuid.UUID
+	if folder, err := s.folderRepo.GetByID(ctx, folderID); err == nil {
+		for _, note := range folder.Notes {
+			noteIDs = append(noteIDs, note.NoteID)
+		}
+	}
+
+	err := s.folderService.DeleteFolder(ctx, folderID, userID)
 	if err != nil {
 		return err
 	}
 	
-	// Cache invalidation is handled by Kafka event handler
+	// Cache invalidation of the folder is handled by Kafka event handler
+	for _, noteID := range noteIDs {
+		invalidateDeletedNote(ctx, s.cacheService, noteID)
+	}
 	return nil
 }
 
-// GetUserFolders gets user folders with caching support
-func (s *CacheIntegratedFolderService) GetUserFolders(userID uuid.UUID) ([]*models.Folder, error) {
-	// For list operations, we typically don't cache the entire list
-	// Instead, individual folder metadata will be cached when accessed
-	return s.folderService.GetUserFolders(userID)
+// GetUserFolders lists the user's folder IDs and hydrates them from cache
+func (s *CacheIntegratedFolderService) GetUserFolders(ctx context.Context, userID uuid.UUID) ([]*models.Folder, error) {
+	ownedIDs, err := s.folderRepo.GetIDsByOwnerID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get owned folders: %w", err)
+	}
+
+	sharedIDs, err := s.folderRepo.GetSharedFolderIDs(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get shared folders: %w", err)
+	}
+
+	return s.hydrateFolders(ctx, append(ownedIDs, sharedIDs...))
+}
+
+// ListUserFolders lists the user's folders a page at a time; pages are not cached
+func (s *CacheIntegratedFolderService) ListUserFolders(ctx context.Context, userID uuid.UUID, req models.PageRequest) (*models.Page[*models.Folder], error) {
+	return s.folderService.ListUserFolders(ctx, userID, req)
+}
+
+// hydrateFolders serves folders from the cache in one round trip and loads
+// all misses with a single database query, preserving the order of folderIDs
+func (s *CacheIntegratedFolderService) hydrateFolders(ctx context.Context, folderIDs []uuid.UUID) ([]*models.Folder, error) {
+	found, err := s.cacheService.GetFolderMetadataBatch(ctx, folderIDs)
+	if err != nil {
+		log.Printf("Batch cache lookup failed for %d folders: %v", len(folderIDs), err)
+		found = make(map[uuid.UUID]*models.Folder, len(folderIDs))
+	}
+	hits := len(found)
+
+	var missIDs []uuid.UUID
+	for _, folderID := range folderIDs {
+		if _, ok := found[folderID]; !ok {
+			missIDs = append(missIDs, folderID)
+		}
+	}
+
+	if len(missIDs) > 0 {
+		folders, err := s.folderRepo.GetByIDs(ctx, missIDs)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get folders: %w", err)
+		}
+		for _, folder := range folders {
+			found[folder.FolderID] = folder
+			if err := s.cacheService.CacheFolderMetadata(ctx, folder); err != nil {
+				log.Printf("Failed to cache folder metadata for %s: %v", folder.FolderID, err)
+			}
+		}
+	}
+
+	log.Printf("Hydrated %d folders (%d cache hits, %d misses)", len(folderIDs), hits, len(missIDs))
+
+	result := make([]*models.Folder, 0, len(folderIDs))
+	for _, folderID := range folderIDs {
+		if folder, ok := found[folderID]; ok {
+			result = append(result, folder)
+		}
+	}
+	return result, nil
 }
 
 // CacheIntegratedNoteService wraps the note service with caching capabilities
 type CacheIntegratedNoteService struct {
-	noteService  NoteService
+	noteService  serviceInterfaces.NoteService
+	noteRepo     interfaces.NoteRepository
 	cacheService cache.CacheService
+	aclLoader    *ACLLoader
 }
 
 // NewCacheIntegratedNoteService creates a new cache-integrated note service
-func NewCacheIntegratedNoteService(noteService NoteService, cacheService cache.CacheService) *CacheIntegratedNoteService {
+func NewCacheIntegratedNoteService(noteService serviceInterfaces.NoteService, noteRepo interfaces.NoteRepository, cacheService cache.CacheService, aclLoader *ACLLoader) *CacheIntegratedNoteService {
 	return &CacheIntegratedNoteService{
 		noteService:  noteService,
+		noteRepo:     noteRepo,
 		cacheService: cacheService,
+		aclLoader:    aclLoader,
 	}
 }
 
 // GetNote attempts to get note from cache first, then falls back to database
-func (s *CacheIntegratedNoteService) GetNote(noteID, userID uuid.UUID) (*models.Note, error) {
-	ctx := context.Background()
+func (s *CacheIntegratedNoteService) GetNote(ctx context.Context, noteID, userID uuid.UUID) (*models.Note, error) {
 	
 	// Try to get from cache first
 	if cachedNote, err := s.cacheService.GetNoteMetadata(ctx, noteID); err == nil && cachedNote != nil {
-		log.Printf("Cache HIT for note %s", noteID)
-		return cachedNote, nil
+		if s.canView(ctx, cachedNote, userID) {
+			log.Printf("Cache HIT for note %s", noteID)
+			return cachedNote, nil
+		}
+		// Let the note service produce the access error
+		return s.noteService.GetNote(ctx, noteID, userID)
 	}
 	
 	log.Printf("Cache MISS for note %s, fetching from database", noteID)
 	
 	// Cache miss, get from database
-	note, err := s.noteService.GetNote(noteID, userID)
+	note, err := s.noteService.GetNote(ctx, noteID, userID)
 	if err != nil {
 		return nil, err
 	}
@@ -140,15 +229,27 @@ func (s *CacheIntegratedNoteService) GetNote(noteID, userID uuid.UUID) (*models.
 	return note, nil
 }
 
+// canView checks a cached note against its owner and the cached ACLs of the
+// note and its parent folder
+func (s *CacheIntegratedNoteService) canView(ctx context.Context, note *models.Note, userID uuid.UUID) bool {
+	if note.OwnerID == userID {
+		return true
+	}
+	if accessLevel, err := s.aclLoader.NoteAccessLevel(ctx, note.NoteID, userID); err == nil && accessLevel != "" {
+		return true
+	}
+	accessLevel, err := s.aclLoader.FolderAccessLevel(ctx, note.FolderID, userID)
+	return err == nil && accessLevel != ""
+}
+
 // CreateNote creates note and caches it
-func (s *CacheIntegratedNoteService) CreateNote(userID, folderID uuid.UUID, title, body string) (*models.Note, error) {
-	note, err := s.noteService.CreateNote(userID, folderID, title, body)
+func (s *CacheIntegratedNoteService) CreateNote(ctx context.Context, userID, folderID uuid.UUID, title, body string) (*models.Note, error) {
+	note, err := s.noteService.CreateNote(ctx, userID, folderID, title, body)
 	if err != nil {
 		return nil, err
 	}
 	
 	// Cache the newly created note
-	ctx := context.Background()
 	if err := s.cacheService.CacheNoteMetadata(ctx, note); err != nil {
 		log.Printf("Failed to cache newly created note %s: %v", note.NoteID, err)
 	}
@@ -157,14 +258,13 @@ func (s *CacheIntegratedNoteService) CreateNote(userID, folderID uuid.UUID, titl
 }
 
 // UpdateNote updates note and refreshes cache
-func (s *CacheIntegratedNoteService) UpdateNote(noteID, userID uuid.UUID, title, body string) (*models.Note, error) {
-	note, err := s.noteService.UpdateNote(noteID, userID, title, body)
+func (s *CacheIntegratedNoteService) UpdateNote(ctx context.Context, noteID, userID uuid.UUID, title, body string) (*models.Note, error) {
+	note, err := s.noteService.UpdateNote(ctx, noteID, userID, title, body)
 	if err != nil {
 		return nil, err
 	}
 	
 	// Update cache with new data
-	ctx := context.Background()
 	if err := s.cacheService.CacheNoteMetadata(ctx, note); err != nil {
 		log.Printf("Failed to cache updated note %s: %v", note.NoteID, err)
 	}
@@ -173,35 +273,113 @@ func (s *CacheIntegratedNoteService) UpdateNote(noteID, userID uuid.UUID, title,
 }
 
 // DeleteNote deletes note and invalidates cache
-func (s *CacheIntegratedNoteService) DeleteNote(noteID, userID uuid.UUID) error {
-	err := s.noteService.DeleteNote(noteID, userID)
+func (s *CacheIntegratedNoteService) DeleteNote(ctx context.Context, noteID, userID uuid.UUID) error {
+	err := s.noteService.DeleteNote(ctx, noteID, userID)
 	if err != nil {
 		return err
 	}
 	
-	// Cache invalidation is handled by Kafka event handler
+	// Deleted notes stay in the database, so a cached copy would keep serving
+	// them until the delete event reaches the Kafka handler
+	invalidateDeletedNote(ctx, s.cacheService, noteID)
 	return nil
 }
 
+// invalidateDeletedNote drops the cached metadata and ACL of a deleted note
+func invalidateDeletedNote(ctx context.Context, cacheService cache.CacheService, noteID uuid.UUID) {
+	if err := cacheService.InvalidateNoteMetadata(ctx, noteID); err != nil {
+		log.Printf("Failed to invalidate note metadata cache for %s: %v", noteID, err)
+	}
+	if err := cacheService.InvalidateAssetACL(ctx, noteID); err != nil {
+		log.Printf("Failed to invalidate asset ACL cache for %s: %v", noteID, err)
+	}
+}
+
 // GetNotesByFolder gets notes by folder
-func (s *CacheIntegratedNoteService) GetNotesByFolder(folderID, userID uuid.UUID) ([]*models.Note, error) {
+func (s *CacheIntegratedNoteService) GetNotesByFolder(ctx context.Context, folderID, userID uuid.UUID) ([]*models.Note, error) {
 	// For list operations, we typically don't cache the entire list
-	return s.noteService.GetNotesByFolder(folderID, userID)
+	return s.noteService.GetNotesByFolder(ctx, folderID, userID)
+}
+
+// GetNotesByFolders gets the notes of several folders; lists are not cached
+func (s *CacheIntegratedNoteService) GetNotesByFolders(ctx context.Context, folderIDs []uuid.UUID, userID uuid.UUID) (map[uuid.UUID][]*models.Note, error) {
+	return s.noteService.GetNotesByFolders(ctx, folderIDs, userID)
+}
+
+// ListNotesByFolder lists notes by folder a page at a time; pages are not cached
+func (s *CacheIntegratedNoteService) ListNotesByFolder(ctx context.Context, folderID, userID uuid.UUID, req models.PageRequest) (*models.Page[*models.Note], error) {
+	return s.noteService.ListNotesByFolder(ctx, folderID, userID, req)
+}
+
+// ListUserNotes lists the user's notes a page at a time; pages are not cached
+func (s *CacheIntegratedNoteService) ListUserNotes(ctx context.Context, userID uuid.UUID, req models.PageRequest) (*models.Page[*models.Note], error) {
+	return s.noteService.ListUserNotes(ctx, userID, req)
 }
 
-// GetUserNotes gets user notes
-func (s *CacheIntegratedNoteService) GetUserNotes(userID uuid.UUID) ([]*models.Note, error) {
-	return s.noteService.GetUserNotes(userID)
+// GetUserNotes lists the user's note IDs and hydrates them from cache
+func (s *CacheIntegratedNoteService) GetUserNotes(ctx context.Context, userID uuid.UUID) ([]*models.Note, error) {
+	ownedIDs, err := s.noteRepo.GetIDsByOwnerID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get owned notes: %w", err)
+	}
+
+	sharedIDs, err := s.noteRepo.GetSharedNoteIDs(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get shared notes: %w", err)
+	}
+
+	return s.hydrateNotes(ctx, append(ownedIDs, sharedIDs...))
+}
+
+// hydrateNotes serves notes from the cache in one round trip and loads all
+// misses with a single database query, preserving the order of noteIDs
+func (s *CacheIntegratedNoteService) hydrateNotes(ctx context.Context, noteIDs []uuid.UUID) ([]*models.Note, error) {
+	found, err := s.cacheService.GetNoteMetadataBatch(ctx, noteIDs)
+	if err != nil {
+		log.Printf("Batch cache lookup failed for %d notes: %v", len(noteIDs), err)
+		found = make(map[uuid.UUID]*models.Note, len(noteIDs))
+	}
+	hits := len(found)
+
+	var missIDs []uuid.UUID
+	for _, noteID := range noteIDs {
+		if _, ok := found[noteID]; !ok {
+			missIDs = append(missIDs, noteID)
+		}
+	}
+
+	if len(missIDs) > 0 {
+		notes, err := s.noteRepo.GetByIDs(ctx, missIDs)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get notes: %w", err)
+		}
+		for _, note := range notes {
+			found[note.NoteID] = note
+			if err := s.cacheService.CacheNoteMetadata(ctx, note); err != nil {
+				log.Printf("Failed to cache note metadata for %s: %v", note.NoteID, err)
+			}
+		}
+	}
+
+	log.Printf("Hydrated %d notes (%d cache hits, %d misses)", len(noteIDs), hits, len(missIDs))
+
+	result := make([]*models.Note, 0, len(noteIDs))
+	for _, noteID := range noteIDs {
+		if note, ok := found[noteID]; ok {
+			result = append(result, note)
+		}
+	}
+	return result, nil
 }
 
 // CacheIntegratedTeamService wraps the team service with caching capabilities
 type CacheIntegratedTeamService struct {
-	teamService  TeamService
+	teamService  serviceInterfaces.TeamService
 	cacheService cache.CacheService
 }
 
 // NewCacheIntegratedTeamService creates a new cache-integrated team service
-func NewCacheIntegratedTeamService(teamService TeamService, cacheService cache.CacheService) *CacheIntegratedTeamService {
+func NewCacheIntegratedTeamService(teamService serviceInterfaces.TeamService, cacheService cache.CacheService) *CacheIntegratedTeamService {
 	return &CacheIntegratedTeamService{
 		teamService:  teamService,
 		cacheService: cacheService,
@@ -209,8 +387,8 @@ func NewCacheIntegratedTeamService(teamService TeamService, cacheService cache.C
 }
 
 // CreateTeam creates team and caches members
-func (s *CacheIntegratedTeamService) CreateTeam(creatorID uuid.UUID, teamName string, managers []TeamMemberInfo, members []TeamMemberInfo) (*models.Team, error) {
-	team, err := s.teamService.CreateTeam(creatorID, teamName, managers, members)
+func (s *CacheIntegratedTeamService) CreateTeam(ctx context.Context, creatorID uuid.UUID, teamName string, managers []serviceInterfaces.TeamMemberInfo, members []serviceInterfaces.TeamMemberInfo) (*models.Team, error) {
+	team, err := s.teamService.CreateTeam(ctx, creatorID, teamName, managers, members)
 	if err != nil {
 		return nil, err
 	}
@@ -220,8 +398,8 @@ func (s *CacheIntegratedTeamService) CreateTeam(creatorID uuid.UUID, teamName st
 }
 
 // AddMember adds member to team and updates cache
-func (s *CacheIntegratedTeamService) AddMember(teamID, requestorID, memberID uuid.UUID) error {
-	err := s.teamService.AddMember(teamID, requestorID, memberID)
+func (s *CacheIntegratedTeamService) AddMember(ctx context.Context, teamID, requestorID, memberID uuid.UUID) error {
+	err := s.teamService.AddMember(ctx, teamID, requestorID, memberID)
 	if err != nil {
 		return err
 	}
@@ -231,8 +409,8 @@ func (s *CacheIntegratedTeamService) AddMember(teamID, requestorID, memberID uui
 }
 
 // RemoveMember removes member from team and updates cache
-func (s *CacheIntegratedTeamService) RemoveMember(teamID, requestorID, memberID uuid.UUID) error {
-	err := s.teamService.RemoveMember(teamID, requestorID, memberID)
+func (s *CacheIntegratedTeamService) RemoveMember(ctx context.Context, teamID, requestorID, memberID uuid.UUID) error {
+	err := s.teamService.RemoveMember(ctx, teamID, requestorID, memberID)
 	if err != nil {
 		return err
 	}
@@ -242,8 +420,8 @@ func (s *CacheIntegratedTeamService) RemoveMember(teamID, requestorID, memberID
 }
 
 // AddManager adds manager to team and updates cache
-func (s *CacheIntegratedTeamService) AddManager(teamID, requestorID, managerID uuid.UUID) error {
-	err := s.teamService.AddManager(teamID, requestorID, managerID)
+func (s *CacheIntegratedTeamService) AddManager(ctx context.Context, teamID, requestorID, managerID uuid.UUID) error {
+	err := s.teamService.AddManager(ctx, teamID, requestorID, managerID)
 	if err != nil {
 		return err
 	}
@@ -253,8 +431,8 @@ func (s *CacheIntegratedTeamService) AddManager(teamID, requestorID, managerID u
 }
 
 // RemoveManager removes manager from team and updates cache
-func (s *CacheIntegratedTeamService) RemoveManager(teamID, requestorID, managerID uuid.UUID) error {
-	err := s.teamService.RemoveManager(teamID, requestorID, managerID)
+func (s *CacheIntegratedTeamService) RemoveManager(ctx context.Context, teamID, requestorID, managerID uuid.UUID) error {
+	err := s.teamService.RemoveManager(ctx, teamID, requestorID, managerID)
 	if err != nil {
 		return err
 	}
@@ -264,54 +442,69 @@ func (s *CacheIntegratedTeamService) RemoveManager(teamID, requestorID, managerI
 }
 
 // GetTeam gets team with cached member lookup
-func (s *CacheIntegratedTeamService) GetTeam(teamID, userID uuid.UUID) (*models.Team, error) {
-	ctx := context.Background()
+func (s *CacheIntegratedTeamService) GetTeam(ctx context.Context, teamID, userID uuid.UUID) (*models.Team, error) {
 	
 	// Check if user is in team using cache
-	cachedMembers, err := s.cacheService.GetTeamMembers(ctx, teamID)
-	if err == nil && cachedMembers != nil {
+	isMember, cached, err := s.cacheService.IsTeamMember(ctx, teamID, userID)
+	if err == nil && cached {
 		log.Printf("Cache HIT for team %s members", teamID)
 		
-		// Check if user is in cached members list
-		userInTeam := false
-		for _, memberID := range cachedMembers {
-			if memberID == userID {
-				userInTeam = true
-				break
-			}
-		}
-		
-		if !userInTeam {
-			return nil, fmt.Errorf("access denied: you are not a member of this team")
+		if !isMember {
+			return nil, forbidden("access denied: you are not a member of this team")
 		}
 	}
 	
 	// Get team from database (could also be cached in the future)
-	return s.teamService.GetTeam(teamID, userID)
+	return s.teamService.GetTeam(ctx, teamID, userID)
 }
 
-// GetUserTeams gets user teams
-func (s *CacheIntegratedTeamService) GetUserTeams(userID uuid.UUID) ([]*models.Team, error) {
-	return s.teamService.GetUserTeams(userID)
+// GetUserTeams gets user teams from cache first, then falls back to database
+func (s *CacheIntegratedTeamService) GetUserTeams(ctx context.Context, userID uuid.UUID) ([]*models.Team, error) {
+	
+	if cachedTeams, err := s.cacheService.GetUserTeams(ctx, userID); err == nil && cachedTeams != nil {
+		log.Printf("Cache HIT for user %s teams", userID)
+		return cachedTeams, nil
+	}
+	
+	log.Printf("Cache MISS for user %s teams, fetching from database", userID)
+	
+	teams, err := s.teamService.GetUserTeams(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	
+	// Invalidation on membership changes is handled by Kafka event handler
+	if err := s.cacheService.CacheUserTeams(ctx, userID, teams); err != nil {
+		log.Printf("Failed to cache teams for user %s: %v", userID, err)
+	}
+	
+	return teams, nil
+}
+
+// ListUserTeams lists the user's teams a page at a time; pages are not cached
+func (s *CacheIntegratedTeamService) ListUserTeams(ctx context.Context, userID uuid.UUID, req models.PageRequest) (*models.Page[*models.Team], error) {
+	return s.teamService.ListUserTeams(ctx, userID, req)
 }
 
 // CacheIntegratedShareService wraps share service with ACL caching
 type CacheIntegratedShareService struct {
-	shareService ShareService
+	shareService serviceInterfaces.ShareService
 	cacheService cache.CacheService
+	aclLoader    *ACLLoader
 }
 
 // NewCacheIntegratedShareService creates a new cache-integrated share service
-func NewCacheIntegratedShareService(shareService ShareService, cacheService cache.CacheService) *CacheIntegratedShareService {
+func NewCacheIntegratedShareService(shareService serviceInterfaces.ShareService, cacheService cache.CacheService, aclLoader *ACLLoader) *CacheIntegratedShareService {
 	return &CacheIntegratedShareService{
 		shareService: shareService,
 		cacheService: cacheService,
+		aclLoader:    aclLoader,
 	}
 }
 
 // ShareFolder shares folder and updates ACL cache
-func (s *CacheIntegratedShareService) ShareFolder(folderID, ownerID, targetUserID uuid.UUID, accessLevel string) error {
-	err := s.shareService.ShareFolder(folderID, ownerID, targetUserID, accessLevel)
+func (s *CacheIntegratedShareService) ShareFolder(ctx context.Context, folderID, ownerID, targetUserID uuid.UUID, accessLevel string) error {
+	err := s.shareService.ShareFolder(ctx, folderID, ownerID, targetUserID, accessLevel)
 	if err != nil {
 		return err
 	}
@@ -321,8 +514,8 @@ func (s *CacheIntegratedShareService) ShareFolder(folderID, ownerID, targetUserI
 }
 
 // UnshareFolder unshares folder and updates ACL cache
-func (s *CacheIntegratedShareService) UnshareFolder(folderID, ownerID, targetUserID uuid.UUID) error {
-	err := s.shareService.UnshareFolder(folderID, ownerID, targetUserID)
+func (s *CacheIntegratedShareService) UnshareFolder(ctx context.Context, folderID, ownerID, targetUserID uuid.UUID) error {
+	err := s.shareService.UnshareFolder(ctx, folderID, ownerID, targetUserID)
 	if err != nil {
 		return err
 	}
@@ -332,13 +525,23 @@ func (s *CacheIntegratedShareService) UnshareFolder(folderID, ownerID, targetUse
 }
 
 // GetFolderShares gets folder shares
-func (s *CacheIntegratedShareService) GetFolderShares(folderID, userID uuid.UUID) ([]*models.FolderShare, error) {
-	return s.shareService.GetFolderShares(folderID, userID)
+func (s *CacheIntegratedShareService) GetFolderShares(ctx context.Context, folderID, userID uuid.UUID) ([]*models.FolderShare, error) {
+	return s.shareService.GetFolderShares(ctx, folderID, userID)
+}
+
+// GetSharesByFolders gets the shares of several folders
+func (s *CacheIntegratedShareService) GetSharesByFolders(ctx context.Context, folderIDs []uuid.UUID, userID uuid.UUID) (map[uuid.UUID][]*models.FolderShare, error) {
+	return s.shareService.GetSharesByFolders(ctx, folderIDs, userID)
+}
+
+// ListFolderShares lists folder shares a page at a time
+func (s *CacheIntegratedShareService) ListFolderShares(ctx context.Context, folderID, userID uuid.UUID, req models.PageRequest) (*models.Page[*models.FolderShare], error) {
+	return s.shareService.ListFolderShares(ctx, folderID, userID, req)
 }
 
 // ShareNote shares note and updates ACL cache
-func (s *CacheIntegratedShareService) ShareNote(noteID, ownerID, targetUserID uuid.UUID, accessLevel string) error {
-	err := s.shareService.ShareNote(noteID, ownerID, targetUserID, accessLevel)
+func (s *CacheIntegratedShareService) ShareNote(ctx context.Context, noteID, ownerID, targetUserID uuid.UUID, accessLevel string) error {
+	err := s.shareService.ShareNote(ctx, noteID, ownerID, targetUserID, accessLevel)
 	if err != nil {
 		return err
 	}
@@ -348,8 +551,8 @@ func (s *CacheIntegratedShareService) ShareNote(noteID, ownerID, targetUserID uu
 }
 
 // UnshareNote unshares note and updates ACL cache
-func (s *CacheIntegratedShareService) UnshareNote(noteID, ownerID, targetUserID uuid.UUID) error {
-	err := s.shareService.UnshareNote(noteID, ownerID, targetUserID)
+func (s *CacheIntegratedShareService) UnshareNote(ctx context.Context, noteID, ownerID, targetUserID uuid.UUID) error {
+	err := s.shareService.UnshareNote(ctx, noteID, ownerID, targetUserID)
 	if err != nil {
 		return err
 	}
@@ -359,24 +562,26 @@ func (s *CacheIntegratedShareService) UnshareNote(noteID, ownerID, targetUserID
 }
 
 // GetNoteShares gets note shares
-func (s *CacheIntegratedShareService) GetNoteShares(noteID, userID uuid.UUID) ([]*models.NoteShare, error) {
-	return s.shareService.GetNoteShares(noteID, userID)
+func (s *CacheIntegratedShareService) GetNoteShares(ctx context.Context, noteID, userID uuid.UUID) ([]*models.NoteShare, error) {
+	return s.shareService.GetNoteShares(ctx, noteID, userID)
 }
 
-// CheckAssetAccess checks if user has access to asset using cache first
-func (s *CacheIntegratedShareService) CheckAssetAccess(assetID, userID uuid.UUID) (string, error) {
-	ctx := context.Background()
-	
-	// Try to get ACL from cache first
-	if cachedACL, err := s.cacheService.GetAssetACL(ctx, assetID); err == nil && cachedACL != nil {
-		if accessLevel, exists := cachedACL[userID.String()]; exists {
-			log.Printf("Cache HIT for asset %s ACL, user %s has %s access", assetID, userID, accessLevel)
-			return accessLevel, nil
-		}
-		log.Printf("Cache HIT for asset %s ACL, but user %s not found", assetID, userID)
-		return "", nil
+// GetSharesByNotes gets the shares of several notes
+func (s *CacheIntegratedShareService) GetSharesByNotes(ctx context.Context, noteIDs []uuid.UUID, userID uuid.UUID) (map[uuid.UUID][]*models.NoteShare, error) {
+	return s.shareService.GetSharesByNotes(ctx, noteIDs, userID)
+}
+
+// ListNoteShares lists note shares a page at a time
+func (s *CacheIntegratedShareService) ListNoteShares(ctx context.Context, noteID, userID uuid.UUID, req models.PageRequest) (*models.Page[*models.NoteShare], error) {
+	return s.shareService.ListNoteShares(ctx, noteID, userID, req)
+}
+
+// CheckAssetAccess returns the user's access level ("owner", "read", "write"
+// or empty), loading and caching the asset ACL from the database on a miss
+func (s *CacheIntegratedShareService) CheckAssetAccess(ctx context.Context, assetID, userID uuid.UUID) (string, error) {
+	acl, err := s.aclLoader.AssetACL(ctx, assetID)
+	if err != nil {
+		return "", err
 	}
-	
-	log.Printf("Cache MISS for asset %s ACL", assetID)
-	return "", nil // Let the service handle database lookup
-}
\ No newline at end of file
+	return acl[userID.String()], nil
+}
@@ -0,0 +1,501 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"asset-management-api/internal/models"
+	"asset-management-api/internal/repository/interfaces"
+	serviceInterfaces "asset-management-api/internal/service/interfaces"
+	"asset-management-api/pkg/cache"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// memoryCache is an in-memory CacheService holding copies of what is cached,
+// as a real backend would after serializing. Operations the tests don't use
+// panic through the embedded nil interface.
+type memoryCache struct {
+	cache.CacheService
+
+	mu        sync.Mutex
+	folders   map[uuid.UUID]models.Folder
+	notes     map[uuid.UUID]models.Note
+	acls      map[uuid.UUID]map[string]string
+	userTeams map[uuid.UUID][]*models.Team
+}
+
+func newMemoryCache() *memoryCache {
+	return &memoryCache{
+		folders:   make(map[uuid.UUID]models.Folder),
+		notes:     make(map[uuid.UUID]models.Note),
+		acls:      make(map[uuid.UUID]map[string]string),
+		userTeams: make(map[uuid.UUID][]*models.Team),
+	}
+}
+
+func (c *memoryCache) CacheFolderMetadata(ctx context.Context, folder *models.Folder) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.folders[folder.FolderID] = *folder
+	return nil
+}
+
+func (c *memoryCache) GetFolderMetadata(ctx context.Context, folderID uuid.UUID) (*models.Folder, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	folder, ok := c.folders[folderID]
+	if !ok {
+		return nil, nil
+	}
+	return &folder, nil
+}
+
+func (c *memoryCache) GetFolderMetadataBatch(ctx context.Context, folderIDs []uuid.UUID) (map[uuid.UUID]*models.Folder, error) {
+	found := make(map[uuid.UUID]*models.Folder)
+	for _, folderID := range folderIDs {
+		if folder, _ := c.GetFolderMetadata(ctx, folderID); folder != nil {
+			found[folderID] = folder
+		}
+	}
+	return found, nil
+}
+
+func (c *memoryCache) InvalidateFolderMetadata(ctx context.Context, folderID uuid.UUID) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.folders, folderID)
+	return nil
+}
+
+func (c *memoryCache) CacheNoteMetadata(ctx context.Context, note *models.Note) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.notes[note.NoteID] = *note
+	return nil
+}
+
+func (c *memoryCache) GetNoteMetadata(ctx context.Context, noteID uuid.UUID) (*models.Note, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	note, ok := c.notes[noteID]
+	if !ok {
+		return nil, nil
+	}
+	return &note, nil
+}
+
+func (c *memoryCache) InvalidateNoteMetadata(ctx context.Context, noteID uuid.UUID) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.notes, noteID)
+	return nil
+}
+
+func (c *memoryCache) CacheAssetACL(ctx context.Context, assetID uuid.UUID, acl map[string]string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.acls[assetID] = acl
+	return nil
+}
+
+func (c *memoryCache) GetAssetACL(ctx context.Context, assetID uuid.UUID) (map[string]string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.acls[assetID], nil
+}
+
+func (c *memoryCache) InvalidateAssetACL(ctx context.Context, assetID uuid.UUID) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.acls, assetID)
+	return nil
+}
+
+func (c *memoryCache) CacheUserTeams(ctx context.Context, userID uuid.UUID, teams []*models.Team) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.userTeams[userID] = teams
+	return nil
+}
+
+func (c *memoryCache) GetUserTeams(ctx context.Context, userID uuid.UUID) ([]*models.Team, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.userTeams[userID], nil
+}
+
+func (c *memoryCache) InvalidateUserTeams(ctx context.Context, userID uuid.UUID) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.userTeams, userID)
+	return nil
+}
+
+// callCounter counts the calls made to a fake repository, by method
+type callCounter struct {
+	mu    sync.Mutex
+	calls map[string]int
+}
+
+func (c *callCounter) record(method string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.calls == nil {
+		c.calls = make(map[string]int)
+	}
+	c.calls[method]++
+}
+
+func (c *callCounter) count(method string) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.calls[method]
+}
+
+type fakeFolderRepo struct {
+	interfaces.FolderRepository
+	callCounter
+	folders map[uuid.UUID]models.Folder
+}
+
+func (r *fakeFolderRepo) CheckOwnership(ctx context.Context, folderID, userID uuid.UUID) (bool, error) {
+	r.record("CheckOwnership")
+	folder, ok := r.folders[folderID]
+	return ok && folder.OwnerID == userID, nil
+}
+
+func (r *fakeFolderRepo) GetByID(ctx context.Context, folderID uuid.UUID) (*models.Folder, error) {
+	r.record("GetByID")
+	folder, ok := r.folders[folderID]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &folder, nil
+}
+
+func (r *fakeFolderRepo) GetByIDs(ctx context.Context, folderIDs []uuid.UUID) ([]*models.Folder, error) {
+	r.record("GetByIDs")
+	var folders []*models.Folder
+	for _, folderID := range folderIDs {
+		if folder, ok := r.folders[folderID]; ok {
+			folders = append(folders, &folder)
+		}
+	}
+	return folders, nil
+}
+
+func (r *fakeFolderRepo) GetIDsByOwnerID(ctx context.Context, ownerID uuid.UUID) ([]uuid.UUID, error) {
+	r.record("GetIDsByOwnerID")
+	var folderIDs []uuid.UUID
+	for folderID, folder := range r.folders {
+		if folder.OwnerID == ownerID {
+			folderIDs = append(folderIDs, folderID)
+		}
+	}
+	return folderIDs, nil
+}
+
+func (r *fakeFolderRepo) GetSharedFolderIDs(ctx context.Context, userID uuid.UUID) ([]uuid.UUID, error) {
+	r.record("GetSharedFolderIDs")
+	return nil, nil
+}
+
+func (r *fakeFolderRepo) Update(ctx context.Context, folder *models.Folder) error {
+	r.record("Update")
+	r.folders[folder.FolderID] = *folder
+	return nil
+}
+
+type fakeNoteRepo struct {
+	interfaces.NoteRepository
+	callCounter
+	notes map[uuid.UUID]models.Note
+}
+
+func (r *fakeNoteRepo) Create(ctx context.Context, note *models.Note) error {
+	r.record("Create")
+	note.NoteID = uuid.New()
+	r.notes[note.NoteID] = *note
+	return nil
+}
+
+func (r *fakeNoteRepo) CheckOwnership(ctx context.Context, noteID, userID uuid.UUID) (bool, error) {
+	r.record("CheckOwnership")
+	note, ok := r.notes[noteID]
+	return ok && note.OwnerID == userID, nil
+}
+
+func (r *fakeNoteRepo) GetByID(ctx context.Context, noteID uuid.UUID) (*models.Note, error) {
+	r.record("GetByID")
+	note, ok := r.notes[noteID]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &note, nil
+}
+
+func (r *fakeNoteRepo) Update(ctx context.Context, note *models.Note) error {
+	r.record("Update")
+	r.notes[note.NoteID] = *note
+	return nil
+}
+
+func (r *fakeNoteRepo) Delete(ctx context.Context, noteID uuid.UUID) error {
+	r.record("Delete")
+	delete(r.notes, noteID)
+	return nil
+}
+
+// fakeShareRepo shares nothing with anyone
+type fakeShareRepo struct {
+	interfaces.ShareRepository
+}
+
+func (fakeShareRepo) CheckFolderAccess(ctx context.Context, folderID, userID uuid.UUID) (string, error) {
+	return "", nil
+}
+
+func (fakeShareRepo) CheckNoteAccess(ctx context.Context, noteID, userID uuid.UUID) (string, error) {
+	return "", nil
+}
+
+type fakeTeamService struct {
+	serviceInterfaces.TeamService
+	callCounter
+	teams []*models.Team
+}
+
+func (s *fakeTeamService) GetUserTeams(ctx context.Context, userID uuid.UUID) ([]*models.Team, error) {
+	s.record("GetUserTeams")
+	return s.teams, nil
+}
+
+func newCachedFolderService(t *testing.T, folders ...models.Folder) (*CacheIntegratedFolderService, *fakeFolderRepo, *memoryCache) {
+	t.Helper()
+	repo := &fakeFolderRepo{folders: make(map[uuid.UUID]models.Folder)}
+	for _, folder := range folders {
+		repo.folders[folder.FolderID] = folder
+	}
+	cacheService := newMemoryCache()
+	aclLoader := NewACLLoader(repo, &fakeNoteRepo{}, fakeShareRepo{}, cacheService)
+	folderService := NewFolderService(repo, fakeShareRepo{}, nil, nil)
+	return NewCacheIntegratedFolderService(folderService, repo, cacheService, aclLoader), repo, cacheService
+}
+
+func newCachedNoteService(t *testing.T, notes ...models.Note) (*CacheIntegratedNoteService, *fakeNoteRepo, *memoryCache) {
+	t.Helper()
+	repo := &fakeNoteRepo{notes: make(map[uuid.UUID]models.Note)}
+	for _, note := range notes {
+		repo.notes[note.NoteID] = note
+	}
+	cacheService := newMemoryCache()
+	folderRepo := &fakeFolderRepo{folders: make(map[uuid.UUID]models.Folder)}
+	aclLoader := NewACLLoader(folderRepo, repo, fakeShareRepo{}, cacheService)
+	noteService := NewNoteService(repo, folderRepo, fakeShareRepo{}, nil)
+	return NewCacheIntegratedNoteService(noteService, repo, cacheService, aclLoader), repo, cacheService
+}
+
+func TestCacheIntegratedFolderServiceServesRepeatGetsFromCache(t *testing.T) {
+	ctx := context.Background()
+	ownerID := uuid.New()
+	folder := models.Folder{FolderID: uuid.New(), Name: "Plans", OwnerID: ownerID}
+	service, repo, _ := newCachedFolderService(t, folder)
+
+	if _, err := service.GetFolder(ctx, folder.FolderID, ownerID); err != nil {
+		t.Fatalf("first GetFolder: %v", err)
+	}
+	if got := repo.count("GetByID"); got != 1 {
+		t.Fatalf("first GetFolder loaded the folder %d times, want 1", got)
+	}
+
+	got, err := service.GetFolder(ctx, folder.FolderID, ownerID)
+	if err != nil {
+		t.Fatalf("second GetFolder: %v", err)
+	}
+	if got.Name != folder.Name {
+		t.Errorf("second GetFolder returned name %q, want %q", got.Name, folder.Name)
+	}
+	if got := repo.count("GetByID"); got != 1 {
+		t.Errorf("second GetFolder loaded the folder from the repository (%d loads), want a cache hit", got)
+	}
+}
+
+func TestCacheIntegratedFolderServiceUpdateRefreshesCache(t *testing.T) {
+	ctx := context.Background()
+	ownerID := uuid.New()
+	folder := models.Folder{FolderID: uuid.New(), Name: "Plans", OwnerID: ownerID}
+	service, repo, _ := newCachedFolderService(t, folder)
+
+	if _, err := service.GetFolder(ctx, folder.FolderID, ownerID); err != nil {
+		t.Fatalf("GetFolder: %v", err)
+	}
+	if _, err := service.UpdateFolder(ctx, folder.FolderID, ownerID, "Roadmap", ""); err != nil {
+		t.Fatalf("UpdateFolder: %v", err)
+	}
+
+	loads := repo.count("GetByID")
+	got, err := service.GetFolder(ctx, folder.FolderID, ownerID)
+	if err != nil {
+		t.Fatalf("GetFolder after update: %v", err)
+	}
+	if got.Name != "Roadmap" {
+		t.Errorf("GetFolder after update returned name %q, want %q", got.Name, "Roadmap")
+	}
+	if got := repo.count("GetByID"); got != loads {
+		t.Errorf("GetFolder after update loaded the folder from the repository, want the refreshed cache entry")
+	}
+}
+
+func TestCacheIntegratedFolderServiceRefetchesAfterInvalidation(t *testing.T) {
+	ctx := context.Background()
+	ownerID := uuid.New()
+	folder := models.Folder{FolderID: uuid.New(), Name: "Plans", OwnerID: ownerID}
+	service, repo, cacheService := newCachedFolderService(t, folder)
+
+	if _, err := service.GetFolder(ctx, folder.FolderID, ownerID); err != nil {
+		t.Fatalf("GetFolder: %v", err)
+	}
+
+	// As the event handler does when another instance changes the folder
+	changed := repo.folders[folder.FolderID]
+	changed.Name = "Archive"
+	repo.folders[folder.FolderID] = changed
+	if err := cacheService.InvalidateFolderMetadata(ctx, folder.FolderID); err != nil {
+		t.Fatalf("InvalidateFolderMetadata: %v", err)
+	}
+
+	got, err := service.GetFolder(ctx, folder.FolderID, ownerID)
+	if err != nil {
+		t.Fatalf("GetFolder after invalidation: %v", err)
+	}
+	if got := repo.count("GetByID"); got != 2 {
+		t.Errorf("GetFolder after invalidation loaded the folder %d times in total, want 2", got)
+	}
+	if got.Name != "Archive" {
+		t.Errorf("GetFolder after invalidation returned name %q, want %q", got.Name, "Archive")
+	}
+}
+
+func TestCacheIntegratedFolderServiceHydratesUserFoldersFromCache(t *testing.T) {
+	ctx := context.Background()
+	ownerID := uuid.New()
+	first := models.Folder{FolderID: uuid.New(), Name: "Plans", OwnerID: ownerID}
+	second := models.Folder{FolderID: uuid.New(), Name: "Notes", OwnerID: ownerID}
+	service, repo, cacheService := newCachedFolderService(t, first, second)
+
+	if folders, err := service.GetUserFolders(ctx, ownerID); err != nil || len(folders) != 2 {
+		t.Fatalf("first GetUserFolders = %d folders, %v; want 2 folders", len(folders), err)
+	}
+	if folders, err := service.GetUserFolders(ctx, ownerID); err != nil || len(folders) != 2 {
+		t.Fatalf("second GetUserFolders = %d folders, %v; want 2 folders", len(folders), err)
+	}
+	if got := repo.count("GetByIDs"); got != 1 {
+		t.Errorf("GetUserFolders loaded folders from the repository %d times, want 1", got)
+	}
+
+	if err := cacheService.InvalidateFolderMetadata(ctx, second.FolderID); err != nil {
+		t.Fatalf("InvalidateFolderMetadata: %v", err)
+	}
+	if _, err := service.GetUserFolders(ctx, ownerID); err != nil {
+		t.Fatalf("GetUserFolders after invalidation: %v", err)
+	}
+	if got := repo.count("GetByIDs"); got != 2 {
+		t.Errorf("GetUserFolders after invalidation loaded folders %d times in total, want 2", got)
+	}
+}
+
+func TestCacheIntegratedNoteServiceServesRepeatGetsFromCache(t *testing.T) {
+	ctx := context.Background()
+	ownerID := uuid.New()
+	note := models.Note{NoteID: uuid.New(), Title: "Standup", FolderID: uuid.New(), OwnerID: ownerID}
+	service, repo, _ := newCachedNoteService(t, note)
+
+	if _, err := service.GetNote(ctx, note.NoteID, ownerID); err != nil {
+		t.Fatalf("first GetNote: %v", err)
+	}
+	if _, err := service.GetNote(ctx, note.NoteID, ownerID); err != nil {
+		t.Fatalf("second GetNote: %v", err)
+	}
+	if got := repo.count("GetByID"); got != 1 {
+		t.Errorf("GetNote loaded the note from the repository %d times, want 1", got)
+	}
+}
+
+func TestCacheIntegratedNoteServiceUpdateRefreshesCache(t *testing.T) {
+	ctx := context.Background()
+	ownerID := uuid.New()
+	note := models.Note{NoteID: uuid.New(), Title: "Standup", FolderID: uuid.New(), OwnerID: ownerID}
+	service, repo, _ := newCachedNoteService(t, note)
+
+	if _, err := service.GetNote(ctx, note.NoteID, ownerID); err != nil {
+		t.Fatalf("GetNote: %v", err)
+	}
+	if _, err := service.UpdateNote(ctx, note.NoteID, ownerID, "Retro", "went well"); err != nil {
+		t.Fatalf("UpdateNote: %v", err)
+	}
+
+	loads := repo.count("GetByID")
+	got, err := service.GetNote(ctx, note.NoteID, ownerID)
+	if err != nil {
+		t.Fatalf("GetNote after update: %v", err)
+	}
+	if got.Title != "Retro" || got.Body != "went well" {
+		t.Errorf("GetNote after update returned %q/%q, want %q/%q", got.Title, got.Body, "Retro", "went well")
+	}
+	if got := repo.count("GetByID"); got != loads {
+		t.Errorf("GetNote after update loaded the note from the repository, want the refreshed cache entry")
+	}
+}
+
+func TestCacheIntegratedNoteServiceRefetchesAfterDelete(t *testing.T) {
+	ctx := context.Background()
+	ownerID := uuid.New()
+	note := models.Note{NoteID: uuid.New(), Title: "Standup", FolderID: uuid.New(), OwnerID: ownerID}
+	service, repo, _ := newCachedNoteService(t, note)
+
+	if _, err := service.GetNote(ctx, note.NoteID, ownerID); err != nil {
+		t.Fatalf("GetNote: %v", err)
+	}
+	if err := service.DeleteNote(ctx, note.NoteID, ownerID); err != nil {
+		t.Fatalf("DeleteNote: %v", err)
+	}
+
+	checks := repo.count("CheckOwnership")
+	if _, err := service.GetNote(ctx, note.NoteID, ownerID); err == nil {
+		t.Error("GetNote after delete served the deleted note, want an error")
+	}
+	if got := repo.count("CheckOwnership"); got == checks {
+		t.Error("GetNote after delete did not consult the repository")
+	}
+}
+
+func TestCacheIntegratedTeamServiceServesRepeatUserTeamsFromCache(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	teams := &fakeTeamService{teams: []*models.Team{{TeamID: uuid.New(), TeamName: "Platform"}}}
+	cacheService := newMemoryCache()
+	service := NewCacheIntegratedTeamService(teams, cacheService)
+
+	for i := 0; i < 2; i++ {
+		if got, err := service.GetUserTeams(ctx, userID); err != nil || len(got) != 1 {
+			t.Fatalf("GetUserTeams = %d teams, %v; want 1 team", len(got), err)
+		}
+	}
+	if got := teams.count("GetUserTeams"); got != 1 {
+		t.Errorf("GetUserTeams loaded teams %d times, want 1", got)
+	}
+
+	// As the event handler does when the user's memberships change
+	if err := cacheService.InvalidateUserTeams(ctx, userID); err != nil {
+		t.Fatalf("InvalidateUserTeams: %v", err)
+	}
+	if _, err := service.GetUserTeams(ctx, userID); err != nil {
+		t.Fatalf("GetUserTeams after invalidation: %v", err)
+	}
+	if got := teams.count("GetUserTeams"); got != 2 {
+		t.Errorf("GetUserTeams after invalidation loaded teams %d times in total, want 2", got)
+	}
+}
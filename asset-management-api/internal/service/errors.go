@@ -0,0 +1,47 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Kinds of errors the services return for requests they refuse, as opposed
+// to failures. The errors themselves carry a message of their own, e.g.
+// "folder not found", and wrap their kind for errors.Is, which the handlers
+// map to the HTTP status.
+var (
+	ErrNotFound  = errors.New("not found")
+	ErrForbidden = errors.New("access denied")
+	ErrConflict  = errors.New("conflict")
+	ErrInvalid   = errors.New("invalid request")
+)
+
+// serviceError is a refused request of one of the kinds above
+type serviceError struct {
+	kind    error
+	message string
+}
+
+func (e *serviceError) Error() string {
+	return e.message
+}
+
+func (e *serviceError) Unwrap() error {
+	return e.kind
+}
+
+func notFound(message string) error {
+	return &serviceError{kind: ErrNotFound, message: message}
+}
+
+func forbidden(message string) error {
+	return &serviceError{kind: ErrForbidden, message: message}
+}
+
+func conflict(message string) error {
+	return &serviceError{kind: ErrConflict, message: message}
+}
+
+func invalid(format string, args ...interface{}) error {
+	return &serviceError{kind: ErrInvalid, message: fmt.Sprintf(format, args...)}
+}
@@ -18,21 +18,23 @@ import (
 type folderService struct {
 	folderRepo interfaces.FolderRepository
 	shareRepo  interfaces.ShareRepository
+	txManager  interfaces.TxManager
 	eventBus   eventbus.EventBus // NEW: Added event bus
 }
 
 // NEW: Updated constructor to accept event bus
-func NewFolderService(folderRepo interfaces.FolderRepository, shareRepo interfaces.ShareRepository, eventBus eventbus.EventBus) serviceInterfaces.FolderService {
+func NewFolderService(folderRepo interfaces.FolderRepository, shareRepo interfaces.ShareRepository, txManager interfaces.TxManager, eventBus eventbus.EventBus) serviceInterfaces.FolderService {
 	return &folderService{
 		folderRepo: folderRepo,
 		shareRepo:  shareRepo,
+		txManager:  txManager,
 		eventBus:   eventBus,
 	}
 }
 
-func (s *folderService) CreateFolder(userID uuid.UUID, name, description string) (*models.Folder, error) {
+func (s *folderService) CreateFolder(ctx context.Context, userID uuid.UUID, name, description string) (*models.Folder, error) {
 	if name == "" {
-		return nil, errors.New("folder name is required")
+		return nil, invalid("folder name is required")
 	}
 
 	folder := &models.Folder{
@@ -41,39 +43,39 @@ func (s *folderService) CreateFolder(userID uuid.UUID, name, description string)
 		OwnerID:     userID,
 	}
 
-	err := s.folderRepo.Create(folder)
+	err := s.folderRepo.Create(ctx, folder)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create folder: %w", err)
 	}
 
 	// NEW: Publish folder created event
-	s.publishFolderCreatedEvent(folder.FolderID, userID, name, description)
+	s.publishFolderCreatedEvent(ctx, folder.FolderID, userID, name, description)
 
 	return folder, nil
 }
 
-func (s *folderService) GetFolder(folderID, userID uuid.UUID) (*models.Folder, error) {
+func (s *folderService) GetFolder(ctx context.Context, folderID, userID uuid.UUID) (*models.Folder, error) {
 	// Check if user owns the folder
-	isOwner, err := s.folderRepo.CheckOwnership(folderID, userID)
+	isOwner, err := s.folderRepo.CheckOwnership(ctx, folderID, userID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to check folder ownership: %w", err)
 	}
 
 	if !isOwner {
 		// Check if folder is shared with user
-		accessLevel, err := s.shareRepo.CheckFolderAccess(folderID, userID)
+		accessLevel, err := s.shareRepo.CheckFolderAccess(ctx, folderID, userID)
 		if err != nil {
 			return nil, fmt.Errorf("failed to check folder access: %w", err)
 		}
 		if accessLevel == "" {
-			return nil, errors.New("access denied: you don't have permission to view this folder")
+			return nil, forbidden("access denied: you don't have permission to view this folder")
 		}
 	}
 
-	folder, err := s.folderRepo.GetByID(folderID)
+	folder, err := s.folderRepo.GetByID(ctx, folderID)
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
-			return nil, errors.New("folder not found")
+			return nil, notFound("folder not found")
 		}
 		return nil, fmt.Errorf("failed to get folder: %w", err)
 	}
@@ -81,33 +83,33 @@ func (s *folderService) GetFolder(folderID, userID uuid.UUID) (*models.Folder, e
 	return folder, nil
 }
 
-func (s *folderService) UpdateFolder(folderID, userID uuid.UUID, name, description string) (*models.Folder, error) {
+func (s *folderService) UpdateFolder(ctx context.Context, folderID, userID uuid.UUID, name, description string) (*models.Folder, error) {
 	if name == "" {
-		return nil, errors.New("folder name is required")
+		return nil, invalid("folder name is required")
 	}
 
 	// Get existing folder first
-	existingFolder, err := s.folderRepo.GetByID(folderID)
+	existingFolder, err := s.folderRepo.GetByID(ctx, folderID)
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
-			return nil, errors.New("folder not found")
+			return nil, notFound("folder not found")
 		}
 		return nil, fmt.Errorf("failed to get folder: %w", err)
 	}
 
 	// Check if user owns the folder or has write access
-	isOwner, err := s.folderRepo.CheckOwnership(folderID, userID)
+	isOwner, err := s.folderRepo.CheckOwnership(ctx, folderID, userID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to check folder ownership: %w", err)
 	}
 
 	if !isOwner {
-		accessLevel, err := s.shareRepo.CheckFolderAccess(folderID, userID)
+		accessLevel, err := s.shareRepo.CheckFolderAccess(ctx, folderID, userID)
 		if err != nil {
 			return nil, fmt.Errorf("failed to check folder access: %w", err)
 		}
 		if accessLevel != "write" {
-			return nil, errors.New("access denied: you don't have write permission for this folder")
+			return nil, forbidden("access denied: you don't have write permission for this folder")
 		}
 	}
 
@@ -124,59 +126,71 @@ func (s *folderService) UpdateFolder(folderID, userID uuid.UUID, name, descripti
 	existingFolder.Name = name
 	existingFolder.Description = description
 
-	err = s.folderRepo.Update(existingFolder)
+	err = s.folderRepo.Update(ctx, existingFolder)
 	if err != nil {
+		if errors.Is(err, interfaces.ErrVersionConflict) {
+			return nil, conflict("folder was modified concurrently, please retry")
+		}
 		return nil, fmt.Errorf("failed to update folder: %w", err)
 	}
 
 	// NEW: Publish folder updated event if there were changes
 	if len(changes) > 0 {
-		s.publishFolderUpdatedEvent(folderID, existingFolder.OwnerID, userID, name, description, changes)
+		s.publishFolderUpdatedEvent(ctx, folderID, existingFolder.OwnerID, userID, name, description, changes)
 	}
 
 	return existingFolder, nil
 }
 
-func (s *folderService) DeleteFolder(folderID, userID uuid.UUID) error {
+func (s *folderService) DeleteFolder(ctx context.Context, folderID, userID uuid.UUID) error {
 	// Get folder info before deletion
-	folder, err := s.folderRepo.GetByID(folderID)
+	folder, err := s.folderRepo.GetByID(ctx, folderID)
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
-			return errors.New("folder not found")
+			return notFound("folder not found")
 		}
 		return fmt.Errorf("failed to get folder: %w", err)
 	}
 
 	// Only the owner can delete a folder
-	isOwner, err := s.folderRepo.CheckOwnership(folderID, userID)
+	isOwner, err := s.folderRepo.CheckOwnership(ctx, folderID, userID)
 	if err != nil {
 		return fmt.Errorf("failed to check folder ownership: %w", err)
 	}
 
 	if !isOwner {
-		return errors.New("access denied: only the folder owner can delete it")
+		return forbidden("access denied: only the folder owner can delete it")
 	}
 
-	err = s.folderRepo.Delete(folderID)
+	// Remove the shares together with the folder, so a failure leaves neither half-deleted
+	err = s.txManager.WithinTransaction(ctx, func(repos interfaces.Repositories) error {
+		if err := repos.Shares.DeleteFolderShares(ctx, folderID); err != nil {
+			return fmt.Errorf("failed to delete folder shares: %w", err)
+		}
+		if err := repos.Folders.Delete(ctx, folderID); err != nil {
+			return fmt.Errorf("failed to delete folder: %w", err)
+		}
+		return nil
+	})
 	if err != nil {
-		return fmt.Errorf("failed to delete folder: %w", err)
+		return err
 	}
 
 	// NEW: Publish folder deleted event
-	s.publishFolderDeletedEvent(folderID, folder.OwnerID, userID, folder.Name)
+	s.publishFolderDeletedEvent(ctx, folderID, folder.OwnerID, userID, folder.Name)
 
 	return nil
 }
 
-func (s *folderService) GetUserFolders(userID uuid.UUID) ([]*models.Folder, error) {
+func (s *folderService) GetUserFolders(ctx context.Context, userID uuid.UUID) ([]*models.Folder, error) {
 	// Get owned folders
-	ownedFolders, err := s.folderRepo.GetByOwnerID(userID)
+	ownedFolders, err := s.folderRepo.GetByOwnerID(ctx, userID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get owned folders: %w", err)
 	}
 
 	// Get shared folders
-	sharedFolders, err := s.folderRepo.GetSharedFolders(userID)
+	sharedFolders, err := s.folderRepo.GetSharedFolders(ctx, userID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get shared folders: %w", err)
 	}
@@ -186,41 +200,46 @@ func (s *folderService) GetUserFolders(userID uuid.UUID) ([]*models.Folder, erro
 	return allFolders, nil
 }
 
+func (s *folderService) ListUserFolders(ctx context.Context, userID uuid.UUID, req models.PageRequest) (*models.Page[*models.Folder], error) {
+	page, err := s.folderRepo.ListAccessible(ctx, userID, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list folders: %w", err)
+	}
+	return page, nil
+}
+
 // NEW: Event publishing methods
-func (s *folderService) publishFolderCreatedEvent(folderID, ownerID uuid.UUID, name, description string) {
+func (s *folderService) publishFolderCreatedEvent(ctx context.Context, folderID, ownerID uuid.UUID, name, description string) {
 	if s.eventBus == nil {
 		return
 	}
 
 	event := types.NewFolderCreatedEvent(folderID, ownerID, ownerID, name, description)
 	
-	ctx := context.Background()
 	if err := s.eventBus.Publish(ctx, types.AssetChangesTopic, event); err != nil {
 		log.Printf("Failed to publish folder created event: %v", err)
 	}
 }
 
-func (s *folderService) publishFolderUpdatedEvent(folderID, ownerID, actionBy uuid.UUID, name, description string, changes []string) {
+func (s *folderService) publishFolderUpdatedEvent(ctx context.Context, folderID, ownerID, actionBy uuid.UUID, name, description string, changes []string) {
 	if s.eventBus == nil {
 		return
 	}
 
 	event := types.NewFolderUpdatedEvent(folderID, ownerID, actionBy, name, description, changes)
 	
-	ctx := context.Background()
 	if err := s.eventBus.Publish(ctx, types.AssetChangesTopic, event); err != nil {
 		log.Printf("Failed to publish folder updated event: %v", err)
 	}
 }
 
-func (s *folderService) publishFolderDeletedEvent(folderID, ownerID, actionBy uuid.UUID, name string) {
+func (s *folderService) publishFolderDeletedEvent(ctx context.Context, folderID, ownerID, actionBy uuid.UUID, name string) {
 	if s.eventBus == nil {
 		return
 	}
 
 	event := types.NewFolderDeletedEvent(folderID, ownerID, actionBy, name)
 	
-	ctx := context.Background()
 	if err := s.eventBus.Publish(ctx, types.AssetChangesTopic, event); err != nil {
 		log.Printf("Failed to publish folder deleted event: %v", err)
 	}
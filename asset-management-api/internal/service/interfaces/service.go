@@ -1,57 +1,95 @@
 package interfaces
 
 import (
+	"context"
+
 	"asset-management-api/internal/models"
 	"github.com/google/uuid"
 )
 
 type FolderService interface {
-	CreateFolder(userID uuid.UUID, name, description string) (*models.Folder, error)
-	GetFolder(folderID, userID uuid.UUID) (*models.Folder, error)
-	UpdateFolder(folderID, userID uuid.UUID, name, description string) (*models.Folder, error)
-	DeleteFolder(folderID, userID uuid.UUID) error
-	GetUserFolders(userID uuid.UUID) ([]*models.Folder, error)
+	CreateFolder(ctx context.Context, userID uuid.UUID, name, description string) (*models.Folder, error)
+	GetFolder(ctx context.Context, folderID, userID uuid.UUID) (*models.Folder, error)
+	UpdateFolder(ctx context.Context, folderID, userID uuid.UUID, name, description string) (*models.Folder, error)
+	DeleteFolder(ctx context.Context, folderID, userID uuid.UUID) error
+	GetUserFolders(ctx context.Context, userID uuid.UUID) ([]*models.Folder, error)
+	ListUserFolders(ctx context.Context, userID uuid.UUID, req models.PageRequest) (*models.Page[*models.Folder], error)
 }
 
 type NoteService interface {
-	CreateNote(userID, folderID uuid.UUID, title, body string) (*models.Note, error)
-	GetNote(noteID, userID uuid.UUID) (*models.Note, error)
-	UpdateNote(noteID, userID uuid.UUID, title, body string) (*models.Note, error)
-	DeleteNote(noteID, userID uuid.UUID) error
-	GetNotesByFolder(folderID, userID uuid.UUID) ([]*models.Note, error)
-	GetUserNotes(userID uuid.UUID) ([]*models.Note, error)
+	CreateNote(ctx context.Context, userID, folderID uuid.UUID, title, body string) (*models.Note, error)
+	GetNote(ctx context.Context, noteID, userID uuid.UUID) (*models.Note, error)
+	UpdateNote(ctx context.Context, noteID, userID uuid.UUID, title, body string) (*models.Note, error)
+	DeleteNote(ctx context.Context, noteID, userID uuid.UUID) error
+	GetNotesByFolder(ctx context.Context, folderID, userID uuid.UUID) ([]*models.Note, error)
+	// GetNotesByFolders gets the notes of several folders by folder ID;
+	// folders the user cannot view are left out
+	GetNotesByFolders(ctx context.Context, folderIDs []uuid.UUID, userID uuid.UUID) (map[uuid.UUID][]*models.Note, error)
+	GetUserNotes(ctx context.Context, userID uuid.UUID) ([]*models.Note, error)
+	ListNotesByFolder(ctx context.Context, folderID, userID uuid.UUID, req models.PageRequest) (*models.Page[*models.Note], error)
+	ListUserNotes(ctx context.Context, userID uuid.UUID, req models.PageRequest) (*models.Page[*models.Note], error)
 }
 
 type ShareService interface {
 	// Folder sharing
-	ShareFolder(folderID, ownerID, targetUserID uuid.UUID, accessLevel string) error
-	UnshareFolder(folderID, ownerID, targetUserID uuid.UUID) error
-	GetFolderShares(folderID, userID uuid.UUID) ([]*models.FolderShare, error)
+	ShareFolder(ctx context.Context, folderID, ownerID, targetUserID uuid.UUID, accessLevel string) error
+	UnshareFolder(ctx context.Context, folderID, ownerID, targetUserID uuid.UUID) error
+	GetFolderShares(ctx context.Context, folderID, userID uuid.UUID) ([]*models.FolderShare, error)
+	// GetSharesByFolders gets the shares of several folders by folder ID;
+	// folders the user does not own are left out
+	GetSharesByFolders(ctx context.Context, folderIDs []uuid.UUID, userID uuid.UUID) (map[uuid.UUID][]*models.FolderShare, error)
+	ListFolderShares(ctx context.Context, folderID, userID uuid.UUID, req models.PageRequest) (*models.Page[*models.FolderShare], error)
 
 	// Note sharing
-	ShareNote(noteID, ownerID, targetUserID uuid.UUID, accessLevel string) error
-	UnshareNote(noteID, ownerID, targetUserID uuid.UUID) error
-	GetNoteShares(noteID, userID uuid.UUID) ([]*models.NoteShare, error)
+	ShareNote(ctx context.Context, noteID, ownerID, targetUserID uuid.UUID, accessLevel string) error
+	UnshareNote(ctx context.Context, noteID, ownerID, targetUserID uuid.UUID) error
+	GetNoteShares(ctx context.Context, noteID, userID uuid.UUID) ([]*models.NoteShare, error)
+	// GetSharesByNotes gets the shares of several notes by note ID; notes
+	// the user does not own are left out
+	GetSharesByNotes(ctx context.Context, noteIDs []uuid.UUID, userID uuid.UUID) (map[uuid.UUID][]*models.NoteShare, error)
+	ListNoteShares(ctx context.Context, noteID, userID uuid.UUID, req models.PageRequest) (*models.Page[*models.NoteShare], error)
 }
 
 type ManagerService interface {
-	GetTeamAssets(teamID, managerID uuid.UUID) ([]*models.AssetInfo, error)
-	GetUserAssets(targetUserID, managerID uuid.UUID) ([]*models.AssetInfo, error)
+	GetTeamAssets(ctx context.Context, teamID, managerID uuid.UUID) ([]*models.AssetInfo, error)
+	GetUserAssets(ctx context.Context, targetUserID, managerID uuid.UUID) ([]*models.AssetInfo, error)
 }
 
 // Thêm vào cuối file:
 type TeamService interface {
-	CreateTeam(creatorID uuid.UUID, teamName string, managers []TeamMemberInfo, members []TeamMemberInfo) (*models.Team, error)
-	AddMember(teamID, requestorID, memberID uuid.UUID) error
-	RemoveMember(teamID, requestorID, memberID uuid.UUID) error  
-	AddManager(teamID, requestorID, managerID uuid.UUID) error
-	RemoveManager(teamID, requestorID, managerID uuid.UUID) error
-	GetTeam(teamID, userID uuid.UUID) (*models.Team, error)
-	GetUserTeams(userID uuid.UUID) ([]*models.Team, error)
+	CreateTeam(ctx context.Context, creatorID uuid.UUID, teamName string, managers []TeamMemberInfo, members []TeamMemberInfo) (*models.Team, error)
+	AddMember(ctx context.Context, teamID, requestorID, memberID uuid.UUID) error
+	RemoveMember(ctx context.Context, teamID, requestorID, memberID uuid.UUID) error  
+	AddManager(ctx context.Context, teamID, requestorID, managerID uuid.UUID) error
+	RemoveManager(ctx context.Context, teamID, requestorID, managerID uuid.UUID) error
+	GetTeam(ctx context.Context, teamID, userID uuid.UUID) (*models.Team, error)
+	GetUserTeams(ctx context.Context, userID uuid.UUID) ([]*models.Team, error)
+	ListUserTeams(ctx context.Context, userID uuid.UUID, req models.PageRequest) (*models.Page[*models.Team], error)
 }
 
 // Và thêm struct:
 type TeamMemberInfo struct {
 	UserID   string `json:"userId"`
 	UserName string `json:"userName"`
-}
\ No newline at end of file
+}
+type WebhookService interface {
+	RegisterWebhook(ctx context.Context, teamID, requestorID uuid.UUID, url, secret string, eventTypes []string) (*models.Webhook, error)
+	ListWebhooks(ctx context.Context, teamID, requestorID uuid.UUID) ([]*models.Webhook, error)
+	DeleteWebhook(ctx context.Context, teamID, webhookID, requestorID uuid.UUID) error
+	GetDeliveries(ctx context.Context, teamID, webhookID, requestorID uuid.UUID, limit int) ([]*models.WebhookDelivery, error)
+
+	// The webhooks of all teams the requestor manages, and single webhooks
+	// by ID, whose team the requestor must manage
+	ListManagedWebhooks(ctx context.Context, requestorID uuid.UUID) ([]*models.Webhook, error)
+	GetWebhook(ctx context.Context, webhookID, requestorID uuid.UUID) (*models.Webhook, error)
+	UpdateWebhook(ctx context.Context, webhookID, requestorID uuid.UUID, req models.UpdateWebhookRequest) (*models.Webhook, error)
+	RotateWebhookSecret(ctx context.Context, webhookID, requestorID uuid.UUID) (*models.Webhook, error)
+}
+
+type SubscriptionService interface {
+	Subscribe(ctx context.Context, userID uuid.UUID, assetType string, assetID uuid.UUID, eventTypes []string) (*models.Subscription, error)
+	ListSubscriptions(ctx context.Context, userID uuid.UUID) ([]*models.Subscription, error)
+	Unsubscribe(ctx context.Context, subscriptionID, userID uuid.UUID) error
+	GetNotifications(ctx context.Context, userID uuid.UUID, unreadOnly bool, limit int) ([]*models.Notification, error)
+	MarkNotificationRead(ctx context.Context, notificationID, userID uuid.UUID) error
+}
@@ -4,9 +4,11 @@ import (
 	"asset-management-api/internal/models"
 	"asset-management-api/internal/repository/interfaces"
 	serviceInterfaces "asset-management-api/internal/service/interfaces"
+	"context"
 	"errors"
 	"fmt"
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
 type managerService struct {
@@ -27,20 +29,23 @@ func NewManagerService(userRepo interfaces.UserRepository, teamRepo interfaces.T
 	}
 }
 
-func (s *managerService) GetTeamAssets(teamID, managerID uuid.UUID) ([]*models.AssetInfo, error) {
+func (s *managerService) GetTeamAssets(ctx context.Context, teamID, managerID uuid.UUID) ([]*models.AssetInfo, error) {
 	// Check if user is a manager
-	isManager, err := s.userRepo.CheckIfManager(managerID)
+	isManager, err := s.userRepo.CheckIfManager(ctx, managerID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to check manager status: %w", err)
 	}
 	if !isManager {
-		return nil, errors.New("access denied: only managers can view team assets")
+		return nil, forbidden("access denied: only managers can view team assets")
 	}
 
 	// Check if manager belongs to this team
-	team, err := s.teamRepo.GetByID(teamID)
+	team, err := s.teamRepo.GetByID(ctx, teamID)
 	if err != nil {
-		return nil, fmt.Errorf("team not found: %w", err)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, notFound("team not found")
+		}
+		return nil, fmt.Errorf("failed to get team: %w", err)
 	}
 
 	isTeamManager := false
@@ -51,14 +56,14 @@ func (s *managerService) GetTeamAssets(teamID, managerID uuid.UUID) ([]*models.A
 		}
 	}
 	if !isTeamManager {
-		return nil, errors.New("access denied: you are not a manager of this team")
+		return nil, forbidden("access denied: you are not a manager of this team")
 	}
 
 	var allAssets []*models.AssetInfo
 
 	// Get assets for each team member
 	for _, member := range team.Members {
-		memberAssets, err := s.getUserAssetsInternal(member.UserID)
+		memberAssets, err := s.getUserAssetsInternal(ctx, member.UserID)
 		if err != nil {
 			return nil, fmt.Errorf("failed to get assets for member %s: %w", member.Username, err)
 		}
@@ -68,23 +73,23 @@ func (s *managerService) GetTeamAssets(teamID, managerID uuid.UUID) ([]*models.A
 	return allAssets, nil
 }
 
-func (s *managerService) GetUserAssets(targetUserID, managerID uuid.UUID) ([]*models.AssetInfo, error) {
+func (s *managerService) GetUserAssets(ctx context.Context, targetUserID, managerID uuid.UUID) ([]*models.AssetInfo, error) {
 	// Check if user is a manager
-	isManager, err := s.userRepo.CheckIfManager(managerID)
+	isManager, err := s.userRepo.CheckIfManager(ctx, managerID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to check manager status: %w", err)
 	}
 	if !isManager {
-		return nil, errors.New("access denied: only managers can view user assets")
+		return nil, forbidden("access denied: only managers can view user assets")
 	}
 
 	// Check if manager and target user are in the same team
-	managerTeams, err := s.teamRepo.GetTeamsByManagerID(managerID)
+	managerTeams, err := s.teamRepo.GetTeamsByManagerID(ctx, managerID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get manager teams: %w", err)
 	}
 
-	userTeams, err := s.teamRepo.GetTeamsByMemberID(targetUserID)
+	userTeams, err := s.teamRepo.GetTeamsByMemberID(ctx, targetUserID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get user teams: %w", err)
 	}
@@ -104,23 +109,26 @@ func (s *managerService) GetUserAssets(targetUserID, managerID uuid.UUID) ([]*mo
 	}
 
 	if !shareTeam {
-		return nil, errors.New("access denied: you can only view assets of users in your teams")
+		return nil, forbidden("access denied: you can only view assets of users in your teams")
 	}
 
-	return s.getUserAssetsInternal(targetUserID)
+	return s.getUserAssetsInternal(ctx, targetUserID)
 }
 
-func (s *managerService) getUserAssetsInternal(userID uuid.UUID) ([]*models.AssetInfo, error) {
+func (s *managerService) getUserAssetsInternal(ctx context.Context, userID uuid.UUID) ([]*models.AssetInfo, error) {
 	var assets []*models.AssetInfo
 
 	// Get user info
-	user, err := s.userRepo.GetByID(userID)
+	user, err := s.userRepo.GetByID(ctx, userID)
 	if err != nil {
-		return nil, fmt.Errorf("user not found: %w", err)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, notFound("user not found")
+		}
+		return nil, fmt.Errorf("failed to get user: %w", err)
 	}
 
 	// Get owned folders
-	folders, err := s.folderRepo.GetByOwnerID(userID)
+	folders, err := s.folderRepo.GetByOwnerID(ctx, userID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get user folders: %w", err)
 	}
@@ -137,7 +145,7 @@ func (s *managerService) getUserAssetsInternal(userID uuid.UUID) ([]*models.Asse
 	}
 
 	// Get owned notes
-	notes, err := s.noteRepo.GetByOwnerID(userID)
+	notes, err := s.noteRepo.GetByOwnerID(ctx, userID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get user notes: %w", err)
 	}
@@ -154,13 +162,13 @@ func (s *managerService) getUserAssetsInternal(userID uuid.UUID) ([]*models.Asse
 	}
 
 	// Get shared folders
-	sharedFolders, err := s.folderRepo.GetSharedFolders(userID)
+	sharedFolders, err := s.folderRepo.GetSharedFolders(ctx, userID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get shared folders: %w", err)
 	}
 
 	for _, folder := range sharedFolders {
-		accessLevel, _ := s.shareRepo.CheckFolderAccess(folder.FolderID, userID)
+		accessLevel, _ := s.shareRepo.CheckFolderAccess(ctx, folder.FolderID, userID)
 		assets = append(assets, &models.AssetInfo{
 			Type:        "folder",
 			ID:          folder.FolderID,
@@ -173,13 +181,13 @@ func (s *managerService) getUserAssetsInternal(userID uuid.UUID) ([]*models.Asse
 	}
 
 	// Get shared notes
-	sharedNotes, err := s.noteRepo.GetSharedNotes(userID)
+	sharedNotes, err := s.noteRepo.GetSharedNotes(ctx, userID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get shared notes: %w", err)
 	}
 
 	for _, note := range sharedNotes {
-		accessLevel, _ := s.shareRepo.CheckNoteAccess(note.NoteID, userID)
+		accessLevel, _ := s.shareRepo.CheckNoteAccess(ctx, note.NoteID, userID)
 		assets = append(assets, &models.AssetInfo{
 			Type:        "note",
 			ID:          note.NoteID,
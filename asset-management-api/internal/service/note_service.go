@@ -1,47 +1,53 @@
 package service
 
 import (
+	"asset-management-api/internal/events/types"
 	"asset-management-api/internal/models"
 	"asset-management-api/internal/repository/interfaces"
 	serviceInterfaces "asset-management-api/internal/service/interfaces"
+	"asset-management-api/pkg/eventbus"
+	"context"
 	"errors"
 	"fmt"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"log"
 )
 
 type noteService struct {
 	noteRepo   interfaces.NoteRepository
 	folderRepo interfaces.FolderRepository
 	shareRepo  interfaces.ShareRepository
+	eventBus   eventbus.EventBus
 }
 
-func NewNoteService(noteRepo interfaces.NoteRepository, folderRepo interfaces.FolderRepository, shareRepo interfaces.ShareRepository) serviceInterfaces.NoteService {
+func NewNoteService(noteRepo interfaces.NoteRepository, folderRepo interfaces.FolderRepository, shareRepo interfaces.ShareRepository, eventBus eventbus.EventBus) serviceInterfaces.NoteService {
 	return &noteService{
 		noteRepo:   noteRepo,
 		folderRepo: folderRepo,
 		shareRepo:  shareRepo,
+		eventBus:   eventBus,
 	}
 }
 
-func (s *noteService) CreateNote(userID, folderID uuid.UUID, title, body string) (*models.Note, error) {
+func (s *noteService) CreateNote(ctx context.Context, userID, folderID uuid.UUID, title, body string) (*models.Note, error) {
 	if title == "" {
-		return nil, errors.New("note title is required")
+		return nil, invalid("note title is required")
 	}
 
 	// Check if user owns the folder or has write access
-	isOwner, err := s.folderRepo.CheckOwnership(folderID, userID)
+	isOwner, err := s.folderRepo.CheckOwnership(ctx, folderID, userID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to check folder ownership: %w", err)
 	}
 
 	if !isOwner {
-		accessLevel, err := s.shareRepo.CheckFolderAccess(folderID, userID)
+		accessLevel, err := s.shareRepo.CheckFolderAccess(ctx, folderID, userID)
 		if err != nil {
 			return nil, fmt.Errorf("failed to check folder access: %w", err)
 		}
 		if accessLevel != "write" {
-			return nil, errors.New("access denied: you don't have write permission for this folder")
+			return nil, forbidden("access denied: you don't have write permission for this folder")
 		}
 	}
 
@@ -52,47 +58,49 @@ func (s *noteService) CreateNote(userID, folderID uuid.UUID, title, body string)
 		OwnerID:  userID,
 	}
 
-	err = s.noteRepo.Create(note)
+	err = s.noteRepo.Create(ctx, note)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create note: %w", err)
 	}
 
+	s.publishNoteCreatedEvent(ctx, note)
+
 	return note, nil
 }
 
-func (s *noteService) GetNote(noteID, userID uuid.UUID) (*models.Note, error) {
+func (s *noteService) GetNote(ctx context.Context, noteID, userID uuid.UUID) (*models.Note, error) {
 	// Check if user owns the note
-	isOwner, err := s.noteRepo.CheckOwnership(noteID, userID)
+	isOwner, err := s.noteRepo.CheckOwnership(ctx, noteID, userID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to check note ownership: %w", err)
 	}
 
 	if !isOwner {
 		// Check if note is shared with user
-		accessLevel, err := s.shareRepo.CheckNoteAccess(noteID, userID)
+		accessLevel, err := s.shareRepo.CheckNoteAccess(ctx, noteID, userID)
 		if err != nil {
 			return nil, fmt.Errorf("failed to check note access: %w", err)
 		}
 		if accessLevel == "" {
 			// Check if user has access to the folder containing this note
-			note, err := s.noteRepo.GetByID(noteID)
+			note, err := s.noteRepo.GetByID(ctx, noteID)
 			if err != nil {
 				return nil, fmt.Errorf("failed to get note: %w", err)
 			}
-			folderAccessLevel, err := s.shareRepo.CheckFolderAccess(note.FolderID, userID)
+			folderAccessLevel, err := s.shareRepo.CheckFolderAccess(ctx, note.FolderID, userID)
 			if err != nil {
 				return nil, fmt.Errorf("failed to check folder access: %w", err)
 			}
 			if folderAccessLevel == "" {
-				return nil, errors.New("access denied: you don't have permission to view this note")
+				return nil, forbidden("access denied: you don't have permission to view this note")
 			}
 		}
 	}
 
-	note, err := s.noteRepo.GetByID(noteID)
+	note, err := s.noteRepo.GetByID(ctx, noteID)
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
-			return nil, errors.New("note not found")
+			return nil, notFound("note not found")
 		}
 		return nil, fmt.Errorf("failed to get note: %w", err)
 	}
@@ -100,110 +108,194 @@ func (s *noteService) GetNote(noteID, userID uuid.UUID) (*models.Note, error) {
 	return note, nil
 }
 
-func (s *noteService) UpdateNote(noteID, userID uuid.UUID, title, body string) (*models.Note, error) {
+func (s *noteService) UpdateNote(ctx context.Context, noteID, userID uuid.UUID, title, body string) (*models.Note, error) {
 	if title == "" {
-		return nil, errors.New("note title is required")
+		return nil, invalid("note title is required")
 	}
 
 	// Check if user owns the note or has write access
-	isOwner, err := s.noteRepo.CheckOwnership(noteID, userID)
+	isOwner, err := s.noteRepo.CheckOwnership(ctx, noteID, userID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to check note ownership: %w", err)
 	}
 
 	if !isOwner {
-		accessLevel, err := s.shareRepo.CheckNoteAccess(noteID, userID)
+		accessLevel, err := s.shareRepo.CheckNoteAccess(ctx, noteID, userID)
 		if err != nil {
 			return nil, fmt.Errorf("failed to check note access: %w", err)
 		}
 		if accessLevel != "write" {
 			// Check folder access as fallback
-			note, err := s.noteRepo.GetByID(noteID)
+			note, err := s.noteRepo.GetByID(ctx, noteID)
 			if err != nil {
 				return nil, fmt.Errorf("failed to get note: %w", err)
 			}
-			folderAccessLevel, err := s.shareRepo.CheckFolderAccess(note.FolderID, userID)
+			folderAccessLevel, err := s.shareRepo.CheckFolderAccess(ctx, note.FolderID, userID)
 			if err != nil {
 				return nil, fmt.Errorf("failed to check folder access: %w", err)
 			}
 			if folderAccessLevel != "write" {
-				return nil, errors.New("access denied: you don't have write permission for this note")
+				return nil, forbidden("access denied: you don't have write permission for this note")
 			}
 		}
 	}
 
-	note, err := s.noteRepo.GetByID(noteID)
+	note, err := s.noteRepo.GetByID(ctx, noteID)
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
-			return nil, errors.New("note not found")
+			return nil, notFound("note not found")
 		}
 		return nil, fmt.Errorf("failed to get note: %w", err)
 	}
 
+	// Track changes for event
+	var changes []string
+	if note.Title != title {
+		changes = append(changes, "title")
+	}
+	if note.Body != body {
+		changes = append(changes, "body")
+	}
+
 	note.Title = title
 	note.Body = body
 
-	err = s.noteRepo.Update(note)
+	err = s.noteRepo.Update(ctx, note)
 	if err != nil {
+		if errors.Is(err, interfaces.ErrVersionConflict) {
+			return nil, conflict("note was modified concurrently, please retry")
+		}
 		return nil, fmt.Errorf("failed to update note: %w", err)
 	}
 
+	if len(changes) > 0 {
+		s.publishNoteUpdatedEvent(ctx, note, userID, changes)
+	}
+
 	return note, nil
 }
 
-func (s *noteService) DeleteNote(noteID, userID uuid.UUID) error {
+func (s *noteService) DeleteNote(ctx context.Context, noteID, userID uuid.UUID) error {
 	// Only the owner can delete a note
-	isOwner, err := s.noteRepo.CheckOwnership(noteID, userID)
+	isOwner, err := s.noteRepo.CheckOwnership(ctx, noteID, userID)
 	if err != nil {
 		return fmt.Errorf("failed to check note ownership: %w", err)
 	}
 
 	if !isOwner {
-		return errors.New("access denied: only the note owner can delete it")
+		return forbidden("access denied: only the note owner can delete it")
 	}
 
-	err = s.noteRepo.Delete(noteID)
+	// Get note info before deletion
+	note, err := s.noteRepo.GetByID(ctx, noteID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return notFound("note not found")
+		}
+		return fmt.Errorf("failed to get note: %w", err)
+	}
+
+	err = s.noteRepo.Delete(ctx, noteID)
 	if err != nil {
 		return fmt.Errorf("failed to delete note: %w", err)
 	}
 
+	s.publishNoteDeletedEvent(ctx, note, userID)
+
 	return nil
 }
 
-func (s *noteService) GetNotesByFolder(folderID, userID uuid.UUID) ([]*models.Note, error) {
-	// Check if user has access to the folder
-	isOwner, err := s.folderRepo.CheckOwnership(folderID, userID)
+func (s *noteService) GetNotesByFolder(ctx context.Context, folderID, userID uuid.UUID) ([]*models.Note, error) {
+	if err := s.checkFolderAccess(ctx, folderID, userID); err != nil {
+		return nil, err
+	}
+
+	notes, err := s.noteRepo.GetByFolderID(ctx, folderID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to check folder ownership: %w", err)
+		return nil, fmt.Errorf("failed to get notes: %w", err)
 	}
 
-	if !isOwner {
-		accessLevel, err := s.shareRepo.CheckFolderAccess(folderID, userID)
-		if err != nil {
-			return nil, fmt.Errorf("failed to check folder access: %w", err)
-		}
-		if accessLevel == "" {
-			return nil, errors.New("access denied: you don't have permission to view this folder")
+	return notes, nil
+}
+
+func (s *noteService) ListNotesByFolder(ctx context.Context, folderID, userID uuid.UUID, req models.PageRequest) (*models.Page[*models.Note], error) {
+	if err := s.checkFolderAccess(ctx, folderID, userID); err != nil {
+		return nil, err
+	}
+
+	page, err := s.noteRepo.ListByFolderID(ctx, folderID, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list notes: %w", err)
+	}
+
+	return page, nil
+}
+
+func (s *noteService) GetNotesByFolders(ctx context.Context, folderIDs []uuid.UUID, userID uuid.UUID) (map[uuid.UUID][]*models.Note, error) {
+	folders, err := s.folderRepo.GetByIDs(ctx, folderIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get folders: %w", err)
+	}
+	sharedIDs, err := s.folderRepo.GetSharedFolderIDs(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get shared folders: %w", err)
+	}
+	shared := make(map[uuid.UUID]bool, len(sharedIDs))
+	for _, folderID := range sharedIDs {
+		shared[folderID] = true
+	}
+
+	// The same rule as checkFolderAccess, for all the folders at once
+	notesByFolder := make(map[uuid.UUID][]*models.Note, len(folders))
+	var accessible []uuid.UUID
+	for _, folder := range folders {
+		if folder.OwnerID == userID || shared[folder.FolderID] {
+			notesByFolder[folder.FolderID] = []*models.Note{}
+			accessible = append(accessible, folder.FolderID)
 		}
 	}
+	if len(accessible) == 0 {
+		return notesByFolder, nil
+	}
 
-	notes, err := s.noteRepo.GetByFolderID(folderID)
+	notes, err := s.noteRepo.GetByFolderIDs(ctx, accessible)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get notes: %w", err)
 	}
+	for _, note := range notes {
+		notesByFolder[note.FolderID] = append(notesByFolder[note.FolderID], note)
+	}
+	return notesByFolder, nil
+}
 
-	return notes, nil
+// checkFolderAccess checks that the user owns the folder or it is shared with them
+func (s *noteService) checkFolderAccess(ctx context.Context, folderID, userID uuid.UUID) error {
+	isOwner, err := s.folderRepo.CheckOwnership(ctx, folderID, userID)
+	if err != nil {
+		return fmt.Errorf("failed to check folder ownership: %w", err)
+	}
+
+	if !isOwner {
+		accessLevel, err := s.shareRepo.CheckFolderAccess(ctx, folderID, userID)
+		if err != nil {
+			return fmt.Errorf("failed to check folder access: %w", err)
+		}
+		if accessLevel == "" {
+			return forbidden("access denied: you don't have permission to view this folder")
+		}
+	}
+	return nil
 }
 
-func (s *noteService) GetUserNotes(userID uuid.UUID) ([]*models.Note, error) {
+func (s *noteService) GetUserNotes(ctx context.Context, userID uuid.UUID) ([]*models.Note, error) {
 	// Get owned notes
-	ownedNotes, err := s.noteRepo.GetByOwnerID(userID)
+	ownedNotes, err := s.noteRepo.GetByOwnerID(ctx, userID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get owned notes: %w", err)
 	}
 
 	// Get shared notes
-	sharedNotes, err := s.noteRepo.GetSharedNotes(userID)
+	sharedNotes, err := s.noteRepo.GetSharedNotes(ctx, userID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get shared notes: %w", err)
 	}
@@ -211,4 +303,48 @@ func (s *noteService) GetUserNotes(userID uuid.UUID) ([]*models.Note, error) {
 	// Combine both lists
 	allNotes := append(ownedNotes, sharedNotes...)
 	return allNotes, nil
-}
\ No newline at end of file
+}
+
+func (s *noteService) ListUserNotes(ctx context.Context, userID uuid.UUID, req models.PageRequest) (*models.Page[*models.Note], error) {
+	page, err := s.noteRepo.ListAccessible(ctx, userID, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list notes: %w", err)
+	}
+	return page, nil
+}
+
+func (s *noteService) publishNoteCreatedEvent(ctx context.Context, note *models.Note) {
+	if s.eventBus == nil {
+		return
+	}
+
+	event := types.NewNoteCreatedEvent(note.NoteID, note.OwnerID, note.OwnerID, note.FolderID, note.Title, note.Body)
+
+	if err := s.eventBus.Publish(ctx, types.AssetChangesTopic, event); err != nil {
+		log.Printf("Failed to publish note created event: %v", err)
+	}
+}
+
+func (s *noteService) publishNoteUpdatedEvent(ctx context.Context, note *models.Note, actionBy uuid.UUID, changes []string) {
+	if s.eventBus == nil {
+		return
+	}
+
+	event := types.NewNoteUpdatedEvent(note.NoteID, note.OwnerID, actionBy, note.Title, note.Body, changes)
+
+	if err := s.eventBus.Publish(ctx, types.AssetChangesTopic, event); err != nil {
+		log.Printf("Failed to publish note updated event: %v", err)
+	}
+}
+
+func (s *noteService) publishNoteDeletedEvent(ctx context.Context, note *models.Note, actionBy uuid.UUID) {
+	if s.eventBus == nil {
+		return
+	}
+
+	event := types.NewNoteDeletedEvent(note.NoteID, note.OwnerID, actionBy, note.Title)
+
+	if err := s.eventBus.Publish(ctx, types.AssetChangesTopic, event); err != nil {
+		log.Printf("Failed to publish note deleted event: %v", err)
+	}
+}
@@ -0,0 +1,168 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"testing"
+
+	"asset-management-api/internal/events/memory"
+	"asset-management-api/internal/events/types"
+	"asset-management-api/internal/models"
+	"asset-management-api/internal/notification"
+	"asset-management-api/internal/repository/interfaces"
+
+	"github.com/google/uuid"
+)
+
+// sharedNoteRepo shares every note with the user at the access level
+type sharedNoteRepo struct {
+	interfaces.ShareRepository
+	userID      uuid.UUID
+	accessLevel string
+}
+
+func (r sharedNoteRepo) CheckFolderAccess(ctx context.Context, folderID, userID uuid.UUID) (string, error) {
+	return "", nil
+}
+
+func (r sharedNoteRepo) CheckNoteAccess(ctx context.Context, noteID, userID uuid.UUID) (string, error) {
+	if userID == r.userID {
+		return r.accessLevel, nil
+	}
+	return "", nil
+}
+
+// staticACL is the ACL source of a single note
+type staticACL map[string]string
+
+func (a staticACL) FolderACL(ctx context.Context, folderID uuid.UUID) (map[string]string, error) {
+	return map[string]string{}, nil
+}
+
+func (a staticACL) NoteACL(ctx context.Context, noteID uuid.UUID) (map[string]string, error) {
+	return a, nil
+}
+
+type fakeSubscriptionRepo struct {
+	interfaces.SubscriptionRepository
+
+	mu            sync.Mutex
+	subscriptions []*models.Subscription
+	notifications []*models.Notification
+}
+
+func (r *fakeSubscriptionRepo) GetByAssetIDs(ctx context.Context, assetIDs []uuid.UUID) ([]*models.Subscription, error) {
+	var matched []*models.Subscription
+	for _, subscription := range r.subscriptions {
+		for _, assetID := range assetIDs {
+			if subscription.AssetID == assetID {
+				matched = append(matched, subscription)
+			}
+		}
+	}
+	return matched, nil
+}
+
+func (r *fakeSubscriptionRepo) CreateNotifications(ctx context.Context, notifications []*models.Notification) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.notifications = append(r.notifications, notifications...)
+	return nil
+}
+
+func (r *fakeSubscriptionRepo) DeleteByAssetID(ctx context.Context, assetID uuid.UUID) error {
+	return nil
+}
+
+func TestNoteServicePublishesNoteEvents(t *testing.T) {
+	ctx := context.Background()
+	ownerID := uuid.New()
+	folder := models.Folder{FolderID: uuid.New(), Name: "Plans", OwnerID: ownerID}
+	folderRepo := &fakeFolderRepo{folders: map[uuid.UUID]models.Folder{folder.FolderID: folder}}
+	noteRepo := &fakeNoteRepo{notes: make(map[uuid.UUID]models.Note)}
+
+	bus := memory.NewInMemoryEventBus("test", 0)
+	defer bus.Close()
+	var published []types.BaseAssetEvent
+	err := bus.Subscribe(ctx, types.AssetChangesTopic, func(ctx context.Context, eventData []byte) error {
+		envelope, err := types.OpenEnvelope(eventData)
+		if err != nil {
+			return err
+		}
+		var event types.BaseAssetEvent
+		if err := json.Unmarshal(envelope.Payload, &event); err != nil {
+			return err
+		}
+		published = append(published, event)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Subscribe: %v", err)
+	}
+
+	noteService := NewNoteService(noteRepo, folderRepo, fakeShareRepo{}, bus)
+	note, err := noteService.CreateNote(ctx, ownerID, folder.FolderID, "Standup", "")
+	if err != nil {
+		t.Fatalf("CreateNote: %v", err)
+	}
+	if _, err := noteService.UpdateNote(ctx, note.NoteID, ownerID, "Standup", "notes"); err != nil {
+		t.Fatalf("UpdateNote: %v", err)
+	}
+	// Saving the note unchanged is not an update
+	if _, err := noteService.UpdateNote(ctx, note.NoteID, ownerID, "Standup", "notes"); err != nil {
+		t.Fatalf("unchanged UpdateNote: %v", err)
+	}
+	if err := noteService.DeleteNote(ctx, note.NoteID, ownerID); err != nil {
+		t.Fatalf("DeleteNote: %v", err)
+	}
+
+	want := []string{types.NoteCreated, types.NoteUpdated, types.NoteDeleted}
+	if len(published) != len(want) {
+		t.Fatalf("published %d events, want %v", len(published), want)
+	}
+	for i, event := range published {
+		if event.EventType != want[i] {
+			t.Errorf("event %d is %s, want %s", i, event.EventType, want[i])
+		}
+		if event.AssetType != types.AssetTypeNote || event.AssetID != note.NoteID || event.OwnerID != ownerID {
+			t.Errorf("event %d is about %s %s of %s, want note %s of %s", i, event.AssetType, event.AssetID, event.OwnerID, note.NoteID, ownerID)
+		}
+	}
+}
+
+func TestNoteServiceNotifiesSubscribersOfSharedNoteUpdates(t *testing.T) {
+	ctx := context.Background()
+	ownerID, recipientID := uuid.New(), uuid.New()
+	note := models.Note{NoteID: uuid.New(), Title: "Standup", FolderID: uuid.New(), OwnerID: ownerID}
+	noteRepo := &fakeNoteRepo{notes: map[uuid.UUID]models.Note{note.NoteID: note}}
+	subscriptionRepo := &fakeSubscriptionRepo{subscriptions: []*models.Subscription{{
+		SubscriptionID: uuid.New(),
+		UserID:         recipientID,
+		AssetType:      types.AssetTypeNote,
+		AssetID:        note.NoteID,
+	}}}
+	acl := staticACL{ownerID.String(): "owner", recipientID.String(): "read"}
+
+	bus := memory.NewInMemoryEventBus("test", 0)
+	defer bus.Close()
+	notifier := notification.NewNotifier(subscriptionRepo, noteRepo, acl, nil)
+	if err := bus.Subscribe(ctx, types.AssetChangesTopic, notifier.HandleAssetEvent); err != nil {
+		t.Fatalf("Subscribe: %v", err)
+	}
+
+	shares := sharedNoteRepo{userID: recipientID, accessLevel: "read"}
+	noteService := NewNoteService(noteRepo, &fakeFolderRepo{}, shares, bus)
+	if _, err := noteService.UpdateNote(ctx, note.NoteID, ownerID, "Standup", "moved to 10:00"); err != nil {
+		t.Fatalf("UpdateNote: %v", err)
+	}
+
+	if len(subscriptionRepo.notifications) != 1 {
+		t.Fatalf("recorded %d notifications, want 1", len(subscriptionRepo.notifications))
+	}
+	got := subscriptionRepo.notifications[0]
+	if got.UserID != recipientID || got.EventType != types.NoteUpdated || got.AssetID != note.NoteID || got.ActionBy != ownerID {
+		t.Errorf("notified %s of %s on %s by %s, want %s of %s on %s by %s",
+			got.UserID, got.EventType, got.AssetID, got.ActionBy, recipientID, types.NoteUpdated, note.NoteID, ownerID)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"asset-management-api/internal/models"
 	"asset-management-api/internal/repository/interfaces"
 	serviceInterfaces "asset-management-api/internal/service/interfaces"
+	"asset-management-api/pkg/cache"
 	"asset-management-api/pkg/eventbus"
 	"context"
 	"errors"
@@ -12,6 +13,7 @@ import (
 	"log"
 
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
 type shareService struct {
@@ -34,33 +36,36 @@ func NewShareService(shareRepo interfaces.ShareRepository, folderRepo interfaces
 }
 
 // Folder sharing methods
-func (s *shareService) ShareFolder(folderID, ownerID, targetUserID uuid.UUID, accessLevel string) error {
+func (s *shareService) ShareFolder(ctx context.Context, folderID, ownerID, targetUserID uuid.UUID, accessLevel string) error {
 	if accessLevel != "read" && accessLevel != "write" {
-		return errors.New("access level must be 'read' or 'write'")
+		return invalid("access level must be 'read' or 'write'")
 	}
 
 	// Check if the user owns the folder
-	isOwner, err := s.folderRepo.CheckOwnership(folderID, ownerID)
+	isOwner, err := s.folderRepo.CheckOwnership(ctx, folderID, ownerID)
 	if err != nil {
 		return fmt.Errorf("failed to check folder ownership: %w", err)
 	}
 	if !isOwner {
-		return errors.New("access denied: only the folder owner can share it")
+		return forbidden("access denied: only the folder owner can share it")
 	}
 
 	// Check if target user exists
-	targetUser, err := s.userRepo.GetByID(targetUserID)
+	_, err = s.userRepo.GetByID(ctx, targetUserID)
 	if err != nil {
-		return fmt.Errorf("target user not found: %w", err)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return notFound("target user not found")
+		}
+		return fmt.Errorf("failed to get user: %w", err)
 	}
 
 	// Don't allow sharing with the owner
 	if ownerID == targetUserID {
-		return errors.New("cannot share folder with yourself")
+		return invalid("cannot share folder with yourself")
 	}
 
 	// Get owner info for event
-	ownerUser, err := s.userRepo.GetByID(ownerID)
+	ownerUser, err := s.userRepo.GetByID(ctx, ownerID)
 	if err != nil {
 		return fmt.Errorf("owner user not found: %w", err)
 	}
@@ -72,55 +77,58 @@ func (s *shareService) ShareFolder(folderID, ownerID, targetUserID uuid.UUID, ac
 		SharedBy:         ownerID,
 	}
 
-	err = s.shareRepo.ShareFolder(folderShare)
+	err = s.shareRepo.ShareFolder(ctx, folderShare)
 	if err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return conflict("folder is already shared with this user")
+		}
 		return fmt.Errorf("failed to share folder: %w", err)
 	}
 
 	// NEW: Publish folder shared event
-	s.publishFolderSharedEvent(folderID, ownerID, targetUserID, accessLevel, ownerUser.Username)
+	s.publishFolderSharedEvent(ctx, folderID, ownerID, targetUserID, accessLevel, ownerUser.Username)
 
 	return nil
 }
 
-func (s *shareService) UnshareFolder(folderID, ownerID, targetUserID uuid.UUID) error {
+func (s *shareService) UnshareFolder(ctx context.Context, folderID, ownerID, targetUserID uuid.UUID) error {
 	// Check if the user owns the folder
-	isOwner, err := s.folderRepo.CheckOwnership(folderID, ownerID)
+	isOwner, err := s.folderRepo.CheckOwnership(ctx, folderID, ownerID)
 	if err != nil {
 		return fmt.Errorf("failed to check folder ownership: %w", err)
 	}
 	if !isOwner {
-		return errors.New("access denied: only the folder owner can unshare it")
+		return forbidden("access denied: only the folder owner can unshare it")
 	}
 
 	// Get owner info for event
-	ownerUser, err := s.userRepo.GetByID(ownerID)
+	ownerUser, err := s.userRepo.GetByID(ctx, ownerID)
 	if err != nil {
 		return fmt.Errorf("owner user not found: %w", err)
 	}
 
-	err = s.shareRepo.UnshareFolder(folderID, targetUserID)
+	err = s.shareRepo.UnshareFolder(ctx, folderID, targetUserID)
 	if err != nil {
 		return fmt.Errorf("failed to unshare folder: %w", err)
 	}
 
 	// NEW: Publish folder unshared event
-	s.publishFolderUnsharedEvent(folderID, ownerID, targetUserID, ownerUser.Username)
+	s.publishFolderUnsharedEvent(ctx, folderID, ownerID, targetUserID, ownerUser.Username)
 
 	return nil
 }
 
-func (s *shareService) GetFolderShares(folderID, userID uuid.UUID) ([]*models.FolderShare, error) {
+func (s *shareService) GetFolderShares(ctx context.Context, folderID, userID uuid.UUID) ([]*models.FolderShare, error) {
 	// Check if the user owns the folder
-	isOwner, err := s.folderRepo.CheckOwnership(folderID, userID)
+	isOwner, err := s.folderRepo.CheckOwnership(ctx, folderID, userID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to check folder ownership: %w", err)
 	}
 	if !isOwner {
-		return nil, errors.New("access denied: only the folder owner can view shares")
+		return nil, forbidden("access denied: only the folder owner can view shares")
 	}
 
-	shares, err := s.shareRepo.GetFolderShares(folderID)
+	shares, err := s.shareRepo.GetFolderShares(ctx, folderID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get folder shares: %w", err)
 	}
@@ -128,34 +136,84 @@ func (s *shareService) GetFolderShares(folderID, userID uuid.UUID) ([]*models.Fo
 	return shares, nil
 }
 
+func (s *shareService) GetSharesByFolders(ctx context.Context, folderIDs []uuid.UUID, userID uuid.UUID) (map[uuid.UUID][]*models.FolderShare, error) {
+	folders, err := s.folderRepo.GetByIDs(ctx, folderIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get folders: %w", err)
+	}
+
+	// Only the folder owner can view shares
+	sharesByFolder := make(map[uuid.UUID][]*models.FolderShare, len(folders))
+	var owned []uuid.UUID
+	for _, folder := range folders {
+		if folder.OwnerID == userID {
+			sharesByFolder[folder.FolderID] = []*models.FolderShare{}
+			owned = append(owned, folder.FolderID)
+		}
+	}
+	if len(owned) == 0 {
+		return sharesByFolder, nil
+	}
+
+	shares, err := s.shareRepo.GetSharesByFolderIDs(ctx, owned)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get folder shares: %w", err)
+	}
+	for _, share := range shares {
+		sharesByFolder[share.FolderID] = append(sharesByFolder[share.FolderID], share)
+	}
+	return sharesByFolder, nil
+}
+
+func (s *shareService) ListFolderShares(ctx context.Context, folderID, userID uuid.UUID, req models.PageRequest) (*models.Page[*models.FolderShare], error) {
+	// Check if the user owns the folder
+	isOwner, err := s.folderRepo.CheckOwnership(ctx, folderID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check folder ownership: %w", err)
+	}
+	if !isOwner {
+		return nil, forbidden("access denied: only the folder owner can view shares")
+	}
+
+	page, err := s.shareRepo.ListFolderShares(ctx, folderID, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list folder shares: %w", err)
+	}
+
+	return page, nil
+}
+
 // Note sharing methods
-func (s *shareService) ShareNote(noteID, ownerID, targetUserID uuid.UUID, accessLevel string) error {
+func (s *shareService) ShareNote(ctx context.Context, noteID, ownerID, targetUserID uuid.UUID, accessLevel string) error {
 	if accessLevel != "read" && accessLevel != "write" {
-		return errors.New("access level must be 'read' or 'write'")
+		return invalid("access level must be 'read' or 'write'")
 	}
 
 	// Check if the user owns the note
-	isOwner, err := s.noteRepo.CheckOwnership(noteID, ownerID)
+	isOwner, err := s.noteRepo.CheckOwnership(ctx, noteID, ownerID)
 	if err != nil {
 		return fmt.Errorf("failed to check note ownership: %w", err)
 	}
 	if !isOwner {
-		return errors.New("access denied: only the note owner can share it")
+		return forbidden("access denied: only the note owner can share it")
 	}
 
 	// Check if target user exists
-	targetUser, err := s.userRepo.GetByID(targetUserID)
+	_, err = s.userRepo.GetByID(ctx, targetUserID)
 	if err != nil {
-		return fmt.Errorf("target user not found: %w", err)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return notFound("target user not found")
+		}
+		return fmt.Errorf("failed to get user: %w", err)
 	}
 
 	// Don't allow sharing with the owner
 	if ownerID == targetUserID {
-		return errors.New("cannot share note with yourself")
+		return invalid("cannot share note with yourself")
 	}
 
 	// Get owner info for event
-	ownerUser, err := s.userRepo.GetByID(ownerID)
+	ownerUser, err := s.userRepo.GetByID(ctx, ownerID)
 	if err != nil {
 		return fmt.Errorf("owner user not found: %w", err)
 	}
@@ -167,55 +225,58 @@ func (s *shareService) ShareNote(noteID, ownerID, targetUserID uuid.UUID, access
 		SharedBy:         ownerID,
 	}
 
-	err = s.shareRepo.ShareNote(noteShare)
+	err = s.shareRepo.ShareNote(ctx, noteShare)
 	if err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return conflict("note is already shared with this user")
+		}
 		return fmt.Errorf("failed to share note: %w", err)
 	}
 
 	// NEW: Publish note shared event
-	s.publishNoteSharedEvent(noteID, ownerID, targetUserID, accessLevel, ownerUser.Username)
+	s.publishNoteSharedEvent(ctx, noteID, ownerID, targetUserID, accessLevel, ownerUser.Username)
 
 	return nil
 }
 
-func (s *shareService) UnshareNote(noteID, ownerID, targetUserID uuid.UUID) error {
+func (s *shareService) UnshareNote(ctx context.Context, noteID, ownerID, targetUserID uuid.UUID) error {
 	// Check if the user owns the note
-	isOwner, err := s.noteRepo.CheckOwnership(noteID, ownerID)
+	isOwner, err := s.noteRepo.CheckOwnership(ctx, noteID, ownerID)
 	if err != nil {
 		return fmt.Errorf("failed to check note ownership: %w", err)
 	}
 	if !isOwner {
-		return errors.New("access denied: only the note owner can unshare it")
+		return forbidden("access denied: only the note owner can unshare it")
 	}
 
 	// Get owner info for event
-	ownerUser, err := s.userRepo.GetByID(ownerID)
+	ownerUser, err := s.userRepo.GetByID(ctx, ownerID)
 	if err != nil {
 		return fmt.Errorf("owner user not found: %w", err)
 	}
 
-	err = s.shareRepo.UnshareNote(noteID, targetUserID)
+	err = s.shareRepo.UnshareNote(ctx, noteID, targetUserID)
 	if err != nil {
 		return fmt.Errorf("failed to unshare note: %w", err)
 	}
 
 	// NEW: Publish note unshared event
-	s.publishNoteUnsharedEvent(noteID, ownerID, targetUserID, ownerUser.Username)
+	s.publishNoteUnsharedEvent(ctx, noteID, ownerID, targetUserID, ownerUser.Username)
 
 	return nil
 }
 
-func (s *shareService) GetNoteShares(noteID, userID uuid.UUID) ([]*models.NoteShare, error) {
+func (s *shareService) GetNoteShares(ctx context.Context, noteID, userID uuid.UUID) ([]*models.NoteShare, error) {
 	// Check if the user owns the note
-	isOwner, err := s.noteRepo.CheckOwnership(noteID, userID)
+	isOwner, err := s.noteRepo.CheckOwnership(ctx, noteID, userID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to check note ownership: %w", err)
 	}
 	if !isOwner {
-		return nil, errors.New("access denied: only the note owner can view shares")
+		return nil, forbidden("access denied: only the note owner can view shares")
 	}
 
-	shares, err := s.shareRepo.GetNoteShares(noteID)
+	shares, err := s.shareRepo.GetNoteShares(ctx, noteID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get note shares: %w", err)
 	}
@@ -223,8 +284,55 @@ func (s *shareService) GetNoteShares(noteID, userID uuid.UUID) ([]*models.NoteSh
 	return shares, nil
 }
 
+func (s *shareService) GetSharesByNotes(ctx context.Context, noteIDs []uuid.UUID, userID uuid.UUID) (map[uuid.UUID][]*models.NoteShare, error) {
+	notes, err := s.noteRepo.GetByIDs(ctx, noteIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get notes: %w", err)
+	}
+
+	// Only the note owner can view shares
+	sharesByNote := make(map[uuid.UUID][]*models.NoteShare, len(notes))
+	var owned []uuid.UUID
+	for _, note := range notes {
+		if note.OwnerID == userID {
+			sharesByNote[note.NoteID] = []*models.NoteShare{}
+			owned = append(owned, note.NoteID)
+		}
+	}
+	if len(owned) == 0 {
+		return sharesByNote, nil
+	}
+
+	shares, err := s.shareRepo.GetSharesByNoteIDs(ctx, owned)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get note shares: %w", err)
+	}
+	for _, share := range shares {
+		sharesByNote[share.NoteID] = append(sharesByNote[share.NoteID], share)
+	}
+	return sharesByNote, nil
+}
+
+func (s *shareService) ListNoteShares(ctx context.Context, noteID, userID uuid.UUID, req models.PageRequest) (*models.Page[*models.NoteShare], error) {
+	// Check if the user owns the note
+	isOwner, err := s.noteRepo.CheckOwnership(ctx, noteID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check note ownership: %w", err)
+	}
+	if !isOwner {
+		return nil, forbidden("access denied: only the note owner can view shares")
+	}
+
+	page, err := s.shareRepo.ListNoteShares(ctx, noteID, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list note shares: %w", err)
+	}
+
+	return page, nil
+}
+
 // NEW: Event publishing methods for folder sharing
-func (s *shareService) publishFolderSharedEvent(folderID, ownerID, sharedWithUserID uuid.UUID, accessLevel, sharedByUserName string) {
+func (s *shareService) publishFolderSharedEvent(ctx context.Context, folderID, ownerID, sharedWithUserID uuid.UUID, accessLevel, sharedByUserName string) {
 	if s.eventBus == nil {
 		return
 	}
@@ -239,14 +347,14 @@ func (s *shareService) publishFolderSharedEvent(folderID, ownerID, sharedWithUse
 		accessLevel,
 		sharedByUserName,
 	)
+	event.ACL = s.folderACLSnapshot(ctx, folderID, ownerID)
 	
-	ctx := context.Background()
 	if err := s.eventBus.Publish(ctx, types.AssetChangesTopic, event); err != nil {
 		log.Printf("Failed to publish folder shared event: %v", err)
 	}
 }
 
-func (s *shareService) publishFolderUnsharedEvent(folderID, ownerID, unsharedFromUserID uuid.UUID, unsharedByUserName string) {
+func (s *shareService) publishFolderUnsharedEvent(ctx context.Context, folderID, ownerID, unsharedFromUserID uuid.UUID, unsharedByUserName string) {
 	if s.eventBus == nil {
 		return
 	}
@@ -260,15 +368,15 @@ func (s *shareService) publishFolderUnsharedEvent(folderID, ownerID, unsharedFro
 		unsharedFromUserID,
 		unsharedByUserName,
 	)
+	event.ACL = s.folderACLSnapshot(ctx, folderID, ownerID)
 	
-	ctx := context.Background()
 	if err := s.eventBus.Publish(ctx, types.AssetChangesTopic, event); err != nil {
 		log.Printf("Failed to publish folder unshared event: %v", err)
 	}
 }
 
 // NEW: Event publishing methods for note sharing
-func (s *shareService) publishNoteSharedEvent(noteID, ownerID, sharedWithUserID uuid.UUID, accessLevel, sharedByUserName string) {
+func (s *shareService) publishNoteSharedEvent(ctx context.Context, noteID, ownerID, sharedWithUserID uuid.UUID, accessLevel, sharedByUserName string) {
 	if s.eventBus == nil {
 		return
 	}
@@ -283,14 +391,14 @@ func (s *shareService) publishNoteSharedEvent(noteID, ownerID, sharedWithUserID
 		accessLevel,
 		sharedByUserName,
 	)
+	event.ACL = s.noteACLSnapshot(ctx, noteID, ownerID)
 	
-	ctx := context.Background()
 	if err := s.eventBus.Publish(ctx, types.AssetChangesTopic, event); err != nil {
 		log.Printf("Failed to publish note shared event: %v", err)
 	}
 }
 
-func (s *shareService) publishNoteUnsharedEvent(noteID, ownerID, unsharedFromUserID uuid.UUID, unsharedByUserName string) {
+func (s *shareService) publishNoteUnsharedEvent(ctx context.Context, noteID, ownerID, unsharedFromUserID uuid.UUID, unsharedByUserName string) {
 	if s.eventBus == nil {
 		return
 	}
@@ -304,9 +412,43 @@ func (s *shareService) publishNoteUnsharedEvent(noteID, ownerID, unsharedFromUse
 		unsharedFromUserID,
 		unsharedByUserName,
 	)
+	event.ACL = s.noteACLSnapshot(ctx, noteID, ownerID)
 	
-	ctx := context.Background()
 	if err := s.eventBus.Publish(ctx, types.AssetChangesTopic, event); err != nil {
 		log.Printf("Failed to publish note unshared event: %v", err)
 	}
+}
+
+// folderACLSnapshot loads the folder's ACL as it stands after a share change.
+// It returns nil if the shares cannot be loaded; the event then only carries
+// the delta.
+func (s *shareService) folderACLSnapshot(ctx context.Context, folderID, ownerID uuid.UUID) types.ACLSnapshot {
+	shares, err := s.shareRepo.GetFolderShares(ctx, folderID)
+	if err != nil {
+		log.Printf("Failed to load ACL snapshot of folder %s: %v", folderID, err)
+		return nil
+	}
+
+	acl := make(types.ACLSnapshot, len(shares)+1)
+	for _, share := range shares {
+		acl[share.SharedWithUserID.String()] = share.AccessLevel
+	}
+	acl[ownerID.String()] = cache.AccessLevelOwner
+	return acl
+}
+
+// noteACLSnapshot loads the note's ACL as it stands after a share change
+func (s *shareService) noteACLSnapshot(ctx context.Context, noteID, ownerID uuid.UUID) types.ACLSnapshot {
+	shares, err := s.shareRepo.GetNoteShares(ctx, noteID)
+	if err != nil {
+		log.Printf("Failed to load ACL snapshot of note %s: %v", noteID, err)
+		return nil
+	}
+
+	acl := make(types.ACLSnapshot, len(shares)+1)
+	for _, share := range shares {
+		acl[share.SharedWithUserID.String()] = share.AccessLevel
+	}
+	acl[ownerID.String()] = cache.AccessLevelOwner
+	return acl
 }
\ No newline at end of file
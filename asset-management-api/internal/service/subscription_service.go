@@ -0,0 +1,133 @@
+package service
+
+import (
+	"asset-management-api/internal/events/types"
+	"asset-management-api/internal/models"
+	"asset-management-api/internal/repository/interfaces"
+	serviceInterfaces "asset-management-api/internal/service/interfaces"
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"time"
+)
+
+// subscriptionEventTypes are the event types a subscription can filter on;
+// the folder and note services publish the created, updated and deleted
+// events, the share service the shared and unshared ones
+var subscriptionEventTypes = map[string]bool{
+	types.FolderCreated:  true,
+	types.FolderUpdated:  true,
+	types.FolderDeleted:  true,
+	types.FolderShared:   true,
+	types.FolderUnshared: true,
+	types.NoteCreated:    true,
+	types.NoteUpdated:    true,
+	types.NoteDeleted:    true,
+	types.NoteShared:     true,
+	types.NoteUnshared:   true,
+}
+
+const (
+	defaultNotificationsLimit = 50
+	maxNotificationsLimit     = 500
+)
+
+type subscriptionService struct {
+	subscriptionRepo interfaces.SubscriptionRepository
+	folderService    serviceInterfaces.FolderService
+	noteService      serviceInterfaces.NoteService
+}
+
+func NewSubscriptionService(subscriptionRepo interfaces.SubscriptionRepository, folderService serviceInterfaces.FolderService, noteService serviceInterfaces.NoteService) serviceInterfaces.SubscriptionService {
+	return &subscriptionService{
+		subscriptionRepo: subscriptionRepo,
+		folderService:    folderService,
+		noteService:      noteService,
+	}
+}
+
+// Subscribe registers the user's interest in a folder or note they can view
+func (s *subscriptionService) Subscribe(ctx context.Context, userID uuid.UUID, assetType string, assetID uuid.UUID, eventTypes []string) (*models.Subscription, error) {
+	for _, eventType := range eventTypes {
+		if !subscriptionEventTypes[eventType] {
+			return nil, invalid("unknown event type: %s", eventType)
+		}
+	}
+
+	// The folder and note services apply the same access rules as viewing the asset
+	switch assetType {
+	case types.AssetTypeFolder:
+		if _, err := s.folderService.GetFolder(ctx, assetID, userID); err != nil {
+			return nil, err
+		}
+	case types.AssetTypeNote:
+		if _, err := s.noteService.GetNote(ctx, assetID, userID); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, invalid("invalid asset type: %s", assetType)
+	}
+
+	existing, err := s.subscriptionRepo.GetByUserAndAsset(ctx, userID, assetID)
+	if err != nil && err != gorm.ErrRecordNotFound {
+		return nil, fmt.Errorf("failed to check existing subscription: %w", err)
+	}
+	if existing != nil {
+		return nil, conflict("already subscribed to this asset")
+	}
+
+	subscription := &models.Subscription{
+		UserID:     userID,
+		AssetType:  assetType,
+		AssetID:    assetID,
+		EventTypes: models.StringList(eventTypes),
+	}
+
+	if err := s.subscriptionRepo.Create(ctx, subscription); err != nil {
+		return nil, fmt.Errorf("failed to create subscription: %w", err)
+	}
+
+	return subscription, nil
+}
+
+func (s *subscriptionService) ListSubscriptions(ctx context.Context, userID uuid.UUID) ([]*models.Subscription, error) {
+	return s.subscriptionRepo.GetByUserID(ctx, userID)
+}
+
+func (s *subscriptionService) Unsubscribe(ctx context.Context, subscriptionID, userID uuid.UUID) error {
+	subscription, err := s.subscriptionRepo.GetByID(ctx, subscriptionID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return notFound("subscription not found")
+		}
+		return fmt.Errorf("failed to get subscription: %w", err)
+	}
+	if subscription.UserID != userID {
+		return notFound("subscription not found")
+	}
+
+	return s.subscriptionRepo.Delete(ctx, subscriptionID)
+}
+
+func (s *subscriptionService) GetNotifications(ctx context.Context, userID uuid.UUID, unreadOnly bool, limit int) ([]*models.Notification, error) {
+	if limit <= 0 {
+		limit = defaultNotificationsLimit
+	}
+	if limit > maxNotificationsLimit {
+		limit = maxNotificationsLimit
+	}
+
+	return s.subscriptionRepo.GetNotifications(ctx, userID, unreadOnly, limit)
+}
+
+func (s *subscriptionService) MarkNotificationRead(ctx context.Context, notificationID, userID uuid.UUID) error {
+	found, err := s.subscriptionRepo.MarkNotificationRead(ctx, notificationID, userID, time.Now().UTC())
+	if err != nil {
+		return fmt.Errorf("failed to mark notification read: %w", err)
+	}
+	if !found {
+		return notFound("notification not found")
+	}
+	return nil
+}
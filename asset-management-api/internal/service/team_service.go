@@ -17,280 +17,317 @@ import (
 type teamService struct {
 	teamRepo  interfaces.TeamRepository
 	userRepo  interfaces.UserRepository
+	txManager interfaces.TxManager
 	eventBus  eventbus.EventBus // NEW: Added event bus
 }
 
 // NEW: Updated constructor to accept event bus
-func NewTeamService(teamRepo interfaces.TeamRepository, userRepo interfaces.UserRepository, eventBus eventbus.EventBus) serviceInterfaces.TeamService {
+func NewTeamService(teamRepo interfaces.TeamRepository, userRepo interfaces.UserRepository, txManager interfaces.TxManager, eventBus eventbus.EventBus) serviceInterfaces.TeamService {
 	return &teamService{
-		teamRepo: teamRepo,
-		userRepo: userRepo,
-		eventBus: eventBus,
+		teamRepo:  teamRepo,
+		userRepo:  userRepo,
+		txManager: txManager,
+		eventBus:  eventBus,
 	}
 }
 
-func (s *teamService) CreateTeam(creatorID uuid.UUID, teamName string, managers []serviceInterfaces.TeamMemberInfo, members []serviceInterfaces.TeamMemberInfo) (*models.Team, error) {
+func (s *teamService) CreateTeam(ctx context.Context, creatorID uuid.UUID, teamName string, managers []serviceInterfaces.TeamMemberInfo, members []serviceInterfaces.TeamMemberInfo) (*models.Team, error) {
 	if teamName == "" {
-		return nil, errors.New("team name is required")
+		return nil, invalid("team name is required")
 	}
 
 	// Check if creator is a manager
-	isManager, err := s.userRepo.CheckIfManager(creatorID)
+	isManager, err := s.userRepo.CheckIfManager(ctx, creatorID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to check creator role: %w", err)
 	}
 	if !isManager {
-		return nil, errors.New("access denied: only managers can create teams")
-	}
-
-	// Create team
-	team := &models.Team{
-		TeamName:  teamName,
-		CreatedBy: creatorID,
-	}
-
-	err = s.teamRepo.Create(team)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create team: %w", err)
-	}
-
-	// Add creator as manager
-	err = s.teamRepo.AddManager(team.TeamID, creatorID)
-	if err != nil {
-		return nil, fmt.Errorf("failed to add creator as manager: %w", err)
+		return nil, forbidden("access denied: only managers can create teams")
 	}
 
+	var team *models.Team
 	var managerIDs []uuid.UUID
 	var memberIDs []uuid.UUID
 
-	// Add additional managers
-	for _, manager := range managers {
-		managerID, err := uuid.Parse(manager.UserID)
-		if err != nil {
-			continue // Skip invalid UUIDs
-		}
-		
-		// Check if user exists and has manager role
-		user, err := s.userRepo.GetByID(managerID)
-		if err != nil {
-			continue // Skip non-existent users
+	// Create the team with its managers and members in one transaction, so a
+	// failure does not leave a team without them
+	err = s.txManager.WithinTransaction(ctx, func(repos interfaces.Repositories) error {
+		// Start over when the transaction is retried
+		managerIDs, memberIDs = nil, nil
+
+		created := &models.Team{
+			TeamName:  teamName,
+			CreatedBy: creatorID,
 		}
-		if user.Role != "manager" {
-			continue // Skip non-managers
+		if err := repos.Teams.Create(ctx, created); err != nil {
+			return fmt.Errorf("failed to create team: %w", err)
 		}
 
-		// Don't add creator again
-		if managerID != creatorID {
-			s.teamRepo.AddManager(team.TeamID, managerID)
+		// Add creator as manager
+		if err := repos.Teams.AddManager(ctx, created.TeamID, creatorID); err != nil {
+			return fmt.Errorf("failed to add creator as manager: %w", err)
+		}
+		added := map[uuid.UUID]bool{creatorID: true}
+
+		// Add additional managers
+		for _, manager := range managers {
+			managerID, err := uuid.Parse(manager.UserID)
+			if err != nil {
+				continue // Skip invalid UUIDs
+			}
+			if added[managerID] {
+				continue // Don't add the creator or a manager twice
+			}
+
+			// Check if user exists and has manager role
+			user, err := repos.Users.GetByID(ctx, managerID)
+			if err != nil {
+				continue // Skip non-existent users
+			}
+			if user.Role != "manager" {
+				continue // Skip non-managers
+			}
+
+			if err := repos.Teams.AddManager(ctx, created.TeamID, managerID); err != nil {
+				return fmt.Errorf("failed to add manager %s: %w", managerID, err)
+			}
+			added[managerID] = true
 			managerIDs = append(managerIDs, managerID)
 		}
-	}
 
-	// Add creator to manager list for event
-	managerIDs = append(managerIDs, creatorID)
-
-	// Add members
-	for _, member := range members {
-		memberID, err := uuid.Parse(member.UserID)
-		if err != nil {
-			continue // Skip invalid UUIDs
-		}
-		
-		// Check if user exists
-		_, err = s.userRepo.GetByID(memberID)
-		if err != nil {
-			continue // Skip non-existent users
+		// Add members
+		addedMembers := make(map[uuid.UUID]bool)
+		for _, member := range members {
+			memberID, err := uuid.Parse(member.UserID)
+			if err != nil {
+				continue // Skip invalid UUIDs
+			}
+			if addedMembers[memberID] {
+				continue // Don't add a member twice
+			}
+
+			// Check if user exists
+			if _, err := repos.Users.GetByID(ctx, memberID); err != nil {
+				continue // Skip non-existent users
+			}
+
+			if err := repos.Teams.AddMember(ctx, created.TeamID, memberID); err != nil {
+				return fmt.Errorf("failed to add member %s: %w", memberID, err)
+			}
+			addedMembers[memberID] = true
+			memberIDs = append(memberIDs, memberID)
 		}
 
-		s.teamRepo.AddMember(team.TeamID, memberID)
-		memberIDs = append(memberIDs, memberID)
+		// Get the complete team with relationships
+		team, err = repos.Teams.GetByID(ctx, created.TeamID)
+		return err
+	})
+	if err != nil {
+		return nil, err
 	}
 
+	// Add creator to manager list for event
+	managerIDs = append(managerIDs, creatorID)
+
 	// NEW: Publish team created event
-	s.publishTeamCreatedEvent(team.TeamID, creatorID, teamName, managerIDs, memberIDs)
+	s.publishTeamCreatedEvent(ctx, team.TeamID, creatorID, teamName, managerIDs, memberIDs)
 
-	// Get the complete team with relationships
-	return s.teamRepo.GetByID(team.TeamID)
+	return team, nil
 }
 
-func (s *teamService) AddMember(teamID, requestorID, memberID uuid.UUID) error {
+func (s *teamService) AddMember(ctx context.Context, teamID, requestorID, memberID uuid.UUID) error {
 	// Check if requestor is a manager of the team
-	isTeamManager, err := s.teamRepo.IsTeamManager(teamID, requestorID)
+	isTeamManager, err := s.teamRepo.IsTeamManager(ctx, teamID, requestorID)
 	if err != nil {
 		return fmt.Errorf("failed to check team manager status: %w", err)
 	}
 	if !isTeamManager {
-		return errors.New("access denied: only team managers can add members")
+		return forbidden("access denied: only team managers can add members")
 	}
 
 	// Check if user exists
-	user, err := s.userRepo.GetByID(memberID)
+	user, err := s.userRepo.GetByID(ctx, memberID)
 	if err != nil {
-		return fmt.Errorf("user not found: %w", err)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return notFound("user not found")
+		}
+		return fmt.Errorf("failed to get user: %w", err)
 	}
 
 	// Check if user is already a team member or manager
-	isAlreadyMember, err := s.userRepo.CheckIfUserInTeam(memberID, teamID)
+	isAlreadyMember, err := s.userRepo.CheckIfUserInTeam(ctx, memberID, teamID)
 	if err != nil {
 		return fmt.Errorf("failed to check team membership: %w", err)
 	}
 	if isAlreadyMember {
-		return errors.New("user is already a member of this team")
+		return conflict("user is already a member of this team")
 	}
 
-	err = s.teamRepo.AddMember(teamID, memberID)
+	err = s.teamRepo.AddMember(ctx, teamID, memberID)
 	if err != nil {
 		return err
 	}
 
 	// NEW: Publish member added event
-	s.publishMemberAddedEvent(teamID, requestorID, memberID, user.Username)
+	s.publishMemberAddedEvent(ctx, teamID, requestorID, memberID, user.Username)
 
 	return nil
 }
 
-func (s *teamService) RemoveMember(teamID, requestorID, memberID uuid.UUID) error {
+func (s *teamService) RemoveMember(ctx context.Context, teamID, requestorID, memberID uuid.UUID) error {
 	// Check if requestor is a manager of the team
-	isTeamManager, err := s.teamRepo.IsTeamManager(teamID, requestorID)
+	isTeamManager, err := s.teamRepo.IsTeamManager(ctx, teamID, requestorID)
 	if err != nil {
 		return fmt.Errorf("failed to check team manager status: %w", err)
 	}
 	if !isTeamManager {
-		return errors.New("access denied: only team managers can remove members")
+		return forbidden("access denied: only team managers can remove members")
 	}
 
 	// Get user info before removal
-	user, err := s.userRepo.GetByID(memberID)
+	user, err := s.userRepo.GetByID(ctx, memberID)
 	if err != nil {
-		return fmt.Errorf("user not found: %w", err)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return notFound("user not found")
+		}
+		return fmt.Errorf("failed to get user: %w", err)
 	}
 
 	// Check if member exists in team
-	isMember, err := s.teamRepo.IsTeamMember(teamID, memberID)
+	isMember, err := s.teamRepo.IsTeamMember(ctx, teamID, memberID)
 	if err != nil {
 		return fmt.Errorf("failed to check team membership: %w", err)
 	}
 	if !isMember {
-		return errors.New("member not found in team")
+		return notFound("member not found in team")
 	}
 
-	err = s.teamRepo.RemoveMember(teamID, memberID)
+	err = s.teamRepo.RemoveMember(ctx, teamID, memberID)
 	if err != nil {
 		return err
 	}
 
 	// NEW: Publish member removed event
-	s.publishMemberRemovedEvent(teamID, requestorID, memberID, user.Username)
+	s.publishMemberRemovedEvent(ctx, teamID, requestorID, memberID, user.Username)
 
 	return nil
 }
 
-func (s *teamService) AddManager(teamID, requestorID, managerID uuid.UUID) error {
+func (s *teamService) AddManager(ctx context.Context, teamID, requestorID, managerID uuid.UUID) error {
 	// Check if requestor is a manager of the team
-	isTeamManager, err := s.teamRepo.IsTeamManager(teamID, requestorID)
+	isTeamManager, err := s.teamRepo.IsTeamManager(ctx, teamID, requestorID)
 	if err != nil {
 		return fmt.Errorf("failed to check team manager status: %w", err)
 	}
 	if !isTeamManager {
-		return errors.New("access denied: only team managers can add other managers")
+		return forbidden("access denied: only team managers can add other managers")
 	}
 
 	// Check if target user exists and has manager role
-	user, err := s.userRepo.GetByID(managerID)
+	user, err := s.userRepo.GetByID(ctx, managerID)
 	if err != nil {
-		return fmt.Errorf("user not found: %w", err)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return notFound("user not found")
+		}
+		return fmt.Errorf("failed to get user: %w", err)
 	}
 	if user.Role != "manager" {
-		return errors.New("target user must have manager role")
+		return invalid("target user must have manager role")
 	}
 
 	// Check if user is already a manager
-	isAlreadyManager, err := s.teamRepo.IsTeamManager(teamID, managerID)
+	isAlreadyManager, err := s.teamRepo.IsTeamManager(ctx, teamID, managerID)
 	if err != nil {
 		return fmt.Errorf("failed to check manager status: %w", err)
 	}
 	if isAlreadyManager {
-		return errors.New("user is already a manager of this team")
+		return conflict("user is already a manager of this team")
 	}
 
 	// Remove from members if they are a member
-	isMember, _ := s.teamRepo.IsTeamMember(teamID, managerID)
+	isMember, _ := s.teamRepo.IsTeamMember(ctx, teamID, managerID)
 	if isMember {
-		s.teamRepo.RemoveMember(teamID, managerID)
+		s.teamRepo.RemoveMember(ctx, teamID, managerID)
 	}
 
-	err = s.teamRepo.AddManager(teamID, managerID)
+	err = s.teamRepo.AddManager(ctx, teamID, managerID)
 	if err != nil {
 		return err
 	}
 
 	// NEW: Publish manager added event
-	s.publishManagerAddedEvent(teamID, requestorID, managerID, user.Username)
+	s.publishManagerAddedEvent(ctx, teamID, requestorID, managerID, user.Username)
 
 	return nil
 }
 
-func (s *teamService) RemoveManager(teamID, requestorID, managerID uuid.UUID) error {
+func (s *teamService) RemoveManager(ctx context.Context, teamID, requestorID, managerID uuid.UUID) error {
 	// Check if requestor is a manager of the team
-	isTeamManager, err := s.teamRepo.IsTeamManager(teamID, requestorID)
+	isTeamManager, err := s.teamRepo.IsTeamManager(ctx, teamID, requestorID)
 	if err != nil {
 		return fmt.Errorf("failed to check team manager status: %w", err)
 	}
 	if !isTeamManager {
-		return errors.New("access denied: only team managers can remove other managers")
+		return forbidden("access denied: only team managers can remove other managers")
 	}
 
 	// Get team to check creator
-	team, err := s.teamRepo.GetByID(teamID)
+	team, err := s.teamRepo.GetByID(ctx, teamID)
 	if err != nil {
-		return fmt.Errorf("team not found: %w", err)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return notFound("team not found")
+		}
+		return fmt.Errorf("failed to get team: %w", err)
 	}
 
 	// Cannot remove the team creator
 	if team.CreatedBy == managerID {
-		return errors.New("cannot remove the team creator")
+		return conflict("cannot remove the team creator")
 	}
 
 	// Get user info before removal
-	user, err := s.userRepo.GetByID(managerID)
+	user, err := s.userRepo.GetByID(ctx, managerID)
 	if err != nil {
-		return fmt.Errorf("user not found: %w", err)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return notFound("user not found")
+		}
+		return fmt.Errorf("failed to get user: %w", err)
 	}
 
 	// Check if target is actually a manager
-	isManager, err := s.teamRepo.IsTeamManager(teamID, managerID)
+	isManager, err := s.teamRepo.IsTeamManager(ctx, teamID, managerID)
 	if err != nil {
 		return fmt.Errorf("failed to check manager status: %w", err)
 	}
 	if !isManager {
-		return errors.New("manager not found in team")
+		return notFound("manager not found in team")
 	}
 
-	err = s.teamRepo.RemoveManager(teamID, managerID)
+	err = s.teamRepo.RemoveManager(ctx, teamID, managerID)
 	if err != nil {
 		return err
 	}
 
 	// NEW: Publish manager removed event
-	s.publishManagerRemovedEvent(teamID, requestorID, managerID, user.Username)
+	s.publishManagerRemovedEvent(ctx, teamID, requestorID, managerID, user.Username)
 
 	return nil
 }
 
-func (s *teamService) GetTeam(teamID, userID uuid.UUID) (*models.Team, error) {
+func (s *teamService) GetTeam(ctx context.Context, teamID, userID uuid.UUID) (*models.Team, error) {
 	// Check if user is part of the team (as member or manager)
-	isInTeam, err := s.userRepo.CheckIfUserInTeam(userID, teamID)
+	isInTeam, err := s.userRepo.CheckIfUserInTeam(ctx, userID, teamID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to check team membership: %w", err)
 	}
 	if !isInTeam {
-		return nil, errors.New("access denied: you are not a member of this team")
+		return nil, forbidden("access denied: you are not a member of this team")
 	}
 
-	team, err := s.teamRepo.GetByID(teamID)
+	team, err := s.teamRepo.GetByID(ctx, teamID)
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
-			return nil, errors.New("team not found")
+			return nil, notFound("team not found")
 		}
 		return nil, fmt.Errorf("failed to get team: %w", err)
 	}
@@ -298,15 +335,15 @@ func (s *teamService) GetTeam(teamID, userID uuid.UUID) (*models.Team, error) {
 	return team, nil
 }
 
-func (s *teamService) GetUserTeams(userID uuid.UUID) ([]*models.Team, error) {
+func (s *teamService) GetUserTeams(ctx context.Context, userID uuid.UUID) ([]*models.Team, error) {
 	// Get teams where user is a manager
-	managerTeams, err := s.teamRepo.GetTeamsByManagerID(userID)
+	managerTeams, err := s.teamRepo.GetTeamsByManagerID(ctx, userID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get manager teams: %w", err)
 	}
 
 	// Get teams where user is a member
-	memberTeams, err := s.teamRepo.GetTeamsByMemberID(userID)
+	memberTeams, err := s.teamRepo.GetTeamsByMemberID(ctx, userID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get member teams: %w", err)
 	}
@@ -330,67 +367,70 @@ func (s *teamService) GetUserTeams(userID uuid.UUID) ([]*models.Team, error) {
 	return allTeams, nil
 }
 
+func (s *teamService) ListUserTeams(ctx context.Context, userID uuid.UUID, req models.PageRequest) (*models.Page[*models.Team], error) {
+	page, err := s.teamRepo.ListByUserID(ctx, userID, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list teams: %w", err)
+	}
+	return page, nil
+}
+
 // NEW: Event publishing methods
-func (s *teamService) publishTeamCreatedEvent(teamID, performedBy uuid.UUID, teamName string, managers, members []uuid.UUID) {
+func (s *teamService) publishTeamCreatedEvent(ctx context.Context, teamID, performedBy uuid.UUID, teamName string, managers, members []uuid.UUID) {
 	if s.eventBus == nil {
 		return
 	}
 
 	event := types.NewTeamCreatedEvent(teamID, performedBy, teamName, managers, members)
 	
-	ctx := context.Background()
 	if err := s.eventBus.Publish(ctx, types.TeamActivityTopic, event); err != nil {
 		log.Printf("Failed to publish team created event: %v", err)
 	}
 }
 
-func (s *teamService) publishMemberAddedEvent(teamID, performedBy, targetUserID uuid.UUID, userName string) {
+func (s *teamService) publishMemberAddedEvent(ctx context.Context, teamID, performedBy, targetUserID uuid.UUID, userName string) {
 	if s.eventBus == nil {
 		return
 	}
 
 	event := types.NewMemberAddedEvent(teamID, performedBy, targetUserID, userName)
 	
-	ctx := context.Background()
 	if err := s.eventBus.Publish(ctx, types.TeamActivityTopic, event); err != nil {
 		log.Printf("Failed to publish member added event: %v", err)
 	}
 }
 
-func (s *teamService) publishMemberRemovedEvent(teamID, performedBy, targetUserID uuid.UUID, userName string) {
+func (s *teamService) publishMemberRemovedEvent(ctx context.Context, teamID, performedBy, targetUserID uuid.UUID, userName string) {
 	if s.eventBus == nil {
 		return
 	}
 
 	event := types.NewMemberRemovedEvent(teamID, performedBy, targetUserID, userName)
 	
-	ctx := context.Background()
 	if err := s.eventBus.Publish(ctx, types.TeamActivityTopic, event); err != nil {
 		log.Printf("Failed to publish member removed event: %v", err)
 	}
 }
 
-func (s *teamService) publishManagerAddedEvent(teamID, performedBy, targetUserID uuid.UUID, userName string) {
+func (s *teamService) publishManagerAddedEvent(ctx context.Context, teamID, performedBy, targetUserID uuid.UUID, userName string) {
 	if s.eventBus == nil {
 		return
 	}
 
 	event := types.NewManagerAddedEvent(teamID, performedBy, targetUserID, userName)
 	
-	ctx := context.Background()
 	if err := s.eventBus.Publish(ctx, types.TeamActivityTopic, event); err != nil {
 		log.Printf("Failed to publish manager added event: %v", err)
 	}
 }
 
-func (s *teamService) publishManagerRemovedEvent(teamID, performedBy, targetUserID uuid.UUID, userName string) {
+func (s *teamService) publishManagerRemovedEvent(ctx context.Context, teamID, performedBy, targetUserID uuid.UUID, userName string) {
 	if s.eventBus == nil {
 		return
 	}
 
 	event := types.NewManagerRemovedEvent(teamID, performedBy, targetUserID, userName)
 	
-	ctx := context.Background()
 	if err := s.eventBus.Publish(ctx, types.TeamActivityTopic, event); err != nil {
 		log.Printf("Failed to publish manager removed event: %v", err)
 	}
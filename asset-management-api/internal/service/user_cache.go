@@ -0,0 +1,61 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	"asset-management-api/internal/models"
+	"asset-management-api/internal/repository/interfaces"
+	"asset-management-api/pkg/cache"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// CacheIntegratedUserRepository caches the user lookups performed during
+// authorization checks. Cached profiles omit the password hash, so callers
+// that need credentials must use GetByEmail, which is never cached.
+type CacheIntegratedUserRepository struct {
+	interfaces.UserRepository
+	cacheService cache.CacheService
+}
+
+// NewCacheIntegratedUserRepository creates a new cache-integrated user repository
+func NewCacheIntegratedUserRepository(userRepo interfaces.UserRepository, cacheService cache.CacheService) *CacheIntegratedUserRepository {
+	return &CacheIntegratedUserRepository{
+		UserRepository: userRepo,
+		cacheService:   cacheService,
+	}
+}
+
+// GetByID attempts to get the user profile from cache first, then falls back to database
+func (r *CacheIntegratedUserRepository) GetByID(ctx context.Context, userID uuid.UUID) (*models.User, error) {
+
+	if cachedUser, err := r.cacheService.GetUserProfile(ctx, userID); err == nil && cachedUser != nil {
+		log.Printf("Cache HIT for user %s profile", userID)
+		return cachedUser, nil
+	}
+
+	user, err := r.UserRepository.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.cacheService.CacheUserProfile(ctx, user); err != nil {
+		log.Printf("Failed to cache user profile for %s: %v", userID, err)
+	}
+
+	return user, nil
+}
+
+// CheckIfManager resolves the role from the cached profile
+func (r *CacheIntegratedUserRepository) CheckIfManager(ctx context.Context, userID uuid.UUID) (bool, error) {
+	user, err := r.GetByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+	return user.Role == "manager", nil
+}
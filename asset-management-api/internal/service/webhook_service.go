@@ -0,0 +1,260 @@
+package service
+
+import (
+	"asset-management-api/internal/events/types"
+	"asset-management-api/internal/models"
+	"asset-management-api/internal/repository/interfaces"
+	serviceInterfaces "asset-management-api/internal/service/interfaces"
+	"asset-management-api/internal/webhook"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"net/url"
+)
+
+// webhookEventTypes are the event types a webhook can filter on
+var webhookEventTypes = map[string]bool{
+	types.FolderCreated:  true,
+	types.FolderUpdated:  true,
+	types.FolderDeleted:  true,
+	types.FolderShared:   true,
+	types.FolderUnshared: true,
+	types.NoteCreated:    true,
+	types.NoteUpdated:    true,
+	types.NoteDeleted:    true,
+	types.NoteShared:     true,
+	types.NoteUnshared:   true,
+	types.TeamCreated:    true,
+	types.MemberAdded:    true,
+	types.MemberRemoved:  true,
+	types.ManagerAdded:   true,
+	types.ManagerRemoved: true,
+}
+
+const (
+	webhookSecretBytes      = 32
+	defaultDeliveriesLimit  = 50
+	maxWebhookDeliveryLimit = 500
+)
+
+type webhookService struct {
+	webhookRepo interfaces.WebhookRepository
+	teamRepo    interfaces.TeamRepository
+}
+
+func NewWebhookService(webhookRepo interfaces.WebhookRepository, teamRepo interfaces.TeamRepository) serviceInterfaces.WebhookService {
+	return &webhookService{
+		webhookRepo: webhookRepo,
+		teamRepo:    teamRepo,
+	}
+}
+
+// RegisterWebhook creates a webhook for the team. A signing secret is generated
+// when none is given; callers only see it in the returned webhook.
+func (s *webhookService) RegisterWebhook(ctx context.Context, teamID, requestorID uuid.UUID, rawURL, secret string, eventTypes []string) (*models.Webhook, error) {
+	if err := s.checkTeamManager(ctx, teamID, requestorID); err != nil {
+		return nil, err
+	}
+
+	webhookURL, err := parseWebhookURL(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkWebhookEventTypes(eventTypes); err != nil {
+		return nil, err
+	}
+
+	if secret == "" {
+		secret, err = generateWebhookSecret()
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate webhook secret: %w", err)
+		}
+	}
+
+	webhook := &models.Webhook{
+		TeamID:     teamID,
+		URL:        webhookURL,
+		Secret:     secret,
+		EventTypes: models.StringList(eventTypes),
+		Active:     true,
+		CreatedBy:  requestorID,
+	}
+
+	if err := s.webhookRepo.Create(ctx, webhook); err != nil {
+		return nil, fmt.Errorf("failed to create webhook: %w", err)
+	}
+
+	return webhook, nil
+}
+
+func (s *webhookService) ListWebhooks(ctx context.Context, teamID, requestorID uuid.UUID) ([]*models.Webhook, error) {
+	if err := s.checkTeamManager(ctx, teamID, requestorID); err != nil {
+		return nil, err
+	}
+
+	return s.webhookRepo.GetByTeamID(ctx, teamID)
+}
+
+func (s *webhookService) DeleteWebhook(ctx context.Context, teamID, webhookID, requestorID uuid.UUID) error {
+	if _, err := s.getTeamWebhook(ctx, teamID, webhookID, requestorID); err != nil {
+		return err
+	}
+
+	return s.webhookRepo.Delete(ctx, webhookID)
+}
+
+func (s *webhookService) GetDeliveries(ctx context.Context, teamID, webhookID, requestorID uuid.UUID, limit int) ([]*models.WebhookDelivery, error) {
+	if _, err := s.getTeamWebhook(ctx, teamID, webhookID, requestorID); err != nil {
+		return nil, err
+	}
+
+	if limit <= 0 {
+		limit = defaultDeliveriesLimit
+	}
+	if limit > maxWebhookDeliveryLimit {
+		limit = maxWebhookDeliveryLimit
+	}
+
+	return s.webhookRepo.GetDeliveries(ctx, webhookID, limit)
+}
+
+func (s *webhookService) ListManagedWebhooks(ctx context.Context, requestorID uuid.UUID) ([]*models.Webhook, error) {
+	teams, err := s.teamRepo.GetTeamsByManagerID(ctx, requestorID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get managed teams: %w", err)
+	}
+
+	teamIDs := make([]uuid.UUID, 0, len(teams))
+	for _, team := range teams {
+		teamIDs = append(teamIDs, team.TeamID)
+	}
+	return s.webhookRepo.GetByTeamIDs(ctx, teamIDs)
+}
+
+// GetWebhook returns a webhook of a team the requestor manages, with its
+// signing secret
+func (s *webhookService) GetWebhook(ctx context.Context, webhookID, requestorID uuid.UUID) (*models.Webhook, error) {
+	webhook, err := s.webhookRepo.GetByID(ctx, webhookID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, notFound("webhook not found")
+		}
+		return nil, fmt.Errorf("failed to get webhook: %w", err)
+	}
+	if err := s.checkTeamManager(ctx, webhook.TeamID, requestorID); err != nil {
+		return nil, err
+	}
+
+	return webhook, nil
+}
+
+// UpdateWebhook changes the URL, event types or active flag of a webhook.
+// Deliveries already scheduled keep going to the webhook as it is when they
+// are attempted.
+func (s *webhookService) UpdateWebhook(ctx context.Context, webhookID, requestorID uuid.UUID, req models.UpdateWebhookRequest) (*models.Webhook, error) {
+	webhook, err := s.GetWebhook(ctx, webhookID, requestorID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.URL != nil {
+		if webhook.URL, err = parseWebhookURL(*req.URL); err != nil {
+			return nil, err
+		}
+	}
+	if req.EventTypes != nil {
+		if err := checkWebhookEventTypes(*req.EventTypes); err != nil {
+			return nil, err
+		}
+		webhook.EventTypes = models.StringList(*req.EventTypes)
+	}
+	if req.Active != nil {
+		webhook.Active = *req.Active
+	}
+
+	if err := s.webhookRepo.Update(ctx, webhook); err != nil {
+		return nil, fmt.Errorf("failed to update webhook: %w", err)
+	}
+	return webhook, nil
+}
+
+// RotateWebhookSecret replaces the signing secret of a webhook with a
+// generated one. Deliveries attempted from then on are signed with it.
+func (s *webhookService) RotateWebhookSecret(ctx context.Context, webhookID, requestorID uuid.UUID) (*models.Webhook, error) {
+	webhook, err := s.GetWebhook(ctx, webhookID, requestorID)
+	if err != nil {
+		return nil, err
+	}
+
+	if webhook.Secret, err = generateWebhookSecret(); err != nil {
+		return nil, fmt.Errorf("failed to generate webhook secret: %w", err)
+	}
+	if err := s.webhookRepo.Update(ctx, webhook); err != nil {
+		return nil, fmt.Errorf("failed to update webhook: %w", err)
+	}
+	return webhook, nil
+}
+
+func (s *webhookService) checkTeamManager(ctx context.Context, teamID, requestorID uuid.UUID) error {
+	isTeamManager, err := s.teamRepo.IsTeamManager(ctx, teamID, requestorID)
+	if err != nil {
+		return fmt.Errorf("failed to check team manager status: %w", err)
+	}
+	if !isTeamManager {
+		return forbidden("access denied: only team managers can manage webhooks")
+	}
+	return nil
+}
+
+func (s *webhookService) getTeamWebhook(ctx context.Context, teamID, webhookID, requestorID uuid.UUID) (*models.Webhook, error) {
+	if err := s.checkTeamManager(ctx, teamID, requestorID); err != nil {
+		return nil, err
+	}
+
+	webhook, err := s.webhookRepo.GetByID(ctx, webhookID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, notFound("webhook not found")
+		}
+		return nil, fmt.Errorf("failed to get webhook: %w", err)
+	}
+	if webhook.TeamID != teamID {
+		return nil, notFound("webhook not found")
+	}
+
+	return webhook, nil
+}
+
+// parseWebhookURL checks that a webhook URL is an absolute http(s) URL that
+// does not point at the service's own host or networks. Host names are
+// checked again on every delivery, once resolved.
+func parseWebhookURL(rawURL string) (string, error) {
+	parsed, err := url.Parse(rawURL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Hostname() == "" {
+		return "", invalid("invalid webhook URL")
+	}
+	if webhook.IsBlockedHost(parsed.Hostname()) {
+		return "", invalid("webhook URL must point to a public address")
+	}
+	return parsed.String(), nil
+}
+
+func checkWebhookEventTypes(eventTypes []string) error {
+	for _, eventType := range eventTypes {
+		if !webhookEventTypes[eventType] {
+			return invalid("unknown event type: %s", eventType)
+		}
+	}
+	return nil
+}
+
+func generateWebhookSecret() (string, error) {
+	buf := make([]byte, webhookSecretBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
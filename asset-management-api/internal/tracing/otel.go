@@ -0,0 +1,109 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// instrumentationName names the tracer of the spans this service starts itself
+const instrumentationName = "asset-management-api"
+
+// ExporterConfig configures the export of spans to an OpenTelemetry collector
+type ExporterConfig struct {
+	ServiceName    string
+	ServiceVersion string
+	Endpoint       string  // OTLP/HTTP base URL, e.g. "http://localhost:4318"
+	SampleRatio    float64 // Share of new traces recorded; sampled parents are always followed
+}
+
+// Setup installs a tracer provider exporting spans over OTLP/HTTP, so the
+// HTTP, database, Redis and Kafka instrumentation record spans. It must run
+// before those clients are created. The returned function flushes pending
+// spans on shutdown.
+func Setup(ctx context.Context, cfg ExporterConfig) (func(context.Context) error, error) {
+	endpoint, err := url.Parse(cfg.Endpoint)
+	if err != nil || endpoint.Host == "" {
+		return nil, fmt.Errorf("invalid OTLP endpoint %q: expected a URL such as http://localhost:4318", cfg.Endpoint)
+	}
+	options := []otlptracehttp.Option{otlptracehttp.WithEndpoint(endpoint.Host)}
+	if endpoint.Scheme == "http" {
+		options = append(options, otlptracehttp.WithInsecure())
+	}
+
+	exporter, err := otlptracehttp.New(ctx, options...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create OTLP exporter: %w", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.SampleRatio))),
+		sdktrace.WithResource(resource.NewWithAttributes(semconv.SchemaURL,
+			semconv.ServiceName(cfg.ServiceName),
+			semconv.ServiceVersion(cfg.ServiceVersion),
+		)),
+	)
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+	return provider.Shutdown, nil
+}
+
+// StartSpan starts a span as a child of the context's span and makes it the
+// context's span. Without a tracer provider the span is not recorded, but a
+// span context is still generated so the trace propagates as before.
+func StartSpan(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	parent, hasParent := FromContext(ctx)
+	if hasParent && trace.SpanContextFromContext(ctx).SpanID().String() != parent.SpanID {
+		ctx = trace.ContextWithRemoteSpanContext(ctx, parent.otel())
+	}
+
+	ctx, span := otel.Tracer(instrumentationName).Start(ctx, name, opts...)
+	if sc, ok := FromSpan(span); ok {
+		return ContextWith(ctx, sc), span
+	}
+	if hasParent {
+		return ContextWith(ctx, parent.NewChild()), span
+	}
+	return ContextWith(ctx, New()), span
+}
+
+// FromSpan returns the span context of an OpenTelemetry span started by this
+// service. Without a tracer provider spans only carry their parent's context,
+// which is reported as remote, so ok is false for them.
+func FromSpan(span trace.Span) (SpanContext, bool) {
+	sc := span.SpanContext()
+	if !sc.IsValid() || sc.IsRemote() {
+		return SpanContext{}, false
+	}
+	return SpanContext{
+		TraceID:    sc.TraceID().String(),
+		SpanID:     sc.SpanID().String(),
+		Flags:      fmt.Sprintf("%02x", byte(sc.TraceFlags())),
+		Tracestate: sc.TraceState().String(),
+	}, true
+}
+
+// otel converts the span context into a remote OpenTelemetry span context
+func (sc SpanContext) otel() trace.SpanContext {
+	traceID, _ := trace.TraceIDFromHex(sc.TraceID)
+	spanID, _ := trace.SpanIDFromHex(sc.SpanID)
+	flags, _ := strconv.ParseUint(sc.Flags, 16, 8)
+	state, _ := trace.ParseTraceState(sc.Tracestate)
+	return trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     spanID,
+		TraceFlags: trace.TraceFlags(flags),
+		TraceState: state,
+		Remote:     true,
+	})
+}
@@ -0,0 +1,47 @@
+package tracing
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// RequestIDHeader carries the ID of the request an operation belongs to, in
+// HTTP requests and responses as well as in the Kafka messages it publishes
+const RequestIDHeader = "X-Request-ID"
+
+// maxRequestIDLength bounds client supplied request IDs
+const maxRequestIDLength = 128
+
+type requestIDKey struct{}
+
+// NewRequestID returns a new random request ID
+func NewRequestID() string {
+	return uuid.NewString()
+}
+
+// ValidRequestID reports whether a request ID supplied by a client or another
+// service can be adopted: it must be short printable ASCII without spaces, so
+// it is safe to echo in headers and logs
+func ValidRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] <= ' ' || id[i] > '~' {
+			return false
+		}
+	}
+	return true
+}
+
+// ContextWithRequestID returns a context carrying the request ID
+func ContextWithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, requestID)
+}
+
+// RequestID returns the request ID of the context, or an empty string
+func RequestID(ctx context.Context) string {
+	requestID, _ := ctx.Value(requestIDKey{}).(string)
+	return requestID
+}
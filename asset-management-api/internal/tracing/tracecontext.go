@@ -0,0 +1,132 @@
+// Package tracing propagates W3C Trace Context (https://www.w3.org/TR/trace-context/)
+// between HTTP requests and Kafka messages, so a request and the event
+// handling it causes share one trace ID. When an OpenTelemetry exporter is
+// set up, the spans of the service's OpenTelemetry instrumentation take the
+// place of the locally generated ones.
+package tracing
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"strings"
+
+	"asset-management-api/internal/events/types"
+)
+
+// Header names defined by W3C Trace Context, used for HTTP and Kafka headers alike
+const (
+	TraceparentHeader = "traceparent"
+	TracestateHeader  = "tracestate"
+)
+
+const (
+	traceparentVersion = "00"
+	traceparentLength  = 55 // version-traceid-parentid-flags
+	flagSampled        = "01"
+)
+
+// SpanContext identifies a span within a trace
+type SpanContext struct {
+	TraceID    string // 32 lowercase hex digits
+	SpanID     string // 16 lowercase hex digits
+	Flags      string // 2 hex digits; "01" means sampled
+	Tracestate string // Vendor data, passed on unchanged
+}
+
+// IsValid reports whether the span context has a trace and span ID
+func (sc SpanContext) IsValid() bool {
+	return sc.TraceID != "" && sc.SpanID != ""
+}
+
+// Traceparent formats the span context as a traceparent header value
+func (sc SpanContext) Traceparent() string {
+	return traceparentVersion + "-" + sc.TraceID + "-" + sc.SpanID + "-" + sc.Flags
+}
+
+// NewChild returns a span context for a new span in the same trace
+func (sc SpanContext) NewChild() SpanContext {
+	child := sc
+	child.SpanID = randomHex(8)
+	return child
+}
+
+// New starts a new sampled trace
+func New() SpanContext {
+	return SpanContext{TraceID: randomHex(16), SpanID: randomHex(8), Flags: flagSampled}
+}
+
+// Parse parses a traceparent header value. Values of future versions are
+// accepted as far as the version 00 fields go, as the specification asks.
+func Parse(traceparent, tracestate string) (SpanContext, bool) {
+	traceparent = strings.TrimSpace(traceparent)
+	if len(traceparent) < traceparentLength {
+		return SpanContext{}, false
+	}
+
+	version := traceparent[0:2]
+	if !isHex(version) || version == "ff" || (version == traceparentVersion && len(traceparent) != traceparentLength) {
+		return SpanContext{}, false
+	}
+	if len(traceparent) > traceparentLength && traceparent[traceparentLength] != '-' {
+		return SpanContext{}, false
+	}
+	if traceparent[2] != '-' || traceparent[35] != '-' || traceparent[52] != '-' {
+		return SpanContext{}, false
+	}
+
+	sc := SpanContext{
+		TraceID:    traceparent[3:35],
+		SpanID:     traceparent[36:52],
+		Flags:      traceparent[53:55],
+		Tracestate: strings.TrimSpace(tracestate),
+	}
+	if !isHex(sc.TraceID) || !isHex(sc.SpanID) || !isHex(sc.Flags) ||
+		isZero(sc.TraceID) || isZero(sc.SpanID) {
+		return SpanContext{}, false
+	}
+	return sc, true
+}
+
+type spanContextKey struct{}
+
+// ContextWith returns a context carrying the span context. Its trace ID is
+// also recorded in the envelopes of events published with the context.
+func ContextWith(ctx context.Context, sc SpanContext) context.Context {
+	ctx = context.WithValue(ctx, spanContextKey{}, sc)
+	return types.ContextWithTraceID(ctx, sc.TraceID)
+}
+
+// FromContext returns the span context stored in the context, if any
+func FromContext(ctx context.Context) (SpanContext, bool) {
+	sc, ok := ctx.Value(spanContextKey{}).(SpanContext)
+	return sc, ok && sc.IsValid()
+}
+
+// TraceID returns the trace ID of the context, or an empty string
+func TraceID(ctx context.Context) string {
+	sc, _ := FromContext(ctx)
+	return sc.TraceID
+}
+
+func isHex(s string) bool {
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if !('0' <= c && c <= '9' || 'a' <= c && c <= 'f') {
+			return false
+		}
+	}
+	return true
+}
+
+func isZero(s string) bool {
+	return strings.Trim(s, "0") == ""
+}
+
+func randomHex(n int) string {
+	buf := make([]byte, n)
+	if _, err := rand.Read(buf); err != nil {
+		panic("tracing: failed to read random bytes: " + err.Error())
+	}
+	return hex.EncodeToString(buf)
+}
@@ -0,0 +1,42 @@
+package utils
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// WeakETag returns a weak entity tag for the version of a resource last
+// modified at updatedAt. The time is cut to the microseconds Postgres stores,
+// so resources read from the database and from the cache get the same tag.
+func WeakETag(updatedAt time.Time) string {
+	return `W/"` + strconv.FormatInt(updatedAt.UnixMicro(), 36) + `"`
+}
+
+// NotModified answers a conditional GET: it sets the ETag of the response and,
+// when the request's If-None-Match lists it, writes 304 Not Modified without a
+// body. It reports whether it did, in which case the handler is done.
+func NotModified(c *gin.Context, etag string) bool {
+	c.Header("ETag", etag)
+	c.Header("Cache-Control", "private, no-cache")
+
+	if !etagMatches(c.GetHeader("If-None-Match"), etag) {
+		return false
+	}
+	c.Status(http.StatusNotModified)
+	return true
+}
+
+// etagMatches compares entity tags weakly, as If-None-Match requires
+func etagMatches(ifNoneMatch, etag string) bool {
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == strings.TrimPrefix(etag, "W/") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,175 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"asset-management-api/internal/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// selectFields projects the data of a success response onto the fields the
+// request selected with ?fields=, see middleware.SparseFieldsetsMiddleware.
+// An object keeps the selected fields, in the order they were asked for, and
+// so does every object of a list; anything else is returned as is. Nested
+// objects, such as a note's owner, are kept or dropped as a whole. A field the
+// data does not have is answered with 400, reported by ok being false.
+func selectFields(c *gin.Context, data interface{}) (selected interface{}, ok bool) {
+	fields := models.FieldsFromContext(c.Request.Context())
+	if fields == nil || data == nil {
+		return data, true
+	}
+
+	if known := knownFields(reflect.ValueOf(data)); known != nil {
+		for _, field := range fields {
+			if !known[field] {
+				names := make([]string, 0, len(known))
+				for name := range known {
+					names = append(names, name)
+				}
+				sort.Strings(names)
+				BadRequestResponse(c, fmt.Sprintf("Unknown field '%s' in query parameter 'fields'", field),
+					fmt.Errorf("available fields: %s", strings.Join(names, ", ")))
+				return nil, false
+			}
+		}
+	}
+
+	raw, err := json.Marshal(data)
+	if err != nil {
+		InternalServerErrorResponse(c, "Failed to encode response", err)
+		return nil, false
+	}
+	projected, err := projectJSON(raw, fields)
+	if err != nil {
+		InternalServerErrorResponse(c, "Failed to encode response", err)
+		return nil, false
+	}
+	return projected, true
+}
+
+// projectJSON keeps the fields of a JSON object, or of the objects of a JSON
+// array
+func projectJSON(raw json.RawMessage, fields models.Fields) (json.RawMessage, error) {
+	trimmed := bytes.TrimSpace(raw)
+	if len(trimmed) == 0 {
+		return raw, nil
+	}
+	switch trimmed[0] {
+	case '{':
+		var object map[string]json.RawMessage
+		if err := json.Unmarshal(trimmed, &object); err != nil {
+			return nil, err
+		}
+		var b bytes.Buffer
+		b.WriteByte('{')
+		for _, field := range fields {
+			value, ok := object[field]
+			if !ok {
+				continue
+			}
+			if b.Len() > 1 {
+				b.WriteByte(',')
+			}
+			name, _ := json.Marshal(field)
+			b.Write(name)
+			b.WriteByte(':')
+			b.Write(value)
+		}
+		b.WriteByte('}')
+		return b.Bytes(), nil
+	case '[':
+		var items []json.RawMessage
+		if err := json.Unmarshal(trimmed, &items); err != nil {
+			return nil, err
+		}
+		var b bytes.Buffer
+		b.WriteByte('[')
+		for i, item := range items {
+			projected, err := projectJSON(item, fields)
+			if err != nil {
+				return nil, err
+			}
+			if i > 0 {
+				b.WriteByte(',')
+			}
+			b.Write(projected)
+		}
+		b.WriteByte(']')
+		return b.Bytes(), nil
+	}
+	return raw, nil
+}
+
+// knownFields returns the JSON fields of a struct or string keyed map, or of
+// the elements of a list of them, or nil when they cannot be told, e.g. for
+// a list of interfaces
+func knownFields(v reflect.Value) map[string]bool {
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+	switch v.Kind() {
+	case reflect.Struct:
+		return structFields(v.Type())
+	case reflect.Map:
+		if v.Type().Key().Kind() != reflect.String {
+			return nil
+		}
+		known := make(map[string]bool, v.Len())
+		for _, key := range v.MapKeys() {
+			known[key.String()] = true
+		}
+		return known
+	case reflect.Slice, reflect.Array:
+		t := v.Type().Elem()
+		for t.Kind() == reflect.Pointer {
+			t = t.Elem()
+		}
+		if t.Kind() == reflect.Struct {
+			return structFields(t)
+		}
+	}
+	return nil
+}
+
+// structFields returns the names encoding/json gives the fields of a struct,
+// including those of embedded structs
+func structFields(t reflect.Type) map[string]bool {
+	known := make(map[string]bool)
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+		if field.Anonymous && name == "" {
+			embedded := field.Type
+			if embedded.Kind() == reflect.Pointer {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				for embeddedName := range structFields(embedded) {
+					known[embeddedName] = true
+				}
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		known[name] = true
+	}
+	return known
+}
@@ -0,0 +1,34 @@
+package utils
+
+import (
+	"asset-management-api/internal/i18n"
+
+	"github.com/gin-gonic/gin"
+)
+
+// LocalizerKey is the Gin context key of the i18n.Localizer of the request,
+// set by middleware.LanguageMiddleware
+const LocalizerKey = "localizer"
+
+// Localizer returns the localizer of the request; without one, messages are
+// answered in English
+func Localizer(c *gin.Context) i18n.Localizer {
+	if value, ok := c.Get(LocalizerKey); ok {
+		if localizer, ok := value.(i18n.Localizer); ok {
+			return localizer
+		}
+	}
+	return i18n.Localizer{}
+}
+
+// Translate returns a message in the language of the request, with its
+// arguments given as name and value pairs, e.g.
+// Translate(c, "Team {team} not found", "team", name)
+func Translate(c *gin.Context, message string, args ...string) string {
+	return Localizer(c).Translate(message, args...)
+}
+
+// Language returns the language of the request, empty when not negotiated
+func Language(c *gin.Context) string {
+	return Localizer(c).Language()
+}
@@ -0,0 +1,85 @@
+package utils
+
+import "github.com/gin-gonic/gin"
+
+// Link points to a resource related to a response
+type Link struct {
+	Href string `json:"href"`
+}
+
+// Links are the links of a response by relation, e.g. "self" or "next"
+type Links map[string]Link
+
+// ResponseBuilder writes success responses with the links of their data, so
+// clients can follow them instead of building URLs:
+//
+//	utils.NewResponse(c).Link("self", "/api/v1/folders/"+id).Send(http.StatusOK, "Folder retrieved", folder)
+type ResponseBuilder struct {
+	c     *gin.Context
+	links Links
+}
+
+// NewResponse starts a success response
+func NewResponse(c *gin.Context) *ResponseBuilder {
+	return &ResponseBuilder{c: c}
+}
+
+// Link adds a link to href, a path on this API with an optional query
+func (b *ResponseBuilder) Link(rel, href string) *ResponseBuilder {
+	if b.links == nil {
+		b.links = make(Links)
+	}
+	b.links[rel] = Link{Href: href}
+	return b
+}
+
+// Links adds links
+func (b *ResponseBuilder) Links(links Links) *ResponseBuilder {
+	for rel, link := range links {
+		b.Link(rel, link.Href)
+	}
+	return b
+}
+
+// PageLinks adds the links of a page of a list: the requested page as
+// "self" and, unless it is the last, the page after as "next"
+func (b *ResponseBuilder) PageLinks(pagination *CursorPagination) *ResponseBuilder {
+	b.Link("self", b.c.Request.URL.RequestURI())
+	if pagination.NextCursor != "" {
+		next := *b.c.Request.URL
+		query := next.Query()
+		query.Set("cursor", pagination.NextCursor)
+		next.RawQuery = query.Encode()
+		b.Link("next", next.RequestURI())
+	}
+	return b
+}
+
+// Send writes the response with data
+func (b *ResponseBuilder) Send(statusCode int, message string, data interface{}) {
+	data, ok := selectFields(b.c, data)
+	if !ok {
+		return
+	}
+	b.c.JSON(statusCode, Response{
+		Success: true,
+		Message: message,
+		Data:    data,
+		Links:   b.links,
+	})
+}
+
+// SendPage writes the response with a page of a list
+func (b *ResponseBuilder) SendPage(statusCode int, message string, data interface{}, pagination *CursorPagination) {
+	data, ok := selectFields(b.c, data)
+	if !ok {
+		return
+	}
+	b.c.JSON(statusCode, CursorPaginatedResponse{
+		Success:    true,
+		Message:    message,
+		Data:       data,
+		Pagination: pagination,
+		Links:      b.links,
+	})
+}
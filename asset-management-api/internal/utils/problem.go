@@ -0,0 +1,209 @@
+package utils
+
+import (
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ProblemContentType is the media type of problem details (RFC 7807)
+const ProblemContentType = "application/problem+json"
+
+// ProblemTypesPath is the path the problem types are documented at; the type
+// of a problem is ProblemTypesPath/<code>
+const ProblemTypesPath = "/problems"
+
+// ProblemDetailsKey is the Gin context key set to true when errors are
+// answered with problem details, see middleware.ErrorFormatMiddleware
+const ProblemDetailsKey = "problem_details"
+
+// Codes of problem details. Titles and details are for people and may change
+// or be translated; codes are part of the API.
+const (
+	CodeInvalidRequest       = "invalid_request"
+	CodeValidationFailed     = "validation_failed"
+	CodeUnauthenticated      = "unauthenticated"
+	CodeForbidden            = "forbidden"
+	CodeNotFound             = "not_found"
+	CodeConflict             = "conflict"
+	CodePreconditionFailed   = "precondition_failed"
+	CodePreconditionRequired = "precondition_required"
+	CodePayloadTooLarge      = "payload_too_large"
+	CodeUnsupportedMediaType = "unsupported_media_type"
+	CodeUpgradeRequired      = "upgrade_required"
+	CodeRateLimited          = "rate_limited"
+	CodeQuotaExceeded        = "quota_exceeded"
+	CodeInternal             = "internal_error"
+	CodeNotImplemented       = "not_implemented"
+	CodeUnavailable          = "unavailable"
+	CodeOverloaded           = "overloaded"
+	CodeUnderMaintenance     = "maintenance"
+	CodeNetworkDenied        = "network_denied"
+)
+
+// ProblemType documents a code of the problem details
+type ProblemType struct {
+	Code        string `json:"code"`
+	Type        string `json:"type"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+}
+
+// problemTypes are the documented codes, keyed by code
+var problemTypes = map[string]ProblemType{}
+
+func init() {
+	for _, t := range []ProblemType{
+		{Code: CodeInvalidRequest, Title: "Invalid request", Description: "A parameter, header or the body of the request cannot be read."},
+		{Code: CodeValidationFailed, Title: "Validation failed", Description: "The request body failed validation; errors lists the fields at fault."},
+		{Code: CodeUnauthenticated, Title: "Authentication required", Description: "The request has no bearer token, or one that is invalid or expired."},
+		{Code: CodeForbidden, Title: "Forbidden", Description: "The caller may not perform the request on this resource."},
+		{Code: CodeNotFound, Title: "Not found", Description: "The resource does not exist or is not visible to the caller."},
+		{Code: CodeConflict, Title: "Conflict", Description: "The request conflicts with the current state of the resource."},
+		{Code: CodePreconditionFailed, Title: "Precondition failed", Description: "The resource changed since the version named in If-Match."},
+		{Code: CodePreconditionRequired, Title: "Precondition required", Description: "The request must name the version it changes in If-Match."},
+		{Code: CodePayloadTooLarge, Title: "Payload too large", Description: "The request body exceeds the limit of the route."},
+		{Code: CodeUnsupportedMediaType, Title: "Unsupported media type", Description: "The route does not take a body of this content type."},
+		{Code: CodeUpgradeRequired, Title: "Upgrade required", Description: "The route must be called with a protocol upgrade, such as WebSocket."},
+		{Code: CodeRateLimited, Title: "Rate limit exceeded", Description: "The caller sent too many requests; retry after Retry-After seconds."},
+		{Code: CodeQuotaExceeded, Title: "Quota exceeded", Description: "The caller's daily or monthly quota is used up until it resets."},
+		{Code: CodeInternal, Title: "Internal error", Description: "The request could not be completed; retrying may succeed."},
+		{Code: CodeNotImplemented, Title: "Not implemented", Description: "The operation is not supported by this deployment."},
+		{Code: CodeUnavailable, Title: "Unavailable", Description: "A feature the request needs is disabled or temporarily unavailable."},
+		{Code: CodeOverloaded, Title: "Overloaded", Description: "The service is shedding load; retry after a short delay."},
+		{Code: CodeUnderMaintenance, Title: "Under maintenance", Description: "The service is under maintenance; retry after Retry-After seconds."},
+		{Code: CodeNetworkDenied, Title: "Network denied", Description: "Requests from the caller's network are refused."},
+	} {
+		t.Type = ProblemTypesPath + "/" + t.Code
+		problemTypes[t.Code] = t
+	}
+}
+
+// statusCodes are the codes of errors answered without one of their own
+var statusCodes = map[int]string{
+	http.StatusBadRequest:            CodeInvalidRequest,
+	http.StatusUnauthorized:          CodeUnauthenticated,
+	http.StatusForbidden:             CodeForbidden,
+	http.StatusNotFound:              CodeNotFound,
+	http.StatusConflict:              CodeConflict,
+	http.StatusPreconditionFailed:    CodePreconditionFailed,
+	http.StatusPreconditionRequired:  CodePreconditionRequired,
+	http.StatusRequestEntityTooLarge: CodePayloadTooLarge,
+	http.StatusUnsupportedMediaType:  CodeUnsupportedMediaType,
+	http.StatusUnprocessableEntity:   CodeValidationFailed,
+	http.StatusUpgradeRequired:       CodeUpgradeRequired,
+	http.StatusTooManyRequests:       CodeRateLimited,
+	http.StatusInternalServerError:   CodeInternal,
+	http.StatusNotImplemented:        CodeNotImplemented,
+	http.StatusServiceUnavailable:    CodeUnavailable,
+}
+
+// StatusCode returns the code of an error status
+func StatusCode(status int) string {
+	if code, ok := statusCodes[status]; ok {
+		return code
+	}
+	if status >= http.StatusInternalServerError {
+		return CodeInternal
+	}
+	return CodeInvalidRequest
+}
+
+// ProblemTypes returns the documented problem types
+func ProblemTypes() map[string]ProblemType {
+	return problemTypes
+}
+
+// Problem is an error response in the format of RFC 7807. Code is the
+// stable, machine-readable error; the members after it extend the format.
+type Problem struct {
+	Type      string      `json:"type"`
+	Title     string      `json:"title"`
+	Status    int         `json:"status"`
+	Detail    string      `json:"detail,omitempty"`
+	Instance  string      `json:"instance,omitempty"`
+	Code      string      `json:"code"`
+	Error     string      `json:"error,omitempty"`
+	Errors    interface{} `json:"errors,omitempty"`
+	RequestID string      `json:"request_id,omitempty"`
+	// Extensions holds data of the error, such as the end of a maintenance
+	// window, merged into the problem
+	Extensions map[string]interface{} `json:"-"`
+}
+
+// WantsProblem reports whether the errors of a request are answered with
+// problem details
+func WantsProblem(c *gin.Context) bool {
+	return c.GetBool(ProblemDetailsKey)
+}
+
+// AcceptsProblem reports whether an Accept header asks for problem details
+func AcceptsProblem(accept string) bool {
+	for _, mediaRange := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(mediaRange))
+		if err != nil || mediaType != ProblemContentType {
+			continue
+		}
+		if q, err := strconv.ParseFloat(params["q"], 64); err == nil && q <= 0 {
+			return false
+		}
+		return true
+	}
+	return false
+}
+
+// ProblemResponse writes an error as problem details. The code defaults to
+// that of the status; the title is the code's, translated with the detail.
+func ProblemResponse(c *gin.Context, problem Problem) {
+	if problem.Code == "" {
+		problem.Code = StatusCode(problem.Status)
+	}
+	if problemType, ok := problemTypes[problem.Code]; ok {
+		problem.Type = problemType.Type
+		problem.Title = Translate(c, problemType.Title)
+	} else {
+		problem.Type = "about:blank"
+		problem.Title = http.StatusText(problem.Status)
+	}
+	problem.Detail = Translate(c, problem.Detail)
+	problem.Error = Translate(c, problem.Error)
+	if problem.Instance == "" {
+		problem.Instance = c.Request.URL.Path
+	}
+	if problem.RequestID == "" {
+		problem.RequestID = c.GetString(RequestIDKey)
+	}
+
+	members := make(gin.H, len(problem.Extensions)+9)
+	for name, value := range problem.Extensions {
+		members[name] = value
+	}
+	for name, value := range problemMembers(problem) {
+		members[name] = value
+	}
+	// Set first, so the JSON renderer keeps it
+	c.Header("Content-Type", ProblemContentType)
+	c.JSON(problem.Status, members)
+}
+
+// problemMembers returns the members of a problem, leaving out empty ones
+func problemMembers(p Problem) gin.H {
+	members := gin.H{"type": p.Type, "title": p.Title, "status": p.Status, "code": p.Code}
+	for name, value := range map[string]string{
+		"detail":     p.Detail,
+		"instance":   p.Instance,
+		"error":      p.Error,
+		"request_id": p.RequestID,
+	} {
+		if value != "" {
+			members[name] = value
+		}
+	}
+	if p.Errors != nil {
+		members["errors"] = p.Errors
+	}
+	return members
+}
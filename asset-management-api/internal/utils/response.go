@@ -6,11 +6,17 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
+// RequestIDKey is the Gin context key of the request ID, which error
+// responses include so clients can quote it in support requests
+const RequestIDKey = "request_id"
+
 type Response struct {
-	Success bool        `json:"success"`
-	Message string      `json:"message,omitempty"`
-	Data    interface{} `json:"data,omitempty"`
-	Error   string      `json:"error,omitempty"`
+	Success   bool        `json:"success"`
+	Message   string      `json:"message,omitempty"`
+	Data      interface{} `json:"data,omitempty"`
+	Error     string      `json:"error,omitempty"`
+	RequestID string      `json:"request_id,omitempty"`
+	Links     Links       `json:"_links,omitempty"`
 }
 
 type PaginatedResponse struct {
@@ -28,7 +34,27 @@ type Pagination struct {
 	TotalPages int   `json:"total_pages"`
 }
 
+type CursorPaginatedResponse struct {
+	Success    bool              `json:"success"`
+	Message    string            `json:"message,omitempty"`
+	Data       interface{}       `json:"data"`
+	Pagination *CursorPagination `json:"pagination"`
+	Links      Links             `json:"_links,omitempty"`
+}
+
+// CursorPagination describes a page of a list read by cursor: the next page
+// is requested with NextCursor, which is empty on the last page
+type CursorPagination struct {
+	Limit      int    `json:"limit"`
+	NextCursor string `json:"next_cursor,omitempty"`
+	HasMore    bool   `json:"has_more"`
+}
+
 func SuccessResponse(c *gin.Context, statusCode int, message string, data interface{}) {
+	data, ok := selectFields(c, data)
+	if !ok {
+		return
+	}
 	c.JSON(statusCode, Response{
 		Success: true,
 		Message: message,
@@ -36,23 +62,58 @@ func SuccessResponse(c *gin.Context, statusCode int, message string, data interf
 	})
 }
 
+// ErrorResponse writes an error response, with the message and error in the
+// language of the request when the catalog translates them. Requests that
+// take problem details get one with the code of the status.
 func ErrorResponse(c *gin.Context, statusCode int, message string, err string) {
+	CodedErrorResponse(c, statusCode, "", message, err)
+}
+
+// CodedErrorResponse writes an error response like ErrorResponse, with a
+// problem code of its own, such as CodeQuotaExceeded for a 429
+func CodedErrorResponse(c *gin.Context, statusCode int, code, message string, err string) {
+	if WantsProblem(c) {
+		ProblemResponse(c, Problem{Status: statusCode, Code: code, Detail: message, Error: err})
+		return
+	}
 	c.JSON(statusCode, Response{
-		Success: false,
-		Message: message,
-		Error:   err,
+		Success:   false,
+		Message:   Translate(c, message),
+		Error:     Translate(c, err),
+		RequestID: c.GetString(RequestIDKey),
 	})
 }
 
+// genericErrorResponse writes an error response whose error only restates
+// the status, which problem details leave to their title
+func genericErrorResponse(c *gin.Context, statusCode int, message string, err string) {
+	if WantsProblem(c) {
+		err = ""
+	}
+	ErrorResponse(c, statusCode, message, err)
+}
+
 func ValidationErrorResponse(c *gin.Context, errors []string) {
-	c.JSON(http.StatusBadRequest, gin.H{
+	if WantsProblem(c) {
+		ProblemResponse(c, Problem{Status: http.StatusBadRequest, Code: CodeValidationFailed, Errors: errors})
+		return
+	}
+	response := gin.H{
 		"success": false,
-		"message": "Validation failed",
+		"message": Translate(c, "Validation failed"),
 		"errors":  errors,
-	})
+	}
+	if requestID := c.GetString(RequestIDKey); requestID != "" {
+		response["request_id"] = requestID
+	}
+	c.JSON(http.StatusBadRequest, response)
 }
 
 func PaginatedSuccessResponse(c *gin.Context, statusCode int, message string, data interface{}, pagination *Pagination) {
+	data, ok := selectFields(c, data)
+	if !ok {
+		return
+	}
 	c.JSON(statusCode, PaginatedResponse{
 		Success:    true,
 		Message:    message,
@@ -61,22 +122,37 @@ func PaginatedSuccessResponse(c *gin.Context, statusCode int, message string, da
 	})
 }
 
+func CursorPaginatedSuccessResponse(c *gin.Context, statusCode int, message string, data interface{}, pagination *CursorPagination) {
+	data, ok := selectFields(c, data)
+	if !ok {
+		return
+	}
+	c.JSON(statusCode, CursorPaginatedResponse{
+		Success:    true,
+		Message:    message,
+		Data:       data,
+		Pagination: pagination,
+	})
+}
+
 func UnauthorizedResponse(c *gin.Context, message string) {
-	ErrorResponse(c, http.StatusUnauthorized, message, "Authentication required")
+	genericErrorResponse(c, http.StatusUnauthorized, message, "Authentication required")
 }
 
 func ForbiddenResponse(c *gin.Context, message string) {
-	ErrorResponse(c, http.StatusForbidden, message, "Access denied")
+	genericErrorResponse(c, http.StatusForbidden, message, "Access denied")
 }
 
 func NotFoundResponse(c *gin.Context, message string) {
-	ErrorResponse(c, http.StatusNotFound, message, "Resource not found")
+	genericErrorResponse(c, http.StatusNotFound, message, "Resource not found")
 }
 
 func InternalServerErrorResponse(c *gin.Context, message string, err error) {
 	var errorMsg string
 	if err != nil {
 		errorMsg = err.Error()
+		// Attached for error reporting
+		_ = c.Error(err)
 	}
 	ErrorResponse(c, http.StatusInternalServerError, message, errorMsg)
 }
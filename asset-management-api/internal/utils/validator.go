@@ -3,6 +3,9 @@ package utils
 import (
 	"strings"
 
+	"asset-management-api/internal/i18n"
+
+	"github.com/gin-gonic/gin"
 	"github.com/go-playground/validator/v10"
 	"github.com/google/uuid"
 )
@@ -26,41 +29,69 @@ type ValidationError struct {
 	Message string `json:"message"`
 }
 
+// ValidateStruct validates a struct by its validate tags, with the messages
+// in English
 func ValidateStruct(s interface{}) []ValidationError {
+	return validateStruct(s, i18n.Localizer{})
+}
+
+// ValidateRequest validates a request body like ValidateStruct, with the
+// messages in the language of the request
+func ValidateRequest(c *gin.Context, s interface{}) []ValidationError {
+	return validateStruct(s, Localizer(c))
+}
+
+func validateStruct(s interface{}, localizer i18n.Localizer) []ValidationError {
 	var validationErrors []ValidationError
-	
+
 	err := validate.Struct(s)
 	if err != nil {
 		for _, err := range err.(validator.ValidationErrors) {
 			var message string
-			
+
 			switch err.Tag() {
 			case "required":
-				message = "This field is required"
+				message = localizer.Translate("This field is required")
 			case "email":
-				message = "Invalid email format"
+				message = localizer.Translate("Invalid email format")
 			case "min":
-				message = "Value is too short"
+				message = localizer.Translate("Value is too short")
 			case "max":
-				message = "Value is too long"
+				message = localizer.Translate("Value is too long")
 			case "uuid":
-				message = "Invalid UUID format"
+				message = localizer.Translate("Invalid UUID format")
 			case "oneof":
-				message = "Invalid value. Allowed values: " + err.Param()
+				message = localizer.Translate("Invalid value. Allowed values: {values}", "values", err.Param())
 			default:
-				message = "Invalid value"
+				message = localizer.Translate("Invalid value")
 			}
-			
+
 			validationErrors = append(validationErrors, ValidationError{
 				Field:   strings.ToLower(err.Field()),
 				Message: message,
 			})
 		}
 	}
-	
+
 	return validationErrors
 }
 
+// BindJSON binds the request body to a T and validates it. When either
+// fails it writes the 400 response and returns false.
+func BindJSON[T any](c *gin.Context) (T, bool) {
+	var req T
+	if err := c.ShouldBindJSON(&req); err != nil {
+		BadRequestResponse(c, "Invalid request format", err)
+		return req, false
+	}
+
+	if errors := ValidateRequest(c, req); len(errors) > 0 {
+		ValidationErrorResponse(c, GetValidationErrorMessages(errors))
+		return req, false
+	}
+	return req, true
+}
+
 func GetValidationErrorMessages(errors []ValidationError) []string {
 	var messages []string
 	for _, err := range errors {
@@ -0,0 +1,532 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"asset-management-api/internal/events/types"
+	"asset-management-api/internal/models"
+	"asset-management-api/internal/repository/interfaces"
+	"asset-management-api/pkg/eventbus"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Request headers sent with every delivery
+const (
+	HeaderSignature = "X-Webhook-Signature"
+	HeaderTimestamp = "X-Webhook-Timestamp"
+	HeaderEvent     = "X-Webhook-Event"
+	HeaderEventID   = "X-Webhook-Event-Id"
+	HeaderDelivery  = "X-Webhook-Delivery"
+)
+
+// maxDrainLength bounds how much of a response is read to reuse its connection
+const maxDrainLength = 64 << 10
+
+// Config controls delivery concurrency, timeouts and retries
+type Config struct {
+	Workers        int
+	QueueSize      int
+	MaxAttempts    int
+	Timeout        time.Duration
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	PollInterval   time.Duration
+}
+
+// ACLSource returns the user ID -> access level map of an asset, as the
+// service ACLLoader does
+type ACLSource interface {
+	FolderACL(ctx context.Context, folderID uuid.UUID) (map[string]string, error)
+	NoteACL(ctx context.Context, noteID uuid.UUID) (map[string]string, error)
+}
+
+// NoteSource looks up notes, to find the folder a note inherits shares from
+type NoteSource interface {
+	GetByID(ctx context.Context, noteID uuid.UUID) (*models.Note, error)
+}
+
+// Dispatcher turns asset and team events into signed HTTP deliveries to the
+// webhooks registered by the affected teams. Every delivery is recorded before
+// it is attempted, so the database is the source of truth: new deliveries are
+// queued straight away, while retries and deliveries that did not fit in the
+// queue are picked up by a poller once their next attempt is due. Deliveries
+// still pending at shutdown resume on the next start.
+type Dispatcher struct {
+	config      Config
+	webhookRepo interfaces.WebhookRepository
+	teamRepo    interfaces.TeamRepository
+	acl         ACLSource
+	notes       NoteSource
+	client      *http.Client
+
+	queue chan uuid.UUID
+	stop  chan struct{}
+	wg    sync.WaitGroup
+
+	// inFlight holds the deliveries queued or being attempted, so the poller
+	// never queues the same delivery twice
+	mu       sync.Mutex
+	inFlight map[uuid.UUID]struct{}
+	started  bool
+	closed   bool
+}
+
+// NewDispatcher creates a new webhook dispatcher
+func NewDispatcher(config Config, webhookRepo interfaces.WebhookRepository, teamRepo interfaces.TeamRepository, acl ACLSource, notes NoteSource) *Dispatcher {
+	if config.Workers <= 0 {
+		config.Workers = 1
+	}
+	if config.MaxAttempts <= 0 {
+		config.MaxAttempts = 1
+	}
+	if config.PollInterval <= 0 {
+		config.PollInterval = 10 * time.Second
+	}
+
+	return &Dispatcher{
+		config:      config,
+		webhookRepo: webhookRepo,
+		teamRepo:    teamRepo,
+		acl:         acl,
+		notes:       notes,
+		client:      newClient(config.Timeout),
+		queue:       make(chan uuid.UUID, config.QueueSize),
+		stop:        make(chan struct{}),
+		inFlight:    make(map[uuid.UUID]struct{}),
+	}
+}
+
+// Start launches the delivery workers and the poller for due deliveries
+func (d *Dispatcher) Start() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.started || d.closed {
+		return
+	}
+	d.started = true
+
+	for i := 0; i < d.config.Workers; i++ {
+		d.wg.Add(1)
+		go d.work()
+	}
+	d.wg.Add(1)
+	go d.poll()
+
+	log.Printf("Webhook dispatcher started with %d workers", d.config.Workers)
+}
+
+// HandleTeamEvent schedules deliveries of a team.activity event to the team's webhooks
+func (d *Dispatcher) HandleTeamEvent(ctx context.Context, eventData []byte) error {
+	envelope, err := types.OpenEnvelope(eventData)
+	if err != nil {
+		return err
+	}
+
+	var event types.BaseTeamEvent
+	if err := json.Unmarshal(envelope.Payload, &event); err != nil {
+		return eventbus.Permanent(fmt.Errorf("failed to unmarshal team event: %w", err))
+	}
+
+	return d.schedule(ctx, envelope, []uuid.UUID{event.TeamID})
+}
+
+// HandleAssetEvent schedules deliveries of an asset.changes event to the
+// webhooks of the owner's teams the asset is shared with: teams with a member
+// or manager other than the owner who can see the asset. Private assets never
+// leave the service, and neither do assets whose shares are already gone,
+// such as deleted ones.
+func (d *Dispatcher) HandleAssetEvent(ctx context.Context, eventData []byte) error {
+	envelope, err := types.OpenEnvelope(eventData)
+	if err != nil {
+		return err
+	}
+
+	var event assetEvent
+	if err := json.Unmarshal(envelope.Payload, &event); err != nil {
+		return eventbus.Permanent(fmt.Errorf("failed to unmarshal asset event: %w", err))
+	}
+
+	audience, err := d.assetAudience(ctx, &event)
+	if err != nil {
+		return err
+	}
+	if len(audience) == 0 {
+		return nil
+	}
+
+	teamIDs, err := d.sharedTeamIDs(ctx, event.OwnerID, audience)
+	if err != nil || len(teamIDs) == 0 {
+		return err
+	}
+
+	return d.schedule(ctx, envelope, teamIDs)
+}
+
+// assetEvent holds the fields of the asset events that decide who sees them
+type assetEvent struct {
+	types.BaseAssetEvent
+	FolderID           uuid.UUID `json:"folderId"`
+	UnsharedFromUserID uuid.UUID `json:"unsharedFromUserId"`
+}
+
+// assetAudience returns the users other than the owner who can see the asset,
+// including through the shares of a note's folder. The user a share was just
+// revoked from saw the asset until now, so they count too.
+func (d *Dispatcher) assetAudience(ctx context.Context, event *assetEvent) (map[uuid.UUID]bool, error) {
+	audience := make(map[uuid.UUID]bool)
+	addACL := func(acl map[string]string, err error) error {
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return fmt.Errorf("failed to get asset ACL: %w", err)
+		}
+		for userID := range acl {
+			if id, err := uuid.Parse(userID); err == nil {
+				audience[id] = true
+			}
+		}
+		return nil
+	}
+
+	switch event.AssetType {
+	case types.AssetTypeFolder:
+		if err := addACL(d.acl.FolderACL(ctx, event.AssetID)); err != nil {
+			return nil, err
+		}
+
+	case types.AssetTypeNote:
+		if err := addACL(d.acl.NoteACL(ctx, event.AssetID)); err != nil {
+			return nil, err
+		}
+
+		folderID := event.FolderID
+		if folderID == uuid.Nil {
+			note, err := d.notes.GetByID(ctx, event.AssetID)
+			if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, fmt.Errorf("failed to get note: %w", err)
+			}
+			if note != nil {
+				folderID = note.FolderID
+			}
+		}
+		if folderID != uuid.Nil {
+			if err := addACL(d.acl.FolderACL(ctx, folderID)); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if event.UnsharedFromUserID != uuid.Nil {
+		audience[event.UnsharedFromUserID] = true
+	}
+	delete(audience, event.OwnerID)
+	return audience, nil
+}
+
+// sharedTeamIDs returns the owner's teams with a manager or member in the audience
+func (d *Dispatcher) sharedTeamIDs(ctx context.Context, ownerID uuid.UUID, audience map[uuid.UUID]bool) ([]uuid.UUID, error) {
+	managed, err := d.teamRepo.GetTeamsByManagerID(ctx, ownerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get owner teams: %w", err)
+	}
+	joined, err := d.teamRepo.GetTeamsByMemberID(ctx, ownerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get owner teams: %w", err)
+	}
+
+	seen := make(map[uuid.UUID]bool)
+	var teamIDs []uuid.UUID
+	for _, team := range append(managed, joined...) {
+		if seen[team.TeamID] {
+			continue
+		}
+		seen[team.TeamID] = true
+		for _, user := range append(team.Managers, team.Members...) {
+			if audience[user.UserID] {
+				teamIDs = append(teamIDs, team.TeamID)
+				break
+			}
+		}
+	}
+	return teamIDs, nil
+}
+
+// legacyEventNamespace derives the IDs of events published without one
+var legacyEventNamespace = uuid.MustParse("5b8f1d3e-2c47-4f0a-9a6e-7d1c0b4e8f21")
+
+// deliveryEventID returns the event's ID. Legacy events carry none, so theirs
+// is derived from the payload, which stays the same when they are redelivered.
+func deliveryEventID(envelope *types.Envelope) uuid.UUID {
+	if envelope.EventID != uuid.Nil {
+		return envelope.EventID
+	}
+	return uuid.NewSHA1(legacyEventNamespace, envelope.Payload)
+}
+
+// schedule records a pending delivery for every matching webhook and queues
+// it. Events are redelivered whenever a handler sharing the subscription
+// fails, so a webhook that already has a delivery of the event is skipped.
+func (d *Dispatcher) schedule(ctx context.Context, envelope *types.Envelope, teamIDs []uuid.UUID) error {
+	webhooks, err := d.webhookRepo.GetActiveByTeamIDs(ctx, teamIDs)
+	if err != nil {
+		return fmt.Errorf("failed to get webhooks: %w", err)
+	}
+
+	var payload []byte
+	eventID := deliveryEventID(envelope)
+	for _, webhook := range webhooks {
+		if !webhook.Accepts(envelope.EventType) {
+			continue
+		}
+
+		if payload == nil {
+			// Share events carry the asset's full ACL, which is not passed
+			// on outside the service
+			envelope.RedactACLSnapshot()
+			if payload, err = json.Marshal(envelope); err != nil {
+				return fmt.Errorf("failed to marshal webhook payload: %w", err)
+			}
+		}
+
+		now := time.Now().UTC()
+		delivery := &models.WebhookDelivery{
+			WebhookID:     webhook.WebhookID,
+			EventID:       eventID,
+			EventType:     envelope.EventType,
+			Payload:       payload,
+			Status:        models.WebhookDeliveryPending,
+			NextAttemptAt: &now,
+		}
+		created, err := d.webhookRepo.CreateDelivery(ctx, delivery)
+		if err != nil {
+			return fmt.Errorf("failed to record webhook delivery: %w", err)
+		}
+		if created {
+			d.enqueue(delivery.DeliveryID)
+		}
+	}
+
+	return nil
+}
+
+// enqueue queues a delivery unless it is already in flight. A full queue is
+// not an error: the poller picks the delivery up once there is room.
+func (d *Dispatcher) enqueue(deliveryID uuid.UUID) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.closed {
+		return false
+	}
+	if _, ok := d.inFlight[deliveryID]; ok {
+		return true
+	}
+
+	select {
+	case d.queue <- deliveryID:
+		d.inFlight[deliveryID] = struct{}{}
+		return true
+	default:
+		return false
+	}
+}
+
+func (d *Dispatcher) done(deliveryID uuid.UUID) {
+	d.mu.Lock()
+	delete(d.inFlight, deliveryID)
+	d.mu.Unlock()
+}
+
+// poll queues pending deliveries whose next attempt is due
+func (d *Dispatcher) poll() {
+	defer d.wg.Done()
+
+	ticker := time.NewTicker(d.config.PollInterval)
+	defer ticker.Stop()
+
+	// Deliveries outlive the events that scheduled them
+	ctx := context.Background()
+	for {
+		d.queueDue(ctx)
+
+		select {
+		case <-ticker.C:
+		case <-d.stop:
+			return
+		}
+	}
+}
+
+func (d *Dispatcher) queueDue(ctx context.Context) {
+	deliveries, err := d.webhookRepo.GetDueDeliveries(ctx, time.Now().UTC(), cap(d.queue)+d.config.Workers)
+	if err != nil {
+		log.Printf("Failed to load due webhook deliveries: %v", err)
+		return
+	}
+
+	for _, delivery := range deliveries {
+		if !d.enqueue(delivery.DeliveryID) {
+			return // Queue full; the rest wait for the next poll
+		}
+	}
+}
+
+func (d *Dispatcher) work() {
+	defer d.wg.Done()
+
+	ctx := context.Background()
+	for {
+		select {
+		case deliveryID := <-d.queue:
+			d.deliver(ctx, deliveryID)
+			d.done(deliveryID)
+		case <-d.stop:
+			return
+		}
+	}
+}
+
+// deliver makes one attempt at a delivery and records the outcome
+func (d *Dispatcher) deliver(ctx context.Context, deliveryID uuid.UUID) {
+	delivery, webhook, err := d.load(ctx, deliveryID)
+	if err != nil {
+		log.Printf("Failed to load webhook delivery %s: %v", deliveryID, err)
+		return
+	}
+	if delivery == nil || delivery.Status != models.WebhookDeliveryPending {
+		return // Webhook deleted, or already settled
+	}
+
+	statusCode, err := d.send(webhook, delivery)
+	delivery.Attempts++
+	delivery.ResponseStatus = statusCode
+	now := time.Now().UTC()
+
+	switch {
+	case err == nil:
+		delivery.Status = models.WebhookDeliverySucceeded
+		delivery.LastError = ""
+		delivery.NextAttemptAt = nil
+		delivery.CompletedAt = &now
+	case delivery.Attempts >= d.config.MaxAttempts:
+		delivery.Status = models.WebhookDeliveryFailed
+		delivery.LastError = err.Error()
+		delivery.NextAttemptAt = nil
+		delivery.CompletedAt = &now
+		log.Printf("Webhook delivery %s to %s failed after %d attempts: %v", delivery.DeliveryID, webhook.URL, delivery.Attempts, err)
+	default:
+		next := now.Add(d.backoff(delivery.Attempts))
+		delivery.LastError = err.Error()
+		delivery.NextAttemptAt = &next
+	}
+
+	if err := d.webhookRepo.UpdateDelivery(ctx, delivery); err != nil {
+		log.Printf("Failed to record webhook delivery %s: %v", delivery.DeliveryID, err)
+	}
+}
+
+// load returns the delivery and its webhook, or nil when either no longer exists
+func (d *Dispatcher) load(ctx context.Context, deliveryID uuid.UUID) (*models.WebhookDelivery, *models.Webhook, error) {
+	delivery, err := d.webhookRepo.GetDelivery(ctx, deliveryID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, nil
+		}
+		return nil, nil, err
+	}
+
+	webhook, err := d.webhookRepo.GetByID(ctx, delivery.WebhookID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, nil
+		}
+		return nil, nil, err
+	}
+
+	return delivery, webhook, nil
+}
+
+// send POSTs the signed payload and returns the response status. Any non-2xx
+// response, redirects included, is an error. Only the status is recorded: the
+// response body is never read back, so deliveries cannot be used to fetch
+// content from the endpoint.
+func (d *Dispatcher) send(webhook *models.Webhook, delivery *models.WebhookDelivery) (int, error) {
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+
+	req, err := http.NewRequest(http.MethodPost, webhook.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "asset-management-api-webhooks")
+	req.Header.Set(HeaderTimestamp, timestamp)
+	req.Header.Set(HeaderSignature, Sign(webhook.Secret, timestamp, delivery.Payload))
+	req.Header.Set(HeaderEvent, delivery.EventType)
+	req.Header.Set(HeaderEventID, delivery.EventID.String())
+	req.Header.Set(HeaderDelivery, delivery.DeliveryID.String())
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	io.Copy(io.Discard, io.LimitReader(resp.Body, maxDrainLength)) // Drain so the connection can be reused
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("endpoint returned status %d", resp.StatusCode)
+	}
+
+	return resp.StatusCode, nil
+}
+
+// backoff doubles the initial backoff for every failed attempt, up to the maximum
+func (d *Dispatcher) backoff(attempts int) time.Duration {
+	backoff := d.config.InitialBackoff
+	for i := 1; i < attempts && backoff < d.config.MaxBackoff; i++ {
+		backoff *= 2
+	}
+	if d.config.MaxBackoff > 0 && backoff > d.config.MaxBackoff {
+		backoff = d.config.MaxBackoff
+	}
+	return backoff
+}
+
+// Close stops queueing and waits for in-progress attempts to finish. Queued
+// deliveries stay pending and resume on the next start.
+func (d *Dispatcher) Close() error {
+	d.mu.Lock()
+	if d.closed {
+		d.mu.Unlock()
+		return nil
+	}
+	d.closed = true
+	d.mu.Unlock()
+
+	close(d.stop)
+	d.wg.Wait()
+	return nil
+}
+
+// HealthCheck returns the health status of the dispatcher
+func (d *Dispatcher) HealthCheck() map[string]interface{} {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return map[string]interface{}{
+		"status":    "healthy",
+		"workers":   d.config.Workers,
+		"queued":    len(d.queue),
+		"in_flight": len(d.inFlight),
+	}
+}
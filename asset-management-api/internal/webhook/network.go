@@ -0,0 +1,80 @@
+package webhook
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// ErrBlockedAddress is returned when a webhook resolves to an address inside
+// the service's own networks
+var ErrBlockedAddress = errors.New("webhook address is not publicly routable")
+
+// IsBlockedAddr reports whether deliveries may not be sent to the address:
+// loopback, private, link-local, unique-local and unspecified addresses reach
+// the service's own host and networks rather than a team's endpoint
+func IsBlockedAddr(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	return !addr.IsValid() ||
+		addr.IsLoopback() ||
+		addr.IsPrivate() || // Includes IPv6 unique-local fc00::/7
+		addr.IsLinkLocalUnicast() ||
+		addr.IsLinkLocalMulticast() ||
+		addr.IsUnspecified()
+}
+
+// IsBlockedHost reports whether a webhook URL's host is known to be blocked
+// without resolving it: a blocked IP literal or localhost. Other host names
+// are checked when deliveries connect, as their addresses can change.
+func IsBlockedHost(host string) bool {
+	host = strings.TrimSuffix(strings.ToLower(host), ".")
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return true
+	}
+	addr, err := netip.ParseAddr(strings.Trim(host, "[]"))
+	return err == nil && IsBlockedAddr(addr)
+}
+
+// dialControl refuses connections to blocked addresses. It runs after DNS
+// resolution, for every address dialed, so a host name cannot point
+// deliveries at an internal service.
+func dialControl(network, address string, _ syscall.RawConn) error {
+	addrPort, err := netip.ParseAddrPort(address)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrBlockedAddress, address)
+	}
+	if IsBlockedAddr(addrPort.Addr()) {
+		return fmt.Errorf("%w: %s", ErrBlockedAddress, addrPort.Addr())
+	}
+	return nil
+}
+
+// newClient creates the HTTP client deliveries are sent with. It connects
+// only to public addresses, ignores proxy settings, which would bypass that
+// check, and does not follow redirects, which could lead anywhere.
+func newClient(timeout time.Duration) *http.Client {
+	dialer := &net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: 30 * time.Second,
+		Control:   dialControl,
+	}
+	return &http.Client{
+		Timeout: timeout,
+		Transport: &http.Transport{
+			DialContext:           dialer.DialContext,
+			ForceAttemptHTTP2:     true,
+			MaxIdleConns:          100,
+			IdleConnTimeout:       90 * time.Second,
+			TLSHandshakeTimeout:   10 * time.Second,
+			ExpectContinueTimeout: 1 * time.Second,
+		},
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
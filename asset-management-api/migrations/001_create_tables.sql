@@ -1,3 +1,4 @@
+-- +goose Up
 -- Create users table
 CREATE TABLE IF NOT EXISTS users (
     user_id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
@@ -89,4 +90,14 @@ INSERT INTO users (username, email, password_hash, role) VALUES
 ('john_manager', 'john@example.com', '$2a$10$dummy_hash_here', 'manager'),
 ('alice_member', 'alice@example.com', '$2a$10$dummy_hash_here', 'member'),
 ('bob_member', 'bob@example.com', '$2a$10$dummy_hash_here', 'member')
-ON CONFLICT (email) DO NOTHING;
\ No newline at end of file
+ON CONFLICT (email) DO NOTHING;
+
+-- +goose Down
+DROP TABLE IF EXISTS note_shares;
+DROP TABLE IF EXISTS folder_shares;
+DROP TABLE IF EXISTS notes;
+DROP TABLE IF EXISTS folders;
+DROP TABLE IF EXISTS team_members;
+DROP TABLE IF EXISTS team_managers;
+DROP TABLE IF EXISTS teams;
+DROP TABLE IF EXISTS users;
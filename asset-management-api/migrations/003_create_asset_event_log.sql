@@ -0,0 +1,25 @@
+-- +goose Up
+-- Create asset_event_log table, the event store for asset.changes
+CREATE TABLE IF NOT EXISTS asset_event_log (
+    id BIGSERIAL PRIMARY KEY,
+    event_id UUID UNIQUE,
+    event_type VARCHAR(50) NOT NULL,
+    asset_type VARCHAR(20) NOT NULL,
+    asset_id UUID NOT NULL,
+    owner_id UUID NOT NULL,
+    action_by UUID NOT NULL,
+    payload JSONB NOT NULL,
+    schema_version INTEGER NOT NULL,
+    producer VARCHAR(255),
+    trace_id VARCHAR(255),
+    occurred_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    recorded_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Assets are not foreign keys: history must outlive deleted assets
+CREATE INDEX IF NOT EXISTS idx_asset_event_log_asset_id ON asset_event_log(asset_id, occurred_at DESC);
+CREATE INDEX IF NOT EXISTS idx_asset_event_log_owner_id ON asset_event_log(owner_id, occurred_at DESC);
+CREATE INDEX IF NOT EXISTS idx_asset_event_log_event_type ON asset_event_log(event_type);
+
+-- +goose Down
+DROP TABLE IF EXISTS asset_event_log;
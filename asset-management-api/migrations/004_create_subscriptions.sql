@@ -0,0 +1,40 @@
+-- +goose Up
+-- Create subscriptions table. asset_id references a folder or a note,
+-- depending on asset_type; subscriptions of a deleted asset are removed when
+-- its delete event is consumed.
+CREATE TABLE IF NOT EXISTS subscriptions (
+    subscription_id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+    asset_type VARCHAR(20) NOT NULL CHECK (asset_type IN ('folder', 'note')),
+    asset_id UUID NOT NULL,
+    event_types JSONB NOT NULL DEFAULT '[]',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (user_id, asset_id)
+);
+
+-- Create notifications table
+CREATE TABLE IF NOT EXISTS notifications (
+    notification_id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+    -- Notifications outlive the subscription, e.g. the one about a deletion
+    subscription_id UUID REFERENCES subscriptions(subscription_id) ON DELETE SET NULL,
+    event_id UUID NOT NULL,
+    event_type VARCHAR(100) NOT NULL,
+    asset_type VARCHAR(20) NOT NULL,
+    asset_id UUID NOT NULL,
+    action_by UUID,
+    payload JSONB NOT NULL,
+    read_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    -- A redelivered event never notifies a user twice
+    UNIQUE (user_id, event_id)
+);
+
+-- Create indexes for better performance
+CREATE INDEX IF NOT EXISTS idx_subscriptions_asset_id ON subscriptions(asset_id);
+CREATE INDEX IF NOT EXISTS idx_notifications_user_id ON notifications(user_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_notifications_unread ON notifications(user_id) WHERE read_at IS NULL;
+
+-- +goose Down
+DROP TABLE IF EXISTS notifications;
+DROP TABLE IF EXISTS subscriptions;
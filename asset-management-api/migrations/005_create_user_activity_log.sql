@@ -0,0 +1,42 @@
+-- +goose Up
+-- Create user_activity_log table, the audit store for user.activity
+CREATE TABLE IF NOT EXISTS user_activity_log (
+    id BIGSERIAL PRIMARY KEY,
+    event_id UUID NOT NULL UNIQUE,
+    actor_id UUID NOT NULL,
+    actor_role VARCHAR(20),
+    operation VARCHAR(100) NOT NULL,
+    method VARCHAR(10) NOT NULL,
+    endpoint VARCHAR(255) NOT NULL,
+    entity_type VARCHAR(50),
+    entity_id VARCHAR(255),
+    result VARCHAR(20) NOT NULL,
+    http_status INTEGER NOT NULL,
+    client_ip VARCHAR(45),
+    payload JSONB NOT NULL,
+    trace_id VARCHAR(255),
+    occurred_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    recorded_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Actors are not foreign keys: the audit trail must outlive deleted users
+CREATE INDEX IF NOT EXISTS idx_user_activity_log_actor_id ON user_activity_log(actor_id, occurred_at DESC);
+CREATE INDEX IF NOT EXISTS idx_user_activity_log_entity ON user_activity_log(entity_type, entity_id, occurred_at DESC);
+
+-- The audit trail is append-only
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION reject_user_activity_log_change() RETURNS TRIGGER AS $$
+BEGIN
+    RAISE EXCEPTION 'user_activity_log is append-only';
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+DROP TRIGGER IF EXISTS user_activity_log_append_only ON user_activity_log;
+CREATE TRIGGER user_activity_log_append_only
+    BEFORE UPDATE OR DELETE ON user_activity_log
+    FOR EACH ROW EXECUTE FUNCTION reject_user_activity_log_change();
+
+-- +goose Down
+DROP TABLE IF EXISTS user_activity_log;
+DROP FUNCTION IF EXISTS reject_user_activity_log_change();
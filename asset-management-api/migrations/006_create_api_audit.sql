@@ -0,0 +1,55 @@
+-- +goose Up
+-- Create api_audit table, the record of every mutating API request,
+-- partitioned by month so old months can be detached or dropped whole
+CREATE TABLE IF NOT EXISTS api_audit (
+    id BIGSERIAL,
+    request_id VARCHAR(64),
+    actor_id UUID,
+    actor_role VARCHAR(20),
+    method VARCHAR(10) NOT NULL,
+    route VARCHAR(255) NOT NULL,
+    path VARCHAR(2048) NOT NULL,
+    entity_type VARCHAR(50),
+    entity_id VARCHAR(255),
+    params JSONB,
+    outcome VARCHAR(20) NOT NULL,
+    http_status INTEGER NOT NULL,
+    latency_ms BIGINT NOT NULL,
+    client_ip VARCHAR(45),
+    trace_id VARCHAR(255),
+    occurred_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    PRIMARY KEY (id, occurred_at)
+) PARTITION BY RANGE (occurred_at);
+
+-- Rows outside the created months land here rather than being rejected
+CREATE TABLE IF NOT EXISTS api_audit_default PARTITION OF api_audit DEFAULT;
+
+-- Actors are not foreign keys: the audit trail must outlive deleted users
+CREATE INDEX IF NOT EXISTS idx_api_audit_occurred_at ON api_audit(occurred_at DESC);
+CREATE INDEX IF NOT EXISTS idx_api_audit_actor_id ON api_audit(actor_id, occurred_at DESC);
+CREATE INDEX IF NOT EXISTS idx_api_audit_entity ON api_audit(entity_type, entity_id, occurred_at DESC);
+
+-- create_api_audit_partition creates the partition of the month containing
+-- the given time; the API calls it for the current and next month
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION create_api_audit_partition(month_of TIMESTAMP WITH TIME ZONE) RETURNS VOID AS $$
+DECLARE
+    month_start DATE := date_trunc('month', month_of AT TIME ZONE 'UTC')::DATE;
+    partition_name TEXT := 'api_audit_' || to_char(month_start, 'YYYY_MM');
+BEGIN
+    EXECUTE format(
+        'CREATE TABLE IF NOT EXISTS %I PARTITION OF api_audit FOR VALUES FROM (%L) TO (%L)',
+        partition_name,
+        month_start::TIMESTAMP AT TIME ZONE 'UTC',
+        (month_start + INTERVAL '1 month')::TIMESTAMP AT TIME ZONE 'UTC'
+    );
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+SELECT create_api_audit_partition(CURRENT_TIMESTAMP);
+SELECT create_api_audit_partition(CURRENT_TIMESTAMP + INTERVAL '1 month');
+
+-- +goose Down
+DROP FUNCTION IF EXISTS create_api_audit_partition(TIMESTAMP WITH TIME ZONE);
+DROP TABLE IF EXISTS api_audit;
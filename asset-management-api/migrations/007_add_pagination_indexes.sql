@@ -0,0 +1,15 @@
+-- +goose Up
+-- Lists are paged newest first by (created_at, id); these indexes let a page
+-- be read from where the previous one ended
+CREATE INDEX IF NOT EXISTS idx_folders_owner_id_created_at ON folders(owner_id, created_at DESC, folder_id DESC);
+CREATE INDEX IF NOT EXISTS idx_notes_folder_id_created_at ON notes(folder_id, created_at DESC, note_id DESC);
+CREATE INDEX IF NOT EXISTS idx_notes_owner_id_created_at ON notes(owner_id, created_at DESC, note_id DESC);
+CREATE INDEX IF NOT EXISTS idx_folder_shares_folder_id_created_at ON folder_shares(folder_id, created_at DESC, shared_with_user_id DESC);
+CREATE INDEX IF NOT EXISTS idx_note_shares_note_id_created_at ON note_shares(note_id, created_at DESC, shared_with_user_id DESC);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_note_shares_note_id_created_at;
+DROP INDEX IF EXISTS idx_folder_shares_folder_id_created_at;
+DROP INDEX IF EXISTS idx_notes_owner_id_created_at;
+DROP INDEX IF EXISTS idx_notes_folder_id_created_at;
+DROP INDEX IF EXISTS idx_folders_owner_id_created_at;
@@ -0,0 +1,29 @@
+-- +goose Up
+-- Deleted folders, notes, shares and teams are kept, marked by deleted_at,
+-- so they can be restored; queries skip them. Foreign keys no longer cascade
+-- such deletes, the application deletes the dependent rows itself.
+ALTER TABLE folders ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE notes ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE folder_shares ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE note_shares ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE teams ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP WITH TIME ZONE;
+
+CREATE INDEX IF NOT EXISTS idx_folders_deleted_at ON folders(deleted_at);
+CREATE INDEX IF NOT EXISTS idx_notes_deleted_at ON notes(deleted_at);
+CREATE INDEX IF NOT EXISTS idx_folder_shares_deleted_at ON folder_shares(deleted_at);
+CREATE INDEX IF NOT EXISTS idx_note_shares_deleted_at ON note_shares(deleted_at);
+CREATE INDEX IF NOT EXISTS idx_teams_deleted_at ON teams(deleted_at);
+
+-- +goose Down
+-- Rows deleted in the meantime are purged, their dependents cascade
+DELETE FROM note_shares WHERE deleted_at IS NOT NULL;
+DELETE FROM folder_shares WHERE deleted_at IS NOT NULL;
+DELETE FROM notes WHERE deleted_at IS NOT NULL;
+DELETE FROM folders WHERE deleted_at IS NOT NULL;
+DELETE FROM teams WHERE deleted_at IS NOT NULL;
+
+ALTER TABLE teams DROP COLUMN IF EXISTS deleted_at;
+ALTER TABLE note_shares DROP COLUMN IF EXISTS deleted_at;
+ALTER TABLE folder_shares DROP COLUMN IF EXISTS deleted_at;
+ALTER TABLE notes DROP COLUMN IF EXISTS deleted_at;
+ALTER TABLE folders DROP COLUMN IF EXISTS deleted_at;
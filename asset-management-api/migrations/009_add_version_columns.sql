@@ -0,0 +1,11 @@
+-- +goose Up
+-- Folders, notes and teams carry a version, raised by every update, so that
+-- an update made from a stale read fails instead of overwriting the newer one
+ALTER TABLE folders ADD COLUMN IF NOT EXISTS version BIGINT NOT NULL DEFAULT 1;
+ALTER TABLE notes ADD COLUMN IF NOT EXISTS version BIGINT NOT NULL DEFAULT 1;
+ALTER TABLE teams ADD COLUMN IF NOT EXISTS version BIGINT NOT NULL DEFAULT 1;
+
+-- +goose Down
+ALTER TABLE teams DROP COLUMN IF EXISTS version;
+ALTER TABLE notes DROP COLUMN IF EXISTS version;
+ALTER TABLE folders DROP COLUMN IF EXISTS version;
@@ -0,0 +1,246 @@
+-- +goose Up
+-- Partition the event store, the user activity trail and the notifications
+-- by month like api_audit, so the months past their retention can be dropped
+-- whole. Unique keys of a partitioned table must include the partition key:
+-- a redelivered event carries the same time as the original, so it still
+-- hits the extended keys.
+
+-- create_monthly_partition creates the partition of the month containing the
+-- given time, named <table>_YYYY_MM; the API calls it for the current and
+-- next month of every partitioned table
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION create_monthly_partition(parent_table TEXT, month_of TIMESTAMP WITH TIME ZONE) RETURNS VOID AS $$
+DECLARE
+    month_start DATE := date_trunc('month', month_of AT TIME ZONE 'UTC')::DATE;
+    partition_name TEXT := parent_table || '_' || to_char(month_start, 'YYYY_MM');
+BEGIN
+    EXECUTE format(
+        'CREATE TABLE IF NOT EXISTS %I PARTITION OF %I FOR VALUES FROM (%L) TO (%L)',
+        partition_name,
+        parent_table,
+        month_start::TIMESTAMP AT TIME ZONE 'UTC',
+        (month_start + INTERVAL '1 month')::TIMESTAMP AT TIME ZONE 'UTC'
+    );
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+-- drop_monthly_partitions_before drops the monthly partitions ending on or
+-- before the start of the month containing cutoff and returns their names.
+-- The default partition is kept.
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION drop_monthly_partitions_before(parent_table TEXT, cutoff TIMESTAMP WITH TIME ZONE) RETURNS SETOF TEXT AS $$
+DECLARE
+    cutoff_month DATE := date_trunc('month', cutoff AT TIME ZONE 'UTC')::DATE;
+    partition_name TEXT;
+BEGIN
+    FOR partition_name IN
+        SELECT child.relname
+        FROM pg_inherits
+        JOIN pg_class child ON child.oid = pg_inherits.inhrelid
+        WHERE pg_inherits.inhparent = parent_table::REGCLASS
+          AND child.relname ~ ('^' || parent_table || '_[0-9]{4}_[0-9]{2}$')
+          AND to_date(right(child.relname, 7), 'YYYY_MM') < cutoff_month
+        ORDER BY child.relname
+    LOOP
+        EXECUTE format('DROP TABLE IF EXISTS %I', partition_name);
+        RETURN NEXT partition_name;
+    END LOOP;
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+-- Superseded by create_monthly_partition
+DROP FUNCTION IF EXISTS create_api_audit_partition(TIMESTAMP WITH TIME ZONE);
+
+-- Each table is recreated partitioned and its rows copied over, after the
+-- partitions of the months they fall in are created. The id sequences are
+-- kept, so ids continue where they left off.
+ALTER TABLE asset_event_log RENAME TO asset_event_log_unpartitioned;
+CREATE TABLE asset_event_log (
+    id BIGINT NOT NULL DEFAULT nextval('asset_event_log_id_seq'),
+    event_id UUID,
+    event_type VARCHAR(50) NOT NULL,
+    asset_type VARCHAR(20) NOT NULL,
+    asset_id UUID NOT NULL,
+    owner_id UUID NOT NULL,
+    action_by UUID NOT NULL,
+    payload JSONB NOT NULL,
+    schema_version INTEGER NOT NULL,
+    producer VARCHAR(255),
+    trace_id VARCHAR(255),
+    occurred_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    recorded_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+) PARTITION BY RANGE (occurred_at);
+CREATE TABLE asset_event_log_default PARTITION OF asset_event_log DEFAULT;
+SELECT create_monthly_partition('asset_event_log', month) FROM (
+    SELECT date_trunc('month', occurred_at) AS month FROM asset_event_log_unpartitioned
+    UNION SELECT CURRENT_TIMESTAMP
+    UNION SELECT CURRENT_TIMESTAMP + INTERVAL '1 month'
+) months;
+INSERT INTO asset_event_log SELECT * FROM asset_event_log_unpartitioned;
+ALTER SEQUENCE asset_event_log_id_seq OWNED BY asset_event_log.id;
+DROP TABLE asset_event_log_unpartitioned;
+ALTER TABLE asset_event_log ADD PRIMARY KEY (id, occurred_at), ADD UNIQUE (event_id, occurred_at);
+CREATE INDEX IF NOT EXISTS idx_asset_event_log_asset_id ON asset_event_log(asset_id, occurred_at DESC);
+CREATE INDEX IF NOT EXISTS idx_asset_event_log_owner_id ON asset_event_log(owner_id, occurred_at DESC);
+CREATE INDEX IF NOT EXISTS idx_asset_event_log_event_type ON asset_event_log(event_type);
+
+ALTER TABLE user_activity_log RENAME TO user_activity_log_unpartitioned;
+CREATE TABLE user_activity_log (
+    id BIGINT NOT NULL DEFAULT nextval('user_activity_log_id_seq'),
+    event_id UUID NOT NULL,
+    actor_id UUID NOT NULL,
+    actor_role VARCHAR(20),
+    operation VARCHAR(100) NOT NULL,
+    method VARCHAR(10) NOT NULL,
+    endpoint VARCHAR(255) NOT NULL,
+    entity_type VARCHAR(50),
+    entity_id VARCHAR(255),
+    result VARCHAR(20) NOT NULL,
+    http_status INTEGER NOT NULL,
+    client_ip VARCHAR(45),
+    payload JSONB NOT NULL,
+    trace_id VARCHAR(255),
+    occurred_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    recorded_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+) PARTITION BY RANGE (occurred_at);
+CREATE TABLE user_activity_log_default PARTITION OF user_activity_log DEFAULT;
+SELECT create_monthly_partition('user_activity_log', month) FROM (
+    SELECT date_trunc('month', occurred_at) AS month FROM user_activity_log_unpartitioned
+    UNION SELECT CURRENT_TIMESTAMP
+    UNION SELECT CURRENT_TIMESTAMP + INTERVAL '1 month'
+) months;
+INSERT INTO user_activity_log SELECT * FROM user_activity_log_unpartitioned;
+ALTER SEQUENCE user_activity_log_id_seq OWNED BY user_activity_log.id;
+DROP TABLE user_activity_log_unpartitioned;
+ALTER TABLE user_activity_log ADD PRIMARY KEY (id, occurred_at), ADD UNIQUE (event_id, occurred_at);
+CREATE INDEX IF NOT EXISTS idx_user_activity_log_actor_id ON user_activity_log(actor_id, occurred_at DESC);
+CREATE INDEX IF NOT EXISTS idx_user_activity_log_entity ON user_activity_log(entity_type, entity_id, occurred_at DESC);
+-- Rows stay append-only; dropping a whole partition is the one way out
+CREATE TRIGGER user_activity_log_append_only
+    BEFORE UPDATE OR DELETE ON user_activity_log
+    FOR EACH ROW EXECUTE FUNCTION reject_user_activity_log_change();
+
+-- Notifications are dated by the event they are about (see the notifier)
+ALTER TABLE notifications RENAME TO notifications_unpartitioned;
+CREATE TABLE notifications (
+    notification_id UUID NOT NULL DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+    subscription_id UUID REFERENCES subscriptions(subscription_id) ON DELETE SET NULL,
+    event_id UUID NOT NULL,
+    event_type VARCHAR(100) NOT NULL,
+    asset_type VARCHAR(20) NOT NULL,
+    asset_id UUID NOT NULL,
+    action_by UUID,
+    payload JSONB NOT NULL,
+    read_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+) PARTITION BY RANGE (created_at);
+CREATE TABLE notifications_default PARTITION OF notifications DEFAULT;
+SELECT create_monthly_partition('notifications', month) FROM (
+    SELECT date_trunc('month', created_at) AS month FROM notifications_unpartitioned WHERE created_at IS NOT NULL
+    UNION SELECT CURRENT_TIMESTAMP
+    UNION SELECT CURRENT_TIMESTAMP + INTERVAL '1 month'
+) months;
+INSERT INTO notifications
+SELECT notification_id, user_id, subscription_id, event_id, event_type, asset_type, asset_id,
+       action_by, payload, read_at, COALESCE(created_at, CURRENT_TIMESTAMP)
+FROM notifications_unpartitioned;
+DROP TABLE notifications_unpartitioned;
+ALTER TABLE notifications ADD PRIMARY KEY (notification_id, created_at), ADD UNIQUE (user_id, event_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_notifications_user_id ON notifications(user_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_notifications_unread ON notifications(user_id) WHERE read_at IS NULL;
+
+-- +goose Down
+ALTER TABLE notifications RENAME TO notifications_partitioned;
+CREATE TABLE notifications (
+    notification_id UUID NOT NULL DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+    subscription_id UUID REFERENCES subscriptions(subscription_id) ON DELETE SET NULL,
+    event_id UUID NOT NULL,
+    event_type VARCHAR(100) NOT NULL,
+    asset_type VARCHAR(20) NOT NULL,
+    asset_id UUID NOT NULL,
+    action_by UUID,
+    payload JSONB NOT NULL,
+    read_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+INSERT INTO notifications SELECT * FROM notifications_partitioned;
+DROP TABLE notifications_partitioned;
+ALTER TABLE notifications ADD PRIMARY KEY (notification_id), ADD UNIQUE (user_id, event_id);
+CREATE INDEX IF NOT EXISTS idx_notifications_user_id ON notifications(user_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_notifications_unread ON notifications(user_id) WHERE read_at IS NULL;
+
+ALTER TABLE user_activity_log RENAME TO user_activity_log_partitioned;
+CREATE TABLE user_activity_log (
+    id BIGINT NOT NULL DEFAULT nextval('user_activity_log_id_seq'),
+    event_id UUID NOT NULL,
+    actor_id UUID NOT NULL,
+    actor_role VARCHAR(20),
+    operation VARCHAR(100) NOT NULL,
+    method VARCHAR(10) NOT NULL,
+    endpoint VARCHAR(255) NOT NULL,
+    entity_type VARCHAR(50),
+    entity_id VARCHAR(255),
+    result VARCHAR(20) NOT NULL,
+    http_status INTEGER NOT NULL,
+    client_ip VARCHAR(45),
+    payload JSONB NOT NULL,
+    trace_id VARCHAR(255),
+    occurred_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    recorded_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+INSERT INTO user_activity_log SELECT * FROM user_activity_log_partitioned;
+ALTER SEQUENCE user_activity_log_id_seq OWNED BY user_activity_log.id;
+DROP TABLE user_activity_log_partitioned;
+ALTER TABLE user_activity_log ADD PRIMARY KEY (id), ADD UNIQUE (event_id);
+CREATE INDEX IF NOT EXISTS idx_user_activity_log_actor_id ON user_activity_log(actor_id, occurred_at DESC);
+CREATE INDEX IF NOT EXISTS idx_user_activity_log_entity ON user_activity_log(entity_type, entity_id, occurred_at DESC);
+CREATE TRIGGER user_activity_log_append_only
+    BEFORE UPDATE OR DELETE ON user_activity_log
+    FOR EACH ROW EXECUTE FUNCTION reject_user_activity_log_change();
+
+ALTER TABLE asset_event_log RENAME TO asset_event_log_partitioned;
+CREATE TABLE asset_event_log (
+    id BIGINT NOT NULL DEFAULT nextval('asset_event_log_id_seq'),
+    event_id UUID,
+    event_type VARCHAR(50) NOT NULL,
+    asset_type VARCHAR(20) NOT NULL,
+    asset_id UUID NOT NULL,
+    owner_id UUID NOT NULL,
+    action_by UUID NOT NULL,
+    payload JSONB NOT NULL,
+    schema_version INTEGER NOT NULL,
+    producer VARCHAR(255),
+    trace_id VARCHAR(255),
+    occurred_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    recorded_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+INSERT INTO asset_event_log SELECT * FROM asset_event_log_partitioned;
+ALTER SEQUENCE asset_event_log_id_seq OWNED BY asset_event_log.id;
+DROP TABLE asset_event_log_partitioned;
+ALTER TABLE asset_event_log ADD PRIMARY KEY (id), ADD UNIQUE (event_id);
+CREATE INDEX IF NOT EXISTS idx_asset_event_log_asset_id ON asset_event_log(asset_id, occurred_at DESC);
+CREATE INDEX IF NOT EXISTS idx_asset_event_log_owner_id ON asset_event_log(owner_id, occurred_at DESC);
+CREATE INDEX IF NOT EXISTS idx_asset_event_log_event_type ON asset_event_log(event_type);
+
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION create_api_audit_partition(month_of TIMESTAMP WITH TIME ZONE) RETURNS VOID AS $$
+DECLARE
+    month_start DATE := date_trunc('month', month_of AT TIME ZONE 'UTC')::DATE;
+    partition_name TEXT := 'api_audit_' || to_char(month_start, 'YYYY_MM');
+BEGIN
+    EXECUTE format(
+        'CREATE TABLE IF NOT EXISTS %I PARTITION OF api_audit FOR VALUES FROM (%L) TO (%L)',
+        partition_name,
+        month_start::TIMESTAMP AT TIME ZONE 'UTC',
+        (month_start + INTERVAL '1 month')::TIMESTAMP AT TIME ZONE 'UTC'
+    );
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+DROP FUNCTION IF EXISTS drop_monthly_partitions_before(TEXT, TIMESTAMP WITH TIME ZONE);
+DROP FUNCTION IF EXISTS create_monthly_partition(TEXT, TIMESTAMP WITH TIME ZONE);
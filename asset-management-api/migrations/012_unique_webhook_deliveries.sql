@@ -0,0 +1,16 @@
+-- +goose Up
+-- A webhook gets one delivery per event, however often the event is
+-- redelivered. Deliveries recorded without an event ID never conflict; of the
+-- duplicates already recorded, the first is kept.
+UPDATE webhook_deliveries SET event_id = NULL WHERE event_id = '00000000-0000-0000-0000-000000000000';
+
+DELETE FROM webhook_deliveries d
+USING webhook_deliveries e
+WHERE d.webhook_id = e.webhook_id
+  AND d.event_id = e.event_id
+  AND (d.created_at, d.delivery_id) > (e.created_at, e.delivery_id);
+
+ALTER TABLE webhook_deliveries ADD CONSTRAINT uq_webhook_deliveries_webhook_event UNIQUE (webhook_id, event_id);
+
+-- +goose Down
+ALTER TABLE webhook_deliveries DROP CONSTRAINT IF EXISTS uq_webhook_deliveries_webhook_event;
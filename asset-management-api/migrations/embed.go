@@ -0,0 +1,11 @@
+// Package migrations holds the versioned SQL migrations of the database, in
+// goose format: each file's number is its version, and its "-- +goose Up"
+// and "-- +goose Down" sections apply and revert it.
+package migrations
+
+import "embed"
+
+// FS holds the migration files, built into the binaries that run them
+//
+//go:embed *.sql
+var FS embed.FS
@@ -2,6 +2,9 @@ package cache
 
 import (
 	"context"
+	"errors"
+	"strconv"
+	"sync/atomic"
 	"time"
 
 	"github.com/google/uuid"
@@ -13,25 +16,52 @@ type CacheService interface {
 	// Team member caching
 	CacheTeamMembers(ctx context.Context, teamID uuid.UUID, members []uuid.UUID) error
 	GetTeamMembers(ctx context.Context, teamID uuid.UUID) ([]uuid.UUID, error)
+	// IsTeamMember reports membership; cached is false on a cache miss
+	IsTeamMember(ctx context.Context, teamID, userID uuid.UUID) (isMember bool, cached bool, err error)
 	AddTeamMember(ctx context.Context, teamID, memberID uuid.UUID) error
 	RemoveTeamMember(ctx context.Context, teamID, memberID uuid.UUID) error
 	InvalidateTeamMembers(ctx context.Context, teamID uuid.UUID) error
 
+	// User -> teams caching; GetUserTeams returns nil on a cache miss
+	CacheUserTeams(ctx context.Context, userID uuid.UUID, teams []*models.Team) error
+	GetUserTeams(ctx context.Context, userID uuid.UUID) ([]*models.Team, error)
+	InvalidateUserTeams(ctx context.Context, userID uuid.UUID) error
+
+	// User profile caching for authorization lookups; GetUserProfile returns
+	// nil on a cache miss. Password hashes are never cached.
+	CacheUserProfile(ctx context.Context, user *models.User) error
+	GetUserProfile(ctx context.Context, userID uuid.UUID) (*models.User, error)
+	InvalidateUserProfile(ctx context.Context, userID uuid.UUID) error
+
 	// Asset metadata caching
 	CacheFolderMetadata(ctx context.Context, folder *models.Folder) error
 	GetFolderMetadata(ctx context.Context, folderID uuid.UUID) (*models.Folder, error)
 	CacheNoteMetadata(ctx context.Context, note *models.Note) error
 	GetNoteMetadata(ctx context.Context, noteID uuid.UUID) (*models.Note, error)
+	// Batch lookups return only the hits; missing IDs are absent from the map
+	GetFolderMetadataBatch(ctx context.Context, folderIDs []uuid.UUID) (map[uuid.UUID]*models.Folder, error)
+	GetNoteMetadataBatch(ctx context.Context, noteIDs []uuid.UUID) (map[uuid.UUID]*models.Note, error)
 	InvalidateFolderMetadata(ctx context.Context, folderID uuid.UUID) error
 	InvalidateNoteMetadata(ctx context.Context, noteID uuid.UUID) error
 
 	// Access control caching
 	CacheAssetACL(ctx context.Context, assetID uuid.UUID, acl map[string]string) error
+	// ReplaceAssetACL overwrites the cached ACL, dropping users missing from acl
+	ReplaceAssetACL(ctx context.Context, assetID uuid.UUID, acl map[string]string) error
 	GetAssetACL(ctx context.Context, assetID uuid.UUID) (map[string]string, error)
 	UpdateAssetACL(ctx context.Context, assetID, userID uuid.UUID, accessLevel string) error
 	RemoveAssetACL(ctx context.Context, assetID, userID uuid.UUID) error
 	InvalidateAssetACL(ctx context.Context, assetID uuid.UUID) error
 
+	// Key namespace versioning
+	KeyVersion() int64
+	BumpKeyVersion(ctx context.Context) (int64, error)
+
+	// Administration; key names are given without the version prefix.
+	// InspectKey returns nil when the key does not exist.
+	InspectKey(ctx context.Context, key string) (*KeyInfo, error)
+	Stats(ctx context.Context) (*CacheStats, error)
+
 	// Generic cache operations
 	HealthCheck() map[string]interface{}
 	Close() error
@@ -41,30 +71,151 @@ type CacheService interface {
 type EventHandler interface {
 	HandleTeamEvent(ctx context.Context, eventData []byte) error
 	HandleAssetEvent(ctx context.Context, eventData []byte) error
+	HandleUserEvent(ctx context.Context, eventData []byte) error
+}
+
+// Invalidator broadcasts invalidations of in-process (L1) cache entries to
+// every instance
+type Invalidator interface {
+	Publish(ctx context.Context, keys ...string) error
+	Subscribe(ctx context.Context, evict func(keys []string)) error
+}
+
+// ResponseCache keeps rendered responses of GET endpoints for a short time.
+// Responses are grouped in scopes, such as a user or a team, which are
+// invalidated as a whole when data shown in them changes.
+type ResponseCache interface {
+	// Get returns the cached response, or nil on a miss, and the scope's
+	// current generation, which a response rendered now is stored under
+	Get(ctx context.Context, scope, key string) (*CachedResponse, string, error)
+	// Set stores a response rendered in the given generation of the scope, so
+	// one rendered before the scope was invalidated is never served
+	Set(ctx context.Context, scope, generation, key string, response *CachedResponse, ttl time.Duration) error
+	// Invalidate drops every cached response of the scopes
+	Invalidate(ctx context.Context, scopes ...string) error
+}
+
+// CachedResponse is a rendered response kept by a ResponseCache
+type CachedResponse struct {
+	Status      int    `json:"status"`
+	ContentType string `json:"content_type"`
+	Body        []byte `json:"body"`
+}
+
+// UserResponseScope groups the cached responses rendered from a user's data
+func UserResponseScope(userID uuid.UUID) string {
+	return "user:" + userID.String()
+}
+
+// TeamResponseScope groups the cached responses rendered from a team's data
+func TeamResponseScope(teamID uuid.UUID) string {
+	return "team:" + teamID.String()
+}
+
+// ErrNotSupported is returned by operations a cache backend cannot provide
+var ErrNotSupported = errors.New("operation not supported by cache backend")
+
+// KeyInfo describes a single cache entry for administrative inspection
+type KeyInfo struct {
+	Key        string      `json:"key"`
+	Type       string      `json:"type"`
+	TTLSeconds int64       `json:"ttl_seconds"`
+	Value      interface{} `json:"value"`
+}
+
+// CacheStats summarizes the contents of the current key namespace
+type CacheStats struct {
+	Backend         string           `json:"backend"`
+	KeyVersion      int64            `json:"key_version"`
+	TotalKeys       int64            `json:"total_keys"`
+	KeyCounts       map[string]int64 `json:"key_counts"`
+	UsedMemoryBytes int64            `json:"used_memory_bytes"`
+}
+
+// KeyVersionKey stores the shared namespace version; it is deliberately unversioned
+const KeyVersionKey = "cache:key_version"
+
+// KeyNamespace holds the version prefix applied to every cache key. Bumping
+// the version orphans all existing entries, which then age out via their TTL.
+type KeyNamespace struct {
+	version atomic.Int64
+}
+
+// NewKeyNamespace creates a namespace starting at the given version
+func NewKeyNamespace(version int64) *KeyNamespace {
+	ns := &KeyNamespace{}
+	ns.version.Store(version)
+	return ns
+}
+
+func (n *KeyNamespace) Version() int64 {
+	return n.version.Load()
+}
+
+func (n *KeyNamespace) SetVersion(version int64) {
+	n.version.Store(version)
+}
+
+// Prefix returns the key prefix for the current version, e.g. "v2:"
+func (n *KeyNamespace) Prefix() string {
+	return "v" + strconv.FormatInt(n.Version(), 10) + ":"
 }
 
 // CacheKeys defines standard cache key formats
-type CacheKeys struct{}
+type CacheKeys struct {
+	namespace *KeyNamespace
+}
 
-func (CacheKeys) TeamMembers(teamID uuid.UUID) string {
-	return "team:" + teamID.String() + ":members"
+// NewCacheKeys creates key helpers bound to a namespace
+func NewCacheKeys(namespace *KeyNamespace) CacheKeys {
+	return CacheKeys{namespace: namespace}
 }
 
-func (CacheKeys) FolderMetadata(folderID uuid.UUID) string {
-	return "folder:" + folderID.String()
+// Raw prefixes an arbitrary key with the current namespace version
+func (k CacheKeys) Raw(key string) string {
+	return k.prefix() + key
 }
 
-func (CacheKeys) NoteMetadata(noteID uuid.UUID) string {
-	return "note:" + noteID.String()
+func (k CacheKeys) prefix() string {
+	if k.namespace == nil {
+		return ""
+	}
+	return k.namespace.Prefix()
 }
 
-func (CacheKeys) AssetACL(assetID uuid.UUID) string {
-	return "asset:" + assetID.String() + ":acl"
+func (k CacheKeys) TeamMembers(teamID uuid.UUID) string {
+	return k.prefix() + "team:" + teamID.String() + ":members"
 }
 
+func (k CacheKeys) UserTeams(userID uuid.UUID) string {
+	return k.prefix() + "user:" + userID.String() + ":teams"
+}
+
+func (k CacheKeys) UserProfile(userID uuid.UUID) string {
+	return k.prefix() + "user:" + userID.String() + ":profile"
+}
+
+func (k CacheKeys) FolderMetadata(folderID uuid.UUID) string {
+	return k.prefix() + "folder:" + folderID.String()
+}
+
+func (k CacheKeys) NoteMetadata(noteID uuid.UUID) string {
+	return k.prefix() + "note:" + noteID.String()
+}
+
+func (k CacheKeys) AssetACL(assetID uuid.UUID) string {
+	return k.prefix() + "asset:" + assetID.String() + ":acl"
+}
+
+// AccessLevelOwner marks the asset owner in a cached ACL alongside the
+// "read"/"write" levels granted through shares
+const AccessLevelOwner = "owner"
+
 // Default cache TTL values
 const (
 	DefaultTeamMembersTTL = 1 * time.Hour
+	DefaultUserTeamsTTL   = 10 * time.Minute
+	DefaultUserProfileTTL = 5 * time.Minute
 	DefaultAssetTTL       = 30 * time.Minute
 	DefaultACLTTL         = 15 * time.Minute
 )
\ No newline at end of file
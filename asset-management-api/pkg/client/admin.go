@@ -0,0 +1,284 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"asset-management-api/pkg/eventbus"
+	"asset-management-api/pkg/ipfilter"
+	"asset-management-api/pkg/maintenance"
+
+	"github.com/google/uuid"
+)
+
+// The methods of this file call the administration routes, which only
+// managers may call.
+
+// CacheKeyInfo is a cache entry as inspected by InspectCacheKey
+type CacheKeyInfo struct {
+	Key        string          `json:"key"`
+	Type       string          `json:"type"`
+	TTLSeconds int64           `json:"ttl_seconds"`
+	Value      json.RawMessage `json:"value"`
+}
+
+// CacheStats summarizes the entries of the current cache key namespace
+type CacheStats struct {
+	Backend         string           `json:"backend"`
+	KeyVersion      int64            `json:"key_version"`
+	TotalKeys       int64            `json:"total_keys"`
+	KeyCounts       map[string]int64 `json:"key_counts"`
+	UsedMemoryBytes int64            `json:"used_memory_bytes"`
+}
+
+// CacheKeyVersion is the version prefixed to every cache key
+func (c *Client) CacheKeyVersion(ctx context.Context) (int64, error) {
+	var out struct {
+		KeyVersion int64 `json:"key_version"`
+	}
+	if err := c.get(ctx, v1Path+"/admin/cache/version", nil, &out); err != nil {
+		return 0, err
+	}
+	return out.KeyVersion, nil
+}
+
+// BumpCacheKeyVersion moves the cache to a new key namespace, orphaning every
+// entry, and returns the new version
+func (c *Client) BumpCacheKeyVersion(ctx context.Context) (int64, error) {
+	var out struct {
+		KeyVersion int64 `json:"key_version"`
+	}
+	if err := c.post(ctx, v1Path+"/admin/cache/version/bump", nil, &out); err != nil {
+		return 0, err
+	}
+	return out.KeyVersion, nil
+}
+
+// InspectCacheKey reads a cache entry, such as "folder:<id>"
+func (c *Client) InspectCacheKey(ctx context.Context, key string) (*CacheKeyInfo, error) {
+	var info CacheKeyInfo
+	if err := c.get(ctx, v1Path+"/admin/cache/keys", url.Values{"key": {key}}, &info); err != nil {
+		return nil, err
+	}
+	return &info, nil
+}
+
+func (c *Client) CacheStats(ctx context.Context) (*CacheStats, error) {
+	var stats CacheStats
+	if err := c.get(ctx, v1Path+"/admin/cache/stats", nil, &stats); err != nil {
+		return nil, err
+	}
+	return &stats, nil
+}
+
+// FlushTeamCache drops the cached entries of a team
+func (c *Client) FlushTeamCache(ctx context.Context, teamID uuid.UUID) error {
+	return c.delete(ctx, route(v1Path, "admin", "cache", "teams", teamID.String()), nil)
+}
+
+// FlushAssetCache drops the cached entries of a folder or note
+func (c *Client) FlushAssetCache(ctx context.Context, assetID uuid.UUID) error {
+	return c.delete(ctx, route(v1Path, "admin", "cache", "assets", assetID.String()), nil)
+}
+
+// RedriveDeadLetters publishes again up to limit events of the dead letter
+// queue of a topic; a limit of 0 takes the API's default
+func (c *Client) RedriveDeadLetters(ctx context.Context, topic string, limit int) (*eventbus.RedriveResult, error) {
+	var result eventbus.RedriveResult
+	_, err := c.call(ctx, request{
+		method: http.MethodPost,
+		path:   route(v1Path, "admin", "events", "dlq", topic, "redrive"),
+		query:  limitQuery(limit),
+	}, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// consumerStatus is the state of the event consumers
+type consumerStatus struct {
+	PausedTopics []string `json:"paused_topics"`
+}
+
+// PausedTopics lists the topics whose consumers are paused
+func (c *Client) PausedTopics(ctx context.Context) ([]string, error) {
+	var status consumerStatus
+	if err := c.get(ctx, v1Path+"/admin/events/consumers", nil, &status); err != nil {
+		return nil, err
+	}
+	return status.PausedTopics, nil
+}
+
+// PauseConsumer stops consuming a topic and returns the paused topics
+func (c *Client) PauseConsumer(ctx context.Context, topic string) ([]string, error) {
+	var status consumerStatus
+	if err := c.post(ctx, route(v1Path, "admin", "events", "consumers", topic, "pause"), nil, &status); err != nil {
+		return nil, err
+	}
+	return status.PausedTopics, nil
+}
+
+// ResumeConsumer resumes consuming a topic and returns the paused topics
+func (c *Client) ResumeConsumer(ctx context.Context, topic string) ([]string, error) {
+	var status consumerStatus
+	if err := c.post(ctx, route(v1Path, "admin", "events", "consumers", topic, "resume"), nil, &status); err != nil {
+		return nil, err
+	}
+	return status.PausedTopics, nil
+}
+
+// DeniedNetworks lists the networks the API refuses requests from
+func (c *Client) DeniedNetworks(ctx context.Context) ([]ipfilter.Entry, error) {
+	var out struct {
+		Entries []ipfilter.Entry `json:"entries"`
+	}
+	if err := c.get(ctx, v1Path+"/admin/network/denylist", nil, &out); err != nil {
+		return nil, err
+	}
+	return out.Entries, nil
+}
+
+// DenyNetwork refuses requests from an address or CIDR network
+func (c *Client) DenyNetwork(ctx context.Context, cidr, reason string) (*ipfilter.Entry, error) {
+	body := map[string]string{"cidr": cidr, "reason": reason}
+	var entry ipfilter.Entry
+	if err := c.post(ctx, v1Path+"/admin/network/denylist", body, &entry); err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+// UndenyNetwork removes a network from the deny list
+func (c *Client) UndenyNetwork(ctx context.Context, cidr string) error {
+	return c.delete(ctx, v1Path+"/admin/network/denylist", url.Values{"cidr": {cidr}})
+}
+
+// Maintenance is the maintenance state of the API; nil when it is not in
+// maintenance
+func (c *Client) Maintenance(ctx context.Context) (*maintenance.State, error) {
+	var out struct {
+		State *maintenance.State `json:"state"`
+	}
+	if err := c.get(ctx, v1Path+"/admin/maintenance", nil, &out); err != nil {
+		return nil, err
+	}
+	return out.State, nil
+}
+
+// EnableMaintenance puts the API in maintenance: requests other than those of
+// managers are answered with a 503 giving message, and retryAfter when set
+func (c *Client) EnableMaintenance(ctx context.Context, message string, retryAfter time.Duration) (*maintenance.State, error) {
+	body := map[string]interface{}{
+		"message":             message,
+		"retry_after_seconds": int(retryAfter / time.Second),
+	}
+	var state maintenance.State
+	if err := c.put(ctx, v1Path+"/admin/maintenance", body, &state); err != nil {
+		return nil, err
+	}
+	return &state, nil
+}
+
+func (c *Client) DisableMaintenance(ctx context.Context) error {
+	return c.delete(ctx, v1Path+"/admin/maintenance", nil)
+}
+
+// AuditRecord is a mutating API request recorded in the audit log
+type AuditRecord struct {
+	ID         int64           `json:"id"`
+	RequestID  string          `json:"request_id,omitempty"`
+	ActorID    *uuid.UUID      `json:"actor_id,omitempty"`
+	ActorRole  string          `json:"actor_role,omitempty"`
+	Method     string          `json:"method"`
+	Route      string          `json:"route"`
+	Path       string          `json:"path"`
+	EntityType string          `json:"entity_type,omitempty"`
+	EntityID   string          `json:"entity_id,omitempty"`
+	Params     json.RawMessage `json:"params,omitempty"`
+	Outcome    string          `json:"outcome"`
+	HTTPStatus int             `json:"http_status"`
+	LatencyMs  int64           `json:"latency_ms"`
+	ClientIP   string          `json:"client_ip,omitempty"`
+	TraceID    string          `json:"trace_id,omitempty"`
+	OccurredAt time.Time       `json:"occurred_at"`
+}
+
+// AuditFilter selects audit records; zero fields match everything
+type AuditFilter struct {
+	ActorID    *uuid.UUID
+	Method     string
+	Route      string
+	EntityType string
+	EntityID   string
+	Outcome    string
+	From       time.Time
+	To         time.Time
+	// Limit is the number of records, the API's default when 0
+	Limit int
+}
+
+func (f AuditFilter) query() url.Values {
+	query := url.Values{}
+	if f.ActorID != nil {
+		query.Set("actor_id", f.ActorID.String())
+	}
+	for name, value := range map[string]string{
+		"method":      f.Method,
+		"route":       f.Route,
+		"entity_type": f.EntityType,
+		"entity_id":   f.EntityID,
+		"outcome":     f.Outcome,
+	} {
+		if value != "" {
+			query.Set(name, value)
+		}
+	}
+	if !f.From.IsZero() {
+		query.Set("from", f.From.Format(time.RFC3339))
+	}
+	if !f.To.IsZero() {
+		query.Set("to", f.To.Format(time.RFC3339))
+	}
+	if f.Limit > 0 {
+		query.Set("limit", strconv.Itoa(f.Limit))
+	}
+	return query
+}
+
+// AuditRecords queries the audit log, the most recent records first
+func (c *Client) AuditRecords(ctx context.Context, filter AuditFilter) ([]AuditRecord, error) {
+	var out struct {
+		Records []AuditRecord `json:"records"`
+	}
+	if err := c.get(ctx, v1Path+"/admin/audit", filter.query(), &out); err != nil {
+		return nil, err
+	}
+	return out.Records, nil
+}
+
+// Backup is a team export written to the backup store
+type Backup struct {
+	TeamID     uuid.UUID `json:"team_id"`
+	Location   string    `json:"location"`
+	ExportedAt time.Time `json:"exported_at"`
+	SizeBytes  int       `json:"size_bytes"`
+	Users      int       `json:"users"`
+	Folders    int       `json:"folders"`
+	Notes      int       `json:"notes"`
+	Shares     int       `json:"shares"`
+	Activity   int       `json:"activity"`
+}
+
+// ExportTeam writes a backup of a team to the backup store
+func (c *Client) ExportTeam(ctx context.Context, teamID uuid.UUID) (*Backup, error) {
+	var backup Backup
+	if err := c.post(ctx, route(v1Path, "admin", "backups", "teams", teamID.String()), nil, &backup); err != nil {
+		return nil, err
+	}
+	return &backup, nil
+}
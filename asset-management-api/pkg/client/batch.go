@@ -0,0 +1,63 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// BatchItem is one request of a batch. Path is an /api/v1 path with an
+// optional query string, such as "/api/v1/folders?limit=10"; Body is encoded
+// as JSON when not nil.
+type BatchItem struct {
+	Method  string            `json:"method"`
+	Path    string            `json:"path"`
+	Headers map[string]string `json:"headers,omitempty"`
+	Body    interface{}       `json:"body,omitempty"`
+}
+
+// BatchResult is the response to one request of a batch
+type BatchResult struct {
+	Status  int               `json:"status"`
+	Headers map[string]string `json:"headers,omitempty"`
+	Body    json.RawMessage   `json:"body,omitempty"`
+}
+
+// OK reports whether the request succeeded
+func (r BatchResult) OK() bool {
+	return r.Status >= 200 && r.Status < 300
+}
+
+// Decode decodes the data of a successful response into out, or returns the
+// error of a failed one
+func (r BatchResult) Decode(out interface{}) error {
+	if !r.OK() {
+		header := make(http.Header, len(r.Headers))
+		for name, value := range r.Headers {
+			header.Set(name, value)
+		}
+		return parseError(r.Status, header, r.Body)
+	}
+	var resp response
+	if len(r.Body) > 0 {
+		if err := json.Unmarshal(r.Body, &resp); err != nil {
+			return fmt.Errorf("client: decoding batch response: %w", err)
+		}
+	}
+	return resp.decode(out)
+}
+
+// Batch runs requests one after the other in a single round trip, as the
+// caller, and returns their results in order. A failed request does not stop
+// the batch; the error returned is that of the batch itself.
+func (c *Client) Batch(ctx context.Context, items []BatchItem) ([]BatchResult, error) {
+	var results []BatchResult
+	req := struct {
+		Requests []BatchItem `json:"requests"`
+	}{Requests: items}
+	if err := c.post(ctx, route(v1Path, "batch"), req, &results); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
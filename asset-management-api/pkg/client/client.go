@@ -0,0 +1,399 @@
+// Package client is a typed Go client of the asset management REST API, for
+// services calling it so they need not build requests by hand.
+//
+// A Client authenticates each request with the bearer token of its
+// TokenSource, retries requests the API turned away before running them (and
+// idempotent requests that failed on the way), and decodes errors into
+// *Error. Lists are read a page at a time with the List methods, or item by
+// item across pages with the Iterator the plural methods return:
+//
+//	c := client.New("http://assets:8000", client.StaticToken(token))
+//	it := c.Folders(client.ListOptions{})
+//	for it.Next(ctx) {
+//		folder := it.Item()
+//		...
+//	}
+//	if err := it.Err(); err != nil {
+//		...
+//	}
+//
+// Routes are those of /api/v1, but for teams, which are served by /api/v2.
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Route prefixes of the API versions the client calls
+const (
+	v1Path = "/api/v1"
+	v2Path = "/api/v2"
+)
+
+// Defaults of Options
+const (
+	DefaultTimeout      = 30 * time.Second
+	DefaultMaxRetries   = 3
+	DefaultMinBackoff   = 200 * time.Millisecond
+	DefaultMaxBackoff   = 5 * time.Second
+	DefaultMaxRetryWait = 30 * time.Second
+	DefaultUserAgent    = "asset-management-api-client"
+)
+
+// maxErrorBody bounds the body of an error response read into an *Error
+const maxErrorBody = 64 * 1024
+
+// problemContentType is the media type of problem details (RFC 7807), asked
+// for so errors come with their code
+const problemContentType = "application/problem+json"
+
+// TokenSource supplies the bearer token of each request
+type TokenSource interface {
+	Token(ctx context.Context) (string, error)
+}
+
+// Invalidator is implemented by token sources that can replace a token the
+// API rejected. The client reports the rejected token and retries the
+// request once with the next one.
+type Invalidator interface {
+	Invalidate(token string)
+}
+
+// StaticToken is a token that never changes, such as a service's long-lived
+// token
+type StaticToken string
+
+func (t StaticToken) Token(ctx context.Context) (string, error) {
+	return string(t), nil
+}
+
+// Options tune a Client. Zero values take the defaults.
+type Options struct {
+	// HTTPClient sends the requests; its Timeout is ignored in favour of
+	// Timeout, which does not apply to event streams
+	HTTPClient *http.Client
+	// Timeout bounds each attempt of a request
+	Timeout time.Duration
+	// MaxRetries is the number of retries after the first attempt; negative
+	// disables retries
+	MaxRetries int
+	// MinBackoff and MaxBackoff bound the jittered exponential delay between
+	// attempts, when the response does not set Retry-After
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+	// MaxRetryWait is the longest Retry-After the client waits for; longer
+	// waits, such as maintenance windows, are returned as errors
+	MaxRetryWait time.Duration
+	UserAgent    string
+	// AcceptLanguage asks for the error messages in a language, e.g. "fr"
+	AcceptLanguage string
+}
+
+// Client calls the asset management API. It is safe for concurrent use.
+type Client struct {
+	baseURL string
+	tokens  TokenSource
+	http    *http.Client
+	opts    Options
+}
+
+// New returns a client of the API at baseURL, such as
+// "http://localhost:8000", authenticating with tokens. A nil TokenSource
+// sends requests without authorization, which only the public routes accept.
+func New(baseURL string, tokens TokenSource, opts ...Options) *Client {
+	var o Options
+	if len(opts) > 0 {
+		o = opts[0]
+	}
+	if o.Timeout <= 0 {
+		o.Timeout = DefaultTimeout
+	}
+	if o.MaxRetries == 0 {
+		o.MaxRetries = DefaultMaxRetries
+	}
+	if o.MinBackoff <= 0 {
+		o.MinBackoff = DefaultMinBackoff
+	}
+	if o.MaxBackoff < o.MinBackoff {
+		o.MaxBackoff = DefaultMaxBackoff
+		if o.MaxBackoff < o.MinBackoff {
+			o.MaxBackoff = o.MinBackoff
+		}
+	}
+	if o.MaxRetryWait <= 0 {
+		o.MaxRetryWait = DefaultMaxRetryWait
+	}
+	if o.UserAgent == "" {
+		o.UserAgent = DefaultUserAgent
+	}
+
+	httpClient := &http.Client{}
+	if o.HTTPClient != nil {
+		copied := *o.HTTPClient
+		copied.Timeout = 0
+		httpClient = &copied
+	}
+
+	return &Client{
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		tokens:  tokens,
+		http:    httpClient,
+		opts:    o,
+	}
+}
+
+// request is a call of the API
+type request struct {
+	method string
+	path   string
+	query  url.Values
+	body   interface{}
+	header http.Header
+}
+
+// response is the decoded body of a successful call
+type response struct {
+	Data       json.RawMessage `json:"data"`
+	Message    string          `json:"message"`
+	Pagination *pagination     `json:"pagination"`
+	header     http.Header
+	status     int
+}
+
+type pagination struct {
+	Limit      int    `json:"limit"`
+	NextCursor string `json:"next_cursor"`
+	HasMore    bool   `json:"has_more"`
+}
+
+// call sends the request, retrying it as allowed, and decodes the data of the
+// response into out when not nil
+func (c *Client) call(ctx context.Context, req request, out interface{}) (*response, error) {
+	var body []byte
+	if req.body != nil {
+		var err error
+		if body, err = json.Marshal(req.body); err != nil {
+			return nil, fmt.Errorf("client: encoding request: %w", err)
+		}
+	}
+
+	refreshed := false
+	for attempt := 0; ; attempt++ {
+		token, err := c.token(ctx)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := c.attempt(ctx, req, body, token)
+
+		var apiErr *Error
+		if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusUnauthorized && !refreshed {
+			if invalidator, ok := c.tokens.(Invalidator); ok {
+				invalidator.Invalidate(token)
+				refreshed = true
+				continue
+			}
+		}
+		if err == nil || attempt >= c.opts.MaxRetries || ctx.Err() != nil {
+			if err != nil {
+				return nil, err
+			}
+			return resp, resp.decode(out)
+		}
+
+		wait, retry := c.retryDelay(req.method, err, attempt)
+		if !retry {
+			return nil, err
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, err
+		case <-timer.C:
+		}
+	}
+}
+
+func (c *Client) token(ctx context.Context) (string, error) {
+	if c.tokens == nil {
+		return "", nil
+	}
+	token, err := c.tokens.Token(ctx)
+	if err != nil {
+		return "", fmt.Errorf("client: getting token: %w", err)
+	}
+	return token, nil
+}
+
+// attempt sends the request once
+func (c *Client) attempt(ctx context.Context, req request, body []byte, token string) (*response, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.opts.Timeout)
+	defer cancel()
+
+	httpReq, err := c.newRequest(ctx, req, body, token)
+	if err != nil {
+		return nil, err
+	}
+	httpResp, err := c.http.Do(httpReq)
+	if err != nil {
+		return nil, err
+	}
+	defer httpResp.Body.Close()
+
+	if httpResp.StatusCode >= 300 {
+		return nil, newError(httpResp)
+	}
+	data, err := io.ReadAll(httpResp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("client: reading response: %w", err)
+	}
+	resp := &response{header: httpResp.Header, status: httpResp.StatusCode}
+	if len(bytes.TrimSpace(data)) > 0 {
+		if err := json.Unmarshal(data, resp); err != nil {
+			return nil, fmt.Errorf("client: decoding response of %s %s: %w", req.method, req.path, err)
+		}
+	}
+	return resp, nil
+}
+
+func (c *Client) newRequest(ctx context.Context, req request, body []byte, token string) (*http.Request, error) {
+	u := c.baseURL + req.path
+	if len(req.query) > 0 {
+		u += "?" + req.query.Encode()
+	}
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, req.method, u, reader)
+	if err != nil {
+		return nil, err
+	}
+	for name, values := range req.header {
+		httpReq.Header[name] = values
+	}
+	if token != "" {
+		httpReq.Header.Set("Authorization", "Bearer "+token)
+	}
+	if body != nil {
+		httpReq.Header.Set("Content-Type", "application/json")
+	}
+	if httpReq.Header.Get("Accept") == "" {
+		httpReq.Header.Set("Accept", "application/json, "+problemContentType)
+	}
+	httpReq.Header.Set("User-Agent", c.opts.UserAgent)
+	if c.opts.AcceptLanguage != "" {
+		httpReq.Header.Set("Accept-Language", c.opts.AcceptLanguage)
+	}
+	return httpReq, nil
+}
+
+// retryDelay reports whether a failed attempt is retried and after how long.
+// Rate limits, quotas and load shedding turn requests away before they run,
+// so any request is retried after them; other failures only for idempotent
+// methods, which are safe to repeat.
+func (c *Client) retryDelay(method string, err error, attempt int) (time.Duration, bool) {
+	var apiErr *Error
+	if !errors.As(err, &apiErr) {
+		// The request may or may not have reached the API
+		return c.backoff(attempt), idempotent(method)
+	}
+
+	switch apiErr.StatusCode {
+	case http.StatusTooManyRequests, http.StatusServiceUnavailable:
+	case http.StatusBadGateway, http.StatusGatewayTimeout:
+		if !idempotent(method) {
+			return 0, false
+		}
+	default:
+		return 0, false
+	}
+	if apiErr.RetryAfter > 0 {
+		return apiErr.RetryAfter, apiErr.RetryAfter <= c.opts.MaxRetryWait
+	}
+	return c.backoff(attempt), true
+}
+
+// backoff is the full-jitter exponential delay before retry attempt+1
+func (c *Client) backoff(attempt int) time.Duration {
+	ceiling := c.opts.MinBackoff << uint(attempt)
+	if ceiling > c.opts.MaxBackoff || ceiling <= 0 {
+		ceiling = c.opts.MaxBackoff
+	}
+	return c.opts.MinBackoff/2 + time.Duration(rand.Int63n(int64(ceiling)))
+}
+
+func idempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// decode decodes the data of the response into out, when not nil
+func (r *response) decode(out interface{}) error {
+	if out == nil || len(r.Data) == 0 || string(r.Data) == "null" {
+		return nil
+	}
+	if err := json.Unmarshal(r.Data, out); err != nil {
+		return fmt.Errorf("client: decoding response data: %w", err)
+	}
+	return nil
+}
+
+func (c *Client) get(ctx context.Context, path string, query url.Values, out interface{}) error {
+	_, err := c.call(ctx, request{method: http.MethodGet, path: path, query: query}, out)
+	return err
+}
+
+func (c *Client) post(ctx context.Context, path string, body, out interface{}) error {
+	_, err := c.call(ctx, request{method: http.MethodPost, path: path, body: body}, out)
+	return err
+}
+
+func (c *Client) put(ctx context.Context, path string, body, out interface{}) error {
+	_, err := c.call(ctx, request{method: http.MethodPut, path: path, body: body}, out)
+	return err
+}
+
+func (c *Client) patch(ctx context.Context, path string, body, out interface{}) error {
+	_, err := c.call(ctx, request{method: http.MethodPatch, path: path, body: body}, out)
+	return err
+}
+
+func (c *Client) delete(ctx context.Context, path string, query url.Values) error {
+	_, err := c.call(ctx, request{method: http.MethodDelete, path: path, query: query}, nil)
+	return err
+}
+
+// route joins a route prefix and escaped path segments
+func route(prefix string, segments ...string) string {
+	var b strings.Builder
+	b.WriteString(prefix)
+	for _, segment := range segments {
+		b.WriteByte('/')
+		b.WriteString(url.PathEscape(segment))
+	}
+	return b.String()
+}
+
+// limitQuery is the query of the endpoints taking only a limit
+func limitQuery(limit int) url.Values {
+	if limit <= 0 {
+		return nil
+	}
+	return url.Values{"limit": {strconv.Itoa(limit)}}
+}
@@ -0,0 +1,205 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Error is an error response of the API
+type Error struct {
+	StatusCode int
+	// Code is the stable error code, such as "not_found", which the client
+	// gets from both API versions by asking for problem details
+	Code    string
+	Message string
+	// Detail is the cause the API gives for the error, if any
+	Detail string
+	// Problems lists what failed validation in the request body
+	Problems  []string
+	RequestID string
+	// RetryAfter is how long the API asked to wait before retrying
+	RetryAfter time.Duration
+}
+
+func (e *Error) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "asset management API: %d", e.StatusCode)
+	if e.Code != "" {
+		fmt.Fprintf(&b, " %s", e.Code)
+	}
+	if e.Message != "" {
+		fmt.Fprintf(&b, ": %s", e.Message)
+	}
+	if e.Detail != "" {
+		fmt.Fprintf(&b, ": %s", e.Detail)
+	}
+	if len(e.Problems) > 0 {
+		fmt.Fprintf(&b, " (%s)", strings.Join(e.Problems, "; "))
+	}
+	if e.RequestID != "" {
+		fmt.Fprintf(&b, " [request %s]", e.RequestID)
+	}
+	return b.String()
+}
+
+// errorBody is the body of an error response: the fields of /api/v1 at the
+// top level, or those of /api/v2 under "error"
+type errorBody struct {
+	Message   string          `json:"message"`
+	Error     json.RawMessage `json:"error"`
+	Errors    []string        `json:"errors"`
+	RequestID string          `json:"request_id"`
+}
+
+// problem is an error response in the format of RFC 7807, which the API
+// answers requests accepting application/problem+json with
+type problem struct {
+	Title     string          `json:"title"`
+	Detail    string          `json:"detail"`
+	Code      string          `json:"code"`
+	Error     string          `json:"error"`
+	Errors    json.RawMessage `json:"errors"`
+	RequestID string          `json:"request_id"`
+}
+
+type v2Error struct {
+	Code      string `json:"code"`
+	Message   string `json:"message"`
+	RequestID string `json:"request_id"`
+	Details   []struct {
+		Field   string `json:"field"`
+		Message string `json:"message"`
+	} `json:"details"`
+}
+
+// newError reads the error of a response with a status of 300 or more
+func newError(resp *http.Response) *Error {
+	data, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+	return parseError(resp.StatusCode, resp.Header, data)
+}
+
+// parseError returns the error of a response from its status, headers and
+// body
+func parseError(status int, header http.Header, data []byte) *Error {
+	apiErr := &Error{
+		StatusCode: status,
+		RequestID:  header.Get("X-Request-ID"),
+	}
+	if seconds, err := strconv.Atoi(header.Get("Retry-After")); err == nil && seconds > 0 {
+		apiErr.RetryAfter = time.Duration(seconds) * time.Second
+	}
+
+	if mediaType, _, _ := mime.ParseMediaType(header.Get("Content-Type")); mediaType == problemContentType {
+		var p problem
+		if err := json.Unmarshal(data, &p); err == nil {
+			apiErr.Code = p.Code
+			apiErr.Message = p.Detail
+			apiErr.Detail = p.Error
+			if apiErr.Message == "" {
+				apiErr.Message = p.Title
+			}
+			apiErr.Problems = validationProblems(p.Errors)
+			if p.RequestID != "" {
+				apiErr.RequestID = p.RequestID
+			}
+			return apiErr
+		}
+	}
+
+	var body errorBody
+	if err := json.Unmarshal(data, &body); err != nil {
+		apiErr.Message = strings.TrimSpace(string(data))
+		if apiErr.Message == "" {
+			apiErr.Message = http.StatusText(status)
+		}
+		return apiErr
+	}
+
+	var v2 v2Error
+	if len(body.Error) > 0 && json.Unmarshal(body.Error, &v2) == nil {
+		apiErr.Code = v2.Code
+		apiErr.Message = v2.Message
+		for _, detail := range v2.Details {
+			apiErr.Problems = append(apiErr.Problems, detail.Field+": "+detail.Message)
+		}
+		if v2.RequestID != "" {
+			apiErr.RequestID = v2.RequestID
+		}
+		return apiErr
+	}
+
+	apiErr.Message = body.Message
+	_ = json.Unmarshal(body.Error, &apiErr.Detail)
+	apiErr.Problems = body.Errors
+	if body.RequestID != "" {
+		apiErr.RequestID = body.RequestID
+	}
+	if apiErr.Message == "" {
+		apiErr.Message = http.StatusText(status)
+	}
+	return apiErr
+}
+
+// validationProblems reads the errors of a problem: messages from /api/v1,
+// or fields and their messages from /api/v2
+func validationProblems(data json.RawMessage) []string {
+	if len(data) == 0 {
+		return nil
+	}
+	var messages []string
+	if json.Unmarshal(data, &messages) == nil {
+		return messages
+	}
+	var details []struct {
+		Field   string `json:"field"`
+		Message string `json:"message"`
+	}
+	if json.Unmarshal(data, &details) == nil {
+		for _, detail := range details {
+			messages = append(messages, detail.Field+": "+detail.Message)
+		}
+	}
+	return messages
+}
+
+// StatusCode returns the status of the error response err wraps, or 0 when
+// it does not wrap one
+func StatusCode(err error) int {
+	var apiErr *Error
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode
+	}
+	return 0
+}
+
+// IsNotFound reports whether err is a 404 response, such as for an asset
+// that does not exist or was deleted
+func IsNotFound(err error) bool {
+	return StatusCode(err) == http.StatusNotFound
+}
+
+// IsForbidden reports whether err is a 403 response: the caller may not
+// access the resource
+func IsForbidden(err error) bool {
+	return StatusCode(err) == http.StatusForbidden
+}
+
+// IsUnauthorized reports whether err is a 401 response: the token is
+// missing, invalid or expired
+func IsUnauthorized(err error) bool {
+	return StatusCode(err) == http.StatusUnauthorized
+}
+
+// IsConflict reports whether err is a 409 or 412 response: the resource
+// changed since it was read
+func IsConflict(err error) bool {
+	status := StatusCode(err)
+	return status == http.StatusConflict || status == http.StatusPreconditionFailed
+}
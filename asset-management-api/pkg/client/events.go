@@ -0,0 +1,153 @@
+package client
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// EventResync is the event the stream sends when it cannot replay the events
+// missed since the Last-Event-ID; what the caller follows must be refetched
+const EventResync = "resync"
+
+// Delays between reconnections of StreamEvents, doubling from the first
+const (
+	firstReconnectDelay = time.Second
+	maxReconnectDelay   = 30 * time.Second
+)
+
+// Event is an event of the Server-Sent Events stream. Asset events carry an
+// ID the stream can be resumed after; Data is the JSON of the event.
+type Event struct {
+	ID    string
+	Event string
+	Data  json.RawMessage
+}
+
+// StreamEvents passes the events visible to the user to handle as they
+// happen, starting after the event lastEventID when set. When the connection
+// drops, it reconnects with backoff and resumes after the last event
+// received. It returns when ctx is done, returning nil, when handle returns an
+// error, which it returns, or when the API refuses the stream.
+func (c *Client) StreamEvents(ctx context.Context, lastEventID string, handle func(Event) error) error {
+	delay := firstReconnectDelay
+	refreshed := false
+	for {
+		token, err := c.token(ctx)
+		if err != nil {
+			return err
+		}
+		var handleErr error
+		received, err := c.stream(ctx, token, lastEventID, func(event Event) error {
+			switch {
+			case event.ID != "":
+				lastEventID = event.ID
+			case event.Event == EventResync:
+				// The stream starts over from the live events
+				lastEventID = ""
+			}
+			handleErr = handle(event)
+			return handleErr
+		})
+		if ctx.Err() != nil {
+			return nil
+		}
+		if handleErr != nil {
+			return handleErr
+		}
+		var apiErr *Error
+		if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusUnauthorized && !refreshed {
+			if invalidator, ok := c.tokens.(Invalidator); ok {
+				invalidator.Invalidate(token)
+				refreshed = true
+				continue
+			}
+		}
+		if errors.As(err, &apiErr) && apiErr.StatusCode != http.StatusTooManyRequests && apiErr.StatusCode < 500 {
+			return err
+		}
+
+		if received {
+			delay = firstReconnectDelay
+			refreshed = false
+		}
+		if apiErr != nil && apiErr.RetryAfter > delay {
+			delay = apiErr.RetryAfter
+		}
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil
+		case <-timer.C:
+		}
+		if delay *= 2; delay > maxReconnectDelay {
+			delay = maxReconnectDelay
+		}
+	}
+}
+
+// stream reads the event stream once, passing each event to handle until the
+// stream ends. It reports whether any event was received. Unlike other
+// requests, the stream is not bound by Options.Timeout.
+func (c *Client) stream(ctx context.Context, token, lastEventID string, handle func(Event) error) (received bool, err error) {
+	header := http.Header{"Accept": {"text/event-stream, " + problemContentType}}
+	if lastEventID != "" {
+		header.Set("Last-Event-ID", lastEventID)
+	}
+	req, err := c.newRequest(ctx, request{method: http.MethodGet, path: v1Path + "/events/stream", header: header}, nil, token)
+	if err != nil {
+		return false, err
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return false, newError(resp)
+	}
+
+	var event Event
+	var data []string
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			// A blank line ends the event
+			if event.Event != "" || len(data) > 0 {
+				event.Data = json.RawMessage(strings.Join(data, "\n"))
+				if event.Event == "" {
+					event.Event = "message"
+				}
+				received = true
+				if err := handle(event); err != nil {
+					return received, err
+				}
+			}
+			event, data = Event{}, nil
+			continue
+		}
+		if strings.HasPrefix(line, ":") {
+			// Heartbeat comments
+			continue
+		}
+		field, value, _ := strings.Cut(line, ":")
+		value = strings.TrimPrefix(value, " ")
+		switch field {
+		case "id":
+			event.ID = value
+		case "event":
+			event.Event = value
+		case "data":
+			data = append(data, value)
+		}
+	}
+	return received, scanner.Err()
+}
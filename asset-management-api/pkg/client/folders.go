@@ -0,0 +1,54 @@
+package client
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// FolderInput is the content of a folder to create or update
+type FolderInput struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+func (c *Client) CreateFolder(ctx context.Context, input FolderInput) (*Folder, error) {
+	var folder Folder
+	if err := c.post(ctx, route(v1Path, "folders"), input, &folder); err != nil {
+		return nil, err
+	}
+	return &folder, nil
+}
+
+// GetFolder returns a folder the caller owns or was shared
+func (c *Client) GetFolder(ctx context.Context, folderID uuid.UUID) (*Folder, error) {
+	var folder Folder
+	if err := c.get(ctx, route(v1Path, "folders", folderID.String()), nil, &folder); err != nil {
+		return nil, err
+	}
+	return &folder, nil
+}
+
+// UpdateFolder replaces the name and description of a folder
+func (c *Client) UpdateFolder(ctx context.Context, folderID uuid.UUID, input FolderInput) (*Folder, error) {
+	var folder Folder
+	if err := c.put(ctx, route(v1Path, "folders", folderID.String()), input, &folder); err != nil {
+		return nil, err
+	}
+	return &folder, nil
+}
+
+// DeleteFolder deletes a folder with its notes
+func (c *Client) DeleteFolder(ctx context.Context, folderID uuid.UUID) error {
+	return c.delete(ctx, route(v1Path, "folders", folderID.String()), nil)
+}
+
+// ListFolders returns a page of the folders the caller owns
+func (c *Client) ListFolders(ctx context.Context, opts ListOptions) (*Page[Folder], error) {
+	return listPage[Folder](ctx, c, route(v1Path, "folders"), opts)
+}
+
+// Folders iterates over the folders the caller owns, from the page of opts
+func (c *Client) Folders(opts ListOptions) *Iterator[Folder] {
+	return newIterator[Folder](c, route(v1Path, "folders"), opts)
+}
@@ -0,0 +1,64 @@
+package client
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// NoteInput is the content of a note to create or update
+type NoteInput struct {
+	Title string `json:"title"`
+	Body  string `json:"body"`
+}
+
+// CreateNote creates a note in a folder the caller owns or may write
+func (c *Client) CreateNote(ctx context.Context, folderID uuid.UUID, input NoteInput) (*Note, error) {
+	var note Note
+	if err := c.post(ctx, route(v1Path, "folders", folderID.String(), "notes"), input, &note); err != nil {
+		return nil, err
+	}
+	return &note, nil
+}
+
+// GetNote returns a note the caller owns or was shared
+func (c *Client) GetNote(ctx context.Context, noteID uuid.UUID) (*Note, error) {
+	var note Note
+	if err := c.get(ctx, route(v1Path, "notes", noteID.String()), nil, &note); err != nil {
+		return nil, err
+	}
+	return &note, nil
+}
+
+// UpdateNote replaces the title and body of a note
+func (c *Client) UpdateNote(ctx context.Context, noteID uuid.UUID, input NoteInput) (*Note, error) {
+	var note Note
+	if err := c.put(ctx, route(v1Path, "notes", noteID.String()), input, &note); err != nil {
+		return nil, err
+	}
+	return &note, nil
+}
+
+func (c *Client) DeleteNote(ctx context.Context, noteID uuid.UUID) error {
+	return c.delete(ctx, route(v1Path, "notes", noteID.String()), nil)
+}
+
+// ListNotes returns a page of the notes the caller owns
+func (c *Client) ListNotes(ctx context.Context, opts ListOptions) (*Page[Note], error) {
+	return listPage[Note](ctx, c, route(v1Path, "notes"), opts)
+}
+
+// Notes iterates over the notes the caller owns, from the page of opts
+func (c *Client) Notes(opts ListOptions) *Iterator[Note] {
+	return newIterator[Note](c, route(v1Path, "notes"), opts)
+}
+
+// ListFolderNotes returns a page of the notes of a folder
+func (c *Client) ListFolderNotes(ctx context.Context, folderID uuid.UUID, opts ListOptions) (*Page[Note], error) {
+	return listPage[Note](ctx, c, route(v1Path, "folders", folderID.String(), "notes"), opts)
+}
+
+// FolderNotes iterates over the notes of a folder, from the page of opts
+func (c *Client) FolderNotes(folderID uuid.UUID, opts ListOptions) *Iterator[Note] {
+	return newIterator[Note](c, route(v1Path, "folders", folderID.String(), "notes"), opts)
+}
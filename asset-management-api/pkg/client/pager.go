@@ -0,0 +1,110 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// DefaultPageSize is the page size of lists when ListOptions leaves it unset
+const DefaultPageSize = 50
+
+// ListOptions select a page of a list read by cursor
+type ListOptions struct {
+	// Limit is the page size, at most 100
+	Limit int
+	// Cursor is the NextCursor of the previous page; empty for the first
+	Cursor string
+}
+
+func (o ListOptions) query() url.Values {
+	limit := o.Limit
+	if limit <= 0 {
+		limit = DefaultPageSize
+	}
+	query := url.Values{"limit": {strconv.Itoa(limit)}}
+	if o.Cursor != "" {
+		query.Set("cursor", o.Cursor)
+	}
+	return query
+}
+
+// Page is a page of a list
+type Page[T any] struct {
+	Items []T
+	// NextCursor reads the next page; it is empty on the last page
+	NextCursor string
+}
+
+// listPage reads a page of the list at path
+func listPage[T any](ctx context.Context, c *Client, path string, opts ListOptions) (*Page[T], error) {
+	var items []T
+	resp, err := c.call(ctx, request{method: http.MethodGet, path: path, query: opts.query()}, &items)
+	if err != nil {
+		return nil, err
+	}
+	page := &Page[T]{Items: items}
+	if resp.Pagination != nil && resp.Pagination.HasMore {
+		page.NextCursor = resp.Pagination.NextCursor
+	}
+	return page, nil
+}
+
+// Iterator reads a list item by item, fetching its pages as they are needed
+type Iterator[T any] struct {
+	fetch func(ctx context.Context, opts ListOptions) (*Page[T], error)
+	opts  ListOptions
+	items []T
+	item  T
+	done  bool
+	err   error
+}
+
+func newIterator[T any](c *Client, path string, opts ListOptions) *Iterator[T] {
+	return &Iterator[T]{
+		fetch: func(ctx context.Context, opts ListOptions) (*Page[T], error) {
+			return listPage[T](ctx, c, path, opts)
+		},
+		opts: opts,
+	}
+}
+
+// Next advances to the next item, reporting whether there is one. It returns
+// false at the end of the list or on an error, which Err returns.
+func (it *Iterator[T]) Next(ctx context.Context) bool {
+	for len(it.items) == 0 {
+		if it.done || it.err != nil {
+			return false
+		}
+		page, err := it.fetch(ctx, it.opts)
+		if err != nil {
+			it.err = err
+			return false
+		}
+		it.items = page.Items
+		it.opts.Cursor = page.NextCursor
+		it.done = page.NextCursor == ""
+	}
+	it.item, it.items = it.items[0], it.items[1:]
+	return true
+}
+
+// Item is the item Next advanced to
+func (it *Iterator[T]) Item() T {
+	return it.item
+}
+
+// Err is the error that ended the iteration, if any
+func (it *Iterator[T]) Err() error {
+	return it.err
+}
+
+// All reads the rest of the list
+func (it *Iterator[T]) All(ctx context.Context) ([]T, error) {
+	var items []T
+	for it.Next(ctx) {
+		items = append(items, it.Item())
+	}
+	return items, it.Err()
+}
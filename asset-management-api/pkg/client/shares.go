@@ -0,0 +1,54 @@
+package client
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type shareRequest struct {
+	UserID      string `json:"user_id"`
+	AccessLevel string `json:"access_level"`
+}
+
+// ShareFolder shares a folder the caller owns with a user, with AccessRead or
+// AccessWrite; sharing it again changes the access level
+func (c *Client) ShareFolder(ctx context.Context, folderID, userID uuid.UUID, accessLevel string) error {
+	req := shareRequest{UserID: userID.String(), AccessLevel: accessLevel}
+	return c.post(ctx, route(v1Path, "folders", folderID.String(), "share"), req, nil)
+}
+
+func (c *Client) UnshareFolder(ctx context.Context, folderID, userID uuid.UUID) error {
+	return c.delete(ctx, route(v1Path, "folders", folderID.String(), "share", userID.String()), nil)
+}
+
+// ListFolderShares returns a page of the shares of a folder the caller owns
+func (c *Client) ListFolderShares(ctx context.Context, folderID uuid.UUID, opts ListOptions) (*Page[FolderShare], error) {
+	return listPage[FolderShare](ctx, c, route(v1Path, "folders", folderID.String(), "shares"), opts)
+}
+
+// FolderShares iterates over the shares of a folder the caller owns
+func (c *Client) FolderShares(folderID uuid.UUID, opts ListOptions) *Iterator[FolderShare] {
+	return newIterator[FolderShare](c, route(v1Path, "folders", folderID.String(), "shares"), opts)
+}
+
+// ShareNote shares a note the caller owns with a user, with AccessRead or
+// AccessWrite; sharing it again changes the access level
+func (c *Client) ShareNote(ctx context.Context, noteID, userID uuid.UUID, accessLevel string) error {
+	req := shareRequest{UserID: userID.String(), AccessLevel: accessLevel}
+	return c.post(ctx, route(v1Path, "notes", noteID.String(), "share"), req, nil)
+}
+
+func (c *Client) UnshareNote(ctx context.Context, noteID, userID uuid.UUID) error {
+	return c.delete(ctx, route(v1Path, "notes", noteID.String(), "share", userID.String()), nil)
+}
+
+// ListNoteShares returns a page of the shares of a note the caller owns
+func (c *Client) ListNoteShares(ctx context.Context, noteID uuid.UUID, opts ListOptions) (*Page[NoteShare], error) {
+	return listPage[NoteShare](ctx, c, route(v1Path, "notes", noteID.String(), "shares"), opts)
+}
+
+// NoteShares iterates over the shares of a note the caller owns
+func (c *Client) NoteShares(noteID uuid.UUID, opts ListOptions) *Iterator[NoteShare] {
+	return newIterator[NoteShare](c, route(v1Path, "notes", noteID.String(), "shares"), opts)
+}
@@ -0,0 +1,78 @@
+package client
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+
+	"asset-management-api/pkg/quota"
+
+	"github.com/google/uuid"
+)
+
+type subscriptionRequest struct {
+	AssetType  string   `json:"asset_type"`
+	AssetID    string   `json:"asset_id"`
+	EventTypes []string `json:"event_types,omitempty"`
+}
+
+// Subscribe subscribes the caller to the changes to a folder or note, with
+// AssetFolder or AssetNote. A folder subscription also matches changes to its
+// notes; empty eventTypes match every event.
+func (c *Client) Subscribe(ctx context.Context, assetType string, assetID uuid.UUID, eventTypes []string) (*Subscription, error) {
+	var subscription Subscription
+	req := subscriptionRequest{AssetType: assetType, AssetID: assetID.String(), EventTypes: eventTypes}
+	if err := c.post(ctx, route(v1Path, "subscriptions"), req, &subscription); err != nil {
+		return nil, err
+	}
+	return &subscription, nil
+}
+
+func (c *Client) ListSubscriptions(ctx context.Context) ([]Subscription, error) {
+	var subscriptions []Subscription
+	if err := c.get(ctx, route(v1Path, "subscriptions"), nil, &subscriptions); err != nil {
+		return nil, err
+	}
+	return subscriptions, nil
+}
+
+func (c *Client) Unsubscribe(ctx context.Context, subscriptionID uuid.UUID) error {
+	return c.delete(ctx, route(v1Path, "subscriptions", subscriptionID.String()), nil)
+}
+
+// Notifications returns the caller's latest notifications, newest first, or
+// only the unread ones; a limit of zero takes the API's default
+func (c *Client) Notifications(ctx context.Context, unreadOnly bool, limit int) ([]Notification, error) {
+	query := limitQuery(limit)
+	if unreadOnly {
+		if query == nil {
+			query = url.Values{}
+		}
+		query.Set("unread", strconv.FormatBool(true))
+	}
+	var notifications []Notification
+	if err := c.get(ctx, route(v1Path, "notifications"), query, &notifications); err != nil {
+		return nil, err
+	}
+	return notifications, nil
+}
+
+func (c *Client) MarkNotificationRead(ctx context.Context, notificationID uuid.UUID) error {
+	return c.post(ctx, route(v1Path, "notifications", notificationID.String(), "read"), nil, nil)
+}
+
+// QuotaUsage is the caller's consumption of their API quotas
+type QuotaUsage struct {
+	Role  string        `json:"role"`
+	Usage []quota.Usage `json:"usage"`
+}
+
+// Quota returns the caller's quota usage; the API answers 503 when quotas
+// are not enabled
+func (c *Client) Quota(ctx context.Context) (*QuotaUsage, error) {
+	var usage QuotaUsage
+	if err := c.get(ctx, route(v1Path, "quota"), nil, &usage); err != nil {
+		return nil, err
+	}
+	return &usage, nil
+}
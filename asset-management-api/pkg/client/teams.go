@@ -0,0 +1,114 @@
+package client
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// TeamInput is a team to create. The creator manages the team along with
+// ManagerIDs.
+type TeamInput struct {
+	Name       string   `json:"name"`
+	ManagerIDs []string `json:"manager_ids,omitempty"`
+	MemberIDs  []string `json:"member_ids,omitempty"`
+}
+
+type teamUserRequest struct {
+	UserID string `json:"user_id"`
+}
+
+func (c *Client) CreateTeam(ctx context.Context, input TeamInput) (*Team, error) {
+	var team Team
+	if err := c.post(ctx, route(v2Path, "teams"), input, &team); err != nil {
+		return nil, err
+	}
+	return &team, nil
+}
+
+// GetTeam returns a team the caller manages or is a member of
+func (c *Client) GetTeam(ctx context.Context, teamID uuid.UUID) (*Team, error) {
+	var team Team
+	if err := c.get(ctx, route(v2Path, "teams", teamID.String()), nil, &team); err != nil {
+		return nil, err
+	}
+	return &team, nil
+}
+
+// ListTeams returns a page of the teams the caller manages or is a member of
+func (c *Client) ListTeams(ctx context.Context, opts ListOptions) (*Page[Team], error) {
+	return listPage[Team](ctx, c, route(v2Path, "teams"), opts)
+}
+
+// Teams iterates over the teams the caller manages or is a member of
+func (c *Client) Teams(opts ListOptions) *Iterator[Team] {
+	return newIterator[Team](c, route(v2Path, "teams"), opts)
+}
+
+// AddTeamMember adds a user to a team the caller manages and returns the user
+func (c *Client) AddTeamMember(ctx context.Context, teamID, userID uuid.UUID) (*User, error) {
+	return c.addTeamUser(ctx, teamID, "members", userID)
+}
+
+func (c *Client) RemoveTeamMember(ctx context.Context, teamID, userID uuid.UUID) error {
+	return c.delete(ctx, route(v2Path, "teams", teamID.String(), "members", userID.String()), nil)
+}
+
+// ListTeamMembers returns a page of the members of a team
+func (c *Client) ListTeamMembers(ctx context.Context, teamID uuid.UUID, opts ListOptions) (*Page[User], error) {
+	return listPage[User](ctx, c, route(v2Path, "teams", teamID.String(), "members"), opts)
+}
+
+// TeamMembers iterates over the members of a team
+func (c *Client) TeamMembers(teamID uuid.UUID, opts ListOptions) *Iterator[User] {
+	return newIterator[User](c, route(v2Path, "teams", teamID.String(), "members"), opts)
+}
+
+// AddTeamManager makes a user a manager of a team the caller manages and
+// returns the user
+func (c *Client) AddTeamManager(ctx context.Context, teamID, userID uuid.UUID) (*User, error) {
+	return c.addTeamUser(ctx, teamID, "managers", userID)
+}
+
+func (c *Client) RemoveTeamManager(ctx context.Context, teamID, userID uuid.UUID) error {
+	return c.delete(ctx, route(v2Path, "teams", teamID.String(), "managers", userID.String()), nil)
+}
+
+// ListTeamManagers returns a page of the managers of a team
+func (c *Client) ListTeamManagers(ctx context.Context, teamID uuid.UUID, opts ListOptions) (*Page[User], error) {
+	return listPage[User](ctx, c, route(v2Path, "teams", teamID.String(), "managers"), opts)
+}
+
+// TeamManagers iterates over the managers of a team
+func (c *Client) TeamManagers(teamID uuid.UUID, opts ListOptions) *Iterator[User] {
+	return newIterator[User](c, route(v2Path, "teams", teamID.String(), "managers"), opts)
+}
+
+func (c *Client) addTeamUser(ctx context.Context, teamID uuid.UUID, collection string, userID uuid.UUID) (*User, error) {
+	var user User
+	req := teamUserRequest{UserID: userID.String()}
+	if err := c.post(ctx, route(v2Path, "teams", teamID.String(), collection), req, &user); err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// TeamAssets returns the folders and notes of the members of a team the
+// caller manages
+func (c *Client) TeamAssets(ctx context.Context, teamID uuid.UUID) ([]AssetInfo, error) {
+	var assets []AssetInfo
+	if err := c.get(ctx, route(v1Path, "teams", teamID.String(), "assets"), nil, &assets); err != nil {
+		return nil, err
+	}
+	return assets, nil
+}
+
+// UserAssets returns the folders and notes of a user in a team the caller
+// manages
+func (c *Client) UserAssets(ctx context.Context, userID uuid.UUID) ([]AssetInfo, error) {
+	var assets []AssetInfo
+	if err := c.get(ctx, route(v1Path, "users", userID.String(), "assets"), nil, &assets); err != nil {
+		return nil, err
+	}
+	return assets, nil
+}
@@ -0,0 +1,159 @@
+package client
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Access levels of shares
+const (
+	AccessRead  = "read"
+	AccessWrite = "write"
+)
+
+// Asset types of subscriptions and manager asset views
+const (
+	AssetFolder = "folder"
+	AssetNote   = "note"
+)
+
+type User struct {
+	ID        uuid.UUID `json:"user_id"`
+	Username  string    `json:"username"`
+	Email     string    `json:"email"`
+	Role      string    `json:"role"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type Folder struct {
+	ID          uuid.UUID `json:"folder_id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	OwnerID     uuid.UUID `json:"owner_id"`
+	// Version grows with every update
+	Version   int64     `json:"version"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+	Owner     *User     `json:"owner,omitempty"`
+}
+
+type Note struct {
+	ID        uuid.UUID `json:"note_id"`
+	Title     string    `json:"title"`
+	Body      string    `json:"body"`
+	FolderID  uuid.UUID `json:"folder_id"`
+	OwnerID   uuid.UUID `json:"owner_id"`
+	Version   int64     `json:"version"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+	Owner     *User     `json:"owner,omitempty"`
+}
+
+type FolderShare struct {
+	FolderID         uuid.UUID `json:"folder_id"`
+	SharedWithUserID uuid.UUID `json:"shared_with_user_id"`
+	AccessLevel      string    `json:"access_level"`
+	SharedBy         uuid.UUID `json:"shared_by"`
+	CreatedAt        time.Time `json:"created_at"`
+	SharedWithUser   *User     `json:"shared_with_user,omitempty"`
+}
+
+type NoteShare struct {
+	NoteID           uuid.UUID `json:"note_id"`
+	SharedWithUserID uuid.UUID `json:"shared_with_user_id"`
+	AccessLevel      string    `json:"access_level"`
+	SharedBy         uuid.UUID `json:"shared_by"`
+	CreatedAt        time.Time `json:"created_at"`
+	SharedWithUser   *User     `json:"shared_with_user,omitempty"`
+}
+
+type Team struct {
+	ID        uuid.UUID `json:"team_id"`
+	Name      string    `json:"team_name"`
+	CreatedBy uuid.UUID `json:"created_by"`
+	Version   int64     `json:"version"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+	Managers  []User    `json:"managers"`
+	Members   []User    `json:"members"`
+}
+
+// AssetInfo is a folder or note in the asset views of managers
+type AssetInfo struct {
+	Type      string    `json:"type"`
+	ID        uuid.UUID `json:"id"`
+	Name      string    `json:"name"`
+	OwnerID   uuid.UUID `json:"owner_id"`
+	OwnerName string    `json:"owner_name"`
+	// AccessLevel is set on assets shared with the user
+	AccessLevel string    `json:"access_level,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// Webhook is an endpoint a team registered to receive events. An empty
+// EventTypes receives every event.
+type Webhook struct {
+	ID         uuid.UUID `json:"webhook_id"`
+	TeamID     uuid.UUID `json:"team_id"`
+	URL        string    `json:"url"`
+	EventTypes []string  `json:"event_types"`
+	Active     bool      `json:"active"`
+	CreatedBy  uuid.UUID `json:"created_by"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+	// Secret signs the deliveries; it is only returned on registration and
+	// by WebhookSecret
+	Secret string `json:"secret,omitempty"`
+}
+
+// Webhook delivery statuses
+const (
+	DeliveryPending   = "pending"
+	DeliverySucceeded = "succeeded"
+	DeliveryFailed    = "failed"
+)
+
+type WebhookDelivery struct {
+	ID             uuid.UUID       `json:"delivery_id"`
+	WebhookID      uuid.UUID       `json:"webhook_id"`
+	EventID        uuid.UUID       `json:"event_id"`
+	EventType      string          `json:"event_type"`
+	Payload        json.RawMessage `json:"payload"`
+	Status         string          `json:"status"`
+	Attempts       int             `json:"attempts"`
+	ResponseStatus int             `json:"response_status,omitempty"`
+	LastError      string          `json:"last_error,omitempty"`
+	NextAttemptAt  *time.Time      `json:"next_attempt_at,omitempty"`
+	CompletedAt    *time.Time      `json:"completed_at,omitempty"`
+	CreatedAt      time.Time       `json:"created_at"`
+	UpdatedAt      time.Time       `json:"updated_at"`
+}
+
+// Subscription is a user's interest in the changes to a folder or note. An
+// empty EventTypes matches every event.
+type Subscription struct {
+	ID         uuid.UUID `json:"subscription_id"`
+	UserID     uuid.UUID `json:"user_id"`
+	AssetType  string    `json:"asset_type"`
+	AssetID    uuid.UUID `json:"asset_id"`
+	EventTypes []string  `json:"event_types"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// Notification is an event a user was notified about through a subscription
+type Notification struct {
+	ID             uuid.UUID       `json:"notification_id"`
+	UserID         uuid.UUID       `json:"user_id"`
+	SubscriptionID *uuid.UUID      `json:"subscription_id,omitempty"`
+	EventID        uuid.UUID       `json:"event_id"`
+	EventType      string          `json:"event_type"`
+	AssetType      string          `json:"asset_type"`
+	AssetID        uuid.UUID       `json:"asset_id"`
+	ActionBy       uuid.UUID       `json:"action_by"`
+	Payload        json.RawMessage `json:"payload"`
+	ReadAt         *time.Time      `json:"read_at,omitempty"`
+	CreatedAt      time.Time       `json:"created_at"`
+}
@@ -0,0 +1,106 @@
+package client
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/google/uuid"
+)
+
+// WebhookInput registers a webhook of a team the caller manages. An empty
+// Secret has the API generate one; empty EventTypes receive every event.
+type WebhookInput struct {
+	TeamID     uuid.UUID `json:"team_id"`
+	URL        string    `json:"url"`
+	Secret     string    `json:"secret,omitempty"`
+	EventTypes []string  `json:"event_types,omitempty"`
+}
+
+// WebhookUpdate changes the fields it sets of a webhook. An empty, non-nil
+// EventTypes makes the webhook receive every event.
+type WebhookUpdate struct {
+	URL        *string   `json:"url,omitempty"`
+	EventTypes *[]string `json:"event_types,omitempty"`
+	Active     *bool     `json:"active,omitempty"`
+}
+
+type webhookSecret struct {
+	Secret string `json:"secret"`
+}
+
+// RegisterWebhook registers a webhook and returns it with its signing secret
+func (c *Client) RegisterWebhook(ctx context.Context, input WebhookInput) (*Webhook, error) {
+	var webhook Webhook
+	if err := c.post(ctx, route(v1Path, "webhooks"), input, &webhook); err != nil {
+		return nil, err
+	}
+	return &webhook, nil
+}
+
+// ListWebhooks returns the webhooks of the teams the caller manages
+func (c *Client) ListWebhooks(ctx context.Context) ([]Webhook, error) {
+	var webhooks []Webhook
+	if err := c.get(ctx, route(v1Path, "webhooks"), nil, &webhooks); err != nil {
+		return nil, err
+	}
+	return webhooks, nil
+}
+
+// ListTeamWebhooks returns the webhooks of a team the caller manages
+func (c *Client) ListTeamWebhooks(ctx context.Context, teamID uuid.UUID) ([]Webhook, error) {
+	var webhooks []Webhook
+	query := url.Values{"team_id": {teamID.String()}}
+	if err := c.get(ctx, route(v1Path, "webhooks"), query, &webhooks); err != nil {
+		return nil, err
+	}
+	return webhooks, nil
+}
+
+func (c *Client) GetWebhook(ctx context.Context, webhookID uuid.UUID) (*Webhook, error) {
+	var webhook Webhook
+	if err := c.get(ctx, route(v1Path, "webhooks", webhookID.String()), nil, &webhook); err != nil {
+		return nil, err
+	}
+	return &webhook, nil
+}
+
+func (c *Client) UpdateWebhook(ctx context.Context, webhookID uuid.UUID, update WebhookUpdate) (*Webhook, error) {
+	var webhook Webhook
+	if err := c.patch(ctx, route(v1Path, "webhooks", webhookID.String()), update, &webhook); err != nil {
+		return nil, err
+	}
+	return &webhook, nil
+}
+
+func (c *Client) DeleteWebhook(ctx context.Context, webhookID uuid.UUID) error {
+	return c.delete(ctx, route(v1Path, "webhooks", webhookID.String()), nil)
+}
+
+// WebhookDeliveries returns the latest deliveries to a webhook, newest
+// first; a limit of zero takes the API's default
+func (c *Client) WebhookDeliveries(ctx context.Context, webhookID uuid.UUID, limit int) ([]WebhookDelivery, error) {
+	var deliveries []WebhookDelivery
+	if err := c.get(ctx, route(v1Path, "webhooks", webhookID.String(), "deliveries"), limitQuery(limit), &deliveries); err != nil {
+		return nil, err
+	}
+	return deliveries, nil
+}
+
+// WebhookSecret returns the signing secret of a webhook
+func (c *Client) WebhookSecret(ctx context.Context, webhookID uuid.UUID) (string, error) {
+	var secret webhookSecret
+	if err := c.get(ctx, route(v1Path, "webhooks", webhookID.String(), "secret"), nil, &secret); err != nil {
+		return "", err
+	}
+	return secret.Secret, nil
+}
+
+// RotateWebhookSecret replaces the signing secret of a webhook and returns
+// the new one
+func (c *Client) RotateWebhookSecret(ctx context.Context, webhookID uuid.UUID) (string, error) {
+	var secret webhookSecret
+	if err := c.post(ctx, route(v1Path, "webhooks", webhookID.String(), "secret", "rotate"), nil, &secret); err != nil {
+		return "", err
+	}
+	return secret.Secret, nil
+}
@@ -0,0 +1,30 @@
+package errreport
+
+import (
+	"context"
+	"time"
+)
+
+// Event is a failure worth reporting: a panic or an error behind a 5xx
+// response, with the request it happened in
+type Event struct {
+	Err   error
+	Panic interface{} // The recovered value, for panics
+	Stack []byte      // The panicking goroutine's stack, for panics
+
+	RequestID string
+	TraceID   string
+	UserID    string
+	Method    string
+	Route     string // Gin route pattern, e.g. "/api/v1/folders/:folderId"
+	Path      string
+	Status    int
+}
+
+// Reporter sends failures to an error tracking service such as Sentry
+type Reporter interface {
+	Report(ctx context.Context, event *Event)
+	// Flush waits up to timeout for reported events to be sent, reporting
+	// whether they all were
+	Flush(timeout time.Duration) bool
+}
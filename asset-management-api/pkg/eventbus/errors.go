@@ -0,0 +1,48 @@
+package eventbus
+
+// PermanentError marks a handler error that retrying cannot fix, such as an
+// event that cannot be decoded. Consumers dead-letter such events straight
+// away instead of retrying them. Any other handler error is retryable.
+type PermanentError struct {
+	Err error
+}
+
+// Permanent marks err as permanent; it returns nil for a nil error
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &PermanentError{Err: err}
+}
+
+func (e *PermanentError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *PermanentError) Unwrap() error {
+	return e.Err
+}
+
+// IsPermanent reports whether retrying cannot fix err. An error joined from
+// several handlers, as FanOut returns, is permanent only when all of its
+// errors are, since retrying still helps the handlers that failed transiently.
+func IsPermanent(err error) bool {
+	switch e := err.(type) {
+	case nil:
+		return false
+	case *PermanentError:
+		return true
+	case interface{ Unwrap() []error }:
+		errs := e.Unwrap()
+		for _, err := range errs {
+			if !IsPermanent(err) {
+				return false
+			}
+		}
+		return len(errs) > 0
+	case interface{ Unwrap() error }:
+		return IsPermanent(e.Unwrap())
+	default:
+		return false
+	}
+}
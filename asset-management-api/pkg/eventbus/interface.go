@@ -1,12 +1,19 @@
 
 package eventbus
 
-import "context"
+import (
+	"context"
+	"errors"
+)
 
 // EventBus defines the interface for publishing and consuming events
 type EventBus interface {
 	// Publish sends an event to the specified topic
 	Publish(ctx context.Context, topic string, event interface{}) error
+
+	// PublishBatch sends several events to the specified topic at once, for
+	// bulk operations
+	PublishBatch(ctx context.Context, topic string, events []interface{}) error
 	
 	// Subscribe starts consuming events from the specified topic
 	Subscribe(ctx context.Context, topic string, handler EventHandler) error
@@ -15,6 +22,13 @@ type EventBus interface {
 	Close() error
 }
 
+// BroadcastSubscriber is implemented by buses that balance a topic's events
+// across instances but can also deliver every event to each instance, for
+// handlers that update per-instance state such as open client connections
+type BroadcastSubscriber interface {
+	SubscribeBroadcast(ctx context.Context, topic string, handler EventHandler) error
+}
+
 // EventHandler defines the function signature for event handlers
 type EventHandler func(ctx context.Context, event []byte) error
 
@@ -23,4 +37,73 @@ type Event struct {
 	EventType string      `json:"eventType"`
 	Timestamp string      `json:"timestamp"`
 	Data      interface{} `json:"data"`
-}
\ No newline at end of file
+}
+
+// DeadLetterSuffix is appended to a topic name to form its dead letter topic
+const DeadLetterSuffix = ".dlq"
+
+// ErrRedriveInProgress is returned when a re-drive of the same topic is already running
+var ErrRedriveInProgress = errors.New("dead letter re-drive already in progress")
+
+// DeadLetterTopic returns the dead letter topic for the given topic
+func DeadLetterTopic(topic string) string {
+	return topic + DeadLetterSuffix
+}
+
+// ErrNotSubscribed is returned when pausing or resuming a topic that is not consumed
+var ErrNotSubscribed = errors.New("not subscribed to topic")
+
+// ConsumptionController pauses and resumes the consumption of single topics,
+// e.g. to stop cache invalidation during an incident without redeploying
+type ConsumptionController interface {
+	// Pause stops handing the topic's events to its handler until Resume
+	Pause(topic string) error
+
+	// Resume continues consuming a paused topic where it stopped
+	Resume(topic string) error
+
+	// PausedTopics returns the topics currently paused
+	PausedTopics() []string
+}
+
+// DeadLetterRedriver replays dead-lettered events onto their original topic
+type DeadLetterRedriver interface {
+	// Redrive republishes up to limit messages from the topic's dead letter queue
+	Redrive(ctx context.Context, topic string, limit int) (*RedriveResult, error)
+}
+
+// RedriveResult summarizes a dead letter re-drive
+type RedriveResult struct {
+	Topic           string `json:"topic"`
+	DeadLetterTopic string `json:"deadLetterTopic"`
+	Redriven        int    `json:"redriven"`
+}
+
+// FanOut returns a handler that passes each event to every handler in turn,
+// for buses that allow a single subscription per topic. All handlers run
+// even if one fails; the failures are joined into the returned error.
+func FanOut(handlers ...EventHandler) EventHandler {
+	return func(ctx context.Context, event []byte) error {
+		var errs []error
+		for _, handler := range handlers {
+			if err := handler(ctx, event); err != nil {
+				errs = append(errs, err)
+			}
+		}
+		return errors.Join(errs...)
+	}
+}
+
+// Deduplicator records which events a consumer has handled, so redelivered
+// events (after retries or rebalances) are not applied twice
+type Deduplicator interface {
+	// Claim marks the event as being handled. It returns false when the event
+	// was already handled, or is being handled elsewhere.
+	Claim(ctx context.Context, scope, eventID string) (bool, error)
+
+	// Complete marks a claimed event as handled
+	Complete(ctx context.Context, scope, eventID string) error
+
+	// Release drops a claim whose handling failed so the event can be retried
+	Release(ctx context.Context, scope, eventID string) error
+}
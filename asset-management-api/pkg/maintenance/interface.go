@@ -0,0 +1,67 @@
+package maintenance
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// State describes a maintenance window switched on through the admin
+// endpoints
+type State struct {
+	Message    string    `json:"message"`
+	RetryAfter int       `json:"retry_after_seconds,omitempty"`
+	StartedBy  string    `json:"started_by,omitempty"`
+	StartedAt  time.Time `json:"started_at"`
+}
+
+// Store keeps the maintenance flag; it is on while a State is stored
+type Store interface {
+	// Get returns the stored state, or nil when maintenance mode is off
+	Get(ctx context.Context) (*State, error)
+	// Set switches maintenance mode on, replacing any state stored before
+	Set(ctx context.Context, state State) error
+	// Clear switches maintenance mode off, reporting whether it was on
+	Clear(ctx context.Context) (bool, error)
+}
+
+// MemoryStore is a Store local to one instance, for running without Redis
+type MemoryStore struct {
+	mu    sync.RWMutex
+	state *State
+}
+
+var _ Store = (*MemoryStore)(nil)
+
+// NewMemoryStore creates an in-memory store with maintenance mode off
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{}
+}
+
+func (s *MemoryStore) Get(ctx context.Context) (*State, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.state == nil {
+		return nil, nil
+	}
+	state := *s.state
+	return &state, nil
+}
+
+func (s *MemoryStore) Set(ctx context.Context, state State) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.state = &state
+	return nil
+}
+
+func (s *MemoryStore) Clear(ctx context.Context) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	on := s.state != nil
+	s.state = nil
+	return on, nil
+}
@@ -0,0 +1,76 @@
+package quota
+
+import (
+	"context"
+	"time"
+)
+
+// Period is the calendar period, in UTC, a quota is counted over
+type Period string
+
+const (
+	Daily   Period = "daily"
+	Monthly Period = "monthly"
+)
+
+// Periods lists every period, in the order usage is reported
+var Periods = []Period{Daily, Monthly}
+
+// Window returns the ID of the period containing now, e.g. "2024-05-31" for
+// a day, and when it ends
+func (p Period) Window(now time.Time) (string, time.Time) {
+	now = now.UTC()
+	year, month, day := now.Date()
+	if p == Monthly {
+		return now.Format("2006-01"), time.Date(year, month+1, 1, 0, 0, 0, 0, time.UTC)
+	}
+	return now.Format("2006-01-02"), time.Date(year, month, day+1, 0, 0, 0, 0, time.UTC)
+}
+
+// Quota caps the calls of a caller per day and per month; a cap of zero or
+// less leaves the period unlimited
+type Quota struct {
+	Daily   int
+	Monthly int
+}
+
+// Limit returns the cap of a period
+func (q Quota) Limit(period Period) int {
+	if period == Monthly {
+		return q.Monthly
+	}
+	return q.Daily
+}
+
+// Unlimited reports whether the quota lets every call through
+func (q Quota) Unlimited() bool {
+	return q.Daily <= 0 && q.Monthly <= 0
+}
+
+// Usage is a caller's consumption of a limited period
+type Usage struct {
+	Period    Period    `json:"period"`
+	Limit     int       `json:"limit"`
+	Used      int       `json:"used"`
+	Remaining int       `json:"remaining"`
+	ResetAt   time.Time `json:"reset_at"`
+}
+
+// Exhausted reports whether no calls are left in the period
+func (u Usage) Exhausted() bool {
+	return u.Remaining <= 0
+}
+
+// Result is the outcome of one quota check
+type Result struct {
+	Allowed bool
+	Usage   []Usage // Usage of the limited periods, after the call when allowed
+}
+
+// Tracker counts calls per key over the periods of a quota
+type Tracker interface {
+	// Consume records a call for key unless a period of the quota is used up
+	Consume(ctx context.Context, key string, quota Quota) (*Result, error)
+	// Usage returns the usage of key without recording a call
+	Usage(ctx context.Context, key string, quota Quota) ([]Usage, error)
+}
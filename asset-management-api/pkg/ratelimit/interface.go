@@ -0,0 +1,74 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Limit allows Requests requests per sliding Window
+type Limit struct {
+	Requests int
+	Window   time.Duration
+}
+
+// Unlimited reports whether the limit lets every request through
+func (l Limit) Unlimited() bool {
+	return l.Requests <= 0 || l.Window <= 0
+}
+
+// Result is the outcome of one rate limit check
+type Result struct {
+	Allowed    bool
+	Remaining  int           // Requests left in the current window
+	RetryAfter time.Duration // When Allowed is false, how long until a request would be
+	ResetAfter time.Duration // How long until the oldest request of the window leaves it
+}
+
+// Limiter counts requests per key and decides whether they are within a limit
+type Limiter interface {
+	// Allow records a request for key unless the key has used up its limit
+	Allow(ctx context.Context, key string, limit Limit) (*Result, error)
+}
+
+// ParseLimit parses a limit in the form "requests/window", e.g. "100/1m"
+func ParseLimit(spec string) (Limit, error) {
+	requests, window, ok := strings.Cut(strings.TrimSpace(spec), "/")
+	if !ok {
+		return Limit{}, fmt.Errorf("invalid rate limit %q: expected requests/window", spec)
+	}
+	n, err := strconv.Atoi(strings.TrimSpace(requests))
+	if err != nil {
+		return Limit{}, fmt.Errorf("invalid rate limit %q: %w", spec, err)
+	}
+	d, err := time.ParseDuration(strings.TrimSpace(window))
+	if err != nil {
+		return Limit{}, fmt.Errorf("invalid rate limit %q: %w", spec, err)
+	}
+	return Limit{Requests: n, Window: d}, nil
+}
+
+// ParseLimits parses a list of limits in the form "name=requests/window;...",
+// e.g. "manager=600/1m;user=300/1m". Entries are separated by semicolons so
+// names may contain commas and spaces, such as "POST /api/v1/folders".
+func ParseLimits(spec string) (map[string]Limit, error) {
+	limits := make(map[string]Limit)
+	for _, entry := range strings.Split(spec, ";") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		name, limitSpec, ok := strings.Cut(entry, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid rate limit entry %q: expected name=requests/window", entry)
+		}
+		limit, err := ParseLimit(limitSpec)
+		if err != nil {
+			return nil, err
+		}
+		limits[strings.TrimSpace(name)] = limit
+	}
+	return limits, nil
+}
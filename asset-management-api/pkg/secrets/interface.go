@@ -0,0 +1,98 @@
+package secrets
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// Schemes of the secret stores references can point to
+const (
+	SchemeVault             = "vault"
+	SchemeAWSSecretsManager = "awssm"
+)
+
+// Provider reads secrets from a secret store such as Vault
+type Provider interface {
+	// Get returns the fields of the named secret. A secret that is not a JSON
+	// object has a single field, named "".
+	Get(ctx context.Context, name string) (map[string]string, error)
+}
+
+// Reference points to a field of a secret in the form "scheme://name#field",
+// e.g. "vault://secret/data/asset-api#jwt_secret". Without a field the whole
+// secret is meant, which must then not be a JSON object.
+type Reference struct {
+	Scheme string
+	Name   string
+	Field  string
+}
+
+// ParseReference parses a secret reference, reporting whether value is one
+func ParseReference(value string) (Reference, bool) {
+	scheme, rest, ok := strings.Cut(value, "://")
+	if !ok || (scheme != SchemeVault && scheme != SchemeAWSSecretsManager) {
+		return Reference{}, false
+	}
+	name, field, _ := strings.Cut(rest, "#")
+	return Reference{Scheme: scheme, Name: name, Field: field}, true
+}
+
+func (ref Reference) String() string {
+	if ref.Field == "" {
+		return ref.Scheme + "://" + ref.Name
+	}
+	return ref.Scheme + "://" + ref.Name + "#" + ref.Field
+}
+
+// Resolver replaces secret references with the secrets they point to, using
+// the provider registered for each scheme
+type Resolver struct {
+	providers map[string]Provider
+}
+
+// NewResolver creates a resolver without providers
+func NewResolver() *Resolver {
+	return &Resolver{providers: make(map[string]Provider)}
+}
+
+// Register makes p resolve the references of scheme
+func (r *Resolver) Register(scheme string, p Provider) {
+	r.providers[scheme] = p
+}
+
+// Resolve replaces every value that is a secret reference with the secret,
+// leaving other values as they are. Each secret is read once, so fields of
+// the same secret, such as the username and password of dynamic database
+// credentials, belong together.
+func (r *Resolver) Resolve(ctx context.Context, values ...*string) error {
+	fetched := make(map[Reference]map[string]string)
+	for _, value := range values {
+		ref, ok := ParseReference(*value)
+		if !ok {
+			continue
+		}
+
+		secret := Reference{Scheme: ref.Scheme, Name: ref.Name}
+		fields, ok := fetched[secret]
+		if !ok {
+			provider, registered := r.providers[ref.Scheme]
+			if !registered {
+				return fmt.Errorf("cannot read secret %s: no %s provider is configured", secret, ref.Scheme)
+			}
+			var err error
+			fields, err = provider.Get(ctx, ref.Name)
+			if err != nil {
+				return fmt.Errorf("failed to read secret %s: %w", secret, err)
+			}
+			fetched[secret] = fields
+		}
+
+		field, ok := fields[ref.Field]
+		if !ok {
+			return fmt.Errorf("secret %s has no field %q", secret, ref.Field)
+		}
+		*value = field
+	}
+	return nil
+}
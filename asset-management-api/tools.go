@@ -0,0 +1,8 @@
+//go:build tools
+
+// Package tools pins the code generators the makefile runs
+package tools
+
+import (
+	_ "github.com/99designs/gqlgen"
+)
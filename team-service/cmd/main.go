@@ -1,16 +1,28 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"team-service/internal/cache/redis"
 	"team-service/internal/config"
 	"team-service/internal/database"
+	"team-service/internal/events/kafka"
 	"team-service/internal/handlers"
+	"team-service/internal/health"
 	"team-service/internal/middleware"
 	"team-service/internal/repositories"
 	"team-service/internal/services"
+	"team-service/pkg/cache"
+	"team-service/pkg/eventbus"
 	"team-service/pkg/logger"
 	
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
 )
 
 func main() {
@@ -29,23 +41,123 @@ func main() {
 	// Initialize repositories
 	teamRepo := repositories.NewTeamRepository(db)
 	
+	// Publish team activity for the asset management API, if enabled
+	var eventBus eventbus.EventBus
+	var producer *kafka.Producer
+	if cfg.KafkaEnabled {
+		producer = kafka.NewProducer(kafka.Config{
+			Brokers:      cfg.KafkaBrokers,
+			Producer:     "team-service",
+			WriteTimeout: cfg.KafkaWriteTimeout,
+		})
+		eventBus = producer
+		logger.Log.Infof("Publishing team activity to Kafka at %v", cfg.KafkaBrokers)
+	}
+	
+	// Cache team membership in Redis, if enabled; without Redis the
+	// service keeps working against the database alone
+	var cacheService cache.CacheService
+	var redisCache *redis.RedisCacheService
+	if cfg.RedisEnabled {
+		redisCache, err = redis.NewRedisCacheService(redis.Config{
+			Addr:              cfg.RedisAddr,
+			Password:          cfg.RedisPassword,
+			Database:          cfg.RedisDatabase,
+			TeamMembershipTTL: cfg.TeamMembershipCacheTTL,
+		})
+		if err != nil {
+			logger.Log.Warnf("Redis unavailable, team membership will not be cached: %v", err)
+		} else {
+			cacheService = redisCache
+			logger.Log.Infof("Caching team membership in Redis at %s", cfg.RedisAddr)
+		}
+	}
+	
 	// Initialize services
-	teamService := services.NewTeamService(teamRepo)
+	teamService := services.NewTeamService(teamRepo, eventBus, cacheService)
 	
 	// Initialize handlers
 	teamHandler := handlers.NewTeamHandler(teamService)
+	healthHandler := handlers.NewHealthHandler(newHealthChecker(cfg, db, producer, redisCache))
 	
 	// Setup routes
-	router := setupRoutes(teamHandler)
+	router := setupRoutes(teamHandler, healthHandler)
+	
+	server := &http.Server{
+		Addr:    ":" + cfg.Port,
+		Handler: router,
+	}
+	
+	// Stop on SIGINT or SIGTERM, letting in-flight requests finish
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
 	
 	// Start server
-	logger.Log.Infof("Starting team service on port %s", cfg.Port)
-	if err := router.Run(":" + cfg.Port); err != nil {
-		log.Fatal("Failed to start server:", err)
+	go func() {
+		logger.Log.Infof("Starting team service on port %s", cfg.Port)
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Fatal("Failed to start server:", err)
+		}
+	}()
+	
+	<-ctx.Done()
+	logger.Log.Info("Shutting down team service...")
+	
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.ShutdownTimeout)
+	defer cancel()
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		logger.Log.Errorf("Server forced to shutdown: %v", err)
 	}
+	
+	if eventBus != nil {
+		if err := eventBus.Close(); err != nil {
+			logger.Log.Errorf("Failed to close event bus: %v", err)
+		}
+	}
+	if cacheService != nil {
+		if err := cacheService.Close(); err != nil {
+			logger.Log.Errorf("Failed to close cache: %v", err)
+		}
+	}
+	if sqlDB, err := db.DB(); err == nil {
+		if err := sqlDB.Close(); err != nil {
+			logger.Log.Errorf("Failed to close database: %v", err)
+		}
+	}
+	logger.Log.Info("Team service stopped")
 }
 
-func setupRoutes(teamHandler *handlers.TeamHandler) *gin.Engine {
+// newHealthChecker checks the dependencies reported by /health. Kafka and
+// Redis are optional: without them team changes are saved but not
+// published, and access checks query the database.
+func newHealthChecker(cfg *config.Config, db *gorm.DB, producer *kafka.Producer, redisCache *redis.RedisCacheService) *health.Checker {
+	checks := []health.Check{{
+		Name:     "database",
+		Critical: true,
+		Probe: func(ctx context.Context) error {
+			sqlDB, err := db.DB()
+			if err != nil {
+				return err
+			}
+			return sqlDB.PingContext(ctx)
+		},
+	}}
+	if producer != nil {
+		checks = append(checks, health.Check{
+			Name:  "kafka",
+			Probe: producer.Ping,
+		})
+	}
+	if redisCache != nil {
+		checks = append(checks, health.Check{
+			Name:  "redis",
+			Probe: redisCache.Ping,
+		})
+	}
+	return health.NewChecker(cfg.HealthCheckTimeout, checks...)
+}
+
+func setupRoutes(teamHandler *handlers.TeamHandler, healthHandler *handlers.HealthHandler) *gin.Engine {
 	router := gin.Default()
 	
 	// Add middleware
@@ -53,12 +165,7 @@ func setupRoutes(teamHandler *handlers.TeamHandler) *gin.Engine {
 	router.Use(gin.Recovery())
 	
 	// Health check
-	router.GET("/health", func(c *gin.Context) {
-		c.JSON(200, gin.H{
-			"status": "healthy",
-			"service": "team-service",
-		})
-	})
+	router.GET("/health", healthHandler.Health)
 	
 	// Protected routes
 	api := router.Group("/api/v1")
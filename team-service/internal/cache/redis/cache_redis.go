@@ -0,0 +1,103 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"team-service/pkg/cache"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+// Config holds the settings of the Redis cache
+type Config struct {
+	Addr     string
+	Password string
+	Database int
+	// TeamMembershipTTL bounds how long a cached team membership is used
+	TeamMembershipTTL time.Duration
+}
+
+// RedisCacheService implements cache.CacheService on Redis
+type RedisCacheService struct {
+	client *redis.Client
+	keys   cache.CacheKeys
+	ttl    time.Duration
+}
+
+var _ cache.CacheService = (*RedisCacheService)(nil)
+
+// NewRedisCacheService connects to Redis and creates the cache
+func NewRedisCacheService(config Config) (*RedisCacheService, error) {
+	client := redis.NewClient(&redis.Options{
+		Addr:     config.Addr,
+		Password: config.Password,
+		DB:       config.Database,
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := client.Ping(ctx).Err(); err != nil {
+		client.Close()
+		return nil, fmt.Errorf("failed to connect to Redis at %s: %w", config.Addr, err)
+	}
+
+	ttl := config.TeamMembershipTTL
+	if ttl <= 0 {
+		ttl = cache.DefaultTeamMembershipTTL
+	}
+	return &RedisCacheService{client: client, ttl: ttl}, nil
+}
+
+// CacheTeamMembership stores the team's membership until the TTL expires or
+// it is invalidated
+func (s *RedisCacheService) CacheTeamMembership(ctx context.Context, teamID uuid.UUID, membership *cache.TeamMembership) error {
+	data, err := json.Marshal(membership)
+	if err != nil {
+		return fmt.Errorf("failed to marshal team membership: %w", err)
+	}
+	if err := s.client.Set(ctx, s.keys.TeamMembership(teamID), data, s.ttl).Err(); err != nil {
+		return fmt.Errorf("failed to cache team membership: %w", err)
+	}
+	return nil
+}
+
+// GetTeamMembership returns the cached membership, or nil on a cache miss
+func (s *RedisCacheService) GetTeamMembership(ctx context.Context, teamID uuid.UUID) (*cache.TeamMembership, error) {
+	data, err := s.client.Get(ctx, s.keys.TeamMembership(teamID)).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get team membership: %w", err)
+	}
+
+	var membership cache.TeamMembership
+	if err := json.Unmarshal(data, &membership); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal team membership: %w", err)
+	}
+	return &membership, nil
+}
+
+// InvalidateTeamMembership drops the cached membership, so the next check
+// reads it from the database
+func (s *RedisCacheService) InvalidateTeamMembership(ctx context.Context, teamID uuid.UUID) error {
+	if err := s.client.Del(ctx, s.keys.TeamMembership(teamID)).Err(); err != nil {
+		return fmt.Errorf("failed to invalidate team membership: %w", err)
+	}
+	return nil
+}
+
+// Ping checks that Redis answers, for health checks
+func (s *RedisCacheService) Ping(ctx context.Context) error {
+	return s.client.Ping(ctx).Err()
+}
+
+// Close closes the Redis connections
+func (s *RedisCacheService) Close() error {
+	return s.client.Close()
+}
@@ -0,0 +1,170 @@
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"team-service/internal/events/types"
+	"team-service/pkg/eventbus"
+	"team-service/pkg/logger"
+
+	"github.com/segmentio/kafka-go"
+)
+
+// Kafka message headers read by the asset management API's consumers
+const (
+	HeaderContentType   = "content-type"
+	HeaderSchemaVersion = "schema-version"
+	HeaderEventID       = "event-id"
+	HeaderEventType     = "event-type"
+)
+
+// Config holds the settings of the producer
+type Config struct {
+	Brokers []string
+	// Producer names the service in the envelopes it publishes
+	Producer string
+	// WriteTimeout bounds how long a publish waits for the brokers
+	WriteTimeout time.Duration
+}
+
+// Producer implements eventbus.EventBus on Kafka, publishing events as JSON
+// envelopes keyed by their partition key
+type Producer struct {
+	config  Config
+	writers map[string]*kafka.Writer
+	mu      sync.Mutex
+}
+
+var _ eventbus.EventBus = (*Producer)(nil)
+
+// NewProducer creates a Kafka producer; writers connect on first publish
+func NewProducer(config Config) *Producer {
+	return &Producer{
+		config:  config,
+		writers: make(map[string]*kafka.Writer),
+	}
+}
+
+// Publish sends an event to the specified Kafka topic
+func (p *Producer) Publish(ctx context.Context, topic string, event interface{}) error {
+	return p.PublishBatch(ctx, topic, []interface{}{event})
+}
+
+// PublishBatch sends several events to the specified Kafka topic in a single write
+func (p *Producer) PublishBatch(ctx context.Context, topic string, events []interface{}) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	messages := make([]kafka.Message, 0, len(events))
+	for _, event := range events {
+		envelope, err := types.NewEnvelope(p.config.Producer, event)
+		if err != nil {
+			return err
+		}
+		value, err := json.Marshal(envelope)
+		if err != nil {
+			return fmt.Errorf("failed to marshal event: %w", err)
+		}
+
+		messages = append(messages, kafka.Message{
+			Key:   partitionKey(event),
+			Value: value,
+			Time:  time.Now(),
+			Headers: []kafka.Header{
+				{Key: HeaderContentType, Value: []byte("application/json")},
+				{Key: HeaderSchemaVersion, Value: []byte(strconv.Itoa(envelope.SchemaVersion))},
+				{Key: HeaderEventID, Value: []byte(envelope.EventID.String())},
+				{Key: HeaderEventType, Value: []byte(envelope.EventType)},
+			},
+		})
+	}
+
+	if err := p.writer(topic).WriteMessages(ctx, messages...); err != nil {
+		var writeErrs kafka.WriteErrors
+		if errors.As(err, &writeErrs) {
+			return fmt.Errorf("failed to write %d of %d messages to topic %s: %w", writeErrs.Count(), len(messages), topic, err)
+		}
+		return fmt.Errorf("failed to write message to topic %s: %w", topic, err)
+	}
+
+	logger.Log.Debugf("Published %d event(s) to topic %s", len(messages), topic)
+	return nil
+}
+
+// writer returns or creates the writer for the specified topic
+func (p *Producer) writer(topic string) *kafka.Writer {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if writer, exists := p.writers[topic]; exists {
+		return writer
+	}
+
+	writer := &kafka.Writer{
+		Addr:         kafka.TCP(p.config.Brokers...),
+		Topic:        topic,
+		Balancer:     &kafka.Hash{}, // Same key, same partition; keyless messages round-robin
+		RequiredAcks: kafka.RequireAll,
+		// Events are published one request at a time, so don't hold them
+		// back waiting for a fuller batch
+		BatchTimeout: 10 * time.Millisecond,
+		WriteTimeout: p.config.WriteTimeout,
+		ErrorLogger:  kafka.LoggerFunc(logger.Log.Errorf),
+	}
+	p.writers[topic] = writer
+	return writer
+}
+
+// Ping dials the brokers until one answers, for health checks
+func (p *Producer) Ping(ctx context.Context) error {
+	var lastErr error
+	for _, broker := range p.config.Brokers {
+		conn, err := kafka.DialContext(ctx, "tcp", broker)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		return conn.Close()
+	}
+	if lastErr == nil {
+		lastErr = errors.New("no Kafka brokers configured")
+	}
+	return lastErr
+}
+
+// Close flushes and closes all writers
+func (p *Producer) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var errs []error
+	for topic, writer := range p.writers {
+		if err := writer.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("failed to close writer for topic %s: %w", topic, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// EventKeyProvider is implemented by events that choose their partition
+// key; events with the same key are written to the same partition, in order
+type EventKeyProvider interface {
+	GetPartitionKey() string
+}
+
+var _ EventKeyProvider = types.BaseTeamEvent{}
+
+// partitionKey returns the event's partition key (for ordering), if it has one
+func partitionKey(event interface{}) []byte {
+	if keyProvider, ok := event.(EventKeyProvider); ok {
+		return []byte(keyProvider.GetPartitionKey())
+	}
+	return nil
+}
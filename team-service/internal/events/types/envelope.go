@@ -0,0 +1,48 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SchemaVersion is the version of the envelope the asset management API's
+// consumers decode
+const SchemaVersion = 2
+
+// Envelope wraps every published event with metadata, in the format the
+// asset management API publishes and consumes
+type Envelope struct {
+	SchemaVersion int             `json:"schemaVersion"`
+	EventID       uuid.UUID       `json:"eventId"`
+	EventType     string          `json:"eventType"`
+	Producer      string          `json:"producer"`
+	Timestamp     time.Time       `json:"timestamp"`
+	Payload       json.RawMessage `json:"payload"`
+}
+
+// NewEnvelope wraps an event payload in an envelope. The event type is taken
+// from the payload's eventType field.
+func NewEnvelope(producer string, event interface{}) (*Envelope, error) {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal event payload: %w", err)
+	}
+
+	var base struct {
+		EventType string `json:"eventType"`
+	}
+	// Payloads that are not JSON objects simply have no event type
+	_ = json.Unmarshal(payload, &base)
+
+	return &Envelope{
+		SchemaVersion: SchemaVersion,
+		EventID:       uuid.New(),
+		EventType:     base.EventType,
+		Producer:      producer,
+		Timestamp:     time.Now().UTC(),
+		Payload:       payload,
+	}, nil
+}
@@ -0,0 +1,50 @@
+// internal/handlers/health_handler.go
+package handlers
+
+import (
+	"net/http"
+	"team-service/internal/health"
+
+	"github.com/gin-gonic/gin"
+)
+
+// HealthHandler reports whether the service and its dependencies are up.
+// The body is the bare report rather than the response envelope, for load
+// balancers and probes.
+type HealthHandler struct {
+	checker *health.Checker
+}
+
+func NewHealthHandler(checker *health.Checker) *HealthHandler {
+	return &HealthHandler{
+		checker: checker,
+	}
+}
+
+// GET /health
+// Answers 503 while a critical dependency is down; a degraded service, whose
+// optional dependencies are down, stays healthy. The service status is
+// "healthy" when everything is up, as before dependencies were checked, so
+// probes matching on it keep working; the dependencies report up or down.
+func (h *HealthHandler) Health(c *gin.Context) {
+	report := h.checker.Run(c.Request.Context())
+
+	status := http.StatusOK
+	if report.Status == health.StatusDown {
+		status = http.StatusServiceUnavailable
+	}
+	c.JSON(status, gin.H{
+		"service":      "team-service",
+		"status":       serviceStatus(report.Status),
+		"dependencies": report.Dependencies,
+		"checked_at":   report.CheckedAt,
+	})
+}
+
+// serviceStatus is the status the service reports for a health report status
+func serviceStatus(status string) string {
+	if status == health.StatusUp {
+		return "healthy"
+	}
+	return status
+}
@@ -1,10 +1,15 @@
 package services
 
 import (
+	"context"
 	"errors"
 	"time"
+	"team-service/internal/events/types"
 	"team-service/internal/models"
 	"team-service/internal/repositories"
+	"team-service/pkg/cache"
+	"team-service/pkg/eventbus"
+	"team-service/pkg/logger"
 	"github.com/google/uuid"
 )
 
@@ -21,11 +26,16 @@ type TeamService interface {
 }
 
 type teamService struct {
-	repo repositories.TeamRepository
+	repo     repositories.TeamRepository
+	eventBus eventbus.EventBus
+	cache    cache.CacheService
 }
 
-func NewTeamService(repo repositories.TeamRepository) TeamService {
-	return &teamService{repo: repo}
+// NewTeamService creates the team service. Team changes are published to the
+// team activity topic on eventBus, and team membership is cached in
+// cacheService; either may be nil to do without.
+func NewTeamService(repo repositories.TeamRepository, eventBus eventbus.EventBus, cacheService cache.CacheService) TeamService {
+	return &teamService{repo: repo, eventBus: eventBus, cache: cacheService}
 }
 
 func (s *teamService) CreateTeam(userID uuid.UUID, req *models.CreateTeamRequest) (*models.TeamResponse, error) {
@@ -62,6 +72,8 @@ func (s *teamService) CreateTeam(userID uuid.UUID, req *models.CreateTeamRequest
 	if err := s.repo.AddManager(creatorManager); err != nil {
 		return nil, err
 	}
+	managers := []uuid.UUID{userID}
+	var members []uuid.UUID
 	
 	// Add additional managers
 	for _, managerInfo := range req.Managers {
@@ -82,7 +94,9 @@ func (s *teamService) CreateTeam(userID uuid.UUID, req *models.CreateTeamRequest
 			AddedBy: userID,
 			AddedAt: time.Now(),
 		}
-		s.repo.AddManager(teamManager)
+		if err := s.repo.AddManager(teamManager); err == nil {
+			managers = append(managers, managerID)
+		}
 	}
 	
 	// Add members
@@ -104,9 +118,13 @@ func (s *teamService) CreateTeam(userID uuid.UUID, req *models.CreateTeamRequest
 			AddedBy: userID,
 			AddedAt: time.Now(),
 		}
-		s.repo.AddMember(teamMember)
+		if err := s.repo.AddMember(teamMember); err == nil {
+			members = append(members, memberID)
+		}
 	}
 	
+	s.publish(types.NewTeamCreatedEvent(team.ID, userID, team.Name, managers, members))
+	
 	// Return team response
 	return s.buildTeamResponse(team.ID)
 }
@@ -145,7 +163,7 @@ func (s *teamService) AddMember(teamID, userID, memberID uuid.UUID) error {
 	}
 	
 	// Verify member exists
-	_, err := s.repo.GetUserByID(memberID)
+	member, err := s.repo.GetUserByID(memberID)
 	if err != nil {
 		return errors.New("member not found")
 	}
@@ -163,7 +181,13 @@ func (s *teamService) AddMember(teamID, userID, memberID uuid.UUID) error {
 		AddedAt: time.Now(),
 	}
 	
-	return s.repo.AddMember(teamMember)
+	if err := s.repo.AddMember(teamMember); err != nil {
+		return err
+	}
+	
+	s.invalidateMembership(teamID)
+	s.publish(types.NewMemberAddedEvent(teamID, userID, memberID, member.Username))
+	return nil
 }
 
 func (s *teamService) RemoveMember(teamID, userID, memberID uuid.UUID) error {
@@ -178,7 +202,13 @@ func (s *teamService) RemoveMember(teamID, userID, memberID uuid.UUID) error {
 		return errors.New("user is not a member of this team")
 	}
 	
-	return s.repo.RemoveMember(teamID, memberID)
+	if err := s.repo.RemoveMember(teamID, memberID); err != nil {
+		return err
+	}
+	
+	s.invalidateMembership(teamID)
+	s.publish(types.NewMemberRemovedEvent(teamID, userID, memberID, s.userName(memberID)))
+	return nil
 }
 
 func (s *teamService) AddManager(teamID, userID, managerID uuid.UUID) error {
@@ -210,23 +240,29 @@ func (s *teamService) AddManager(teamID, userID, managerID uuid.UUID) error {
 		AddedAt: time.Now(),
 	}
 	
-	return s.repo.AddManager(teamManager)
+	if err := s.repo.AddManager(teamManager); err != nil {
+		return err
+	}
+	
+	s.invalidateMembership(teamID)
+	s.publish(types.NewManagerAddedEvent(teamID, userID, managerID, manager.Username))
+	return nil
 }
 
 func (s *teamService) RemoveManager(teamID, userID, managerID uuid.UUID) error {
-	// Get team info
-	team, err := s.repo.GetTeamByID(teamID)
+	// Get team membership
+	membership, err := s.teamMembership(teamID)
 	if err != nil {
 		return errors.New("team not found")
 	}
 	
 	// Check if user is the creator or a manager
-	if team.CreatedBy != userID && !s.isTeamManager(teamID, userID) {
+	if !membership.IsManager(userID) {
 		return errors.New("only team creator or managers can remove managers")
 	}
 	
 	// Prevent removing the team creator
-	if team.CreatedBy == managerID {
+	if membership.CreatedBy == managerID {
 		return errors.New("cannot remove team creator")
 	}
 	
@@ -236,46 +272,106 @@ func (s *teamService) RemoveManager(teamID, userID, managerID uuid.UUID) error {
 		return errors.New("user is not a manager of this team")
 	}
 	
-	return s.repo.RemoveManager(teamID, managerID)
+	if err := s.repo.RemoveManager(teamID, managerID); err != nil {
+		return err
+	}
+	
+	s.invalidateMembership(teamID)
+	s.publish(types.NewManagerRemovedEvent(teamID, userID, managerID, s.userName(managerID)))
+	return nil
 }
 
 // Helper functions
 func (s *teamService) hasTeamAccess(teamID, userID uuid.UUID) bool {
-	team, err := s.repo.GetTeamByID(teamID)
+	membership, err := s.teamMembership(teamID)
 	if err != nil {
 		return false
 	}
 	
-	// Creator has access
-	if team.CreatedBy == userID {
-		return true
-	}
-	
-	// Check if manager
-	isManager, _ := s.repo.IsManager(teamID, userID)
-	if isManager {
-		return true
-	}
-	
-	// Check if member
-	isMember, _ := s.repo.IsMember(teamID, userID)
-	return isMember
+	// Creator, managers and members have access
+	return membership.HasAccess(userID)
 }
 
 func (s *teamService) isTeamManager(teamID, userID uuid.UUID) bool {
-	team, err := s.repo.GetTeamByID(teamID)
+	membership, err := s.teamMembership(teamID)
 	if err != nil {
 		return false
 	}
 	
 	// Creator is always a manager
-	if team.CreatedBy == userID {
-		return true
+	return membership.IsManager(userID)
+}
+
+// teamMembership returns who belongs to the team, from the cache if it has
+// it and from the database otherwise. The cache is an optimization only: when
+// Redis fails, the database answers.
+func (s *teamService) teamMembership(teamID uuid.UUID) (*cache.TeamMembership, error) {
+	ctx := context.Background()
+	if s.cache != nil {
+		membership, err := s.cache.GetTeamMembership(ctx, teamID)
+		if err != nil {
+			logger.Log.Warnf("Failed to read team membership from cache: %v", err)
+		} else if membership != nil {
+			return membership, nil
+		}
+	}
+	
+	team, err := s.repo.GetTeamByID(teamID)
+	if err != nil {
+		return nil, err
+	}
+	
+	membership := &cache.TeamMembership{
+		CreatedBy: team.CreatedBy,
+		Managers:  make([]uuid.UUID, 0, len(team.Managers)),
+		Members:   make([]uuid.UUID, 0, len(team.Members)),
+	}
+	for _, manager := range team.Managers {
+		membership.Managers = append(membership.Managers, manager.UserID)
+	}
+	for _, member := range team.Members {
+		membership.Members = append(membership.Members, member.UserID)
+	}
+	
+	if s.cache != nil {
+		if err := s.cache.CacheTeamMembership(ctx, teamID, membership); err != nil {
+			logger.Log.Warnf("Failed to cache team membership: %v", err)
+		}
+	}
+	return membership, nil
+}
+
+// invalidateMembership drops the team's cached membership after it changed.
+// If that fails, the change shows once the cached entry expires.
+func (s *teamService) invalidateMembership(teamID uuid.UUID) {
+	if s.cache == nil {
+		return
+	}
+	
+	if err := s.cache.InvalidateTeamMembership(context.Background(), teamID); err != nil {
+		logger.Log.Errorf("Failed to invalidate team membership cache: %v", err)
+	}
+}
+
+// publish sends a team activity event. The change is already saved, so a
+// failure to publish is logged rather than failing the request.
+func (s *teamService) publish(event interface{}) {
+	if s.eventBus == nil {
+		return
 	}
 	
-	// Check if explicitly a manager
-	isManager, _ := s.repo.IsManager(teamID, userID)
-	return isManager
+	if err := s.eventBus.Publish(context.Background(), types.TeamActivityTopic, event); err != nil {
+		logger.Log.Errorf("Failed to publish team activity event: %v", err)
+	}
+}
+
+// userName returns the user's name for events, or "" if the user is gone
+func (s *teamService) userName(userID uuid.UUID) string {
+	user, err := s.repo.GetUserByID(userID)
+	if err != nil {
+		return ""
+	}
+	return user.Username
 }
 
 func (s *teamService) buildTeamResponse(teamID uuid.UUID) (*models.TeamResponse, error) {
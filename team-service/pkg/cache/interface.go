@@ -0,0 +1,60 @@
+package cache
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CacheService defines the interface for caching operations
+type CacheService interface {
+	// Team membership caching; GetTeamMembership returns nil on a cache miss
+	CacheTeamMembership(ctx context.Context, teamID uuid.UUID, membership *TeamMembership) error
+	GetTeamMembership(ctx context.Context, teamID uuid.UUID) (*TeamMembership, error)
+	InvalidateTeamMembership(ctx context.Context, teamID uuid.UUID) error
+
+	// Generic cache operations
+	Ping(ctx context.Context) error
+	Close() error
+}
+
+// TeamMembership is who belongs to a team, as checked on every team request
+type TeamMembership struct {
+	CreatedBy uuid.UUID   `json:"createdBy"`
+	Managers  []uuid.UUID `json:"managers"`
+	Members   []uuid.UUID `json:"members"`
+}
+
+// IsManager reports whether the user manages the team; the creator always does
+func (m *TeamMembership) IsManager(userID uuid.UUID) bool {
+	return m.CreatedBy == userID || contains(m.Managers, userID)
+}
+
+// HasAccess reports whether the user may see the team: its creator, managers
+// and members
+func (m *TeamMembership) HasAccess(userID uuid.UUID) bool {
+	return m.IsManager(userID) || contains(m.Members, userID)
+}
+
+func contains(ids []uuid.UUID, id uuid.UUID) bool {
+	for _, candidate := range ids {
+		if candidate == id {
+			return true
+		}
+	}
+	return false
+}
+
+// CacheKeys defines standard cache key formats. Keys are prefixed with the
+// service name, so the team service can share a Redis with other services.
+type CacheKeys struct{}
+
+func (CacheKeys) TeamMembership(teamID uuid.UUID) string {
+	return "team-service:team:" + teamID.String() + ":membership"
+}
+
+// Default cache TTL values
+const (
+	DefaultTeamMembershipTTL = 10 * time.Minute
+)
@@ -0,0 +1,18 @@
+package eventbus
+
+import "context"
+
+// EventBus defines the interface for publishing events. It is the producer
+// side of the asset management API's event bus, whose consumers read what
+// the team service publishes.
+type EventBus interface {
+	// Publish sends an event to the specified topic
+	Publish(ctx context.Context, topic string, event interface{}) error
+
+	// PublishBatch sends several events to the specified topic at once, for
+	// bulk operations
+	PublishBatch(ctx context.Context, topic string, events []interface{}) error
+
+	// Close closes the event bus connections
+	Close() error
+}